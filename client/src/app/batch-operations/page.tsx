@@ -18,6 +18,30 @@ type ImportResult = {
   message?: string;
 };
 
+type StudentImportRow = {
+  line: number;
+  roll_no: string;
+  status: 'created' | 'skipped' | 'failed';
+  student_id?: number;
+  reason?: string;
+};
+
+type StudentImportReport = {
+  created: number;
+  skipped: number;
+  failed: number;
+  rows: StudentImportRow[];
+};
+
+const toImportResult = (report: StudentImportReport): ImportResult => ({
+  success: report.created,
+  failed: report.failed,
+  errors: report.rows
+    .filter((row) => row.status === 'failed')
+    .map((row) => `Line ${row.line}${row.roll_no ? ` (${row.roll_no})` : ''}: ${row.reason}`),
+  message: report.skipped > 0 ? `${report.skipped} already imported and skipped` : undefined,
+});
+
 type Course = {
   id: number;
   name: string;
@@ -59,8 +83,8 @@ export default function BatchOperationsPage() {
   const handleStudentTemplateDownload = () => {
     downloadCsvTemplate(
       'students_import_template.csv',
-      'roll_no,first_name,last_name,email',
-      'CS001,John,Doe,john.doe@example.edu'
+      'roll_no,name,email,enrollment_year',
+      `CS001,John Doe,john.doe@example.edu,${new Date().getFullYear()}`
     );
     setSuccess('Student CSV template downloaded');
   };
@@ -124,8 +148,9 @@ export default function BatchOperationsPage() {
         throw new Error(result.error || 'Import failed');
       }
 
-      setImportResult(result.data);
-      setSuccess(`Successfully imported ${result.data.success} students`);
+      const report = result.data as StudentImportReport;
+      setImportResult(toImportResult(report));
+      setSuccess(`Successfully imported ${report.created} students`);
     } catch (error) {
       setError(error instanceof Error ? error.message : 'Failed to import students');
     } finally {
@@ -335,6 +360,9 @@ export default function BatchOperationsPage() {
                 <span className="text-muted-foreground">Failed:</span>
                 <span className="font-semibold text-red-600">{importResult.failed}</span>
               </div>
+              {importResult.message && (
+                <p className="text-sm text-muted-foreground">{importResult.message}</p>
+              )}
               {importResult.errors && importResult.errors.length > 0 && (
                 <div className="mt-4">
                   <p className="text-sm font-medium mb-2">Errors:</p>
@@ -51,7 +51,7 @@ interface Exam {
   course_name?: string;
   room_number?: string;
   instructions?: string;
-  allowed_materials?: string;
+  allowed_materials?: string[];
   question_paper_sets?: number;
 }
 
@@ -406,7 +406,10 @@ export default function ExamsPage() {
         total_marks: totalMarks,
         passing_marks: passingMarks,
         instructions: createForm.instructions.trim(),
-        allowed_materials: createForm.allowedMaterials.trim(),
+        allowed_materials: createForm.allowedMaterials
+          .split(/[,\n]/)
+          .map((material) => material.trim())
+          .filter(Boolean),
         question_paper_sets: questionPaperSets,
       });
 
@@ -1292,7 +1295,7 @@ export default function ExamsPage() {
                     value={createForm.allowedMaterials}
                     onChange={(event) => setCreateForm((prev) => ({ ...prev, allowedMaterials: event.target.value }))}
                     className="flex min-h-[90px] w-full rounded-md border border-input bg-background px-3 py-2 text-sm"
-                    placeholder="Calculator, notes, etc."
+                    placeholder="One per line or comma-separated, e.g. Calculator, Notes"
                   />
                 </div>
               </div>
@@ -1565,10 +1568,10 @@ export default function ExamsPage() {
                 </div>
               )}
 
-              {selectedExam.allowed_materials && (
+              {selectedExam.allowed_materials && selectedExam.allowed_materials.length > 0 && (
                 <div>
                   <p className="text-xs uppercase text-muted-foreground">Allowed Materials</p>
-                  <p className="text-sm mt-1">{selectedExam.allowed_materials}</p>
+                  <p className="text-sm mt-1">{selectedExam.allowed_materials.join(", ")}</p>
                 </div>
               )}
 
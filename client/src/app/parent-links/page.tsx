@@ -66,10 +66,10 @@ export default function ParentLinksPage() {
     try {
       setLoading(true);
       setError(null);
-      const data = await api.get<{ relationships: ParentRelationship[] }>(
-        endpoints.parentRelationships.list
-      );
-      setRelationships(data?.relationships ?? []);
+      const data = await api.get<{
+        relationships: { items: ParentRelationship[]; total: number; limit: number; offset: number };
+      }>(`${endpoints.parentRelationships.list}?limit=200`);
+      setRelationships(data?.relationships?.items ?? []);
     } catch (err) {
       logger.error("Failed to load parent links", err as Error);
       setError("Failed to load parent links.");
@@ -20,6 +20,7 @@ type Question = {
   text: string;
   type: QuizType;
   points: number;
+  scoring_mode?: "all_or_nothing" | "partial";
   options?: AnswerOption[];
 };
 
@@ -62,7 +63,7 @@ export default function QuizAttemptPage({ params }: PageProps) {
   const [error, setError] = useState<string | null>(null);
 
   // Local answers state keyed by questionId
-  const [answers, setAnswers] = useState<Record<number, { optionId?: number; text?: string }>>({});
+  const [answers, setAnswers] = useState<Record<number, { optionIds?: number[]; text?: string }>>({});
 
   const questions = useMemo(() => attempt?.quiz?.questions ?? [], [attempt]);
 
@@ -74,12 +75,12 @@ export default function QuizAttemptPage({ params }: PageProps) {
         const data = await api.get<Attempt>(endpoints.quizAttempts.get(attemptId));
         setAttempt(data);
         // Seed existing answers if any
-        const prefilled: Record<number, { optionId?: number; text?: string }> = {};
+        const prefilled: Record<number, { optionIds?: number[]; text?: string }> = {};
         (data.answers || []).forEach((a) => {
           const qid = a.question_id;
           if (!qid) return;
           if (a.selected_option_id && a.selected_option_id.length > 0) {
-            prefilled[qid] = { optionId: a.selected_option_id[0] };
+            prefilled[qid] = { optionIds: a.selected_option_id };
           } else if (a.answer_text) {
             prefilled[qid] = { text: a.answer_text };
           }
@@ -99,7 +100,7 @@ export default function QuizAttemptPage({ params }: PageProps) {
       const a = answers[q.id] || {};
       const base: StudentAnswer = { question_id: q.id };
       if (isChoiceQuestion(q.type)) {
-        return { ...base, selected_option_id: a.optionId ? [a.optionId] : [] };
+        return { ...base, selected_option_id: a.optionIds ?? [] };
       }
       return { ...base, answer_text: a.text || "" };
     });
@@ -167,10 +168,33 @@ export default function QuizAttemptPage({ params }: PageProps) {
             <CardDescription>{q.points} points • {q.type}</CardDescription>
           </CardHeader>
           <CardContent className="space-y-3">
-            {isChoiceQuestion(q.type) ? (
+            {isChoiceQuestion(q.type) && isMultiSelectQuestion(q) ? (
+              <div className="space-y-2">
+                {(q.options || []).map((opt) => (
+                  <div key={opt.id} className="flex items-center space-x-2">
+                    <input
+                      type="checkbox"
+                      id={`q-${q.id}-opt-${opt.id}`}
+                      className="h-4 w-4"
+                      checked={answers[q.id]?.optionIds?.includes(opt.id) ?? false}
+                      onChange={() =>
+                        setAnswers((prev) => {
+                          const current = prev[q.id]?.optionIds ?? [];
+                          const optionIds = current.includes(opt.id)
+                            ? current.filter((id) => id !== opt.id)
+                            : [...current, opt.id];
+                          return { ...prev, [q.id]: { ...prev[q.id], optionIds } };
+                        })
+                      }
+                    />
+                    <label htmlFor={`q-${q.id}-opt-${opt.id}`} className="text-sm">{opt.text}</label>
+                  </div>
+                ))}
+              </div>
+            ) : isChoiceQuestion(q.type) ? (
               <RadioGroup
-                value={answers[q.id]?.optionId?.toString()}
-                onValueChange={(val) => setAnswers((prev) => ({ ...prev, [q.id]: { ...prev[q.id], optionId: Number(val) } }))}
+                value={answers[q.id]?.optionIds?.[0]?.toString()}
+                onValueChange={(val) => setAnswers((prev) => ({ ...prev, [q.id]: { ...prev[q.id], optionIds: [Number(val)] } }))}
                 className="space-y-2"
               >
                 {(q.options || []).map((opt) => (
@@ -214,6 +238,11 @@ function isChoiceQuestion(type: QuizType): boolean {
   return type === "MultipleChoice" || type === "TrueFalse" || type === "multiple_choice" || type === "true_false";
 }
 
+// Questions with partial scoring or several correct options let students pick more than one option
+function isMultiSelectQuestion(q: Question): boolean {
+  return q.scoring_mode === "partial" || (q.options || []).filter((opt) => opt.is_correct).length > 1;
+}
+
 function isCompletedAttempt(status: string): boolean {
   return status === "Completed" || status === "Graded" || status === "submitted" || status === "graded";
 }
@@ -56,6 +56,8 @@ type CourseOption = {
 
 type QuestionType = "multiple_choice" | "true_false" | "short_answer";
 
+type ScoringMode = "all_or_nothing" | "partial";
+
 type QuestionOption = {
   id?: number;
   text: string;
@@ -69,6 +71,7 @@ type DisplayQuestion = {
   options?: QuestionOption[];
   correctAnswer?: string;
   points: number;
+  scoringMode: ScoringMode;
 };
 
 type ApiQuestion = {
@@ -88,6 +91,7 @@ type ApiQuestion = {
   correctAnswer?: string;
   points?: number;
   marks?: number;
+  scoring_mode?: string;
 };
 
 type StartAttemptResponse = {
@@ -147,6 +151,7 @@ const normalizeQuestion = (item: ApiQuestion): DisplayQuestion => {
     options,
     correctAnswer,
     points: item.points ?? item.marks ?? 1,
+    scoringMode: item.scoring_mode === "partial" ? "partial" : "all_or_nothing",
   };
 };
 
@@ -187,6 +192,7 @@ export default function QuizzesPage() {
     ],
     correctAnswer: "",
     points: 1,
+    scoringMode: "all_or_nothing" as ScoringMode,
   });
 
   const [newQuiz, setNewQuiz] = useState({
@@ -402,6 +408,7 @@ export default function QuizzesPage() {
       ],
       correctAnswer: "",
       points: 1,
+      scoringMode: "all_or_nothing",
     });
     setEditingQuestion(null);
   };
@@ -427,6 +434,7 @@ export default function QuizzesPage() {
         options: options.slice(0, 4),
         correctAnswer: "",
         points: question.points,
+        scoringMode: question.scoringMode,
       });
     } else {
       setNewQuestion({
@@ -440,6 +448,7 @@ export default function QuizzesPage() {
         ],
         correctAnswer: question.correctAnswer || "",
         points: question.points,
+        scoringMode: question.scoringMode,
       });
     }
     setShowQuestionDialog(true);
@@ -471,10 +480,12 @@ export default function QuizzesPage() {
         options?: Array<{ text: string; is_correct: boolean }>;
         correct_answer?: string;
         points: number;
+        scoring_mode: ScoringMode;
       } = {
         text: newQuestion.text.trim(),
         type: newQuestion.type,
         points: Number(newQuestion.points) || 1,
+        scoring_mode: newQuestion.type === "multiple_choice" ? newQuestion.scoringMode : "all_or_nothing",
       };
 
       if (newQuestion.type === "multiple_choice") {
@@ -1007,14 +1018,13 @@ export default function QuizzesPage() {
                     {newQuestion.options?.map((option, index) => (
                       <div key={index} className="flex items-center gap-2">
                         <input
-                          type="radio"
-                          name="correctOption"
+                          type="checkbox"
+                          aria-label={`Option ${String.fromCharCode(65 + index)} is correct`}
                           checked={option.isCorrect}
                           onChange={() => {
-                            const newOptions = newQuestion.options?.map((opt, i) => ({
-                              ...opt,
-                              isCorrect: i === index,
-                            }));
+                            const newOptions = newQuestion.options?.map((opt, i) =>
+                              i === index ? { ...opt, isCorrect: !opt.isCorrect } : opt
+                            );
                             setNewQuestion({ ...newQuestion, options: newOptions });
                           }}
                           className="h-4 w-4"
@@ -1033,6 +1043,18 @@ export default function QuizzesPage() {
                       </div>
                     ))}
                   </div>
+                  <label htmlFor="quiz-question-scoring" className="text-sm font-medium">Scoring</label>
+                  <select
+                    id="quiz-question-scoring"
+                    className="flex h-10 w-full rounded-md border border-input bg-background px-3 py-2 text-sm"
+                    value={newQuestion.scoringMode}
+                    onChange={(e) =>
+                      setNewQuestion({ ...newQuestion, scoringMode: e.target.value as ScoringMode })
+                    }
+                  >
+                    <option value="all_or_nothing">All or nothing</option>
+                    <option value="partial">Partial credit per correct option</option>
+                  </select>
                 </div>
               )}
 
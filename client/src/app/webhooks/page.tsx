@@ -47,6 +47,8 @@ const EVENT_TYPES = [
   { value: "grade.submitted", label: "Grade Submitted" },
   { value: "assignment.created", label: "Assignment Created" },
   { value: "attendance.marked", label: "Attendance Marked" },
+  { value: "result.published", label: "Exam Results Published" },
+  { value: "exam.enrollment.created", label: "Exam Enrollment Created" },
 ];
 
 const normalizeWebhook = (webhook: WebhookApi): Webhook => ({
@@ -2,19 +2,28 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"net/http"
+	"os"
 	"time"
 
 	"eduhub/server/api/handler"
+	"eduhub/server/db/migrations"
 	"eduhub/server/internal/config"
+	"eduhub/server/internal/helpers"
+	"eduhub/server/internal/metrics"
 	"eduhub/server/internal/middleware"
+	"eduhub/server/internal/migrate"
 	"eduhub/server/internal/repository"
 	"eduhub/server/internal/services"
 	"eduhub/server/internal/services/audit"
+	"eduhub/server/internal/services/quiz"
+	"eduhub/server/internal/services/scheduler"
+	"eduhub/server/logger"
 
 	"github.com/labstack/echo/v4"
 	echomid "github.com/labstack/echo/v4/middleware"
+	"github.com/rs/zerolog"
 )
 
 func isWebSocketNotificationsPath(c echo.Context) bool {
@@ -28,6 +37,10 @@ type App struct {
 	services   *services.Services
 	handlers   *handler.Handlers
 	middleware *middleware.Middleware
+	inFlight   *middleware.InFlightTracker
+	metrics    *metrics.Registry
+	scheduler  scheduler.SchedulerService
+	logger     zerolog.Logger
 }
 
 func New() (*App, error) {
@@ -38,12 +51,100 @@ func New() (*App, error) {
 	if cfg.DB == nil || cfg.DB.Pool == nil {
 		return nil, fmt.Errorf("database connection pool is nil")
 	}
+	loggers, err := logger.NewSubsystems(cfg.AppConfig.LogLevel, cfg.AppConfig.SubsystemLogLevels)
+	if err != nil {
+		return nil, err
+	}
+	loggers.Apply()
+
+	if err := prepareSchema(context.Background(), cfg.DB, cfg.DBConfig, loggers.For(logger.SubsystemApp)); err != nil {
+		return nil, err
+	}
+
 	// Initialize auth service
-	services := services.NewServices(cfg)
+	services := services.NewServices(cfg, loggers)
 	handlers := handler.NewHandlers(services)
+	handlers.Parent.SetVerificationURL(cfg.AppConfig.FrontendURL + "/parent/verify-link")
 	// repos := repository.NewRepository(cfg.DB)
 	mid := middleware.NewMiddleware(services)
 
+	var registry *metrics.Registry
+	if cfg.AppConfig.MetricsEnabled {
+		registry = metrics.NewRegistry(poolStats(cfg.DB))
+		handlers.Metrics = handler.NewMetricsHandler(registry, cfg.AppConfig.MetricsToken)
+	}
+
+	var sched scheduler.SchedulerService
+	if cfg.AlertConfig != nil && cfg.AlertConfig.Enabled {
+		sched = scheduler.NewSchedulerService()
+		alertService := services.ParentAlertService
+		err := sched.ScheduleAttendanceAlerts(cfg.AlertConfig.Schedule, func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+			defer cancel()
+			_, err := alertService.RunAllColleges(ctx)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid ATTENDANCE_ALERT_SCHEDULE: %w", err)
+		}
+	}
+	if cfg.DashboardSnapshotConfig != nil && cfg.DashboardSnapshotConfig.Enabled {
+		if sched == nil {
+			sched = scheduler.NewSchedulerService()
+		}
+		analyticsService := services.AnalyticsService
+		err := sched.ScheduleDailyReports(cfg.DashboardSnapshotConfig.Schedule, func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+			defer cancel()
+			_, err := analyticsService.CaptureAllDashboardSnapshots(ctx)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid DASHBOARD_SNAPSHOT_SCHEDULE: %w", err)
+		}
+	}
+	if cfg.AssignmentReminderConfig != nil && cfg.AssignmentReminderConfig.Enabled {
+		if sched == nil {
+			sched = scheduler.NewSchedulerService()
+		}
+		assignmentService := services.AssignmentService
+		window := cfg.AssignmentReminderConfig.Window
+		_, err := sched.AddJob(cfg.AssignmentReminderConfig.Schedule, func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+			defer cancel()
+			_, err := assignmentService.NotifyDueSoon(ctx, window)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid ASSIGNMENT_REMINDER_SCHEDULE: %w", err)
+		}
+	}
+
+	if cfg.QuizAttemptCleanupConfig != nil && cfg.QuizAttemptCleanupConfig.Enabled {
+		if sched == nil {
+			sched = scheduler.NewSchedulerService()
+		}
+		attemptService := services.QuizAttemptService
+		policy := quiz.AbandonedAttemptPolicy{
+			Grace:  cfg.QuizAttemptCleanupConfig.Grace,
+			Action: quiz.AbandonedAttemptAction(cfg.QuizAttemptCleanupConfig.Action),
+		}
+		_, err := sched.AddJob(cfg.QuizAttemptCleanupConfig.Schedule, func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+			defer cancel()
+			_, err := attemptService.CloseAbandonedAttempts(ctx, policy)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid QUIZ_ATTEMPT_CLEANUP_SCHEDULE: %w", err)
+		}
+	}
+
+	logger := zerolog.New(zerolog.ConsoleWriter{
+		Out:        os.Stderr,
+		TimeFormat: time.RFC3339,
+	}).With().Timestamp().Str("component", "app").Logger()
+
 	return &App{
 		e:          echo.New(),
 		db:         cfg.DB,
@@ -51,18 +152,128 @@ func New() (*App, error) {
 		services:   services,
 		handlers:   handlers,
 		middleware: mid,
+		inFlight:   middleware.NewInFlightTracker(),
+		metrics:    registry,
+		scheduler:  sched,
+		logger:     logger,
 	}, nil
 }
 
+// poolStats adapts the pgx pool statistics to the metrics registry
+func poolStats(db *repository.DB) metrics.PoolStatsFunc {
+	return func() (metrics.PoolStats, bool) {
+		stat, ok := db.Stat()
+		if !ok {
+			return metrics.PoolStats{}, false
+		}
+		return metrics.PoolStats{
+			AcquiredConns: stat.AcquiredConns(),
+			IdleConns:     stat.IdleConns(),
+			TotalConns:    stat.TotalConns(),
+			MaxConns:      stat.MaxConns(),
+		}, true
+	}
+}
+
+// prepareSchema applies pending migrations when DB_AUTO_MIGRATE is set and
+// then, unless DB_SCHEMA_CHECK is off, refuses to start against a database
+// whose schema version differs from the one embedded in this binary
+func prepareSchema(ctx context.Context, db *repository.DB, dbCfg *config.DBConfig, log zerolog.Logger) error {
+	if !dbCfg.AutoMigrate && !dbCfg.SchemaCheck {
+		return nil
+	}
+
+	migrator, err := migrate.New(migrations.FS)
+	if err != nil {
+		return err
+	}
+	conn, err := migrate.Acquire(ctx, db)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if dbCfg.AutoMigrate {
+		applied, err := migrator.Up(ctx, conn)
+		if err != nil {
+			return fmt.Errorf("failed to apply database migrations: %w", err)
+		}
+		log.Info().Int("applied", applied).Uint("version", migrator.Latest()).Msg("database migrations applied")
+	}
+	if dbCfg.SchemaCheck {
+		if err := migrator.Check(ctx, conn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ShutdownTimeout returns the configured drain timeout for graceful shutdown
+func (a *App) ShutdownTimeout() time.Duration {
+	if a.config.AppConfig == nil || a.config.AppConfig.ShutdownTimeout <= 0 {
+		return 30 * time.Second
+	}
+	return a.config.AppConfig.ShutdownTimeout
+}
+
+// Shutdown stops accepting new connections, waits for in-flight requests to
+// finish until ctx expires, and then releases the database pool and Redis client.
 func (a *App) Shutdown(ctx context.Context) error {
+	start := time.Now()
+	a.logger.Info().Int64("in_flight", a.inFlight.Count()).Msg("draining in-flight requests")
+
+	if a.scheduler != nil {
+		// Stop waits for running jobs; don't let a long job hold up shutdown
+		stopped := make(chan struct{})
+		go func() {
+			_ = a.scheduler.Stop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			a.logger.Warn().Msg("scheduled jobs still running at shutdown")
+		}
+	}
+
+	// WebSocket connections are hijacked and not tracked by http.Server.Shutdown
 	a.services.WebSocketService.Stop()
-	return a.e.Shutdown(ctx)
+
+	shutdownErr := a.e.Shutdown(ctx)
+	if shutdownErr != nil {
+		abandoned := a.inFlight.Count()
+		a.logger.Warn().
+			Err(shutdownErr).
+			Int64("abandoned_requests", abandoned).
+			Dur("drain_duration", time.Since(start)).
+			Msg("drain timeout exceeded, forcing remaining connections closed")
+		if err := a.e.Close(); err != nil {
+			a.logger.Error().Err(err).Msg("failed to force-close server")
+		}
+	} else {
+		a.logger.Info().Dur("drain_duration", time.Since(start)).Msg("all in-flight requests drained")
+	}
+
+	if a.services.RedisCache != nil {
+		if err := a.services.RedisCache.Close(); err != nil {
+			a.logger.Error().Err(err).Msg("failed to close Redis client")
+			shutdownErr = errors.Join(shutdownErr, err)
+		}
+	}
+
+	if a.db != nil {
+		a.db.Close()
+	}
+
+	return shutdownErr
 }
 
 func (a *App) Start() error {
 	a.e.HideBanner = true
 	a.e.HidePort = false
 
+	// Assign the request ID first so every later log line and error body carries it
+	a.e.Use(middleware.RequestID(a.logger))
 	a.e.Use(echomid.RequestLoggerWithConfig(echomid.RequestLoggerConfig{
 		LogStatus:  true,
 		LogMethod:  true,
@@ -71,31 +282,41 @@ func (a *App) Start() error {
 		LogValuesFunc: func(c echo.Context, values echomid.RequestLoggerValues) error {
 			_, err := fmt.Fprintf(
 				a.e.Logger.Output(),
-				"%s %d %s %s %s\n",
+				"%s %d %s %s %s request_id=%s\n",
 				values.StartTime.Format(time.RFC3339Nano),
 				values.Status,
 				values.Method,
 				values.URI,
 				values.Latency,
+				helpers.GetRequestID(c),
 			)
 			return err
 		},
 	}))
 
+	a.e.Use(a.inFlight.Middleware())
+	if a.metrics != nil {
+		a.e.Use(middleware.Metrics(a.metrics))
+	}
 	a.e.Use(echomid.Recover())
 	a.e.Use(middleware.ErrorHandlerMiddleware())
 	a.e.Use(middleware.NewErrorSanitizationMiddleware().Middleware)
 	a.e.Use(middleware.RecoverMiddleware())
 	a.e.Use(middleware.SecurityHeaders())
 	a.e.Use(middleware.ValidatorMiddleware())
+	a.e.Use(middleware.RequestLimits(
+		a.config.AppConfig.MaxRequestBodyBytes,
+		a.config.AppConfig.MaxUploadBodyBytes,
+		a.config.AppConfig.MaxBulkItems,
+	))
 
 	a.e.Use(echomid.CORSWithConfig(echomid.CORSConfig{
 		AllowOrigins:     a.config.AppConfig.CORSOrigins,
-		AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With", "X-Client-Version"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-		MaxAge:           3600,
+		AllowMethods:     a.config.AppConfig.CORSAllowMethods,
+		AllowHeaders:     a.config.AppConfig.CORSAllowHeaders,
+		ExposeHeaders:    []string{"Content-Length", echo.HeaderXRequestID},
+		AllowCredentials: a.config.AppConfig.CORSAllowCredentials,
+		MaxAge:           a.config.AppConfig.CORSMaxAge,
 	}))
 
 	a.e.Use(echomid.GzipWithConfig(echomid.GzipConfig{
@@ -110,12 +331,18 @@ func (a *App) Start() error {
 
 	a.e.Use(audit.AuditMiddleware(a.services.AuditService))
 
-	handler.SetupRoutes(a.e, a.handlers, a.middleware.Auth, a.middleware.ParamValidator)
+	handler.SetupRoutes(a.e, a.handlers, a.middleware.Auth, a.middleware.ParamValidator, a.middleware.Idempotency)
 
 	a.e.Server.ReadTimeout = 10 * time.Second
 	a.e.Server.WriteTimeout = 30 * time.Second
 	a.e.Server.IdleTimeout = 60 * time.Second
 	a.e.Server.MaxHeaderBytes = 1 << 20
 
+	if a.scheduler != nil {
+		if err := a.scheduler.Start(); err != nil {
+			return fmt.Errorf("failed to start scheduler: %w", err)
+		}
+	}
+
 	return a.e.Start(":" + a.config.AppPort)
 }
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"strconv"
 	"strings"
 	"time"
@@ -63,14 +64,24 @@ func (h *AdvancedAnalyticsHandler) GetCourseEngagement(c echo.Context) error {
 	return helpers.Success(c, engagement, 200)
 }
 
-// GetPredictiveInsights retrieves predictive analytics and insights
+// GetPredictiveInsights retrieves predictive analytics and insights, for the
+// whole college or, with ?course_id=, for the students enrolled in one course
 func (h *AdvancedAnalyticsHandler) GetPredictiveInsights(c echo.Context) error {
 	collegeID, err := helpers.ExtractCollegeID(c)
 	if err != nil {
 		return err
 	}
 
-	insights, err := h.advancedAnalyticsService.GetPredictiveInsights(c.Request().Context(), collegeID)
+	var courseID *int
+	if raw := c.QueryParam("course_id"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil || id <= 0 {
+			return helpers.Error(c, "invalid course_id", 400)
+		}
+		courseID = &id
+	}
+
+	insights, err := h.advancedAnalyticsService.GetPredictiveInsights(c.Request().Context(), collegeID, courseID)
 	if err != nil {
 		return helpers.Error(c, err.Error(), 500)
 	}
@@ -163,3 +174,83 @@ func (h *AdvancedAnalyticsHandler) GetComparativeAnalysis(c echo.Context) error
 
 	return helpers.Success(c, analysis, 200)
 }
+
+// ReloadConfig re-reads the dropout-risk weights without a restart (Admin only)
+func (h *AdvancedAnalyticsHandler) ReloadConfig(c echo.Context) error {
+	cfg, err := h.advancedAnalyticsService.ReloadConfig()
+	if err != nil {
+		return helpers.Error(c, err.Error(), 400)
+	}
+
+	return helpers.Success(c, cfg, 200)
+}
+
+// ListCourseSkills lists how the college's courses roll up into skills
+func (h *AdvancedAnalyticsHandler) ListCourseSkills(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	mappings, err := h.advancedAnalyticsService.ListCourseSkills(c.Request().Context(), collegeID)
+	if err != nil {
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, mappings, 200)
+}
+
+// SetCourseSkill maps a course onto a named skill (Admin only)
+func (h *AdvancedAnalyticsHandler) SetCourseSkill(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	courseID, err := strconv.Atoi(c.Param("courseID"))
+	if err != nil {
+		return helpers.Error(c, "invalid course ID", 400)
+	}
+
+	var req struct {
+		Skill string `json:"skill"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return helpers.Error(c, "invalid request body", 400)
+	}
+
+	mapping, err := h.advancedAnalyticsService.SetCourseSkill(c.Request().Context(), collegeID, courseID, req.Skill)
+	if err != nil {
+		switch {
+		case errors.Is(err, analytics.ErrInvalidSkillName):
+			return helpers.Error(c, err.Error(), 400)
+		case errors.Is(err, analytics.ErrCourseNotFound):
+			return helpers.Error(c, err.Error(), 404)
+		}
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, mapping, 200)
+}
+
+// DeleteCourseSkill removes a course's skill mapping (Admin only)
+func (h *AdvancedAnalyticsHandler) DeleteCourseSkill(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	courseID, err := strconv.Atoi(c.Param("courseID"))
+	if err != nil {
+		return helpers.Error(c, "invalid course ID", 400)
+	}
+
+	if err := h.advancedAnalyticsService.DeleteCourseSkill(c.Request().Context(), collegeID, courseID); err != nil {
+		if errors.Is(err, analytics.ErrSkillMappingNotFound) {
+			return helpers.Error(c, err.Error(), 404)
+		}
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, "Skill mapping deleted successfully", 204)
+}
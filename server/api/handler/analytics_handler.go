@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"net/http"
 	"strconv"
+	"time"
 
 	"eduhub/server/internal/helpers"
 	"eduhub/server/internal/services/analytics"
@@ -9,6 +11,10 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// analyticsMaxAge is how long clients may reuse an analytics response before
+// revalidating it with its ETag
+const analyticsMaxAge = time.Minute
+
 type AnalyticsHandler struct {
 	analyticsService analytics.AnalyticsService
 }
@@ -19,7 +25,8 @@ func NewAnalyticsHandler(analyticsService analytics.AnalyticsService) *Analytics
 	}
 }
 
-// GetStudentPerformance retrieves performance metrics for a student
+// GetStudentPerformance retrieves performance metrics for a student.
+// ?include_exams=true adds a summary of their published exam results.
 func (h *AnalyticsHandler) GetStudentPerformance(c echo.Context) error {
 	studentIDStr := c.Param("studentID")
 	studentID, err := strconv.Atoi(studentIDStr)
@@ -41,7 +48,9 @@ func (h *AnalyticsHandler) GetStudentPerformance(c echo.Context) error {
 		}
 	}
 
-	metrics, err := h.analyticsService.GetStudentPerformance(c.Request().Context(), collegeID, studentID, courseID)
+	includeExams, _ := strconv.ParseBool(c.QueryParam("include_exams"))
+
+	metrics, err := h.analyticsService.GetStudentPerformance(c.Request().Context(), collegeID, studentID, courseID, includeExams)
 	if err != nil {
 		return helpers.Error(c, err.Error(), 500)
 	}
@@ -49,7 +58,9 @@ func (h *AnalyticsHandler) GetStudentPerformance(c echo.Context) error {
 	return helpers.Success(c, metrics, 200)
 }
 
-// GetCourseAnalytics retrieves analytics for a course
+// GetCourseAnalytics retrieves analytics for a course, or one of its
+// sections with ?section_id=. It answers 304 when the client's ETag or
+// Last-Modified is still current.
 func (h *AnalyticsHandler) GetCourseAnalytics(c echo.Context) error {
 	courseIDStr := c.Param("courseID")
 	courseID, err := strconv.Atoi(courseIDStr)
@@ -62,7 +73,20 @@ func (h *AnalyticsHandler) GetCourseAnalytics(c echo.Context) error {
 		return err
 	}
 
-	analytics, err := h.analyticsService.GetCourseAnalytics(c.Request().Context(), collegeID, courseID)
+	sectionID, err := parseOptionalInt(c.QueryParam("section_id"))
+	if err != nil {
+		return helpers.Error(c, "invalid section ID", 400)
+	}
+
+	version, err := h.analyticsService.CourseAnalyticsVersion(c.Request().Context(), collegeID, courseID, sectionID)
+	if err != nil {
+		return helpers.Error(c, err.Error(), 500)
+	}
+	if helpers.NotModified(c, version.ETag, version.LastModified, analyticsMaxAge) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	analytics, err := h.analyticsService.GetCourseAnalytics(c.Request().Context(), collegeID, courseID, sectionID)
 	if err != nil {
 		return helpers.Error(c, err.Error(), 500)
 	}
@@ -70,13 +94,42 @@ func (h *AnalyticsHandler) GetCourseAnalytics(c echo.Context) error {
 	return helpers.Success(c, analytics, 200)
 }
 
-// GetCollegeDashboard retrieves dashboard metrics for college
+// GetCourseAnalyticsHistory retrieves a course's analytics month by month
+func (h *AnalyticsHandler) GetCourseAnalyticsHistory(c echo.Context) error {
+	courseID, err := strconv.Atoi(c.Param("courseID"))
+	if err != nil {
+		return helpers.Error(c, "invalid course ID", 400)
+	}
+
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	history, err := h.analyticsService.GetCourseAnalyticsHistory(c.Request().Context(), collegeID, courseID)
+	if err != nil {
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, history, 200)
+}
+
+// GetCollegeDashboard retrieves dashboard metrics for college, answering
+// 304 when the client's copy is still current
 func (h *AnalyticsHandler) GetCollegeDashboard(c echo.Context) error {
 	collegeID, err := helpers.ExtractCollegeID(c)
 	if err != nil {
 		return err
 	}
 
+	version, err := h.analyticsService.CollegeDashboardVersion(c.Request().Context(), collegeID)
+	if err != nil {
+		return helpers.Error(c, err.Error(), 500)
+	}
+	if helpers.NotModified(c, version.ETag, version.LastModified, analyticsMaxAge) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
 	dashboard, err := h.analyticsService.GetCollegeDashboard(c.Request().Context(), collegeID)
 	if err != nil {
 		return helpers.Error(c, err.Error(), 500)
@@ -85,6 +138,76 @@ func (h *AnalyticsHandler) GetCollegeDashboard(c echo.Context) error {
 	return helpers.Success(c, dashboard, 200)
 }
 
+// GetFacultyWorkload retrieves teaching and grading load for a faculty member.
+// Faculty may only view their own workload; admins may view anyone's.
+func (h *AnalyticsHandler) GetFacultyWorkload(c echo.Context) error {
+	facultyID, err := strconv.Atoi(c.Param("facultyID"))
+	if err != nil {
+		return helpers.Error(c, "invalid faculty ID", 400)
+	}
+
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	role, err := helpers.GetUserRole(c)
+	if err != nil {
+		return helpers.Error(c, "Unauthorized", 401)
+	}
+	if role != "admin" {
+		userID, err := helpers.ExtractUserID(c)
+		if err != nil || userID != facultyID {
+			return helpers.Error(c, "faculty can only view their own workload", 403)
+		}
+	}
+
+	workload, err := h.analyticsService.GetFacultyWorkload(c.Request().Context(), collegeID, facultyID)
+	if err != nil {
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, workload, 200)
+}
+
+// CaptureDashboardSnapshot records today's dashboard metrics for the college
+func (h *AnalyticsHandler) CaptureDashboardSnapshot(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := h.analyticsService.CaptureDashboardSnapshot(c.Request().Context(), collegeID)
+	if err != nil {
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, snapshot, 201)
+}
+
+// GetDashboardTrend retrieves the most recent daily dashboard snapshots
+func (h *AnalyticsHandler) GetDashboardTrend(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	limit := 0
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			return helpers.Error(c, "limit must be a positive integer", 400)
+		}
+	}
+
+	trend, err := h.analyticsService.GetDashboardTrend(c.Request().Context(), collegeID, limit)
+	if err != nil {
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, trend, 200)
+}
+
 // GetAttendanceTrends retrieves attendance trends
 func (h *AnalyticsHandler) GetAttendanceTrends(c echo.Context) error {
 	collegeID, err := helpers.ExtractCollegeID(c)
@@ -109,6 +232,33 @@ func (h *AnalyticsHandler) GetAttendanceTrends(c echo.Context) error {
 	return helpers.Success(c, trends, 200)
 }
 
+// GetStudentAttendanceTrends retrieves one student's daily attendance,
+// optionally for one course with ?course_id=. Ownership is checked by the
+// route's middleware.
+func (h *AnalyticsHandler) GetStudentAttendanceTrends(c echo.Context) error {
+	studentID, err := strconv.Atoi(c.Param("studentID"))
+	if err != nil {
+		return helpers.Error(c, "invalid student ID", 400)
+	}
+
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	courseID, err := parseOptionalInt(c.QueryParam("course_id"))
+	if err != nil {
+		return helpers.Error(c, "invalid course ID", 400)
+	}
+
+	trends, err := h.analyticsService.GetStudentAttendanceTrends(c.Request().Context(), collegeID, studentID, courseID)
+	if err != nil {
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, trends, 200)
+}
+
 // GetGradeDistribution retrieves grade distribution for a course
 func (h *AnalyticsHandler) GetGradeDistribution(c echo.Context) error {
 	courseIDStr := c.Param("courseID")
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/services/analytics"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type versionedAnalyticsService struct {
+	analytics.AnalyticsService
+	version    *analytics.DataVersion
+	dashboards int
+	courses    int
+}
+
+func (s *versionedAnalyticsService) CollegeDashboardVersion(ctx context.Context, collegeID int) (*analytics.DataVersion, error) {
+	return s.version, nil
+}
+
+func (s *versionedAnalyticsService) CourseAnalyticsVersion(ctx context.Context, collegeID, courseID int, sectionID *int) (*analytics.DataVersion, error) {
+	return s.version, nil
+}
+
+func (s *versionedAnalyticsService) GetCollegeDashboard(ctx context.Context, collegeID int) (*analytics.CollegeDashboard, error) {
+	s.dashboards++
+	return &analytics.CollegeDashboard{TotalStudents: 10}, nil
+}
+
+func (s *versionedAnalyticsService) GetCourseAnalytics(ctx context.Context, collegeID, courseID int, sectionID *int) (*analytics.CourseAnalytics, error) {
+	s.courses++
+	return &analytics.CourseAnalytics{CourseID: courseID}, nil
+}
+
+func TestAnalyticsHandler_ConditionalGet(t *testing.T) {
+	lastModified := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	version := &analytics.DataVersion{ETag: `W/"abc123"`, LastModified: lastModified}
+
+	newRequest := func(path string, headers map[string]string) (echo.Context, *httptest.ResponseRecorder) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		rec.Header().Set("Cache-Control", "no-store, no-cache, must-revalidate, private")
+		rec.Header().Set("Pragma", "no-cache")
+		c := e.NewContext(req, rec)
+		c.Set("college_id", 1)
+		return c, rec
+	}
+
+	t.Run("dashboard without validators is sent in full", func(t *testing.T) {
+		svc := &versionedAnalyticsService{version: version}
+		h := NewAnalyticsHandler(svc)
+		c, rec := newRequest("/api/analytics/dashboard", nil)
+
+		require.NoError(t, h.GetCollegeDashboard(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `W/"abc123"`, rec.Header().Get("ETag"))
+		assert.Equal(t, "Mon, 02 Mar 2026 09:30:00 GMT", rec.Header().Get("Last-Modified"))
+		assert.Equal(t, "private, max-age=60", rec.Header().Get("Cache-Control"))
+		assert.Empty(t, rec.Header().Get("Pragma"))
+		assert.Equal(t, 1, svc.dashboards)
+	})
+
+	t.Run("dashboard with a matching If-None-Match is 304", func(t *testing.T) {
+		svc := &versionedAnalyticsService{version: version}
+		h := NewAnalyticsHandler(svc)
+		c, rec := newRequest("/api/analytics/dashboard", map[string]string{"If-None-Match": `"other", W/"abc123"`})
+
+		require.NoError(t, h.GetCollegeDashboard(c))
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Body.String())
+		assert.Equal(t, `W/"abc123"`, rec.Header().Get("ETag"))
+		assert.Zero(t, svc.dashboards, "a 304 must not rebuild the dashboard")
+	})
+
+	t.Run("course analytics with a stale ETag is sent in full", func(t *testing.T) {
+		svc := &versionedAnalyticsService{version: version}
+		h := NewAnalyticsHandler(svc)
+		c, rec := newRequest("/api/analytics/courses/4", map[string]string{"If-None-Match": `W/"old"`})
+		c.SetParamNames("courseID")
+		c.SetParamValues("4")
+
+		require.NoError(t, h.GetCourseAnalytics(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 1, svc.courses)
+	})
+
+	t.Run("course analytics unchanged since If-Modified-Since is 304", func(t *testing.T) {
+		svc := &versionedAnalyticsService{version: version}
+		h := NewAnalyticsHandler(svc)
+		c, rec := newRequest("/api/analytics/courses/4", map[string]string{"If-Modified-Since": "Mon, 02 Mar 2026 09:30:00 GMT"})
+		c.SetParamNames("courseID")
+		c.SetParamValues("4")
+
+		require.NoError(t, h.GetCourseAnalytics(c))
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Zero(t, svc.courses)
+	})
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"strconv"
 	"time"
 
@@ -114,6 +115,9 @@ func (h *AssignmentHandler) UpdateAssignment(c echo.Context) error {
 
 	err = h.assignmentService.UpdateAssignment(c.Request().Context(), collegeID, assignmentID, &req)
 	if err != nil {
+		if errors.Is(err, assignment.ErrInvalidLatePolicy) {
+			return helpers.Error(c, err.Error(), 400)
+		}
 		return helpers.Error(c, err.Error(), 500)
 	}
 
@@ -147,6 +151,11 @@ func (h *AssignmentHandler) SubmitAssignment(c echo.Context) error {
 		return helpers.Error(c, "invalid assignment ID", 400)
 	}
 
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
 	studentID, err := helpers.ExtractStudentID(c)
 	if err != nil {
 		return helpers.Error(c, "student ID required", 400)
@@ -160,14 +169,83 @@ func (h *AssignmentHandler) SubmitAssignment(c echo.Context) error {
 	submission.AssignmentID = assignmentID
 	submission.StudentID = studentID
 
-	err = h.assignmentService.SubmitAssignment(c.Request().Context(), &submission)
+	err = h.assignmentService.SubmitAssignment(c.Request().Context(), collegeID, &submission)
 	if err != nil {
+		switch {
+		case errors.Is(err, assignment.ErrSubmissionClosed):
+			return helpers.Error(c, err.Error(), 403)
+		case errors.Is(err, assignment.ErrAssignmentNotFound):
+			return helpers.Error(c, err.Error(), 404)
+		}
 		return helpers.Error(c, err.Error(), 400)
 	}
 
 	return helpers.Success(c, submission, 201)
 }
 
+// UploadSubmissionFile accepts a multipart "file" for the student's submission,
+// stores it in object storage and returns its key with a presigned download URL
+func (h *AssignmentHandler) UploadSubmissionFile(c echo.Context) error {
+	courseID, err := strconv.Atoi(c.Param("courseID"))
+	if err != nil {
+		return helpers.Error(c, "invalid course ID", 400)
+	}
+
+	assignmentID, err := strconv.Atoi(c.Param("assignmentID"))
+	if err != nil {
+		return helpers.Error(c, "invalid assignment ID", 400)
+	}
+
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	studentID, err := helpers.ExtractStudentID(c)
+	if err != nil {
+		return helpers.Error(c, "student ID required", 400)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return helpers.Error(c, "file is required", 400)
+	}
+
+	src, err := fileHeader.Open()
+	if err != nil {
+		return helpers.Error(c, "failed to open file", 500)
+	}
+	defer src.Close()
+
+	upload, err := h.assignmentService.UploadSubmissionFile(c.Request().Context(), collegeID, courseID, assignmentID, studentID, &assignment.SubmissionFile{
+		Name:        fileHeader.Filename,
+		ContentType: fileHeader.Header.Get("Content-Type"),
+		Size:        fileHeader.Size,
+		Reader:      src,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, assignment.ErrSubmissionTooLarge):
+			return helpers.Error(c, err.Error(), 413)
+		case errors.Is(err, assignment.ErrSubmissionFileType):
+			return helpers.Error(c, err.Error(), 415)
+		case errors.Is(err, assignment.ErrSubmissionRejected):
+			return helpers.Error(c, err.Error(), 422)
+		case errors.Is(err, assignment.ErrSubmissionFileEmpty):
+			return helpers.Error(c, err.Error(), 400)
+		case errors.Is(err, assignment.ErrAssignmentNotFound):
+			return helpers.Error(c, err.Error(), 404)
+		case errors.Is(err, assignment.ErrSubmissionClosed), errors.Is(err, assignment.ErrNotEnrolledInCourse):
+			return helpers.Error(c, err.Error(), 403)
+		case errors.Is(err, assignment.ErrStorageNotConfigured):
+			return helpers.Error(c, err.Error(), 503)
+		}
+		return helpers.Error(c, "failed to upload submission", 500)
+	}
+
+	return helpers.Success(c, upload, 201)
+}
+
 func (h *AssignmentHandler) GradeSubmission(c echo.Context) error {
 	submissionIDStr := c.Param("submissionID")
 	submissionID, err := strconv.Atoi(submissionIDStr)
@@ -234,6 +312,9 @@ func (h *AssignmentHandler) BulkGradeSubmissions(c echo.Context) error {
 	if err := c.Bind(&body); err != nil {
 		return helpers.Error(c, "invalid request body", 400)
 	}
+	if msg, over := helpers.ExceedsBulkLimit(c, len(body), "submissions"); over {
+		return helpers.Error(c, msg, 400)
+	}
 
 	grades := make(map[int]*assignment.GradeInput)
 	for k, v := range body {
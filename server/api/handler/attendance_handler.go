@@ -2,11 +2,14 @@ package handler
 
 import (
 	"encoding/base64"
+	"errors"
 	"math"
 	"net/http"
+	"time"
 
 	"eduhub/server/internal/helpers"
 	"eduhub/server/internal/models" // Import models package
+	"eduhub/server/internal/repository"
 	attendancesvc "eduhub/server/internal/services/attendance"
 	"eduhub/server/internal/services/course"
 
@@ -23,6 +26,13 @@ type BulkAttendanceRequest struct {
 	Attendances []models.StudentAttendanceStatus `json:"attendances" validate:"required,dive"` // Use dive for validating nested structs
 }
 
+// RosterAttendanceRequest marks a course roster for one date (YYYY-MM-DD).
+// Statuses are checked per student rather than rejecting the whole request.
+type RosterAttendanceRequest struct {
+	Date        string                           `json:"date"`
+	Attendances []models.StudentAttendanceStatus `json:"attendances"`
+}
+
 type QRCodeRequest struct {
 	QRCodeData string `json:"qrcode_data"`
 }
@@ -134,7 +144,17 @@ func (a *AttendanceHandler) GetAttendanceByCourse(c echo.Context) error {
 		return helpers.Error(c, "Invalid course ID", http.StatusBadRequest)
 	}
 
-	attendance, err := a.attendanceService.GetAttendanceByCourse(ctx, collegeID, courseID, 100, 0)
+	sectionID, err := parseOptionalInt(c.QueryParam("section_id"))
+	if err != nil {
+		return helpers.Error(c, "Invalid section ID", http.StatusBadRequest)
+	}
+
+	var attendance []*models.Attendance
+	if sectionID != nil {
+		attendance, err = a.attendanceService.GetAttendanceBySection(ctx, collegeID, courseID, *sectionID, 100, 0)
+	} else {
+		attendance, err = a.attendanceService.GetAttendanceByCourse(ctx, collegeID, courseID, 100, 0)
+	}
 	if err != nil {
 		return helpers.Error(c, "unable to get attendance", http.StatusInternalServerError)
 	}
@@ -204,7 +224,7 @@ func (a *AttendanceHandler) UpdateAttendance(c echo.Context) error {
 		return helpers.Error(c, "invalid courseID ", 400)
 	}
 
-	ok, err := a.attendanceService.UpdateAttendanceStatus(ctx, collegeID, studentID, courseID, lectureID, "Present")
+	ok, err := a.attendanceService.UpdateAttendanceStatus(ctx, collegeID, studentID, courseID, lectureID, models.AttendancePresent)
 	if !ok {
 		return helpers.Error(c, "Unable update attendance", 500)
 	}
@@ -345,6 +365,9 @@ func (a *AttendanceHandler) MarkBulkAttendance(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return helpers.Error(c, "Invalid request body: "+err.Error(), http.StatusBadRequest)
 	}
+	if msg, over := helpers.ExceedsBulkLimit(c, len(req.Attendances), "attendance records"); over {
+		return helpers.Error(c, msg, http.StatusBadRequest)
+	}
 
 	// Optional: Add validation using a validator library if you have one integrated
 	// if err := c.Validate(&req); err != nil {
@@ -359,3 +382,48 @@ func (a *AttendanceHandler) MarkBulkAttendance(c echo.Context) error {
 
 	return helpers.Success(c, "Bulk attendance marked successfully", http.StatusOK)
 }
+
+// MarkRosterAttendance records a course roster for a date in one go. Invalid
+// entries are skipped and listed in the response; the rest are saved together.
+func (a *AttendanceHandler) MarkRosterAttendance(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return helpers.Error(c, "invalid collegeID", http.StatusBadRequest)
+	}
+
+	courseID, err := helpers.GetIDFromParam(c, "courseID")
+	if err != nil {
+		return err
+	}
+
+	var req RosterAttendanceRequest
+	if err := c.Bind(&req); err != nil {
+		return helpers.Error(c, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+	}
+
+	date, err := time.Parse(time.DateOnly, req.Date)
+	if err != nil {
+		return helpers.Error(c, "date must be in YYYY-MM-DD format", http.StatusBadRequest)
+	}
+	if len(req.Attendances) == 0 {
+		return helpers.Error(c, "attendances must not be empty", http.StatusBadRequest)
+	}
+	if msg, over := helpers.ExceedsBulkLimit(c, len(req.Attendances), "attendance records"); over {
+		return helpers.Error(c, msg, http.StatusBadRequest)
+	}
+
+	rejected, err := a.attendanceService.MarkRosterAttendance(ctx, collegeID, courseID, date, req.Attendances)
+	if err != nil {
+		if errors.Is(err, repository.ErrNoLectureOnDate) {
+			return helpers.Error(c, err.Error(), http.StatusBadRequest)
+		}
+		return helpers.Error(c, "Failed to mark roster attendance: "+err.Error(), http.StatusInternalServerError)
+	}
+
+	return helpers.Success(c, map[string]any{
+		"marked":   len(req.Attendances) - len(rejected),
+		"rejected": rejected,
+	}, http.StatusOK)
+}
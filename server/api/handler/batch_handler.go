@@ -2,11 +2,11 @@ package handler
 
 import (
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"strconv"
 
 	"eduhub/server/internal/helpers"
-	"eduhub/server/internal/models"
 	"eduhub/server/internal/services/batch"
 
 	"github.com/labstack/echo/v4"
@@ -22,7 +22,8 @@ func NewBatchHandler(batchService batch.BatchService) *BatchHandler {
 	}
 }
 
-// ImportStudents imports students from CSV file
+// ImportStudents imports students from a CSV file with the columns
+// roll_no, name, email, enrollment_year and reports the outcome of every row
 func (h *BatchHandler) ImportStudents(c echo.Context) error {
 	collegeID, err := helpers.ExtractCollegeID(c)
 	if err != nil {
@@ -41,35 +42,27 @@ func (h *BatchHandler) ImportStudents(c echo.Context) error {
 	defer src.Close()
 
 	reader := csv.NewReader(src)
+	// Short rows are reported per line instead of rejecting the whole file
+	reader.FieldsPerRecord = -1
 	records, err := reader.ReadAll()
 	if err != nil {
 		return helpers.Error(c, "failed to parse CSV", 400)
 	}
 
-	// Skip header row
+	// Header row plus at least one student
 	if len(records) < 2 {
 		return helpers.Error(c, "CSV file is empty", 400)
 	}
 
-	var students []models.Student
-	for i, record := range records[1:] {
-		if len(record) < 1 {
-			return helpers.Error(c, fmt.Sprintf("invalid record at line %d", i+2), 400)
-		}
-
-		student := models.Student{
-			RollNo:    record[0],
-			CollegeID: collegeID,
-		}
-		students = append(students, student)
-	}
-
-	result, err := h.batchService.ImportStudents(c.Request().Context(), collegeID, students)
+	report, err := h.batchService.ImportStudents(c.Request().Context(), collegeID, records)
 	if err != nil {
+		if errors.Is(err, batch.ErrInvalidImportHeader) {
+			return helpers.Error(c, err.Error(), 400)
+		}
 		return helpers.Error(c, err.Error(), 500)
 	}
 
-	return helpers.Success(c, result, 200)
+	return helpers.Success(c, report, 200)
 }
 
 // ExportStudents exports students to CSV
@@ -174,6 +167,9 @@ func (h *BatchHandler) BulkEnroll(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return helpers.Error(c, "invalid request body", 400)
 	}
+	if msg, over := helpers.ExceedsBulkLimit(c, len(req.StudentIDs), "students"); over {
+		return helpers.Error(c, msg, 400)
+	}
 
 	result, err := h.batchService.BulkEnroll(c.Request().Context(), collegeID, req.CourseID, req.StudentIDs)
 	if err != nil {
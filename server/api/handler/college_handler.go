@@ -4,6 +4,7 @@ import (
 	"eduhub/server/internal/helpers"
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/services/college"
+	"eduhub/server/internal/validation"
 
 	"github.com/labstack/echo/v4"
 )
@@ -31,6 +32,9 @@ func (h *CollegeHandler) UpdateCollegeDetails(c echo.Context) error {
 
 	err = h.collegeService.UpdateCollegePartial(c.Request().Context(), collegeID, &req)
 	if err != nil {
+		if fields := validation.Fields(err); fields != nil {
+			return helpers.ValidationError(c, fields)
+		}
 		return helpers.Error(c, "Failed to process college request", 500)
 	}
 
@@ -8,6 +8,7 @@ import (
 	"eduhub/server/internal/services/course"
 	"eduhub/server/internal/services/enrollment"
 	"eduhub/server/internal/services/student"
+	"eduhub/server/internal/validation"
 
 	"github.com/labstack/echo/v4"
 )
@@ -52,6 +53,9 @@ func (h *CourseHandler) UpdateCourse(c echo.Context) error {
 	// Call service method with partial update request
 	err = h.courseService.UpdateCoursePartial(c.Request().Context(), collegeID, courseID, &req)
 	if err != nil {
+		if fields := validation.Fields(err); fields != nil {
+			return helpers.ValidationError(c, fields)
+		}
 		return helpers.Error(c, err.Error(), 500)
 	}
 
@@ -155,6 +159,9 @@ func (h *CourseHandler) CreateCourse(c echo.Context) error {
 
 	err = h.courseService.CreateCourse(c.Request().Context(), &course)
 	if err != nil {
+		if fields := validation.Fields(err); fields != nil {
+			return helpers.ValidationError(c, fields)
+		}
 		return helpers.Error(c, err.Error(), 400)
 	}
 
@@ -204,6 +211,9 @@ func (h *CourseHandler) EnrollStudents(c echo.Context) error {
 	if len(req.StudentIDs) == 0 {
 		return helpers.Error(c, "at least one student ID is required", 400)
 	}
+	if msg, over := helpers.ExceedsBulkLimit(c, len(req.StudentIDs), "students"); over {
+		return helpers.Error(c, msg, 400)
+	}
 
 	_, err = h.courseService.FindCourseByID(c.Request().Context(), collegeID, courseID)
 	if err != nil {
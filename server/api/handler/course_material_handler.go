@@ -6,6 +6,7 @@ import (
 	"eduhub/server/internal/helpers"
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/services/course_material"
+	"eduhub/server/internal/validation"
 
 	"github.com/labstack/echo/v4"
 )
@@ -61,6 +62,9 @@ func (h *CourseMaterialHandler) CreateModule(c echo.Context) error {
 
 	module, err := h.courseMaterialService.CreateModule(ctx, courseID, collegeID, userID, &req)
 	if err != nil {
+		if fields := validation.Fields(err); fields != nil {
+			return helpers.ValidationError(c, fields)
+		}
 		return helpers.Error(c, err.Error(), 400)
 	}
 
@@ -166,6 +170,9 @@ func (h *CourseMaterialHandler) UpdateModule(c echo.Context) error {
 	}
 
 	if err := h.courseMaterialService.UpdateModule(ctx, collegeID, moduleID, &req); err != nil {
+		if fields := validation.Fields(err); fields != nil {
+			return helpers.ValidationError(c, fields)
+		}
 		return helpers.Error(c, err.Error(), 400)
 	}
 
@@ -248,6 +255,9 @@ func (h *CourseMaterialHandler) CreateMaterial(c echo.Context) error {
 
 	material, err := h.courseMaterialService.CreateMaterial(ctx, courseID, collegeID, userID, &req)
 	if err != nil {
+		if fields := validation.Fields(err); fields != nil {
+			return helpers.ValidationError(c, fields)
+		}
 		return helpers.Error(c, err.Error(), 400)
 	}
 
@@ -366,6 +376,9 @@ func (h *CourseMaterialHandler) UpdateMaterial(c echo.Context) error {
 	}
 
 	if err := h.courseMaterialService.UpdateMaterial(ctx, collegeID, materialID, &req); err != nil {
+		if fields := validation.Fields(err); fields != nil {
+			return helpers.ValidationError(c, fields)
+		}
 		return helpers.Error(c, err.Error(), 400)
 	}
 
@@ -245,7 +245,7 @@ func (h *DashboardHandler) GetStudentDashboard(c echo.Context) error {
 		totalSessions := len(attendanceRecords)
 		presentCount := 0
 		for _, att := range attendanceRecords {
-			if att.Status == "present" {
+			if models.IsAttendancePresent(att.Status) {
 				presentCount++
 			}
 		}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"eduhub/server/internal/helpers"
+	"eduhub/server/internal/services/email"
+
+	"github.com/labstack/echo/v4"
+)
+
+// EmailHandler lets admins inspect and resend background emails that could
+// not be delivered
+type EmailHandler struct {
+	deliveryService email.DeliveryService
+}
+
+func NewEmailHandler(deliveryService email.DeliveryService) *EmailHandler {
+	return &EmailHandler{
+		deliveryService: deliveryService,
+	}
+}
+
+// ListDeadLetters lists the college's undelivered emails, newest first.
+// Resent ones are included with ?include_resent=true.
+// GET /api/emails/dead-letters
+func (h *EmailHandler) ListDeadLetters(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	limit := 50
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
+		limit = l
+	}
+	offset := 0
+	if o, err := strconv.Atoi(c.QueryParam("offset")); err == nil {
+		offset = o
+	}
+	includeResent, _ := strconv.ParseBool(c.QueryParam("include_resent"))
+
+	letters, err := h.deliveryService.ListDeadLetters(c.Request().Context(), collegeID, includeResent, limit, offset)
+	if err != nil {
+		return helpers.Error(c, "failed to load dead-lettered emails", 500)
+	}
+	return helpers.Success(c, letters, 200)
+}
+
+// RetryDeadLetter resends an undelivered email and reports whether it went out
+// POST /api/emails/dead-letters/:letterID/retry
+func (h *EmailHandler) RetryDeadLetter(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+	letterID, err := strconv.Atoi(c.Param("letterID"))
+	if err != nil {
+		return helpers.Error(c, "invalid dead letter ID", 400)
+	}
+
+	letter, err := h.deliveryService.RetryDeadLetter(c.Request().Context(), collegeID, letterID)
+	switch {
+	case err == nil:
+		return helpers.Success(c, letter, 200)
+	case errors.Is(err, email.ErrEmailDeadLetterNotFound):
+		return helpers.ResourceNotFound(c, "dead letter")
+	case errors.Is(err, email.ErrEmailAlreadyResent):
+		return helpers.Error(c, err.Error(), 409)
+	}
+	var rcptErr *email.RecipientError
+	if errors.As(err, &rcptErr) {
+		return helpers.Error(c, rcptErr.Error(), 422)
+	}
+	// The attempt is recorded on the dead letter, so the admin can retry later
+	return helpers.Error(c, "failed to resend email", 502)
+}
@@ -1,23 +1,53 @@
 package handler
 
 import (
+	"context"
+	"encoding/csv"
+	"errors"
 	"strconv"
 	"time"
 
 	"eduhub/server/internal/helpers"
 	"eduhub/server/internal/models"
+	"eduhub/server/internal/services/audit"
 	"eduhub/server/internal/services/exam"
 
 	"github.com/labstack/echo/v4"
 )
 
 type ExamHandler struct {
-	examService exam.ExamService
+	examService  exam.ExamService
+	auditService audit.AuditService
 }
 
-func NewExamHandler(examService exam.ExamService) *ExamHandler {
+// NewExamHandler creates the exam handler. auditService may be nil, which
+// turns off audit logging of exam actions.
+func NewExamHandler(examService exam.ExamService, auditService audit.AuditService) *ExamHandler {
 	return &ExamHandler{
-		examService: examService,
+		examService:  examService,
+		auditService: auditService,
+	}
+}
+
+// logExamAudit records an audit event against an exam. A failed audit write
+// must not fail the request, so it is only logged.
+func logExamAudit(c echo.Context, auditService audit.AuditService, collegeID, userID int, action, entityType string, examID int, changes models.JSONMap) {
+	if auditService == nil {
+		return
+	}
+	auditLog := &models.AuditLog{
+		CollegeID:  collegeID,
+		UserID:     userID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   examID,
+		Changes:    changes,
+		IPAddress:  c.RealIP(),
+		UserAgent:  c.Request().UserAgent(),
+	}
+	// Record the event even if the client has already gone away
+	if err := auditService.LogAction(context.WithoutCancel(c.Request().Context()), auditLog); err != nil {
+		c.Logger().Error("Failed to log audit event:", err)
 	}
 }
 
@@ -42,34 +72,43 @@ func (h *ExamHandler) CreateExam(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return helpers.Error(c, "invalid request body", 400)
 	}
+	if req.Backfill {
+		if role, err := helpers.GetUserRole(c); err != nil || role != "admin" {
+			return helpers.Error(c, "only admins can backfill past exams", 403)
+		}
+	}
+	opts := exam.CreateExamOptions{Backfill: req.Backfill}
 
 	exam := &models.Exam{
-		CollegeID:         collegeID,
-		CourseID:          req.CourseID,
-		Title:             req.Title,
-		Description:       req.Description,
-		ExamType:          req.ExamType,
-		StartTime:         req.StartTime,
-		EndTime:           req.EndTime,
-		Duration:          req.Duration,
-		TotalMarks:        req.TotalMarks,
-		PassingMarks:      req.PassingMarks,
-		Instructions:      req.Instructions,
-		AllowedMaterials:  req.AllowedMaterials,
-		QuestionPaperSets: req.QuestionPaperSets,
-		Status:            "scheduled",
-		CreatedBy:         userID,
-	}
-
-	if err := h.examService.CreateExam(c.Request().Context(), exam); err != nil {
+		CollegeID:           collegeID,
+		CourseID:            req.CourseID,
+		Title:               req.Title,
+		Description:         req.Description,
+		ExamType:            req.ExamType,
+		StartTime:           req.StartTime,
+		EndTime:             req.EndTime,
+		Duration:            req.Duration,
+		TotalMarks:          req.TotalMarks,
+		PassingMarks:        req.PassingMarks,
+		PassingIsPercentage: req.PassingIsPercentage,
+		Instructions:        req.Instructions,
+		AllowedMaterials:    req.AllowedMaterials,
+		QuestionPaperSets:   req.QuestionPaperSets,
+		SeatingScheme:       req.SeatingScheme,
+		Status:              "scheduled",
+		CreatedBy:           userID,
+	}
+
+	if err := h.examService.CreateExam(c.Request().Context(), exam, opts); err != nil {
 		return helpers.Error(c, err.Error(), 400)
 	}
 
 	return helpers.Success(c, exam, 201)
 }
 
-// GetExam retrieves an exam by ID
-// GET /api/v1/exams/:examID
+// GetExam retrieves an exam by ID. With expand=true the response also carries
+// the course name and the assigned room's name, number and location.
+// GET /api/v1/exams/:examID?expand=
 func (h *ExamHandler) GetExam(c echo.Context) error {
 	collegeID, err := helpers.ExtractCollegeID(c)
 	if err != nil {
@@ -81,16 +120,28 @@ func (h *ExamHandler) GetExam(c echo.Context) error {
 		return helpers.Error(c, "invalid exam ID", 400)
 	}
 
-	exam, err := h.examService.GetExam(c.Request().Context(), collegeID, examID)
+	if c.QueryParam("expand") == "true" {
+		detailed, err := h.examService.GetExamWithDetails(c.Request().Context(), collegeID, examID)
+		if err != nil {
+			return helpers.LookupError(c, err, "exam", exam.ErrExamNotFound)
+		}
+		return helpers.Success(c, detailed, 200)
+	}
+
+	found, err := h.examService.GetExam(c.Request().Context(), collegeID, examID)
 	if err != nil {
-		return helpers.Error(c, "exam not found", 404)
+		return helpers.LookupError(c, err, "exam", exam.ErrExamNotFound)
 	}
 
-	return helpers.Success(c, exam, 200)
+	return helpers.Success(c, found, 200)
 }
 
 // ListExams lists all exams with optional filters
-// GET /api/v1/exams
+// GET /api/v1/exams?course_id=&status=&exam_type=&from=&to=&limit=&offset=
+// GET /api/v1/exams?limit=&cursor=
+// Offsets suit jumping to a numbered page. Clients that walk the whole list
+// should send cursor (empty for the first page) and follow next_cursor until
+// it comes back empty; the response is then {"exams": [...], "next_cursor": "..."}.
 func (h *ExamHandler) ListExams(c echo.Context) error {
 	collegeID, err := helpers.ExtractCollegeID(c)
 	if err != nil {
@@ -98,18 +149,39 @@ func (h *ExamHandler) ListExams(c echo.Context) error {
 	}
 
 	// Parse query parameters
-	filters := make(map[string]any)
+	var filter models.ExamFilter
 
 	if courseID := c.QueryParam("course_id"); courseID != "" {
 		if id, err := strconv.Atoi(courseID); err == nil {
-			filters["course_id"] = id
+			filter.CourseID = &id
 		}
 	}
 	if status := c.QueryParam("status"); status != "" {
-		filters["status"] = status
+		filter.Status = &status
 	}
 	if examType := c.QueryParam("exam_type"); examType != "" {
-		filters["exam_type"] = examType
+		filter.ExamType = &examType
+	}
+	if raw := c.QueryParam("from"); raw != "" {
+		from, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return helpers.Error(c, "invalid from date, expected YYYY-MM-DD", 400)
+		}
+		filter.From = &from
+	}
+	if raw := c.QueryParam("to"); raw != "" {
+		to, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return helpers.Error(c, "invalid to date, expected YYYY-MM-DD", 400)
+		}
+		// The filter's upper bound is exclusive, so include the whole day
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+	if c.QueryParam("include_deleted") == "true" {
+		if role, err := helpers.GetUserRole(c); err == nil && role == "admin" {
+			filter.IncludeDeleted = true
+		}
 	}
 
 	limit := 50
@@ -119,13 +191,28 @@ func (h *ExamHandler) ListExams(c echo.Context) error {
 			limit = parsed
 		}
 	}
+
+	if cursor, ok := cursorParam(c); ok {
+		if c.QueryParam("offset") != "" {
+			return helpers.Error(c, "cursor and offset cannot be combined", 400)
+		}
+		exams, next, err := h.examService.ListExamsAfter(c.Request().Context(), collegeID, filter, cursor, limit)
+		if err != nil {
+			if errors.Is(err, exam.ErrInvalidCursor) {
+				return helpers.Error(c, err.Error(), 400)
+			}
+			return helpers.Error(c, err.Error(), 500)
+		}
+		return helpers.Success(c, map[string]any{"exams": exams, "next_cursor": next}, 200)
+	}
+
 	if o := c.QueryParam("offset"); o != "" {
 		if parsed, err := strconv.Atoi(o); err == nil {
 			offset = parsed
 		}
 	}
 
-	exams, err := h.examService.ListExams(c.Request().Context(), collegeID, filters, limit, offset)
+	exams, err := h.examService.ListExams(c.Request().Context(), collegeID, filter, limit, offset)
 	if err != nil {
 		return helpers.Error(c, err.Error(), 500)
 	}
@@ -133,6 +220,16 @@ func (h *ExamHandler) ListExams(c echo.Context) error {
 	return helpers.Success(c, exams, 200)
 }
 
+// cursorParam reports whether the request asked for cursor pagination. An
+// empty ?cursor= requests the first page.
+func cursorParam(c echo.Context) (string, bool) {
+	values, ok := c.QueryParams()["cursor"]
+	if !ok {
+		return "", false
+	}
+	return values[0], true
+}
+
 // ListExamsByCourse lists exams for a specific course
 // GET /api/v1/courses/:courseID/exams
 func (h *ExamHandler) ListExamsByCourse(c echo.Context) error {
@@ -180,22 +277,32 @@ func (h *ExamHandler) UpdateExam(c echo.Context) error {
 		return helpers.Error(c, "invalid exam ID", 400)
 	}
 
-	var exam models.Exam
-	if err := c.Bind(&exam); err != nil {
+	var req models.Exam
+	if err := c.Bind(&req); err != nil {
 		return helpers.Error(c, "invalid request body", 400)
 	}
 
-	exam.ID = examID
-	exam.CollegeID = collegeID
+	if req.Version < 1 {
+		return helpers.Error(c, "version is required", 400)
+	}
+
+	req.ID = examID
+	req.CollegeID = collegeID
 
-	if err := h.examService.UpdateExam(c.Request().Context(), &exam); err != nil {
+	if err := h.examService.UpdateExam(c.Request().Context(), &req); err != nil {
+		if errors.Is(err, exam.ErrVersionConflict) {
+			return helpers.Error(c, err.Error(), 409)
+		}
 		return helpers.Error(c, err.Error(), 400)
 	}
 
-	return helpers.Success(c, "exam updated successfully", 200)
+	return helpers.Success(c, map[string]any{
+		"message": "exam updated successfully",
+		"version": req.Version,
+	}, 200)
 }
 
-// DeleteExam deletes an exam
+// DeleteExam soft-deletes an exam, keeping its enrollments and results
 // DELETE /api/v1/exams/:examID
 func (h *ExamHandler) DeleteExam(c echo.Context) error {
 	collegeID, err := helpers.ExtractCollegeID(c)
@@ -215,6 +322,131 @@ func (h *ExamHandler) DeleteExam(c echo.Context) error {
 	return helpers.Success(c, "exam deleted successfully", 200)
 }
 
+// CancelExamRequest carries the reason an exam is cancelled
+type CancelExamRequest struct {
+	Reason string `json:"reason"`
+}
+
+// CancelExam cancels an exam, keeping its record, and tells enrolled students
+// POST /api/v1/exams/:examID/cancel
+func (h *ExamHandler) CancelExam(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		return err
+	}
+
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	var req CancelExamRequest
+	if err := c.Bind(&req); err != nil {
+		return helpers.Error(c, "invalid request body", 400)
+	}
+
+	cancelled, err := h.examService.CancelExam(c.Request().Context(), collegeID, examID, req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, exam.ErrExamNotFound):
+			return helpers.ResourceNotFound(c, "exam")
+		case errors.Is(err, exam.ErrExamNotCancellable):
+			return helpers.Error(c, err.Error(), 409)
+		case errors.Is(err, exam.ErrInvalidCancellation):
+			return helpers.Error(c, err.Error(), 400)
+		}
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	logExamAudit(c, h.auditService, collegeID, userID, "UPDATE", "exam", examID, models.JSONMap{
+		"status": cancelled.Status,
+		"reason": req.Reason,
+	})
+	return helpers.Success(c, cancelled, 200)
+}
+
+// RestoreExam brings back a soft-deleted exam
+// POST /api/v1/exams/:examID/restore
+func (h *ExamHandler) RestoreExam(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	if err := h.examService.RestoreExam(c.Request().Context(), collegeID, examID); err != nil {
+		return helpers.LookupError(c, err, "deleted exam", exam.ErrExamNotFound)
+	}
+
+	return helpers.Success(c, "exam restored successfully", 200)
+}
+
+// PreviewTimetable proposes a conflict-free exam timetable without saving it
+// POST /api/v1/exams/timetable/dry-run
+func (h *ExamHandler) PreviewTimetable(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	var req exam.TimetableRequest
+	if err := c.Bind(&req); err != nil {
+		return helpers.Error(c, "invalid request body", 400)
+	}
+
+	proposal, err := h.examService.GenerateTimetable(c.Request().Context(), collegeID, &req)
+	if err != nil {
+		return timetableError(c, err)
+	}
+
+	return helpers.Success(c, proposal, 200)
+}
+
+// ConfirmTimetable generates the timetable for the request and creates its exams
+// POST /api/v1/exams/timetable/confirm
+func (h *ExamHandler) ConfirmTimetable(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req exam.TimetableRequest
+	if err := c.Bind(&req); err != nil {
+		return helpers.Error(c, "invalid request body", 400)
+	}
+
+	exams, err := h.examService.ConfirmTimetable(c.Request().Context(), collegeID, userID, &req)
+	if err != nil {
+		return timetableError(c, err)
+	}
+
+	return helpers.Success(c, exams, 201)
+}
+
+func timetableError(c echo.Context, err error) error {
+	if errors.Is(err, exam.ErrTimetableInfeasible) {
+		return helpers.Error(c, err.Error(), 422)
+	}
+	if errors.Is(err, exam.ErrRoomBooked) {
+		return helpers.Error(c, err.Error(), 409)
+	}
+	return helpers.Error(c, err.Error(), 400)
+}
+
 // GetExamStats retrieves statistics for an exam
 // GET /api/v1/exams/:examID/stats
 func (h *ExamHandler) GetExamStats(c echo.Context) error {
@@ -293,6 +525,9 @@ func (h *ExamHandler) EnrollMultipleStudents(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return helpers.Error(c, "invalid request body", 400)
 	}
+	if msg, over := helpers.ExceedsBulkLimit(c, len(req.StudentIDs), "students"); over {
+		return helpers.Error(c, msg, 400)
+	}
 
 	if err := h.examService.EnrollMultipleStudents(c.Request().Context(), examID, collegeID, req.StudentIDs); err != nil {
 		return helpers.Error(c, err.Error(), 400)
@@ -301,6 +536,30 @@ func (h *ExamHandler) EnrollMultipleStudents(c echo.Context) error {
 	return helpers.Success(c, "students enrolled successfully", 201)
 }
 
+// EnrollCourse enrolls every student of the exam's course in the exam
+// POST /api/v1/exams/:examID/enroll-course
+func (h *ExamHandler) EnrollCourse(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	summary, err := h.examService.EnrollCourse(c.Request().Context(), examID, collegeID)
+	if err != nil {
+		if errors.Is(err, exam.ErrExamNotFound) {
+			return helpers.ResourceNotFound(c, "exam")
+		}
+		return helpers.Error(c, "failed to enroll course students", 500)
+	}
+
+	return helpers.Success(c, summary, 200)
+}
+
 // ListEnrollments lists all enrollments for an exam
 // GET /api/v1/exams/:examID/enrollments
 func (h *ExamHandler) ListEnrollments(c echo.Context) error {
@@ -317,7 +576,58 @@ func (h *ExamHandler) ListEnrollments(c echo.Context) error {
 	return helpers.Success(c, enrollments, 200)
 }
 
-// GetStudentEnrollments lists all exams a student is enrolled in
+// GetUpcomingExams lists exams a student is enrolled in that have not started yet
+// GET /api/v1/students/:studentID/upcoming-exams
+func (h *ExamHandler) GetUpcomingExams(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	studentID, err := strconv.Atoi(c.Param("studentID"))
+	if err != nil {
+		return helpers.Error(c, "invalid student ID", 400)
+	}
+
+	exams, err := h.examService.GetUpcomingExams(c.Request().Context(), studentID, collegeID)
+	if err != nil {
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, exams, 200)
+}
+
+// ListExamClashes audits students enrolled in exams whose times overlap.
+// Pass student_id to check a single student.
+// GET /api/v1/exams/clashes
+func (h *ExamHandler) ListExamClashes(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	var clashes []*models.ExamClash
+	if raw := c.QueryParam("student_id"); raw != "" {
+		studentID, err := strconv.Atoi(raw)
+		if err != nil || studentID <= 0 {
+			return helpers.Error(c, "invalid student ID", 400)
+		}
+		clashes, err = h.examService.DetectStudentExamClashes(c.Request().Context(), collegeID, studentID)
+		if err != nil {
+			return helpers.Error(c, err.Error(), 500)
+		}
+	} else {
+		clashes, err = h.examService.DetectCollegeExamClashes(c.Request().Context(), collegeID)
+		if err != nil {
+			return helpers.Error(c, err.Error(), 500)
+		}
+	}
+
+	return helpers.Success(c, clashes, 200)
+}
+
+// GetStudentEnrollments lists all exams a student is enrolled in, with each
+// exam's title, course, schedule and whether the student's result is out
 // GET /api/v1/students/:studentID/exam-enrollments
 func (h *ExamHandler) GetStudentEnrollments(c echo.Context) error {
 	collegeID, err := helpers.ExtractCollegeID(c)
@@ -330,7 +640,7 @@ func (h *ExamHandler) GetStudentEnrollments(c echo.Context) error {
 		return helpers.Error(c, "invalid student ID", 400)
 	}
 
-	enrollments, err := h.examService.GetStudentEnrollments(c.Request().Context(), studentID, collegeID)
+	enrollments, err := h.examService.GetStudentExamEnrollments(c.Request().Context(), studentID, collegeID)
 	if err != nil {
 		return helpers.Error(c, err.Error(), 500)
 	}
@@ -338,9 +648,43 @@ func (h *ExamHandler) GetStudentEnrollments(c echo.Context) error {
 	return helpers.Success(c, enrollments, 200)
 }
 
+// GetStudentExamSummary counts a student's exam enrollments and results for
+// dashboards that only show the numbers. Students only count their
+// published results, as in GetStudentResults.
+// GET /api/v1/students/:studentID/exam-summary
+func (h *ExamHandler) GetStudentExamSummary(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	studentID, err := strconv.Atoi(c.Param("studentID"))
+	if err != nil {
+		return helpers.Error(c, "invalid student ID", 400)
+	}
+
+	ctx := c.Request().Context()
+	enrollments, err := h.examService.CountStudentEnrollments(ctx, studentID, collegeID)
+	if err != nil {
+		return helpers.Error(c, err.Error(), 500)
+	}
+	role, _ := helpers.GetUserRole(c)
+	results, err := h.examService.CountStudentResults(ctx, studentID, collegeID, role == "student")
+	if err != nil {
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, map[string]int{"enrollments": enrollments, "results": results}, 200)
+}
+
 // UpdateEnrollment updates an enrollment
 // PUT /api/v1/exams/:examID/enrollments/:studentID
 func (h *ExamHandler) UpdateEnrollment(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
 	examID, err := strconv.Atoi(c.Param("examID"))
 	if err != nil {
 		return helpers.Error(c, "invalid exam ID", 400)
@@ -351,9 +695,12 @@ func (h *ExamHandler) UpdateEnrollment(c echo.Context) error {
 		return helpers.Error(c, "invalid student ID", 400)
 	}
 
+	if err := h.requireExam(c, collegeID, examID); err != nil {
+		return helpers.LookupError(c, err, "exam", exam.ErrExamNotFound)
+	}
 	enrollment, err := h.examService.GetEnrollment(c.Request().Context(), examID, studentID)
 	if err != nil {
-		return helpers.Error(c, "enrollment not found", 404)
+		return helpers.LookupError(c, err, "enrollment", exam.ErrEnrollmentNotFound)
 	}
 
 	var update struct {
@@ -372,62 +719,475 @@ func (h *ExamHandler) UpdateEnrollment(c echo.Context) error {
 	if update.RoomNumber != nil {
 		enrollment.RoomNumber = update.RoomNumber
 	}
-	if update.QuestionPaperSet != nil {
-		enrollment.QuestionPaperSet = update.QuestionPaperSet
+	if update.QuestionPaperSet != nil {
+		enrollment.QuestionPaperSet = update.QuestionPaperSet
+	}
+	if update.Status != nil {
+		enrollment.Status = *update.Status
+	}
+
+	if err := h.examService.UpdateEnrollment(c.Request().Context(), enrollment); err != nil {
+		return helpers.Error(c, err.Error(), 400)
+	}
+
+	return helpers.Success(c, "enrollment updated successfully", 200)
+}
+
+// DeleteEnrollment removes a student from an exam
+// DELETE /api/v1/exams/:examID/enrollments/:studentID
+func (h *ExamHandler) DeleteEnrollment(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	studentID, err := strconv.Atoi(c.Param("studentID"))
+	if err != nil {
+		return helpers.Error(c, "invalid student ID", 400)
+	}
+
+	if err := h.requireExam(c, collegeID, examID); err != nil {
+		return helpers.LookupError(c, err, "exam", exam.ErrExamNotFound)
+	}
+	if err := h.examService.DeleteEnrollment(c.Request().Context(), examID, studentID); err != nil {
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, "enrollment deleted successfully", 200)
+}
+
+// ===========================
+// Invigilation
+// ===========================
+
+// AssignInvigilator puts a staff member on an exam
+// POST /api/v1/exams/:examID/invigilators
+func (h *ExamHandler) AssignInvigilator(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	assignedBy, err := helpers.ExtractUserID(c)
+	if err != nil {
+		return err
+	}
+
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	var req struct {
+		UserID int  `json:"user_id"`
+		RoomID *int `json:"room_id"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return helpers.Error(c, "invalid request body", 400)
+	}
+
+	invigilator := &models.ExamInvigilator{
+		ExamID:     examID,
+		CollegeID:  collegeID,
+		RoomID:     req.RoomID,
+		UserID:     req.UserID,
+		AssignedBy: &assignedBy,
+	}
+	if err := h.examService.AssignInvigilator(c.Request().Context(), invigilator); err != nil {
+		if errors.Is(err, exam.ErrInvigilatorConflict) || errors.Is(err, exam.ErrInvigilatorAssigned) {
+			return helpers.Error(c, err.Error(), 409)
+		}
+		return helpers.Error(c, err.Error(), 400)
+	}
+
+	return helpers.Success(c, invigilator, 201)
+}
+
+// ListInvigilators lists the staff supervising an exam
+// GET /api/v1/exams/:examID/invigilators
+func (h *ExamHandler) ListInvigilators(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	invigilators, err := h.examService.ListInvigilators(c.Request().Context(), collegeID, examID)
+	if err != nil {
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, invigilators, 200)
+}
+
+// CheckInStudent marks the student with the scanned or typed roll number as
+// having appeared for the exam
+// POST /api/v1/exams/:examID/check-in
+func (h *ExamHandler) CheckInStudent(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		return err
+	}
+
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	var req struct {
+		RollNo string `json:"roll_no"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return helpers.Error(c, "invalid request body", 400)
+	}
+
+	role, _ := helpers.GetUserRole(c)
+	enrollment, err := h.examService.CheckInStudent(c.Request().Context(), collegeID, examID, userID, role == "admin", req.RollNo)
+	if err != nil {
+		switch {
+		case errors.Is(err, exam.ErrExamNotFound):
+			return helpers.ResourceNotFound(c, "exam")
+		case errors.Is(err, exam.ErrEnrollmentNotFound):
+			return helpers.Error(c, "student is not enrolled in this exam", 404)
+		case errors.Is(err, exam.ErrNotInvigilator):
+			return helpers.Error(c, err.Error(), 403)
+		case errors.Is(err, exam.ErrAlreadyAppeared), errors.Is(err, exam.ErrEnrollmentDisqualified),
+			errors.Is(err, exam.ErrExamNotInProgress):
+			return helpers.Error(c, err.Error(), 409)
+		}
+		return helpers.Error(c, err.Error(), 400)
+	}
+
+	return helpers.Success(c, enrollment, 200)
+}
+
+// GrantExtension gives a student extra time during an exam (invigilators
+// of the exam and admins)
+// POST /api/v1/exams/:examID/extensions
+func (h *ExamHandler) GrantExtension(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		return err
+	}
+
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	var req exam.GrantExtensionRequest
+	if err := c.Bind(&req); err != nil {
+		return helpers.Error(c, "invalid request body", 400)
+	}
+
+	role, _ := helpers.GetUserRole(c)
+	grant, err := h.examService.GrantExtension(c.Request().Context(), collegeID, examID, userID, role == "admin", req)
+	if err != nil {
+		switch {
+		case errors.Is(err, exam.ErrExamNotFound):
+			return helpers.ResourceNotFound(c, "exam")
+		case errors.Is(err, exam.ErrEnrollmentNotFound):
+			return helpers.Error(c, "student is not enrolled in this exam", 404)
+		case errors.Is(err, exam.ErrNotInvigilator):
+			return helpers.Error(c, err.Error(), 403)
+		case errors.Is(err, exam.ErrExtensionCapExceeded), errors.Is(err, exam.ErrExamNotInProgress):
+			return helpers.Error(c, err.Error(), 409)
+		}
+		return helpers.Error(c, err.Error(), 400)
+	}
+
+	logExamAudit(c, h.auditService, collegeID, userID, "UPDATE", "exam_time_extension", examID, models.JSONMap{
+		"extension_id": grant.Extension.ID,
+		"student_id":   grant.Extension.StudentID,
+		"minutes":      grant.Extension.Minutes,
+		"reason":       grant.Extension.Reason,
+		"total":        grant.TotalExtensionMinutes,
+	})
+	return helpers.Success(c, grant, 201)
+}
+
+// ListExtensions lists the extra time granted to students during an exam
+// GET /api/v1/exams/:examID/extensions
+func (h *ExamHandler) ListExtensions(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	extensions, err := h.examService.ListExtensions(c.Request().Context(), collegeID, examID)
+	if err != nil {
+		if errors.Is(err, exam.ErrExamNotFound) {
+			return helpers.ResourceNotFound(c, "exam")
+		}
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, extensions, 200)
+}
+
+// RecordProctoringEvent records a proctoring event during the calling
+// student's exam
+// POST /api/v1/exams/:examID/proctoring-events
+func (h *ExamHandler) RecordProctoringEvent(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	studentID, err := helpers.ExtractStudentID(c)
+	if err != nil {
+		return err
+	}
+
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	var req exam.ProctoringEventRequest
+	if err := c.Bind(&req); err != nil {
+		return helpers.Error(c, "invalid request body", 400)
+	}
+
+	event, err := h.examService.RecordProctoringEvent(c.Request().Context(), collegeID, examID, studentID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, exam.ErrExamNotFound):
+			return helpers.ResourceNotFound(c, "exam")
+		case errors.Is(err, exam.ErrEnrollmentNotFound), errors.Is(err, exam.ErrEnrollmentDisqualified):
+			return helpers.Error(c, err.Error(), 403)
+		case errors.Is(err, exam.ErrExamNotInProgress):
+			return helpers.Error(c, err.Error(), 409)
+		}
+		return helpers.Error(c, err.Error(), 400)
+	}
+
+	return helpers.Success(c, event, 201)
+}
+
+// ReviewProctoringEvents lists the proctoring events flagged during a
+// student's exam
+// GET /api/v1/exams/:examID/proctoring-events/:studentID
+func (h *ExamHandler) ReviewProctoringEvents(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	studentID, err := strconv.Atoi(c.Param("studentID"))
+	if err != nil {
+		return helpers.Error(c, "invalid student ID", 400)
+	}
+
+	review, err := h.examService.ReviewProctoringEvents(c.Request().Context(), collegeID, examID, studentID)
+	if err != nil {
+		if errors.Is(err, exam.ErrExamNotFound) {
+			return helpers.ResourceNotFound(c, "exam")
+		}
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, review, 200)
+}
+
+// RemoveInvigilator unassigns a staff member from an exam
+// DELETE /api/v1/exams/:examID/invigilators/:userID
+func (h *ExamHandler) RemoveInvigilator(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	userID, err := strconv.Atoi(c.Param("userID"))
+	if err != nil {
+		return helpers.Error(c, "invalid user ID", 400)
+	}
+
+	if err := h.examService.RemoveInvigilator(c.Request().Context(), collegeID, examID, userID); err != nil {
+		return helpers.Error(c, err.Error(), 404)
+	}
+
+	return helpers.Success(c, "invigilator removed successfully", 200)
+}
+
+// SuggestInvigilators reports how many invigilators an exam needs and who is free
+// GET /api/v1/exams/:examID/invigilators/suggestions
+func (h *ExamHandler) SuggestInvigilators(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	suggestion, err := h.examService.SuggestInvigilators(c.Request().Context(), collegeID, examID)
+	if err != nil {
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, suggestion, 200)
+}
+
+// ===========================
+// Seat Allocation & Hall Tickets
+// ===========================
+
+// AllocateSeats allocates seats for all enrolled students
+// POST /api/v1/exams/:examID/allocate-seats
+func (h *ExamHandler) AllocateSeats(c echo.Context) error {
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.examService.AllocateSeats(c.Request().Context(), collegeID, examID); err != nil {
+		if errors.Is(err, exam.ErrSeatAllocationInProgress) || errors.Is(err, exam.ErrSeatPlanStale) {
+			return helpers.Error(c, err.Error(), 409)
+		}
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, "seats allocated successfully", 200)
+}
+
+// SetAccommodations replaces a student's accessibility accommodations for an exam
+// PUT /api/v1/exams/:examID/enrollments/:studentID/accommodations
+func (h *ExamHandler) SetAccommodations(c echo.Context) error {
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	studentID, err := strconv.Atoi(c.Param("studentID"))
+	if err != nil {
+		return helpers.Error(c, "invalid student ID", 400)
+	}
+
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
 	}
-	if update.Status != nil {
-		enrollment.Status = *update.Status
+
+	var accommodations models.ExamAccommodations
+	if err := c.Bind(&accommodations); err != nil {
+		return helpers.Error(c, "invalid request body", 400)
 	}
 
-	if err := h.examService.UpdateEnrollment(c.Request().Context(), enrollment); err != nil {
-		return helpers.Error(c, err.Error(), 400)
+	if err := h.examService.SetAccommodations(c.Request().Context(), collegeID, examID, studentID, accommodations); err != nil {
+		if errors.Is(err, exam.ErrInvalidAccommodations) {
+			return helpers.Error(c, err.Error(), 400)
+		}
+		if errors.Is(err, exam.ErrEnrollmentNotFound) {
+			return helpers.Error(c, err.Error(), 404)
+		}
+		return helpers.Error(c, err.Error(), 500)
 	}
 
-	return helpers.Success(c, "enrollment updated successfully", 200)
+	return helpers.Success(c, "accommodations updated", 200)
 }
 
-// DeleteEnrollment removes a student from an exam
-// DELETE /api/v1/exams/:examID/enrollments/:studentID
-func (h *ExamHandler) DeleteEnrollment(c echo.Context) error {
+// AllocateSeatsPreview returns the seat plan allocation would write, without writing it
+// POST /api/v1/exams/:examID/allocate-seats/preview
+func (h *ExamHandler) AllocateSeatsPreview(c echo.Context) error {
 	examID, err := strconv.Atoi(c.Param("examID"))
 	if err != nil {
 		return helpers.Error(c, "invalid exam ID", 400)
 	}
 
-	studentID, err := strconv.Atoi(c.Param("studentID"))
+	collegeID, err := helpers.ExtractCollegeID(c)
 	if err != nil {
-		return helpers.Error(c, "invalid student ID", 400)
+		return err
 	}
 
-	if err := h.examService.DeleteEnrollment(c.Request().Context(), examID, studentID); err != nil {
+	preview, err := h.examService.AllocateSeatsPreview(c.Request().Context(), collegeID, examID)
+	if err != nil {
 		return helpers.Error(c, err.Error(), 500)
 	}
 
-	return helpers.Success(c, "enrollment deleted successfully", 200)
+	return helpers.Success(c, preview, 200)
 }
 
-// ===========================
-// Seat Allocation & Hall Tickets
-// ===========================
-
-// AllocateSeats allocates seats for all enrolled students
-// POST /api/v1/exams/:examID/allocate-seats
-func (h *ExamHandler) AllocateSeats(c echo.Context) error {
+// ConfirmSeatAllocation applies a previewed seat plan
+// POST /api/v1/exams/:examID/allocate-seats/confirm
+func (h *ExamHandler) ConfirmSeatAllocation(c echo.Context) error {
 	examID, err := strconv.Atoi(c.Param("examID"))
 	if err != nil {
 		return helpers.Error(c, "invalid exam ID", 400)
 	}
 
-	if err := h.examService.AllocateSeats(c.Request().Context(), examID); err != nil {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		PlanID string `json:"plan_id"`
+	}
+	if err := c.Bind(&req); err != nil || req.PlanID == "" {
+		return helpers.Error(c, "plan_id is required", 400)
+	}
+
+	if err := h.examService.ConfirmSeatAllocation(c.Request().Context(), collegeID, examID, req.PlanID); err != nil {
+		if errors.Is(err, exam.ErrSeatAllocationInProgress) || errors.Is(err, exam.ErrSeatPlanStale) {
+			return helpers.Error(c, err.Error(), 409)
+		}
 		return helpers.Error(c, err.Error(), 500)
 	}
 
 	return helpers.Success(c, "seats allocated successfully", 200)
 }
 
-// GenerateHallTicket generates hall ticket for a student
+// GenerateHallTicket generates hall ticket for a student, labelled in ?lang
+// or else in the student's own language
 // GET /api/v1/exams/:examID/hall-ticket/:studentID
 func (h *ExamHandler) GenerateHallTicket(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
 	examID, err := strconv.Atoi(c.Param("examID"))
 	if err != nil {
 		return helpers.Error(c, "invalid exam ID", 400)
@@ -438,14 +1198,52 @@ func (h *ExamHandler) GenerateHallTicket(c echo.Context) error {
 		return helpers.Error(c, "invalid student ID", 400)
 	}
 
-	hallTicket, err := h.examService.GenerateHallTicket(c.Request().Context(), examID, studentID)
+	if err := h.requireExam(c, collegeID, examID); err != nil {
+		return helpers.LookupError(c, err, "exam", exam.ErrExamNotFound)
+	}
+	hallTicket, err := h.examService.GenerateHallTicket(c.Request().Context(), examID, studentID, c.QueryParam("lang"))
 	if err != nil {
+		if errors.Is(err, exam.ErrEnrollmentNotFound) {
+			return helpers.ResourceNotFound(c, "enrollment")
+		}
 		return helpers.Error(c, err.Error(), 500)
 	}
 
 	return helpers.Success(c, hallTicket, 200)
 }
 
+// VerifyHallTicket checks whether a presented hall ticket version is the
+// student's current one, so invigilators can turn away outdated tickets
+// GET /api/v1/exams/:examID/hall-ticket/:studentID/verify?version=N
+func (h *ExamHandler) VerifyHallTicket(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+	studentID, err := strconv.Atoi(c.Param("studentID"))
+	if err != nil {
+		return helpers.Error(c, "invalid student ID", 400)
+	}
+	version, err := strconv.Atoi(c.QueryParam("version"))
+	if err != nil || version < 1 {
+		return helpers.Error(c, "version must be a positive integer", 400)
+	}
+
+	verification, err := h.examService.VerifyHallTicket(c.Request().Context(), collegeID, examID, studentID, version)
+	if err != nil {
+		if errors.Is(err, exam.ErrEnrollmentNotFound) {
+			return helpers.Error(c, err.Error(), 404)
+		}
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, verification, 200)
+}
+
 // GenerateAllHallTickets generates hall tickets for all enrolled students
 // POST /api/v1/exams/:examID/hall-tickets
 func (h *ExamHandler) GenerateAllHallTickets(c echo.Context) error {
@@ -507,6 +1305,76 @@ func (h *ExamHandler) CreateResult(c echo.Context) error {
 // GetResult retrieves a specific exam result
 // GET /api/v1/exams/:examID/results/:studentID
 func (h *ExamHandler) GetResult(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	studentID, err := strconv.Atoi(c.Param("studentID"))
+	if err != nil {
+		return helpers.Error(c, "invalid student ID", 400)
+	}
+
+	result, err := h.examService.GetResult(c.Request().Context(), examID, studentID)
+	if err != nil {
+		return helpers.LookupError(c, err, "result", exam.ErrExamResultNotFound)
+	}
+	if result.CollegeID != collegeID {
+		return helpers.ResourceNotFound(c, "result")
+	}
+	// Students only see a result once it has been published
+	if role, err := helpers.GetUserRole(c); err == nil && role == "student" && !result.Published {
+		return helpers.ResourceNotFound(c, "result")
+	}
+
+	return helpers.Success(c, result, 200)
+}
+
+// GetResultHistory shows auditors every earlier version of a student's
+// result and whether the history is intact
+// GET /api/v1/exams/:examID/results/:studentID/history
+func (h *ExamHandler) GetResultHistory(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	studentID, err := strconv.Atoi(c.Param("studentID"))
+	if err != nil {
+		return helpers.Error(c, "invalid student ID", 400)
+	}
+
+	history, err := h.examService.GetResultHistory(c.Request().Context(), collegeID, examID, studentID)
+	if err != nil {
+		return helpers.LookupError(c, err, "result", exam.ErrExamResultNotFound)
+	}
+
+	return helpers.Success(c, history, 200)
+}
+
+// UpdateResult re-grades an existing exam result
+// PUT /api/v1/exams/:examID/results/:studentID
+func (h *ExamHandler) UpdateResult(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		return err
+	}
+
 	examID, err := strconv.Atoi(c.Param("examID"))
 	if err != nil {
 		return helpers.Error(c, "invalid exam ID", 400)
@@ -517,9 +1385,32 @@ func (h *ExamHandler) GetResult(c echo.Context) error {
 		return helpers.Error(c, "invalid student ID", 400)
 	}
 
+	var req models.UpdateExamResultRequest
+	if err := c.Bind(&req); err != nil {
+		return helpers.Error(c, "invalid request body", 400)
+	}
+	if req.Version < 1 {
+		return helpers.Error(c, "version is required", 400)
+	}
+
 	result, err := h.examService.GetResult(c.Request().Context(), examID, studentID)
 	if err != nil {
-		return helpers.Error(c, "result not found", 404)
+		return helpers.LookupError(c, err, "result", exam.ErrExamResultNotFound)
+	}
+	if result.CollegeID != collegeID {
+		return helpers.ResourceNotFound(c, "result")
+	}
+
+	result.MarksObtained = &req.MarksObtained
+	result.Remarks = req.Remarks
+	result.EvaluatedBy = &userID
+	result.Version = req.Version
+
+	if err := h.examService.UpdateResult(c.Request().Context(), result); err != nil {
+		if errors.Is(err, exam.ErrVersionConflict) {
+			return helpers.Error(c, err.Error(), 409)
+		}
+		return helpers.Error(c, err.Error(), 400)
 	}
 
 	return helpers.Success(c, result, 200)
@@ -541,8 +1432,34 @@ func (h *ExamHandler) ListResults(c echo.Context) error {
 	return helpers.Success(c, results, 200)
 }
 
+// PublishResults makes all graded results of an exam visible to students
+// POST /api/v1/exams/:examID/results/publish
+func (h *ExamHandler) PublishResults(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	published, err := h.examService.PublishResults(c.Request().Context(), collegeID, examID)
+	if err != nil {
+		return helpers.Error(c, err.Error(), 400)
+	}
+
+	return helpers.Success(c, map[string]int{"published": published}, 200)
+}
+
 // GetStudentResults retrieves all exam results for a student
-// GET /api/v1/students/:studentID/exam-results
+// GET /api/v1/students/:studentID/exam-results?course_id=&result=&from=&to=&sort=&order=
+// from and to are dates (YYYY-MM-DD) of the exam, both inclusive; sort is one
+// of marks, percentage, created_at or published_at.
+// With ?cursor=&limit= the newest-first results are paged by cursor instead
+// and returned as {"results": [...], "next_cursor": "..."}; sort is not
+// allowed then.
 func (h *ExamHandler) GetStudentResults(c echo.Context) error {
 	collegeID, err := helpers.ExtractCollegeID(c)
 	if err != nil {
@@ -566,8 +1483,60 @@ func (h *ExamHandler) GetStudentResults(c echo.Context) error {
 		return helpers.Error(c, "invalid student ID", 400)
 	}
 
-	results, err := h.examService.GetStudentResults(c.Request().Context(), studentID, collegeID)
+	filter := models.ExamResultFilter{
+		SortBy:    c.QueryParam("sort"),
+		SortOrder: c.QueryParam("order"),
+	}
+	if raw := c.QueryParam("course_id"); raw != "" {
+		courseID, err := strconv.Atoi(raw)
+		if err != nil || courseID <= 0 {
+			return helpers.Error(c, "invalid course_id", 400)
+		}
+		filter.CourseID = &courseID
+	}
+	if result := c.QueryParam("result"); result != "" {
+		filter.Result = &result
+	}
+	if raw := c.QueryParam("from"); raw != "" {
+		from, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return helpers.Error(c, "invalid from date, expected YYYY-MM-DD", 400)
+		}
+		filter.From = &from
+	}
+	if raw := c.QueryParam("to"); raw != "" {
+		to, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return helpers.Error(c, "invalid to date, expected YYYY-MM-DD", 400)
+		}
+		// The filter's upper bound is exclusive, so include the whole day
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+
+	role, _ := helpers.GetUserRole(c)
+	if cursor, ok := cursorParam(c); ok {
+		limit := 50
+		if l := c.QueryParam("limit"); l != "" {
+			if parsed, err := strconv.Atoi(l); err == nil {
+				limit = parsed
+			}
+		}
+		results, next, err := h.examService.GetStudentResultsAfter(c.Request().Context(), studentID, collegeID, role == "student", filter, cursor, limit)
+		if err != nil {
+			if errors.Is(err, exam.ErrInvalidResultFilter) || errors.Is(err, exam.ErrInvalidCursor) {
+				return helpers.Error(c, err.Error(), 400)
+			}
+			return helpers.Error(c, err.Error(), 500)
+		}
+		return helpers.Success(c, map[string]any{"results": results, "next_cursor": next}, 200)
+	}
+
+	results, err := h.examService.GetStudentResults(c.Request().Context(), studentID, collegeID, role == "student", filter)
 	if err != nil {
+		if errors.Is(err, exam.ErrInvalidResultFilter) {
+			return helpers.Error(c, err.Error(), 400)
+		}
 		return helpers.Error(c, err.Error(), 500)
 	}
 
@@ -575,8 +1544,13 @@ func (h *ExamHandler) GetStudentResults(c echo.Context) error {
 }
 
 // BulkGradeResults grades multiple exam results at once
-// POST /api/v1/exams/:examID/bulk-grade
+// POST /api/v1/exams/:examID/bulk-grade?mode=all_or_nothing|partial
 func (h *ExamHandler) BulkGradeResults(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
 	examID, err := strconv.Atoi(c.Param("examID"))
 	if err != nil {
 		return helpers.Error(c, "invalid exam ID", 400)
@@ -586,15 +1560,87 @@ func (h *ExamHandler) BulkGradeResults(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return helpers.Error(c, "invalid request body", 400)
 	}
+	if msg, over := helpers.ExceedsBulkLimit(c, len(req), "results"); over {
+		return helpers.Error(c, msg, 400)
+	}
+
+	mode := exam.BulkGradeMode(c.QueryParam("mode"))
+	report, err := h.examService.BulkGradeResults(c.Request().Context(), collegeID, examID, req, mode)
+	if err != nil {
+		return helpers.Error(c, err.Error(), 400)
+	}
+
+	// An all-or-nothing batch that was rolled back is a client error; the
+	// report says which students caused it
+	if !report.Committed && report.Failed > 0 {
+		return helpers.Error(c, map[string]any{
+			"message": "no results were saved",
+			"report":  report,
+		}, 422)
+	}
+
+	return helpers.Success(c, report, 200)
+}
+
+// ImportResults grades an exam from an uploaded CSV with the columns
+// roll_no (or student_id), marks_obtained, remarks and reports the outcome of
+// every line
+// POST /api/v1/exams/:examID/results/import?mode=all_or_nothing|partial
+func (h *ExamHandler) ImportResults(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return helpers.Error(c, "file is required", 400)
+	}
+	src, err := file.Open()
+	if err != nil {
+		return helpers.Error(c, "failed to open file", 500)
+	}
+	defer src.Close()
+
+	reader := csv.NewReader(src)
+	// Short rows are reported per line instead of rejecting the whole file
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return helpers.Error(c, "failed to parse CSV", 400)
+	}
+	if len(records) > 0 {
+		if msg, over := helpers.ExceedsBulkLimit(c, len(records)-1, "results"); over {
+			return helpers.Error(c, msg, 400)
+		}
+	}
 
-	if err := h.examService.BulkGradeResults(c.Request().Context(), examID, req); err != nil {
+	mode := exam.BulkGradeMode(c.QueryParam("mode"))
+	report, err := h.examService.ImportResults(c.Request().Context(), collegeID, examID, records, mode)
+	if err != nil {
+		if errors.Is(err, exam.ErrExamNotFound) {
+			return helpers.Error(c, err.Error(), 404)
+		}
 		return helpers.Error(c, err.Error(), 400)
 	}
 
-	return helpers.Success(c, "results graded successfully", 200)
+	if !report.Committed && report.Failed > 0 {
+		return helpers.Error(c, map[string]any{
+			"message": "no results were saved",
+			"report":  report,
+		}, 422)
+	}
+
+	return helpers.Success(c, report, 200)
 }
 
-// GetResultStats retrieves statistics for exam results
+// GetResultStats retrieves statistics for exam results. With
+// ?group_by=section it also breaks them down per section.
 // GET /api/v1/exams/:examID/result-stats
 func (h *ExamHandler) GetResultStats(c echo.Context) error {
 	examID, err := strconv.Atoi(c.Param("examID"))
@@ -602,6 +1648,17 @@ func (h *ExamHandler) GetResultStats(c echo.Context) error {
 		return helpers.Error(c, "invalid exam ID", 400)
 	}
 
+	if groupBy := c.QueryParam("group_by"); groupBy != "" {
+		grouped, err := h.examService.GetGroupedResultStats(c.Request().Context(), examID, groupBy)
+		if err != nil {
+			if errors.Is(err, exam.ErrInvalidStatsGrouping) {
+				return helpers.Error(c, err.Error(), 400)
+			}
+			return helpers.Error(c, err.Error(), 500)
+		}
+		return helpers.Success(c, grouped, 200)
+	}
+
 	stats, err := h.examService.GetResultStats(c.Request().Context(), examID)
 	if err != nil {
 		return helpers.Error(c, err.Error(), 500)
@@ -614,7 +1671,11 @@ func (h *ExamHandler) GetResultStats(c echo.Context) error {
 // Revaluation Handlers
 // ===========================
 
-// CreateRevaluationRequest creates a revaluation request
+// CreateRevaluationRequest lets a student ask for one of their own published
+// results to be revalued within the revaluation window. Admins can file a
+// request for a student, and only they may override the deadline. When the
+// college charges a revaluation fee it answers 402 with the fee assignment to
+// pay and the amount due until the fee is paid; the request is then sent again.
 // POST /api/v1/revaluation-requests
 func (h *ExamHandler) CreateRevaluationRequest(c echo.Context) error {
 	collegeID, err := helpers.ExtractCollegeID(c)
@@ -623,54 +1684,124 @@ func (h *ExamHandler) CreateRevaluationRequest(c echo.Context) error {
 	}
 
 	var req struct {
-		ExamResultID  int     `json:"exam_result_id"`
-		StudentID     int     `json:"student_id"`
-		Reason        string  `json:"reason"`
-		PreviousMarks float64 `json:"previous_marks"`
+		ExamResultID     int    `json:"exam_result_id"`
+		StudentID        int    `json:"student_id"` // Admin only: the student the request is for
+		Reason           string `json:"reason"`
+		OverrideDeadline bool   `json:"override_deadline"` // Admin only: accept the request after the deadline
 	}
 	if err := c.Bind(&req); err != nil {
 		return helpers.Error(c, "invalid request body", 400)
 	}
 
-	request := &models.RevaluationRequest{
-		ExamResultID:  req.ExamResultID,
-		StudentID:     req.StudentID,
-		CollegeID:     collegeID,
-		Reason:        req.Reason,
-		PreviousMarks: req.PreviousMarks,
-		Status:        "pending",
-		RequestedAt:   time.Now(),
+	studentID := req.StudentID
+	if role, err := helpers.GetUserRole(c); err == nil && role == "admin" {
+		if studentID == 0 {
+			return helpers.Error(c, "student_id is required", 400)
+		}
+	} else {
+		if req.OverrideDeadline {
+			return helpers.Error(c, "only admins can override the revaluation deadline", 403)
+		}
+		if studentID, err = helpers.ExtractStudentID(c); err != nil {
+			return err
+		}
 	}
+	opts := exam.CreateRevaluationOptions{OverrideDeadline: req.OverrideDeadline}
 
-	if err := h.examService.CreateRevaluationRequest(c.Request().Context(), request); err != nil {
+	request := &models.RevaluationRequest{
+		ExamResultID: req.ExamResultID,
+		StudentID:    studentID,
+		CollegeID:    collegeID,
+		Reason:       req.Reason,
+		Status:       "pending",
+		RequestedAt:  time.Now(),
+	}
+
+	if err := h.examService.CreateRevaluationRequest(c.Request().Context(), request, opts); err != nil {
+		var due *exam.RevaluationFeeDueError
+		switch {
+		case errors.As(err, &due):
+			return helpers.Error(c, map[string]any{
+				"message":           "pay the revaluation fee to submit this request",
+				"fee_assignment_id": due.FeeAssignmentID,
+				"amount_due":        due.AmountDue,
+			}, 402)
+		case errors.Is(err, exam.ErrExamResultNotFound):
+			return helpers.ResourceNotFound(c, "result")
+		}
 		return helpers.Error(c, err.Error(), 400)
 	}
 
 	return helpers.Success(c, request, 201)
 }
 
-// ListRevaluationRequests lists revaluation requests
-// GET /api/v1/revaluation-requests
+// ListMyRevaluationRequests lists the calling student's revaluation requests
+// with their status and, once decided, the revised marks and comments
+// GET /api/v1/revaluation-requests/mine
+func (h *ExamHandler) ListMyRevaluationRequests(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+	studentID, err := helpers.ExtractStudentID(c)
+	if err != nil {
+		return err
+	}
+
+	requests, err := h.examService.ListStudentRevaluationRequests(c.Request().Context(), collegeID, studentID)
+	if err != nil {
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, requests, 200)
+}
+
+// ListRevaluationRequests lists revaluation requests with the exam title,
+// student name and roll number, and current marks of each
+// GET /api/v1/revaluation-requests?status=&student_id=&exam_id=&from=&to=
 func (h *ExamHandler) ListRevaluationRequests(c echo.Context) error {
 	collegeID, err := helpers.ExtractCollegeID(c)
 	if err != nil {
 		return err
 	}
 
-	filters := make(map[string]any)
+	var filter models.RevaluationRequestFilter
 	if status := c.QueryParam("status"); status != "" {
-		filters["status"] = status
+		filter.Status = &status
 	}
 	if studentID := c.QueryParam("student_id"); studentID != "" {
 		if id, err := strconv.Atoi(studentID); err == nil {
-			filters["student_id"] = id
+			filter.StudentID = &id
 		}
 	}
 	if contextStudentID, ok := c.Get("student_id").(int); ok && contextStudentID > 0 {
-		filters["student_id"] = contextStudentID
+		filter.StudentID = &contextStudentID
+	}
+	if raw := c.QueryParam("exam_id"); raw != "" {
+		examID, err := strconv.Atoi(raw)
+		if err != nil || examID <= 0 {
+			return helpers.Error(c, "invalid exam_id", 400)
+		}
+		filter.ExamID = &examID
+	}
+	if raw := c.QueryParam("from"); raw != "" {
+		from, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return helpers.Error(c, "invalid from date, expected YYYY-MM-DD", 400)
+		}
+		filter.From = &from
+	}
+	if raw := c.QueryParam("to"); raw != "" {
+		to, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return helpers.Error(c, "invalid to date, expected YYYY-MM-DD", 400)
+		}
+		// The filter's upper bound is exclusive, so include the whole day
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
 	}
 
-	requests, err := h.examService.ListRevaluationRequests(c.Request().Context(), collegeID, filters)
+	requests, err := h.examService.ListRevaluationRequestDetails(c.Request().Context(), collegeID, filter)
 	if err != nil {
 		return helpers.Error(c, err.Error(), 500)
 	}
@@ -774,7 +1905,7 @@ func (h *ExamHandler) GetRoom(c echo.Context) error {
 
 	room, err := h.examService.GetRoom(c.Request().Context(), collegeID, roomID)
 	if err != nil {
-		return helpers.Error(c, "room not found", 404)
+		return helpers.LookupError(c, err, "room", exam.ErrExamRoomNotFound)
 	}
 
 	return helpers.Success(c, room, 200)
@@ -868,3 +1999,43 @@ func (h *ExamHandler) CheckRoomAvailability(c echo.Context) error {
 
 	return helpers.Success(c, map[string]bool{"available": available}, 200)
 }
+
+// GetRoomUtilization reports how heavily each exam room was booked between
+// two dates, busiest room first
+// GET /api/v1/exam-rooms/utilization?start_date=YYYY-MM-DD&end_date=YYYY-MM-DD
+// end_date is inclusive.
+func (h *ExamHandler) GetRoomUtilization(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	startDate, err := time.Parse("2006-01-02", c.QueryParam("start_date"))
+	if err != nil {
+		return helpers.Error(c, "start_date is required as YYYY-MM-DD", 400)
+	}
+	endDate, err := time.Parse("2006-01-02", c.QueryParam("end_date"))
+	if err != nil {
+		return helpers.Error(c, "end_date is required as YYYY-MM-DD", 400)
+	}
+	// Include the whole of the last day
+	endDate = endDate.AddDate(0, 0, 1)
+
+	rooms, err := h.examService.GetRoomUtilization(c.Request().Context(), collegeID, startDate, endDate)
+	if err != nil {
+		if errors.Is(err, exam.ErrInvalidUtilizationWindow) {
+			return helpers.Error(c, err.Error(), 400)
+		}
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, rooms, 200)
+}
+
+// requireExam checks the exam is in the caller's college. Enrollments and
+// results are looked up by exam ID alone, so routes that address them call
+// it first to keep other colleges' exams out of reach.
+func (h *ExamHandler) requireExam(c echo.Context, collegeID, examID int) error {
+	_, err := h.examService.GetExam(c.Request().Context(), collegeID, examID)
+	return err
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/services/exam"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scopedExamService holds one exam of college 1. Like the repository it only
+// finds the exam when asked for it with that college.
+type scopedExamService struct {
+	exam.ExamService
+	getErr           error
+	enrollmentLoaded bool
+}
+
+func (s *scopedExamService) GetExam(ctx context.Context, collegeID, examID int) (*models.Exam, error) {
+	if s.getErr != nil {
+		return nil, s.getErr
+	}
+	if collegeID != 1 || examID != 4 {
+		return nil, exam.ErrExamNotFound
+	}
+	return &models.Exam{ID: 4, CollegeID: 1}, nil
+}
+
+func (s *scopedExamService) GetEnrollment(ctx context.Context, examID, studentID int) (*models.ExamEnrollment, error) {
+	s.enrollmentLoaded = true
+	return &models.ExamEnrollment{ExamID: examID, StudentID: studentID, CollegeID: 1}, nil
+}
+
+func (s *scopedExamService) GetResult(ctx context.Context, examID, studentID int) (*models.ExamResult, error) {
+	return &models.ExamResult{ExamID: examID, StudentID: studentID, CollegeID: 1, Published: true}, nil
+}
+
+func examRequest(method string, collegeID int, names, values []string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(method, "/", nil), rec)
+	c.SetParamNames(names...)
+	c.SetParamValues(values...)
+	c.Set("college_id", collegeID)
+	return c, rec
+}
+
+func TestExamHandlerCrossCollegeAccess(t *testing.T) {
+	t.Run("exam of another college is not found", func(t *testing.T) {
+		h := NewExamHandler(&scopedExamService{}, nil)
+		c, rec := examRequest(http.MethodGet, 2, []string{"examID"}, []string{"4"})
+
+		require.NoError(t, h.GetExam(c))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), "exam not found")
+	})
+
+	t.Run("own exam is returned", func(t *testing.T) {
+		h := NewExamHandler(&scopedExamService{}, nil)
+		c, rec := examRequest(http.MethodGet, 1, []string{"examID"}, []string{"4"})
+
+		require.NoError(t, h.GetExam(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("database errors are not reported as not found", func(t *testing.T) {
+		h := NewExamHandler(&scopedExamService{getErr: errors.New("connection reset")}, nil)
+		c, rec := examRequest(http.MethodGet, 1, []string{"examID"}, []string{"4"})
+
+		require.NoError(t, h.GetExam(c))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "connection reset")
+	})
+
+	t.Run("result of another college is not found", func(t *testing.T) {
+		h := NewExamHandler(&scopedExamService{}, nil)
+		c, rec := examRequest(http.MethodGet, 2, []string{"examID", "studentID"}, []string{"4", "7"})
+
+		require.NoError(t, h.GetResult(c))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), "result not found")
+	})
+
+	t.Run("enrollments of another college's exam are out of reach", func(t *testing.T) {
+		svc := &scopedExamService{}
+		h := NewExamHandler(svc, nil)
+		c, rec := examRequest(http.MethodPut, 2, []string{"examID", "studentID"}, []string{"4", "7"})
+
+		require.NoError(t, h.UpdateEnrollment(c))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.False(t, svc.enrollmentLoaded)
+	})
+}
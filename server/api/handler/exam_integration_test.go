@@ -0,0 +1,99 @@
+//go:build integration
+
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/repository"
+	"eduhub/server/internal/services/exam"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+)
+
+func TestExamAllowedMaterialsRoundTripIntegration(t *testing.T) {
+	ctx, db, pool := setupIntegrationDB(t, "users", "colleges", "courses", "exams")
+	fixture, cleanup := seedIntegrationFixture(t, ctx, pool)
+	defer cleanup()
+
+	service := exam.NewExamService(repository.NewExamRepository(db), nil, nil, nil, nil, 0, 0, 0, nil, nil, nil, nil, zerolog.Nop())
+	handler := NewExamHandler(service, nil)
+	e := echo.New()
+
+	start := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Minute)
+	body, _ := json.Marshal(map[string]any{
+		"course_id":           fixture.CourseID,
+		"title":               "Materials Round Trip",
+		"exam_type":           "final",
+		"start_time":          start,
+		"end_time":            start.Add(3 * time.Hour),
+		"duration":            180,
+		"total_marks":         100,
+		"passing_marks":       40,
+		"allowed_materials":   []string{"Calculator", " Formula sheet ", "Periodic table"},
+		"question_paper_sets": 3,
+	})
+
+	createReq := httptest.NewRequest(http.MethodPost, "/api/exams", bytes.NewReader(body))
+	createReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	createRec := httptest.NewRecorder()
+	createCtx := e.NewContext(createReq, createRec)
+	createCtx.Set("college_id", fixture.CollegeID)
+	createCtx.Set("user_id", fixture.FacultyUserID)
+
+	if err := handler.CreateExam(createCtx); err != nil {
+		t.Fatalf("CreateExam returned error: %v", err)
+	}
+	if createRec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", createRec.Code, createRec.Body.String())
+	}
+
+	var created struct {
+		Data struct {
+			ID int `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(createRec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("failed decoding create response: %v", err)
+	}
+
+	getReq := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/exams/%d", created.Data.ID), nil)
+	getRec := httptest.NewRecorder()
+	getCtx := e.NewContext(getReq, getRec)
+	getCtx.SetParamNames("examID")
+	getCtx.SetParamValues(strconv.Itoa(created.Data.ID))
+	getCtx.Set("college_id", fixture.CollegeID)
+
+	if err := handler.GetExam(getCtx); err != nil {
+		t.Fatalf("GetExam returned error: %v", err)
+	}
+	if getRec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", getRec.Code, getRec.Body.String())
+	}
+
+	var fetched struct {
+		Data struct {
+			AllowedMaterials  []string `json:"allowed_materials"`
+			QuestionPaperSets int      `json:"question_paper_sets"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(getRec.Body.Bytes(), &fetched); err != nil {
+		t.Fatalf("failed decoding get response: %v", err)
+	}
+
+	want := []string{"Calculator", "Formula sheet", "Periodic table"}
+	if fmt.Sprint(fetched.Data.AllowedMaterials) != fmt.Sprint(want) {
+		t.Fatalf("expected allowed materials %v, got %v", want, fetched.Data.AllowedMaterials)
+	}
+	if fetched.Data.QuestionPaperSets != 3 {
+		t.Fatalf("expected 3 question paper sets, got %d", fetched.Data.QuestionPaperSets)
+	}
+}
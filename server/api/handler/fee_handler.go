@@ -2,15 +2,18 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"strconv"
 
+	"eduhub/server/internal/helpers"
 	"eduhub/server/internal/middleware"
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/services/fee"
 
 	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
 )
 
 type FeeHandler struct {
@@ -107,7 +110,11 @@ func (h *FeeHandler) AssignFeeToStudent(c echo.Context) error {
 		return err
 	}
 
-	if err := h.feeService.AssignFeeToStudent(c.Request().Context(), &req); err != nil {
+	collegeID := c.Get("college_id").(int)
+	if err := h.feeService.AssignFeeToStudent(c.Request().Context(), &req, collegeID); err != nil {
+		if errors.Is(err, fee.ErrStudentNotInCollege) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to assign fee: "+err.Error())
 	}
 
@@ -121,8 +128,15 @@ func (h *FeeHandler) BulkAssignFee(c echo.Context) error {
 	if err := middleware.BindAndValidate(c, &req); err != nil {
 		return err
 	}
+	if msg, over := helpers.ExceedsBulkLimit(c, len(req.StudentIDs), "students"); over {
+		return echo.NewHTTPError(http.StatusBadRequest, msg)
+	}
 
-	if err := h.feeService.BulkAssignFeeToStudents(c.Request().Context(), &req); err != nil {
+	collegeID := c.Get("college_id").(int)
+	if err := h.feeService.BulkAssignFeeToStudents(c.Request().Context(), &req, collegeID); err != nil {
+		if errors.Is(err, fee.ErrStudentNotInCollege) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to bulk assign fee: "+err.Error())
 	}
 
@@ -157,6 +171,28 @@ func (h *FeeHandler) GetStudentFeesSummary(c echo.Context) error {
 	})
 }
 
+// GetStudentLedger returns a student's charges, payments and outstanding
+// balance for admins of the student's college
+func (h *FeeHandler) GetStudentLedger(c echo.Context) error {
+	studentID, err := strconv.Atoi(c.Param("studentID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid student ID")
+	}
+
+	collegeID := c.Get("college_id").(int)
+	ledger, err := h.feeService.GetStudentLedger(c.Request().Context(), studentID, collegeID)
+	if err != nil {
+		if errors.Is(err, fee.ErrStudentNotInCollege) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get student ledger: "+err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"data": ledger,
+	})
+}
+
 // Fee Payment Management
 
 func (h *FeeHandler) MakeFeePayment(c echo.Context) error {
@@ -170,6 +206,9 @@ func (h *FeeHandler) MakeFeePayment(c echo.Context) error {
 
 	payment, err := h.feeService.MakeFeePayment(c.Request().Context(), &req, studentID, &userID)
 	if err != nil {
+		if errors.Is(err, fee.ErrOverpayment) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to make payment: "+err.Error())
 	}
 
@@ -189,6 +228,9 @@ func (h *FeeHandler) InitiateOnlinePayment(c echo.Context) error {
 
 	response, err := h.feeService.InitiateOnlinePayment(c.Request().Context(), &req, studentID)
 	if err != nil {
+		if errors.Is(err, fee.ErrOverpayment) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to initiate payment: "+err.Error())
 	}
 
@@ -214,6 +256,7 @@ func (h *FeeHandler) VerifyPayment(c echo.Context) error {
 
 // HandleWebhook processes Razorpay webhook events with proper signature verification.
 // Security: Implements HMAC-SHA256 signature verification to prevent fraudulent webhook calls.
+// The route is public since Razorpay cannot authenticate; the signature is the only check.
 func (h *FeeHandler) HandleWebhook(c echo.Context) error {
 	// Read raw body for signature verification (must be done before any parsing)
 	body, err := io.ReadAll(c.Request().Body)
@@ -233,7 +276,7 @@ func (h *FeeHandler) HandleWebhook(c echo.Context) error {
 
 	// Verify the signature
 	if !h.feeService.VerifyWebhookSignature(body, signature) {
-		return c.JSON(http.StatusUnauthorized, map[string]string{
+		return c.JSON(http.StatusBadRequest, map[string]string{
 			"error": "Invalid webhook signature",
 		})
 	}
@@ -253,7 +296,7 @@ func (h *FeeHandler) HandleWebhook(c echo.Context) error {
 	// Process the webhook event
 	if err := h.feeService.ProcessWebhookEvent(c.Request().Context(), payload.Event, payload.Payload); err != nil {
 		// Log the error but return 200 to prevent Razorpay from retrying indefinitely
-		// In production, you'd want to log this to a monitoring service
+		log.Error().Err(err).Str("event", payload.Event).Msg("failed to process Razorpay webhook")
 		return c.JSON(http.StatusOK, map[string]string{
 			"status": "acknowledged",
 			"note":   "Event processing had issues but acknowledged",
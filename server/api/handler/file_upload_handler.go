@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"eduhub/server/internal/helpers"
 	"eduhub/server/internal/services/storage"
@@ -113,17 +116,64 @@ func (h *FileUploadHandler) DeleteFile(c echo.Context) error {
 	return helpers.Success(c, "File deleted successfully", 200)
 }
 
-// GetFileURL generates a presigned URL for file download
+// GetFileURL generates a presigned URL for file download.
+// Kept for older clients; it applies the same access checks as GetPresignedURL.
 func (h *FileUploadHandler) GetFileURL(c echo.Context) error {
+	return h.GetPresignedURL(c)
+}
+
+// GetPresignedURL returns a time-limited download URL for the object in the
+// "key" query parameter. The optional "expires_in" parameter asks for a
+// lifetime in seconds up to the configured maximum. Admins and faculty may
+// read any object in their college; other users only objects they own.
+func (h *FileUploadHandler) GetPresignedURL(c echo.Context) error {
 	objectKey := c.QueryParam("key")
 	if objectKey == "" {
 		return helpers.Error(c, "object key is required", 400)
 	}
 
-	url, err := h.storageService.GetFileURL(c.Request().Context(), objectKey)
+	var expiry time.Duration
+	if raw := c.QueryParam("expires_in"); raw != "" {
+		seconds, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || seconds <= 0 {
+			return helpers.Error(c, "expires_in must be a positive number of seconds", 400)
+		}
+		expiry = time.Duration(seconds) * time.Second
+	}
+
+	collegeID, err := helpers.ExtractCollegeID(c)
 	if err != nil {
-		return helpers.Error(c, err.Error(), 500)
+		return err
+	}
+
+	role, err := helpers.GetUserRole(c)
+	if err != nil {
+		return err
+	}
+
+	access := storage.ObjectAccess{
+		CollegeID:  collegeID,
+		Privileged: role == "admin" || role == "faculty",
+	}
+	// Read user_id directly: ExtractUserID falls back to the student ID, which is a different key space
+	if userID, ok := c.Get("user_id").(int); ok {
+		access.UserID = userID
+	}
+	// Set by LoadStudentProfile for students only
+	if studentID, ok := c.Get("student_id").(int); ok {
+		access.StudentID = studentID
+	}
+
+	url, err := h.storageService.GetPresignedURL(c.Request().Context(), objectKey, access, expiry)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrInvalidObjectKey), errors.Is(err, storage.ErrInvalidURLExpiry):
+			return helpers.Error(c, err.Error(), 400)
+		case errors.Is(err, storage.ErrObjectAccessDenied):
+			return helpers.Error(c, err.Error(), 403)
+		}
+		return helpers.Error(c, "failed to generate download URL", 500)
 	}
 
-	return helpers.Success(c, map[string]string{"url": url}, 200)
+	return helpers.Success(c, url, 200)
 }
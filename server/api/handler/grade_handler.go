@@ -9,6 +9,7 @@ import (
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/services/course"
 	"eduhub/server/internal/services/grades"
+	"eduhub/server/internal/validation"
 
 	"github.com/labstack/echo/v4"
 )
@@ -79,6 +80,9 @@ func (h *GradeHandler) CreateAssessment(c echo.Context) error {
 
 	err = h.gradeService.CreateGrade(c.Request().Context(), &grade)
 	if err != nil {
+		if fields := validation.Fields(err); fields != nil {
+			return helpers.ValidationError(c, fields)
+		}
 		return helpers.Error(c, err.Error(), 400)
 	}
 
@@ -11,6 +11,7 @@ type Handlers struct {
 	Student           *StudentHandler
 	College           *CollegeHandler
 	Course            *CourseHandler
+	Section           *SectionHandler
 	CourseMaterial    *CourseMaterialHandler
 	Lecture           *LectureHandler
 	Quiz              *QuizHandler
@@ -33,20 +34,32 @@ type Handlers struct {
 	Batch             *BatchHandler
 	Report            *ReportHandler
 	Webhook           *WebhookHandler
+	Email             *EmailHandler
 	Audit             *AuditHandler
 	Role              *RoleHandler
 	Fee               *FeeHandler
 	Timetable         *TimetableHandler
 	Exam              *ExamHandler
+	QuestionPaper     *QuestionPaperHandler
 	Placement         *PlacementHandler
 	Forum             *ForumHandler
 	Parent            *ParentHandler
+	ParentAlert       *ParentAlertHandler
 	SelfService       *SelfServiceHandler
 	FacultyTools      *FacultyToolsHandler
 	Settings          *SettingsHandler
+	Search            *SearchHandler
+	// Metrics is nil when the metrics endpoint is disabled
+	Metrics *MetricsHandler
 }
 
 func NewHandlers(services *services.Services) *Handlers {
+	// Avoid wrapping a nil *RedisCache in a non-nil interface
+	var redisPinger DependencyPinger
+	if services.RedisCache != nil {
+		redisPinger = services.RedisCache
+	}
+
 	return &Handlers{
 		Auth: NewAuthHandler(services.Auth),
 		Dashboard: NewDashboardHandler(
@@ -65,6 +78,7 @@ func NewHandlers(services *services.Services) *Handlers {
 		Student:           NewStudentHandler(services.StudentService),
 		College:           NewCollegeHandler(services.CollegeService),
 		Course:            NewCourseHandler(services.CourseService, services.EnrollmentService, services.StudentService),
+		Section:           NewSectionHandler(services.SectionService),
 		CourseMaterial:    NewCourseMaterialHandler(services.CourseMaterialService),
 		Lecture:           NewLectureHandler(services.LectureService),
 		Quiz:              NewQuizHandler(services.QuizService, services.EnrollmentService, services.CourseService),
@@ -75,7 +89,7 @@ func NewHandlers(services *services.Services) *Handlers {
 		User:              NewUserHandler(services.UserService),
 		Announcement:      NewAnnouncementHandler(services.AnnouncementService),
 		Profile:           NewProfileHandler(services.ProfileService, services.AuditService, services.StorageService),
-		System:            NewSystemHandler(services.DB),
+		System:            NewSystemHandler(services.DB, redisPinger),
 		Question:          NewQuestionHandler(services.QuestionService),
 		QuizAttempt:       NewQuizAttemptHandler(services.QuizAttemptService),
 		FileUpload:        NewFileUploadHandler(services.StorageService),
@@ -87,11 +101,13 @@ func NewHandlers(services *services.Services) *Handlers {
 		Batch:             NewBatchHandler(services.BatchService),
 		Report:            NewReportHandler(services.ReportService),
 		Webhook:           NewWebhookHandler(services.WebhookService),
+		Email:             NewEmailHandler(services.EmailDeliveryService),
 		Audit:             NewAuditHandler(services.AuditService),
 		Role:              NewRoleHandler(services.RoleService),
 		Fee:               NewFeeHandler(services.FeeService),
 		Timetable:         NewTimetableHandler(services.TimetableService),
-		Exam:              NewExamHandler(services.ExamService),
+		Exam:              NewExamHandler(services.ExamService, services.AuditService),
+		QuestionPaper:     NewQuestionPaperHandler(services.QuestionPaperService, services.AuditService),
 		Placement:         NewPlacementHandler(services.PlacementService),
 		Forum:             NewForumHandler(services.ForumService),
 		Parent: NewParentHandler(
@@ -100,10 +116,15 @@ func NewHandlers(services *services.Services) *Handlers {
 			services.GradeService,
 			services.AssignmentService,
 			services.EmailService,
+			services.EmailDeliveryService,
+			services.FeeService,
+			services.AnalyticsService,
 			services.DB,
 		),
+		ParentAlert:  NewParentAlertHandler(services.ParentAlertService),
 		SelfService:  NewSelfServiceHandler(services.SelfServiceService),
 		FacultyTools: NewFacultyToolsHandler(services.FacultyToolsService),
 		Settings:     NewSettingsHandler(services.SettingsService),
+		Search:       NewSearchHandler(services.SearchService),
 	}
 }
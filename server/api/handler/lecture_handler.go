@@ -6,6 +6,7 @@ import (
 	"eduhub/server/internal/helpers"
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/services/lecture"
+	"eduhub/server/internal/validation"
 
 	"github.com/labstack/echo/v4"
 )
@@ -39,6 +40,9 @@ func (h *LectureHandler) UpdateLecture(c echo.Context) error {
 
 	err = h.lectureService.UpdateLecturePartial(c.Request().Context(), collegeID, lectureID, &req)
 	if err != nil {
+		if fields := validation.Fields(err); fields != nil {
+			return helpers.ValidationError(c, fields)
+		}
 		return helpers.Error(c, err.Error(), 500)
 	}
 
@@ -130,6 +134,9 @@ func (h *LectureHandler) CreateLecture(c echo.Context) error {
 
 	err = h.lectureService.CreateLecture(c.Request().Context(), lecture)
 	if err != nil {
+		if fields := validation.Fields(err); fields != nil {
+			return helpers.ValidationError(c, fields)
+		}
 		return helpers.Error(c, err.Error(), 500)
 	}
 
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"eduhub/server/internal/helpers"
+	"eduhub/server/internal/metrics"
+
+	"github.com/labstack/echo/v4"
+)
+
+type MetricsHandler struct {
+	registry *metrics.Registry
+	token    string
+}
+
+// NewMetricsHandler creates a metrics handler. When token is non-empty,
+// scrapers must send it as a bearer token.
+func NewMetricsHandler(registry *metrics.Registry, token string) *MetricsHandler {
+	return &MetricsHandler{
+		registry: registry,
+		token:    token,
+	}
+}
+
+// Metrics renders the collected metrics in the Prometheus text format
+func (h *MetricsHandler) Metrics(c echo.Context) error {
+	if !h.authorized(c.Request()) {
+		return helpers.Error(c, "unauthorized", http.StatusUnauthorized)
+	}
+	c.Response().Header().Set(echo.HeaderContentType, metrics.ContentType)
+	c.Response().WriteHeader(http.StatusOK)
+	_, err := h.registry.WriteTo(c.Response())
+	return err
+}
+
+// authorized reports whether the request carries the configured bearer token
+func (h *MetricsHandler) authorized(r *http.Request) bool {
+	if h.token == "" {
+		return true
+	}
+	presented, ok := strings.CutPrefix(r.Header.Get(echo.HeaderAuthorization), "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(presented), []byte(h.token)) == 1
+}
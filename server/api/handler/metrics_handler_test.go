@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"eduhub/server/internal/metrics"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsHandler_Token(t *testing.T) {
+	e := echo.New()
+	h := NewMetricsHandler(metrics.NewRegistry(nil), "scrape-secret")
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{name: "missing token", header: "", want: http.StatusUnauthorized},
+		{name: "wrong token", header: "Bearer nope", want: http.StatusUnauthorized},
+		{name: "valid token", header: "Bearer scrape-secret", want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tt.header != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			require.NoError(t, h.Metrics(e.NewContext(req, rec)))
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"strconv"
 
 	"eduhub/server/internal/helpers"
 	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
 	"eduhub/server/internal/services/notification"
 
 	"github.com/labstack/echo/v4"
@@ -20,7 +22,7 @@ func NewNotificationHandler(notificationService notification.NotificationService
 	}
 }
 
-// GetNotifications retrieves notifications for the current user
+// GetNotifications retrieves notifications for the current user, unread first
 func (h *NotificationHandler) GetNotifications(c echo.Context) error {
 	userID, err := helpers.ExtractUserID(c)
 	if err != nil {
@@ -42,8 +44,15 @@ func (h *NotificationHandler) GetNotifications(c echo.Context) error {
 			limit = l
 		}
 	}
+	offset := 0
+	if offsetStr := c.QueryParam("offset"); offsetStr != "" {
+		o, err := strconv.Atoi(offsetStr)
+		if err == nil {
+			offset = o
+		}
+	}
 
-	notifications, err := h.notificationService.GetUserNotifications(c.Request().Context(), collegeID, userID, unreadOnly, limit)
+	notifications, err := h.notificationService.GetUserNotifications(c.Request().Context(), collegeID, userID, unreadOnly, limit, offset)
 	if err != nil {
 		return helpers.Error(c, err.Error(), 500)
 	}
@@ -71,6 +80,9 @@ func (h *NotificationHandler) MarkAsRead(c echo.Context) error {
 
 	err = h.notificationService.MarkAsRead(c.Request().Context(), collegeID, notificationID, userID)
 	if err != nil {
+		if errors.Is(err, repository.ErrNotificationNotFound) {
+			return helpers.Error(c, err.Error(), 404)
+		}
 		return helpers.Error(c, err.Error(), 500)
 	}
 
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"eduhub/server/internal/helpers"
+	"eduhub/server/internal/services/parentalert"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ParentAlertHandler exposes manual triggers for scheduled parent alerts
+type ParentAlertHandler struct {
+	alertService parentalert.ParentAlertService
+}
+
+// NewParentAlertHandler creates a new ParentAlertHandler
+func NewParentAlertHandler(alertService parentalert.ParentAlertService) *ParentAlertHandler {
+	return &ParentAlertHandler{alertService: alertService}
+}
+
+// RunLowAttendanceAlerts godoc
+// @Summary Run low-attendance parent alerts now
+// @Description Emails opted-in parents of students in the admin's college whose recent attendance is below the configured threshold. Parents alerted within the cooldown window are skipped.
+// @Tags Parent Portal
+// @Produce json
+// @Success 200 {object} parentalert.RunResult
+// @Failure 500 {object} helpers.ErrorResponse
+// @Router /api/parent/relationships/attendance-alerts/run [post]
+func (h *ParentAlertHandler) RunLowAttendanceAlerts(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.alertService.RunLowAttendanceAlerts(c.Request().Context(), collegeID)
+	if err != nil {
+		return helpers.Error(c, "Failed to run attendance alerts", http.StatusInternalServerError)
+	}
+
+	return helpers.Success(c, result, http.StatusOK)
+}
@@ -2,9 +2,15 @@ package handler
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
+	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -12,15 +18,18 @@ import (
 	"eduhub/server/internal/helpers"
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/repository"
+	"eduhub/server/internal/services/analytics"
 	"eduhub/server/internal/services/assignment"
 	"eduhub/server/internal/services/attendance"
 	"eduhub/server/internal/services/auth"
 	"eduhub/server/internal/services/email"
+	"eduhub/server/internal/services/fee"
 	"eduhub/server/internal/services/grades"
 	"eduhub/server/internal/services/student"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
 )
 
 // ParentHandler handles parent portal related requests
@@ -30,9 +39,21 @@ type ParentHandler struct {
 	gradesService     grades.GradeServices
 	assignmentService assignment.AssignmentService
 	emailService      email.EmailService
+	emailDelivery     email.DeliveryService
+	feeService        fee.FeeService
+	analyticsService  analytics.AnalyticsService
 	db                *repository.DB
+	verificationURL   string
 }
 
+// parentLinkTokenTTL is how long a parent has to confirm a link request
+const parentLinkTokenTTL = 72 * time.Hour
+
+const (
+	defaultParentPageSize = 50
+	maxParentPageSize     = 200
+)
+
 // NewParentHandler creates a new ParentHandler
 func NewParentHandler(
 	studentService student.StudentService,
@@ -40,6 +61,9 @@ func NewParentHandler(
 	gradesService grades.GradeServices,
 	assignmentService assignment.AssignmentService,
 	emailService email.EmailService,
+	emailDelivery email.DeliveryService,
+	feeService fee.FeeService,
+	analyticsService analytics.AnalyticsService,
 	db *repository.DB,
 ) *ParentHandler {
 	return &ParentHandler{
@@ -48,10 +72,19 @@ func NewParentHandler(
 		gradesService:     gradesService,
 		assignmentService: assignmentService,
 		emailService:      emailService,
+		emailDelivery:     emailDelivery,
+		feeService:        feeService,
+		analyticsService:  analyticsService,
 		db:                db,
 	}
 }
 
+// SetVerificationURL sets the client page that parent verification emails
+// link to. The token is appended as a "token" query parameter.
+func (h *ParentHandler) SetVerificationURL(verificationURL string) {
+	h.verificationURL = verificationURL
+}
+
 // GetLinkedChildren godoc
 // @Summary Get linked children for parent
 // @Description Returns a list of students linked to the authenticated parent
@@ -69,13 +102,12 @@ func (h *ParentHandler) GetLinkedChildren(c echo.Context) error {
 		return err
 	}
 
-	students, err := h.studentService.ListStudents(c.Request().Context(), collegeID, 1000, 0)
-	if err != nil {
-		return helpers.Error(c, "Failed to fetch students", http.StatusInternalServerError)
-	}
-
-	linkedStudents := make([]map[string]any, 0, len(students))
+	linkedStudents := make([]map[string]any, 0)
 	if role == "admin" || role == "faculty" {
+		students, err := h.studentService.ListStudents(c.Request().Context(), collegeID, 1000, 0)
+		if err != nil {
+			return helpers.Error(c, "Failed to fetch students", http.StatusInternalServerError)
+		}
 		for _, student := range students {
 			linkedStudents = append(linkedStudents, map[string]any{
 				"id":             student.StudentID,
@@ -93,27 +125,34 @@ func (h *ParentHandler) GetLinkedChildren(c echo.Context) error {
 	if err != nil {
 		return helpers.Error(c, "Unauthorized", http.StatusUnauthorized)
 	}
-	parentUserID, err := h.resolveParentUserID(c.Request().Context(), kratosID)
+	parentUserID, err := h.resolveUserID(c.Request().Context(), kratosID)
 	if err != nil {
 		return helpers.Success(c, map[string]any{
 			"students": linkedStudents,
 		}, http.StatusOK)
 	}
 
-	linkedStudentIDs, err := h.getLinkedStudentIDSet(c.Request().Context(), collegeID, parentUserID)
+	children, err := h.getLinkedStudents(c.Request().Context(), collegeID, parentUserID)
 	if err != nil {
 		return helpers.Error(c, "Failed to fetch linked students", http.StatusInternalServerError)
 	}
 
-	for _, student := range students {
-		if _, ok := linkedStudentIDs[student.StudentID]; !ok {
-			continue
-		}
+	studentIDs := make([]int, 0, len(children))
+	for _, child := range children {
+		studentIDs = append(studentIDs, child.StudentID)
+	}
+	summaries, err := h.getChildSummaries(c.Request().Context(), collegeID, studentIDs)
+	if err != nil {
+		return helpers.Error(c, "Failed to compute dashboard metrics", http.StatusInternalServerError)
+	}
+
+	for _, child := range children {
 		linkedStudents = append(linkedStudents, map[string]any{
-			"id":             student.StudentID,
-			"rollNo":         student.RollNo,
-			"enrollmentYear": student.EnrollmentYear,
-			"isActive":       student.IsActive,
+			"id":             child.StudentID,
+			"rollNo":         child.RollNo,
+			"enrollmentYear": child.EnrollmentYear,
+			"isActive":       child.IsActive,
+			"metrics":        summaries[child.StudentID].toMap(),
 		})
 	}
 
@@ -156,27 +195,16 @@ func (h *ParentHandler) GetChildDashboard(c echo.Context) error {
 		return helpers.NotFound(c, map[string]any{"error": "Student not found"}, http.StatusNotFound)
 	}
 
-	// Return basic student info
-	attendanceRecords, _ := h.attendanceService.GetAttendanceByStudent(c.Request().Context(), collegeID, studentID, 1000, 0)
-	attendanceRate := calculateAttendanceRate(attendanceRecords)
-
-	grades, _ := h.gradesService.GetGradesByStudent(c.Request().Context(), collegeID, studentID)
-	averageGrade := calculateAverageGrade(grades)
-
-	pendingAssignments, err := h.getPendingAssignmentCount(c.Request().Context(), collegeID, studentID)
+	summaries, err := h.getChildSummaries(c.Request().Context(), collegeID, []int{studentID})
 	if err != nil {
 		return helpers.Error(c, "Failed to compute dashboard metrics", http.StatusInternalServerError)
 	}
+	metrics := summaries[studentID].toMap()
+	metrics["enrolledCourses"] = len(student.Enrollments)
 
 	return helpers.Success(c, map[string]any{
 		"student": student,
-		"metrics": map[string]any{
-			"enrolledCourses":    len(student.Enrollments),
-			"attendanceRate":     attendanceRate,
-			"pendingAssignments": pendingAssignments,
-			"averageGrade":       averageGrade,
-			"assessmentsCount":   len(grades),
-		},
+		"metrics": metrics,
 	}, http.StatusOK)
 }
 
@@ -224,9 +252,50 @@ func (h *ParentHandler) GetChildAttendance(c echo.Context) error {
 	}, http.StatusOK)
 }
 
+// GetChildAttendanceTrends godoc
+// @Summary Get child's attendance trend
+// @Description Returns a child's daily attendance over the last two weeks, optionally for one course
+// @Tags Parent Portal
+// @Accept json
+// @Produce json
+// @Param studentID path int true "Student ID"
+// @Param course_id query int false "Course ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} helpers.ErrorResponse
+// @Failure 401 {object} helpers.ErrorResponse
+// @Failure 403 {object} helpers.ErrorResponse
+// @Failure 500 {object} helpers.ErrorResponse
+// @Router /api/parent/children/{studentID}/attendance/trends [get]
+func (h *ParentHandler) GetChildAttendanceTrends(c echo.Context) error {
+	studentID, err := strconv.Atoi(c.Param("studentID"))
+	if err != nil {
+		return helpers.Error(c, "Invalid student ID", http.StatusBadRequest)
+	}
+	courseID, err := parseOptionalInt(c.QueryParam("course_id"))
+	if err != nil {
+		return helpers.Error(c, "Invalid course ID", http.StatusBadRequest)
+	}
+
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	// Verify the parent has access to this student
+	if err := h.verifyParentAccess(c, studentID); err != nil {
+		return err
+	}
+
+	trends, err := h.analyticsService.GetStudentAttendanceTrends(c.Request().Context(), collegeID, studentID, courseID)
+	if err != nil {
+		return helpers.Error(c, "Failed to get attendance trend", http.StatusInternalServerError)
+	}
+	return helpers.Success(c, trends, http.StatusOK)
+}
+
 // GetChildGrades godoc
 // @Summary Get child's grades
-// @Description Returns grades for a specific child
+// @Description Returns a child's grades grouped by course with each course's average and the overall GPA. The raw grades are under "grades".
 // @Tags Parent Portal
 // @Accept json
 // @Produce json
@@ -257,14 +326,31 @@ func (h *ParentHandler) GetChildGrades(c echo.Context) error {
 	grades, err := h.gradesService.GetGradesByStudent(c.Request().Context(), collegeID, studentID)
 	if err != nil {
 		return helpers.Success(c, map[string]any{
-			"grades": []any{},
-			"total":  0,
+			"courses":    []any{},
+			"overallGPA": 0.0,
+			"grades":     []any{},
+			"total":      0,
 		}, http.StatusOK)
 	}
 
+	courseIDs := make([]int, 0, len(grades))
+	for _, grade := range grades {
+		courseIDs = append(courseIDs, grade.CourseID)
+	}
+	courses, err := h.getGradeCourses(c.Request().Context(), collegeID, courseIDs)
+	if err != nil {
+		// The grades are still worth showing without course names
+		log.Warn().Err(err).Int("student_id", studentID).Msg("failed to load courses for child grades")
+		courses = map[int]gradeCourse{}
+	}
+
+	report := summarizeChildGrades(grades, courses)
 	return helpers.Success(c, map[string]any{
-		"grades": grades,
-		"total":  len(grades),
+		"courses":      report.Courses,
+		"overallGPA":   report.OverallGPA,
+		"totalCredits": report.TotalCredits,
+		"grades":       grades,
+		"total":        len(grades),
 	}, http.StatusOK)
 }
 
@@ -312,7 +398,48 @@ func (h *ParentHandler) GetChildAssignments(c echo.Context) error {
 	}, http.StatusOK)
 }
 
-// verifyParentAccess checks if the authenticated user has access to the student's data
+// GetChildFees godoc
+// @Summary Get child's fees
+// @Description Returns a child's fee assignments, payment history and outstanding balance
+// @Tags Parent Portal
+// @Accept json
+// @Produce json
+// @Param studentID path int true "Student ID"
+// @Success 200 {object} models.StudentFeeLedger
+// @Failure 401 {object} helpers.ErrorResponse
+// @Failure 403 {object} helpers.ErrorResponse
+// @Failure 404 {object} helpers.ErrorResponse
+// @Failure 500 {object} helpers.ErrorResponse
+// @Router /api/parent/children/{studentID}/fees [get]
+func (h *ParentHandler) GetChildFees(c echo.Context) error {
+	studentID, err := strconv.Atoi(c.Param("studentID"))
+	if err != nil {
+		return helpers.Error(c, "Invalid student ID", http.StatusBadRequest)
+	}
+
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	// Verify the parent has access to this student
+	if err := h.verifyParentAccess(c, studentID); err != nil {
+		return err
+	}
+
+	ledger, err := h.feeService.GetStudentLedger(c.Request().Context(), studentID, collegeID)
+	if err != nil {
+		if errors.Is(err, fee.ErrStudentNotInCollege) {
+			return helpers.Error(c, "Student not found", http.StatusNotFound)
+		}
+		return helpers.Error(c, "Failed to get fees", http.StatusInternalServerError)
+	}
+
+	return helpers.Success(c, ledger, http.StatusOK)
+}
+
+// verifyParentAccess checks if the authenticated user has access to the student's data.
+// Denials are returned as errors so callers stop before writing the data.
 func (h *ParentHandler) verifyParentAccess(c echo.Context, studentID int) error {
 	role := h.currentRole(c)
 	if role == "admin" || role == "faculty" {
@@ -326,12 +453,12 @@ func (h *ParentHandler) verifyParentAccess(c echo.Context, studentID int) error
 
 	kratosID, err := helpers.GetKratosID(c)
 	if err != nil {
-		return helpers.Error(c, "Unauthorized", http.StatusUnauthorized)
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
 	}
 
-	parentUserID, err := h.resolveParentUserID(c.Request().Context(), kratosID)
+	parentUserID, err := h.resolveUserID(c.Request().Context(), kratosID)
 	if err != nil {
-		return helpers.Error(c, "Forbidden: Parent account is not linked", http.StatusForbidden)
+		return echo.NewHTTPError(http.StatusForbidden, "Forbidden: Parent account is not linked")
 	}
 
 	ctx := c.Request().Context()
@@ -348,18 +475,31 @@ func (h *ParentHandler) verifyParentAccess(c echo.Context, studentID int) error
 		collegeID, parentUserID, studentID,
 	).Scan(&exists)
 	if err != nil {
-		return helpers.Error(c, "Failed to verify parent access", http.StatusInternalServerError)
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to verify parent access")
 	}
 
 	if exists {
 		return nil
 	}
 
-	return helpers.Error(c, "Forbidden: You don't have access to this student's data", http.StatusForbidden)
+	return echo.NewHTTPError(http.StatusForbidden, "Forbidden: You don't have access to this student's data")
 }
 
-// ListParentRelationships returns all parent-student relationships for the admin's college (admin only).
+// ListParentRelationships returns a page of parent-student relationships for the admin's college (admin only).
+// Supports limit/offset pagination and optional student_id, parent_user_id and
+// status (pending or verified) filters.
 func (h *ParentHandler) ListParentRelationships(c echo.Context) error {
+	return h.listParentRelationships(c, c.QueryParam("status"))
+}
+
+// ListPendingParentRelationships returns link requests awaiting verification or
+// admin approval (admin only). It accepts the same pagination and filters as
+// ListParentRelationships.
+func (h *ParentHandler) ListPendingParentRelationships(c echo.Context) error {
+	return h.listParentRelationships(c, "pending")
+}
+
+func (h *ParentHandler) listParentRelationships(c echo.Context, status string) error {
 	if h.currentRole(c) != "admin" {
 		return helpers.Error(c, "Forbidden", http.StatusForbidden)
 	}
@@ -369,7 +509,46 @@ func (h *ParentHandler) ListParentRelationships(c echo.Context) error {
 		return err
 	}
 
-	rows, err := h.db.Pool.Query(c.Request().Context(), `
+	limit, offset := parsePageParams(c)
+
+	// Optional filters are appended as extra positional arguments
+	where := "psr.college_id = $1"
+	args := []any{collegeID}
+	switch status {
+	case "":
+	case "pending":
+		where += " AND psr.is_verified = FALSE"
+	case "verified":
+		where += " AND psr.is_verified = TRUE"
+	default:
+		return helpers.Error(c, "Invalid status: must be pending or verified", http.StatusBadRequest)
+	}
+	for _, filter := range []struct{ param, column string }{
+		{"student_id", "psr.student_id"},
+		{"parent_user_id", "psr.parent_user_id"},
+	} {
+		raw := c.QueryParam(filter.param)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.Atoi(raw)
+		if err != nil || value <= 0 {
+			return helpers.Error(c, "Invalid "+filter.param, http.StatusBadRequest)
+		}
+		args = append(args, value)
+		where += fmt.Sprintf(" AND %s = $%d", filter.column, len(args))
+	}
+
+	var total int
+	if err := h.db.Pool.QueryRow(c.Request().Context(),
+		"SELECT COUNT(*) FROM parent_student_relationships psr WHERE "+where,
+		args...,
+	).Scan(&total); err != nil {
+		return helpers.Error(c, "Failed to count relationships", http.StatusInternalServerError)
+	}
+
+	pageArgs := append(args, limit, offset)
+	rows, err := h.db.Pool.Query(c.Request().Context(), fmt.Sprintf(`
 		SELECT
 			psr.id,
 			psr.parent_user_id,
@@ -387,9 +566,10 @@ func (h *ParentHandler) ListParentRelationships(c echo.Context) error {
 		JOIN users u ON u.id = psr.parent_user_id
 		JOIN students s ON s.student_id = psr.student_id
 		JOIN users u2 ON u2.id = s.user_id
-		WHERE psr.college_id = $1
-		ORDER BY psr.created_at DESC`,
-		collegeID,
+		WHERE %s
+		ORDER BY psr.created_at DESC, psr.id DESC
+		LIMIT $%d OFFSET $%d`, where, len(args)+1, len(args)+2),
+		pageArgs...,
 	)
 	if err != nil {
 		return helpers.Error(c, "Failed to fetch relationships", http.StatusInternalServerError)
@@ -433,7 +613,14 @@ func (h *ParentHandler) ListParentRelationships(c echo.Context) error {
 	if relationships == nil {
 		relationships = []rel{}
 	}
-	return helpers.Success(c, map[string]any{"relationships": relationships}, http.StatusOK)
+	return helpers.Success(c, map[string]any{
+		"relationships": map[string]any{
+			"items":  relationships,
+			"total":  total,
+			"limit":  limit,
+			"offset": offset,
+		},
+	}, http.StatusOK)
 }
 
 // CreateParentRelationship creates a parent-student link (admin only).
@@ -549,39 +736,401 @@ func (h *ParentHandler) DeleteParentRelationship(c echo.Context) error {
 	return helpers.Success(c, map[string]string{"message": "Link removed"}, http.StatusOK)
 }
 
-// ContactParent sends a direct email to a parent from faculty/admin users.
+// ApproveParentRelationship marks a pending parent-student link as verified (admin only).
+// Any outstanding email verification token is invalidated.
+func (h *ParentHandler) ApproveParentRelationship(c echo.Context) error {
+	if h.currentRole(c) != "admin" {
+		return helpers.Error(c, "Forbidden", http.StatusForbidden)
+	}
+
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	relID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return helpers.Error(c, "Invalid relationship ID", http.StatusBadRequest)
+	}
+
+	result, err := h.db.Pool.Exec(c.Request().Context(), `
+		UPDATE parent_student_relationships
+		SET is_verified = TRUE,
+		    verified_at = NOW(),
+		    verification_token_hash = NULL,
+		    verification_expires_at = NULL
+		WHERE id = $1
+		  AND college_id = $2
+		  AND is_verified = FALSE`,
+		relID, collegeID,
+	)
+	if err != nil {
+		return helpers.Error(c, "Failed to approve link", http.StatusInternalServerError)
+	}
+	if result.RowsAffected() == 0 {
+		return helpers.NotFound(c, map[string]any{"error": "Pending relationship not found"}, http.StatusNotFound)
+	}
+
+	return helpers.Success(c, map[string]any{"id": relID, "message": "Link approved"}, http.StatusOK)
+}
+
+// RequestParentLink godoc
+// @Summary Request a link to a parent account
+// @Description Creates an unverified parent-student relationship for the authenticated student and emails the parent an invite.
+// @Description The response is the same whether or not the email belongs to a parent account.
+// @Description The link becomes active once the parent confirms it or an admin approves it. Students can only link parents to themselves.
+// @Tags Parent Portal
+// @Accept json
+// @Produce json
+// @Success 202 {object} map[string]interface{}
+// @Failure 400 {object} helpers.ErrorResponse
+// @Failure 409 {object} helpers.ErrorResponse
+// @Failure 500 {object} helpers.ErrorResponse
+// @Router /api/student/parent-links [post]
+func (h *ParentHandler) RequestParentLink(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+	studentID, err := helpers.ExtractStudentID(c)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		ParentEmail          string `json:"parentEmail"`
+		Relation             string `json:"relation"`
+		IsPrimaryContact     bool   `json:"isPrimaryContact"`
+		ReceiveNotifications bool   `json:"receiveNotifications"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return helpers.Error(c, "Invalid request body", http.StatusBadRequest)
+	}
+	req.ParentEmail = strings.TrimSpace(req.ParentEmail)
+	if req.ParentEmail == "" {
+		return helpers.Error(c, "Parent email is required", http.StatusBadRequest)
+	}
+	switch req.Relation {
+	case "father", "mother", "guardian":
+	default:
+		return helpers.Error(c, "Relation must be one of father, mother, guardian", http.StatusBadRequest)
+	}
+
+	ctx := c.Request().Context()
+
+	var parentUserID int
+	var parentName, parentEmail string
+	err = h.db.Pool.QueryRow(ctx,
+		`SELECT id, name, email FROM users WHERE LOWER(email) = LOWER($1) AND role = 'parent' AND is_active = TRUE`,
+		req.ParentEmail,
+	).Scan(&parentUserID, &parentName, &parentEmail)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			// Answer as if the invite went out so the endpoint can't be used to
+			// find out which emails have parent accounts
+			log.Info().Int("student_id", studentID).Msg("parent link requested for an email without an active parent account")
+			return parentLinkRequested(c)
+		}
+		return helpers.Error(c, "Failed to look up parent account", http.StatusInternalServerError)
+	}
+
+	token, tokenHash, err := newParentLinkToken()
+	if err != nil {
+		return helpers.Error(c, "Failed to generate verification token", http.StatusInternalServerError)
+	}
+
+	// Re-requesting an unverified link rotates its token; verified links are left alone
+	var relationshipID int
+	err = h.db.Pool.QueryRow(ctx, `
+		INSERT INTO parent_student_relationships
+			(parent_user_id, student_id, college_id, relation, is_primary_contact, receive_notifications,
+			 is_verified, verification_token_hash, verification_expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, FALSE, $7, $8)
+		ON CONFLICT (parent_user_id, student_id, college_id) DO UPDATE
+		SET relation = EXCLUDED.relation,
+		    is_primary_contact = EXCLUDED.is_primary_contact,
+		    receive_notifications = EXCLUDED.receive_notifications,
+		    verification_token_hash = EXCLUDED.verification_token_hash,
+		    verification_expires_at = EXCLUDED.verification_expires_at
+		WHERE parent_student_relationships.is_verified = FALSE
+		RETURNING id`,
+		parentUserID, studentID, collegeID, req.Relation, req.IsPrimaryContact, req.ReceiveNotifications,
+		tokenHash, time.Now().Add(parentLinkTokenTTL),
+	).Scan(&relationshipID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return helpers.Error(c, "This parent is already linked to your account", http.StatusConflict)
+		}
+		return helpers.Error(c, "Failed to create link request", http.StatusInternalServerError)
+	}
+
+	link := email.ParentLinkData{
+		ParentName:     parentName,
+		Relation:       req.Relation,
+		VerifyURL:      h.verificationURL + "?token=" + url.QueryEscape(token),
+		ExpiresInHours: int(parentLinkTokenTTL.Hours()),
+	}
+	// Sent in the background with retries, since a distinct error here would
+	// confirm the account exists. The link carries the raw token, so a failed
+	// email is not dead-lettered; the student can request the link again.
+	if err := h.emailDelivery.SendSecretTemplateAsync(ctx, collegeID, parentEmail, email.TemplateParentLink, link); err != nil {
+		log.Error().Err(err).Int("relationship_id", relationshipID).Msg("failed to queue parent link verification email")
+	}
+
+	return parentLinkRequested(c)
+}
+
+// parentLinkRequested is the one response RequestParentLink gives whether or
+// not the email belongs to a parent account
+func parentLinkRequested(c echo.Context) error {
+	return helpers.Success(c, map[string]any{
+		"message": "If a parent account exists for this email, a verification email has been sent",
+	}, http.StatusAccepted)
+}
+
+// VerifyParentLink godoc
+// @Summary Confirm a parent-student link
+// @Description Marks a pending relationship as verified when given a valid, unexpired token addressed to the authenticated parent
+// @Tags Parent Portal
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} helpers.ErrorResponse
+// @Failure 401 {object} helpers.ErrorResponse
+// @Router /api/parent/verify-link [post]
+func (h *ParentHandler) VerifyParentLink(c echo.Context) error {
+	var req struct {
+		Token string `json:"token"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return helpers.Error(c, "Invalid request body", http.StatusBadRequest)
+	}
+	req.Token = strings.TrimSpace(req.Token)
+	if req.Token == "" {
+		return helpers.Error(c, "Verification token is required", http.StatusBadRequest)
+	}
+
+	kratosID, err := helpers.GetKratosID(c)
+	if err != nil {
+		return helpers.Error(c, "Unauthorized", http.StatusUnauthorized)
+	}
+	parentUserID, err := h.resolveUserID(c.Request().Context(), kratosID)
+	if err != nil {
+		return helpers.Error(c, "Forbidden: Parent account is not linked", http.StatusForbidden)
+	}
+
+	var relationshipID, studentID int
+	err = h.db.Pool.QueryRow(c.Request().Context(), `
+		UPDATE parent_student_relationships
+		SET is_verified = TRUE,
+		    verified_at = NOW(),
+		    verification_token_hash = NULL,
+		    verification_expires_at = NULL
+		WHERE verification_token_hash = $1
+		  AND parent_user_id = $2
+		  AND is_verified = FALSE
+		  AND verification_expires_at > NOW()
+		RETURNING id, student_id`,
+		hashParentLinkToken(req.Token), parentUserID,
+	).Scan(&relationshipID, &studentID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return helpers.Error(c, "Invalid or expired verification token", http.StatusBadRequest)
+		}
+		return helpers.Error(c, "Failed to verify link", http.StatusInternalServerError)
+	}
+
+	return helpers.Success(c, map[string]any{
+		"id":        relationshipID,
+		"studentId": studentID,
+		"message":   "Parent link verified",
+	}, http.StatusOK)
+}
+
+// ContactParent sends a direct email to a parent from faculty/admin users and
+// records it in the parent communication log. If the email is sent but the log
+// write fails, the response reports the message as sent but unlogged.
 func (h *ParentHandler) ContactParent(c echo.Context) error {
 	role := h.currentRole(c)
 	if role != "admin" && role != "faculty" {
 		return helpers.Error(c, "Forbidden", http.StatusForbidden)
 	}
 
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
 	var req struct {
 		ParentName string `json:"parentName"`
 		Email      string `json:"email"`
 		Phone      string `json:"phone"`
 		Subject    string `json:"subject"`
 		Message    string `json:"message"`
+		StudentID  *int   `json:"studentId"`
 	}
 	if err := c.Bind(&req); err != nil {
 		return helpers.Error(c, "Invalid request body", http.StatusBadRequest)
 	}
 
-	if strings.TrimSpace(req.Email) == "" || strings.TrimSpace(req.Subject) == "" || strings.TrimSpace(req.Message) == "" {
+	req.ParentName = strings.TrimSpace(req.ParentName)
+	req.Email = strings.TrimSpace(req.Email)
+	req.Phone = strings.TrimSpace(req.Phone)
+	req.Subject = strings.TrimSpace(req.Subject)
+	req.Message = strings.TrimSpace(req.Message)
+	if req.Email == "" || req.Subject == "" || req.Message == "" {
 		return helpers.Error(c, "Email, subject, and message are required", http.StatusBadRequest)
 	}
 
-	body := fmt.Sprintf(
-		"<html><body><p><strong>Parent:</strong> %s</p><p><strong>Phone:</strong> %s</p><p>%s</p></body></html>",
-		strings.TrimSpace(req.ParentName),
-		strings.TrimSpace(req.Phone),
-		strings.ReplaceAll(strings.TrimSpace(req.Message), "\n", "<br/>"),
-	)
-	if err := h.emailService.SendEmail(c.Request().Context(), strings.TrimSpace(req.Email), strings.TrimSpace(req.Subject), body); err != nil {
+	ctx := c.Request().Context()
+	if req.StudentID != nil {
+		var exists bool
+		err := h.db.Pool.QueryRow(ctx,
+			`SELECT EXISTS (SELECT 1 FROM students WHERE student_id = $1 AND college_id = $2)`,
+			*req.StudentID, collegeID,
+		).Scan(&exists)
+		if err != nil {
+			return helpers.Error(c, "Failed to verify student", http.StatusInternalServerError)
+		}
+		if !exists {
+			return helpers.Error(c, "Student does not belong to your college", http.StatusBadRequest)
+		}
+	}
+
+	contact := email.ParentContactData{
+		Subject:    req.Subject,
+		ParentName: req.ParentName,
+		Phone:      req.Phone,
+		Message:    req.Message,
+	}
+	if err := h.emailService.SendTemplate(ctx, req.Email, email.TemplateParentContact, contact); err != nil {
+		var rcptErr *email.RecipientError
+		if errors.As(err, &rcptErr) {
+			return helpers.Error(c, rcptErr.Error(), http.StatusBadRequest)
+		}
 		return helpers.Error(c, "Failed to send parent contact email", http.StatusInternalServerError)
 	}
 
-	return helpers.Success(c, map[string]string{"status": "sent"}, http.StatusOK)
+	communicationID, err := h.logParentCommunication(c, collegeID, req.StudentID, req.ParentName, req.Email, req.Phone, req.Subject, req.Message)
+	if err != nil {
+		return helpers.Success(c, map[string]any{
+			"status":  "sent",
+			"logged":  false,
+			"warning": "Email was sent but could not be recorded in the communication log",
+		}, http.StatusOK)
+	}
+
+	return helpers.Success(c, map[string]any{
+		"status":          "sent",
+		"logged":          true,
+		"communicationId": communicationID,
+	}, http.StatusOK)
+}
+
+// ListParentCommunications godoc
+// @Summary List parent communications for a student
+// @Description Returns the log of messages faculty and admins sent to a student's parents, newest first
+// @Tags Parent Portal
+// @Produce json
+// @Param studentID path int true "Student ID"
+// @Param limit query int false "Maximum number of entries (default 50)"
+// @Param offset query int false "Number of entries to skip"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} helpers.ErrorResponse
+// @Failure 403 {object} helpers.ErrorResponse
+// @Failure 500 {object} helpers.ErrorResponse
+// @Router /api/parent/children/{studentID}/communications [get]
+func (h *ParentHandler) ListParentCommunications(c echo.Context) error {
+	role := h.currentRole(c)
+	if role != "admin" && role != "faculty" {
+		return helpers.Error(c, "Forbidden", http.StatusForbidden)
+	}
+
+	studentID, err := strconv.Atoi(c.Param("studentID"))
+	if err != nil {
+		return helpers.Error(c, "Invalid student ID", http.StatusBadRequest)
+	}
+
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	limit, offset := parsePageParams(c)
+
+	rows, err := h.db.Pool.Query(c.Request().Context(), `
+		SELECT
+			pc.id,
+			pc.sender_user_id,
+			u.name AS sender_name,
+			COALESCE(pc.parent_name, ''),
+			pc.parent_email,
+			COALESCE(pc.parent_phone, ''),
+			pc.subject,
+			pc.message,
+			pc.sent_at
+		FROM parent_communications pc
+		JOIN users u ON u.id = pc.sender_user_id
+		WHERE pc.college_id = $1
+		  AND pc.student_id = $2
+		ORDER BY pc.sent_at DESC, pc.id DESC
+		LIMIT $3 OFFSET $4`,
+		collegeID, studentID, limit, offset,
+	)
+	if err != nil {
+		return helpers.Error(c, "Failed to fetch communications", http.StatusInternalServerError)
+	}
+	defer rows.Close()
+
+	type communication struct {
+		ID           int    `json:"id"`
+		SenderUserID int    `json:"senderUserId"`
+		SenderName   string `json:"senderName"`
+		ParentName   string `json:"parentName"`
+		ParentEmail  string `json:"parentEmail"`
+		ParentPhone  string `json:"parentPhone"`
+		Subject      string `json:"subject"`
+		Message      string `json:"message"`
+		SentAt       string `json:"sentAt"`
+	}
+
+	communications := []communication{}
+	for rows.Next() {
+		var entry communication
+		var sentAt time.Time
+		if err := rows.Scan(
+			&entry.ID, &entry.SenderUserID, &entry.SenderName,
+			&entry.ParentName, &entry.ParentEmail, &entry.ParentPhone,
+			&entry.Subject, &entry.Message, &sentAt,
+		); err != nil {
+			return helpers.Error(c, "Failed to scan communication", http.StatusInternalServerError)
+		}
+		entry.SentAt = sentAt.Format(time.RFC3339)
+		communications = append(communications, entry)
+	}
+	if rows.Err() != nil {
+		return helpers.Error(c, "Failed to iterate communications", http.StatusInternalServerError)
+	}
+
+	return helpers.Success(c, map[string]any{
+		"communications": communications,
+		"limit":          limit,
+		"offset":         offset,
+	}, http.StatusOK)
+}
+
+// parsePageParams reads limit/offset query parameters, falling back to the
+// default page size and capping the limit at maxParentPageSize
+func parsePageParams(c echo.Context) (int, int) {
+	limit := defaultParentPageSize
+	if l, err := strconv.Atoi(c.QueryParam("limit")); err == nil && l > 0 {
+		limit = min(l, maxParentPageSize)
+	}
+	offset := 0
+	if o, err := strconv.Atoi(c.QueryParam("offset")); err == nil && o > 0 {
+		offset = o
+	}
+	return limit, offset
 }
 
 func (h *ParentHandler) currentRole(c echo.Context) string {
@@ -592,7 +1141,7 @@ func (h *ParentHandler) currentRole(c echo.Context) string {
 	return identity.Traits.Role
 }
 
-func (h *ParentHandler) resolveParentUserID(ctx context.Context, kratosID string) (int, error) {
+func (h *ParentHandler) resolveUserID(ctx context.Context, kratosID string) (int, error) {
 	var userID int
 	err := h.db.Pool.QueryRow(ctx,
 		`SELECT id FROM users WHERE kratos_identity_id = $1 AND is_active = TRUE`,
@@ -600,20 +1149,55 @@ func (h *ParentHandler) resolveParentUserID(ctx context.Context, kratosID string
 	).Scan(&userID)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return 0, fmt.Errorf("parent user not found")
+			return 0, fmt.Errorf("user not found")
 		}
 		return 0, err
 	}
 	return userID, nil
 }
 
-func (h *ParentHandler) getLinkedStudentIDSet(ctx context.Context, collegeID, parentUserID int) (map[int]struct{}, error) {
+// logParentCommunication records a sent parent email and returns the log entry ID
+func (h *ParentHandler) logParentCommunication(c echo.Context, collegeID int, studentID *int, parentName, email, phone, subject, message string) (int, error) {
+	kratosID, err := helpers.GetKratosID(c)
+	if err != nil {
+		return 0, err
+	}
+	senderUserID, err := h.resolveUserID(c.Request().Context(), kratosID)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int
+	err = h.db.Pool.QueryRow(c.Request().Context(), `
+		INSERT INTO parent_communications
+			(college_id, sender_user_id, student_id, parent_name, parent_email, parent_phone, subject, message)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, NULLIF($6, ''), $7, $8)
+		RETURNING id`,
+		collegeID, senderUserID, studentID, parentName, email, phone, subject, message,
+	).Scan(&id)
+	return id, err
+}
+
+// linkedStudent is the subset of student fields shown in the parent's child list
+type linkedStudent struct {
+	StudentID      int
+	RollNo         string
+	EnrollmentYear int
+	IsActive       bool
+}
+
+// getLinkedStudents returns the verified children of a parent in one query
+func (h *ParentHandler) getLinkedStudents(ctx context.Context, collegeID, parentUserID int) ([]linkedStudent, error) {
 	rows, err := h.db.Pool.Query(ctx, `
-		SELECT student_id
-		FROM parent_student_relationships
-		WHERE college_id = $1
-		  AND parent_user_id = $2
-		  AND is_verified = TRUE`,
+		SELECT s.student_id, s.roll_no, COALESCE(s.enrollment_year, 0), s.is_active
+		FROM parent_student_relationships psr
+		JOIN students s
+		  ON s.student_id = psr.student_id
+		 AND s.college_id = psr.college_id
+		WHERE psr.college_id = $1
+		  AND psr.parent_user_id = $2
+		  AND psr.is_verified = TRUE
+		ORDER BY s.roll_no ASC`,
 		collegeID, parentUserID,
 	)
 	if err != nil {
@@ -621,69 +1205,236 @@ func (h *ParentHandler) getLinkedStudentIDSet(ctx context.Context, collegeID, pa
 	}
 	defer rows.Close()
 
-	ids := make(map[int]struct{})
+	students := make([]linkedStudent, 0)
 	for rows.Next() {
-		var studentID int
-		if err := rows.Scan(&studentID); err != nil {
+		var s linkedStudent
+		if err := rows.Scan(&s.StudentID, &s.RollNo, &s.EnrollmentYear, &s.IsActive); err != nil {
 			return nil, err
 		}
-		ids[studentID] = struct{}{}
+		students = append(students, s)
 	}
 	if rows.Err() != nil {
 		return nil, rows.Err()
 	}
-	return ids, nil
-}
-
-func (h *ParentHandler) getPendingAssignmentCount(ctx context.Context, collegeID, studentID int) (int, error) {
-	var count int
-	err := h.db.Pool.QueryRow(ctx, `
-		SELECT COUNT(*)
-		FROM assignments a
-		JOIN enrollments e
-		  ON e.course_id = a.course_id
-		 AND e.college_id = a.college_id
-		 AND e.student_id = $2
-		LEFT JOIN assignment_submissions s
-		  ON s.assignment_id = a.id
-		 AND s.student_id = $2
-		WHERE a.college_id = $1
-		  AND s.id IS NULL
-		  AND a.due_date >= NOW()`,
-		collegeID, studentID,
-	).Scan(&count)
-	if err != nil {
-		return 0, err
+	return students, nil
+}
+
+// childSummary holds the dashboard metrics for a single student
+type childSummary struct {
+	EnrolledCourses    int
+	AttendanceRate     float64
+	PendingAssignments int
+	AverageGrade       float64
+	AssessmentsCount   int
+}
+
+func (s childSummary) toMap() map[string]any {
+	return map[string]any{
+		"enrolledCourses":    s.EnrolledCourses,
+		"attendanceRate":     s.AttendanceRate,
+		"pendingAssignments": s.PendingAssignments,
+		"averageGrade":       s.AverageGrade,
+		"assessmentsCount":   s.AssessmentsCount,
 	}
-	return count, nil
 }
 
-func calculateAttendanceRate(records []*models.Attendance) float64 {
-	if len(records) == 0 {
-		return 0
+// getChildSummaries computes attendance, grade, enrollment and pending
+// assignment metrics for all given students in a single round trip. Students
+// without any records get a zero-valued summary.
+func (h *ParentHandler) getChildSummaries(ctx context.Context, collegeID int, studentIDs []int) (map[int]childSummary, error) {
+	summaries := make(map[int]childSummary, len(studentIDs))
+	if len(studentIDs) == 0 {
+		return summaries, nil
 	}
 
-	present := 0
-	for _, record := range records {
-		if strings.EqualFold(record.Status, "present") {
-			present++
+	rows, err := h.db.Pool.Query(ctx, `
+		SELECT
+			ids.student_id,
+			COALESCE(enr.course_count, 0),
+			COALESCE(att.total, 0),
+			COALESCE(att.present, 0),
+			COALESCE(pa.pending, 0),
+			COALESCE(g.average, 0)::float8,
+			COALESCE(g.assessments, 0)
+		FROM unnest($2::int[]) AS ids(student_id)
+		LEFT JOIN (
+			SELECT student_id, COUNT(*) AS course_count
+			FROM enrollments
+			WHERE college_id = $1 AND student_id = ANY($2)
+			GROUP BY student_id
+		) enr ON enr.student_id = ids.student_id
+		LEFT JOIN (
+			SELECT student_id,
+			       COUNT(*) AS total,
+			       COUNT(*) FILTER (WHERE `+models.AttendancePresentSQL("status")+`) AS present
+			FROM attendance
+			WHERE college_id = $1 AND student_id = ANY($2)
+			GROUP BY student_id
+		) att ON att.student_id = ids.student_id
+		LEFT JOIN (
+			SELECT student_id, AVG(percentage) AS average, COUNT(*) AS assessments
+			FROM grades
+			WHERE college_id = $1 AND student_id = ANY($2)
+			GROUP BY student_id
+		) g ON g.student_id = ids.student_id
+		LEFT JOIN (
+			SELECT e.student_id, COUNT(*) AS pending
+			FROM assignments a
+			JOIN enrollments e
+			  ON e.course_id = a.course_id
+			 AND e.college_id = a.college_id
+			LEFT JOIN assignment_submissions sub
+			  ON sub.assignment_id = a.id
+			 AND sub.student_id = e.student_id
+			WHERE a.college_id = $1
+			  AND e.student_id = ANY($2)
+			  AND sub.id IS NULL
+			  AND a.due_date >= NOW()
+			GROUP BY e.student_id
+		) pa ON pa.student_id = ids.student_id`,
+		collegeID, studentIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			studentID       int
+			summary         childSummary
+			totalAttendance int
+			presentCount    int
+		)
+		if err := rows.Scan(
+			&studentID, &summary.EnrolledCourses, &totalAttendance, &presentCount,
+			&summary.PendingAssignments, &summary.AverageGrade, &summary.AssessmentsCount,
+		); err != nil {
+			return nil, err
+		}
+		if totalAttendance > 0 {
+			summary.AttendanceRate = roundToHundredths(float64(presentCount) / float64(totalAttendance) * 100)
 		}
+		summary.AverageGrade = roundToHundredths(summary.AverageGrade)
+		summaries[studentID] = summary
 	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return summaries, nil
+}
 
-	rate := (float64(present) / float64(len(records))) * 100
-	return math.Round(rate*100) / 100
+func roundToHundredths(v float64) float64 {
+	return math.Round(v*100) / 100
 }
 
-func calculateAverageGrade(grades []*models.Grade) float64 {
-	if len(grades) == 0 {
-		return 0
+// newParentLinkToken returns a random token for the email link and the hash
+// that is stored in the database
+func newParentLinkToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
 	}
+	token := hex.EncodeToString(buf)
+	return token, hashParentLinkToken(token), nil
+}
 
-	total := 0.0
-	for _, grade := range grades {
-		total += grade.Percentage
+func hashParentLinkToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// gradeCourse is the course detail a child's grade report needs
+type gradeCourse struct {
+	Name    string
+	Credits int
+}
+
+// courseGrades is one course of a child's grade report
+type courseGrades struct {
+	CourseID          int     `json:"courseId"`
+	CourseName        string  `json:"courseName"`
+	Credits           int     `json:"credits"`
+	Assessments       int     `json:"assessments"`
+	AveragePercentage float64 `json:"averagePercentage"`
+	GradePoint        float64 `json:"gradePoint"`
+}
+
+// childGradeReport groups a child's grades by course
+type childGradeReport struct {
+	Courses      []courseGrades
+	OverallGPA   float64
+	TotalCredits int
+}
+
+// getGradeCourses loads the names and credits of the given courses
+func (h *ParentHandler) getGradeCourses(ctx context.Context, collegeID int, courseIDs []int) (map[int]gradeCourse, error) {
+	courses := make(map[int]gradeCourse)
+	if len(courseIDs) == 0 {
+		return courses, nil
 	}
 
-	avg := total / float64(len(grades))
-	return math.Round(avg*100) / 100
+	rows, err := h.db.Pool.Query(ctx,
+		`SELECT id, name, credits FROM courses WHERE college_id = $1 AND id = ANY($2)`,
+		collegeID, courseIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var course gradeCourse
+		if err := rows.Scan(&id, &course.Name, &course.Credits); err != nil {
+			return nil, err
+		}
+		courses[id] = course
+	}
+	return courses, rows.Err()
+}
+
+// summarizeChildGrades averages each course's assessment percentages and
+// weights the courses' grade points by credits into an overall GPA, the same
+// way the student dashboard does, so parents and students see one GPA.
+// Courses are listed by name.
+func summarizeChildGrades(grades []*models.Grade, courses map[int]gradeCourse) childGradeReport {
+	totals := make(map[int]float64)
+	byCourse := make(map[int]*courseGrades)
+	order := []int{}
+	for _, grade := range grades {
+		summary, ok := byCourse[grade.CourseID]
+		if !ok {
+			course, found := courses[grade.CourseID]
+			if !found {
+				course.Name = "Unknown Course"
+			}
+			summary = &courseGrades{CourseID: grade.CourseID, CourseName: course.Name, Credits: course.Credits}
+			byCourse[grade.CourseID] = summary
+			order = append(order, grade.CourseID)
+		}
+		summary.Assessments++
+		totals[grade.CourseID] += grade.Percentage
+	}
+
+	report := childGradeReport{Courses: make([]courseGrades, 0, len(order))}
+	weightedGradePoints := 0.0
+	for _, courseID := range order {
+		summary := byCourse[courseID]
+		average := totals[courseID] / float64(summary.Assessments)
+		summary.AveragePercentage = roundToHundredths(average)
+		summary.GradePoint = calculateGradePoint(average)
+		if summary.Credits > 0 {
+			report.TotalCredits += summary.Credits
+			weightedGradePoints += summary.GradePoint * float64(summary.Credits)
+		}
+		report.Courses = append(report.Courses, *summary)
+	}
+	sort.SliceStable(report.Courses, func(i, j int) bool {
+		return report.Courses[i].CourseName < report.Courses[j].CourseName
+	})
+	if report.TotalCredits > 0 {
+		report.OverallGPA = roundToHundredths(weightedGradePoints / float64(report.TotalCredits))
+	}
+	return report
 }
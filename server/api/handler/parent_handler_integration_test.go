@@ -96,10 +96,10 @@ func TestParentDashboardMetricsIntegration(t *testing.T) {
 	studentService := student.NewstudentService(studentRepo, attendanceRepo, enrollmentRepo, profileRepo, gradeRepo)
 	attendanceService := attendance.NewAttendanceService(attendanceRepo, studentRepo, enrollmentRepo)
 	gradeService := grades.NewGradeServices(gradeRepo, studentRepo, enrollmentRepo, courseRepo)
-	assignmentService := assignment.NewAssignmentService(assignmentRepo, nil)
+	assignmentService := assignment.NewAssignmentService(assignmentRepo, nil, assignment.SubmissionUploadConfig{}, assignment.LatePolicy{}, nil)
 	emailService := email.NewEmailService("", "", "", "", "")
 
-	handler := NewParentHandler(studentService, attendanceService, gradeService, assignmentService, emailService, db)
+	handler := NewParentHandler(studentService, attendanceService, gradeService, assignmentService, emailService, nil, nil, nil, db)
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/parent/children/%d/dashboard", fixture.StudentID), nil)
 	rec := httptest.NewRecorder()
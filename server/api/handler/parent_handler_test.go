@@ -0,0 +1,448 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+	"eduhub/server/internal/services/auth"
+	"eduhub/server/internal/services/email"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetChildSummaries(t *testing.T) {
+	t.Run("computes metrics for all children in one query", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		h := &ParentHandler{db: &repository.DB{Pool: mock}}
+
+		mock.ExpectQuery(`FROM unnest\(\$2::int\[\]\)`).
+			WithArgs(7, []int{1, 2}).
+			WillReturnRows(pgxmock.NewRows([]string{
+				"student_id", "course_count", "total", "present", "pending", "average", "assessments",
+			}).
+				AddRow(1, 3, 3, 2, 1, 81.456, 4).
+				AddRow(2, 0, 0, 0, 0, 0.0, 0))
+
+		summaries, err := h.getChildSummaries(context.Background(), 7, []int{1, 2})
+		require.NoError(t, err)
+
+		assert.Equal(t, childSummary{
+			EnrolledCourses:    3,
+			AttendanceRate:     66.67,
+			PendingAssignments: 1,
+			AverageGrade:       81.46,
+			AssessmentsCount:   4,
+		}, summaries[1])
+		assert.Equal(t, childSummary{}, summaries[2])
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("skips the query when there are no children", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		h := &ParentHandler{db: &repository.DB{Pool: mock}}
+
+		summaries, err := h.getChildSummaries(context.Background(), 7, nil)
+		require.NoError(t, err)
+		assert.Empty(t, summaries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSummarizeChildGrades(t *testing.T) {
+	grades := []*models.Grade{
+		{CourseID: 2, Percentage: 92},
+		{CourseID: 1, Percentage: 70},
+		{CourseID: 2, Percentage: 80},
+		{CourseID: 9, Percentage: 40},
+	}
+	courses := map[int]gradeCourse{
+		1: {Name: "Algebra", Credits: 4},
+		2: {Name: "Biology", Credits: 2},
+	}
+
+	report := summarizeChildGrades(grades, courses)
+
+	require.Len(t, report.Courses, 3)
+	assert.Equal(t, courseGrades{CourseID: 1, CourseName: "Algebra", Credits: 4, Assessments: 1, AveragePercentage: 70, GradePoint: 2.7}, report.Courses[0])
+	assert.Equal(t, courseGrades{CourseID: 2, CourseName: "Biology", Credits: 2, Assessments: 2, AveragePercentage: 86, GradePoint: 3.7}, report.Courses[1])
+	// A course that could not be loaded is listed but has no credits to weigh
+	assert.Equal(t, "Unknown Course", report.Courses[2].CourseName)
+	assert.Equal(t, 6, report.TotalCredits)
+	assert.Equal(t, 3.03, report.OverallGPA)
+
+	empty := summarizeChildGrades(nil, nil)
+	assert.Empty(t, empty.Courses)
+	assert.Zero(t, empty.OverallGPA)
+}
+
+func TestParentLinkToken(t *testing.T) {
+	token, hash, err := newParentLinkToken()
+	require.NoError(t, err)
+
+	assert.Len(t, token, 64)
+	assert.Len(t, hash, 64)
+	assert.NotEqual(t, token, hash, "token must not be stored in plaintext")
+	assert.Equal(t, hash, hashParentLinkToken(token))
+}
+
+func TestVerifyParentLink(t *testing.T) {
+	newRequest := func(body string) (echo.Context, *httptest.ResponseRecorder) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/parent/verify-link", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("identity", &auth.Identity{ID: "kratos-parent"})
+		return c, rec
+	}
+
+	t.Run("valid token verifies the relationship", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		h := &ParentHandler{db: &repository.DB{Pool: mock}}
+
+		mock.ExpectQuery(`SELECT id FROM users WHERE kratos_identity_id`).
+			WithArgs("kratos-parent").
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(11))
+		mock.ExpectQuery(`UPDATE parent_student_relationships`).
+			WithArgs(hashParentLinkToken("abc"), 11).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "student_id"}).AddRow(5, 42))
+
+		c, rec := newRequest(`{"token":"abc"}`)
+		require.NoError(t, h.VerifyParentLink(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"studentId":42`)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown or expired token is rejected", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		h := &ParentHandler{db: &repository.DB{Pool: mock}}
+
+		mock.ExpectQuery(`SELECT id FROM users WHERE kratos_identity_id`).
+			WithArgs("kratos-parent").
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(11))
+		mock.ExpectQuery(`UPDATE parent_student_relationships`).
+			WithArgs(hashParentLinkToken("stale"), 11).
+			WillReturnError(pgx.ErrNoRows)
+
+		c, rec := newRequest(`{"token":"stale"}`)
+		require.NoError(t, h.VerifyParentLink(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		h := &ParentHandler{}
+		c, rec := newRequest(`{}`)
+		require.NoError(t, h.VerifyParentLink(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+type recordingEmailService struct {
+	email.EmailService
+	sentTo []string
+	bodies []string
+}
+
+func (s *recordingEmailService) SendEmail(ctx context.Context, to, subject, body string) error {
+	if _, err := email.ParseRecipients(to); err != nil {
+		return err
+	}
+	s.sentTo = append(s.sentTo, to)
+	return nil
+}
+
+func (s *recordingEmailService) SendTemplate(ctx context.Context, to, templateName string, data any) error {
+	subject, body, err := email.Render(templateName, data)
+	if err != nil {
+		return err
+	}
+	s.bodies = append(s.bodies, body)
+	return s.SendEmail(ctx, to, subject, body)
+}
+
+func TestContactParent(t *testing.T) {
+	newRequest := func(body string) (echo.Context, *httptest.ResponseRecorder) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/parent/contact", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		identity := &auth.Identity{ID: "kratos-faculty"}
+		identity.Traits.Role = "faculty"
+		c.Set("identity", identity)
+		c.Set("college_id", 3)
+		return c, rec
+	}
+	const body = `{"email":"parent@example.com","subject":"Hello","message":"Please call","studentId":42}`
+
+	t.Run("sent email is logged", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		mail := &recordingEmailService{}
+		h := &ParentHandler{db: &repository.DB{Pool: mock}, emailService: mail}
+
+		mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM students`).
+			WithArgs(42, 3).
+			WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(`SELECT id FROM users WHERE kratos_identity_id`).
+			WithArgs("kratos-faculty").
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(9))
+		mock.ExpectQuery(`INSERT INTO parent_communications`).
+			WithArgs(3, 9, pgxmock.AnyArg(), "", "parent@example.com", "", "Hello", "Please call").
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(77))
+
+		c, rec := newRequest(body)
+		require.NoError(t, h.ContactParent(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"logged":true`)
+		assert.Contains(t, rec.Body.String(), `"communicationId":77`)
+		assert.Equal(t, []string{"parent@example.com"}, mail.sentTo)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("message is escaped", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		mail := &recordingEmailService{}
+		h := &ParentHandler{db: &repository.DB{Pool: mock}, emailService: mail}
+
+		mock.ExpectQuery(`SELECT id FROM users WHERE kratos_identity_id`).
+			WithArgs("kratos-faculty").
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(9))
+		mock.ExpectQuery(`INSERT INTO parent_communications`).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(78))
+
+		c, rec := newRequest(`{"email":"parent@example.com","subject":"Hi","parentName":"<b>Ravi</b>","message":"<script>alert(1)</script>\nBye"}`)
+		require.NoError(t, h.ContactParent(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.Len(t, mail.bodies, 1)
+		assert.Contains(t, mail.bodies[0], "&lt;b&gt;Ravi&lt;/b&gt;")
+		assert.Contains(t, mail.bodies[0], "&lt;script&gt;alert(1)&lt;/script&gt;<br/>Bye")
+		assert.NotContains(t, mail.bodies[0], "<script>")
+	})
+
+	t.Run("log failure is reported as unlogged", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		mail := &recordingEmailService{}
+		h := &ParentHandler{db: &repository.DB{Pool: mock}, emailService: mail}
+
+		mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM students`).
+			WithArgs(42, 3).
+			WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(`SELECT id FROM users WHERE kratos_identity_id`).
+			WithArgs("kratos-faculty").
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(9))
+		mock.ExpectQuery(`INSERT INTO parent_communications`).
+			WillReturnError(errors.New("relation does not exist"))
+
+		c, rec := newRequest(body)
+		require.NoError(t, h.ContactParent(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"logged":false`)
+		assert.Len(t, mail.sentTo, 1)
+	})
+
+	t.Run("invalid recipient is a bad request", func(t *testing.T) {
+		mail := &recordingEmailService{}
+		h := &ParentHandler{emailService: mail}
+
+		c, rec := newRequest(`{"email":"parent@example.com\r\nBcc: x@example.com","subject":"Hello","message":"Please call"}`)
+		require.NoError(t, h.ContactParent(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "invalid recipient address")
+		assert.Empty(t, mail.sentTo)
+	})
+
+	t.Run("student from another college is rejected before sending", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		mail := &recordingEmailService{}
+		h := &ParentHandler{db: &repository.DB{Pool: mock}, emailService: mail}
+
+		mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM students`).
+			WithArgs(42, 3).
+			WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+
+		c, rec := newRequest(body)
+		require.NoError(t, h.ContactParent(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Empty(t, mail.sentTo)
+	})
+}
+
+func TestListParentRelationships(t *testing.T) {
+	newRequest := func(query string) (echo.Context, *httptest.ResponseRecorder) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/parent/relationships?"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		identity := &auth.Identity{ID: "kratos-admin"}
+		identity.Traits.Role = "admin"
+		c.Set("identity", identity)
+		c.Set("college_id", 3)
+		return c, rec
+	}
+	columns := []string{
+		"id", "parent_user_id", "parent_name", "parent_email", "student_id", "student_roll_no",
+		"student_name", "relation", "is_primary_contact", "receive_notifications", "is_verified", "created_at",
+	}
+
+	t.Run("filters and paginates", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		h := &ParentHandler{db: &repository.DB{Pool: mock}}
+
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM parent_student_relationships psr WHERE psr.college_id = \$1 AND psr.student_id = \$2`).
+			WithArgs(3, 42).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(31))
+		mock.ExpectQuery(`LIMIT \$3 OFFSET \$4`).
+			WithArgs(3, 42, 10, 20).
+			WillReturnRows(pgxmock.NewRows(columns).
+				AddRow(1, 9, "Pat", "pat@example.com", 42, "R42", "Sam", "guardian", true, true, true, time.Now()))
+
+		c, rec := newRequest("student_id=42&limit=10&offset=20")
+		require.NoError(t, h.ListParentRelationships(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"total":31`)
+		assert.Contains(t, rec.Body.String(), `"items":[{"id":1`)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("limit is capped", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		h := &ParentHandler{db: &repository.DB{Pool: mock}}
+
+		mock.ExpectQuery(`SELECT COUNT`).
+			WithArgs(3).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(`LIMIT \$2 OFFSET \$3`).
+			WithArgs(3, maxParentPageSize, 0).
+			WillReturnRows(pgxmock.NewRows(columns))
+
+		c, rec := newRequest("limit=100000")
+		require.NoError(t, h.ListParentRelationships(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"items":[]`)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("pending requests only", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		h := &ParentHandler{db: &repository.DB{Pool: mock}}
+
+		mock.ExpectQuery(`WHERE psr.college_id = \$1 AND psr.is_verified = FALSE`).
+			WithArgs(3).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(`psr.is_verified = FALSE .*LIMIT \$2 OFFSET \$3`).
+			WithArgs(3, defaultParentPageSize, 0).
+			WillReturnRows(pgxmock.NewRows(columns).
+				AddRow(2, 9, "Pat", "pat@example.com", 42, "R42", "Sam", "guardian", false, true, false, time.Now()))
+
+		c, rec := newRequest("")
+		require.NoError(t, h.ListPendingParentRelationships(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"total":1`)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		h := &ParentHandler{}
+		c, rec := newRequest("parent_user_id=abc")
+		require.NoError(t, h.ListParentRelationships(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		h := &ParentHandler{}
+		c, rec := newRequest("status=archived")
+		require.NoError(t, h.ListParentRelationships(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestApproveParentRelationship(t *testing.T) {
+	newRequest := func(role string) (echo.Context, *httptest.ResponseRecorder) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, "/api/parent/relationships/5/approve", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("5")
+		identity := &auth.Identity{ID: "kratos-admin"}
+		identity.Traits.Role = role
+		c.Set("identity", identity)
+		c.Set("college_id", 3)
+		return c, rec
+	}
+
+	t.Run("pending link is verified", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		h := &ParentHandler{db: &repository.DB{Pool: mock}}
+
+		mock.ExpectExec(`UPDATE parent_student_relationships`).
+			WithArgs(5, 3).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		c, rec := newRequest("admin")
+		require.NoError(t, h.ApproveParentRelationship(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already verified or other college", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		h := &ParentHandler{db: &repository.DB{Pool: mock}}
+
+		mock.ExpectExec(`UPDATE parent_student_relationships`).
+			WithArgs(5, 3).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		c, rec := newRequest("admin")
+		require.NoError(t, h.ApproveParentRelationship(c))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("non-admin is forbidden", func(t *testing.T) {
+		h := &ParentHandler{}
+		c, rec := newRequest("faculty")
+		require.NoError(t, h.ApproveParentRelationship(c))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"eduhub/server/internal/helpers"
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/services/quiz"
+	"eduhub/server/internal/validation"
 
 	"github.com/labstack/echo/v4"
 )
@@ -53,7 +54,8 @@ func (h *QuestionHandler) ListQuestions(c echo.Context) error {
 		}
 	}
 
-	questions, err := h.questionService.ListQuestionsByQuiz(c.Request().Context(), collegeID, quizID, limit, offset)
+	role, _ := helpers.GetUserRole(c)
+	questions, err := h.questionService.ListQuestionsByQuiz(c.Request().Context(), collegeID, quizID, limit, offset, role)
 	if err != nil {
 		return helpers.Error(c, err.Error(), 500)
 	}
@@ -83,6 +85,9 @@ func (h *QuestionHandler) CreateQuestion(c echo.Context) error {
 
 	err = h.questionService.CreateQuestion(c.Request().Context(), collegeID, &req)
 	if err != nil {
+		if fields := validation.Fields(err); fields != nil {
+			return helpers.ValidationError(c, fields)
+		}
 		return helpers.Error(c, err.Error(), 400)
 	}
 
@@ -102,7 +107,8 @@ func (h *QuestionHandler) GetQuestion(c echo.Context) error {
 		return err
 	}
 
-	question, err := h.questionService.GetQuestion(c.Request().Context(), collegeID, questionID)
+	role, _ := helpers.GetUserRole(c)
+	question, err := h.questionService.GetQuestion(c.Request().Context(), collegeID, questionID, role)
 	if err != nil {
 		return helpers.Error(c, "question not found", 404)
 	}
@@ -132,6 +138,9 @@ func (h *QuestionHandler) UpdateQuestion(c echo.Context) error {
 
 	err = h.questionService.UpdateQuestion(c.Request().Context(), collegeID, &req)
 	if err != nil {
+		if fields := validation.Fields(err); fields != nil {
+			return helpers.ValidationError(c, fields)
+		}
 		return helpers.Error(c, err.Error(), 500)
 	}
 
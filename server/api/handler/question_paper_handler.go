@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"eduhub/server/internal/helpers"
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/services/audit"
+	"eduhub/server/internal/services/exam"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxQuestionPaperSize caps an uploaded question paper at 20MB
+const maxQuestionPaperSize = 20 * 1024 * 1024
+
+type QuestionPaperHandler struct {
+	paperService exam.QuestionPaperService
+	auditService audit.AuditService
+}
+
+func NewQuestionPaperHandler(paperService exam.QuestionPaperService, auditService audit.AuditService) *QuestionPaperHandler {
+	return &QuestionPaperHandler{
+		paperService: paperService,
+		auditService: auditService,
+	}
+}
+
+// UploadQuestionPaper stores the PDF for one question paper set of an exam
+// POST /api/v1/exams/:examID/question-papers/:set
+func (h *QuestionPaperHandler) UploadQuestionPaper(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		return helpers.Error(c, "user ID required", 401)
+	}
+
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+	paperSet, err := strconv.Atoi(c.Param("set"))
+	if err != nil {
+		return helpers.Error(c, "invalid question paper set", 400)
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return helpers.Error(c, "file is required", 400)
+	}
+	if file.Size > maxQuestionPaperSize {
+		return helpers.Error(c, "question paper exceeds 20MB limit", 400)
+	}
+	if strings.ToLower(filepath.Ext(file.Filename)) != ".pdf" {
+		return helpers.Error(c, "question papers must be PDF files", 400)
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return helpers.Error(c, "failed to open file", 500)
+	}
+	defer src.Close()
+
+	paper, err := h.paperService.UploadQuestionPaper(c.Request().Context(), collegeID, examID, paperSet, userID, exam.QuestionPaperUpload{
+		FileName:    file.Filename,
+		ContentType: "application/pdf",
+		Size:        file.Size,
+		Body:        src,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, exam.ErrExamNotFound):
+			return helpers.Error(c, err.Error(), 404)
+		case errors.Is(err, exam.ErrInvalidPaperSet):
+			return helpers.Error(c, err.Error(), 400)
+		case errors.Is(err, exam.ErrQuestionPaperLocked):
+			return helpers.Error(c, err.Error(), 409)
+		}
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	h.logAccess(c, collegeID, userID, "UPLOAD", examID, models.JSONMap{
+		"paper_id":  paper.ID,
+		"paper_set": paperSet,
+		"filename":  file.Filename,
+		"size":      file.Size,
+	})
+	return helpers.Success(c, paper, 201)
+}
+
+// ListQuestionPapers lists the papers uploaded for an exam
+// GET /api/v1/exams/:examID/question-papers
+func (h *QuestionPaperHandler) ListQuestionPapers(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	papers, err := h.paperService.ListQuestionPapers(c.Request().Context(), collegeID, examID)
+	if err != nil {
+		if errors.Is(err, exam.ErrExamNotFound) {
+			return helpers.Error(c, err.Error(), 404)
+		}
+		return helpers.Error(c, err.Error(), 500)
+	}
+	return helpers.Success(c, papers, 200)
+}
+
+// GetMyQuestionPaper gives the calling student a short-lived link to their
+// question paper while the exam is running. Every attempt is audited,
+// including refused ones.
+// GET /api/v1/exams/:examID/question-paper
+func (h *QuestionPaperHandler) GetMyQuestionPaper(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+	studentID, err := helpers.ExtractStudentID(c)
+	if err != nil {
+		return err
+	}
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		return helpers.Error(c, "user ID required", 401)
+	}
+	examID, err := strconv.Atoi(c.Param("examID"))
+	if err != nil {
+		return helpers.Error(c, "invalid exam ID", 400)
+	}
+
+	delivery, err := h.paperService.GetStudentQuestionPaper(c.Request().Context(), collegeID, examID, studentID)
+	changes := models.JSONMap{"student_id": studentID, "granted": err == nil}
+	if err != nil {
+		changes["reason"] = err.Error()
+	} else {
+		changes["paper_set"] = delivery.PaperSet
+	}
+	h.logAccess(c, collegeID, userID, "READ", examID, changes)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, exam.ErrExamNotFound), errors.Is(err, exam.ErrQuestionPaperNotFound):
+			return helpers.Error(c, err.Error(), 404)
+		case errors.Is(err, exam.ErrEnrollmentNotFound), errors.Is(err, exam.ErrEnrollmentDisqualified),
+			errors.Is(err, exam.ErrQuestionPaperUnavailable):
+			return helpers.Error(c, err.Error(), 403)
+		case errors.Is(err, exam.ErrPaperSetNotAssigned):
+			return helpers.Error(c, err.Error(), 409)
+		}
+		return helpers.Error(c, err.Error(), 500)
+	}
+	return helpers.Success(c, delivery, 200)
+}
+
+// logAccess records a question paper audit event against the exam
+func (h *QuestionPaperHandler) logAccess(c echo.Context, collegeID, userID int, action string, examID int, changes models.JSONMap) {
+	logExamAudit(c, h.auditService, collegeID, userID, action, "exam_question_paper", examID, changes)
+}
@@ -1,11 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"strconv"
 
 	"eduhub/server/internal/helpers"
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/services/quiz"
+	"eduhub/server/internal/validation"
 
 	"github.com/labstack/echo/v4"
 )
@@ -40,6 +42,15 @@ func (h *QuizAttemptHandler) StartQuizAttempt(c echo.Context) error {
 
 	attempt, err := h.attemptService.StartAttempt(c.Request().Context(), collegeID, quizID, studentID)
 	if err != nil {
+		if fields := validation.Fields(err); fields != nil {
+			return helpers.ValidationError(c, fields)
+		}
+		if errors.Is(err, quiz.ErrQuizNotFound) {
+			return helpers.ResourceNotFound(c, "quiz")
+		}
+		if errors.Is(err, quiz.ErrQuizNotYetOpen) || errors.Is(err, quiz.ErrQuizClosed) || errors.Is(err, quiz.ErrQuizNotInSection) {
+			return helpers.Error(c, err.Error(), 403)
+		}
 		return helpers.Error(c, err.Error(), 400)
 	}
 
@@ -79,6 +90,68 @@ func (h *QuizAttemptHandler) SubmitQuizAttempt(c echo.Context) error {
 	return helpers.Success(c, result, 200)
 }
 
+// AutosaveAnswers stores in-progress answers without submitting the attempt
+// PUT /api/attempts/:attemptID/autosave
+func (h *QuizAttemptHandler) AutosaveAnswers(c echo.Context) error {
+	attemptID, err := strconv.Atoi(c.Param("attemptID"))
+	if err != nil {
+		return helpers.Error(c, "invalid attempt ID", 400)
+	}
+
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	studentID, err := helpers.ExtractStudentID(c)
+	if err != nil {
+		return helpers.Error(c, "student ID required", 401)
+	}
+
+	var req struct {
+		Answers []models.StudentAnswer `json:"answers"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return helpers.Error(c, "invalid request body", 400)
+	}
+
+	result, err := h.attemptService.AutosaveAnswers(c.Request().Context(), collegeID, attemptID, studentID, req.Answers)
+	if err != nil {
+		if errors.Is(err, quiz.ErrAttemptNotInProgress) {
+			return helpers.Error(c, err.Error(), 409)
+		}
+		return helpers.Error(c, err.Error(), 400)
+	}
+
+	return helpers.Success(c, result, 200)
+}
+
+// GetSavedAnswers returns the answers saved so far for the student's attempt
+// GET /api/attempts/:attemptID/answers
+func (h *QuizAttemptHandler) GetSavedAnswers(c echo.Context) error {
+	attemptID, err := strconv.Atoi(c.Param("attemptID"))
+	if err != nil {
+		return helpers.Error(c, "invalid attempt ID", 400)
+	}
+
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	studentID, err := helpers.ExtractStudentID(c)
+	if err != nil {
+		return helpers.Error(c, "student ID required", 401)
+	}
+
+	answers, err := h.attemptService.GetSavedAnswers(c.Request().Context(), collegeID, attemptID, studentID)
+	if err != nil {
+		return helpers.Error(c, err.Error(), 404)
+	}
+
+	return helpers.Success(c, answers, 200)
+}
+
 // GetQuizAttempt retrieves details of a quiz attempt
 func (h *QuizAttemptHandler) GetQuizAttempt(c echo.Context) error {
 	attemptIDStr := c.Param("attemptID")
@@ -92,7 +165,8 @@ func (h *QuizAttemptHandler) GetQuizAttempt(c echo.Context) error {
 		return err
 	}
 
-	attempt, err := h.attemptService.GetAttempt(c.Request().Context(), collegeID, attemptID)
+	role, _ := helpers.GetUserRole(c)
+	attempt, err := h.attemptService.GetAttempt(c.Request().Context(), collegeID, attemptID, role)
 	if err != nil {
 		return helpers.Error(c, "attempt not found", 404)
 	}
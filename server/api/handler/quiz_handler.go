@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"strconv"
 
 	"eduhub/server/internal/helpers"
@@ -8,6 +9,7 @@ import (
 	"eduhub/server/internal/services/course"
 	"eduhub/server/internal/services/enrollment"
 	"eduhub/server/internal/services/quiz"
+	"eduhub/server/internal/validation"
 
 	"github.com/labstack/echo/v4"
 )
@@ -90,6 +92,9 @@ func (h *QuizHandler) CreateQuiz(c echo.Context) error {
 
 	err = h.quizService.CreateQuiz(c.Request().Context(), &quizReq)
 	if err != nil {
+		if fields := validation.Fields(err); fields != nil {
+			return helpers.ValidationError(c, fields)
+		}
 		return helpers.Error(c, err.Error(), 400)
 	}
 
@@ -109,12 +114,23 @@ func (h *QuizHandler) GetQuiz(c echo.Context) error {
 		return err
 	}
 
-	quiz, err := h.quizService.GetQuizByID(c.Request().Context(), collegeID, quizID)
+	// ?include=questions nests the questions and their options in the quiz.
+	// Students only see them without the correct answers.
+	if c.QueryParam("include") == "questions" {
+		role, _ := helpers.GetUserRole(c)
+		full, err := h.quizService.GetFullQuiz(c.Request().Context(), collegeID, quizID, role)
+		if err != nil {
+			return helpers.LookupError(c, err, "quiz", quiz.ErrQuizNotFound)
+		}
+		return helpers.Success(c, full, 200)
+	}
+
+	found, err := h.quizService.GetQuizByID(c.Request().Context(), collegeID, quizID)
 	if err != nil {
-		return helpers.Error(c, "quiz not found", 404)
+		return helpers.LookupError(c, err, "quiz", quiz.ErrQuizNotFound)
 	}
 
-	return helpers.Success(c, quiz, 200)
+	return helpers.Success(c, found, 200)
 }
 
 // UpdateQuiz updates a quiz
@@ -131,9 +147,9 @@ func (h *QuizHandler) UpdateQuiz(c echo.Context) error {
 	}
 
 	// Get the existing quiz first
-	quiz, err := h.quizService.GetQuizByID(c.Request().Context(), collegeID, quizID)
+	existing, err := h.quizService.GetQuizByID(c.Request().Context(), collegeID, quizID)
 	if err != nil {
-		return helpers.Error(c, "quiz not found", 404)
+		return helpers.LookupError(c, err, "quiz", quiz.ErrQuizNotFound)
 	}
 
 	// Bind update request
@@ -144,20 +160,42 @@ func (h *QuizHandler) UpdateQuiz(c echo.Context) error {
 
 	// Apply updates to quiz
 	if req.Title != nil {
-		quiz.Title = *req.Title
+		existing.Title = *req.Title
 	}
 	if req.Description != nil {
-		quiz.Description = *req.Description
+		existing.Description = *req.Description
 	}
 	if req.TimeLimitMinutes != nil {
-		quiz.TimeLimitMinutes = *req.TimeLimitMinutes
+		existing.TimeLimitMinutes = *req.TimeLimitMinutes
 	}
 	if req.DueDate != nil {
-		quiz.DueDate = *req.DueDate
+		existing.DueDate = *req.DueDate
+	}
+	if req.AvailableFrom != nil {
+		existing.AvailableFrom = req.AvailableFrom
+	}
+	if req.AvailableUntil != nil {
+		existing.AvailableUntil = req.AvailableUntil
+	}
+	if req.SectionID != nil {
+		// Section 0 opens the quiz to the whole course again
+		existing.SectionID = req.SectionID
+		if *req.SectionID == 0 {
+			existing.SectionID = nil
+		}
 	}
 
-	err = h.quizService.UpdateQuiz(c.Request().Context(), quiz)
+	err = h.quizService.UpdateQuiz(c.Request().Context(), existing)
 	if err != nil {
+		if fields := validation.Fields(err); fields != nil {
+			return helpers.ValidationError(c, fields)
+		}
+		if errors.Is(err, quiz.ErrSectionNotFound) {
+			return helpers.Error(c, err.Error(), 400)
+		}
+		if errors.Is(err, quiz.ErrQuizNotFound) {
+			return helpers.ResourceNotFound(c, "quiz")
+		}
 		return helpers.Error(c, err.Error(), 500)
 	}
 
@@ -179,12 +217,97 @@ func (h *QuizHandler) DeleteQuiz(c echo.Context) error {
 
 	err = h.quizService.DeleteQuiz(c.Request().Context(), collegeID, quizID)
 	if err != nil {
+		if errors.Is(err, quiz.ErrQuizNotFound) {
+			return helpers.ResourceNotFound(c, "quiz")
+		}
 		return helpers.Error(c, err.Error(), 500)
 	}
 
 	return helpers.Success(c, "Quiz deleted successfully", 200)
 }
 
+// GrantQuizExtension lets a student start a quiz after it closes
+// POST /api/courses/:courseID/quizzes/:quizID/extensions
+func (h *QuizHandler) GrantQuizExtension(c echo.Context) error {
+	quizID, err := strconv.Atoi(c.Param("quizID"))
+	if err != nil {
+		return helpers.Error(c, "invalid quiz ID", 400)
+	}
+
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var extension models.QuizExtension
+	if err := c.Bind(&extension); err != nil {
+		return helpers.Error(c, "invalid request body", 400)
+	}
+	extension.QuizID = quizID
+	extension.CollegeID = collegeID
+	extension.GrantedBy = &userID
+
+	if err := h.quizService.GrantExtension(c.Request().Context(), &extension); err != nil {
+		if errors.Is(err, quiz.ErrQuizNotFound) {
+			return helpers.ResourceNotFound(c, "quiz")
+		}
+		return helpers.Error(c, err.Error(), 400)
+	}
+
+	return helpers.Success(c, extension, 201)
+}
+
+// ListQuizExtensions lists the per-student extensions for a quiz
+// GET /api/courses/:courseID/quizzes/:quizID/extensions
+func (h *QuizHandler) ListQuizExtensions(c echo.Context) error {
+	quizID, err := strconv.Atoi(c.Param("quizID"))
+	if err != nil {
+		return helpers.Error(c, "invalid quiz ID", 400)
+	}
+
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	extensions, err := h.quizService.ListExtensions(c.Request().Context(), collegeID, quizID)
+	if err != nil {
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, extensions, 200)
+}
+
+// RevokeQuizExtension removes a student's extension for a quiz
+// DELETE /api/courses/:courseID/quizzes/:quizID/extensions/:studentID
+func (h *QuizHandler) RevokeQuizExtension(c echo.Context) error {
+	quizID, err := strconv.Atoi(c.Param("quizID"))
+	if err != nil {
+		return helpers.Error(c, "invalid quiz ID", 400)
+	}
+
+	studentID, err := strconv.Atoi(c.Param("studentID"))
+	if err != nil {
+		return helpers.Error(c, "invalid student ID", 400)
+	}
+
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.quizService.RevokeExtension(c.Request().Context(), collegeID, quizID, studentID); err != nil {
+		return helpers.LookupError(c, err, "extension", quiz.ErrQuizExtensionNotFound)
+	}
+
+	return helpers.Success(c, "Extension revoked successfully", 200)
+}
+
 // GetMyQuizzes returns all quizzes across all enrolled courses for current student
 func (h *QuizHandler) GetMyQuizzes(c echo.Context) error {
 	collegeID, err := helpers.ExtractCollegeID(c)
@@ -224,6 +347,10 @@ func (h *QuizHandler) GetMyQuizzes(c echo.Context) error {
 		}
 
 		for _, quiz := range quizzes {
+			// Quizzes set for another section are not the student's
+			if quiz.SectionID != nil && (enrollmentRecord.SectionID == nil || *quiz.SectionID != *enrollmentRecord.SectionID) {
+				continue
+			}
 			result = append(result, map[string]any{
 				"id":              quiz.ID,
 				"title":           quiz.Title,
@@ -232,6 +359,8 @@ func (h *QuizHandler) GetMyQuizzes(c echo.Context) error {
 				"courseName":      courseName,
 				"duration":        quiz.TimeLimitMinutes,
 				"dueDate":         quiz.DueDate,
+				"availableFrom":   quiz.AvailableFrom,
+				"availableUntil":  quiz.AvailableUntil,
 				"status":          "not_started",
 				"attempts":        0,
 				"maxAttempts":     1,
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/services/quiz"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scopedQuizService holds quiz 3 of college 1 and fails the way the
+// repository does for any other college
+type scopedQuizService struct {
+	quiz.QuizService
+	deleted bool
+}
+
+func (s *scopedQuizService) GetQuizByID(ctx context.Context, collegeID, quizID int) (*models.Quiz, error) {
+	if collegeID != 1 || quizID != 3 {
+		return nil, fmt.Errorf("GetQuizByID: %w (id: %d, college: %d)", quiz.ErrQuizNotFound, quizID, collegeID)
+	}
+	return &models.Quiz{ID: 3, CollegeID: 1, Title: "Quiz 1"}, nil
+}
+
+func (s *scopedQuizService) DeleteQuiz(ctx context.Context, collegeID, quizID int) error {
+	if collegeID != 1 || quizID != 3 {
+		return fmt.Errorf("failed to delete quiz: %w", quiz.ErrQuizNotFound)
+	}
+	s.deleted = true
+	return nil
+}
+
+func quizRequest(method string, collegeID int) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(method, "/", nil), rec)
+	c.SetParamNames("courseID", "quizID")
+	c.SetParamValues("5", "3")
+	c.Set("college_id", collegeID)
+	return c, rec
+}
+
+func TestQuizHandlerCrossCollegeAccess(t *testing.T) {
+	t.Run("quiz of another college is not found", func(t *testing.T) {
+		h := &QuizHandler{quizService: &scopedQuizService{}}
+		c, rec := quizRequest(http.MethodGet, 2)
+
+		require.NoError(t, h.GetQuiz(c))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), "quiz not found")
+		assert.NotContains(t, rec.Body.String(), "college")
+	})
+
+	t.Run("own quiz is returned", func(t *testing.T) {
+		h := &QuizHandler{quizService: &scopedQuizService{}}
+		c, rec := quizRequest(http.MethodGet, 1)
+
+		require.NoError(t, h.GetQuiz(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("deleting another college's quiz is not found", func(t *testing.T) {
+		svc := &scopedQuizService{}
+		h := &QuizHandler{quizService: svc}
+		c, rec := quizRequest(http.MethodDelete, 2)
+
+		require.NoError(t, h.DeleteQuiz(c))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.False(t, svc.deleted)
+	})
+}
@@ -7,15 +7,21 @@ import (
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
 
-func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *middleware.ParamValidator) {
+func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *middleware.ParamValidator, idem *middleware.IdempotencyMiddleware) {
 	// Initialize rate limiters
-	authRateLimiter := middleware.StrictRateLimiter()     // 5 requests per minute for auth
-	passwordRateLimiter := middleware.StrictRateLimiter() // 5 requests per minute for password ops
+	authRateLimiter := middleware.StrictRateLimiter()           // 5 requests per minute for auth
+	passwordRateLimiter := middleware.StrictRateLimiter()       // 5 requests per minute for password ops
+	parentLinkRateLimiter := middleware.StrictRateLimiter()     // 5 requests per minute for parent link emails
+	proctoringRateLimiter := middleware.ProctoringRateLimiter() // 1 event per second per student, bursts of 20
 
 	// Public routes
 	e.GET("/health", a.System.HealthCheck)
 	e.GET("/ready", a.System.ReadinessCheck)
 	e.GET("/alive", a.System.LivenessCheck)
+	e.GET("/version", a.System.Version)
+	if a.Metrics != nil {
+		e.GET("/metrics", a.Metrics.Metrics)
+	}
 
 	// Register Swagger routes - make sure these are registered correctly
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
@@ -47,6 +53,9 @@ func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *mi
 
 	auth.POST("/change-password", a.Auth.ChangePassword, m.ValidateToken, passwordRateLimiter.Middleware())
 
+	// Razorpay payment webhooks (public, verified by X-Razorpay-Signature)
+	e.POST("/api/v1/payments/razorpay/webhook", a.Fee.HandleWebhook)
+
 	apiGroup := e.Group("/api", m.ValidateToken, m.RequireCollege)
 
 	// Dashboard
@@ -55,6 +64,7 @@ func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *mi
 	// Student Dashboard (student-specific comprehensive view)
 	student := apiGroup.Group("/student", m.RequireRole(middleware.RoleStudent), m.LoadStudentProfile)
 	student.GET("/dashboard", a.Dashboard.GetStudentDashboard)
+	student.POST("/parent-links", a.Parent.RequestParentLink, parentLinkRateLimiter.Middleware())
 
 	// User profile management
 	profile := apiGroup.Group("/profile")
@@ -68,6 +78,11 @@ func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *mi
 	apiGroup.GET("/settings", a.Settings.GetSettings)
 	apiGroup.PUT("/settings", a.Settings.UpdateSettings)
 
+	// Global search across students, courses and exams
+	apiGroup.GET("/search", a.Search.Search,
+		m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty, middleware.RoleStudent),
+		m.LoadStudentProfile)
+
 	// College management
 	college := apiGroup.Group("/college", m.RequireRole(middleware.RoleAdmin))
 	college.GET("", a.College.GetCollegeDetails)
@@ -92,6 +107,9 @@ func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *mi
 	students.PATCH("/:studentID", a.Student.UpdateStudent, m.RequireRole(middleware.RoleAdmin), pv.ValidateIDParam("studentID")) // PATCH: Allows partial updates to student details
 	students.DELETE("/:studentID", a.Student.DeleteStudent, m.RequireRole(middleware.RoleAdmin), pv.ValidateIDParam("studentID"))
 	students.PUT("/:studentID/freeze", a.Student.FreezeStudent, m.RequireRole(middleware.RoleAdmin), pv.ValidateIDParam("studentID"))
+	students.POST("/:studentID/deactivate", a.Student.DeactivateStudent, m.RequireRole(middleware.RoleAdmin), pv.ValidateIDParam("studentID"))
+	students.POST("/:studentID/reactivate", a.Student.ReactivateStudent, m.RequireRole(middleware.RoleAdmin), pv.ValidateIDParam("studentID"))
+	students.GET("/:studentID/status-history", a.Student.GetStudentStatusHistory, m.RequireRole(middleware.RoleAdmin), pv.ValidateIDParam("studentID"))
 
 	// Course management
 	courses := apiGroup.Group("/courses")
@@ -106,6 +124,14 @@ func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *mi
 	courses.DELETE("/:courseID/students/:studentID", a.Course.RemoveStudent, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty), pv.ValidateMultipleIDParams("courseID", "studentID"))
 	courses.GET("/:courseID/students", a.Course.ListEnrolledStudents, pv.ValidateIDParam("courseID"))
 
+	// Course sections
+	courses.GET("/:courseID/sections", a.Section.ListSections, pv.ValidateIDParam("courseID"))
+	courses.POST("/:courseID/sections", a.Section.CreateSection, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty), pv.ValidateIDParam("courseID"))
+	courses.PATCH("/:courseID/sections/:sectionID", a.Section.RenameSection, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty), pv.ValidateMultipleIDParams("courseID", "sectionID"))
+	courses.DELETE("/:courseID/sections/:sectionID", a.Section.DeleteSection, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty), pv.ValidateMultipleIDParams("courseID", "sectionID"))
+	courses.PUT("/:courseID/sections/:sectionID/students", a.Section.AssignStudents, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty), pv.ValidateMultipleIDParams("courseID", "sectionID"))
+	courses.DELETE("/:courseID/sections/:sectionID/students/:studentID", a.Section.UnassignStudent, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty), pv.ValidateMultipleIDParams("courseID", "sectionID", "studentID"))
+
 	// Course Materials & Modules
 	// Module management (nested under courses)
 	modules := apiGroup.Group("/courses/:courseID/modules")
@@ -159,6 +185,8 @@ func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *mi
 		m.VerifyStudentOwnership())
 	attendance.POST("/mark/bulk/course/:courseID/lecture/:lectureID", a.Attendance.MarkBulkAttendance,
 		m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
+	attendance.POST("/mark/roster/course/:courseID", a.Attendance.MarkRosterAttendance,
+		m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 	attendance.GET("/course/:courseID/lecture/:lectureID/qrcode", a.Attendance.GenerateQRCode,
 		m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 	attendance.GET("/course/:courseID", a.Attendance.GetAttendanceByCourse,
@@ -171,6 +199,10 @@ func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *mi
 		m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty, middleware.RoleStudent),
 		m.LoadStudentProfile,
 		m.VerifyStudentOwnership())
+	attendance.GET("/student/:studentID/trends", a.Analytics.GetStudentAttendanceTrends,
+		m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty, middleware.RoleStudent),
+		m.LoadStudentProfile,
+		m.VerifyStudentOwnership())
 	attendance.GET("/student/:studentID/course/:courseID", a.Attendance.GetAttendanceByStudentAndCourse,
 		m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty, middleware.RoleStudent),
 		m.LoadStudentProfile,
@@ -225,6 +257,7 @@ func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *mi
 	assignments.PATCH("/:assignmentID", a.Assignment.UpdateAssignment, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 	assignments.DELETE("/:assignmentID", a.Assignment.DeleteAssignment, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 	assignments.POST("/:assignmentID/submit", a.Assignment.SubmitAssignment, m.RequireRole(middleware.RoleStudent), m.LoadStudentProfile)
+	assignments.POST("/:assignmentID/submission-file", a.Assignment.UploadSubmissionFile, m.RequireRole(middleware.RoleStudent), m.LoadStudentProfile)
 	assignments.POST("/submissions/:submissionID/grade", a.Assignment.GradeSubmission, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 	// Assignment grading enhancements
 	assignments.GET("/:assignmentID/submissions", a.Assignment.ListSubmissionsByAssignment, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
@@ -244,6 +277,9 @@ func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *mi
 	quizzes.GET("/:quizID", a.Quiz.GetQuiz)
 	quizzes.PATCH("/:quizID", a.Quiz.UpdateQuiz, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 	quizzes.DELETE("/:quizID", a.Quiz.DeleteQuiz, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
+	quizzes.GET("/:quizID/extensions", a.Quiz.ListQuizExtensions, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
+	quizzes.POST("/:quizID/extensions", a.Quiz.GrantQuizExtension, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
+	quizzes.DELETE("/:quizID/extensions/:studentID", a.Quiz.RevokeQuizExtension, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 
 	// Convenience endpoint for all quizzes (current user)
 	quizzesAll := apiGroup.Group("/quizzes")
@@ -275,13 +311,16 @@ func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *mi
 	attemptRoutes := apiGroup.Group("/attempts")
 	attemptRoutes.GET("/:attemptID", a.QuizAttempt.GetQuizAttempt)
 	attemptRoutes.POST("/:attemptID/submit", a.QuizAttempt.SubmitQuizAttempt, m.RequireRole(middleware.RoleStudent), m.LoadStudentProfile)
+	attemptRoutes.PUT("/:attemptID/autosave", a.QuizAttempt.AutosaveAnswers, m.RequireRole(middleware.RoleStudent), m.LoadStudentProfile)
+	attemptRoutes.GET("/:attemptID/answers", a.QuizAttempt.GetSavedAnswers, m.RequireRole(middleware.RoleStudent), m.LoadStudentProfile)
 	attemptRoutes.GET("/student/:studentID", a.QuizAttempt.ListStudentAttempts)
 
 	// File Upload management (legacy)
 	files := apiGroup.Group("/files")
 	files.POST("/upload", a.FileUpload.UploadFile)
 	files.DELETE("", a.FileUpload.DeleteFile)
-	files.GET("/url", a.FileUpload.GetFileURL)
+	files.GET("/url", a.FileUpload.GetFileURL, m.LoadStudentProfile)
+	files.GET("/presigned-url", a.FileUpload.GetPresignedURL, m.LoadStudentProfile)
 
 	// Advanced File Management with versioning
 	fileGroup := apiGroup.Group("/file-management")
@@ -322,8 +361,12 @@ func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *mi
 	// Analytics management
 	analytics := apiGroup.Group("/analytics", m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 	analytics.GET("/dashboard", a.Analytics.GetCollegeDashboard)
+	analytics.GET("/dashboard/trend", a.Analytics.GetDashboardTrend)
+	analytics.POST("/dashboard/snapshots", a.Analytics.CaptureDashboardSnapshot, m.RequireRole(middleware.RoleAdmin))
 	analytics.GET("/students/:studentID/performance", a.Analytics.GetStudentPerformance)
 	analytics.GET("/courses/:courseID/analytics", a.Analytics.GetCourseAnalytics)
+	analytics.GET("/courses/:courseID/analytics/history", a.Analytics.GetCourseAnalyticsHistory)
+	analytics.GET("/faculty/:facultyID/workload", a.Analytics.GetFacultyWorkload)
 	analytics.GET("/courses/:courseID/grades/distribution", a.Analytics.GetGradeDistribution)
 	analytics.GET("/attendance/trends", a.Analytics.GetAttendanceTrends)
 
@@ -334,6 +377,10 @@ func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *mi
 	advancedAnalytics.GET("/learning-analytics", a.AdvancedAnalytics.GetLearningAnalytics)
 	advancedAnalytics.GET("/performance/:entityType/:entityID/trends", a.AdvancedAnalytics.GetPerformanceTrends)
 	advancedAnalytics.GET("/courses/comparative", a.AdvancedAnalytics.GetComparativeAnalysis)
+	advancedAnalytics.POST("/config/reload", a.AdvancedAnalytics.ReloadConfig, m.RequireRole(middleware.RoleAdmin))
+	advancedAnalytics.GET("/skills", a.AdvancedAnalytics.ListCourseSkills)
+	advancedAnalytics.PUT("/courses/:courseID/skill", a.AdvancedAnalytics.SetCourseSkill, m.RequireRole(middleware.RoleAdmin))
+	advancedAnalytics.DELETE("/courses/:courseID/skill", a.AdvancedAnalytics.DeleteCourseSkill, m.RequireRole(middleware.RoleAdmin))
 
 	// Batch Operations management
 	batch := apiGroup.Group("/batch", m.RequireRole(middleware.RoleAdmin))
@@ -371,6 +418,11 @@ func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *mi
 	webhooks.DELETE("/:webhookID", a.Webhook.DeleteWebhook)
 	webhooks.POST("/:webhookID/test", a.Webhook.TestWebhook)
 
+	// Background emails that could not be delivered
+	emails := apiGroup.Group("/emails", m.RequireRole(middleware.RoleAdmin))
+	emails.GET("/dead-letters", a.Email.ListDeadLetters)
+	emails.POST("/dead-letters/:letterID/retry", a.Email.RetryDeadLetter)
+
 	// Audit Logging management
 	audit := apiGroup.Group("/audit", m.RequireRole(middleware.RoleAdmin))
 	audit.GET("/logs", a.Audit.GetAuditLogs)
@@ -405,6 +457,7 @@ func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *mi
 	// Fee assignments
 	fees.POST("/assign", a.Fee.AssignFeeToStudent, m.RequireRole(middleware.RoleAdmin))
 	fees.POST("/bulk-assign", a.Fee.BulkAssignFee, m.RequireRole(middleware.RoleAdmin))
+	fees.GET("/students/:studentID/ledger", a.Fee.GetStudentLedger, m.RequireRole(middleware.RoleAdmin))
 
 	// Student fee operations
 	fees.GET("/my-fees", a.Fee.GetStudentFees, m.RequireRole(middleware.RoleStudent), m.LoadStudentProfile)
@@ -412,7 +465,6 @@ func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *mi
 	fees.POST("/payments", a.Fee.MakeFeePayment, m.RequireRole(middleware.RoleStudent), m.LoadStudentProfile)
 	fees.POST("/payments/online", a.Fee.InitiateOnlinePayment, m.RequireRole(middleware.RoleStudent), m.LoadStudentProfile)
 	fees.POST("/payments/verify", a.Fee.VerifyPayment, m.RequireRole(middleware.RoleStudent), m.LoadStudentProfile)
-	fees.POST("/webhook", a.Fee.HandleWebhook) // Razorpay Webhook is public
 	fees.GET("/my-payments", a.Fee.GetStudentPayments, m.RequireRole(middleware.RoleStudent), m.LoadStudentProfile)
 
 	// Timetable Management
@@ -430,29 +482,60 @@ func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *mi
 	exams := apiGroup.Group("/exams")
 	// Exam CRUD
 	exams.GET("", a.Exam.ListExams)
+	exams.GET("/clashes", a.Exam.ListExamClashes, m.RequireRole(middleware.RoleAdmin))
+	exams.POST("/timetable/dry-run", a.Exam.PreviewTimetable, m.RequireRole(middleware.RoleAdmin))
+	exams.POST("/timetable/confirm", a.Exam.ConfirmTimetable, m.RequireRole(middleware.RoleAdmin))
 	exams.POST("", a.Exam.CreateExam, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 	exams.GET("/:examID", a.Exam.GetExam)
 	exams.PUT("/:examID", a.Exam.UpdateExam, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 	exams.DELETE("/:examID", a.Exam.DeleteExam, m.RequireRole(middleware.RoleAdmin))
+	exams.POST("/:examID/restore", a.Exam.RestoreExam, m.RequireRole(middleware.RoleAdmin))
+	exams.POST("/:examID/cancel", a.Exam.CancelExam, m.RequireRole(middleware.RoleAdmin))
 	exams.GET("/:examID/stats", a.Exam.GetExamStats, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 
 	// Enrollment
-	exams.POST("/:examID/enroll", a.Exam.EnrollStudent, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
+	exams.POST("/:examID/enroll", a.Exam.EnrollStudent, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty), idem.Middleware())
 	exams.POST("/:examID/enroll-bulk", a.Exam.EnrollMultipleStudents, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
+	exams.POST("/:examID/enroll-course", a.Exam.EnrollCourse, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 	exams.GET("/:examID/enrollments", a.Exam.ListEnrollments, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 	exams.PUT("/:examID/enrollments/:studentID", a.Exam.UpdateEnrollment, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 	exams.DELETE("/:examID/enrollments/:studentID", a.Exam.DeleteEnrollment, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
+	exams.PUT("/:examID/enrollments/:studentID/accommodations", a.Exam.SetAccommodations, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
+
+	// Invigilation
+	exams.GET("/:examID/invigilators", a.Exam.ListInvigilators, m.RequireRole(middleware.RoleAdmin))
+	exams.GET("/:examID/invigilators/suggestions", a.Exam.SuggestInvigilators, m.RequireRole(middleware.RoleAdmin))
+	exams.POST("/:examID/invigilators", a.Exam.AssignInvigilator, m.RequireRole(middleware.RoleAdmin))
+	exams.DELETE("/:examID/invigilators/:userID", a.Exam.RemoveInvigilator, m.RequireRole(middleware.RoleAdmin))
+	exams.POST("/:examID/check-in", a.Exam.CheckInStudent, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
+	exams.POST("/:examID/extensions", a.Exam.GrantExtension, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
+	exams.GET("/:examID/extensions", a.Exam.ListExtensions, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
+	exams.POST("/:examID/proctoring-events", a.Exam.RecordProctoringEvent,
+		m.RequireRole(middleware.RoleStudent), m.LoadStudentProfile, proctoringRateLimiter.Middleware())
+	exams.GET("/:examID/proctoring-events/:studentID", a.Exam.ReviewProctoringEvents, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
+
+	// Question papers
+	exams.POST("/:examID/question-papers/:set", a.QuestionPaper.UploadQuestionPaper, m.RequireRole(middleware.RoleAdmin))
+	exams.GET("/:examID/question-papers", a.QuestionPaper.ListQuestionPapers, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
+	exams.GET("/:examID/question-paper", a.QuestionPaper.GetMyQuestionPaper, m.RequireRole(middleware.RoleStudent), m.LoadStudentProfile)
 
 	// Seat allocation and hall tickets
 	exams.POST("/:examID/allocate-seats", a.Exam.AllocateSeats, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
+	exams.POST("/:examID/allocate-seats/preview", a.Exam.AllocateSeatsPreview, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
+	exams.POST("/:examID/allocate-seats/confirm", a.Exam.ConfirmSeatAllocation, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 	exams.GET("/:examID/hall-ticket/:studentID", a.Exam.GenerateHallTicket)
+	exams.GET("/:examID/hall-ticket/:studentID/verify", a.Exam.VerifyHallTicket, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 	exams.POST("/:examID/hall-tickets", a.Exam.GenerateAllHallTickets, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 
 	// Results
-	exams.POST("/:examID/results", a.Exam.CreateResult, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
+	exams.POST("/:examID/results", a.Exam.CreateResult, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty), idem.Middleware())
 	exams.GET("/:examID/results", a.Exam.ListResults, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
+	exams.POST("/:examID/results/publish", a.Exam.PublishResults, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 	exams.GET("/:examID/results/:studentID", a.Exam.GetResult)
+	exams.PUT("/:examID/results/:studentID", a.Exam.UpdateResult, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
+	exams.GET("/:examID/results/:studentID/history", a.Exam.GetResultHistory, m.RequireRole(middleware.RoleAdmin))
 	exams.POST("/:examID/bulk-grade", a.Exam.BulkGradeResults, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
+	exams.POST("/:examID/results/import", a.Exam.ImportResults, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 	exams.GET("/:examID/result-stats", a.Exam.GetResultStats, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 
 	// Student exam views
@@ -460,6 +543,14 @@ func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *mi
 		m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty, middleware.RoleStudent),
 		m.LoadStudentProfile,
 		m.VerifyStudentOwnership())
+	apiGroup.GET("/students/:studentID/exam-summary", a.Exam.GetStudentExamSummary,
+		m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty, middleware.RoleStudent),
+		m.LoadStudentProfile,
+		m.VerifyStudentOwnership())
+	apiGroup.GET("/students/:studentID/upcoming-exams", a.Exam.GetUpcomingExams,
+		m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty, middleware.RoleStudent),
+		m.LoadStudentProfile,
+		m.VerifyStudentOwnership())
 	apiGroup.GET("/students/:studentID/exam-results", a.Exam.GetStudentResults,
 		m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty, middleware.RoleStudent),
 		m.LoadStudentProfile)
@@ -469,7 +560,8 @@ func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *mi
 
 	// Revaluation
 	revaluation := apiGroup.Group("/revaluation-requests")
-	revaluation.POST("", a.Exam.CreateRevaluationRequest, m.RequireRole(middleware.RoleStudent), m.LoadStudentProfile)
+	revaluation.POST("", a.Exam.CreateRevaluationRequest, m.RequireRole(middleware.RoleStudent, middleware.RoleAdmin), m.LoadStudentProfile)
+	revaluation.GET("/mine", a.Exam.ListMyRevaluationRequests, m.RequireRole(middleware.RoleStudent), m.LoadStudentProfile)
 	revaluation.GET("", a.Exam.ListRevaluationRequests,
 		m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty, middleware.RoleStudent),
 		m.LoadStudentProfile)
@@ -480,6 +572,7 @@ func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *mi
 	examRooms := apiGroup.Group("/exam-rooms")
 	examRooms.GET("", a.Exam.ListRooms)
 	examRooms.POST("", a.Exam.CreateRoom, m.RequireRole(middleware.RoleAdmin))
+	examRooms.GET("/utilization", a.Exam.GetRoomUtilization, m.RequireRole(middleware.RoleAdmin))
 	examRooms.GET("/:roomID", a.Exam.GetRoom)
 	examRooms.PUT("/:roomID", a.Exam.UpdateRoom, m.RequireRole(middleware.RoleAdmin))
 	examRooms.DELETE("/:roomID", a.Exam.DeleteRoom, m.RequireRole(middleware.RoleAdmin))
@@ -515,15 +608,22 @@ func SetupRoutes(e *echo.Echo, a *Handlers, m *middleware.AuthMiddleware, pv *mi
 	parent.GET("/children", a.Parent.GetLinkedChildren)
 	parent.GET("/children/:studentID/dashboard", a.Parent.GetChildDashboard)
 	parent.GET("/children/:studentID/attendance", a.Parent.GetChildAttendance)
+	parent.GET("/children/:studentID/attendance/trends", a.Parent.GetChildAttendanceTrends)
 	parent.GET("/children/:studentID/grades", a.Parent.GetChildGrades)
 	parent.GET("/children/:studentID/assignments", a.Parent.GetChildAssignments)
+	parent.GET("/children/:studentID/fees", a.Parent.GetChildFees)
+	parent.GET("/children/:studentID/communications", a.Parent.ListParentCommunications, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
 	parent.POST("/contact", a.Parent.ContactParent, m.RequireRole(middleware.RoleAdmin, middleware.RoleFaculty))
+	parent.POST("/verify-link", a.Parent.VerifyParentLink, m.RequireRole(middleware.RoleParent))
 
 	// Parent-Student Link Management (admin only)
 	parentRelationships := apiGroup.Group("/parent/relationships", m.RequireRole(middleware.RoleAdmin))
 	parentRelationships.GET("", a.Parent.ListParentRelationships)
+	parentRelationships.GET("/pending", a.Parent.ListPendingParentRelationships)
 	parentRelationships.POST("", a.Parent.CreateParentRelationship)
 	parentRelationships.DELETE("/:id", a.Parent.DeleteParentRelationship)
+	parentRelationships.POST("/:id/approve", a.Parent.ApproveParentRelationship)
+	parentRelationships.POST("/attendance-alerts/run", a.ParentAlert.RunLowAttendanceAlerts)
 
 	// Self-Service Routes
 	selfService := apiGroup.Group("/self-service", m.RequireRole(middleware.RoleStudent), m.LoadStudentProfile)
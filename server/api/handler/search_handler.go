@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"eduhub/server/internal/helpers"
+	"eduhub/server/internal/services/search"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SearchHandler struct {
+	searchService search.SearchService
+}
+
+func NewSearchHandler(searchService search.SearchService) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+// Search godoc
+// @Summary Search students, courses and exams
+// @Description Searches the caller's college by student name or roll number, course name and exam title. Results are ranked exact, prefix, then partial matches and filtered by role.
+// @Tags Search
+// @Produce json
+// @Param q query string true "Search text (2-100 characters)"
+// @Param limit query int false "Maximum results (default 20, max 50)"
+// @Success 200 {array} models.SearchResult
+// @Failure 400 {object} helpers.ErrorResponse
+// @Failure 401 {object} helpers.ErrorResponse
+// @Failure 500 {object} helpers.ErrorResponse
+// @Router /api/search [get]
+func (h *SearchHandler) Search(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	role, err := helpers.GetUserRole(c)
+	if err != nil {
+		return err
+	}
+
+	searcher := search.Searcher{CollegeID: collegeID, Role: role}
+	if userID, err := helpers.ExtractUserID(c); err == nil {
+		searcher.UserID = userID
+	}
+	if studentID, ok := c.Get("student_id").(int); ok {
+		searcher.StudentID = studentID
+	}
+
+	limit := 0
+	if raw := c.QueryParam("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			return helpers.Error(c, "limit must be a positive integer", http.StatusBadRequest)
+		}
+	}
+
+	results, err := h.searchService.Search(c.Request().Context(), searcher, c.QueryParam("q"), limit)
+	if err != nil {
+		if errors.Is(err, search.ErrInvalidQuery) {
+			return helpers.Error(c, err.Error(), http.StatusBadRequest)
+		}
+		return helpers.Error(c, "Failed to search", http.StatusInternalServerError)
+	}
+
+	return helpers.Success(c, results, http.StatusOK)
+}
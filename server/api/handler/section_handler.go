@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"eduhub/server/internal/helpers"
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/services/course"
+	"eduhub/server/internal/validation"
+
+	"github.com/labstack/echo/v4"
+)
+
+type SectionHandler struct {
+	sectionService course.SectionService
+}
+
+func NewSectionHandler(sectionService course.SectionService) *SectionHandler {
+	return &SectionHandler{
+		sectionService: sectionService,
+	}
+}
+
+// ListSections lists a course's sections with their student counts
+// GET /api/courses/:courseID/sections
+func (h *SectionHandler) ListSections(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+	courseID, err := strconv.Atoi(c.Param("courseID"))
+	if err != nil {
+		return helpers.Error(c, "invalid course ID", 400)
+	}
+
+	sections, err := h.sectionService.ListSections(c.Request().Context(), collegeID, courseID)
+	if err != nil {
+		return sectionError(c, err)
+	}
+	return helpers.Success(c, sections, 200)
+}
+
+// CreateSection adds a section to a course
+// POST /api/courses/:courseID/sections
+func (h *SectionHandler) CreateSection(c echo.Context) error {
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+	courseID, err := strconv.Atoi(c.Param("courseID"))
+	if err != nil {
+		return helpers.Error(c, "invalid course ID", 400)
+	}
+
+	var req models.CourseSectionRequest
+	if err := c.Bind(&req); err != nil {
+		return helpers.Error(c, "invalid request body", 400)
+	}
+
+	section, err := h.sectionService.CreateSection(c.Request().Context(), collegeID, courseID, &req)
+	if err != nil {
+		return sectionError(c, err)
+	}
+	return helpers.Success(c, section, 201)
+}
+
+// RenameSection renames a section
+// PATCH /api/courses/:courseID/sections/:sectionID
+func (h *SectionHandler) RenameSection(c echo.Context) error {
+	collegeID, courseID, sectionID, err := sectionParams(c)
+	if err != nil {
+		return err
+	}
+
+	var req models.CourseSectionRequest
+	if err := c.Bind(&req); err != nil {
+		return helpers.Error(c, "invalid request body", 400)
+	}
+
+	section, err := h.sectionService.RenameSection(c.Request().Context(), collegeID, courseID, sectionID, &req)
+	if err != nil {
+		return sectionError(c, err)
+	}
+	return helpers.Success(c, section, 200)
+}
+
+// DeleteSection removes a section; its students and quizzes go back to the
+// whole course
+// DELETE /api/courses/:courseID/sections/:sectionID
+func (h *SectionHandler) DeleteSection(c echo.Context) error {
+	collegeID, courseID, sectionID, err := sectionParams(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.sectionService.DeleteSection(c.Request().Context(), collegeID, courseID, sectionID); err != nil {
+		return sectionError(c, err)
+	}
+	return helpers.Success(c, "section deleted", 200)
+}
+
+// AssignStudents moves enrolled students into a section
+// PUT /api/courses/:courseID/sections/:sectionID/students
+func (h *SectionHandler) AssignStudents(c echo.Context) error {
+	collegeID, courseID, sectionID, err := sectionParams(c)
+	if err != nil {
+		return err
+	}
+
+	var req models.AssignSectionRequest
+	if err := c.Bind(&req); err != nil {
+		return helpers.Error(c, "invalid request body", 400)
+	}
+
+	assignment, err := h.sectionService.AssignStudents(c.Request().Context(), collegeID, courseID, sectionID, &req)
+	if err != nil {
+		return sectionError(c, err)
+	}
+	return helpers.Success(c, assignment, 200)
+}
+
+// UnassignStudent takes a student out of a section
+// DELETE /api/courses/:courseID/sections/:sectionID/students/:studentID
+func (h *SectionHandler) UnassignStudent(c echo.Context) error {
+	collegeID, courseID, sectionID, err := sectionParams(c)
+	if err != nil {
+		return err
+	}
+	studentID, err := strconv.Atoi(c.Param("studentID"))
+	if err != nil {
+		return helpers.Error(c, "invalid student ID", 400)
+	}
+
+	if err := h.sectionService.UnassignStudent(c.Request().Context(), collegeID, courseID, sectionID, studentID); err != nil {
+		return sectionError(c, err)
+	}
+	return helpers.Success(c, "student removed from section", 200)
+}
+
+// sectionParams reads the college and the course and section path IDs
+func sectionParams(c echo.Context) (collegeID, courseID, sectionID int, err error) {
+	collegeID, err = helpers.ExtractCollegeID(c)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if courseID, err = strconv.Atoi(c.Param("courseID")); err != nil {
+		return 0, 0, 0, echo.NewHTTPError(400, "invalid course ID")
+	}
+	if sectionID, err = strconv.Atoi(c.Param("sectionID")); err != nil {
+		return 0, 0, 0, echo.NewHTTPError(400, "invalid section ID")
+	}
+	return collegeID, courseID, sectionID, nil
+}
+
+// sectionError maps section service errors to responses
+func sectionError(c echo.Context, err error) error {
+	if fields := validation.Fields(err); fields != nil {
+		return helpers.ValidationError(c, fields)
+	}
+	switch {
+	case errors.Is(err, course.ErrCourseNotFound), errors.Is(err, course.ErrSectionNotFound),
+		errors.Is(err, course.ErrStudentNotInSection):
+		return helpers.Error(c, err.Error(), 404)
+	case errors.Is(err, course.ErrSectionNameTaken):
+		return helpers.Error(c, err.Error(), 409)
+	}
+	return helpers.Error(c, err.Error(), 500)
+}
@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"strconv"
 
 	"eduhub/server/internal/helpers"
 	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
 	"eduhub/server/internal/services/student"
 
 	"github.com/labstack/echo/v4"
@@ -149,4 +152,75 @@ func (h *StudentHandler) FreezeStudent(c echo.Context) error {
 	}
 
 	return helpers.Success(c, "Student frozen successfully", 200)
-}
\ No newline at end of file
+}
+
+// StudentStatusRequest carries the reason for a deactivation or reactivation
+type StudentStatusRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (h *StudentHandler) DeactivateStudent(c echo.Context) error {
+	return h.changeStudentStatus(c, h.studentService.DeactivateStudent)
+}
+
+func (h *StudentHandler) ReactivateStudent(c echo.Context) error {
+	return h.changeStudentStatus(c, h.studentService.ReactivateStudent)
+}
+
+type studentStatusFunc func(ctx context.Context, collegeID int, studentID int, changedBy int, reason string) (*models.StudentStatusChange, error)
+
+func (h *StudentHandler) changeStudentStatus(c echo.Context, change studentStatusFunc) error {
+	studentID, err := strconv.Atoi(c.Param("studentID"))
+	if err != nil {
+		return helpers.Error(c, "invalid student ID", 400)
+	}
+
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+	userID, err := helpers.ExtractUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req StudentStatusRequest
+	if err := c.Bind(&req); err != nil {
+		return helpers.Error(c, "invalid request body", 400)
+	}
+
+	// The returned entry carries previous_is_active so the caller can undo it
+	result, err := change(c.Request().Context(), collegeID, studentID, userID, req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrStudentNotFound):
+			return helpers.Error(c, err.Error(), 404)
+		case errors.Is(err, repository.ErrStudentStatusUnchanged):
+			return helpers.Error(c, err.Error(), 409)
+		case errors.Is(err, student.ErrStatusReasonRequired), errors.Is(err, student.ErrStatusReasonTooLong):
+			return helpers.Error(c, err.Error(), 400)
+		}
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, result, 200)
+}
+
+func (h *StudentHandler) GetStudentStatusHistory(c echo.Context) error {
+	studentID, err := strconv.Atoi(c.Param("studentID"))
+	if err != nil {
+		return helpers.Error(c, "invalid student ID", 400)
+	}
+
+	collegeID, err := helpers.ExtractCollegeID(c)
+	if err != nil {
+		return err
+	}
+
+	history, err := h.studentService.GetStudentStatusHistory(c.Request().Context(), collegeID, studentID)
+	if err != nil {
+		return helpers.Error(c, err.Error(), 500)
+	}
+
+	return helpers.Success(c, history, 200)
+}
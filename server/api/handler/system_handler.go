@@ -4,19 +4,33 @@ import (
 	"context"
 	"time"
 
+	"eduhub/server/internal/buildinfo"
 	"eduhub/server/internal/helpers"
 	"eduhub/server/internal/repository"
 
 	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
 )
 
+// readinessCheckTimeout bounds each dependency check performed by ReadinessCheck
+const readinessCheckTimeout = 2 * time.Second
+
+// DependencyPinger is implemented by optional dependencies (e.g. Redis) that
+// ReadinessCheck verifies before reporting the service as ready.
+type DependencyPinger interface {
+	Ping(ctx context.Context) error
+}
+
 type SystemHandler struct {
-	db *repository.DB
+	db    *repository.DB
+	redis DependencyPinger
 }
 
-func NewSystemHandler(db *repository.DB) *SystemHandler {
+// NewSystemHandler creates a system handler. redis may be nil when Redis is disabled.
+func NewSystemHandler(db *repository.DB, redis DependencyPinger) *SystemHandler {
 	return &SystemHandler{
-		db: db,
+		db:    db,
+		redis: redis,
 	}
 }
 
@@ -49,11 +63,11 @@ func (h *SystemHandler) HealthCheck(c echo.Context) error {
 		return helpers.Success(c, status, 503)
 	}
 
-	err := h.db.Pool.Ping(ctx)
+	err := h.db.Ping(ctx)
 	if err != nil {
+		log.Error().Err(err).Str("dependency", "database").Msg("health check failed")
 		status["status"] = "unhealthy"
 		status["database"] = "unavailable"
-		status["error"] = err.Error()
 		return helpers.Success(c, status, 503)
 	}
 	status["database"] = "connected"
@@ -61,28 +75,67 @@ func (h *SystemHandler) HealthCheck(c echo.Context) error {
 	return helpers.Success(c, status, 200)
 }
 
-// ReadinessCheck checks if the service is ready to serve requests
+// ReadinessCheck verifies that every dependency required to serve requests is
+// reachable. It returns 200 only when all checks pass, otherwise 503 with the
+// status of each dependency. The endpoint is public, so each check reports
+// only "ok" or "unavailable"; the underlying error is logged.
 func (h *SystemHandler) ReadinessCheck(c echo.Context) error {
-	ctx, cancel := context.WithTimeout(c.Request().Context(), 3*time.Second)
-	defer cancel()
+	checks := map[string]string{}
+	ready := true
 
-	// Check if database is ready
 	if h.db == nil {
-		return helpers.Error(c, "service not ready: database not initialized", 503)
+		log.Error().Str("dependency", "database").Msg("readiness check failed: database not initialized")
+		checks["database"] = "unavailable"
+		ready = false
+	} else if !pingDependency(c.Request().Context(), "database", h.db) {
+		checks["database"] = "unavailable"
+		ready = false
+	} else {
+		checks["database"] = "ok"
 	}
-	if h.db.Pool == nil {
-		return helpers.Error(c, "service not ready: database pool not initialized", 503)
+
+	if h.redis != nil {
+		if pingDependency(c.Request().Context(), "redis", h.redis) {
+			checks["redis"] = "ok"
+		} else {
+			checks["redis"] = "unavailable"
+			ready = false
+		}
 	}
 
-	err := h.db.Pool.Ping(ctx)
-	if err != nil {
-		return helpers.Error(c, "service not ready: "+err.Error(), 503)
+	if !ready {
+		return helpers.Error(c, map[string]any{
+			"status": "not ready",
+			"checks": checks,
+		}, 503)
 	}
 
-	return helpers.Success(c, map[string]string{"status": "ready"}, 200)
+	return helpers.Success(c, map[string]any{
+		"status": "ready",
+		"checks": checks,
+	}, 200)
 }
 
-// LivenessCheck checks if the service is alive
+// pingDependency pings one dependency within readinessCheckTimeout and logs
+// why it failed
+func pingDependency(ctx context.Context, name string, dep DependencyPinger) bool {
+	ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+	if err := dep.Ping(ctx); err != nil {
+		log.Error().Err(err).Str("dependency", name).Msg("readiness check failed")
+		return false
+	}
+	return true
+}
+
+// LivenessCheck checks if the service is alive. It intentionally does not
+// touch any dependency so that a slow database never causes a restart loop.
 func (h *SystemHandler) LivenessCheck(c echo.Context) error {
 	return helpers.Success(c, map[string]string{"status": "alive"}, 200)
 }
+
+// Version reports which build is deployed: the version, commit and build date
+// stamped in at build time and the Go runtime it was built with.
+func (h *SystemHandler) Version(c echo.Context) error {
+	return helpers.Success(c, buildinfo.Get(), 200)
+}
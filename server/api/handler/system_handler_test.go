@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"eduhub/server/internal/repository"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubPinger struct {
+	err    error
+	called bool
+}
+
+func (s *stubPinger) Ping(ctx context.Context) error {
+	s.called = true
+	return s.err
+}
+
+func newReadinessTest(t *testing.T) (pgxmock.PgxPoolIface, *repository.DB) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	t.Cleanup(mock.Close)
+	return mock, &repository.DB{Pool: mock}
+}
+
+func decodeChecks(t *testing.T, rec *httptest.ResponseRecorder, key string) map[string]any {
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	payload, ok := body[key].(map[string]any)
+	require.True(t, ok, "missing %s in body: %s", key, rec.Body.String())
+	checks, ok := payload["checks"].(map[string]any)
+	require.True(t, ok)
+	return checks
+}
+
+func TestReadinessCheck(t *testing.T) {
+	e := echo.New()
+
+	t.Run("all dependencies healthy", func(t *testing.T) {
+		mock, db := newReadinessTest(t)
+		mock.ExpectExec("SELECT 1").WillReturnResult(pgxmock.NewResult("SELECT", 1))
+		redis := &stubPinger{}
+		h := NewSystemHandler(db, redis)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/ready", nil), rec)
+		require.NoError(t, h.ReadinessCheck(c))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		checks := decodeChecks(t, rec, "data")
+		assert.Equal(t, "ok", checks["database"])
+		assert.Equal(t, "ok", checks["redis"])
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database down", func(t *testing.T) {
+		mock, db := newReadinessTest(t)
+		mock.ExpectExec("SELECT 1").WillReturnError(errors.New("connection refused"))
+		h := NewSystemHandler(db, &stubPinger{})
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/ready", nil), rec)
+		require.NoError(t, h.ReadinessCheck(c))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		checks := decodeChecks(t, rec, "error")
+		assert.Equal(t, "unavailable", checks["database"])
+		assert.Equal(t, "ok", checks["redis"])
+	})
+
+	t.Run("redis down", func(t *testing.T) {
+		mock, db := newReadinessTest(t)
+		mock.ExpectExec("SELECT 1").WillReturnResult(pgxmock.NewResult("SELECT", 1))
+		h := NewSystemHandler(db, &stubPinger{err: errors.New("redis timeout")})
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/ready", nil), rec)
+		require.NoError(t, h.ReadinessCheck(c))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		checks := decodeChecks(t, rec, "error")
+		assert.Equal(t, "ok", checks["database"])
+		assert.Equal(t, "unavailable", checks["redis"])
+	})
+
+	t.Run("redis disabled is skipped", func(t *testing.T) {
+		mock, db := newReadinessTest(t)
+		mock.ExpectExec("SELECT 1").WillReturnResult(pgxmock.NewResult("SELECT", 1))
+		h := NewSystemHandler(db, nil)
+
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/ready", nil), rec)
+		require.NoError(t, h.ReadinessCheck(c))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		checks := decodeChecks(t, rec, "data")
+		assert.NotContains(t, checks, "redis")
+	})
+}
+
+func TestLivenessCheck_DoesNotTouchDependencies(t *testing.T) {
+	e := echo.New()
+	mock, db := newReadinessTest(t)
+	redis := &stubPinger{}
+	h := NewSystemHandler(db, redis)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/alive", nil), rec)
+	require.NoError(t, h.LivenessCheck(c))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.False(t, redis.called)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestVersion(t *testing.T) {
+	e := echo.New()
+	h := NewSystemHandler(nil, nil)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/version", nil), rec)
+	require.NoError(t, h.Version(c))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Data map[string]string `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	// Test binaries are not built with ldflags
+	assert.Equal(t, "dev", body.Data["version"])
+	assert.Equal(t, "dev", body.Data["commit"])
+	assert.Equal(t, "dev", body.Data["build_date"])
+	assert.Equal(t, runtime.Version(), body.Data["go_version"])
+}
@@ -7,6 +7,7 @@ import (
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/services/auth"
 	"eduhub/server/internal/services/user"
+	"eduhub/server/internal/validation"
 
 	"github.com/labstack/echo/v4"
 )
@@ -51,6 +52,9 @@ func (h *UserHandler) UpdateProfile(c echo.Context) error {
 
 	err = h.userService.UpdateUserPartial(c.Request().Context(), userID, &req)
 	if err != nil {
+		if fields := validation.Fields(err); fields != nil {
+			return helpers.ValidationError(c, fields)
+		}
 		return helpers.Error(c, err.Error(), 500)
 	}
 
@@ -96,6 +100,9 @@ func (h *UserHandler) CreateUser(c echo.Context) error {
 
 	err := h.userService.CreateUser(c.Request().Context(), &user)
 	if err != nil {
+		if fields := validation.Fields(err); fields != nil {
+			return helpers.ValidationError(c, fields)
+		}
 		return helpers.Error(c, err.Error(), 400)
 	}
 
@@ -133,6 +140,9 @@ func (h *UserHandler) UpdateUser(c echo.Context) error {
 
 	err = h.userService.UpdateUserPartial(c.Request().Context(), userID, &req)
 	if err != nil {
+		if fields := validation.Fields(err); fields != nil {
+			return helpers.ValidationError(c, fields)
+		}
 		return helpers.Error(c, err.Error(), 500)
 	}
 
@@ -21,6 +21,7 @@ import (
 	filesvc "eduhub/server/internal/services/file"
 	storagesvc "eduhub/server/internal/services/storage"
 	storageclient "eduhub/server/internal/storage"
+	"eduhub/server/logger"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/joho/godotenv"
@@ -911,7 +912,7 @@ func ensureExamFlow(ctx context.Context, db *repository.DB, fixture *demoFixture
 		end := start.Add(2 * time.Hour)
 		err = db.Pool.QueryRow(ctx, `
 			INSERT INTO exams (college_id, course_id, title, description, exam_type, start_time, end_time, duration, total_marks, passing_marks, room_id, status, instructions, allowed_materials, question_paper_sets, created_by)
-			VALUES ($1, $2, 'Midterm Practical', 'Hands-on exam for the demo course', 'practical', $3, $4, 120, 100, 40, $5, 'scheduled', 'Bring your laptop', 'Notebook', 1, $6)
+			VALUES ($1, $2, 'Midterm Practical', 'Hands-on exam for the demo course', 'practical', $3, $4, 120, 100, 40, $5, 'scheduled', 'Bring your laptop', '["Notebook"]', 1, $6)
 			RETURNING id`, fixture.CollegeID, fixture.CourseID, start, end, roomID, fixture.Faculty.ID).Scan(&examID)
 	}
 	if err != nil {
@@ -1153,7 +1154,7 @@ func ensureFileArtifacts(ctx context.Context, db *repository.DB, fixture *demoFi
 		return 0, err
 	}
 	fileRepo := repository.NewFileRepository(db)
-	storageService := storagesvc.NewStorageService(minioClient.Client(), storageCfg.Bucket, storageCfg.Endpoint, storageCfg.UseSSL)
+	storageService := storagesvc.NewStorageService(minioClient.Client(), storageCfg.Bucket, storageCfg.Endpoint, storageCfg.UseSSL, time.Duration(storageCfg.PresignedURLExpirySeconds)*time.Second, time.Duration(storageCfg.MaxPresignedURLExpirySeconds)*time.Second)
 	fileService := filesvc.NewFileService(fileRepo, storageService)
 
 	var folderID int
@@ -1235,7 +1236,11 @@ func verifyDemoLogins() error {
 	}
 	defer cfg.DB.Close()
 
-	svcs := services.NewServices(cfg)
+	loggers, err := logger.NewSubsystems(cfg.AppConfig.LogLevel, cfg.AppConfig.SubsystemLogLevels)
+	if err != nil {
+		return fmt.Errorf("configure loggers for login verification: %w", err)
+	}
+	svcs := services.NewServices(cfg, loggers)
 	defer svcs.WebSocketService.Stop()
 
 	e := echo.New()
@@ -0,0 +1,87 @@
+// Command migrate reports or advances the database schema version using the
+// migrations embedded in the server binary.
+//
+//	go run ./server/cmd/migrate version   # print current and expected versions
+//	go run ./server/cmd/migrate up        # apply pending migrations
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"eduhub/server/db/migrations"
+	"eduhub/server/internal/config"
+	"eduhub/server/internal/migrate"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: migrate [version|up]\n")
+	}
+	flag.Parse()
+
+	command := flag.Arg(0)
+	if command == "" {
+		command = "version"
+	}
+	if command != "version" && command != "up" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	loadEnvFiles()
+
+	migrator, err := migrate.New(migrations.FS)
+	if err != nil {
+		fatalf("failed to load migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	db, err := config.LoadDatabaseWithRetry(3)
+	if err != nil {
+		fatalf("failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	conn, err := migrate.Acquire(ctx, db)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	defer conn.Release()
+
+	if command == "up" {
+		applied, err := migrator.Up(ctx, conn)
+		if err != nil {
+			fatalf("%v", err)
+		}
+		fmt.Printf("applied %d migration(s)\n", applied)
+	}
+
+	version, dirty, err := migrator.Version(ctx, conn)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	fmt.Printf("database version: %d", version)
+	if dirty {
+		fmt.Print(" (dirty)")
+	}
+	fmt.Printf("\nexpected version: %d\n", migrator.Latest())
+	if dirty || version != migrator.Latest() {
+		os.Exit(1)
+	}
+}
+
+func loadEnvFiles() {
+	for _, path := range []string{".env", "server/.env.local"} {
+		_ = godotenv.Load(path)
+	}
+}
+
+func fatalf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
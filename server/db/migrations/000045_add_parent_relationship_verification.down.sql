@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_parent_relationships_verification_token;
+
+ALTER TABLE parent_student_relationships
+    DROP COLUMN IF EXISTS verification_expires_at,
+    DROP COLUMN IF EXISTS verification_token_hash;
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE parent_student_relationships
+    ADD COLUMN IF NOT EXISTS verification_token_hash VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS verification_expires_at TIMESTAMP;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_parent_relationships_verification_token
+    ON parent_student_relationships(verification_token_hash)
+    WHERE verification_token_hash IS NOT NULL;
+
+COMMIT;
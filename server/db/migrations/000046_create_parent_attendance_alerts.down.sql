@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS parent_attendance_alerts;
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+
+-- Log of low-attendance emails sent to parents, used to enforce the cooldown window
+CREATE TABLE IF NOT EXISTS parent_attendance_alerts (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES parent_student_relationships(id) ON DELETE CASCADE,
+    parent_user_id INTEGER NOT NULL,
+    student_id INTEGER NOT NULL REFERENCES students(student_id) ON DELETE CASCADE,
+    college_id INTEGER NOT NULL,
+    attendance_rate DECIMAL(5,2) NOT NULL,
+    threshold DECIMAL(5,2) NOT NULL,
+    sent_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_parent_attendance_alerts_relationship_sent
+    ON parent_attendance_alerts(relationship_id, sent_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_parent_attendance_alerts_college
+    ON parent_attendance_alerts(college_id);
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+-- Record of messages sent to parents by faculty and admins
+CREATE TABLE IF NOT EXISTS parent_communications (
+    id SERIAL PRIMARY KEY,
+    college_id INTEGER NOT NULL,
+    sender_user_id INTEGER NOT NULL REFERENCES users(id),
+    student_id INTEGER REFERENCES students(student_id) ON DELETE SET NULL,
+    parent_name VARCHAR(255),
+    parent_email VARCHAR(255) NOT NULL,
+    parent_phone VARCHAR(50),
+    subject VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+    sent_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_parent_communications_student
+    ON parent_communications(college_id, student_id, sent_at DESC);
+
+COMMIT;
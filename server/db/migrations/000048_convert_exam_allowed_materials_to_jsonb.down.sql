@@ -0,0 +1,21 @@
+BEGIN;
+
+ALTER TABLE exams
+    ALTER COLUMN question_paper_sets DROP NOT NULL;
+
+ALTER TABLE exams
+    DROP CONSTRAINT IF EXISTS check_allowed_materials_array;
+
+-- Subqueries aren't allowed in ALTER COLUMN ... USING, so go through a temporary column
+ALTER TABLE exams ADD COLUMN allowed_materials_text TEXT;
+
+UPDATE exams
+SET allowed_materials_text = NULLIF(
+    (SELECT string_agg(value, ', ') FROM jsonb_array_elements_text(allowed_materials)),
+    ''
+);
+
+ALTER TABLE exams DROP COLUMN allowed_materials;
+ALTER TABLE exams RENAME COLUMN allowed_materials_text TO allowed_materials;
+
+COMMIT;
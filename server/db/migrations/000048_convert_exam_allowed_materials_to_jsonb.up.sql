@@ -0,0 +1,25 @@
+BEGIN;
+
+-- Store allowed materials as a JSON array of strings instead of free text.
+-- Existing values that are already JSON arrays are kept; anything else is
+-- treated as a comma-separated list.
+ALTER TABLE exams
+    ALTER COLUMN allowed_materials TYPE JSONB USING (
+        CASE
+            WHEN allowed_materials IS NULL OR btrim(allowed_materials) = '' THEN '[]'::jsonb
+            WHEN left(btrim(allowed_materials), 1) = '[' THEN allowed_materials::jsonb
+            ELSE to_jsonb(array_remove(regexp_split_to_array(btrim(allowed_materials), '\s*,\s*'), ''))
+        END
+    ),
+    ALTER COLUMN allowed_materials SET DEFAULT '[]'::jsonb,
+    ALTER COLUMN allowed_materials SET NOT NULL;
+
+ALTER TABLE exams
+    ADD CONSTRAINT check_allowed_materials_array CHECK (jsonb_typeof(allowed_materials) = 'array');
+
+UPDATE exams SET question_paper_sets = 1 WHERE question_paper_sets IS NULL;
+
+ALTER TABLE exams
+    ALTER COLUMN question_paper_sets SET NOT NULL;
+
+COMMIT;
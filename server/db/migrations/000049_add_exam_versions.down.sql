@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE exam_results DROP COLUMN IF EXISTS version;
+ALTER TABLE exams DROP COLUMN IF EXISTS version;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Version counters for optimistic locking: updates must name the version they
+-- read, and each successful update increments it.
+ALTER TABLE exams ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE exam_results ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+COMMIT;
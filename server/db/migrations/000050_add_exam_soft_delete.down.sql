@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_exams_college_active;
+ALTER TABLE exams DROP COLUMN IF EXISTS deleted_at;
+
+COMMIT;
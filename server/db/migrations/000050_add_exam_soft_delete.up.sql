@@ -0,0 +1,9 @@
+BEGIN;
+
+-- Deleting an exam only stamps deleted_at so enrollments and results stay
+-- available for transcripts; normal queries filter on deleted_at IS NULL.
+ALTER TABLE exams ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_exams_college_active ON exams(college_id) WHERE deleted_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+-- Staff assigned to supervise an exam. room_id is the room they watch when
+-- an exam is spread over several rooms; it defaults to the exam's room.
+CREATE TABLE IF NOT EXISTS exam_invigilators (
+    id SERIAL PRIMARY KEY,
+    exam_id INTEGER NOT NULL REFERENCES exams(id) ON DELETE CASCADE,
+    college_id INTEGER NOT NULL REFERENCES colleges(id) ON DELETE CASCADE,
+    room_id INTEGER REFERENCES exam_rooms(id) ON DELETE SET NULL,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    assigned_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(exam_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_exam_invigilators_exam ON exam_invigilators(exam_id);
+CREATE INDEX IF NOT EXISTS idx_exam_invigilators_user ON exam_invigilators(user_id);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE student_answers DROP COLUMN IF EXISTS last_saved_at;
+
+COMMIT;
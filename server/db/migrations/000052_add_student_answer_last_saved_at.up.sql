@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Stamped on every save, including autosaves, so a client reconnecting after a
+-- crash can tell how recent the recovered answers are.
+ALTER TABLE student_answers ADD COLUMN IF NOT EXISTS last_saved_at TIMESTAMPTZ;
+
+COMMIT;
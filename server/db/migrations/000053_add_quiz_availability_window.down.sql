@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE IF EXISTS quiz_extensions;
+ALTER TABLE quizzes DROP CONSTRAINT IF EXISTS valid_availability_window;
+ALTER TABLE quizzes DROP COLUMN IF EXISTS available_until;
+ALTER TABLE quizzes DROP COLUMN IF EXISTS available_from;
+
+COMMIT;
@@ -0,0 +1,26 @@
+BEGIN;
+
+-- Optional window in which students may start a quiz; NULL means unbounded
+ALTER TABLE quizzes ADD COLUMN IF NOT EXISTS available_from TIMESTAMPTZ;
+ALTER TABLE quizzes ADD COLUMN IF NOT EXISTS available_until TIMESTAMPTZ;
+ALTER TABLE quizzes ADD CONSTRAINT valid_availability_window
+    CHECK (available_from IS NULL OR available_until IS NULL OR available_until > available_from);
+
+-- Per-student extensions: the student may start the quiz until available_until
+-- even after the quiz has closed for everyone else
+CREATE TABLE IF NOT EXISTS quiz_extensions (
+    id SERIAL PRIMARY KEY,
+    quiz_id INTEGER NOT NULL REFERENCES quizzes(id) ON DELETE CASCADE,
+    student_id INTEGER NOT NULL REFERENCES students(student_id) ON DELETE CASCADE,
+    college_id INTEGER NOT NULL REFERENCES colleges(id) ON DELETE CASCADE,
+    available_until TIMESTAMPTZ NOT NULL,
+    reason TEXT,
+    granted_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE(quiz_id, student_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_quiz_extensions_quiz ON quiz_extensions(quiz_id);
+
+COMMIT;
@@ -0,0 +1,22 @@
+BEGIN;
+
+-- One row per college per day capturing the college dashboard metrics, so
+-- admins can chart how they change over time
+CREATE TABLE IF NOT EXISTS dashboard_snapshots (
+    id SERIAL PRIMARY KEY,
+    college_id INTEGER NOT NULL REFERENCES colleges(id) ON DELETE CASCADE,
+    snapshot_date DATE NOT NULL DEFAULT CURRENT_DATE,
+    total_students INTEGER NOT NULL DEFAULT 0,
+    total_courses INTEGER NOT NULL DEFAULT 0,
+    total_faculty INTEGER NOT NULL DEFAULT 0,
+    average_attendance DOUBLE PRECISION NOT NULL DEFAULT 0,
+    overall_gpa DOUBLE PRECISION NOT NULL DEFAULT 0,
+    active_announcements INTEGER NOT NULL DEFAULT 0,
+    upcoming_events INTEGER NOT NULL DEFAULT 0,
+    captured_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(college_id, snapshot_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_dashboard_snapshots_date ON dashboard_snapshots(snapshot_date);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE assignment_submissions DROP COLUMN IF EXISTS graded_at;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- When a submission was first graded, used to measure grading turnaround.
+-- Existing graded rows use their last update as the best available estimate.
+ALTER TABLE assignment_submissions ADD COLUMN IF NOT EXISTS graded_at TIMESTAMPTZ;
+UPDATE assignment_submissions SET graded_at = updated_at WHERE grade IS NOT NULL AND graded_at IS NULL;
+
+COMMIT;
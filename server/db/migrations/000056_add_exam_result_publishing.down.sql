@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_exam_results_exam_published;
+ALTER TABLE exam_results DROP COLUMN IF EXISTS published_at;
+ALTER TABLE exam_results DROP COLUMN IF EXISTS published;
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+-- Results are graded privately and shown to students only once published.
+-- Results that already exist were visible before this change, so they start
+-- out published.
+ALTER TABLE exam_results ADD COLUMN IF NOT EXISTS published BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE exam_results ADD COLUMN IF NOT EXISTS published_at TIMESTAMPTZ;
+UPDATE exam_results SET published = TRUE, published_at = updated_at WHERE published = FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_exam_results_exam_published ON exam_results(exam_id, published);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS course_skill_mappings;
+
+COMMIT;
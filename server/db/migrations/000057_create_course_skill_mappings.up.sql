@@ -0,0 +1,17 @@
+BEGIN;
+
+-- Optional roll-up of courses into named skills (e.g. "Mathematics") for
+-- student progression; unmapped courses report under their own name
+CREATE TABLE IF NOT EXISTS course_skill_mappings (
+    id SERIAL PRIMARY KEY,
+    college_id INTEGER NOT NULL REFERENCES colleges(id) ON DELETE CASCADE,
+    course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    skill_name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(college_id, course_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_course_skill_mappings_skill ON course_skill_mappings(college_id, skill_name);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE questions DROP COLUMN IF EXISTS scoring_mode;
+
+COMMIT;
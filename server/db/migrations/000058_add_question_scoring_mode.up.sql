@@ -0,0 +1,9 @@
+BEGIN;
+
+-- How auto-grading marks multiple-choice questions with several correct options:
+-- all_or_nothing needs exactly the correct set, partial gives proportional credit
+ALTER TABLE questions
+    ADD COLUMN IF NOT EXISTS scoring_mode VARCHAR(20) NOT NULL DEFAULT 'all_or_nothing'
+        CHECK (scoring_mode IN ('all_or_nothing', 'partial'));
+
+COMMIT;
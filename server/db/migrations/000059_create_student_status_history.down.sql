@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS student_status_history;
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+-- Audit trail for student deactivation and reactivation: who flipped
+-- students.is_active, when, why, and what the flag was before
+CREATE TABLE IF NOT EXISTS student_status_history (
+    id SERIAL PRIMARY KEY,
+    student_id INTEGER NOT NULL REFERENCES students(student_id) ON DELETE CASCADE,
+    college_id INTEGER NOT NULL REFERENCES colleges(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('deactivate', 'reactivate')),
+    previous_is_active BOOLEAN NOT NULL,
+    is_active BOOLEAN NOT NULL,
+    reason TEXT NOT NULL,
+    changed_by INTEGER NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_student_status_history_student
+    ON student_status_history(college_id, student_id, changed_at DESC);
+
+COMMIT;
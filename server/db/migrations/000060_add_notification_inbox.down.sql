@@ -0,0 +1,12 @@
+BEGIN;
+
+ALTER TABLE assignments DROP COLUMN IF EXISTS due_reminder_sent_at;
+
+DROP INDEX IF EXISTS idx_notifications_inbox;
+
+ALTER TABLE notifications
+    DROP COLUMN IF EXISTS read_at,
+    DROP COLUMN IF EXISTS is_read,
+    DROP COLUMN IF EXISTS user_id;
+
+COMMIT;
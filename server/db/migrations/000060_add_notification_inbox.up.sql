@@ -0,0 +1,18 @@
+BEGIN;
+
+-- In-app inbox: every notification row is addressed to one user, who can
+-- mark it read
+ALTER TABLE notifications
+    ADD COLUMN IF NOT EXISTS user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS is_read BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS read_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_notifications_inbox
+    ON notifications (college_id, user_id, is_read, created_at DESC);
+
+-- Set once the "due soon" reminder has gone out so each assignment is only
+-- announced once
+ALTER TABLE assignments
+    ADD COLUMN IF NOT EXISTS due_reminder_sent_at TIMESTAMPTZ;
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+ALTER TABLE assignment_submissions
+    DROP COLUMN IF EXISTS late_penalty,
+    DROP COLUMN IF EXISTS raw_grade,
+    DROP COLUMN IF EXISTS is_late;
+
+ALTER TABLE assignments
+    DROP COLUMN IF EXISTS late_cutoff_at,
+    DROP COLUMN IF EXISTS late_penalty_max_percent,
+    DROP COLUMN IF EXISTS late_penalty_percent,
+    DROP COLUMN IF EXISTS late_penalty_policy;
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+
+-- Per-assignment overrides of the college-wide late penalty policy. NULL
+-- columns fall back to the server defaults; late_cutoff_at rejects any
+-- submission made after it.
+ALTER TABLE assignments
+    ADD COLUMN IF NOT EXISTS late_penalty_policy TEXT CHECK (late_penalty_policy IN ('none', 'flat', 'per_day')),
+    ADD COLUMN IF NOT EXISTS late_penalty_percent INTEGER CHECK (late_penalty_percent BETWEEN 0 AND 100),
+    ADD COLUMN IF NOT EXISTS late_penalty_max_percent INTEGER CHECK (late_penalty_max_percent BETWEEN 0 AND 100),
+    ADD COLUMN IF NOT EXISTS late_cutoff_at TIMESTAMPTZ;
+
+-- raw_grade is what the grader awarded; grade is raw_grade less late_penalty.
+ALTER TABLE assignment_submissions
+    ADD COLUMN IF NOT EXISTS is_late BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS raw_grade INTEGER,
+    ADD COLUMN IF NOT EXISTS late_penalty INTEGER;
+
+UPDATE assignment_submissions s
+SET is_late = TRUE
+FROM assignments a
+WHERE a.id = s.assignment_id AND s.submission_time > a.due_date;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE exam_enrollments
+    DROP COLUMN IF EXISTS accommodation_notes,
+    DROP COLUMN IF EXISTS scribe,
+    DROP COLUMN IF EXISTS separate_room,
+    DROP COLUMN IF EXISTS extra_time_minutes;
+
+COMMIT;
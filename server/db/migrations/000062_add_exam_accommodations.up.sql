@@ -0,0 +1,10 @@
+BEGIN;
+
+-- Accessibility accommodations a student sits a particular exam with
+ALTER TABLE exam_enrollments
+    ADD COLUMN IF NOT EXISTS extra_time_minutes INTEGER NOT NULL DEFAULT 0 CHECK (extra_time_minutes >= 0),
+    ADD COLUMN IF NOT EXISTS separate_room BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS scribe BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS accommodation_notes TEXT NOT NULL DEFAULT '';
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_parent_attendance_alerts_relationship_period;
+ALTER TABLE parent_attendance_alerts DROP COLUMN IF EXISTS period_start;
+
+COMMIT;
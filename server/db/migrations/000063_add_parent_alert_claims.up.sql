@@ -0,0 +1,13 @@
+BEGIN;
+
+-- An alert row is claimed before the email is sent. The cooldown period it
+-- belongs to is unique per relationship, so overlapping job runs cannot both
+-- claim, and both send, the same alert.
+ALTER TABLE parent_attendance_alerts ADD COLUMN IF NOT EXISTS period_start TIMESTAMP;
+UPDATE parent_attendance_alerts SET period_start = sent_at WHERE period_start IS NULL;
+ALTER TABLE parent_attendance_alerts ALTER COLUMN period_start SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_parent_attendance_alerts_relationship_period
+    ON parent_attendance_alerts(relationship_id, period_start);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE exam_enrollments
+    DROP COLUMN IF EXISTS appeared_at;
+
+COMMIT;
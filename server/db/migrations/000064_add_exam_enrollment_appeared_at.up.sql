@@ -0,0 +1,7 @@
+BEGIN;
+
+-- When an invigilator checked the student in at the exam
+ALTER TABLE exam_enrollments
+    ADD COLUMN IF NOT EXISTS appeared_at TIMESTAMP;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS exam_question_papers;
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+-- One uploaded question paper per exam paper set. The file lives in object
+-- storage under object_key and is only handed out during the exam.
+CREATE TABLE IF NOT EXISTS exam_question_papers (
+    id SERIAL PRIMARY KEY,
+    college_id INTEGER NOT NULL REFERENCES colleges(id) ON DELETE CASCADE,
+    exam_id INTEGER NOT NULL REFERENCES exams(id) ON DELETE CASCADE,
+    paper_set INTEGER NOT NULL CHECK (paper_set > 0),
+    object_key TEXT NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    uploaded_by INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (exam_id, paper_set)
+);
+
+COMMIT;
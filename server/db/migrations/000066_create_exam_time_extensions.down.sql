@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE exam_enrollments
+    DROP COLUMN IF EXISTS extension_minutes;
+
+DROP TABLE IF EXISTS exam_time_extensions;
+
+COMMIT;
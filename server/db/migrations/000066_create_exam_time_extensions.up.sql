@@ -0,0 +1,23 @@
+BEGIN;
+
+-- Ad-hoc extra time granted during an exam, e.g. after a technical issue.
+-- Unlike accommodations these are recorded one grant at a time with who gave
+-- them and why; extension_minutes keeps the running total per enrollment.
+CREATE TABLE IF NOT EXISTS exam_time_extensions (
+    id SERIAL PRIMARY KEY,
+    college_id INTEGER NOT NULL REFERENCES colleges(id) ON DELETE CASCADE,
+    exam_id INTEGER NOT NULL REFERENCES exams(id) ON DELETE CASCADE,
+    enrollment_id INTEGER NOT NULL REFERENCES exam_enrollments(id) ON DELETE CASCADE,
+    student_id INTEGER NOT NULL,
+    extra_minutes INTEGER NOT NULL CHECK (extra_minutes > 0),
+    reason TEXT NOT NULL,
+    granted_by INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_exam_time_extensions_exam ON exam_time_extensions(exam_id, student_id);
+
+ALTER TABLE exam_enrollments
+    ADD COLUMN IF NOT EXISTS extension_minutes INTEGER NOT NULL DEFAULT 0;
+
+COMMIT;
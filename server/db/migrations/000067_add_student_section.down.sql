@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_students_college_section;
+
+ALTER TABLE students
+    DROP COLUMN IF EXISTS section;
+
+COMMIT;
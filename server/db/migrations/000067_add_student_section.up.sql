@@ -0,0 +1,10 @@
+BEGIN;
+
+-- The section (or batch) a student is taught in, e.g. "A" or "2024-CSE-B".
+-- Optional: students without one are reported as unassigned.
+ALTER TABLE students
+    ADD COLUMN IF NOT EXISTS section VARCHAR(50);
+
+CREATE INDEX IF NOT EXISTS idx_students_college_section ON students(college_id, section);
+
+COMMIT;
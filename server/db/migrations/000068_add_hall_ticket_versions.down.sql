@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE exam_enrollments
+    DROP COLUMN IF EXISTS hall_ticket_fingerprint,
+    DROP COLUMN IF EXISTS hall_ticket_generated_at,
+    DROP COLUMN IF EXISTS hall_ticket_version;
+
+COMMIT;
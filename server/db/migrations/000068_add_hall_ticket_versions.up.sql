@@ -0,0 +1,12 @@
+BEGIN;
+
+-- Each regeneration of a hall ticket with different contents (seat, room,
+-- timing...) gets the next version; only the latest version is valid.
+-- hall_ticket_fingerprint identifies the contents of the latest version so
+-- regenerating an unchanged ticket keeps its version.
+ALTER TABLE exam_enrollments
+    ADD COLUMN IF NOT EXISTS hall_ticket_version INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS hall_ticket_generated_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS hall_ticket_fingerprint TEXT;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE exams
+    DROP COLUMN IF EXISTS cancelled_at,
+    DROP COLUMN IF EXISTS cancellation_reason;
+
+COMMIT;
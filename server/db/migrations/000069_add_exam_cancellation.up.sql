@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Cancelled exams keep their record; these say when and why
+ALTER TABLE exams
+    ADD COLUMN IF NOT EXISTS cancellation_reason TEXT,
+    ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMP;
+
+COMMIT;
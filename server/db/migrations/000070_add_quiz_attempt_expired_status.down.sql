@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_quiz_attempts_in_progress_start;
+
+UPDATE quiz_attempts SET status = 'submitted' WHERE status = 'expired';
+ALTER TABLE quiz_attempts DROP CONSTRAINT IF EXISTS quiz_attempts_status_check;
+ALTER TABLE quiz_attempts ADD CONSTRAINT quiz_attempts_status_check
+    CHECK (status IN ('in_progress', 'submitted', 'graded'));
+
+COMMIT;
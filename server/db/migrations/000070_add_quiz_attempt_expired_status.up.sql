@@ -0,0 +1,11 @@
+BEGIN;
+
+-- Abandoned attempts can be closed as expired instead of submitted
+ALTER TABLE quiz_attempts DROP CONSTRAINT IF EXISTS quiz_attempts_status_check;
+ALTER TABLE quiz_attempts ADD CONSTRAINT quiz_attempts_status_check
+    CHECK (status IN ('in_progress', 'submitted', 'graded', 'expired'));
+
+CREATE INDEX IF NOT EXISTS idx_quiz_attempts_in_progress_start
+    ON quiz_attempts (start_time) WHERE status = 'in_progress';
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_quizzes_section;
+ALTER TABLE quizzes DROP CONSTRAINT IF EXISTS quizzes_section_fk;
+ALTER TABLE quizzes DROP COLUMN IF EXISTS section_id;
+
+DROP INDEX IF EXISTS idx_enrollments_section;
+ALTER TABLE enrollments DROP CONSTRAINT IF EXISTS enrollments_section_fk;
+ALTER TABLE enrollments DROP COLUMN IF EXISTS section_id;
+
+DROP TABLE IF EXISTS course_sections;
+
+COMMIT;
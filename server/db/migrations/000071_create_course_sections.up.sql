@@ -0,0 +1,30 @@
+BEGIN;
+
+-- Large courses are taught in sections; a student belongs to at most one
+-- section of each course they are enrolled in
+CREATE TABLE IF NOT EXISTS course_sections (
+    id SERIAL PRIMARY KEY,
+    college_id INTEGER NOT NULL REFERENCES colleges(id) ON DELETE CASCADE,
+    course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT course_sections_course_name_key UNIQUE (course_id, name),
+    -- Lets enrollments and quizzes reference a section of their own course
+    CONSTRAINT course_sections_id_course_key UNIQUE (id, course_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_course_sections_college_course ON course_sections(college_id, course_id);
+
+ALTER TABLE enrollments ADD COLUMN IF NOT EXISTS section_id INTEGER;
+ALTER TABLE enrollments ADD CONSTRAINT enrollments_section_fk
+    FOREIGN KEY (section_id, course_id) REFERENCES course_sections(id, course_id);
+CREATE INDEX IF NOT EXISTS idx_enrollments_section ON enrollments(section_id) WHERE section_id IS NOT NULL;
+
+-- A quiz without a section is open to the whole course
+ALTER TABLE quizzes ADD COLUMN IF NOT EXISTS section_id INTEGER;
+ALTER TABLE quizzes ADD CONSTRAINT quizzes_section_fk
+    FOREIGN KEY (section_id, course_id) REFERENCES course_sections(id, course_id);
+CREATE INDEX IF NOT EXISTS idx_quizzes_section ON quizzes(section_id) WHERE section_id IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+-- Emails sent in the background that still failed after every retry, kept so
+-- an admin can inspect and resend them
+CREATE TABLE IF NOT EXISTS email_dead_letters (
+    id SERIAL PRIMARY KEY,
+    college_id INTEGER NOT NULL REFERENCES colleges(id) ON DELETE CASCADE,
+    recipient TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    attempts INTEGER NOT NULL,
+    last_error TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resent_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_dead_letters_college_created ON email_dead_letters(college_id, created_at DESC);
+
+COMMIT;
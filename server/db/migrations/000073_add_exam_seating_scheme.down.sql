@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE exams DROP COLUMN IF EXISTS seating_scheme;
+
+COMMIT;
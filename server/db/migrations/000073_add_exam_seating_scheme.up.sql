@@ -0,0 +1,9 @@
+BEGIN;
+
+-- How seat numbers are laid out when seats are allocated: numbered in
+-- enrollment order, prefixed with the room, or ordered by roll number
+ALTER TABLE exams
+    ADD COLUMN IF NOT EXISTS seating_scheme VARCHAR(20) NOT NULL DEFAULT 'sequential'
+        CHECK (seating_scheme IN ('sequential', 'room_prefixed', 'roll_sorted'));
+
+COMMIT;
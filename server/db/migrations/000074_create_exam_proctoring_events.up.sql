@@ -0,0 +1,21 @@
+BEGIN;
+
+-- Events a remote proctoring client reports during a student's exam, such as
+-- switching tabs or the camera losing the student's face. occurred_at is
+-- when the client saw the event; created_at is when it reached the server.
+CREATE TABLE IF NOT EXISTS exam_proctoring_events (
+    id BIGSERIAL PRIMARY KEY,
+    college_id INTEGER NOT NULL REFERENCES colleges(id) ON DELETE CASCADE,
+    exam_id INTEGER NOT NULL REFERENCES exams(id) ON DELETE CASCADE,
+    enrollment_id INTEGER NOT NULL REFERENCES exam_enrollments(id) ON DELETE CASCADE,
+    student_id INTEGER NOT NULL,
+    event_type VARCHAR(30) NOT NULL
+        CHECK (event_type IN ('tab_switch', 'face_not_detected', 'disconnect')),
+    occurred_at TIMESTAMPTZ NOT NULL,
+    metadata JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_exam_proctoring_events_enrollment ON exam_proctoring_events(enrollment_id, occurred_at);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS exam_result_history;
+DROP FUNCTION IF EXISTS exam_result_history_append_only();
+
+COMMIT;
@@ -0,0 +1,39 @@
+BEGIN;
+
+-- Every version of an exam result that an update replaced, written in the
+-- same transaction as the update. Rows chain per result: entry_hash covers
+-- the row and the previous row's hash, so an edited or removed row breaks
+-- every hash after it. There are no foreign keys so the history outlives
+-- the result, and the trigger below keeps the table append-only.
+CREATE TABLE IF NOT EXISTS exam_result_history (
+    id BIGSERIAL PRIMARY KEY,
+    result_id INTEGER NOT NULL,
+    college_id INTEGER NOT NULL,
+    exam_id INTEGER NOT NULL,
+    student_id INTEGER NOT NULL,
+    version INTEGER NOT NULL,
+    marks_obtained DECIMAL(10, 2),
+    grade VARCHAR(10),
+    percentage DECIMAL(5, 2),
+    result VARCHAR(50) NOT NULL,
+    evaluated_by INTEGER,
+    evaluated_at TIMESTAMPTZ,
+    superseded_at TIMESTAMPTZ NOT NULL,
+    prev_hash VARCHAR(64) NOT NULL DEFAULT '',
+    entry_hash VARCHAR(64) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_exam_result_history_result ON exam_result_history(result_id, id);
+
+CREATE OR REPLACE FUNCTION exam_result_history_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'exam_result_history is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS exam_result_history_append_only ON exam_result_history;
+CREATE TRIGGER exam_result_history_append_only
+    BEFORE UPDATE OR DELETE ON exam_result_history
+    FOR EACH ROW EXECUTE FUNCTION exam_result_history_append_only();
+
+COMMIT;
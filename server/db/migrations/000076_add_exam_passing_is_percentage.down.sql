@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE exams DROP COLUMN IF EXISTS passing_is_percentage;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Whether passing_marks is a percentage of total_marks (e.g. 40 for 40%)
+-- rather than an absolute mark; existing exams keep absolute pass marks
+ALTER TABLE exams
+    ADD COLUMN IF NOT EXISTS passing_is_percentage BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMIT;
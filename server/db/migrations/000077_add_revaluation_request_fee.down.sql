@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_revaluation_requests_fee_assignment;
+ALTER TABLE revaluation_requests
+    DROP COLUMN IF EXISTS fee_amount,
+    DROP COLUMN IF EXISTS fee_assignment_id;
+
+COMMIT;
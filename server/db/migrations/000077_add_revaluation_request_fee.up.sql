@@ -0,0 +1,13 @@
+BEGIN;
+
+-- Colleges that charge for revaluation define a fee structure of type
+-- 'revaluation'. Each request records the fee assignment it was paid from and
+-- the fee it used up, so one payment never pays for two requests.
+ALTER TABLE revaluation_requests
+    ADD COLUMN IF NOT EXISTS fee_assignment_id INT REFERENCES fee_assignments(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS fee_amount DECIMAL(10, 2) CHECK (fee_amount >= 0);
+
+CREATE INDEX IF NOT EXISTS idx_revaluation_requests_fee_assignment
+    ON revaluation_requests(fee_assignment_id) WHERE fee_assignment_id IS NOT NULL;
+
+COMMIT;
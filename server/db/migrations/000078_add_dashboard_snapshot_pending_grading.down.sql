@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE dashboard_snapshots DROP COLUMN IF EXISTS pending_grading;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- Grading backlog captured with the rest of the college dashboard. Snapshots
+-- taken before this change did not record it and read as zero.
+ALTER TABLE dashboard_snapshots ADD COLUMN IF NOT EXISTS pending_grading INTEGER NOT NULL DEFAULT 0;
+
+COMMIT;
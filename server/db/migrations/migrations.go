@@ -0,0 +1,10 @@
+// Package migrations embeds the SQL schema migrations so the server binary
+// can apply them and check the database against them without the source tree.
+package migrations
+
+import "embed"
+
+// FS holds every NNNNNN_name.up.sql and NNNNNN_name.down.sql file in this directory
+//
+//go:embed *.sql
+var FS embed.FS
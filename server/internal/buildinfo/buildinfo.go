@@ -0,0 +1,36 @@
+// Package buildinfo holds the version details stamped into the binary at
+// build time so the API can report which build is deployed.
+package buildinfo
+
+import "runtime"
+
+// Info describes the running build
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+}
+
+var current = Info{Version: "dev", Commit: "dev", BuildDate: "dev"}
+
+// Set records the values main received through -ldflags. Empty values keep
+// the "dev" default.
+func Set(version, commit, buildDate string) {
+	if version != "" {
+		current.Version = version
+	}
+	if commit != "" {
+		current.Commit = commit
+	}
+	if buildDate != "" {
+		current.BuildDate = buildDate
+	}
+}
+
+// Get returns the build details of the running binary
+func Get() Info {
+	info := current
+	info.GoVersion = runtime.Version()
+	return info
+}
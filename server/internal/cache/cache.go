@@ -3,12 +3,16 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
+// ErrKeyNotFound is returned by Get when the key does not exist or has expired
+var ErrKeyNotFound = errors.New("key not found")
+
 // Cache interface defines cache operations
 type Cache interface {
 	Set(ctx context.Context, key string, value any, ttl time.Duration) error
@@ -118,7 +122,7 @@ func (c *RedisCache) Get(ctx context.Context, key string, dest any) error {
 	data, err := c.client.Get(ctx, c.buildKey(key)).Bytes()
 	if err != nil {
 		if err == redis.Nil {
-			return fmt.Errorf("key not found")
+			return ErrKeyNotFound
 		}
 		return fmt.Errorf("failed to get value: %w", err)
 	}
@@ -130,6 +134,17 @@ func (c *RedisCache) Get(ctx context.Context, key string, dest any) error {
 	return nil
 }
 
+// SetNX stores a value only if the key does not already exist. It reports
+// whether the value was stored, which makes it usable as a simple lock.
+func (c *RedisCache) SetNX(ctx context.Context, key string, value any, ttl time.Duration) (bool, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	return c.client.SetNX(ctx, c.buildKey(key), data, ttl).Result()
+}
+
 // Delete removes a value from Redis
 func (c *RedisCache) Delete(ctx context.Context, key string) error {
 	return c.client.Del(ctx, c.buildKey(key)).Err()
@@ -221,6 +236,7 @@ const (
 	PrefixCollege    = "college:"
 	PrefixUser       = "user:"
 	PrefixSession    = "session:"
+	PrefixExamStats  = "exam_stats:"
 )
 
 // Helper functions for common cache operations
@@ -250,6 +266,13 @@ func BuildCalendarKey(collegeID int, startDate, endDate string) string {
 	return fmt.Sprintf("%s%d:%s:%s", PrefixCalendar, collegeID, startDate, endDate)
 }
 
+// BuildExamStatsKey creates a cache key for an exam's statistics. Exam IDs
+// are unique across colleges, so callers must check the exam belongs to the
+// college before serving the entry.
+func BuildExamStatsKey(examID int) string {
+	return fmt.Sprintf("%s%d", PrefixExamStats, examID)
+}
+
 // BuildSessionKey creates a cache key for user session
 func BuildSessionKey(sessionID string) string {
 	return fmt.Sprintf("%s%s", PrefixSession, sessionID)
@@ -0,0 +1,174 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrLockHeld is returned by AcquireLock when another owner holds the lock
+var ErrLockHeld = errors.New("lock is held by another owner")
+
+// ErrLockNotHeld is returned by Release when the lock expired, and possibly
+// passed to another owner, before it was released
+var ErrLockNotHeld = errors.New("lock is no longer held")
+
+// PrefixLock namespaces lock keys apart from cached values
+const PrefixLock = "lock:"
+
+// Locker hands out named locks so that a job runs in one place at a time.
+//
+// Locks are leases: one that is not released within its ttl expires and can
+// be taken by someone else, so ttl must comfortably exceed the guarded work.
+// There are no fencing tokens, so a holder that stalls past its ttl can still
+// overlap with the next one; use locks to avoid duplicate work, not as the
+// only guard for correctness. The Redis locker relies on a single Redis
+// instance. The in-process fallback only excludes callers within the same
+// process.
+type Locker interface {
+	// AcquireLock takes the lock named key for at most ttl. It does not
+	// wait: ErrLockHeld is returned if the lock is taken.
+	AcquireLock(ctx context.Context, key string, ttl time.Duration) (*Lock, error)
+}
+
+// Lock is a held lock
+type Lock struct {
+	Key     string
+	token   string
+	release func(ctx context.Context, key, token string) error
+}
+
+// Release gives up the lock. It returns ErrLockNotHeld if the lock had
+// already expired; releasing never frees a lock taken since by another owner.
+func (l *Lock) Release(ctx context.Context) error {
+	return l.release(ctx, l.Key, l.token)
+}
+
+// NewLocker returns a Locker backed by c, or an in-process one when c is nil
+// because Redis is disabled
+func NewLocker(c *RedisCache) Locker {
+	if c == nil {
+		return NewLocalLocker()
+	}
+	return &redisLocker{cache: c}
+}
+
+// releaseScript deletes the lock only while it still holds the caller's
+// token, so an expired holder cannot free a lock someone else now owns
+var releaseScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0`)
+
+type redisLocker struct {
+	cache *RedisCache
+}
+
+func (l *redisLocker) AcquireLock(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	if err := validateLock(key, ttl); err != nil {
+		return nil, err
+	}
+	token, err := newLockToken()
+	if err != nil {
+		return nil, err
+	}
+
+	// SET NX with a sub-second precise expiry is sent as SET ... NX PX
+	acquired, err := l.cache.client.SetNX(ctx, l.cache.buildKey(PrefixLock+key), token, ttl).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock %q: %w", key, err)
+	}
+	if !acquired {
+		return nil, ErrLockHeld
+	}
+	return &Lock{Key: key, token: token, release: l.release}, nil
+}
+
+func (l *redisLocker) release(ctx context.Context, key, token string) error {
+	deleted, err := releaseScript.Run(ctx, l.cache.client, []string{l.cache.buildKey(PrefixLock + key)}, token).Int64()
+	if err != nil {
+		return fmt.Errorf("failed to release lock %q: %w", key, err)
+	}
+	if deleted == 0 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// LocalLocker is the in-process Locker used when Redis is disabled. It only
+// excludes callers within the same process.
+type LocalLocker struct {
+	mu    sync.Mutex
+	locks map[string]localLock
+	now   func() time.Time
+}
+
+type localLock struct {
+	token   string
+	expires time.Time
+}
+
+// NewLocalLocker creates an in-process Locker
+func NewLocalLocker() *LocalLocker {
+	return &LocalLocker{locks: map[string]localLock{}, now: time.Now}
+}
+
+// AcquireLock takes the lock named key for at most ttl
+func (l *LocalLocker) AcquireLock(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	if err := validateLock(key, ttl); err != nil {
+		return nil, err
+	}
+	token, err := newLockToken()
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if held, ok := l.locks[key]; ok && now.Before(held.expires) {
+		return nil, ErrLockHeld
+	}
+	l.locks[key] = localLock{token: token, expires: now.Add(ttl)}
+	return &Lock{Key: key, token: token, release: l.release}, nil
+}
+
+func (l *LocalLocker) release(_ context.Context, key, token string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	held, ok := l.locks[key]
+	if !ok || held.token != token {
+		return ErrLockNotHeld
+	}
+	delete(l.locks, key)
+	if !l.now().Before(held.expires) {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+func validateLock(key string, ttl time.Duration) error {
+	if key == "" {
+		return errors.New("lock key is required")
+	}
+	if ttl <= 0 {
+		return errors.New("lock ttl must be positive")
+	}
+	return nil
+}
+
+// newLockToken returns a random value identifying one acquisition of a lock
+func newLockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate lock token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
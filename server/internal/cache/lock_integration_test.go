@@ -0,0 +1,49 @@
+//go:build integration
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisLocker_Integration_AcquireContendRelease(t *testing.T) {
+	requireRedisAvailable(t)
+	locker := NewLocker(newTestCache(t))
+	ctx := context.Background()
+
+	lock, err := locker.AcquireLock(ctx, "seats:7", 10*time.Second)
+	require.NoError(t, err)
+
+	_, err = locker.AcquireLock(ctx, "seats:7", 10*time.Second)
+	assert.ErrorIs(t, err, ErrLockHeld)
+
+	require.NoError(t, lock.Release(ctx))
+	assert.ErrorIs(t, lock.Release(ctx), ErrLockNotHeld)
+
+	again, err := locker.AcquireLock(ctx, "seats:7", 10*time.Second)
+	require.NoError(t, err)
+	require.NoError(t, again.Release(ctx))
+}
+
+func TestRedisLocker_Integration_Expiry(t *testing.T) {
+	requireRedisAvailable(t)
+	locker := NewLocker(newTestCache(t))
+	ctx := context.Background()
+
+	first, err := locker.AcquireLock(ctx, "publish:3", 100*time.Millisecond)
+	require.NoError(t, err)
+	time.Sleep(200 * time.Millisecond)
+
+	second, err := locker.AcquireLock(ctx, "publish:3", 10*time.Second)
+	require.NoError(t, err, "an expired lock can be taken over")
+
+	assert.ErrorIs(t, first.Release(ctx), ErrLockNotHeld)
+	_, err = locker.AcquireLock(ctx, "publish:3", 10*time.Second)
+	assert.ErrorIs(t, err, ErrLockHeld, "the stale release must not free the new holder's lock")
+	require.NoError(t, second.Release(ctx))
+}
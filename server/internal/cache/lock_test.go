@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLocker_FallsBackToLocal(t *testing.T) {
+	assert.IsType(t, &LocalLocker{}, NewLocker(nil))
+}
+
+func TestLocalLocker_AcquireContendRelease(t *testing.T) {
+	ctx := context.Background()
+	locker := NewLocalLocker()
+
+	lock, err := locker.AcquireLock(ctx, "seats:7", time.Minute)
+	require.NoError(t, err)
+
+	_, err = locker.AcquireLock(ctx, "seats:7", time.Minute)
+	assert.ErrorIs(t, err, ErrLockHeld)
+
+	other, err := locker.AcquireLock(ctx, "seats:8", time.Minute)
+	require.NoError(t, err, "other keys are independent")
+	require.NoError(t, other.Release(ctx))
+
+	require.NoError(t, lock.Release(ctx))
+	assert.ErrorIs(t, lock.Release(ctx), ErrLockNotHeld)
+
+	again, err := locker.AcquireLock(ctx, "seats:7", time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, again.Release(ctx))
+}
+
+func TestLocalLocker_Expiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 5, 10, 9, 0, 0, 0, time.UTC)
+	locker := NewLocalLocker()
+	locker.now = func() time.Time { return now }
+
+	first, err := locker.AcquireLock(ctx, "publish:3", time.Second)
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Second)
+	second, err := locker.AcquireLock(ctx, "publish:3", time.Second)
+	require.NoError(t, err, "an expired lock can be taken over")
+
+	assert.ErrorIs(t, first.Release(ctx), ErrLockNotHeld)
+	_, err = locker.AcquireLock(ctx, "publish:3", time.Second)
+	assert.ErrorIs(t, err, ErrLockHeld, "the stale release must not free the new holder's lock")
+	require.NoError(t, second.Release(ctx))
+}
+
+func TestLocalLocker_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	locker := NewLocalLocker()
+
+	var acquired atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := locker.AcquireLock(ctx, "job", time.Minute); err == nil {
+				acquired.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), acquired.Load())
+}
+
+func TestLocker_Validation(t *testing.T) {
+	ctx := context.Background()
+	locker := NewLocalLocker()
+
+	_, err := locker.AcquireLock(ctx, "", time.Minute)
+	assert.Error(t, err)
+	_, err = locker.AcquireLock(ctx, "job", 0)
+	assert.Error(t, err)
+}
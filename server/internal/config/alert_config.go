@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// AlertConfig controls the scheduled low-attendance emails sent to parents.
+//
+// Environment Variables:
+//   - ATTENDANCE_ALERTS_ENABLED: Run the job on a schedule (default: false)
+//   - ATTENDANCE_ALERT_SCHEDULE: Cron expression with seconds (default: "0 0 18 * * *", daily at 6 PM)
+//   - ATTENDANCE_ALERT_THRESHOLD: Alert when attendance is below this percentage (default: 75)
+//   - ATTENDANCE_ALERT_WINDOW_DAYS: Days of attendance used to compute the rate (default: 30)
+//   - ATTENDANCE_ALERT_MIN_SESSIONS: Minimum records in the window before alerting (default: 5)
+//   - ATTENDANCE_ALERT_COOLDOWN: Minimum time between alerts for the same child (default: "168h")
+type AlertConfig struct {
+	Enabled     bool
+	Schedule    string
+	Threshold   float64
+	WindowDays  int
+	MinSessions int
+	Cooldown    time.Duration
+}
+
+// LoadAlertConfig loads parent alert configuration from environment variables
+func LoadAlertConfig() (*AlertConfig, error) {
+	config := &AlertConfig{
+		Enabled:     os.Getenv("ATTENDANCE_ALERTS_ENABLED") == "true",
+		Schedule:    os.Getenv("ATTENDANCE_ALERT_SCHEDULE"),
+		Threshold:   75,
+		WindowDays:  30,
+		MinSessions: 5,
+		Cooldown:    7 * 24 * time.Hour,
+	}
+	if config.Schedule == "" {
+		config.Schedule = "0 0 18 * * *"
+	}
+
+	if raw := os.Getenv("ATTENDANCE_ALERT_THRESHOLD"); raw != "" {
+		threshold, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ATTENDANCE_ALERT_THRESHOLD value: %w", err)
+		}
+		config.Threshold = threshold
+	}
+
+	if raw := os.Getenv("ATTENDANCE_ALERT_WINDOW_DAYS"); raw != "" {
+		days, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ATTENDANCE_ALERT_WINDOW_DAYS value: %w", err)
+		}
+		config.WindowDays = days
+	}
+
+	if raw := os.Getenv("ATTENDANCE_ALERT_MIN_SESSIONS"); raw != "" {
+		sessions, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ATTENDANCE_ALERT_MIN_SESSIONS value: %w", err)
+		}
+		config.MinSessions = sessions
+	}
+
+	if raw := os.Getenv("ATTENDANCE_ALERT_COOLDOWN"); raw != "" {
+		cooldown, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ATTENDANCE_ALERT_COOLDOWN value: %w", err)
+		}
+		config.Cooldown = cooldown
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Validate checks that the alert settings are within sensible ranges
+func (c *AlertConfig) Validate() error {
+	if c.Threshold <= 0 || c.Threshold > 100 {
+		return fmt.Errorf("AlertConfig.Threshold must be between 0 and 100, got %v", c.Threshold)
+	}
+	if c.WindowDays < 1 {
+		return fmt.Errorf("AlertConfig.WindowDays must be at least 1, got %d", c.WindowDays)
+	}
+	if c.MinSessions < 1 {
+		return fmt.Errorf("AlertConfig.MinSessions must be at least 1, got %d", c.MinSessions)
+	}
+	if c.Cooldown <= 0 {
+		return fmt.Errorf("AlertConfig.Cooldown must be positive, got %s", c.Cooldown)
+	}
+	return nil
+}
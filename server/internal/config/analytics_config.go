@@ -1,8 +1,13 @@
 package config
 
 import (
+	"bufio"
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
 )
 
 type AnalyticsConfig struct {
@@ -19,31 +24,173 @@ type AnalyticsConfig struct {
 	RiskLevelLowThreshold        float64
 	RiskMinScore                 float64
 	RiskMaxScore                 float64
+
+	// AttendanceRiskThreshold and GradeRiskThreshold flag a student as at
+	// risk of dropping out of a course when their attendance rate or average
+	// grade there, in percent, is below them. InactivityDays flags a student
+	// who has not attended the course for that many days.
+	AttendanceRiskThreshold float64
+	GradeRiskThreshold      float64
+	InactivityDays          int
+
+	// CourseGradeRiskThreshold is the average grade, in percent, below which
+	// course analytics lists a student as at risk. It is kept apart from
+	// GradeRiskThreshold because that list has always used a stricter cutoff.
+	CourseGradeRiskThreshold float64
+
+	// SkillLevelScale is the top of the skill level scale in student
+	// progression. A skill's level is its average percentage mapped linearly
+	// onto 0..SkillLevelScale, so the default of 4 turns 75% into level 3.
+	SkillLevelScale float64
 }
 
+// LoadAnalyticsConfig loads the risk model from the environment. A config that
+// fails Validate is logged and replaced with the defaults, since inverted
+// thresholds or negative weights would silently mislabel every student.
 func LoadAnalyticsConfig() *AnalyticsConfig {
+	cfg := loadAnalyticsConfig(os.Getenv)
+	if err := cfg.Validate(); err != nil {
+		log.Warn().Err(err).Msg("invalid analytics risk config, using defaults")
+		return defaultAnalyticsConfig()
+	}
+	return cfg
+}
+
+func defaultAnalyticsConfig() *AnalyticsConfig {
+	return loadAnalyticsConfig(func(string) string { return "" })
+}
+
+// ReadAnalyticsConfig loads the analytics config from the environment, with
+// any ANALYTICS_* keys in the KEY=VALUE file named by ANALYTICS_CONFIG_FILE
+// taking precedence. Unlike the environment, the file can be edited while the
+// server runs, so this is what a runtime reload calls. The result is validated.
+func ReadAnalyticsConfig() (*AnalyticsConfig, error) {
+	overrides := map[string]string{}
+	if path := os.Getenv("ANALYTICS_CONFIG_FILE"); path != "" {
+		var err error
+		overrides, err = readKeyValueFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ANALYTICS_CONFIG_FILE: %w", err)
+		}
+	}
+
+	cfg := loadAnalyticsConfig(func(key string) string {
+		if value, ok := overrides[key]; ok {
+			return value
+		}
+		return os.Getenv(key)
+	})
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func loadAnalyticsConfig(lookup func(string) string) *AnalyticsConfig {
+	get := func(key string, defaultValue float64) float64 {
+		return parseFloatOrDefault(lookup(key), defaultValue)
+	}
+
 	return &AnalyticsConfig{
-		RiskWeightGradeVeryLow:       getEnvFloat("ANALYTICS_RISK_GRADE_VERY_LOW_WEIGHT", 0.45),
-		RiskWeightGradeLow:           getEnvFloat("ANALYTICS_RISK_GRADE_LOW_WEIGHT", 0.30),
-		RiskWeightGradeMedium:        getEnvFloat("ANALYTICS_RISK_GRADE_MEDIUM_WEIGHT", 0.15),
-		RiskWeightAttendanceVeryLow:  getEnvFloat("ANALYTICS_RISK_ATTENDANCE_VERY_LOW_WEIGHT", 0.35),
-		RiskWeightAttendanceLow:      getEnvFloat("ANALYTICS_RISK_ATTENDANCE_LOW_WEIGHT", 0.25),
-		RiskWeightAttendanceMedium:   getEnvFloat("ANALYTICS_RISK_ATTENDANCE_MEDIUM_WEIGHT", 0.10),
-		RiskWeightNoRecentGrades:     getEnvFloat("ANALYTICS_RISK_NO_RECENT_GRADES_WEIGHT", 0.20),
-		RiskWeightFewRecentGrades:    getEnvFloat("ANALYTICS_RISK_FEW_RECENT_GRADES_WEIGHT", 0.10),
-		RiskWeightNoRecentAttendance: getEnvFloat("ANALYTICS_RISK_NO_RECENT_ATTENDANCE_WEIGHT", 0.10),
-		RiskLevelHighThreshold:       getEnvFloat("ANALYTICS_RISK_HIGH_THRESHOLD", 0.75),
-		RiskLevelLowThreshold:        getEnvFloat("ANALYTICS_RISK_LOW_THRESHOLD", 0.45),
-		RiskMinScore:                 getEnvFloat("ANALYTICS_RISK_MIN_SCORE", 0.05),
-		RiskMaxScore:                 getEnvFloat("ANALYTICS_RISK_MAX_SCORE", 0.99),
+		RiskWeightGradeVeryLow:       get("ANALYTICS_RISK_GRADE_VERY_LOW_WEIGHT", 0.45),
+		RiskWeightGradeLow:           get("ANALYTICS_RISK_GRADE_LOW_WEIGHT", 0.30),
+		RiskWeightGradeMedium:        get("ANALYTICS_RISK_GRADE_MEDIUM_WEIGHT", 0.15),
+		RiskWeightAttendanceVeryLow:  get("ANALYTICS_RISK_ATTENDANCE_VERY_LOW_WEIGHT", 0.35),
+		RiskWeightAttendanceLow:      get("ANALYTICS_RISK_ATTENDANCE_LOW_WEIGHT", 0.25),
+		RiskWeightAttendanceMedium:   get("ANALYTICS_RISK_ATTENDANCE_MEDIUM_WEIGHT", 0.10),
+		RiskWeightNoRecentGrades:     get("ANALYTICS_RISK_NO_RECENT_GRADES_WEIGHT", 0.20),
+		RiskWeightFewRecentGrades:    get("ANALYTICS_RISK_FEW_RECENT_GRADES_WEIGHT", 0.10),
+		RiskWeightNoRecentAttendance: get("ANALYTICS_RISK_NO_RECENT_ATTENDANCE_WEIGHT", 0.10),
+		RiskLevelHighThreshold:       get("ANALYTICS_RISK_HIGH_THRESHOLD", 0.75),
+		RiskLevelLowThreshold:        get("ANALYTICS_RISK_LOW_THRESHOLD", 0.45),
+		RiskMinScore:                 get("ANALYTICS_RISK_MIN_SCORE", 0.05),
+		RiskMaxScore:                 get("ANALYTICS_RISK_MAX_SCORE", 0.99),
+		AttendanceRiskThreshold:      get("ANALYTICS_ATTENDANCE_RISK_THRESHOLD", 60),
+		GradeRiskThreshold:           get("ANALYTICS_GRADE_RISK_THRESHOLD", 50),
+		InactivityDays:               parseIntOrDefault(lookup("ANALYTICS_INACTIVITY_DAYS"), 14),
+		CourseGradeRiskThreshold:     get("ANALYTICS_COURSE_GRADE_RISK_THRESHOLD", 60),
+		SkillLevelScale:              get("ANALYTICS_SKILL_LEVEL_SCALE", 4),
 	}
 }
 
+// Validate rejects weights and thresholds that would make risk levels meaningless
+func (c *AnalyticsConfig) Validate() error {
+	weights := []float64{
+		c.RiskWeightGradeVeryLow, c.RiskWeightGradeLow, c.RiskWeightGradeMedium,
+		c.RiskWeightAttendanceVeryLow, c.RiskWeightAttendanceLow, c.RiskWeightAttendanceMedium,
+		c.RiskWeightNoRecentGrades, c.RiskWeightFewRecentGrades, c.RiskWeightNoRecentAttendance,
+	}
+	for _, w := range weights {
+		if w < 0 {
+			return fmt.Errorf("AnalyticsConfig risk weights cannot be negative")
+		}
+	}
+	if c.RiskMinScore < 0 || c.RiskMaxScore > 1 || c.RiskMinScore >= c.RiskMaxScore {
+		return fmt.Errorf("AnalyticsConfig risk scores must satisfy 0 <= min < max <= 1")
+	}
+	if c.RiskLevelLowThreshold >= c.RiskLevelHighThreshold {
+		return fmt.Errorf("AnalyticsConfig.RiskLevelLowThreshold must be below RiskLevelHighThreshold")
+	}
+	if c.AttendanceRiskThreshold < 0 || c.AttendanceRiskThreshold > 100 {
+		return fmt.Errorf("AnalyticsConfig.AttendanceRiskThreshold must be a percentage between 0 and 100")
+	}
+	if c.GradeRiskThreshold < 0 || c.GradeRiskThreshold > 100 {
+		return fmt.Errorf("AnalyticsConfig.GradeRiskThreshold must be a percentage between 0 and 100")
+	}
+	if c.CourseGradeRiskThreshold < 0 || c.CourseGradeRiskThreshold > 100 {
+		return fmt.Errorf("AnalyticsConfig.CourseGradeRiskThreshold must be a percentage between 0 and 100")
+	}
+	if c.InactivityDays <= 0 {
+		return fmt.Errorf("AnalyticsConfig.InactivityDays must be positive")
+	}
+	if c.SkillLevelScale <= 0 {
+		return fmt.Errorf("AnalyticsConfig.SkillLevelScale must be positive")
+	}
+	return nil
+}
+
+// readKeyValueFile parses KEY=VALUE lines, skipping blanks and # comments
+func readKeyValueFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid line %q, expected KEY=VALUE", line)
+		}
+		values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return values, scanner.Err()
+}
+
 func getEnvFloat(key string, defaultValue float64) float64 {
-	if value := os.Getenv(key); value != "" {
+	return parseFloatOrDefault(os.Getenv(key), defaultValue)
+}
+
+func parseFloatOrDefault(value string, defaultValue float64) float64 {
+	if value != "" {
 		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
 			return parsed
 		}
 	}
 	return defaultValue
 }
+
+func parseIntOrDefault(value string, defaultValue int) int {
+	if value != "" {
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
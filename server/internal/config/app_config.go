@@ -7,6 +7,9 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
+
+	"eduhub/server/logger"
 )
 
 // AppConfig holds general application configuration settings.
@@ -28,11 +31,79 @@ type AppConfig struct {
 	// Default: "info"
 	LogLevel string
 
+	// SubsystemLogLevels overrides LogLevel for individual subsystems, keyed
+	// by subsystem name (see the logger.Subsystem* constants).
+	// Loaded from LOG_LEVEL_<SUBSYSTEM> environment variables, e.g. LOG_LEVEL_EXAM=debug.
+	// Default: none, every subsystem logs at LogLevel
+	SubsystemLogLevels map[string]string
+
 	// CORSOrigins specifies allowed origins for CORS requests.
 	// Loaded from CORS_ORIGINS environment variable (comma-separated).
 	// Default: ["http://localhost:3000"] for development
 	CORSOrigins []string
 
+	// CORSAllowMethods lists the HTTP methods allowed in preflight responses.
+	// Loaded from CORS_ALLOW_METHODS environment variable (comma-separated).
+	// Default: GET, POST, PUT, PATCH, DELETE
+	CORSAllowMethods []string
+
+	// CORSAllowHeaders lists the request headers clients may send cross-origin.
+	// Loaded from CORS_ALLOW_HEADERS environment variable (comma-separated).
+	// Default: the headers the web client and API middleware use
+	CORSAllowHeaders []string
+
+	// CORSAllowCredentials lets browsers send cookies and auth headers.
+	// Cannot be combined with a "*" origin.
+	// Loaded from CORS_ALLOW_CREDENTIALS environment variable (true/false).
+	// Default: true
+	CORSAllowCredentials bool
+
+	// CORSMaxAge is how long, in seconds, browsers may cache a preflight response.
+	// Loaded from CORS_MAX_AGE environment variable.
+	// Default: 3600
+	CORSMaxAge int
+
+	// ShutdownTimeout is how long the server waits for in-flight requests to
+	// finish after a shutdown signal before closing remaining connections.
+	// Loaded from APP_SHUTDOWN_TIMEOUT environment variable (Go duration, e.g. "30s").
+	// Default: 30s
+	ShutdownTimeout time.Duration
+
+	// MetricsEnabled exposes the Prometheus /metrics endpoint and per-route
+	// request instrumentation.
+	// Loaded from METRICS_ENABLED environment variable (true/false).
+	// Default: false
+	MetricsEnabled bool
+
+	// MetricsToken, when set, must be presented as a bearer token to read
+	// /metrics. Required in production when metrics are enabled.
+	// Loaded from METRICS_TOKEN (or METRICS_TOKEN_FILE) environment variable.
+	MetricsToken string
+
+	// MaxRequestBodyBytes caps the size of a request body other than a
+	// multipart upload.
+	// Loaded from MAX_REQUEST_BODY_MB environment variable (MiB).
+	// Default: 2 MiB
+	MaxRequestBodyBytes int64
+
+	// MaxUploadBodyBytes caps the size of a multipart/form-data request body.
+	// It must leave room for the largest file an upload endpoint accepts.
+	// Loaded from MAX_UPLOAD_SIZE_MB environment variable (MiB).
+	// Default: 50 MiB
+	MaxUploadBodyBytes int64
+
+	// MaxBulkItems is the most items (students, grades, attendance rows) one
+	// bulk request may carry.
+	// Loaded from MAX_BULK_ITEMS environment variable.
+	// Default: 1000
+	MaxBulkItems int
+
+	// FrontendURL is the base URL of the web client, used to build links in
+	// outgoing emails (e.g. parent relationship verification).
+	// Loaded from FRONTEND_URL environment variable.
+	// Default: "http://localhost:3000"
+	FrontendURL string
+
 	// Razorpay configuration
 	RazorpayKey           string
 	RazorpaySecret        string
@@ -50,6 +121,17 @@ type AppConfig struct {
 //   - APP_PORT: The port for the application server (default: "8080")
 //   - APP_DEBUG: Enable debug mode (default: false)
 //   - APP_LOG_LEVEL: Logging level (default: "info")
+//   - LOG_LEVEL_<SUBSYSTEM>: Logging level of one subsystem, e.g. LOG_LEVEL_EXAM (default: APP_LOG_LEVEL)
+//   - APP_SHUTDOWN_TIMEOUT: Graceful shutdown drain timeout (default: "30s")
+//   - METRICS_ENABLED: Expose the Prometheus /metrics endpoint (default: false)
+//   - METRICS_TOKEN: Bearer token required to read /metrics (required in production when enabled)
+//   - MAX_REQUEST_BODY_MB: Largest non-upload request body in MiB (default: 2)
+//   - MAX_UPLOAD_SIZE_MB: Largest multipart upload body in MiB (default: 50)
+//   - MAX_BULK_ITEMS: Most items accepted by one bulk request (default: 1000)
+//   - FRONTEND_URL: Base URL of the web client used in email links (default: "http://localhost:3000")
+//   - CORS_ALLOW_METHODS, CORS_ALLOW_HEADERS: Comma-separated preflight allow lists
+//   - CORS_ALLOW_CREDENTIALS: Allow cookies/credentials cross-origin (default: true)
+//   - CORS_MAX_AGE: Preflight cache lifetime in seconds (default: 3600)
 //
 // Security Considerations:
 //   - Port is validated to be a valid integer between 1 and 65535
@@ -82,17 +164,17 @@ func LoadAppConfig() (*AppConfig, error) {
 	}
 
 	// Validate log level (restrict to known safe values)
-	validLogLevels := map[string]bool{
-		"debug": true,
-		"info":  true,
-		"warn":  true,
-		"error": true,
-	}
-	if !validLogLevels[logLevel] {
-		return nil, fmt.Errorf("invalid APP_LOG_LEVEL: must be one of 'debug', 'info', 'warn', 'error', got %s", logLevel)
+	if err := validateLogLevel("APP_LOG_LEVEL", logLevel); err != nil {
+		return nil, err
 	}
 	config.LogLevel = logLevel
 
+	subsystemLevels, err := loadSubsystemLogLevels()
+	if err != nil {
+		return nil, err
+	}
+	config.SubsystemLogLevels = subsystemLevels
+
 	// Load CORS origins with secure default
 	corsOriginsStr := os.Getenv("CORS_ORIGINS")
 	if corsOriginsStr == "" {
@@ -113,9 +195,96 @@ func LoadAppConfig() (*AppConfig, error) {
 		}
 	}
 
+	config.CORSAllowMethods = splitList(os.Getenv("CORS_ALLOW_METHODS"), defaultCORSAllowMethods)
+	for i, method := range config.CORSAllowMethods {
+		method = strings.ToUpper(method)
+		if !validCORSMethods[method] {
+			return nil, fmt.Errorf("invalid CORS_ALLOW_METHODS: unknown method %s", method)
+		}
+		config.CORSAllowMethods[i] = method
+	}
+	config.CORSAllowHeaders = splitList(os.Getenv("CORS_ALLOW_HEADERS"), defaultCORSAllowHeaders)
+
+	config.CORSAllowCredentials = true
+	if raw := os.Getenv("CORS_ALLOW_CREDENTIALS"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CORS_ALLOW_CREDENTIALS: must be true or false, got %s", raw)
+		}
+		config.CORSAllowCredentials = parsed
+	}
+
+	config.CORSMaxAge = 3600
+	if raw := os.Getenv("CORS_MAX_AGE"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("invalid CORS_MAX_AGE: must be a non-negative number of seconds, got %s", raw)
+		}
+		config.CORSMaxAge = parsed
+	}
+
+	if err := config.validateCORS(); err != nil {
+		return nil, err
+	}
+
+	// Load graceful shutdown timeout
+	shutdownTimeout := 30 * time.Second
+	if raw := os.Getenv("APP_SHUTDOWN_TIMEOUT"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid APP_SHUTDOWN_TIMEOUT: must be a positive duration (e.g. \"30s\"), got %s", raw)
+		}
+		shutdownTimeout = parsed
+	}
+	config.ShutdownTimeout = shutdownTimeout
+
+	// Load request size limits
+	config.MaxRequestBodyBytes, err = loadSizeMB("MAX_REQUEST_BODY_MB", 2)
+	if err != nil {
+		return nil, err
+	}
+	config.MaxUploadBodyBytes, err = loadSizeMB("MAX_UPLOAD_SIZE_MB", 50)
+	if err != nil {
+		return nil, err
+	}
+	config.MaxBulkItems = 1000
+	if raw := os.Getenv("MAX_BULK_ITEMS"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid MAX_BULK_ITEMS: must be a positive number, got %s", raw)
+		}
+		config.MaxBulkItems = parsed
+	}
+
+	// Metrics are opt-in because /metrics is served outside the API auth
+	config.MetricsEnabled = os.Getenv("METRICS_ENABLED") == "true"
+	metricsToken, err := getSecret("METRICS_TOKEN")
+	if err != nil {
+		return nil, err
+	}
+	config.MetricsToken = metricsToken
+
+	// Load frontend URL used for links in emails
+	frontendURL := strings.TrimRight(strings.TrimSpace(os.Getenv("FRONTEND_URL")), "/")
+	if frontendURL == "" {
+		frontendURL = "http://localhost:3000"
+	}
+	if !strings.HasPrefix(frontendURL, "http://") && !strings.HasPrefix(frontendURL, "https://") {
+		return nil, fmt.Errorf("invalid FRONTEND_URL: must start with http:// or https://, got %s", frontendURL)
+	}
+	config.FrontendURL = frontendURL
+
 	config.RazorpayKey = os.Getenv("RAZORPAY_KEY_ID")
-	config.RazorpaySecret = os.Getenv("RAZORPAY_KEY_SECRET")
-	config.RazorpayWebhookSecret = os.Getenv("RAZORPAY_WEBHOOK_SECRET")
+	razorpaySecret, err := getSecret("RAZORPAY_KEY_SECRET")
+	if err != nil {
+		return nil, err
+	}
+	config.RazorpaySecret = razorpaySecret
+	webhookSecret, err := getSecret("RAZORPAY_WEBHOOK_SECRET")
+	if err != nil {
+		return nil, err
+	}
+	config.RazorpayWebhookSecret = webhookSecret
 
 	appEnv := os.Getenv("APP_ENV")
 	if appEnv == "production" {
@@ -130,6 +299,9 @@ func LoadAppConfig() (*AppConfig, error) {
 				return nil, fmt.Errorf("SECURITY ERROR: CORS origins contain localhost in production environment")
 			}
 		}
+		if config.MetricsEnabled && config.MetricsToken == "" {
+			return nil, fmt.Errorf("SECURITY ERROR: METRICS_TOKEN must be set when METRICS_ENABLED is true in production environment")
+		}
 	}
 
 	return config, nil
@@ -146,5 +318,99 @@ func (c *AppConfig) Validate() error {
 	if c.LogLevel == "" {
 		return fmt.Errorf("AppConfig.LogLevel cannot be empty")
 	}
+	for name, level := range c.SubsystemLogLevels {
+		if err := validateLogLevel("LOG_LEVEL_"+strings.ToUpper(name), level); err != nil {
+			return err
+		}
+	}
+	return c.validateCORS()
+}
+
+var validLogLevels = map[string]bool{
+	"debug": true,
+	"info":  true,
+	"warn":  true,
+	"error": true,
+}
+
+func validateLogLevel(envVar, level string) error {
+	if !validLogLevels[level] {
+		return fmt.Errorf("invalid %s: must be one of 'debug', 'info', 'warn', 'error', got %s", envVar, level)
+	}
+	return nil
+}
+
+// loadSubsystemLogLevels collects the LOG_LEVEL_<SUBSYSTEM> overrides. An
+// unknown subsystem is rejected so a typo doesn't silently do nothing.
+func loadSubsystemLogLevels() (map[string]string, error) {
+	const prefix = "LOG_LEVEL_"
+	levels := make(map[string]string)
+	for _, entry := range os.Environ() {
+		envVar, level, _ := strings.Cut(entry, "=")
+		if !strings.HasPrefix(envVar, prefix) || level == "" {
+			continue
+		}
+		name := strings.ToLower(strings.TrimPrefix(envVar, prefix))
+		if !logger.IsSubsystem(name) {
+			return nil, fmt.Errorf("invalid %s: unknown subsystem %q", envVar, name)
+		}
+		if err := validateLogLevel(envVar, level); err != nil {
+			return nil, err
+		}
+		levels[name] = level
+	}
+	return levels, nil
+}
+
+var (
+	defaultCORSAllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+	defaultCORSAllowHeaders = []string{
+		"Origin", "Content-Type", "Accept", "Authorization", "X-Requested-With",
+		"X-Client-Version", "X-Request-Id", "Idempotency-Key",
+	}
+	validCORSMethods = map[string]bool{
+		"GET": true, "HEAD": true, "POST": true, "PUT": true,
+		"PATCH": true, "DELETE": true, "OPTIONS": true,
+	}
+)
+
+// validateCORS rejects credentials with a wildcard origin: browsers refuse
+// that combination, and echo would otherwise reflect any origin back
+func (c *AppConfig) validateCORS() error {
+	if !c.CORSAllowCredentials {
+		return nil
+	}
+	for _, origin := range c.CORSOrigins {
+		if origin == "*" {
+			return fmt.Errorf("CORS_ALLOW_CREDENTIALS cannot be true when CORS_ORIGINS contains \"*\"")
+		}
+	}
 	return nil
 }
+
+// loadSizeMB reads a positive size in MiB from envVar and returns it in bytes
+func loadSizeMB(envVar string, defaultMB int64) (int64, error) {
+	raw := os.Getenv(envVar)
+	if raw == "" {
+		return defaultMB << 20, nil
+	}
+	parsed, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || parsed <= 0 || parsed > 1<<20 {
+		return 0, fmt.Errorf("invalid %s: must be a positive number of MiB, got %s", envVar, raw)
+	}
+	return parsed << 20, nil
+}
+
+// splitList parses a comma-separated value, returning a copy of defaults when it is empty
+func splitList(raw string, defaults []string) []string {
+	values := make([]string, 0)
+	for _, item := range strings.Split(raw, ",") {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			values = append(values, trimmed)
+		}
+	}
+	if len(values) == 0 {
+		return append([]string(nil), defaults...)
+	}
+	return values
+}
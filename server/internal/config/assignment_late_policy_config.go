@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// AssignmentLatePolicyConfig is the default late submission policy. Each
+// assignment may override any of it.
+//
+// Environment Variables:
+//   - ASSIGNMENT_LATE_PENALTY_POLICY: "none", "flat" or "per_day" (default: "per_day")
+//   - ASSIGNMENT_LATE_PENALTY_PERCENT: Percent deducted once (flat) or per started day late (per_day) (default: 10)
+//   - ASSIGNMENT_LATE_PENALTY_MAX_PERCENT: Cap on the total deduction, 0 leaves it uncapped (default: 50)
+//   - ASSIGNMENT_LATE_CUTOFF_AFTER: Reject submissions this long after the due date, "0" accepts them indefinitely (default: "0")
+type AssignmentLatePolicyConfig struct {
+	Policy      string
+	Percent     int
+	MaxPercent  int
+	CutoffAfter time.Duration
+}
+
+// LoadAssignmentLatePolicyConfig loads the late submission policy from environment variables
+func LoadAssignmentLatePolicyConfig() (*AssignmentLatePolicyConfig, error) {
+	config := &AssignmentLatePolicyConfig{
+		Policy:     os.Getenv("ASSIGNMENT_LATE_PENALTY_POLICY"),
+		Percent:    10,
+		MaxPercent: 50,
+	}
+	if config.Policy == "" {
+		config.Policy = "per_day"
+	}
+
+	if raw := os.Getenv("ASSIGNMENT_LATE_PENALTY_PERCENT"); raw != "" {
+		percent, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ASSIGNMENT_LATE_PENALTY_PERCENT value: %w", err)
+		}
+		config.Percent = percent
+	}
+
+	if raw := os.Getenv("ASSIGNMENT_LATE_PENALTY_MAX_PERCENT"); raw != "" {
+		percent, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ASSIGNMENT_LATE_PENALTY_MAX_PERCENT value: %w", err)
+		}
+		config.MaxPercent = percent
+	}
+
+	if raw := os.Getenv("ASSIGNMENT_LATE_CUTOFF_AFTER"); raw != "" {
+		cutoff, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ASSIGNMENT_LATE_CUTOFF_AFTER value: %w", err)
+		}
+		config.CutoffAfter = cutoff
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Validate checks the policy name and that percentages are within 0-100
+func (c *AssignmentLatePolicyConfig) Validate() error {
+	switch c.Policy {
+	case "none", "flat", "per_day":
+	default:
+		return fmt.Errorf("AssignmentLatePolicyConfig.Policy must be none, flat or per_day, got %q", c.Policy)
+	}
+	if c.Percent < 0 || c.Percent > 100 {
+		return fmt.Errorf("AssignmentLatePolicyConfig.Percent must be between 0 and 100, got %d", c.Percent)
+	}
+	if c.MaxPercent < 0 || c.MaxPercent > 100 {
+		return fmt.Errorf("AssignmentLatePolicyConfig.MaxPercent must be between 0 and 100, got %d", c.MaxPercent)
+	}
+	if c.CutoffAfter < 0 {
+		return fmt.Errorf("AssignmentLatePolicyConfig.CutoffAfter cannot be negative, got %s", c.CutoffAfter)
+	}
+	return nil
+}
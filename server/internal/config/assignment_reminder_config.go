@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// AssignmentReminderConfig controls the in-app reminders sent to students for
+// assignments they haven't submitted shortly before the due date.
+//
+// Environment Variables:
+//   - ASSIGNMENT_REMINDERS_ENABLED: Run the job on a schedule (default: false)
+//   - ASSIGNMENT_REMINDER_SCHEDULE: Cron expression with seconds (default: "0 0 * * * *", hourly)
+//   - ASSIGNMENT_REMINDER_WINDOW: Remind about assignments due within this long (default: "24h")
+type AssignmentReminderConfig struct {
+	Enabled  bool
+	Schedule string
+	Window   time.Duration
+}
+
+// LoadAssignmentReminderConfig loads assignment reminder configuration from environment variables
+func LoadAssignmentReminderConfig() (*AssignmentReminderConfig, error) {
+	config := &AssignmentReminderConfig{
+		Enabled:  os.Getenv("ASSIGNMENT_REMINDERS_ENABLED") == "true",
+		Schedule: os.Getenv("ASSIGNMENT_REMINDER_SCHEDULE"),
+		Window:   24 * time.Hour,
+	}
+	if config.Schedule == "" {
+		config.Schedule = "0 0 * * * *"
+	}
+
+	if raw := os.Getenv("ASSIGNMENT_REMINDER_WINDOW"); raw != "" {
+		window, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ASSIGNMENT_REMINDER_WINDOW value: %w", err)
+		}
+		config.Window = window
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Validate checks that the reminder window is usable
+func (c *AssignmentReminderConfig) Validate() error {
+	if c.Window <= 0 {
+		return fmt.Errorf("AssignmentReminderConfig.Window must be positive, got %s", c.Window)
+	}
+	return nil
+}
@@ -39,6 +39,30 @@ type Config struct {
 	// Loaded via LoadStorageConfig() from the storage configuration module.
 	StorageConfig *StorageConfig
 
+	// AlertConfig contains settings for scheduled parent attendance alerts.
+	// Loaded via LoadAlertConfig() from the alert configuration module.
+	AlertConfig *AlertConfig
+
+	// ExamConfig contains exam administration settings such as invigilation staffing.
+	// Loaded via LoadExamConfig() from the exam configuration module.
+	ExamConfig *ExamConfig
+
+	// DashboardSnapshotConfig controls the daily dashboard history capture and retention.
+	// Loaded via LoadDashboardSnapshotConfig() from the dashboard snapshot configuration module.
+	DashboardSnapshotConfig *DashboardSnapshotConfig
+
+	// AssignmentReminderConfig controls the scheduled "assignment due soon" reminders.
+	// Loaded via LoadAssignmentReminderConfig() from the assignment reminder configuration module.
+	AssignmentReminderConfig *AssignmentReminderConfig
+
+	// QuizAttemptCleanupConfig controls the scheduled closing of abandoned quiz attempts.
+	// Loaded via LoadQuizAttemptCleanupConfig() from the quiz attempt cleanup configuration module.
+	QuizAttemptCleanupConfig *QuizAttemptCleanupConfig
+
+	// AssignmentLatePolicyConfig is the default late submission penalty and cutoff.
+	// Loaded via LoadAssignmentLatePolicyConfig() from the assignment late policy configuration module.
+	AssignmentLatePolicyConfig *AssignmentLatePolicyConfig
+
 	// AppPort is the port for the application server (deprecated, use AppConfig.Port).
 	// Kept for backward compatibility.
 	AppPort string
@@ -107,16 +131,58 @@ func NewConfig() (*Config, error) {
 		storageConfig = nil
 	}
 
+	// Load parent alert configuration
+	alertConfig, err := LoadAlertConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load alert config: %w", err)
+	}
+
+	// Load exam configuration
+	examConfig, err := LoadExamConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load exam config: %w", err)
+	}
+
+	// Load dashboard snapshot configuration
+	snapshotConfig, err := LoadDashboardSnapshotConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dashboard snapshot config: %w", err)
+	}
+
+	// Load assignment reminder configuration
+	reminderConfig, err := LoadAssignmentReminderConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load assignment reminder config: %w", err)
+	}
+
+	// Load quiz attempt cleanup configuration
+	quizCleanupConfig, err := LoadQuizAttemptCleanupConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load quiz attempt cleanup config: %w", err)
+	}
+
+	// Load assignment late policy configuration
+	latePolicyConfig, err := LoadAssignmentLatePolicyConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load assignment late policy config: %w", err)
+	}
+
 	// Create the main config
 	cfg := &Config{
-		DB:            db,
-		DBConfig:      dbConfig,
-		AuthConfig:    authConfig,
-		AppConfig:     appConfig,
-		RedisConfig:   redisConfig,
-		EmailConfig:   emailConfig,
-		StorageConfig: storageConfig,
-		AppPort:       appConfig.Port,
+		DB:                         db,
+		DBConfig:                   dbConfig,
+		AuthConfig:                 authConfig,
+		AppConfig:                  appConfig,
+		RedisConfig:                redisConfig,
+		EmailConfig:                emailConfig,
+		StorageConfig:              storageConfig,
+		AlertConfig:                alertConfig,
+		ExamConfig:                 examConfig,
+		DashboardSnapshotConfig:    snapshotConfig,
+		AssignmentReminderConfig:   reminderConfig,
+		QuizAttemptCleanupConfig:   quizCleanupConfig,
+		AssignmentLatePolicyConfig: latePolicyConfig,
+		AppPort:                    appConfig.Port,
 	}
 
 	// Perform comprehensive validation
@@ -183,6 +249,36 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("StorageConfig validation failed: %w", err)
 		}
 	}
+	if c.AlertConfig != nil {
+		if err := c.AlertConfig.Validate(); err != nil {
+			return fmt.Errorf("AlertConfig validation failed: %w", err)
+		}
+	}
+	if c.ExamConfig != nil {
+		if err := c.ExamConfig.Validate(); err != nil {
+			return fmt.Errorf("ExamConfig validation failed: %w", err)
+		}
+	}
+	if c.DashboardSnapshotConfig != nil {
+		if err := c.DashboardSnapshotConfig.Validate(); err != nil {
+			return fmt.Errorf("DashboardSnapshotConfig validation failed: %w", err)
+		}
+	}
+	if c.AssignmentReminderConfig != nil {
+		if err := c.AssignmentReminderConfig.Validate(); err != nil {
+			return fmt.Errorf("AssignmentReminderConfig validation failed: %w", err)
+		}
+	}
+	if c.QuizAttemptCleanupConfig != nil {
+		if err := c.QuizAttemptCleanupConfig.Validate(); err != nil {
+			return fmt.Errorf("QuizAttemptCleanupConfig validation failed: %w", err)
+		}
+	}
+	if c.AssignmentLatePolicyConfig != nil {
+		if err := c.AssignmentLatePolicyConfig.Validate(); err != nil {
+			return fmt.Errorf("AssignmentLatePolicyConfig validation failed: %w", err)
+		}
+	}
 
 	return nil
 }
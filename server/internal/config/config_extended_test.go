@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"eduhub/server/internal/repository"
 
@@ -108,6 +109,30 @@ func TestLoadAppConfig(t *testing.T) {
 		assert.Contains(t, err.Error(), "APP_LOG_LEVEL")
 	})
 
+	t.Run("subsystem log level overrides", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("LOG_LEVEL_EXAM", "debug")
+		cfg, err := LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"exam": "debug"}, cfg.SubsystemLogLevels)
+	})
+
+	t.Run("invalid subsystem log level", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("LOG_LEVEL_EXAM", "trace")
+		_, err := LoadAppConfig()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "LOG_LEVEL_EXAM")
+	})
+
+	t.Run("unknown log subsystem", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("LOG_LEVEL_GRADING", "debug")
+		_, err := LoadAppConfig()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown subsystem")
+	})
+
 	t.Run("custom CORS origins", func(t *testing.T) {
 		os.Clearenv()
 		os.Setenv("CORS_ORIGINS", "https://example.com, https://app.example.com")
@@ -164,6 +189,169 @@ func TestLoadAppConfig(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, "key", cfg.RazorpayKey)
 	})
+
+	t.Run("default shutdown timeout", func(t *testing.T) {
+		os.Clearenv()
+		cfg, err := LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 30*time.Second, cfg.ShutdownTimeout)
+	})
+
+	t.Run("custom shutdown timeout", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("APP_SHUTDOWN_TIMEOUT", "45s")
+		cfg, err := LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 45*time.Second, cfg.ShutdownTimeout)
+	})
+
+	t.Run("metrics disabled by default", func(t *testing.T) {
+		os.Clearenv()
+		cfg, err := LoadAppConfig()
+		require.NoError(t, err)
+		assert.False(t, cfg.MetricsEnabled)
+	})
+
+	t.Run("metrics can be enabled with a token", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("METRICS_ENABLED", "true")
+		os.Setenv("METRICS_TOKEN", "scrape-secret")
+		cfg, err := LoadAppConfig()
+		require.NoError(t, err)
+		assert.True(t, cfg.MetricsEnabled)
+		assert.Equal(t, "scrape-secret", cfg.MetricsToken)
+	})
+
+	t.Run("metrics without token rejected in production", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("APP_ENV", "production")
+		os.Setenv("RAZORPAY_KEY_ID", "key")
+		os.Setenv("RAZORPAY_KEY_SECRET", "secret")
+		os.Setenv("RAZORPAY_WEBHOOK_SECRET", "webhook")
+		os.Setenv("CORS_ORIGINS", "https://app.example.com")
+		os.Setenv("METRICS_ENABLED", "true")
+		_, err := LoadAppConfig()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "METRICS_TOKEN")
+	})
+
+	t.Run("default frontend URL", func(t *testing.T) {
+		os.Clearenv()
+		cfg, err := LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "http://localhost:3000", cfg.FrontendURL)
+	})
+
+	t.Run("frontend URL trailing slash is trimmed", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("FRONTEND_URL", "https://eduhub.example.com/")
+		cfg, err := LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "https://eduhub.example.com", cfg.FrontendURL)
+	})
+
+	t.Run("invalid frontend URL", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("FRONTEND_URL", "eduhub.example.com")
+		_, err := LoadAppConfig()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "FRONTEND_URL")
+	})
+
+	t.Run("invalid shutdown timeout", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("APP_SHUTDOWN_TIMEOUT", "soon")
+		_, err := LoadAppConfig()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "APP_SHUTDOWN_TIMEOUT")
+	})
+
+	t.Run("default request limits", func(t *testing.T) {
+		os.Clearenv()
+		cfg, err := LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, int64(2<<20), cfg.MaxRequestBodyBytes)
+		assert.Equal(t, int64(50<<20), cfg.MaxUploadBodyBytes)
+		assert.Equal(t, 1000, cfg.MaxBulkItems)
+	})
+
+	t.Run("custom request limits", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("MAX_REQUEST_BODY_MB", "5")
+		os.Setenv("MAX_UPLOAD_SIZE_MB", "100")
+		os.Setenv("MAX_BULK_ITEMS", "250")
+		cfg, err := LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, int64(5<<20), cfg.MaxRequestBodyBytes)
+		assert.Equal(t, int64(100<<20), cfg.MaxUploadBodyBytes)
+		assert.Equal(t, 250, cfg.MaxBulkItems)
+	})
+
+	t.Run("invalid request limits", func(t *testing.T) {
+		for key, value := range map[string]string{
+			"MAX_REQUEST_BODY_MB": "0",
+			"MAX_UPLOAD_SIZE_MB":  "lots",
+			"MAX_BULK_ITEMS":      "-1",
+		} {
+			os.Clearenv()
+			os.Setenv(key, value)
+			_, err := LoadAppConfig()
+			require.Error(t, err, key)
+			assert.Contains(t, err.Error(), key)
+		}
+	})
+
+	t.Run("default CORS preflight settings", func(t *testing.T) {
+		os.Clearenv()
+		cfg, err := LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"GET", "POST", "PUT", "PATCH", "DELETE"}, cfg.CORSAllowMethods)
+		assert.Contains(t, cfg.CORSAllowHeaders, "Authorization")
+		assert.Contains(t, cfg.CORSAllowHeaders, "Idempotency-Key")
+		assert.True(t, cfg.CORSAllowCredentials)
+		assert.Equal(t, 3600, cfg.CORSMaxAge)
+	})
+
+	t.Run("custom CORS preflight settings", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("CORS_ALLOW_METHODS", "get, post")
+		os.Setenv("CORS_ALLOW_HEADERS", "Content-Type, X-Tenant")
+		os.Setenv("CORS_ALLOW_CREDENTIALS", "false")
+		os.Setenv("CORS_MAX_AGE", "600")
+		cfg, err := LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"GET", "POST"}, cfg.CORSAllowMethods)
+		assert.Equal(t, []string{"Content-Type", "X-Tenant"}, cfg.CORSAllowHeaders)
+		assert.False(t, cfg.CORSAllowCredentials)
+		assert.Equal(t, 600, cfg.CORSMaxAge)
+	})
+
+	t.Run("credentials with wildcard origin", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("CORS_ORIGINS", "*")
+		_, err := LoadAppConfig()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "CORS_ALLOW_CREDENTIALS")
+
+		os.Setenv("CORS_ALLOW_CREDENTIALS", "false")
+		cfg, err := LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"*"}, cfg.CORSOrigins)
+	})
+
+	t.Run("invalid CORS values", func(t *testing.T) {
+		for key, value := range map[string]string{
+			"CORS_ALLOW_METHODS":     "GET,FETCH",
+			"CORS_ALLOW_CREDENTIALS": "sometimes",
+			"CORS_MAX_AGE":           "-5",
+		} {
+			os.Clearenv()
+			os.Setenv(key, value)
+			_, err := LoadAppConfig()
+			require.Error(t, err, key)
+			assert.Contains(t, err.Error(), key)
+		}
+	})
 }
 
 // --- DBConfig.Validate ---
@@ -430,6 +618,11 @@ func TestStorageConfig_Validate(t *testing.T) {
 		cfg := &StorageConfig{Endpoint: "localhost:9000", Bucket: "eduhub", PresignedURLExpirySeconds: 0}
 		require.Error(t, cfg.Validate())
 	})
+
+	t.Run("unknown file scanner fails", func(t *testing.T) {
+		cfg := &StorageConfig{Endpoint: "localhost:9000", Bucket: "eduhub", PresignedURLExpirySeconds: 3600, FileScanner: "clamav"}
+		require.Error(t, cfg.Validate())
+	})
 }
 
 // --- LoadStorageConfig ---
@@ -444,6 +637,10 @@ func TestLoadStorageConfig(t *testing.T) {
 		assert.Equal(t, "us-east-1", cfg.Region)
 		assert.False(t, cfg.UseSSL)
 		assert.Equal(t, int64(3600), cfg.PresignedURLExpirySeconds)
+		assert.Equal(t, int64(604800), cfg.MaxPresignedURLExpirySeconds)
+		assert.Equal(t, int64(25<<20), cfg.MaxSubmissionSizeBytes)
+		assert.Contains(t, cfg.AllowedSubmissionTypes, "application/pdf")
+		assert.Equal(t, "none", cfg.FileScanner)
 	})
 
 	t.Run("custom values", func(t *testing.T) {
@@ -462,12 +659,37 @@ func TestLoadStorageConfig(t *testing.T) {
 		assert.Equal(t, int64(7200), cfg.PresignedURLExpirySeconds)
 	})
 
+	t.Run("submission limits", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("STORAGE_SUBMISSION_MAX_SIZE_MB", "10")
+		os.Setenv("STORAGE_SUBMISSION_ALLOWED_TYPES", "application/pdf, Image/PNG ,")
+		cfg, err := LoadStorageConfig()
+		require.NoError(t, err)
+		assert.Equal(t, int64(10<<20), cfg.MaxSubmissionSizeBytes)
+		assert.Equal(t, []string{"application/pdf", "image/png"}, cfg.AllowedSubmissionTypes)
+	})
+
+	t.Run("negative submission size", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("STORAGE_SUBMISSION_MAX_SIZE_MB", "-1")
+		_, err := LoadStorageConfig()
+		require.Error(t, err)
+	})
+
 	t.Run("invalid expiry", func(t *testing.T) {
 		os.Clearenv()
 		os.Setenv("STORAGE_PRESIGNED_URL_EXPIRY", "not-a-number")
 		_, err := LoadStorageConfig()
 		require.Error(t, err)
 	})
+
+	t.Run("default expiry above the maximum", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("STORAGE_PRESIGNED_URL_EXPIRY", "7200")
+		os.Setenv("STORAGE_PRESIGNED_URL_MAX_EXPIRY", "3600")
+		_, err := LoadStorageConfig()
+		require.Error(t, err)
+	})
 }
 
 // --- EmailConfig.Validate ---
@@ -566,6 +788,11 @@ func TestLoadAnalyticsConfig(t *testing.T) {
 		assert.Equal(t, 0.45, cfg.RiskLevelLowThreshold)
 		assert.Equal(t, 0.05, cfg.RiskMinScore)
 		assert.Equal(t, 0.99, cfg.RiskMaxScore)
+		assert.Equal(t, 4.0, cfg.SkillLevelScale)
+		assert.Equal(t, 60.0, cfg.AttendanceRiskThreshold)
+		assert.Equal(t, 50.0, cfg.GradeRiskThreshold)
+		assert.Equal(t, 14, cfg.InactivityDays)
+		assert.Equal(t, 60.0, cfg.CourseGradeRiskThreshold)
 	})
 
 	t.Run("custom values from env", func(t *testing.T) {
@@ -583,6 +810,216 @@ func TestLoadAnalyticsConfig(t *testing.T) {
 		cfg := LoadAnalyticsConfig()
 		assert.Equal(t, 0.45, cfg.RiskWeightGradeVeryLow)
 	})
+
+	t.Run("inverted thresholds revert to defaults", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("ANALYTICS_RISK_GRADE_VERY_LOW_WEIGHT", "0.50")
+		os.Setenv("ANALYTICS_RISK_HIGH_THRESHOLD", "0.40")
+		os.Setenv("ANALYTICS_RISK_LOW_THRESHOLD", "0.60")
+		cfg := LoadAnalyticsConfig()
+		assert.Equal(t, 0.75, cfg.RiskLevelHighThreshold)
+		assert.Equal(t, 0.45, cfg.RiskLevelLowThreshold)
+		assert.Equal(t, 0.45, cfg.RiskWeightGradeVeryLow)
+	})
+
+	t.Run("negative weight reverts to defaults", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("ANALYTICS_RISK_ATTENDANCE_LOW_WEIGHT", "-0.25")
+		cfg := LoadAnalyticsConfig()
+		assert.Equal(t, 0.25, cfg.RiskWeightAttendanceLow)
+	})
+}
+
+func TestAnalyticsConfigValidate(t *testing.T) {
+	valid := func() *AnalyticsConfig {
+		os.Clearenv()
+		return LoadAnalyticsConfig()
+	}
+
+	assert.NoError(t, valid().Validate())
+
+	cfg := valid()
+	cfg.RiskLevelLowThreshold = cfg.RiskLevelHighThreshold
+	assert.Error(t, cfg.Validate())
+
+	cfg = valid()
+	cfg.RiskWeightNoRecentGrades = -0.1
+	assert.Error(t, cfg.Validate())
+
+	cfg = valid()
+	cfg.RiskMinScore, cfg.RiskMaxScore = 0.9, 0.5
+	assert.Error(t, cfg.Validate())
+
+	cfg = valid()
+	cfg.SkillLevelScale = 0
+	assert.Error(t, cfg.Validate())
+
+	cfg = valid()
+	cfg.GradeRiskThreshold = 120
+	assert.Error(t, cfg.Validate())
+
+	cfg = valid()
+	cfg.AttendanceRiskThreshold = -5
+	assert.Error(t, cfg.Validate())
+
+	cfg = valid()
+	cfg.InactivityDays = 0
+	assert.Error(t, cfg.Validate())
+
+	cfg = valid()
+	cfg.CourseGradeRiskThreshold = 101
+	assert.Error(t, cfg.Validate())
+}
+
+// --- LoadAlertConfig ---
+
+func TestLoadAlertConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		os.Clearenv()
+		cfg, err := LoadAlertConfig()
+		require.NoError(t, err)
+		assert.False(t, cfg.Enabled)
+		assert.Equal(t, "0 0 18 * * *", cfg.Schedule)
+		assert.Equal(t, 75.0, cfg.Threshold)
+		assert.Equal(t, 30, cfg.WindowDays)
+		assert.Equal(t, 5, cfg.MinSessions)
+		assert.Equal(t, 7*24*time.Hour, cfg.Cooldown)
+	})
+
+	t.Run("custom values from env", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("ATTENDANCE_ALERTS_ENABLED", "true")
+		os.Setenv("ATTENDANCE_ALERT_SCHEDULE", "0 30 7 * * 1-5")
+		os.Setenv("ATTENDANCE_ALERT_THRESHOLD", "60")
+		os.Setenv("ATTENDANCE_ALERT_WINDOW_DAYS", "14")
+		os.Setenv("ATTENDANCE_ALERT_MIN_SESSIONS", "3")
+		os.Setenv("ATTENDANCE_ALERT_COOLDOWN", "72h")
+		cfg, err := LoadAlertConfig()
+		require.NoError(t, err)
+		assert.True(t, cfg.Enabled)
+		assert.Equal(t, "0 30 7 * * 1-5", cfg.Schedule)
+		assert.Equal(t, 60.0, cfg.Threshold)
+		assert.Equal(t, 14, cfg.WindowDays)
+		assert.Equal(t, 3, cfg.MinSessions)
+		assert.Equal(t, 72*time.Hour, cfg.Cooldown)
+	})
+
+	t.Run("threshold out of range", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("ATTENDANCE_ALERT_THRESHOLD", "120")
+		_, err := LoadAlertConfig()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Threshold")
+	})
+
+	t.Run("invalid cooldown", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("ATTENDANCE_ALERT_COOLDOWN", "weekly")
+		_, err := LoadAlertConfig()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "ATTENDANCE_ALERT_COOLDOWN")
+	})
+}
+
+// --- LoadExamConfig ---
+
+func TestLoadExamConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		os.Clearenv()
+		cfg, err := LoadExamConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 30, cfg.StudentsPerInvigilator)
+		assert.True(t, cfg.NotifyParentsOfResults)
+		assert.Equal(t, 60, cfg.MaxExtensionMinutes)
+		assert.Equal(t, 7, cfg.RevaluationWindowDays)
+	})
+
+	t.Run("parent result emails can be disabled", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("EXAM_RESULTS_NOTIFY_PARENTS", "false")
+		cfg, err := LoadExamConfig()
+		require.NoError(t, err)
+		assert.False(t, cfg.NotifyParentsOfResults)
+	})
+
+	t.Run("custom ratio", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("EXAM_STUDENTS_PER_INVIGILATOR", "25")
+		cfg, err := LoadExamConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 25, cfg.StudentsPerInvigilator)
+	})
+
+	t.Run("ratio must be positive", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("EXAM_STUDENTS_PER_INVIGILATOR", "0")
+		_, err := LoadExamConfig()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "StudentsPerInvigilator")
+	})
+
+	t.Run("custom extension cap", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("EXAM_MAX_EXTENSION_MINUTES", "45")
+		cfg, err := LoadExamConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 45, cfg.MaxExtensionMinutes)
+	})
+
+	t.Run("extension cap must be positive", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("EXAM_MAX_EXTENSION_MINUTES", "0")
+		_, err := LoadExamConfig()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "MaxExtensionMinutes")
+	})
+
+	t.Run("custom revaluation window", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("EXAM_REVALUATION_WINDOW_DAYS", "14")
+		cfg, err := LoadExamConfig()
+		require.NoError(t, err)
+		assert.Equal(t, 14, cfg.RevaluationWindowDays)
+	})
+
+	t.Run("revaluation window must be positive", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("EXAM_REVALUATION_WINDOW_DAYS", "0")
+		_, err := LoadExamConfig()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "RevaluationWindowDays")
+	})
+}
+
+// --- LoadDashboardSnapshotConfig ---
+
+func TestLoadDashboardSnapshotConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		os.Clearenv()
+		cfg, err := LoadDashboardSnapshotConfig()
+		require.NoError(t, err)
+		assert.False(t, cfg.Enabled)
+		assert.Equal(t, "0 30 23 * * *", cfg.Schedule)
+		assert.Equal(t, 365, cfg.RetentionDays)
+	})
+
+	t.Run("zero retention keeps everything", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("DASHBOARD_SNAPSHOTS_ENABLED", "true")
+		os.Setenv("DASHBOARD_SNAPSHOT_RETENTION_DAYS", "0")
+		cfg, err := LoadDashboardSnapshotConfig()
+		require.NoError(t, err)
+		assert.True(t, cfg.Enabled)
+		assert.Equal(t, 0, cfg.RetentionDays)
+	})
+
+	t.Run("negative retention is rejected", func(t *testing.T) {
+		os.Clearenv()
+		os.Setenv("DASHBOARD_SNAPSHOT_RETENTION_DAYS", "-1")
+		_, err := LoadDashboardSnapshotConfig()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "RetentionDays")
+	})
 }
 
 // --- getEnvOrDefault ---
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// DashboardSnapshotConfig controls the daily capture of college dashboard
+// metrics used for trend charts.
+//
+// Environment Variables:
+//   - DASHBOARD_SNAPSHOTS_ENABLED: Capture snapshots on a schedule (default: false)
+//   - DASHBOARD_SNAPSHOT_SCHEDULE: Cron expression with seconds (default: "0 30 23 * * *", daily at 11:30 PM)
+//   - DASHBOARD_SNAPSHOT_RETENTION_DAYS: Delete snapshots older than this many days, 0 keeps them forever (default: 365)
+type DashboardSnapshotConfig struct {
+	Enabled       bool
+	Schedule      string
+	RetentionDays int
+}
+
+// LoadDashboardSnapshotConfig loads dashboard snapshot configuration from environment variables
+func LoadDashboardSnapshotConfig() (*DashboardSnapshotConfig, error) {
+	config := &DashboardSnapshotConfig{
+		Enabled:       os.Getenv("DASHBOARD_SNAPSHOTS_ENABLED") == "true",
+		Schedule:      os.Getenv("DASHBOARD_SNAPSHOT_SCHEDULE"),
+		RetentionDays: 365,
+	}
+	if config.Schedule == "" {
+		config.Schedule = "0 30 23 * * *"
+	}
+
+	if raw := os.Getenv("DASHBOARD_SNAPSHOT_RETENTION_DAYS"); raw != "" {
+		days, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DASHBOARD_SNAPSHOT_RETENTION_DAYS value: %w", err)
+		}
+		config.RetentionDays = days
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Validate checks that the snapshot settings are within sensible ranges
+func (c *DashboardSnapshotConfig) Validate() error {
+	if c.RetentionDays < 0 {
+		return fmt.Errorf("DashboardSnapshotConfig.RetentionDays cannot be negative, got %d", c.RetentionDays)
+	}
+	return nil
+}
@@ -22,20 +22,43 @@ type DBConfig struct {
 	SSLRootCert string // Path to SSL root certificate for production
 	SSLCert     string // Path to SSL client certificate (optional)
 	SSLKey      string // Path to SSL client key (optional)
+
+	// Connection pool tuning. Zero values fall back to the defaults below.
+	MaxConns        int32
+	MinConns        int32
+	MaxConnLifetime time.Duration
+	MaxConnIdleTime time.Duration
+
+	// AutoMigrate applies pending embedded migrations at startup (DB_AUTO_MIGRATE).
+	AutoMigrate bool
+	// SchemaCheck refuses to start when the schema version differs from the
+	// one the binary was built for (DB_SCHEMA_CHECK, default true).
+	SchemaCheck bool
 }
 
+// Pool defaults sized for low-resource deployments
+const (
+	defaultDBMaxConns        int32 = 20
+	defaultDBMinConns        int32 = 2
+	defaultDBMaxConnLifetime       = 30 * time.Minute
+	defaultDBMaxConnIdleTime       = 5 * time.Minute
+)
+
 // LoadDatabaseConfig loads database configuration from environment variables
 // SECURITY: Supports SSL/TLS configuration for production databases
 func LoadDatabaseConfig() (*DBConfig, error) {
 	dbHost := os.Getenv("DB_HOST")
 	dbPortStr := os.Getenv("DB_PORT")
 	dbUser := os.Getenv("DB_USER")
-	dbPassword := os.Getenv("DB_PASSWORD")
+	dbPassword, err := getSecret("DB_PASSWORD")
+	if err != nil {
+		return nil, err
+	}
 	dbName := os.Getenv("DB_NAME")
 	dbSSLMode := os.Getenv("DB_SSLMODE") // Often "disable" for local dev, "require"/"verify-full" for prod
 
 	if dbHost == "" || dbPortStr == "" || dbUser == "" || dbPassword == "" || dbName == "" {
-		return nil, fmt.Errorf("database environment variables (DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME) must be set")
+		return nil, fmt.Errorf("database environment variables (DB_HOST, DB_PORT, DB_USER, DB_PASSWORD or DB_PASSWORD_FILE, DB_NAME) must be set")
 	}
 
 	dbPort, err := strconv.Atoi(dbPortStr)
@@ -52,22 +75,94 @@ func LoadDatabaseConfig() (*DBConfig, error) {
 	sslCert := os.Getenv("DB_SSL_CERT")          // e.g., "/path/to/client.crt"
 	sslKey := os.Getenv("DB_SSL_KEY")            // e.g., "/path/to/client.key"
 
+	maxConns, err := getEnvInt32("DB_MAX_CONNS", defaultDBMaxConns)
+	if err != nil {
+		return nil, err
+	}
+	minConns, err := getEnvInt32("DB_MIN_CONNS", defaultDBMinConns)
+	if err != nil {
+		return nil, err
+	}
+	maxConnLifetime, err := getEnvDuration("DB_MAX_CONN_LIFETIME", defaultDBMaxConnLifetime)
+	if err != nil {
+		return nil, err
+	}
+	maxConnIdleTime, err := getEnvDuration("DB_MAX_CONN_IDLE_TIME", defaultDBMaxConnIdleTime)
+	if err != nil {
+		return nil, err
+	}
+
 	// SECURITY: Enforce SSL in production - disabled SSL is a security vulnerability
 	if os.Getenv("APP_ENV") == "production" && dbSSLMode == "disable" {
 		return nil, fmt.Errorf("SECURITY ERROR: Database SSL cannot be disabled in production environment. Set DB_SSL_MODE to 'require' or higher")
 	}
 
-	return &DBConfig{
-		Host:        dbHost,
-		Port:        strconv.Itoa(dbPort),
-		User:        dbUser,
-		Password:    dbPassword,
-		DBName:      dbName,
-		SSLMode:     dbSSLMode,
-		SSLRootCert: sslRootCert,
-		SSLCert:     sslCert,
-		SSLKey:      sslKey,
-	}, nil
+	config := &DBConfig{
+		Host:            dbHost,
+		Port:            strconv.Itoa(dbPort),
+		User:            dbUser,
+		Password:        dbPassword,
+		DBName:          dbName,
+		SSLMode:         dbSSLMode,
+		SSLRootCert:     sslRootCert,
+		SSLCert:         sslCert,
+		SSLKey:          sslKey,
+		MaxConns:        maxConns,
+		MinConns:        minConns,
+		MaxConnLifetime: maxConnLifetime,
+		MaxConnIdleTime: maxConnIdleTime,
+		AutoMigrate:     os.Getenv("DB_AUTO_MIGRATE") == "true",
+		SchemaCheck:     os.Getenv("DB_SCHEMA_CHECK") != "false",
+	}
+	if err := config.validatePool(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func getEnvInt32(key string, defaultValue int32) (int32, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return defaultValue, nil
+	}
+	value, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("invalid %s: must be a positive integer, got %s", key, raw)
+	}
+	return int32(value), nil
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) (time.Duration, error) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return defaultValue, nil
+	}
+	value, err := time.ParseDuration(raw)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("invalid %s: must be a positive duration (e.g. \"30m\"), got %s", key, raw)
+	}
+	return value, nil
+}
+
+// applyPool copies the pool tuning onto a pgxpool config, keeping the
+// defaults for anything left unset
+func (c *DBConfig) applyPool(poolConfig *pgxpool.Config) {
+	poolConfig.MaxConns = defaultDBMaxConns
+	if c.MaxConns > 0 {
+		poolConfig.MaxConns = c.MaxConns
+	}
+	poolConfig.MinConns = defaultDBMinConns
+	if c.MinConns > 0 {
+		poolConfig.MinConns = c.MinConns
+	}
+	poolConfig.MaxConnLifetime = defaultDBMaxConnLifetime
+	if c.MaxConnLifetime > 0 {
+		poolConfig.MaxConnLifetime = c.MaxConnLifetime
+	}
+	poolConfig.MaxConnIdleTime = defaultDBMaxConnIdleTime
+	if c.MaxConnIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = c.MaxConnIdleTime
+	}
 }
 
 // LoadDatabaseWithRetry loads the database with proper error handling instead of panics.
@@ -88,12 +183,9 @@ func LoadDatabaseWithRetry(maxRetries int) (*repository.DB, error) {
 		return nil, fmt.Errorf("unable to parse config: %w", err)
 	}
 
-	// OPTIMIZED: Low-resource configuration for better performance on limited hardware
-	// Reduce connection overhead and memory usage
-	poolConfig.MaxConnIdleTime = 5 * time.Minute    // Reduced from 10 minutes - close idle connections faster
-	poolConfig.MaxConnLifetime = 30 * time.Minute   // Reduced from 1 hour - prevent connection buildup
-	poolConfig.MinConns = 2                         // Reduced from 4 - lower baseline memory usage
-	poolConfig.MaxConns = 20                        // Reduced from 100 - prevent resource exhaustion
+	// Pool size and connection lifetimes come from DB_MAX_CONNS and friends;
+	// the defaults are tuned for low-resource hardware
+	dbConfig.applyPool(poolConfig)
 	poolConfig.HealthCheckPeriod = 30 * time.Second // Reduced from 1 hour - faster detection of failed connections
 
 	// OPTIMIZED: Connection timeouts for better resource management
@@ -195,5 +287,23 @@ func (c *DBConfig) Validate() error {
 	if c.SSLMode == "" {
 		return fmt.Errorf("DBConfig.SSLMode cannot be empty")
 	}
+	return c.validatePool()
+}
+
+// validatePool checks the pool tuning; zero means "use the default"
+func (c *DBConfig) validatePool() error {
+	if c.MaxConns < 0 || c.MinConns < 0 {
+		return fmt.Errorf("DBConfig.MaxConns and DBConfig.MinConns cannot be negative")
+	}
+	if c.MaxConnLifetime < 0 || c.MaxConnIdleTime < 0 {
+		return fmt.Errorf("DBConfig.MaxConnLifetime and DBConfig.MaxConnIdleTime cannot be negative")
+	}
+	maxConns := c.MaxConns
+	if maxConns == 0 {
+		maxConns = defaultDBMaxConns
+	}
+	if c.MinConns > maxConns {
+		return fmt.Errorf("DBConfig.MinConns (%d) cannot exceed MaxConns (%d)", c.MinConns, maxConns)
+	}
 	return nil
 }
@@ -3,6 +3,9 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 func TestLoadDatabaseConfig(t *testing.T) {
@@ -24,12 +27,13 @@ func TestLoadDatabaseConfig(t *testing.T) {
 			},
 			expectError: false,
 			expected: &DBConfig{
-				Host:     "localhost",
-				Port:     "5432",
-				User:     "testuser",
-				Password: "testpass",
-				DBName:   "testdb",
-				SSLMode:  "require",
+				Host:        "localhost",
+				Port:        "5432",
+				User:        "testuser",
+				Password:    "testpass",
+				DBName:      "testdb",
+				SSLMode:     "require",
+				SchemaCheck: true,
 			},
 		},
 		{
@@ -62,12 +66,35 @@ func TestLoadDatabaseConfig(t *testing.T) {
 			},
 			expectError: false,
 			expected: &DBConfig{
-				Host:     "localhost",
-				Port:     "5432",
-				User:     "testuser",
-				Password: "testpass",
-				DBName:   "testdb",
-				SSLMode:  "disable",
+				Host:        "localhost",
+				Port:        "5432",
+				User:        "testuser",
+				Password:    "testpass",
+				DBName:      "testdb",
+				SSLMode:     "disable",
+				SchemaCheck: true,
+			},
+		},
+		{
+			name: "migration flags",
+			envVars: map[string]string{
+				"DB_HOST":         "localhost",
+				"DB_PORT":         "5432",
+				"DB_USER":         "testuser",
+				"DB_PASSWORD":     "testpass",
+				"DB_NAME":         "testdb",
+				"DB_AUTO_MIGRATE": "true",
+				"DB_SCHEMA_CHECK": "false",
+			},
+			expectError: false,
+			expected: &DBConfig{
+				Host:        "localhost",
+				Port:        "5432",
+				User:        "testuser",
+				Password:    "testpass",
+				DBName:      "testdb",
+				SSLMode:     "disable",
+				AutoMigrate: true,
 			},
 		},
 	}
@@ -108,6 +135,12 @@ func TestLoadDatabaseConfig(t *testing.T) {
 				if config.SSLMode != tt.expected.SSLMode {
 					t.Errorf("expected SSLMode %s, got %s", tt.expected.SSLMode, config.SSLMode)
 				}
+				if config.AutoMigrate != tt.expected.AutoMigrate {
+					t.Errorf("expected AutoMigrate %t, got %t", tt.expected.AutoMigrate, config.AutoMigrate)
+				}
+				if config.SchemaCheck != tt.expected.SchemaCheck {
+					t.Errorf("expected SchemaCheck %t, got %t", tt.expected.SchemaCheck, config.SchemaCheck)
+				}
 			}
 		})
 	}
@@ -154,3 +187,98 @@ func TestBuildDSN(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadDatabaseConfigPoolSettings(t *testing.T) {
+	base := map[string]string{
+		"DB_HOST":     "localhost",
+		"DB_PORT":     "5432",
+		"DB_USER":     "testuser",
+		"DB_PASSWORD": "testpass",
+		"DB_NAME":     "testdb",
+	}
+	setEnv := func(extra map[string]string) {
+		os.Clearenv()
+		for k, v := range base {
+			os.Setenv(k, v)
+		}
+		for k, v := range extra {
+			os.Setenv(k, v)
+		}
+	}
+
+	t.Run("defaults match the previous hardcoded pool", func(t *testing.T) {
+		setEnv(nil)
+		config, err := LoadDatabaseConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if config.MaxConns != 20 || config.MinConns != 2 {
+			t.Errorf("expected 2..20 conns, got %d..%d", config.MinConns, config.MaxConns)
+		}
+		if config.MaxConnLifetime != 30*time.Minute || config.MaxConnIdleTime != 5*time.Minute {
+			t.Errorf("unexpected lifetimes %s / %s", config.MaxConnLifetime, config.MaxConnIdleTime)
+		}
+	})
+
+	t.Run("custom values", func(t *testing.T) {
+		setEnv(map[string]string{
+			"DB_MAX_CONNS":          "50",
+			"DB_MIN_CONNS":          "5",
+			"DB_MAX_CONN_LIFETIME":  "1h",
+			"DB_MAX_CONN_IDLE_TIME": "10m",
+		})
+		config, err := LoadDatabaseConfig()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		poolConfig, err := pgxpool.ParseConfig(buildDSN(*config))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		config.applyPool(poolConfig)
+		if poolConfig.MaxConns != 50 || poolConfig.MinConns != 5 {
+			t.Errorf("expected 5..50 conns, got %d..%d", poolConfig.MinConns, poolConfig.MaxConns)
+		}
+		if poolConfig.MaxConnLifetime != time.Hour || poolConfig.MaxConnIdleTime != 10*time.Minute {
+			t.Errorf("unexpected lifetimes %s / %s", poolConfig.MaxConnLifetime, poolConfig.MaxConnIdleTime)
+		}
+	})
+
+	invalid := map[string]map[string]string{
+		"min above max":     {"DB_MAX_CONNS": "4", "DB_MIN_CONNS": "8"},
+		"zero max conns":    {"DB_MAX_CONNS": "0"},
+		"non-numeric conns": {"DB_MIN_CONNS": "many"},
+		"bad lifetime":      {"DB_MAX_CONN_LIFETIME": "forever"},
+		"negative idle":     {"DB_MAX_CONN_IDLE_TIME": "-1m"},
+	}
+	for name, extra := range invalid {
+		t.Run(name, func(t *testing.T) {
+			setEnv(extra)
+			if _, err := LoadDatabaseConfig(); err == nil {
+				t.Error("expected error but got none")
+			}
+		})
+	}
+}
+
+func TestDBConfigValidatePool(t *testing.T) {
+	config := DBConfig{
+		Host: "localhost", Port: "5432", User: "user",
+		Password: "pass", DBName: "db", SSLMode: "disable",
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("unset pool settings should be valid: %v", err)
+	}
+
+	config.MinConns = 30
+	if err := config.Validate(); err == nil {
+		t.Error("expected MinConns above the default MaxConns to fail")
+	}
+
+	config.MinConns = 2
+	config.MaxConnLifetime = -time.Minute
+	if err := config.Validate(); err == nil {
+		t.Error("expected negative MaxConnLifetime to fail")
+	}
+}
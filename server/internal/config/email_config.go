@@ -36,7 +36,7 @@ type EmailConfig struct {
 //   - SMTP_HOST: SMTP server hostname (required)
 //   - SMTP_PORT: SMTP server port (default: "587")
 //   - SMTP_USERNAME: SMTP authentication username (required)
-//   - SMTP_PASSWORD: SMTP authentication password (required)
+//   - SMTP_PASSWORD: SMTP authentication password (required; or SMTP_PASSWORD_FILE)
 //   - EMAIL_FROM: Email address for "From" field (required)
 //   - SMTP_STARTTLS: Enable STARTTLS (default: "true")
 //
@@ -44,11 +44,16 @@ type EmailConfig struct {
 //   - *EmailConfig: The loaded SMTP configuration
 //   - error: Any validation errors
 func LoadEmailConfig() (*EmailConfig, error) {
+	password, err := getSecret("SMTP_PASSWORD")
+	if err != nil {
+		return nil, fmt.Errorf("email config: %w", err)
+	}
+
 	config := &EmailConfig{
 		Host:         os.Getenv("SMTP_HOST"),
 		Port:         getEnvOrDefault("SMTP_PORT", "587"),
 		Username:     os.Getenv("SMTP_USERNAME"),
-		Password:     os.Getenv("SMTP_PASSWORD"),
+		Password:     password,
 		FromAddress:  os.Getenv("EMAIL_FROM"),
 		EnableStartTLS: getEnvOrDefault("SMTP_STARTTLS", "true") == "true",
 	}
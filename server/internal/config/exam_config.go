@@ -0,0 +1,74 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// ExamConfig holds settings for exam administration.
+//
+// Environment Variables:
+//   - EXAM_STUDENTS_PER_INVIGILATOR: Students one invigilator can supervise, used to suggest staffing (default: 30)
+//   - EXAM_RESULTS_NOTIFY_PARENTS: Also email verified parents when results are published (default: true)
+//   - EXAM_MAX_EXTENSION_MINUTES: Most ad-hoc extra time a student can be granted in one exam (default: 60)
+//   - EXAM_REVALUATION_WINDOW_DAYS: Days after a result is published that the student can request revaluation (default: 7)
+type ExamConfig struct {
+	StudentsPerInvigilator int
+	NotifyParentsOfResults bool
+	MaxExtensionMinutes    int
+	RevaluationWindowDays  int
+}
+
+// LoadExamConfig loads exam configuration from environment variables
+func LoadExamConfig() (*ExamConfig, error) {
+	config := &ExamConfig{
+		StudentsPerInvigilator: 30,
+		NotifyParentsOfResults: os.Getenv("EXAM_RESULTS_NOTIFY_PARENTS") != "false",
+		MaxExtensionMinutes:    60,
+		RevaluationWindowDays:  7,
+	}
+
+	if raw := os.Getenv("EXAM_STUDENTS_PER_INVIGILATOR"); raw != "" {
+		ratio, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EXAM_STUDENTS_PER_INVIGILATOR value: %w", err)
+		}
+		config.StudentsPerInvigilator = ratio
+	}
+
+	if raw := os.Getenv("EXAM_MAX_EXTENSION_MINUTES"); raw != "" {
+		minutes, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EXAM_MAX_EXTENSION_MINUTES value: %w", err)
+		}
+		config.MaxExtensionMinutes = minutes
+	}
+
+	if raw := os.Getenv("EXAM_REVALUATION_WINDOW_DAYS"); raw != "" {
+		days, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EXAM_REVALUATION_WINDOW_DAYS value: %w", err)
+		}
+		config.RevaluationWindowDays = days
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Validate checks that the exam settings are within sensible ranges
+func (c *ExamConfig) Validate() error {
+	if c.StudentsPerInvigilator < 1 {
+		return fmt.Errorf("ExamConfig.StudentsPerInvigilator must be at least 1, got %d", c.StudentsPerInvigilator)
+	}
+	if c.MaxExtensionMinutes < 1 {
+		return fmt.Errorf("ExamConfig.MaxExtensionMinutes must be at least 1, got %d", c.MaxExtensionMinutes)
+	}
+	if c.RevaluationWindowDays < 1 {
+		return fmt.Errorf("ExamConfig.RevaluationWindowDays must be at least 1, got %d", c.RevaluationWindowDays)
+	}
+	return nil
+}
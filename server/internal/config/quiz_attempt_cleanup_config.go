@@ -0,0 +1,62 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// QuizAttemptCleanupConfig controls the job closing quiz attempts that were
+// started but never submitted.
+//
+// Environment Variables:
+//   - QUIZ_ATTEMPT_CLEANUP_ENABLED: Run the job on a schedule (default: false)
+//   - QUIZ_ATTEMPT_CLEANUP_SCHEDULE: Cron expression with seconds (default: "0 */5 * * * *", every 5 minutes)
+//   - QUIZ_ATTEMPT_CLEANUP_GRACE: How long after an attempt's time runs out to wait before closing it (default: "5m")
+//   - QUIZ_ATTEMPT_CLEANUP_ACTION: "submit" to submit and grade the saved answers, or "expire" (default: "submit")
+type QuizAttemptCleanupConfig struct {
+	Enabled  bool
+	Schedule string
+	Grace    time.Duration
+	Action   string
+}
+
+// LoadQuizAttemptCleanupConfig loads quiz attempt cleanup configuration from environment variables
+func LoadQuizAttemptCleanupConfig() (*QuizAttemptCleanupConfig, error) {
+	config := &QuizAttemptCleanupConfig{
+		Enabled:  os.Getenv("QUIZ_ATTEMPT_CLEANUP_ENABLED") == "true",
+		Schedule: os.Getenv("QUIZ_ATTEMPT_CLEANUP_SCHEDULE"),
+		Grace:    5 * time.Minute,
+		Action:   os.Getenv("QUIZ_ATTEMPT_CLEANUP_ACTION"),
+	}
+	if config.Schedule == "" {
+		config.Schedule = "0 */5 * * * *"
+	}
+	if config.Action == "" {
+		config.Action = "submit"
+	}
+
+	if raw := os.Getenv("QUIZ_ATTEMPT_CLEANUP_GRACE"); raw != "" {
+		grace, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid QUIZ_ATTEMPT_CLEANUP_GRACE value: %w", err)
+		}
+		config.Grace = grace
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// Validate checks the grace period and action
+func (c *QuizAttemptCleanupConfig) Validate() error {
+	if c.Grace < 0 {
+		return fmt.Errorf("QuizAttemptCleanupConfig.Grace must not be negative, got %s", c.Grace)
+	}
+	if c.Action != "submit" && c.Action != "expire" {
+		return fmt.Errorf("QuizAttemptCleanupConfig.Action must be submit or expire, got %q", c.Action)
+	}
+	return nil
+}
@@ -44,7 +44,10 @@ func LoadRedisConfig() (*RedisConfig, error) {
 		port = "6379" // default
 	}
 
-	password := os.Getenv("REDIS_PASSWORD")
+	password, err := getSecret("REDIS_PASSWORD")
+	if err != nil {
+		return nil, err
+	}
 
 	dbStr := os.Getenv("REDIS_DB")
 	db := 0
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// getSecret reads a secret from the environment. If <key>_FILE is set, the
+// secret is read from that path instead (Docker and Kubernetes mount secrets
+// as files) and trailing newlines are trimmed. The file takes precedence over
+// the plain variable; an unreadable file is an error rather than a silent
+// fallback so a misconfigured mount is caught at startup.
+func getSecret(key string) (string, error) {
+	path := os.Getenv(key + "_FILE")
+	if path == "" {
+		return os.Getenv(key), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s_FILE: %w", key, err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSecretFile(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	return path
+}
+
+func TestGetSecret(t *testing.T) {
+	t.Run("plain variable", func(t *testing.T) {
+		t.Setenv("TEST_SECRET", "from-env")
+		t.Setenv("TEST_SECRET_FILE", "")
+
+		value, err := getSecret("TEST_SECRET")
+		require.NoError(t, err)
+		assert.Equal(t, "from-env", value)
+	})
+
+	t.Run("file takes precedence and is trimmed", func(t *testing.T) {
+		t.Setenv("TEST_SECRET", "from-env")
+		t.Setenv("TEST_SECRET_FILE", writeSecretFile(t, "from-file\n"))
+
+		value, err := getSecret("TEST_SECRET")
+		require.NoError(t, err)
+		assert.Equal(t, "from-file", value)
+	})
+
+	t.Run("missing file is an error", func(t *testing.T) {
+		t.Setenv("TEST_SECRET", "from-env")
+		t.Setenv("TEST_SECRET_FILE", filepath.Join(t.TempDir(), "does-not-exist"))
+
+		_, err := getSecret("TEST_SECRET")
+		assert.ErrorContains(t, err, "TEST_SECRET_FILE")
+	})
+}
+
+func TestSecretFilesAreUsedByLoaders(t *testing.T) {
+	t.Run("database password file", func(t *testing.T) {
+		t.Setenv("DB_HOST", "localhost")
+		t.Setenv("DB_PORT", "5432")
+		t.Setenv("DB_USER", "eduhub")
+		t.Setenv("DB_PASSWORD", "")
+		t.Setenv("DB_PASSWORD_FILE", writeSecretFile(t, "db-secret\r\n"))
+		t.Setenv("DB_NAME", "eduhub")
+		t.Setenv("APP_ENV", "")
+
+		cfg, err := LoadDatabaseConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "db-secret", cfg.Password)
+	})
+
+	t.Run("database password file missing", func(t *testing.T) {
+		t.Setenv("DB_HOST", "localhost")
+		t.Setenv("DB_PORT", "5432")
+		t.Setenv("DB_USER", "eduhub")
+		t.Setenv("DB_PASSWORD", "inline")
+		t.Setenv("DB_PASSWORD_FILE", filepath.Join(t.TempDir(), "missing"))
+		t.Setenv("DB_NAME", "eduhub")
+
+		_, err := LoadDatabaseConfig()
+		assert.Error(t, err)
+	})
+
+	t.Run("redis password file", func(t *testing.T) {
+		t.Setenv("REDIS_ENABLED", "true")
+		t.Setenv("REDIS_PASSWORD_FILE", writeSecretFile(t, "redis-secret\n"))
+
+		cfg, err := LoadRedisConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "redis-secret", cfg.Password)
+	})
+
+	t.Run("smtp password file", func(t *testing.T) {
+		t.Setenv("SMTP_HOST", "smtp.example.com")
+		t.Setenv("SMTP_USERNAME", "mailer")
+		t.Setenv("SMTP_PASSWORD", "")
+		t.Setenv("SMTP_PASSWORD_FILE", writeSecretFile(t, "smtp-secret"))
+		t.Setenv("EMAIL_FROM", "noreply@example.com")
+
+		cfg, err := LoadEmailConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "smtp-secret", cfg.Password)
+	})
+
+	t.Run("razorpay secret files", func(t *testing.T) {
+		t.Setenv("APP_ENV", "")
+		t.Setenv("RAZORPAY_KEY_SECRET_FILE", writeSecretFile(t, "key-secret\n"))
+		t.Setenv("RAZORPAY_WEBHOOK_SECRET_FILE", writeSecretFile(t, "hook-secret\n"))
+
+		cfg, err := LoadAppConfig()
+		require.NoError(t, err)
+		assert.Equal(t, "key-secret", cfg.RazorpaySecret)
+		assert.Equal(t, "hook-secret", cfg.RazorpayWebhookSecret)
+	})
+}
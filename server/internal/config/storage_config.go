@@ -6,8 +6,23 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 )
 
+// DefaultSubmissionMIMETypes are the content types accepted for assignment
+// submission uploads when STORAGE_SUBMISSION_ALLOWED_TYPES is unset.
+var DefaultSubmissionMIMETypes = []string{
+	"application/pdf",
+	"application/msword",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"application/vnd.ms-powerpoint",
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	"application/zip",
+	"text/plain",
+	"image/png",
+	"image/jpeg",
+}
+
 // StorageConfig holds file storage configuration parameters.
 // It supports Minio and S3-compatible storage services.
 type StorageConfig struct {
@@ -31,6 +46,19 @@ type StorageConfig struct {
 
 	// PresignedURLExpirySeconds is how long presigned URLs remain valid (default: 3600)
 	PresignedURLExpirySeconds int64
+
+	// MaxPresignedURLExpirySeconds caps the expiry a caller may request for a
+	// single presigned URL (default: 604800, seven days)
+	MaxPresignedURLExpirySeconds int64
+
+	// MaxSubmissionSizeBytes caps the size of an assignment submission upload (default: 25 MiB)
+	MaxSubmissionSizeBytes int64
+
+	// AllowedSubmissionTypes lists the MIME types accepted for assignment submission uploads
+	AllowedSubmissionTypes []string
+
+	// FileScanner selects the scanner run on uploaded submissions: "none" (default) or "mimesniff"
+	FileScanner string
 }
 
 // LoadStorageConfig loads storage configuration from environment variables.
@@ -44,6 +72,11 @@ type StorageConfig struct {
 //   - STORAGE_USE_SSL: Use SSL/TLS (default: "false")
 //   - STORAGE_REGION: Storage region (default: "us-east-1")
 //   - STORAGE_PRESIGNED_URL_EXPIRY: Presigned URL expiry in seconds (default: "3600")
+//   - STORAGE_PRESIGNED_URL_MAX_EXPIRY: Longest expiry a request may ask for, in seconds (default: "604800")
+//   - STORAGE_SUBMISSION_MAX_SIZE_MB: Max assignment submission upload size in MiB (default: "25")
+//   - STORAGE_SUBMISSION_ALLOWED_TYPES: Comma-separated MIME types allowed for submissions
+//     (default: PDF, Word, PowerPoint, ZIP, plain text, PNG and JPEG)
+//   - STORAGE_FILE_SCANNER: Scanner for uploaded submissions, "none" or "mimesniff" (default: "none")
 //
 // Returns:
 //   - *StorageConfig: The loaded storage configuration
@@ -55,14 +88,40 @@ func LoadStorageConfig() (*StorageConfig, error) {
 		return nil, fmt.Errorf("invalid STORAGE_PRESIGNED_URL_EXPIRY value: %s", expiryStr)
 	}
 
+	maxExpiryStr := getEnvOrDefault("STORAGE_PRESIGNED_URL_MAX_EXPIRY", "604800")
+	maxExpirySeconds, err := strconv.ParseInt(maxExpiryStr, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid STORAGE_PRESIGNED_URL_MAX_EXPIRY value: %s", maxExpiryStr)
+	}
+
+	maxSizeStr := getEnvOrDefault("STORAGE_SUBMISSION_MAX_SIZE_MB", "25")
+	maxSizeMB, err := strconv.ParseInt(maxSizeStr, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid STORAGE_SUBMISSION_MAX_SIZE_MB value: %s", maxSizeStr)
+	}
+
+	allowedTypes := DefaultSubmissionMIMETypes
+	if raw := os.Getenv("STORAGE_SUBMISSION_ALLOWED_TYPES"); raw != "" {
+		allowedTypes = nil
+		for _, t := range strings.Split(raw, ",") {
+			if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+				allowedTypes = append(allowedTypes, t)
+			}
+		}
+	}
+
 	config := &StorageConfig{
-		Endpoint:                  getEnvOrDefault("STORAGE_ENDPOINT", "localhost:9000"),
-		Bucket:                    getEnvOrDefault("STORAGE_BUCKET", "eduhub"),
-		AccessKey:                 os.Getenv("STORAGE_ACCESS_KEY"),
-		SecretKey:                 os.Getenv("STORAGE_SECRET_KEY"),
-		UseSSL:                    getEnvOrDefault("STORAGE_USE_SSL", "false") == "true",
-		Region:                    getEnvOrDefault("STORAGE_REGION", "us-east-1"),
-		PresignedURLExpirySeconds: expirySeconds,
+		Endpoint:                     getEnvOrDefault("STORAGE_ENDPOINT", "localhost:9000"),
+		Bucket:                       getEnvOrDefault("STORAGE_BUCKET", "eduhub"),
+		AccessKey:                    os.Getenv("STORAGE_ACCESS_KEY"),
+		SecretKey:                    os.Getenv("STORAGE_SECRET_KEY"),
+		UseSSL:                       getEnvOrDefault("STORAGE_USE_SSL", "false") == "true",
+		Region:                       getEnvOrDefault("STORAGE_REGION", "us-east-1"),
+		PresignedURLExpirySeconds:    expirySeconds,
+		MaxPresignedURLExpirySeconds: maxExpirySeconds,
+		MaxSubmissionSizeBytes:       maxSizeMB << 20,
+		AllowedSubmissionTypes:       allowedTypes,
+		FileScanner:                  getEnvOrDefault("STORAGE_FILE_SCANNER", "none"),
 	}
 
 	if err := config.Validate(); err != nil {
@@ -95,6 +154,24 @@ func (c *StorageConfig) Validate() error {
 	if c.PresignedURLExpirySeconds <= 0 {
 		return fmt.Errorf("STORAGE_PRESIGNED_URL_EXPIRY must be greater than 0")
 	}
+	// Zero means no maximum was configured and the storage service default applies
+	if c.MaxPresignedURLExpirySeconds < 0 {
+		return fmt.Errorf("STORAGE_PRESIGNED_URL_MAX_EXPIRY must not be negative")
+	}
+	if c.MaxPresignedURLExpirySeconds > 0 && c.PresignedURLExpirySeconds > c.MaxPresignedURLExpirySeconds {
+		return fmt.Errorf("STORAGE_PRESIGNED_URL_EXPIRY must not exceed STORAGE_PRESIGNED_URL_MAX_EXPIRY")
+	}
+
+	// Zero means the submission limits were not configured and the defaults apply
+	if c.MaxSubmissionSizeBytes < 0 {
+		return fmt.Errorf("STORAGE_SUBMISSION_MAX_SIZE_MB must not be negative")
+	}
+
+	switch c.FileScanner {
+	case "", "none", "mimesniff":
+	default:
+		return fmt.Errorf("STORAGE_FILE_SCANNER must be none or mimesniff, got %q", c.FileScanner)
+	}
 
 	return nil
 }
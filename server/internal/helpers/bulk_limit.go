@@ -0,0 +1,32 @@
+package helpers
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MaxBulkItemsContextKey is the echo context key holding the most items one
+// bulk request may carry
+const MaxBulkItemsContextKey = "max_bulk_items"
+
+// DefaultMaxBulkItems applies when no limit was set on the context
+const DefaultMaxBulkItems = 1000
+
+// MaxBulkItems returns the bulk item limit for the current request
+func MaxBulkItems(c echo.Context) int {
+	if limit, ok := c.Get(MaxBulkItemsContextKey).(int); ok && limit > 0 {
+		return limit
+	}
+	return DefaultMaxBulkItems
+}
+
+// ExceedsBulkLimit reports whether n items of the named kind are more than one
+// bulk request may carry, along with the message to return to the client
+func ExceedsBulkLimit(c echo.Context, n int, items string) (string, bool) {
+	limit := MaxBulkItems(c)
+	if n <= limit {
+		return "", false
+	}
+	return fmt.Sprintf("too many %s: a request may contain at most %d, got %d", items, limit, n), true
+}
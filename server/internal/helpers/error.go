@@ -4,14 +4,25 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+// RequestIDContextKey is the echo context key holding the request correlation ID
+const RequestIDContextKey = "request_id"
+
 type ErrorResponse struct {
-    Success bool `json:"success"`
-    Error   any  `json:"error"`
+	Success   bool   `json:"success"`
+	Error     any    `json:"error"`
+	RequestID string `json:"request_id,omitempty"`
 }
 
 func Error(c echo.Context, error any, status int) error {
-    return c.JSON(status, ErrorResponse{
-        Success: false,
-        Error:   error,
-    })
+	return c.JSON(status, ErrorResponse{
+		Success:   false,
+		Error:     error,
+		RequestID: GetRequestID(c),
+	})
+}
+
+// GetRequestID returns the correlation ID assigned to the current request, if any
+func GetRequestID(c echo.Context) string {
+	id, _ := c.Get(RequestIDContextKey).(string)
+	return id
 }
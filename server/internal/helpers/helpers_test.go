@@ -2,6 +2,8 @@ package helpers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -250,6 +252,25 @@ func TestErrorResponse(t *testing.T) {
 	})
 }
 
+func TestValidationErrorResponse(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	_ = ValidationError(c, map[string]string{"title": "required: title is required"})
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var resp struct {
+		Success bool                `json:"success"`
+		Error   ValidationErrorBody `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.False(t, resp.Success)
+	assert.Equal(t, "validation failed", resp.Error.Message)
+	assert.Equal(t, "required: title is required", resp.Error.Fields["title"])
+}
+
 // --- Success ---
 
 func TestSuccessResponse(t *testing.T) {
@@ -302,6 +323,32 @@ func TestNotFoundResponse(t *testing.T) {
 	})
 }
 
+func TestLookupError(t *testing.T) {
+	errMissing := errors.New("exam not found")
+
+	t.Run("not found sentinel is a 404", func(t *testing.T) {
+		c := newTestContext()
+		rec := c.Response().Writer.(*httptest.ResponseRecorder)
+
+		_ = LookupError(c, fmt.Errorf("load: %w", errMissing), "exam", errMissing)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		var resp ErrorResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "exam not found", resp.Error)
+	})
+
+	t.Run("other errors are a 500 without their detail", func(t *testing.T) {
+		c := newTestContext()
+		rec := c.Response().Writer.(*httptest.ResponseRecorder)
+
+		_ = LookupError(c, errors.New("college 9: connection reset"), "exam", errMissing)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "college 9")
+	})
+}
+
 // --- ExtractUserID edge cases ---
 
 func TestExtractUserID_TypeErrors(t *testing.T) {
@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -17,3 +18,22 @@ func NotFound(c echo.Context, data any, status int) error {
 		Status: status,
 	})
 }
+
+// ResourceNotFound answers 404 for a resource that is not in the caller's
+// college. A resource of another college gets the same answer as one that
+// does not exist, never a 403, so tenants cannot probe each other's IDs.
+func ResourceNotFound(c echo.Context, resource string) error {
+	return Error(c, resource+" not found", http.StatusNotFound)
+}
+
+// LookupError answers a failed load of a college-scoped resource: 404 via
+// ResourceNotFound when err is one of the notFound sentinels, otherwise 500
+// without the underlying error, which may name another college's data.
+func LookupError(c echo.Context, err error, resource string, notFound ...error) error {
+	for _, target := range notFound {
+		if errors.Is(err, target) {
+			return ResourceNotFound(c, resource)
+		}
+	}
+	return Error(c, "failed to load "+resource, http.StatusInternalServerError)
+}
@@ -0,0 +1,18 @@
+package helpers
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// ValidationErrorBody is the error body of a request that failed struct
+// validation, with one message per failing field
+type ValidationErrorBody struct {
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields"`
+}
+
+// ValidationError responds 400 with the failing fields, as produced by
+// validation.Fields
+func ValidationError(c echo.Context, fields map[string]string) error {
+	return Error(c, ValidationErrorBody{Message: "validation failed", Fields: fields}, 400)
+}
@@ -0,0 +1,80 @@
+// Package i18n translates the labels of generated documents and emails.
+// Translations live in locales/<locale>.json as flat key to format string
+// maps; English is the reference and every other locale may leave keys out.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// DefaultLocale is used when no locale is given or the given one is not
+// supported, and for any key a locale does not translate
+const DefaultLocale = "en"
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// catalogs holds every embedded locale by name; a locale file that does not
+// parse fails at startup rather than on first use
+var catalogs = mustLoadCatalogs()
+
+func mustLoadCatalogs() map[string]map[string]string {
+	files, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(err)
+	}
+
+	loaded := make(map[string]map[string]string, len(files))
+	for _, file := range files {
+		src, err := localeFiles.ReadFile(path.Join("locales", file.Name()))
+		if err != nil {
+			panic(err)
+		}
+		catalog := map[string]string{}
+		if err := json.Unmarshal(src, &catalog); err != nil {
+			panic(fmt.Sprintf("locale %s: %v", file.Name(), err))
+		}
+		loaded[strings.TrimSuffix(file.Name(), path.Ext(file.Name()))] = catalog
+	}
+	if _, ok := loaded[DefaultLocale]; !ok {
+		panic("i18n: missing default locale " + DefaultLocale)
+	}
+	return loaded
+}
+
+// Normalize maps a locale such as "hi-IN" or "HI_in" to a supported locale,
+// falling back to DefaultLocale
+func Normalize(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if _, ok := catalogs[locale]; ok {
+		return locale
+	}
+	if lang, _, found := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-"); found {
+		if _, ok := catalogs[lang]; ok {
+			return lang
+		}
+	}
+	return DefaultLocale
+}
+
+// T translates key into locale and formats it with args. A key the locale
+// does not translate falls back to English, and a key English does not know
+// is returned as is, so a missing translation never fails a send or a
+// download. Translations may use indexed verbs such as %[2]s when their word
+// order differs from English.
+func T(locale, key string, args ...any) string {
+	format, ok := catalogs[Normalize(locale)][key]
+	if !ok {
+		if format, ok = catalogs[DefaultLocale][key]; !ok {
+			return key
+		}
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,56 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "en", Normalize(""))
+	assert.Equal(t, "en", Normalize("fr"))
+	assert.Equal(t, "hi", Normalize("hi"))
+	assert.Equal(t, "hi", Normalize("HI-in"))
+	assert.Equal(t, "hi", Normalize("hi_IN"))
+	assert.Equal(t, "en", Normalize("en-GB"))
+}
+
+func TestT(t *testing.T) {
+	t.Run("translates and formats", func(t *testing.T) {
+		assert.Equal(t, "अतिरिक्त समय: 15 मिनट", T("hi", "hall_ticket.extra_time", 15))
+		assert.Equal(t, "Extra time: 15 minutes", T("en", "hall_ticket.extra_time", 15))
+	})
+
+	t.Run("indexed verbs reorder arguments", func(t *testing.T) {
+		assert.Equal(t, "Ravi's result for Midterm has been published.", T("en", "result_published.student_result", "Ravi", "Midterm"))
+		assert.Equal(t, "Midterm में Ravi का परिणाम प्रकाशित हो गया है।", T("hi", "result_published.student_result", "Ravi", "Midterm"))
+	})
+
+	t.Run("unsupported locale falls back to English", func(t *testing.T) {
+		assert.Equal(t, "Seat number", T("fr", "hall_ticket.seat_number"))
+	})
+
+	t.Run("missing translation falls back to English", func(t *testing.T) {
+		catalogs["en"]["test.only_english"] = "Only %s"
+		defer delete(catalogs["en"], "test.only_english")
+
+		assert.Equal(t, "Only English", T("hi", "test.only_english", "English"))
+	})
+
+	t.Run("unknown key is returned as is", func(t *testing.T) {
+		assert.Equal(t, "hall_ticket.unknown", T("hi", "hall_ticket.unknown"))
+	})
+}
+
+// Every translated key must exist in English, or a typo in a locale file
+// would go unnoticed behind the fallback
+func TestLocalesOnlyTranslateEnglishKeys(t *testing.T) {
+	for locale, catalog := range catalogs {
+		for key, format := range catalog {
+			_, ok := catalogs[DefaultLocale][key]
+			assert.True(t, ok, "%s: %s is not an English key", locale, key)
+			assert.Equal(t, strings.Count(catalogs[DefaultLocale][key], "%"), strings.Count(format, "%"), "%s: %s takes different arguments", locale, key)
+		}
+	}
+}
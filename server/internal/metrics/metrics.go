@@ -0,0 +1,152 @@
+// Package metrics provides a small Prometheus-compatible metrics registry for
+// HTTP request and database pool instrumentation. Metrics are rendered in the
+// Prometheus text exposition format (version 0.0.4).
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ContentType is the Prometheus text exposition content type
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets are the request duration histogram upper bounds in seconds
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// PoolStats is a snapshot of database connection pool statistics
+type PoolStats struct {
+	AcquiredConns int32
+	IdleConns     int32
+	TotalConns    int32
+	MaxConns      int32
+}
+
+// PoolStatsFunc returns the current pool statistics, or false when unavailable
+type PoolStatsFunc func() (PoolStats, bool)
+
+type requestKey struct {
+	method string
+	route  string
+	status string
+}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// Registry collects HTTP request metrics and renders them for scraping
+type Registry struct {
+	mu        sync.Mutex
+	buckets   []float64
+	requests  map[requestKey]uint64
+	durations map[requestKey]*histogram
+	poolStats PoolStatsFunc
+}
+
+// NewRegistry creates a registry. poolStats may be nil.
+func NewRegistry(poolStats PoolStatsFunc) *Registry {
+	return &Registry{
+		buckets:   DefaultBuckets,
+		requests:  make(map[requestKey]uint64),
+		durations: make(map[requestKey]*histogram),
+		poolStats: poolStats,
+	}
+}
+
+// ObserveRequest records a completed HTTP request. route should be the
+// router's pattern (e.g. "/api/exams/:examID"), not the raw path, so that
+// label cardinality stays bounded.
+func (r *Registry) ObserveRequest(method, route string, status int, duration time.Duration) {
+	key := requestKey{method: method, route: route, status: strconv.Itoa(status)}
+	seconds := duration.Seconds()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.requests[key]++
+
+	h, ok := r.durations[key]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(r.buckets))}
+		r.durations[key] = h
+	}
+	for i, upper := range r.buckets {
+		if seconds <= upper {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// WriteTo renders all metrics in the Prometheus text format
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+
+	r.mu.Lock()
+	keys := make([]requestKey, 0, len(r.requests))
+	for k := range r.requests {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].route != keys[j].route {
+			return keys[i].route < keys[j].route
+		}
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].status < keys[j].status
+	})
+
+	b.WriteString("# HELP http_requests_total Total number of HTTP requests by route, method and status.\n")
+	b.WriteString("# TYPE http_requests_total counter\n")
+	for _, k := range keys {
+		fmt.Fprintf(&b, "http_requests_total{%s} %d\n", k.labels(), r.requests[k])
+	}
+
+	b.WriteString("# HELP http_request_duration_seconds HTTP request latency by route, method and status.\n")
+	b.WriteString("# TYPE http_request_duration_seconds histogram\n")
+	for _, k := range keys {
+		h := r.durations[k]
+		labels := k.labels()
+		for i, upper := range r.buckets {
+			fmt.Fprintf(&b, "http_request_duration_seconds_bucket{%s,le=\"%s\"} %d\n",
+				labels, strconv.FormatFloat(upper, 'f', -1, 64), h.counts[i])
+		}
+		fmt.Fprintf(&b, "http_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, h.count)
+		fmt.Fprintf(&b, "http_request_duration_seconds_sum{%s} %s\n", labels, strconv.FormatFloat(h.sum, 'f', -1, 64))
+		fmt.Fprintf(&b, "http_request_duration_seconds_count{%s} %d\n", labels, h.count)
+	}
+	r.mu.Unlock()
+
+	if r.poolStats != nil {
+		if stats, ok := r.poolStats(); ok {
+			writeGauge(&b, "db_pool_acquired_connections", "Number of currently acquired database connections.", int64(stats.AcquiredConns))
+			writeGauge(&b, "db_pool_idle_connections", "Number of idle database connections.", int64(stats.IdleConns))
+			writeGauge(&b, "db_pool_total_connections", "Total number of database connections in the pool.", int64(stats.TotalConns))
+			writeGauge(&b, "db_pool_max_connections", "Maximum size of the database connection pool.", int64(stats.MaxConns))
+		}
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func writeGauge(b *strings.Builder, name, help string, value int64) {
+	fmt.Fprintf(b, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(b, "# TYPE %s gauge\n", name)
+	fmt.Fprintf(b, "%s %d\n", name, value)
+}
+
+func (k requestKey) labels() string {
+	// %q escapes backslashes, quotes and newlines the same way the exposition format requires
+	return fmt.Sprintf("method=%q,route=%q,status=%q", k.method, k.route, k.status)
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_ObserveRequest(t *testing.T) {
+	r := NewRegistry(nil)
+	r.ObserveRequest("GET", "/api/exams/:examID", 200, 20*time.Millisecond)
+	r.ObserveRequest("GET", "/api/exams/:examID", 200, 2*time.Second)
+	r.ObserveRequest("GET", "/api/exams/:examID", 404, time.Millisecond)
+
+	var b strings.Builder
+	_, err := r.WriteTo(&b)
+	require.NoError(t, err)
+	out := b.String()
+
+	assert.Contains(t, out, `http_requests_total{method="GET",route="/api/exams/:examID",status="200"} 2`)
+	assert.Contains(t, out, `http_requests_total{method="GET",route="/api/exams/:examID",status="404"} 1`)
+	assert.Contains(t, out, `http_request_duration_seconds_bucket{method="GET",route="/api/exams/:examID",status="200",le="0.025"} 1`)
+	assert.Contains(t, out, `http_request_duration_seconds_bucket{method="GET",route="/api/exams/:examID",status="200",le="+Inf"} 2`)
+	assert.Contains(t, out, `http_request_duration_seconds_count{method="GET",route="/api/exams/:examID",status="200"} 2`)
+	assert.NotContains(t, out, "db_pool_")
+}
+
+func TestRegistry_PoolStats(t *testing.T) {
+	r := NewRegistry(func() (PoolStats, bool) {
+		return PoolStats{AcquiredConns: 3, IdleConns: 2, TotalConns: 5, MaxConns: 20}, true
+	})
+
+	var b strings.Builder
+	_, err := r.WriteTo(&b)
+	require.NoError(t, err)
+	out := b.String()
+
+	assert.Contains(t, out, "db_pool_acquired_connections 3")
+	assert.Contains(t, out, "db_pool_idle_connections 2")
+	assert.Contains(t, out, "db_pool_total_connections 5")
+	assert.Contains(t, out, "db_pool_max_connections 20")
+}
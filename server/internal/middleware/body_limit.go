@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"eduhub/server/internal/helpers"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequestLimits caps request bodies at maxBodyBytes, or maxUploadBytes for
+// multipart uploads, and records maxBulkItems for handlers to check with
+// helpers.ExceedsBulkLimit. A declared Content-Length over the cap is refused
+// with 413 up front; a body that only turns out too long while being read
+// fails to bind.
+func RequestLimits(maxBodyBytes, maxUploadBytes int64, maxBulkItems int) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(helpers.MaxBulkItemsContextKey, maxBulkItems)
+
+			req := c.Request()
+			limit := maxBodyBytes
+			if strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+				limit = maxUploadBytes
+			}
+			if limit <= 0 || req.Body == nil || req.Body == http.NoBody {
+				return next(c)
+			}
+
+			if req.ContentLength > limit {
+				return helpers.Error(c, fmt.Sprintf("request body too large: the limit is %d bytes", limit), http.StatusRequestEntityTooLarge)
+			}
+			req.Body = http.MaxBytesReader(c.Response(), req.Body, limit)
+			return next(c)
+		}
+	}
+}
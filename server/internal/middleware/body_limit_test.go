@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"eduhub/server/internal/helpers"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLimits(t *testing.T) {
+	e := echo.New()
+	mw := RequestLimits(16, 64, 5)
+
+	run := func(body, contentType string) (*httptest.ResponseRecorder, string, int, error) {
+		var read string
+		var bulk int
+		handler := mw(func(c echo.Context) error {
+			bulk = helpers.MaxBulkItems(c)
+			data, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return err
+			}
+			read = string(data)
+			return c.NoContent(http.StatusOK)
+		})
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, contentType)
+		rec := httptest.NewRecorder()
+		err := handler(e.NewContext(req, rec))
+		return rec, read, bulk, err
+	}
+
+	t.Run("body within the limit", func(t *testing.T) {
+		rec, read, bulk, err := run(`{"a":1}`, echo.MIMEApplicationJSON)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `{"a":1}`, read)
+		assert.Equal(t, 5, bulk)
+	})
+
+	t.Run("declared length over the limit", func(t *testing.T) {
+		rec, _, _, err := run(strings.Repeat("x", 17), echo.MIMEApplicationJSON)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		assert.Contains(t, rec.Body.String(), "request body too large")
+	})
+
+	t.Run("undeclared length over the limit", func(t *testing.T) {
+		var readErr error
+		handler := mw(func(c echo.Context) error {
+			_, readErr = io.ReadAll(c.Request().Body)
+			return nil
+		})
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(strings.Repeat("x", 17)))
+		req.ContentLength = -1
+		require.NoError(t, handler(e.NewContext(req, httptest.NewRecorder())))
+		var maxErr *http.MaxBytesError
+		assert.ErrorAs(t, readErr, &maxErr)
+	})
+
+	t.Run("multipart uses the upload limit", func(t *testing.T) {
+		rec, read, _, err := run(strings.Repeat("x", 40), echo.MIMEMultipartForm+"; boundary=abc")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Len(t, read, 40)
+	})
+}
+
+func TestExceedsBulkLimit(t *testing.T) {
+	e := echo.New()
+	c := e.NewContext(httptest.NewRequest(http.MethodPost, "/", nil), httptest.NewRecorder())
+
+	_, over := helpers.ExceedsBulkLimit(c, helpers.DefaultMaxBulkItems, "students")
+	assert.False(t, over)
+
+	c.Set(helpers.MaxBulkItemsContextKey, 2)
+	_, over = helpers.ExceedsBulkLimit(c, 2, "students")
+	assert.False(t, over)
+	msg, over := helpers.ExceedsBulkLimit(c, 3, "students")
+	assert.True(t, over)
+	assert.Equal(t, "too many students: a request may contain at most 2, got 3", msg)
+}
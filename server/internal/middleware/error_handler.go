@@ -5,16 +5,19 @@ import (
 	"fmt"
 	"net/http"
 
+	"eduhub/server/internal/helpers"
+
 	"github.com/labstack/echo/v4"
 )
 
 // ErrorResponse represents a standardized error response
 type ErrorResponse struct {
-	Error   string         `json:"error"`
-	Message string         `json:"message"`
-	Code    string         `json:"code,omitempty"`
-	Details map[string]any `json:"details,omitempty"`
-	Status  int            `json:"status"`
+	Error     string         `json:"error"`
+	Message   string         `json:"message"`
+	Code      string         `json:"code,omitempty"`
+	Details   map[string]any `json:"details,omitempty"`
+	Status    int            `json:"status"`
+	RequestID string         `json:"request_id,omitempty"`
 }
 
 // AppError represents an application error with additional context
@@ -99,11 +102,12 @@ func ErrorHandlerMiddleware() echo.MiddlewareFunc {
 			// Handle AppError
 			if appErr, ok := err.(*AppError); ok {
 				return c.JSON(appErr.Status, ErrorResponse{
-					Error:   appErr.Code,
-					Message: appErr.Message,
-					Code:    appErr.Code,
-					Details: appErr.Details,
-					Status:  appErr.Status,
+					Error:     appErr.Code,
+					Message:   appErr.Message,
+					Code:      appErr.Code,
+					Details:   appErr.Details,
+					Status:    appErr.Status,
+					RequestID: helpers.GetRequestID(c),
 				})
 			}
 
@@ -116,29 +120,32 @@ func ErrorHandlerMiddleware() echo.MiddlewareFunc {
 				code := getErrorCode(status)
 
 				return c.JSON(status, ErrorResponse{
-					Error:   code,
-					Message: message,
-					Code:    code,
-					Status:  status,
+					Error:     code,
+					Message:   message,
+					Code:      code,
+					Status:    status,
+					RequestID: helpers.GetRequestID(c),
 				})
 			}
 
 			// Handle JSON parsing errors
 			if _, ok := err.(*json.UnmarshalTypeError); ok {
 				return c.JSON(http.StatusBadRequest, ErrorResponse{
-					Error:   "BAD_REQUEST",
-					Message: "Invalid JSON format in request body",
-					Code:    "INVALID_JSON",
-					Status:  http.StatusBadRequest,
+					Error:     "BAD_REQUEST",
+					Message:   "Invalid JSON format in request body",
+					Code:      "INVALID_JSON",
+					Status:    http.StatusBadRequest,
+					RequestID: helpers.GetRequestID(c),
 				})
 			}
 
 			// Default to internal server error for unknown errors
 			return c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "INTERNAL_SERVER_ERROR",
-				Message: "An unexpected error occurred. Please try again later.",
-				Code:    "INTERNAL_ERROR",
-				Status:  http.StatusInternalServerError,
+				Error:     "INTERNAL_SERVER_ERROR",
+				Message:   "An unexpected error occurred. Please try again later.",
+				Code:      "INTERNAL_ERROR",
+				Status:    http.StatusInternalServerError,
+				RequestID: helpers.GetRequestID(c),
 			})
 		}
 	}
@@ -186,10 +193,11 @@ func RecoverMiddleware() echo.MiddlewareFunc {
 
 					// Return internal server error
 					_ = c.JSON(http.StatusInternalServerError, ErrorResponse{
-						Error:   "INTERNAL_SERVER_ERROR",
-						Message: "An unexpected error occurred. Please try again later.",
-						Code:    "PANIC_RECOVERED",
-						Status:  http.StatusInternalServerError,
+						Error:     "INTERNAL_SERVER_ERROR",
+						Message:   "An unexpected error occurred. Please try again later.",
+						Code:      "PANIC_RECOVERED",
+						Status:    http.StatusInternalServerError,
+						RequestID: helpers.GetRequestID(c),
 					})
 				}
 			}()
@@ -6,7 +6,6 @@ import (
 	"strings"
 
 	"github.com/labstack/echo/v4"
-	"github.com/rs/zerolog/log"
 )
 
 // ErrorSanitizationMiddleware sanitizes error responses to prevent information leakage
@@ -32,7 +31,7 @@ func (m *ErrorSanitizationMiddleware) Middleware(next echo.HandlerFunc) echo.Han
 		}
 
 		// Log the full error for debugging
-		log.Error().
+		RequestLogger(c).Error().
 			Err(err).
 			Str("path", c.Request().URL.Path).
 			Str("method", c.Request().Method).
@@ -119,7 +118,7 @@ func (m *ErrorSanitizationMiddleware) RecoverMiddleware(next echo.HandlerFunc) e
 				}
 
 				// Log the panic
-				log.Error().
+				RequestLogger(c).Error().
 					Err(err).
 					Interface("panic", r).
 					Str("path", c.Request().URL.Path).
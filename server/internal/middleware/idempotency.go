@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"eduhub/server/internal/cache"
+	"eduhub/server/internal/helpers"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header clients use to make a POST safe to retry
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses served from a stored result
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// DefaultIdempotencyTTL is how long a stored response can be replayed
+	DefaultIdempotencyTTL = 24 * time.Hour
+
+	maxIdempotencyKeyLength = 255
+	// idempotencyLockTTL bounds how long a crashed request can block retries
+	idempotencyLockTTL = time.Minute
+)
+
+// IdempotencyStore is the subset of the Redis cache used to record responses
+type IdempotencyStore interface {
+	SetNX(ctx context.Context, key string, value any, ttl time.Duration) (bool, error)
+	Set(ctx context.Context, key string, value any, ttl time.Duration) error
+	Get(ctx context.Context, key string, dest any) error
+	Delete(ctx context.Context, key string) error
+}
+
+// storedResponse is the record kept for each (identity, route, key) tuple.
+// A record with Completed=false marks a request that is still running.
+type storedResponse struct {
+	Completed   bool   `json:"completed"`
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// IdempotencyMiddleware replays the first response for repeated POST requests
+// carrying the same Idempotency-Key. Requests without the header pass through
+// unprotected, and a nil store disables the middleware entirely.
+type IdempotencyMiddleware struct {
+	store IdempotencyStore
+	ttl   time.Duration
+}
+
+// NewIdempotencyMiddleware creates an idempotency middleware. store may be nil
+// when Redis is not configured.
+func NewIdempotencyMiddleware(store IdempotencyStore, ttl time.Duration) *IdempotencyMiddleware {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	return &IdempotencyMiddleware{store: store, ttl: ttl}
+}
+
+// Middleware returns the echo middleware. Register it after authentication so
+// the stored response is scoped to the caller's identity.
+func (m *IdempotencyMiddleware) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if m == nil || m.store == nil || c.Request().Method != http.MethodPost {
+				return next(c)
+			}
+
+			key := c.Request().Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				return next(c)
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				return helpers.Error(c, "Idempotency-Key must be at most "+strconv.Itoa(maxIdempotencyKeyLength)+" characters", http.StatusBadRequest)
+			}
+
+			ctx := c.Request().Context()
+			storeKey := cache.CacheKey("idempotency", idempotencyIdentity(c), c.Path(), key)
+
+			acquired, err := m.store.SetNX(ctx, storeKey, storedResponse{}, idempotencyLockTTL)
+			if err != nil {
+				// Fail open: an unavailable store should not block writes
+				RequestLogger(c).Warn().Err(err).Msg("idempotency store unavailable, processing request unprotected")
+				return next(c)
+			}
+
+			if !acquired {
+				var existing storedResponse
+				if err := m.store.Get(ctx, storeKey, &existing); err != nil {
+					if errors.Is(err, cache.ErrKeyNotFound) {
+						// The earlier attempt failed and released the key between our calls
+						return helpers.Error(c, "A request with this Idempotency-Key was just retried, please try again", http.StatusConflict)
+					}
+					RequestLogger(c).Warn().Err(err).Msg("failed to read stored idempotent response")
+					return helpers.Error(c, "Unable to verify Idempotency-Key", http.StatusServiceUnavailable)
+				}
+				if !existing.Completed {
+					return helpers.Error(c, "A request with this Idempotency-Key is already being processed", http.StatusConflict)
+				}
+				c.Response().Header().Set(IdempotentReplayedHeader, "true")
+				return c.Blob(existing.Status, existing.ContentType, existing.Body)
+			}
+
+			recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+
+			handlerErr := next(c)
+
+			// Detach from the request context so a client disconnect cannot
+			// leave the lock behind
+			storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 3*time.Second)
+			defer cancel()
+
+			status := c.Response().Status
+			if handlerErr != nil || !c.Response().Committed || status >= http.StatusInternalServerError {
+				// Let the client retry failed attempts
+				if err := m.store.Delete(storeCtx, storeKey); err != nil {
+					RequestLogger(c).Warn().Err(err).Msg("failed to release idempotency key")
+				}
+				return handlerErr
+			}
+
+			record := storedResponse{
+				Completed:   true,
+				Status:      status,
+				ContentType: c.Response().Header().Get(echo.HeaderContentType),
+				Body:        recorder.body.Bytes(),
+			}
+			if err := m.store.Set(storeCtx, storeKey, record, m.ttl); err != nil {
+				RequestLogger(c).Warn().Err(err).Msg("failed to store idempotent response")
+			}
+			return nil
+		}
+	}
+}
+
+// idempotencyIdentity scopes keys per caller so two users cannot collide
+func idempotencyIdentity(c echo.Context) string {
+	if id, err := helpers.GetKratosID(c); err == nil {
+		return id
+	}
+	if id, err := helpers.ExtractUserID(c); err == nil {
+		return "user:" + strconv.Itoa(id)
+	}
+	return "anonymous:" + c.RealIP()
+}
+
+// responseRecorder copies everything written to the client into a buffer
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,190 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/cache"
+	"eduhub/server/internal/services/auth"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryIdempotencyStore struct {
+	mu   sync.Mutex
+	data map[string][]byte
+	err  error
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{data: make(map[string][]byte)}
+}
+
+func (s *memoryIdempotencyStore) SetNX(_ context.Context, key string, value any, _ time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return false, s.err
+	}
+	if _, ok := s.data[key]; ok {
+		return false, nil
+	}
+	s.data[key], _ = json.Marshal(value)
+	return true, nil
+}
+
+func (s *memoryIdempotencyStore) Set(_ context.Context, key string, value any, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key], _ = json.Marshal(value)
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Get(_ context.Context, key string, dest any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.data[key]
+	if !ok {
+		return cache.ErrKeyNotFound
+	}
+	return json.Unmarshal(data, dest)
+}
+
+func (s *memoryIdempotencyStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, key)
+	return nil
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	e := echo.New()
+
+	serve := func(mw *IdempotencyMiddleware, identityID, key string, handler echo.HandlerFunc) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/exams/1/enroll", nil)
+		if key != "" {
+			req.Header.Set(IdempotencyKeyHeader, key)
+		}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/api/exams/:examID/enroll")
+		c.Set("identity", &auth.Identity{ID: identityID})
+		require.NoError(t, mw.Middleware()(handler)(c))
+		return rec
+	}
+
+	t.Run("replays the first response for a repeated key", func(t *testing.T) {
+		mw := NewIdempotencyMiddleware(newMemoryIdempotencyStore(), time.Hour)
+		calls := 0
+		handler := func(c echo.Context) error {
+			calls++
+			return c.JSON(http.StatusCreated, map[string]int{"enrollment": calls})
+		}
+
+		first := serve(mw, "user-1", "abc", handler)
+		second := serve(mw, "user-1", "abc", handler)
+
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, http.StatusCreated, second.Code)
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, "true", second.Header().Get(IdempotentReplayedHeader))
+	})
+
+	t.Run("keys are scoped to the caller", func(t *testing.T) {
+		mw := NewIdempotencyMiddleware(newMemoryIdempotencyStore(), time.Hour)
+		calls := 0
+		handler := func(c echo.Context) error {
+			calls++
+			return c.NoContent(http.StatusCreated)
+		}
+
+		serve(mw, "user-1", "abc", handler)
+		serve(mw, "user-2", "abc", handler)
+
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("requests without a key are not deduplicated", func(t *testing.T) {
+		mw := NewIdempotencyMiddleware(newMemoryIdempotencyStore(), time.Hour)
+		calls := 0
+		handler := func(c echo.Context) error {
+			calls++
+			return c.NoContent(http.StatusCreated)
+		}
+
+		serve(mw, "user-1", "", handler)
+		serve(mw, "user-1", "", handler)
+
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("server errors release the key for retry", func(t *testing.T) {
+		mw := NewIdempotencyMiddleware(newMemoryIdempotencyStore(), time.Hour)
+		calls := 0
+		handler := func(c echo.Context) error {
+			calls++
+			if calls == 1 {
+				return c.NoContent(http.StatusInternalServerError)
+			}
+			return c.NoContent(http.StatusCreated)
+		}
+
+		serve(mw, "user-1", "abc", handler)
+		rec := serve(mw, "user-1", "abc", handler)
+
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("in-progress key returns conflict", func(t *testing.T) {
+		store := newMemoryIdempotencyStore()
+		mw := NewIdempotencyMiddleware(store, time.Hour)
+		rec := serve(mw, "user-1", "abc", func(c echo.Context) error {
+			inner := serve(mw, "user-1", "abc", func(c echo.Context) error {
+				t.Fatal("duplicate request must not reach the handler")
+				return nil
+			})
+			assert.Equal(t, http.StatusConflict, inner.Code)
+			return c.NoContent(http.StatusCreated)
+		})
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("store failure processes the request unprotected", func(t *testing.T) {
+		store := newMemoryIdempotencyStore()
+		store.err = errors.New("connection refused")
+		mw := NewIdempotencyMiddleware(store, time.Hour)
+		calls := 0
+
+		rec := serve(mw, "user-1", "abc", func(c echo.Context) error {
+			calls++
+			return c.NoContent(http.StatusCreated)
+		})
+
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("nil store is a pass-through", func(t *testing.T) {
+		mw := NewIdempotencyMiddleware(nil, 0)
+		calls := 0
+		handler := func(c echo.Context) error {
+			calls++
+			return c.NoContent(http.StatusCreated)
+		}
+
+		serve(mw, "user-1", "abc", handler)
+		serve(mw, "user-1", "abc", handler)
+
+		assert.Equal(t, 2, calls)
+	})
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"sync/atomic"
+
+	"github.com/labstack/echo/v4"
+)
+
+// InFlightTracker counts requests that are currently being handled.
+// It is used during graceful shutdown to report how many requests were
+// still running when the drain timeout expired.
+type InFlightTracker struct {
+	count atomic.Int64
+}
+
+// NewInFlightTracker creates a new in-flight request tracker
+func NewInFlightTracker() *InFlightTracker {
+	return &InFlightTracker{}
+}
+
+// Middleware increments the counter for the lifetime of each request
+func (t *InFlightTracker) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			t.count.Add(1)
+			defer t.count.Add(-1)
+			return next(c)
+		}
+	}
+}
+
+// Count returns the number of requests currently in flight
+func (t *InFlightTracker) Count() int64 {
+	return t.count.Load()
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInFlightTracker(t *testing.T) {
+	e := echo.New()
+	tracker := NewInFlightTracker()
+
+	var during int64
+	handler := tracker.Middleware()(func(c echo.Context) error {
+		during = tracker.Count()
+		return c.String(http.StatusOK, "ok")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	assert.NoError(t, handler(c))
+	assert.Equal(t, int64(1), during)
+	assert.Equal(t, int64(0), tracker.Count())
+}
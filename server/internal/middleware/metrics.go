@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"eduhub/server/internal/metrics"
+
+	"github.com/labstack/echo/v4"
+)
+
+// unmatchedRoute labels requests that did not match any registered route,
+// so that scanners probing random paths cannot blow up label cardinality.
+const unmatchedRoute = "unmatched"
+
+// Metrics records request count and latency per route pattern and status.
+// The route label uses the echo route pattern (c.Path()), never the raw URL.
+func Metrics(registry *metrics.Registry) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			route := c.Path()
+			if route == "" {
+				route = unmatchedRoute
+			}
+
+			status := c.Response().Status
+			if err != nil && !c.Response().Committed {
+				var he *echo.HTTPError
+				var appErr *AppError
+				switch {
+				case errors.As(err, &he):
+					status = he.Code
+				case errors.As(err, &appErr):
+					status = appErr.Status
+				default:
+					status = http.StatusInternalServerError
+				}
+			}
+
+			registry.ObserveRequest(c.Request().Method, route, status, time.Since(start))
+			return err
+		}
+	}
+}
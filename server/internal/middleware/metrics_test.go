@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"eduhub/server/internal/metrics"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsMiddleware_UsesRoutePattern(t *testing.T) {
+	e := echo.New()
+	registry := metrics.NewRegistry(nil)
+	e.Use(Metrics(registry))
+	e.GET("/api/exams/:examID", func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+
+	for _, id := range []string{"1", "2", "3"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/exams/"+id, nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	var b strings.Builder
+	_, err := registry.WriteTo(&b)
+	require.NoError(t, err)
+	assert.Contains(t, b.String(), `http_requests_total{method="GET",route="/api/exams/:examID",status="200"} 3`)
+	assert.NotContains(t, b.String(), `route="/api/exams/1"`)
+}
+
+func TestMetricsMiddleware_RecordsHTTPErrorStatus(t *testing.T) {
+	e := echo.New()
+	registry := metrics.NewRegistry(nil)
+	e.Use(Metrics(registry))
+	e.GET("/forbidden", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusForbidden, "nope")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/forbidden", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	var b strings.Builder
+	_, err := registry.WriteTo(&b)
+	require.NoError(t, err)
+	assert.Contains(t, b.String(), `http_requests_total{method="GET",route="/forbidden",status="403"} 1`)
+}
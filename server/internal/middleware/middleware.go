@@ -14,6 +14,10 @@ type Middleware struct {
 
 	// ParamValidator validates route parameters (IDs)
 	ParamValidator *ParamValidator
+
+	// Idempotency replays stored responses for retried POST requests.
+	// It is a pass-through when Redis is not configured.
+	Idempotency *IdempotencyMiddleware
 }
 
 // NewMiddleware creates a Middleware bundle wired to the given services.
@@ -21,7 +25,7 @@ type Middleware struct {
 // which is convenient in tests.
 func NewMiddleware(svc *services.Services) *Middleware {
 	if svc == nil {
-		return &Middleware{Auth: &AuthMiddleware{}, ParamValidator: &ParamValidator{}}
+		return &Middleware{Auth: &AuthMiddleware{}, ParamValidator: &ParamValidator{}, Idempotency: NewIdempotencyMiddleware(nil, 0)}
 	}
 
 	authMiddleware := NewAuthMiddleware(
@@ -30,8 +34,14 @@ func NewMiddleware(svc *services.Services) *Middleware {
 		nil,                 // hydra: already embedded inside svc.Auth
 	)
 
+	var idempotencyStore IdempotencyStore
+	if svc.RedisCache != nil {
+		idempotencyStore = svc.RedisCache
+	}
+
 	return &Middleware{
 		Auth:           authMiddleware,
 		ParamValidator: &ParamValidator{},
+		Idempotency:    NewIdempotencyMiddleware(idempotencyStore, DefaultIdempotencyTTL),
 	}
 }
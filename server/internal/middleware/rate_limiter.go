@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"fmt"
 	"net/http"
 	"os"
 	"sync"
@@ -25,6 +26,7 @@ type RateLimiter struct {
 	started  bool
 	logger   zerolog.Logger
 	cleanup  time.Duration
+	key      func(echo.Context) string
 }
 
 func NewRateLimiter(r rate.Limit, b int) *RateLimiter {
@@ -38,6 +40,7 @@ func NewRateLimiter(r rate.Limit, b int) *RateLimiter {
 		started:  false,
 		logger:   logger,
 		cleanup:  5 * time.Minute,
+		key:      func(c echo.Context) string { return c.RealIP() },
 	}
 }
 
@@ -47,6 +50,14 @@ func (rl *RateLimiter) SetCleanupInterval(d time.Duration) {
 	rl.cleanup = d
 }
 
+// SetKeyFunc changes what requests are counted by; the default is the
+// client IP
+func (rl *RateLimiter) SetKeyFunc(key func(echo.Context) string) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.key = key
+}
+
 func (rl *RateLimiter) getVisitor(ip string) *rate.Limiter {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
@@ -120,11 +131,13 @@ func (rl *RateLimiter) Middleware() echo.MiddlewareFunc {
 
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			ip := c.RealIP()
-			limiter := rl.getVisitor(ip)
+			rl.mu.RLock()
+			key := rl.key(c)
+			rl.mu.RUnlock()
+			limiter := rl.getVisitor(key)
 
 			if !limiter.Allow() {
-				rl.logger.Warn().Str("ip", ip).Msg("Rate limit exceeded")
+				rl.logger.Warn().Str("key", key).Msg("Rate limit exceeded")
 				return c.JSON(http.StatusTooManyRequests, map[string]any{
 					"error":   "Too many requests",
 					"message": "Rate limit exceeded. Please try again later.",
@@ -147,3 +160,17 @@ func ModerateRateLimiter() *RateLimiter {
 func LenientRateLimiter() *RateLimiter {
 	return NewRateLimiter(rate.Every(600*time.Millisecond), 100)
 }
+
+// ProctoringRateLimiter allows each student a burst of 20 proctoring events
+// and one a second after that. It must run after LoadStudentProfile so
+// students behind the same exam centre IP do not share a budget.
+func ProctoringRateLimiter() *RateLimiter {
+	rl := NewRateLimiter(rate.Every(time.Second), 20)
+	rl.SetKeyFunc(func(c echo.Context) string {
+		if studentID, ok := c.Get("student_id").(int); ok {
+			return fmt.Sprintf("student:%d", studentID)
+		}
+		return c.RealIP()
+	})
+	return rl
+}
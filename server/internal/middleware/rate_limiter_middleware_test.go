@@ -143,3 +143,30 @@ func TestLenientRateLimiter(t *testing.T) {
 	assert.Equal(t, rate.Every(600*time.Millisecond), rl.rate)
 	assert.Equal(t, 100, rl.burst)
 }
+
+func TestProctoringRateLimiter_CountsPerStudent(t *testing.T) {
+	rl := ProctoringRateLimiter()
+	defer rl.Stop()
+
+	mw := rl.Middleware()
+	e := echo.New()
+	handler := mw(func(c echo.Context) error {
+		return c.String(http.StatusOK, "ok")
+	})
+
+	send := func(studentID int) int {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set("X-Real-Ip", "10.0.0.1") // same exam centre
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("student_id", studentID)
+		require.NoError(t, handler(c))
+		return rec.Code
+	}
+
+	for i := 0; i < 20; i++ {
+		require.Equal(t, http.StatusOK, send(7))
+	}
+	assert.Equal(t, http.StatusTooManyRequests, send(7))
+	assert.Equal(t, http.StatusOK, send(8), "another student at the same IP has their own budget")
+}
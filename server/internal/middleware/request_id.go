@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"eduhub/server/internal/helpers"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// maxRequestIDLength bounds client-supplied request IDs
+const maxRequestIDLength = 128
+
+// RequestID assigns a correlation ID to every request. An incoming
+// X-Request-ID header is reused when it is well formed, otherwise a new UUID
+// is generated. The ID is stored in the echo context, echoed back in the
+// response header, and attached to a request-scoped zerolog logger available
+// via zerolog.Ctx(c.Request().Context()).
+func RequestID(base zerolog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			id := req.Header.Get(echo.HeaderXRequestID)
+			if !isValidRequestID(id) {
+				id = uuid.New().String()
+			}
+
+			c.Set(helpers.RequestIDContextKey, id)
+			c.Response().Header().Set(echo.HeaderXRequestID, id)
+
+			reqLogger := base.With().Str("request_id", id).Logger()
+			c.SetRequest(req.WithContext(reqLogger.WithContext(req.Context())))
+
+			return next(c)
+		}
+	}
+}
+
+// isValidRequestID accepts only short IDs made of characters that are safe to
+// write into logs and headers, preventing log injection via the header.
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// RequestLogger returns the request-scoped logger installed by RequestID,
+// falling back to the global logger when the middleware is not in the chain.
+func RequestLogger(c echo.Context) *zerolog.Logger {
+	if l := zerolog.Ctx(c.Request().Context()); l.GetLevel() != zerolog.Disabled {
+		return l
+	}
+	return &log.Logger
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"eduhub/server/internal/helpers"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestID(t *testing.T) {
+	t.Run("reuses incoming header", func(t *testing.T) {
+		e := echo.New()
+		var buf bytes.Buffer
+		mw := RequestID(zerolog.New(&buf))
+
+		var seen string
+		handler := mw(func(c echo.Context) error {
+			seen = helpers.GetRequestID(c)
+			zerolog.Ctx(c.Request().Context()).Info().Msg("handling")
+			return c.String(http.StatusOK, "ok")
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(echo.HeaderXRequestID, "abc-123")
+		rec := httptest.NewRecorder()
+		require.NoError(t, handler(e.NewContext(req, rec)))
+
+		assert.Equal(t, "abc-123", seen)
+		assert.Equal(t, "abc-123", rec.Header().Get(echo.HeaderXRequestID))
+		assert.Contains(t, buf.String(), `"request_id":"abc-123"`)
+	})
+
+	t.Run("generates id when header missing", func(t *testing.T) {
+		e := echo.New()
+		handler := RequestID(zerolog.Nop())(func(c echo.Context) error {
+			return c.String(http.StatusOK, "ok")
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		rec := httptest.NewRecorder()
+		require.NoError(t, handler(e.NewContext(req, rec)))
+
+		assert.Len(t, rec.Header().Get(echo.HeaderXRequestID), 36)
+	})
+
+	t.Run("replaces unsafe header", func(t *testing.T) {
+		e := echo.New()
+		handler := RequestID(zerolog.Nop())(func(c echo.Context) error {
+			return c.String(http.StatusOK, "ok")
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(echo.HeaderXRequestID, "bad id\ninjected")
+		rec := httptest.NewRecorder()
+		require.NoError(t, handler(e.NewContext(req, rec)))
+
+		id := rec.Header().Get(echo.HeaderXRequestID)
+		assert.NotContains(t, id, "injected")
+		assert.Len(t, id, 36)
+	})
+
+	t.Run("error responses carry the request id", func(t *testing.T) {
+		e := echo.New()
+		handler := RequestID(zerolog.Nop())(func(c echo.Context) error {
+			return helpers.Error(c, "boom", http.StatusBadRequest)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(echo.HeaderXRequestID, "req-42")
+		rec := httptest.NewRecorder()
+		require.NoError(t, handler(e.NewContext(req, rec)))
+
+		assert.True(t, strings.Contains(rec.Body.String(), `"request_id":"req-42"`))
+	})
+}
@@ -0,0 +1,222 @@
+// Package migrate applies the embedded SQL migrations and reports the schema
+// version. It keeps its state in the same schema_migrations table as the
+// golang-migrate CLI, so databases migrated with `task db:migrate` and
+// databases migrated by the server agree on their version.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"eduhub/server/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// lockID is the advisory lock held while migrations run, so two instances
+// starting together do not apply the same migration twice
+const lockID int64 = 7_241_533_190
+
+var (
+	// ErrSchemaMismatch is returned by Check when the database is not at the
+	// version the binary was built for
+	ErrSchemaMismatch = errors.New("database schema version does not match this build")
+	// ErrDirty is returned when a previous migration failed half way and the
+	// database has to be repaired by hand
+	ErrDirty = errors.New("database schema is dirty after a failed migration")
+)
+
+// Conn is the single connection migrations run on. It must be one session
+// (e.g. an acquired *pgxpool.Conn), not a pool, because the advisory lock and
+// the BEGIN/COMMIT inside each migration file are tied to the session.
+type Conn interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Acquire takes one connection from the database pool to run Up, Check or
+// Version on. The caller must Release it.
+func Acquire(ctx context.Context, db *repository.DB) (*pgxpool.Conn, error) {
+	pool, ok := db.Pool.(*pgxpool.Pool)
+	if !ok {
+		return nil, fmt.Errorf("migrations need a pgx connection pool")
+	}
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire a connection for migrations: %w", err)
+	}
+	return conn, nil
+}
+
+// Migration is one numbered up migration
+type Migration struct {
+	Version uint
+	Name    string
+	SQL     string
+}
+
+// Migrator applies a fixed, ordered set of migrations
+type Migrator struct {
+	migrations []Migration
+}
+
+// New reads every NNNNNN_name.up.sql file at the root of fsys
+func New(fsys fs.FS) (*Migrator, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var migrations []Migration
+	seen := make(map[uint]string)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".up.sql") {
+			continue
+		}
+		rawVersion, _, ok := strings.Cut(name, "_")
+		if !ok {
+			return nil, fmt.Errorf("invalid migration file name %q", name)
+		}
+		version, err := strconv.ParseUint(rawVersion, 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("invalid migration version in %q", name)
+		}
+		if other, dup := seen[uint(version)]; dup {
+			return nil, fmt.Errorf("migration version %d is used by both %q and %q", version, other, name)
+		}
+		seen[uint(version)] = name
+
+		body, err := fs.ReadFile(fsys, path.Clean(name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %q: %w", name, err)
+		}
+		migrations = append(migrations, Migration{
+			Version: uint(version),
+			Name:    strings.TrimSuffix(name, ".up.sql"),
+			SQL:     string(body),
+		})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return &Migrator{migrations: migrations}, nil
+}
+
+// Latest is the version the binary expects the database to be at
+func (m *Migrator) Latest() uint {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version returns the database's current version, 0 when nothing has been applied
+func (m *Migrator) Version(ctx context.Context, conn Conn) (version uint, dirty bool, err error) {
+	var exists bool
+	if err := conn.QueryRow(ctx, `SELECT to_regclass('schema_migrations') IS NOT NULL`).Scan(&exists); err != nil {
+		return 0, false, fmt.Errorf("failed to look up schema_migrations: %w", err)
+	}
+	if !exists {
+		return 0, false, nil
+	}
+
+	var v int64
+	err = conn.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&v, &dirty)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return uint(v), dirty, nil
+}
+
+// Check fails unless the database is clean and exactly at Latest
+func (m *Migrator) Check(ctx context.Context, conn Conn) error {
+	version, dirty, err := m.Version(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("%w: version %d, fix it and force the version with the migrate CLI", ErrDirty, version)
+	}
+	if version != m.Latest() {
+		return fmt.Errorf("%w: database is at %d, binary expects %d; run the migrations or set DB_AUTO_MIGRATE=true",
+			ErrSchemaMismatch, version, m.Latest())
+	}
+	return nil
+}
+
+// Up applies every migration newer than the database's version and returns
+// how many ran. A failed migration leaves the version marked dirty, exactly
+// like golang-migrate, so the next start refuses to continue.
+func (m *Migrator) Up(ctx context.Context, conn Conn) (int, error) {
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+		return 0, fmt.Errorf("failed to take the migration lock: %w", err)
+	}
+	defer func() {
+		_, _ = conn.Exec(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, lockID)
+	}()
+
+	if _, err := conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`); err != nil {
+		return 0, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	current, dirty, err := m.Version(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, fmt.Errorf("%w: version %d", ErrDirty, current)
+	}
+	if current > m.Latest() {
+		return 0, fmt.Errorf("%w: database is at %d, newer than the %d this binary knows", ErrSchemaMismatch, current, m.Latest())
+	}
+
+	applied := 0
+	for _, migration := range m.migrations {
+		if migration.Version <= current {
+			continue
+		}
+		if err := setVersion(ctx, conn, migration.Version, true); err != nil {
+			return applied, err
+		}
+		// No arguments, so pgx sends the file with the simple protocol and
+		// the BEGIN/COMMIT it contains are honoured
+		if _, err := conn.Exec(ctx, migration.SQL); err != nil {
+			// Leave the session usable if the file's own transaction is still open
+			_, _ = conn.Exec(context.WithoutCancel(ctx), `ROLLBACK`)
+			return applied, fmt.Errorf("migration %s failed: %w", migration.Name, err)
+		}
+		if err := setVersion(ctx, conn, migration.Version, false); err != nil {
+			return applied, err
+		}
+		applied++
+	}
+	return applied, nil
+}
+
+// setVersion replaces the single schema_migrations row. Both values are
+// formatted from a uint and a bool, so the statement can be sent as one
+// transaction with the simple protocol.
+func setVersion(ctx context.Context, conn Conn, version uint, dirty bool) error {
+	_, err := conn.Exec(ctx, fmt.Sprintf(`BEGIN;
+DELETE FROM schema_migrations;
+INSERT INTO schema_migrations (version, dirty) VALUES (%d, %t);
+COMMIT;`, version, dirty))
+	if err != nil {
+		_, _ = conn.Exec(context.WithoutCancel(ctx), `ROLLBACK`)
+		return fmt.Errorf("failed to record schema version %d: %w", version, err)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"eduhub/server/db/migrations"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFS() fstest.MapFS {
+	return fstest.MapFS{
+		"000002_add_rooms.up.sql":      {Data: []byte("CREATE TABLE rooms (id int);")},
+		"000002_add_rooms.down.sql":    {Data: []byte("DROP TABLE rooms;")},
+		"000001_create_users.up.sql":   {Data: []byte("CREATE TABLE users (id int);")},
+		"000001_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		"README.md":                    {Data: []byte("not a migration")},
+	}
+}
+
+func newMockConn(t *testing.T) pgxmock.PgxConnIface {
+	conn, err := pgxmock.NewConn()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close(context.Background()) })
+	return conn
+}
+
+func expectVersion(conn pgxmock.PgxConnIface, version int64, dirty bool) {
+	conn.ExpectQuery(`SELECT to_regclass`).
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+	conn.ExpectQuery(`SELECT version, dirty FROM schema_migrations`).
+		WillReturnRows(pgxmock.NewRows([]string{"version", "dirty"}).AddRow(version, dirty))
+}
+
+func TestNew(t *testing.T) {
+	m, err := New(testFS())
+	require.NoError(t, err)
+	require.Len(t, m.migrations, 2)
+	assert.Equal(t, "000001_create_users", m.migrations[0].Name)
+	assert.Equal(t, uint(2), m.Latest())
+
+	t.Run("duplicate version", func(t *testing.T) {
+		fsys := testFS()
+		fsys["000002_add_halls.up.sql"] = &fstest.MapFile{Data: []byte("SELECT 1;")}
+		_, err := New(fsys)
+		assert.ErrorContains(t, err, "migration version 2 is used by both")
+	})
+
+	t.Run("embedded migrations load", func(t *testing.T) {
+		m, err := New(migrations.FS)
+		require.NoError(t, err)
+		assert.Greater(t, m.Latest(), uint(0))
+	})
+}
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+	m, err := New(testFS())
+	require.NoError(t, err)
+
+	t.Run("up to date", func(t *testing.T) {
+		conn := newMockConn(t)
+		expectVersion(conn, 2, false)
+		assert.NoError(t, m.Check(ctx, conn))
+	})
+
+	t.Run("behind", func(t *testing.T) {
+		conn := newMockConn(t)
+		expectVersion(conn, 1, false)
+		err := m.Check(ctx, conn)
+		assert.ErrorIs(t, err, ErrSchemaMismatch)
+		assert.ErrorContains(t, err, "database is at 1, binary expects 2")
+	})
+
+	t.Run("dirty", func(t *testing.T) {
+		conn := newMockConn(t)
+		expectVersion(conn, 2, true)
+		assert.ErrorIs(t, m.Check(ctx, conn), ErrDirty)
+	})
+
+	t.Run("never migrated", func(t *testing.T) {
+		conn := newMockConn(t)
+		conn.ExpectQuery(`SELECT to_regclass`).
+			WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+		assert.ErrorIs(t, m.Check(ctx, conn), ErrSchemaMismatch)
+	})
+}
+
+func TestUp(t *testing.T) {
+	ctx := context.Background()
+	m, err := New(testFS())
+	require.NoError(t, err)
+
+	t.Run("applies only pending migrations", func(t *testing.T) {
+		conn := newMockConn(t)
+		conn.ExpectExec(`SELECT pg_advisory_lock`).WithArgs(lockID).WillReturnResult(pgxmock.NewResult("SELECT", 1))
+		conn.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations`).WillReturnResult(pgxmock.NewResult("CREATE", 0))
+		expectVersion(conn, 1, false)
+		conn.ExpectExec(`VALUES \(2, true\)`).WillReturnResult(pgxmock.NewResult("COMMIT", 0))
+		conn.ExpectExec(`CREATE TABLE rooms`).WillReturnResult(pgxmock.NewResult("CREATE", 0))
+		conn.ExpectExec(`VALUES \(2, false\)`).WillReturnResult(pgxmock.NewResult("COMMIT", 0))
+		conn.ExpectExec(`SELECT pg_advisory_unlock`).WithArgs(lockID).WillReturnResult(pgxmock.NewResult("SELECT", 1))
+
+		applied, err := m.Up(ctx, conn)
+		require.NoError(t, err)
+		assert.Equal(t, 1, applied)
+		assert.NoError(t, conn.ExpectationsWereMet())
+	})
+
+	t.Run("failed migration stays dirty", func(t *testing.T) {
+		conn := newMockConn(t)
+		conn.ExpectExec(`SELECT pg_advisory_lock`).WillReturnResult(pgxmock.NewResult("SELECT", 1))
+		conn.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations`).WillReturnResult(pgxmock.NewResult("CREATE", 0))
+		expectVersion(conn, 1, false)
+		conn.ExpectExec(`VALUES \(2, true\)`).WillReturnResult(pgxmock.NewResult("COMMIT", 0))
+		conn.ExpectExec(`CREATE TABLE rooms`).WillReturnError(errors.New("syntax error"))
+		conn.ExpectExec(`ROLLBACK`).WillReturnResult(pgxmock.NewResult("ROLLBACK", 0))
+		conn.ExpectExec(`SELECT pg_advisory_unlock`).WillReturnResult(pgxmock.NewResult("SELECT", 1))
+
+		applied, err := m.Up(ctx, conn)
+		assert.ErrorContains(t, err, "migration 000002_add_rooms failed")
+		assert.Equal(t, 0, applied)
+		assert.NoError(t, conn.ExpectationsWereMet())
+	})
+
+	t.Run("refuses a dirty database", func(t *testing.T) {
+		conn := newMockConn(t)
+		conn.ExpectExec(`SELECT pg_advisory_lock`).WillReturnResult(pgxmock.NewResult("SELECT", 1))
+		conn.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations`).WillReturnResult(pgxmock.NewResult("CREATE", 0))
+		expectVersion(conn, 1, true)
+		conn.ExpectExec(`SELECT pg_advisory_unlock`).WillReturnResult(pgxmock.NewResult("SELECT", 1))
+
+		_, err := m.Up(ctx, conn)
+		assert.ErrorIs(t, err, ErrDirty)
+		assert.NoError(t, conn.ExpectationsWereMet())
+	})
+}
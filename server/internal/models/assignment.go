@@ -4,6 +4,14 @@ import (
 	"time"
 )
 
+// Late penalty policies. A flat penalty deducts the same percentage however
+// late the work is; a per-day penalty deducts it for each started day.
+const (
+	LatePenaltyNone   = "none"
+	LatePenaltyFlat   = "flat"
+	LatePenaltyPerDay = "per_day"
+)
+
 // Assignment represents an assignment given in a course.
 type Assignment struct {
 	ID          int       `db:"id" json:"id" validate:"omitempty,gte=0"`        // Primary Key
@@ -15,6 +23,12 @@ type Assignment struct {
 	MaxPoints   int       `db:"max_points" json:"max_points"`                   // Maximum points for the assignment
 	CreatedAt   time.Time `db:"created_at" json:"created_at"`                   // Timestamp of creation
 	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`                   // Timestamp of last update
+
+	// Late policy overrides; nil uses the server-wide default
+	LatePenaltyPolicy     *string    `db:"late_penalty_policy" json:"late_penalty_policy,omitempty" validate:"omitempty,oneof=none flat per_day"`
+	LatePenaltyPercent    *int       `db:"late_penalty_percent" json:"late_penalty_percent,omitempty" validate:"omitempty,min=0,max=100"`
+	LatePenaltyMaxPercent *int       `db:"late_penalty_max_percent" json:"late_penalty_max_percent,omitempty" validate:"omitempty,min=0,max=100"`
+	LateCutoffAt          *time.Time `db:"late_cutoff_at" json:"late_cutoff_at,omitempty"` // Submissions after this are rejected
 }
 
 type UpdateAssignmentRequest struct {
@@ -27,6 +41,11 @@ type UpdateAssignmentRequest struct {
 	MaxPoints   *int
 	CreatedAt   *time.Time
 	UpdatedAt   *time.Time
+
+	LatePenaltyPolicy     *string    `json:"late_penalty_policy"`
+	LatePenaltyPercent    *int       `json:"late_penalty_percent"`
+	LatePenaltyMaxPercent *int       `json:"late_penalty_max_percent"`
+	LateCutoffAt          *time.Time `json:"late_cutoff_at"`
 }
 
 // AssignmentSubmission represents a student's submission for an assignment.
@@ -41,4 +60,7 @@ type AssignmentSubmission struct {
 	Feedback       *string   `db:"feedback" json:"feedback,omitempty"`         // Feedback from instructor, nullable
 	CreatedAt      time.Time `db:"created_at" json:"created_at"`               // Timestamp of creation
 	UpdatedAt      time.Time `db:"updated_at" json:"updated_at"`               // Timestamp of last update
+	IsLate         bool      `db:"is_late" json:"is_late"`                     // Submitted after the due date
+	RawGrade       *int      `db:"raw_grade" json:"raw_grade,omitempty"`       // Grade before the late penalty, nullable
+	LatePenalty    *int      `db:"late_penalty" json:"late_penalty,omitempty"` // Points deducted for lateness, nullable
 }
@@ -21,6 +21,13 @@ type StudentAttendanceStatus struct {
 	Status    string `json:"status" validate:"required,oneof=Present Absent"` // Ensure status is either Present or Absent
 }
 
+// RosterAttendanceResult reports a roster entry that was not recorded and why.
+type RosterAttendanceResult struct {
+	StudentID int    `json:"student_id"`
+	Status    string `json:"status"`
+	Error     string `json:"error"`
+}
+
 // AttendanceCourseStats represents aggregated attendance data for a course.
 type AttendanceCourseStats struct {
 	CourseID       int     `json:"courseId"`
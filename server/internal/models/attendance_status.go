@@ -0,0 +1,41 @@
+package models
+
+import "strings"
+
+// Canonical attendance statuses as stored in attendance.status. Older rows and
+// external imports may use other casings, so compare with IsAttendancePresent
+// or AttendancePresentSQL rather than against these strings directly.
+const (
+	AttendancePresent = "Present"
+	AttendanceAbsent  = "Absent"
+	AttendanceLate    = "Late"
+	AttendanceExcused = "Excused"
+	AttendanceFreezed = "Freezed"
+)
+
+var canonicalAttendanceStatuses = map[string]string{
+	"present": AttendancePresent,
+	"absent":  AttendanceAbsent,
+	"late":    AttendanceLate,
+	"excused": AttendanceExcused,
+	"freezed": AttendanceFreezed,
+}
+
+// NormalizeAttendanceStatus maps any casing of a known status to its canonical
+// form. ok is false for unknown statuses.
+func NormalizeAttendanceStatus(status string) (string, bool) {
+	canonical, ok := canonicalAttendanceStatuses[strings.ToLower(strings.TrimSpace(status))]
+	return canonical, ok
+}
+
+// IsAttendancePresent reports whether status counts as present, ignoring case
+func IsAttendancePresent(status string) bool {
+	canonical, _ := NormalizeAttendanceStatus(status)
+	return canonical == AttendancePresent
+}
+
+// AttendancePresentSQL returns a SQL condition matching present rows of the
+// given status column, with the same case-insensitive rules as IsAttendancePresent
+func AttendancePresentSQL(column string) string {
+	return "LOWER(TRIM(" + column + ")) = 'present'"
+}
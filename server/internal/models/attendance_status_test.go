@@ -0,0 +1,27 @@
+package models
+
+import "testing"
+
+func TestAttendanceStatusNormalization(t *testing.T) {
+	for _, status := range []string{"Present", "present", "PRESENT", " pResent "} {
+		if !IsAttendancePresent(status) {
+			t.Fatalf("expected %q to count as present", status)
+		}
+		if canonical, ok := NormalizeAttendanceStatus(status); !ok || canonical != AttendancePresent {
+			t.Fatalf("expected %q to normalize to %q, got %q", status, AttendancePresent, canonical)
+		}
+	}
+
+	for _, status := range []string{"Absent", "LATE", "", "presentish"} {
+		if IsAttendancePresent(status) {
+			t.Fatalf("expected %q not to count as present", status)
+		}
+	}
+
+	if _, ok := NormalizeAttendanceStatus("unknown"); ok {
+		t.Fatal("expected unknown status to be rejected")
+	}
+	if got := AttendancePresentSQL("a.status"); got != "LOWER(TRIM(a.status)) = 'present'" {
+		t.Fatalf("unexpected SQL condition %q", got)
+	}
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// CourseSection is one of the groups a large course is taught in
+type CourseSection struct {
+	ID           int       `db:"id" json:"id"`
+	CollegeID    int       `db:"college_id" json:"college_id"`
+	CourseID     int       `db:"course_id" json:"course_id"`
+	Name         string    `db:"name" json:"name"`
+	StudentCount int       `db:"student_count" json:"student_count"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// CourseSectionRequest creates or renames a section
+type CourseSectionRequest struct {
+	Name string `json:"name" validate:"required,max=50"`
+}
+
+// AssignSectionRequest moves enrolled students into a section
+type AssignSectionRequest struct {
+	StudentIDs []int `json:"student_ids" validate:"required,min=1,dive,gte=1"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// EmailDeadLetter is a background email that failed on every retry. ResentAt
+// is set once an admin has resent it successfully.
+type EmailDeadLetter struct {
+	ID            int        `json:"id" db:"id"`
+	CollegeID     int        `json:"college_id" db:"college_id"`
+	Recipient     string     `json:"recipient" db:"recipient"`
+	Subject       string     `json:"subject" db:"subject"`
+	Body          string     `json:"body" db:"body"`
+	Attempts      int        `json:"attempts" db:"attempts"`
+	LastError     string     `json:"last_error" db:"last_error"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	LastAttemptAt time.Time  `json:"last_attempt_at" db:"last_attempt_at"`
+	ResentAt      *time.Time `json:"resent_at,omitempty" db:"resent_at"`
+}
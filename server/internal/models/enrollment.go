@@ -18,6 +18,7 @@ type Enrollment struct {
 	EnrollmentDate time.Time        `db:"enrollment_date" json:"enrollment_date"`
 	Status         EnrollmentStatus `db:"status" json:"status"` // Active, Completed, Dropped
 	Grade          string           `db:"grade" json:"grade,omitempty"`
+	SectionID      *int             `db:"section_id" json:"section_id,omitempty"` // nil until assigned to a section
 	CreatedAt      time.Time        `db:"created_at" json:"created_at"`
 	UpdatedAt      time.Time        `db:"updated_at" json:"updated_at"`
 
@@ -2,6 +2,15 @@ package models
 
 import "time"
 
+// Seating schemes decide how AllocateSeats numbers seats. Sequential numbers
+// them in enrollment order (S001, S002, ...); room-prefixed numbers each room
+// on its own (A-001, B-001, ...); roll-sorted numbers them in roll number order.
+const (
+	SeatingSequential   = "sequential"
+	SeatingRoomPrefixed = "room_prefixed"
+	SeatingRollSorted   = "roll_sorted"
+)
+
 // Exam represents a formal examination in the system
 type Exam struct {
 	ID          int       `db:"id" json:"id"`
@@ -15,16 +24,46 @@ type Exam struct {
 	Duration    int       `db:"duration" json:"duration"` // Duration in minutes
 	TotalMarks  float64   `db:"total_marks" json:"total_marks"`
 	PassingMarks float64  `db:"passing_marks" json:"passing_marks"`
+	PassingIsPercentage bool `db:"passing_is_percentage" json:"passing_is_percentage"` // PassingMarks is a percentage of TotalMarks rather than a mark
 	RoomID      *int      `db:"room_id" json:"room_id,omitempty"`
 	Status      string    `db:"status" json:"status"` // scheduled, ongoing, completed, cancelled
 	CreatedBy   int       `db:"created_by" json:"created_by"`
 	CreatedAt   time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+	Version     int       `db:"version" json:"version"` // Incremented on every update; updates must send the version they read
+	DeletedAt   *time.Time `db:"deleted_at" json:"deleted_at,omitempty"` // Set when the exam is soft-deleted
+	CancellationReason *string   `db:"cancellation_reason" json:"cancellation_reason,omitempty"` // Set when the exam is cancelled
+	CancelledAt        *time.Time `db:"cancelled_at" json:"cancelled_at,omitempty"`
 
 	// Metadata
 	Instructions       string            `db:"instructions" json:"instructions,omitempty"`
-	AllowedMaterials   string            `db:"allowed_materials" json:"allowed_materials,omitempty"`
+	AllowedMaterials   []string          `db:"allowed_materials" json:"allowed_materials"`     // Stored as a JSONB array
 	QuestionPaperSets  int               `db:"question_paper_sets" json:"question_paper_sets"` // Number of different question paper sets
+	SeatingScheme      string            `db:"seating_scheme" json:"seating_scheme"`           // sequential, room_prefixed or roll_sorted
+}
+
+// PassingThreshold is the mark a student needs to pass, converting a
+// percentage pass mark into marks out of TotalMarks
+func (e *Exam) PassingThreshold() float64 {
+	if e.PassingIsPercentage {
+		return e.PassingMarks * e.TotalMarks / 100
+	}
+	return e.PassingMarks
+}
+
+// Passes reports whether marks meet the exam's pass mark
+func (e *Exam) Passes(marks float64) bool {
+	return marks >= e.PassingThreshold()
+}
+
+// ExamWithDetails is an exam together with the course and room names needed
+// to render it without further lookups
+type ExamWithDetails struct {
+	Exam
+	CourseName   string  `db:"course_name" json:"course_name"`
+	RoomName     *string `db:"room_name" json:"room_name,omitempty"`
+	RoomNumber   *string `db:"room_number" json:"room_number,omitempty"`
+	RoomLocation *string `db:"room_location" json:"room_location,omitempty"`
 }
 
 // ExamEnrollment represents a student's enrollment in an exam
@@ -39,8 +78,139 @@ type ExamEnrollment struct {
 	QuestionPaperSet *int      `db:"question_paper_set" json:"question_paper_set,omitempty"`
 	Status          string     `db:"status" json:"status"` // enrolled, appeared, absent, disqualified
 	HallTicketGenerated bool   `db:"hall_ticket_generated" json:"hall_ticket_generated"`
+	AppearedAt      *time.Time `db:"appeared_at" json:"appeared_at,omitempty"` // Set when an invigilator checks the student in
+	ExtensionMinutes int       `db:"extension_minutes" json:"extension_minutes"` // Total ad-hoc extensions granted during the exam
+	Accommodations  ExamAccommodations `json:"accommodations"`
 	CreatedAt       time.Time  `db:"created_at" json:"created_at"`
 	UpdatedAt       time.Time  `db:"updated_at" json:"updated_at"`
+
+	// Clashes lists other exams of the student that overlap this one. It is
+	// filled in on enrollment as a warning and is not stored.
+	Clashes []*ExamClash `db:"-" json:"clashes,omitempty"`
+}
+
+// ExtraMinutes is the student's total extra time: accommodations plus any
+// extensions granted during the exam
+func (e *ExamEnrollment) ExtraMinutes() int {
+	return e.Accommodations.ExtraTimeMinutes + e.ExtensionMinutes
+}
+
+// EndTime is when the student's time for the exam runs out
+func (e *ExamEnrollment) EndTime(exam *Exam) time.Time {
+	return exam.EndTime.Add(time.Duration(e.ExtraMinutes()) * time.Minute)
+}
+
+// ExamTimeExtension is extra time an invigilator or admin granted a student
+// during an exam, for example after a technical issue
+type ExamTimeExtension struct {
+	ID           int       `db:"id" json:"id"`
+	CollegeID    int       `db:"college_id" json:"college_id"`
+	ExamID       int       `db:"exam_id" json:"exam_id"`
+	EnrollmentID int       `db:"enrollment_id" json:"enrollment_id"`
+	StudentID    int       `db:"student_id" json:"student_id"`
+	Minutes      int       `db:"extra_minutes" json:"minutes"`
+	Reason       string    `db:"reason" json:"reason"`
+	GrantedBy    int       `db:"granted_by" json:"granted_by"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+}
+
+// Proctoring event types a remote proctoring client can report. Every type is
+// a flag for faculty to review; none of them changes the student's result.
+const (
+	ProctoringTabSwitch       = "tab_switch"
+	ProctoringFaceNotDetected = "face_not_detected"
+	ProctoringDisconnect      = "disconnect"
+)
+
+// ExamProctoringEvent is something a proctoring client flagged during a
+// student's exam. OccurredAt is the client's clock; CreatedAt the server's.
+type ExamProctoringEvent struct {
+	ID           int64     `db:"id" json:"id"`
+	CollegeID    int       `db:"college_id" json:"college_id"`
+	ExamID       int       `db:"exam_id" json:"exam_id"`
+	EnrollmentID int       `db:"enrollment_id" json:"enrollment_id"`
+	StudentID    int       `db:"student_id" json:"student_id"`
+	EventType    string    `db:"event_type" json:"event_type"`
+	OccurredAt   time.Time `db:"occurred_at" json:"occurred_at"`
+	Metadata     JSONMap   `db:"metadata" json:"metadata,omitempty"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+}
+
+// StudentExamEnrollment is an enrollment together with the exam details a
+// student's exam list shows
+type StudentExamEnrollment struct {
+	ExamEnrollment
+	ExamTitle       string    `db:"exam_title" json:"exam_title"`
+	ExamType        string    `db:"exam_type" json:"exam_type"`
+	CourseID        int       `db:"course_id" json:"course_id"`
+	CourseName      string    `db:"course_name" json:"course_name"`
+	StartTime       time.Time `db:"start_time" json:"start_time"`
+	EndTime         time.Time `db:"end_time" json:"end_time"`
+	ExamStatus      string    `db:"exam_status" json:"exam_status"`
+	ResultPublished bool      `db:"result_published" json:"result_published"` // The student's result is out
+}
+
+// ExamAccommodations are the accessibility adjustments a student sits an
+// exam with. Only admins and faculty may set them.
+type ExamAccommodations struct {
+	ExtraTimeMinutes int    `db:"extra_time_minutes" json:"extra_time_minutes"`
+	SeparateRoom     bool   `db:"separate_room" json:"separate_room"`
+	Scribe           bool   `db:"scribe" json:"scribe"`
+	Notes            string `db:"accommodation_notes" json:"notes,omitempty"`
+}
+
+// Any reports whether the student has any accommodation
+func (a ExamAccommodations) Any() bool {
+	return a.ExtraTimeMinutes > 0 || a.SeparateRoom || a.Scribe || a.Notes != ""
+}
+
+// UpcomingExam is an exam a student is enrolled in that has not started yet,
+// together with their seating and hall ticket details
+type UpcomingExam struct {
+	ExamID              int       `db:"exam_id" json:"exam_id"`
+	CourseID            int       `db:"course_id" json:"course_id"`
+	Title               string    `db:"title" json:"title"`
+	ExamType            string    `db:"exam_type" json:"exam_type"`
+	StartTime           time.Time `db:"start_time" json:"start_time"`
+	EndTime             time.Time `db:"end_time" json:"end_time"`
+	Duration            int       `db:"duration" json:"duration"`
+	TotalMarks          float64   `db:"total_marks" json:"total_marks"`
+	Instructions        string    `db:"instructions" json:"instructions,omitempty"`
+	AllowedMaterials    []string  `db:"allowed_materials" json:"allowed_materials"`
+	EnrollmentID        int       `db:"enrollment_id" json:"enrollment_id"`
+	EnrollmentStatus    string    `db:"enrollment_status" json:"enrollment_status"`
+	SeatNumber          *string   `db:"seat_number" json:"seat_number,omitempty"`
+	RoomNumber          *string   `db:"room_number" json:"room_number,omitempty"`
+	HallTicketGenerated bool      `db:"hall_ticket_generated" json:"hall_ticket_generated"`
+	ExtraTimeMinutes    int       `db:"extra_time_minutes" json:"extra_time_minutes,omitempty"` // already included in EndTime
+}
+
+// TimetableCourse is a course to be placed in an exam timetable with the
+// students who would sit its exam
+type TimetableCourse struct {
+	CourseID   int    `db:"course_id" json:"course_id"`
+	Name       string `db:"name" json:"name"`
+	StudentIDs []int  `db:"student_ids" json:"student_ids"`
+}
+
+// ExamClash is a pair of exams a student is enrolled in whose times overlap
+type ExamClash struct {
+	StudentID         int       `db:"student_id" json:"student_id"`
+	ExamID            int       `db:"exam_id" json:"exam_id"`
+	ExamTitle         string    `db:"exam_title" json:"exam_title"`
+	StartTime         time.Time `db:"start_time" json:"start_time"`
+	EndTime           time.Time `db:"end_time" json:"end_time"`
+	ClashingExamID    int       `db:"clashing_exam_id" json:"clashing_exam_id"`
+	ClashingExamTitle string    `db:"clashing_exam_title" json:"clashing_exam_title"`
+	ClashingStartTime time.Time `db:"clashing_start_time" json:"clashing_start_time"`
+	ClashingEndTime   time.Time `db:"clashing_end_time" json:"clashing_end_time"`
+}
+
+// StudentExamWindow is the time a student is already sitting an exam
+type StudentExamWindow struct {
+	StudentID int       `db:"student_id" json:"student_id"`
+	StartTime time.Time `db:"start_time" json:"start_time"`
+	EndTime   time.Time `db:"end_time" json:"end_time"`
 }
 
 // ExamResult represents the result of a student's exam
@@ -57,10 +227,56 @@ type ExamResult struct {
 	EvaluatedBy       *int       `db:"evaluated_by" json:"evaluated_by,omitempty"`
 	EvaluatedAt       *time.Time `db:"evaluated_at" json:"evaluated_at,omitempty"`
 	RevaluationStatus string     `db:"revaluation_status" json:"revaluation_status"` // none, requested, in_progress, completed
+	Version           int        `db:"version" json:"version"`                       // Optimistic locking counter
+	Published         bool       `db:"published" json:"published"`                   // Visible to the student
+	PublishedAt       *time.Time `db:"published_at" json:"published_at,omitempty"`
 	CreatedAt         time.Time  `db:"created_at" json:"created_at"`
 	UpdatedAt         time.Time  `db:"updated_at" json:"updated_at"`
 }
 
+// ExamResultHistoryEntry is a version of an exam result that a later update
+// replaced. EntryHash chains it to the entry before it, PrevHash, so edits
+// to the history can be detected.
+type ExamResultHistoryEntry struct {
+	ID            int64      `db:"id" json:"id"`
+	ResultID      int        `db:"result_id" json:"result_id"`
+	CollegeID     int        `db:"college_id" json:"college_id"`
+	ExamID        int        `db:"exam_id" json:"exam_id"`
+	StudentID     int        `db:"student_id" json:"student_id"`
+	Version       int        `db:"version" json:"version"`
+	MarksObtained *float64   `db:"marks_obtained" json:"marks_obtained,omitempty"`
+	Grade         *string    `db:"grade" json:"grade,omitempty"`
+	Percentage    *float64   `db:"percentage" json:"percentage,omitempty"`
+	Result        string     `db:"result" json:"result"`
+	EvaluatedBy   *int       `db:"evaluated_by" json:"evaluated_by,omitempty"`
+	EvaluatedAt   *time.Time `db:"evaluated_at" json:"evaluated_at,omitempty"`
+	SupersededAt  time.Time  `db:"superseded_at" json:"superseded_at"` // when the update replaced this version
+	PrevHash      string     `db:"prev_hash" json:"prev_hash"`
+	EntryHash     string     `db:"entry_hash" json:"entry_hash"`
+}
+
+// ExamFilter narrows a list of exams. Nil fields apply no filter.
+// Soft-deleted exams are left out unless IncludeDeleted is set.
+type ExamFilter struct {
+	CourseID       *int
+	Status         *string
+	ExamType       *string
+	From           *time.Time // exams starting at or after From
+	To             *time.Time // exams starting before To
+	IncludeDeleted bool
+}
+
+// ExamResultFilter narrows and orders a student's results. Zero values apply
+// no filter; an empty SortBy keeps the newest results first.
+type ExamResultFilter struct {
+	CourseID  *int
+	Result    *string    // pass, fail, absent, pending
+	From      *time.Time // exams starting at or after From
+	To        *time.Time // exams starting before To
+	SortBy    string     // marks, percentage, created_at or published_at
+	SortOrder string     // asc or desc (default)
+}
+
 // RevaluationRequest represents a request for exam re-evaluation
 type RevaluationRequest struct {
 	ID              int        `db:"id" json:"id"`
@@ -75,10 +291,45 @@ type RevaluationRequest struct {
 	ReviewComments  string     `db:"review_comments" json:"review_comments,omitempty"`
 	RequestedAt     time.Time  `db:"requested_at" json:"requested_at"`
 	ReviewedAt      *time.Time `db:"reviewed_at" json:"reviewed_at,omitempty"`
+	FeeAssignmentID *int       `db:"fee_assignment_id" json:"fee_assignment_id,omitempty"` // Fee assignment the revaluation fee was paid against
+	FeeAmount       *float64   `db:"fee_amount" json:"fee_amount,omitempty"`               // Revaluation fee charged; nil when the college charges none
 	CreatedAt       time.Time  `db:"created_at" json:"created_at"`
 	UpdatedAt       time.Time  `db:"updated_at" json:"updated_at"`
 }
 
+// RevaluationRequestFilter narrows a list of revaluation requests. Nil fields
+// apply no filter.
+type RevaluationRequestFilter struct {
+	Status    *string
+	StudentID *int
+	ExamID    *int
+	From      *time.Time // requested at or after From
+	To        *time.Time // requested before To
+}
+
+// RevaluationRequestDetail is a revaluation request together with the exam,
+// student and current result it concerns, as shown on the review screen
+type RevaluationRequestDetail struct {
+	RevaluationRequest
+	ExamID       int      `db:"exam_id" json:"exam_id"`
+	ExamTitle    string   `db:"exam_title" json:"exam_title"`
+	StudentName  string   `db:"student_name" json:"student_name"`
+	RollNo       string   `db:"roll_no" json:"roll_no"`
+	CurrentMarks *float64 `db:"current_marks" json:"current_marks,omitempty"`
+}
+
+// SeatAssignment is the seat, room and paper set given to one exam enrollment
+type SeatAssignment struct {
+	EnrollmentID     int     `json:"enrollment_id"`
+	StudentID        int     `json:"student_id"`
+	SeatNumber       string  `json:"seat_number"`
+	RoomNumber       *string `json:"room_number,omitempty"`
+	QuestionPaperSet *int    `json:"question_paper_set,omitempty"`
+
+	// PreviousSeatNumber is the seat held before this plan; only set in previews
+	PreviousSeatNumber *string `json:"previous_seat_number,omitempty"`
+}
+
 // ExamRoom represents a physical room/hall for conducting exams
 type ExamRoom struct {
 	ID           int       `db:"id" json:"id"`
@@ -93,6 +344,65 @@ type ExamRoom struct {
 	UpdatedAt    time.Time `db:"updated_at" json:"updated_at"`
 }
 
+// RoomUtilization is how heavily an exam room was booked over a reporting
+// window. BookedHours only counts the part of each exam inside the window.
+type RoomUtilization struct {
+	RoomID             int     `db:"room_id" json:"room_id"`
+	RoomNumber         string  `db:"room_number" json:"room_number"`
+	RoomName           string  `db:"room_name" json:"room_name"`
+	ExamCount          int     `db:"exam_count" json:"exam_count"`
+	BookedHours        float64 `db:"booked_hours" json:"booked_hours"`
+	AvailableHours     float64 `json:"available_hours"`
+	UtilizationPercent float64 `json:"utilization_percent"`
+}
+
+// ExamQuestionPaper is the uploaded paper for one of an exam's question
+// paper sets. The object key stays server-side; students get a presigned
+// URL during the exam instead.
+type ExamQuestionPaper struct {
+	ID          int       `db:"id" json:"id"`
+	CollegeID   int       `db:"college_id" json:"college_id"`
+	ExamID      int       `db:"exam_id" json:"exam_id"`
+	PaperSet    int       `db:"paper_set" json:"paper_set"`
+	ObjectKey   string    `db:"object_key" json:"-"`
+	FileName    string    `db:"file_name" json:"file_name"`
+	ContentType string    `db:"content_type" json:"content_type"`
+	SizeBytes   int64     `db:"size_bytes" json:"size_bytes"`
+	UploadedBy  int       `db:"uploaded_by" json:"uploaded_by"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// ExamInvigilator is a staff member assigned to supervise an exam
+type ExamInvigilator struct {
+	ID         int       `db:"id" json:"id"`
+	ExamID     int       `db:"exam_id" json:"exam_id"`
+	CollegeID  int       `db:"college_id" json:"college_id"`
+	RoomID     *int      `db:"room_id" json:"room_id,omitempty"`
+	RoomNumber *string   `db:"room_number" json:"room_number,omitempty"`
+	UserID     int       `db:"user_id" json:"user_id"`
+	Name       string    `db:"name" json:"name"`
+	Email      string    `db:"email" json:"email"`
+	AssignedBy *int      `db:"assigned_by" json:"assigned_by,omitempty"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+}
+
+// InvigilatorConflict is another exam an invigilator already supervises
+// whose times overlap the one they are being assigned to
+type InvigilatorConflict struct {
+	ExamID    int       `db:"exam_id" json:"exam_id"`
+	Title     string    `db:"title" json:"title"`
+	StartTime time.Time `db:"start_time" json:"start_time"`
+	EndTime   time.Time `db:"end_time" json:"end_time"`
+}
+
+// InvigilatorCandidate is a staff member free to invigilate an exam
+type InvigilatorCandidate struct {
+	UserID int    `db:"user_id" json:"user_id"`
+	Name   string `db:"name" json:"name"`
+	Email  string `db:"email" json:"email"`
+}
+
 // DTO for creating/updating exams
 type CreateExamRequest struct {
 	CourseID           int       `json:"course_id" validate:"required"`
@@ -104,9 +414,12 @@ type CreateExamRequest struct {
 	Duration           int       `json:"duration" validate:"required,min=1"`
 	TotalMarks         float64   `json:"total_marks" validate:"required,min=0"`
 	PassingMarks       float64   `json:"passing_marks" validate:"required,min=0"`
+	PassingIsPercentage bool     `json:"passing_is_percentage"`                // PassingMarks is a percentage (0-100) rather than a mark
 	Instructions       string    `json:"instructions"`
-	AllowedMaterials   string    `json:"allowed_materials"`
-	QuestionPaperSets  int       `json:"question_paper_sets" validate:"min=1"`
+	AllowedMaterials   []string  `json:"allowed_materials"`                    // e.g. ["calculator", "formula sheet"]
+	QuestionPaperSets  int       `json:"question_paper_sets" validate:"min=1"` // Number of distinct papers, at least 1
+	SeatingScheme      string    `json:"seating_scheme" validate:"omitempty,oneof=sequential room_prefixed roll_sorted"` // Defaults to sequential
+	Backfill           bool      `json:"backfill"`                             // Admin only: record an exam that already took place
 }
 
 // DTO for exam result submission
@@ -116,6 +429,13 @@ type ExamResultRequest struct {
 	Remarks        string   `json:"remarks"`
 }
 
+// DTO for updating an existing exam result
+type UpdateExamResultRequest struct {
+	MarksObtained float64 `json:"marks_obtained" validate:"min=0"`
+	Remarks       string  `json:"remarks"`
+	Version       int     `json:"version" validate:"min=1"` // Version the client last read
+}
+
 // DTO for hall ticket generation
 type HallTicketResponse struct {
 	ExamID           int       `json:"exam_id"`
@@ -130,4 +450,41 @@ type HallTicketResponse struct {
 	RoomNumber       string    `json:"room_number"`
 	QuestionPaperSet int       `json:"question_paper_set"`
 	Instructions     string    `json:"instructions"`
+	ExtraTimeMinutes int       `json:"extra_time_minutes,omitempty"` // already included in EndTime
+	Accommodations   []string  `json:"accommodations,omitempty"`
+	Version          int       `json:"version"`      // only the latest version is valid
+	GeneratedAt      time.Time `json:"generated_at"` // when this version was first issued
+	// Locale is the language Labels and Accommodations are printed in; it
+	// never changes the ticket's version
+	Locale string            `json:"locale"`
+	Labels map[string]string `json:"labels"`
+}
+
+// HallTicketIssue is the latest version of a student's hall ticket. Version
+// is zero and GeneratedAt nil until a ticket is generated.
+type HallTicketIssue struct {
+	Version     int        `db:"hall_ticket_version" json:"version"`
+	GeneratedAt *time.Time `db:"hall_ticket_generated_at" json:"generated_at,omitempty"`
+}
+
+// HallTicketVerification tells an invigilator whether a presented hall
+// ticket version is the current one
+type HallTicketVerification struct {
+	ExamID         int        `json:"exam_id"`
+	StudentID      int        `json:"student_id"`
+	Version        int        `json:"version"`
+	CurrentVersion int        `json:"current_version"`
+	Valid          bool       `json:"valid"`
+	GeneratedAt    *time.Time `json:"generated_at,omitempty"`
+}
+
+// ResultNotificationRecipient is a student, or a parent of the student, to
+// email when the student's exam result is published
+type ResultNotificationRecipient struct {
+	StudentID   int    `json:"student_id"`
+	StudentName string `json:"student_name"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	IsParent    bool   `json:"is_parent"`
+	Locale      string `json:"locale"` // the recipient's language setting
 }
@@ -88,7 +88,7 @@ type CreateFeeStructureRequest struct {
 	Description  *string    `json:"description" validate:"omitempty,maxlen=1000"`
 	Amount       float64    `json:"amount" validate:"required,gt=0"`
 	Currency     string     `json:"currency" validate:"omitempty,len=3"`
-	FeeType      string     `json:"fee_type" validate:"required,oneof=tuition hostel exam library misc"`
+	FeeType      string     `json:"fee_type" validate:"required,oneof=tuition hostel exam library misc revaluation"`
 	Frequency    string     `json:"frequency" validate:"required,oneof=semester annual monthly one-time"`
 	AcademicYear *string    `json:"academic_year" validate:"omitempty,maxlen=20"`
 	Semester     *string    `json:"semester" validate:"omitempty,maxlen=20"`
@@ -102,7 +102,7 @@ type UpdateFeeStructureRequest struct {
 	Name         *string    `json:"name" validate:"omitempty,minlen=2,maxlen=200"`
 	Description  *string    `json:"description" validate:"omitempty,maxlen=1000"`
 	Amount       *float64   `json:"amount" validate:"omitempty,gt=0"`
-	FeeType      *string    `json:"fee_type" validate:"omitempty,oneof=tuition hostel exam library misc"`
+	FeeType      *string    `json:"fee_type" validate:"omitempty,oneof=tuition hostel exam library misc revaluation"`
 	Frequency    *string    `json:"frequency" validate:"omitempty,oneof=semester annual monthly one-time"`
 	AcademicYear *string    `json:"academic_year" validate:"omitempty,maxlen=20"`
 	Semester     *string    `json:"semester" validate:"omitempty,maxlen=20"`
@@ -197,3 +197,12 @@ type StudentFeesSummary struct {
 	PendingAssignments int     `json:"pending_assignments"`
 	OverdueAssignments int     `json:"overdue_assignments"`
 }
+
+// StudentFeeLedger is a student's charges and payment history together with
+// their outstanding balance
+type StudentFeeLedger struct {
+	StudentID   int                 `json:"student_id"`
+	Summary     *StudentFeesSummary `json:"summary"`
+	Assignments []*FeeAssignment    `json:"assignments"`
+	Payments    []*FeePayment       `json:"payments"`
+}
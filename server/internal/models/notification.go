@@ -3,12 +3,25 @@ package models
 import "time"
 
 type Notification struct {
-	ID        int       `json:"id" db:"id"`
-	UserID    int       `json:"user_id" db:"user_id"`
-	CollegeID int       `json:"college_id" db:"college_id"`
-	Title     string    `json:"title" db:"title"`
-	Message   string    `json:"message" db:"message"`
-	Type      string    `json:"type" db:"type"` // info, warning, success, error
-	IsRead    bool      `json:"is_read" db:"is_read"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID        int        `json:"id" db:"id"`
+	UserID    int        `json:"user_id" db:"user_id"`
+	CollegeID int        `json:"college_id" db:"college_id"`
+	Title     string     `json:"title" db:"title"`
+	Message   string     `json:"message" db:"message"`
+	Type      string     `json:"type" db:"type"` // info, warning, success, error
+	IsRead    bool       `json:"is_read" db:"is_read"`
+	ReadAt    *time.Time `json:"read_at,omitempty" db:"read_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+
+	// What the notification is about, so the client can link to it
+	RelatedEntityType *string `json:"related_entity_type,omitempty" db:"related_entity_type"`
+	RelatedEntityID   *int    `json:"related_entity_id,omitempty" db:"related_entity_id"`
 }
+
+// Entity types in-app notifications link to
+const (
+	NotificationEntityExam        = "exam"
+	NotificationEntityAssignment  = "assignment"
+	NotificationEntityRevaluation = "revaluation"
+	NotificationEntityQuiz        = "quiz"
+)
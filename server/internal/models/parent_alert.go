@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// LowAttendanceRecipient is a verified parent who should be told that a
+// linked student's recent attendance is below the alert threshold
+type LowAttendanceRecipient struct {
+	RelationshipID int     `json:"relationship_id" db:"relationship_id"`
+	ParentUserID   int     `json:"parent_user_id" db:"parent_user_id"`
+	ParentName     string  `json:"parent_name" db:"parent_name"`
+	ParentEmail    string  `json:"parent_email" db:"parent_email"`
+	StudentID      int     `json:"student_id" db:"student_id"`
+	StudentName    string  `json:"student_name" db:"student_name"`
+	RollNo         string  `json:"roll_no" db:"roll_no"`
+	CollegeID      int     `json:"college_id" db:"college_id"`
+	TotalSessions  int     `json:"total_sessions" db:"total_sessions"`
+	PresentCount   int     `json:"present_count" db:"present_count"`
+	AttendanceRate float64 `json:"attendance_rate" db:"attendance_rate"`
+}
+
+// ParentAttendanceAlert records a low-attendance email sent to a parent
+type ParentAttendanceAlert struct {
+	ID             int       `json:"id" db:"id"`
+	RelationshipID int       `json:"relationship_id" db:"relationship_id"`
+	ParentUserID   int       `json:"parent_user_id" db:"parent_user_id"`
+	StudentID      int       `json:"student_id" db:"student_id"`
+	CollegeID      int       `json:"college_id" db:"college_id"`
+	AttendanceRate float64   `json:"attendance_rate" db:"attendance_rate"`
+	Threshold      float64   `json:"threshold" db:"threshold"`
+	PeriodStart    time.Time `json:"period_start" db:"period_start"`
+	SentAt         time.Time `json:"sent_at" db:"sent_at"`
+}
+
+// LowAttendanceCriteria selects which students trigger a parent alert
+type LowAttendanceCriteria struct {
+	CollegeID int
+	// Threshold is the attendance percentage below which parents are alerted
+	Threshold float64
+	// WindowStart limits the rate to attendance recorded on or after this date
+	WindowStart time.Time
+	// MinSessions skips students with too few records for a meaningful rate
+	MinSessions int
+	// CooldownStart excludes relationships already alerted after this time
+	CooldownStart time.Time
+}
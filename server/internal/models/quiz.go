@@ -10,17 +10,37 @@ const (
 	ShortAnswer    QuizType = "short_answer"
 )
 
+// ScoringMode sets how auto-grading marks a multiple-choice question that has
+// more than one correct option.
+type ScoringMode string
+
+const (
+	// ScoringAllOrNothing awards the points only when exactly the correct options are selected
+	ScoringAllOrNothing ScoringMode = "all_or_nothing"
+	// ScoringPartial awards points in proportion to the correct options selected, less any wrong ones
+	ScoringPartial ScoringMode = "partial"
+)
+
+// Valid reports whether m is a known scoring mode. An empty mode is valid and
+// means all or nothing.
+func (m ScoringMode) Valid() bool {
+	return m == "" || m == ScoringAllOrNothing || m == ScoringPartial
+}
+
 // Quiz represents a quiz associated with a course.
 type Quiz struct {
-	ID               int       `db:"id" json:"id"`
-	CollegeID        int       `db:"college_id" json:"college_id"`
-	CourseID         int       `db:"course_id" json:"course_id"`
-	Title            string    `db:"title" json:"title"`
-	Description      string    `db:"description" json:"description"`
-	TimeLimitMinutes int       `db:"time_limit_minutes" json:"time_limit_minutes"` // 0 for no limit
-	DueDate          time.Time `db:"due_date" json:"due_date"`                     // Optional due date
-	CreatedAt        time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt        time.Time `db:"updated_at" json:"updated_at"`
+	ID               int        `db:"id" json:"id"`
+	CollegeID        int        `db:"college_id" json:"college_id"`
+	CourseID         int        `db:"course_id" json:"course_id"`
+	Title            string     `db:"title" json:"title"`
+	Description      string     `db:"description" json:"description"`
+	TimeLimitMinutes int        `db:"time_limit_minutes" json:"time_limit_minutes"`     // 0 for no limit
+	DueDate          time.Time  `db:"due_date" json:"due_date"`                         // Optional due date
+	AvailableFrom    *time.Time `db:"available_from" json:"available_from,omitempty"`   // Attempts may not start before this; nil for no limit
+	AvailableUntil   *time.Time `db:"available_until" json:"available_until,omitempty"` // Attempts may not start after this; nil for no limit
+	SectionID        *int       `db:"section_id" json:"section_id,omitempty"`           // Only this course section may attempt it; nil for the whole course
+	CreatedAt        time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt        time.Time  `db:"updated_at" json:"updated_at"`
 
 	// Relations - not stored in DB
 	Course    *Course     `db:"-" json:"course,omitempty"`
@@ -29,14 +49,15 @@ type Quiz struct {
 
 // Question represents a single question within a quiz.
 type Question struct {
-	ID            int       `db:"id" json:"id"`
-	QuizID        int       `db:"quiz_id" json:"quiz_id"`
-	Text          string    `db:"text" json:"text"`
-	Type          QuizType  `db:"type" json:"type"` // e.g., MultipleChoice, TrueFalse, ShortAnswer
-	Points        int       `db:"points" json:"points"`
-	CorrectAnswer *string   `db:"correct_answer" json:"correct_answer,omitempty"` // For ShortAnswer questions
-	CreatedAt     time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt     time.Time `db:"updated_at" json:"updated_at"`
+	ID            int         `db:"id" json:"id"`
+	QuizID        int         `db:"quiz_id" json:"quiz_id"`
+	Text          string      `db:"text" json:"text"`
+	Type          QuizType    `db:"type" json:"type"` // e.g., MultipleChoice, TrueFalse, ShortAnswer
+	Points        int         `db:"points" json:"points"`
+	CorrectAnswer *string     `db:"correct_answer" json:"correct_answer,omitempty"` // For ShortAnswer questions
+	ScoringMode   ScoringMode `db:"scoring_mode" json:"scoring_mode" validate:"omitempty,oneof=all_or_nothing partial"`
+	CreatedAt     time.Time   `db:"created_at" json:"created_at"`
+	UpdatedAt     time.Time   `db:"updated_at" json:"updated_at"`
 
 	// Relations - not stored in DB
 	Options []*AnswerOption `db:"-" json:"options,omitempty"` // For MultipleChoice/TrueFalse
@@ -49,6 +70,8 @@ const (
 	QuizAttemptStatusInProgress QuizAttemptStatus = "in_progress"
 	QuizAttemptStatusCompleted  QuizAttemptStatus = "submitted"
 	QuizAttemptStatusGraded     QuizAttemptStatus = "graded"
+	// QuizAttemptStatusExpired is an abandoned attempt closed without grading
+	QuizAttemptStatusExpired QuizAttemptStatus = "expired"
 )
 
 // AnswerOption represents a possible answer for a multiple-choice or true/false question.
@@ -81,17 +104,26 @@ type QuizAttempt struct {
 	Answers []*StudentAnswer `db:"-" json:"answers,omitempty"`
 }
 
+// ClosedQuizAttempt is an abandoned attempt closed by the cleanup job, with
+// what is needed to tell the student about it
+type ClosedQuizAttempt struct {
+	QuizAttempt
+	UserID    int    `db:"user_id" json:"user_id"`
+	QuizTitle string `db:"quiz_title" json:"quiz_title"`
+}
+
 // StudentAnswer represents a student's answer to a specific question in an attempt.
 type StudentAnswer struct {
-	ID               int       `db:"id" json:"id"`
-	QuizAttemptID    int       `db:"quiz_attempt_id" json:"quiz_attempt_id"`
-	QuestionID       int       `db:"question_id" json:"question_id"`
-	SelectedOptionID *[]int    `db:"selected_option_id" json:"selected_option_id"` // Nullable, for MC/TF
-	AnswerText       string    `db:"answer_text" json:"answer_text"`               // Nullable, for ShortAnswer
-	IsCorrect        *bool     `db:"is_correct" json:"is_correct"`                 // Nullable until graded
-	PointsAwarded    *int      `db:"points_awarded" json:"points_awarded"`         // Nullable until graded
-	CreatedAt        time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt        time.Time `db:"updated_at" json:"updated_at"`
+	ID               int        `db:"id" json:"id"`
+	QuizAttemptID    int        `db:"quiz_attempt_id" json:"quiz_attempt_id"`
+	QuestionID       int        `db:"question_id" json:"question_id"`
+	SelectedOptionID *[]int     `db:"selected_option_id" json:"selected_option_id"` // Nullable, for MC/TF
+	AnswerText       string     `db:"answer_text" json:"answer_text"`               // Nullable, for ShortAnswer
+	IsCorrect        *bool      `db:"is_correct" json:"is_correct"`                 // Nullable until graded
+	PointsAwarded    *int       `db:"points_awarded" json:"points_awarded"`         // Nullable until graded
+	LastSavedAt      *time.Time `db:"last_saved_at" json:"last_saved_at,omitempty"` // Server time of the latest save or autosave
+	CreatedAt        time.Time  `db:"created_at" json:"created_at"`
+	UpdatedAt        time.Time  `db:"updated_at" json:"updated_at"`
 }
 
 type QuestionWithCorrectAnswer struct {
@@ -127,6 +159,22 @@ type UpdateQuizRequest struct {
 	Description      *string    `json:"description" validate:"omitempty,max=500"`
 	TimeLimitMinutes *int       `json:"time_limit_minutes" validate:"omitempty,gte=0"`
 	DueDate          *time.Time `json:"due_date" validate:"omitempty"`
+	AvailableFrom    *time.Time `json:"available_from" validate:"omitempty"`
+	AvailableUntil   *time.Time `json:"available_until" validate:"omitempty"`
+	SectionID        *int       `json:"section_id" validate:"omitempty,gte=0"` // 0 opens the quiz to the whole course
+}
+
+// QuizExtension lets one student start a quiz after it has closed for everyone else.
+type QuizExtension struct {
+	ID             int       `db:"id" json:"id"`
+	QuizID         int       `db:"quiz_id" json:"quiz_id"`
+	StudentID      int       `db:"student_id" json:"student_id"`
+	CollegeID      int       `db:"college_id" json:"college_id"`
+	AvailableUntil time.Time `db:"available_until" json:"available_until"`
+	Reason         string    `db:"reason" json:"reason,omitempty"`
+	GrantedBy      *int      `db:"granted_by" json:"granted_by,omitempty"`
+	CreatedAt      time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at" json:"updated_at"`
 }
 
 // UpdateQuestionRequest provides fields for partial updates to Question via PATCH
@@ -0,0 +1,27 @@
+package models
+
+// Search result types
+const (
+	SearchTypeStudent = "student"
+	SearchTypeCourse  = "course"
+	SearchTypeExam    = "exam"
+)
+
+// SearchResult is a single match from the global search. Rank is 0 for an
+// exact match, 1 for a prefix match and 2 for a match elsewhere in the text.
+type SearchResult struct {
+	Type     string `db:"type" json:"type"`
+	ID       int    `db:"id" json:"id"`
+	Title    string `db:"title" json:"title"`
+	Subtitle string `db:"subtitle" json:"subtitle,omitempty"`
+	Rank     int    `db:"rank" json:"rank"`
+}
+
+// SearchScope limits what a search can see within a college. Zero IDs mean
+// no restriction of that kind.
+type SearchScope struct {
+	CollegeID       int
+	IncludeStudents bool
+	InstructorID    int // only students and exams from courses this user teaches
+	StudentID       int // only exams from courses this student is enrolled in
+}
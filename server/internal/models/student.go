@@ -9,6 +9,7 @@ type Student struct {
 	KratosIdentityID string    `db:"kratos_identity_id" json:"kratos_identity_id"`
 	EnrollmentYear   int       `db:"enrollment_year" json:"enrollment_year"`
 	RollNo           string    `db:"roll_no" json:"roll_no"`
+	Section          *string   `db:"section" json:"section,omitempty"`
 	IsActive         bool      `db:"is_active" json:"is_active"`
 	CreatedAt        time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt        time.Time `db:"updated_at" json:"updated_at"`
@@ -25,5 +26,25 @@ type UpdateStudentRequest struct {
 	CollegeID *int `json:"college_id" validate:"omitempty,gte=1"`
 	EnrollmentYear *int `json:"enrollment_year" validate:"omitempty,gte=1947"`
 	RollNo *string `json:"roll_no" validate:"omitempty,min=1,max=50"`
+	Section *string `json:"section" validate:"omitempty,max=50"`
 	IsActive *bool `json:"is_active" validate:"omitempty"`
-}
\ No newline at end of file
+}
+// Student status actions recorded in student_status_history
+const (
+	StudentStatusDeactivate = "deactivate"
+	StudentStatusReactivate = "reactivate"
+)
+
+// StudentStatusChange is one audited flip of Student.IsActive. PreviousIsActive
+// is what the flag was before the change, so every entry can be reversed.
+type StudentStatusChange struct {
+	ID               int       `db:"id" json:"id"`
+	StudentID        int       `db:"student_id" json:"student_id"`
+	CollegeID        int       `db:"college_id" json:"college_id"`
+	Action           string    `db:"action" json:"action"`
+	PreviousIsActive bool      `db:"previous_is_active" json:"previous_is_active"`
+	IsActive         bool      `db:"is_active" json:"is_active"`
+	Reason           string    `db:"reason" json:"reason"`
+	ChangedBy        int       `db:"changed_by" json:"changed_by"`
+	ChangedAt        time.Time `db:"changed_at" json:"changed_at"`
+}
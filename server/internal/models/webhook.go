@@ -16,3 +16,17 @@ type Webhook struct {
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
 }
+
+// WebhookDelivery records one attempt to deliver an event to a webhook
+type WebhookDelivery struct {
+	ID             int        `json:"id" db:"id"`
+	WebhookID      int        `json:"webhook_id" db:"webhook_id"`
+	EventType      string     `json:"event_type" db:"event_type"`
+	Payload        []byte     `json:"payload" db:"payload"`
+	AttemptNumber  int        `json:"attempt_number" db:"attempt_number"`
+	ResponseStatus *int       `json:"response_status,omitempty" db:"response_status"`
+	ResponseBody   *string    `json:"response_body,omitempty" db:"response_body"`
+	ErrorMessage   *string    `json:"error_message,omitempty" db:"error_message"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
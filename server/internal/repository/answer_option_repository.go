@@ -35,6 +35,10 @@ type AnswerOptionRepository interface {
 	// FindAnswerOptionsByQuestion retrieves all answer options for a specific question.
 	// Results are ordered by creation date (ascending).
 	FindAnswerOptionsByQuestion(ctx context.Context, questionID int) ([]*models.AnswerOption, error)
+
+	// FindAnswerOptionsByQuestions retrieves the answer options of several questions in one query.
+	// Results are ordered by question, then by creation date (ascending).
+	FindAnswerOptionsByQuestions(ctx context.Context, questionIDs []int) ([]*models.AnswerOption, error)
 }
 
 // answerOptionRepository implements the AnswerOptionRepository interface.
@@ -174,3 +178,25 @@ func (r *answerOptionRepository) FindAnswerOptionsByQuestion(ctx context.Context
 
 	return options, nil
 }
+
+// FindAnswerOptionsByQuestions retrieves the answer options of several questions in one query.
+// Like FindAnswerOptionsByQuestion it relies on the caller having already
+// checked the questions belong to the college.
+func (r *answerOptionRepository) FindAnswerOptionsByQuestions(ctx context.Context, questionIDs []int) ([]*models.AnswerOption, error) {
+	options := []*models.AnswerOption{}
+	if len(questionIDs) == 0 {
+		return options, nil
+	}
+
+	sql := `SELECT id, question_id, COALESCE(text, option_text) AS text, is_correct, created_at, updated_at
+			FROM answer_options
+			WHERE question_id = ANY($1)
+			ORDER BY question_id, created_at ASC`
+
+	err := pgxscan.Select(ctx, r.DB.Pool, &options, sql, questionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("FindAnswerOptionsByQuestions: failed to execute query: %w", err)
+	}
+
+	return options, nil
+}
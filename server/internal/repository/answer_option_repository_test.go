@@ -1,9 +1,13 @@
 package repository
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"eduhub/server/internal/models"
 )
@@ -35,4 +39,30 @@ func TestAnswerOptionModelValidation(t *testing.T) {
 	assert.Equal(t, 2, option.QuestionID)
 	assert.Equal(t, "Test Option", option.Text)
 	assert.True(t, option.IsCorrect)
-}
\ No newline at end of file
+}
+func TestFindAnswerOptionsByQuestions(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := NewAnswerOptionRepository(&DB{Pool: mock})
+	ctx := context.Background()
+	now := time.Now()
+
+	mock.ExpectQuery(`SELECT id, question_id, COALESCE\(text, option_text\) AS text, is_correct, created_at, updated_at FROM answer_options WHERE question_id = ANY\(\$1\)`).
+		WithArgs([]int{3, 4}).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "question_id", "text", "is_correct", "created_at", "updated_at"}).
+			AddRow(10, 3, "Paris", true, now, now).
+			AddRow(11, 4, "Berlin", false, now, now))
+
+	options, err := repo.FindAnswerOptionsByQuestions(ctx, []int{3, 4})
+	require.NoError(t, err)
+	require.Len(t, options, 2)
+	assert.Equal(t, 3, options[0].QuestionID)
+	assert.True(t, options[0].IsCorrect)
+	assert.Equal(t, "Berlin", options[1].Text)
+
+	options, err = repo.FindAnswerOptionsByQuestions(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, options, "no questions needs no query")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -32,6 +32,12 @@ type AssignmentRepository interface {
 	FindSubmissionsByAssignment(ctx context.Context, assignmentID int, limit, offset uint64) ([]*models.AssignmentSubmission, error)
 	FindSubmissionsByStudent(ctx context.Context, studentID int, limit, offset uint64) ([]*models.AssignmentSubmission, error)
 	CountPendingSubmissionsByCollege(ctx context.Context, collegeID int) (int, error)
+	IsStudentActivelyEnrolled(ctx context.Context, collegeID int, studentID int, courseID int) (bool, error)
+
+	// Due-soon reminders
+	ClaimAssignmentsDueBefore(ctx context.Context, until time.Time) ([]*models.Assignment, error)
+	ReleaseAssignmentReminder(ctx context.Context, assignmentID int) error
+	ListReminderUserIDs(ctx context.Context, assignment *models.Assignment) ([]int, error)
 }
 
 type assignmentRepository struct {
@@ -51,8 +57,9 @@ func (r *assignmentRepository) CreateAssignment(ctx context.Context, assignment
 	assignment.CreatedAt = now
 	assignment.UpdatedAt = now
 
-	sql := `INSERT INTO assignments (course_id, college_id, title, description, due_date, max_points, created_at, updated_at)
-			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	sql := `INSERT INTO assignments (course_id, college_id, title, description, due_date, max_points, created_at, updated_at,
+			 	late_penalty_policy, late_penalty_percent, late_penalty_max_percent, late_cutoff_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 			 RETURNING id`
 
 	temp := struct {
@@ -61,7 +68,8 @@ func (r *assignmentRepository) CreateAssignment(ctx context.Context, assignment
 
 	err := pgxscan.Get(ctx, r.DB.Pool, &temp, sql,
 		assignment.CourseID, assignment.CollegeID, assignment.Title, assignment.Description,
-		assignment.DueDate, assignment.MaxPoints, assignment.CreatedAt, assignment.UpdatedAt)
+		assignment.DueDate, assignment.MaxPoints, assignment.CreatedAt, assignment.UpdatedAt,
+		assignment.LatePenaltyPolicy, assignment.LatePenaltyPercent, assignment.LatePenaltyMaxPercent, assignment.LateCutoffAt)
 
 	if err != nil {
 		return fmt.Errorf("CreateAssignment: failed to execute query or scan ID: %w", err)
@@ -72,7 +80,8 @@ func (r *assignmentRepository) CreateAssignment(ctx context.Context, assignment
 
 func (r *assignmentRepository) GetAssignmentByID(ctx context.Context, collegeID int, assignmentID int) (*models.Assignment, error) {
 	assignment := &models.Assignment{}
-	sql := `SELECT id, course_id, college_id, title, description, due_date, max_points, created_at, updated_at
+	sql := `SELECT id, course_id, college_id, title, description, due_date, max_points, created_at, updated_at,
+			 	late_penalty_policy, late_penalty_percent, late_penalty_max_percent, late_cutoff_at
 			 FROM assignments
 			 WHERE id = $1 AND college_id = $2`
 
@@ -142,6 +151,26 @@ func (r *assignmentRepository) UpdateAssignmentPartial(ctx context.Context, coll
 		args = append(args, *req.MaxPoints)
 		argIndex++
 	}
+	if req.LatePenaltyPolicy != nil {
+		sql += fmt.Sprintf(`, late_penalty_policy = $%d`, argIndex)
+		args = append(args, *req.LatePenaltyPolicy)
+		argIndex++
+	}
+	if req.LatePenaltyPercent != nil {
+		sql += fmt.Sprintf(`, late_penalty_percent = $%d`, argIndex)
+		args = append(args, *req.LatePenaltyPercent)
+		argIndex++
+	}
+	if req.LatePenaltyMaxPercent != nil {
+		sql += fmt.Sprintf(`, late_penalty_max_percent = $%d`, argIndex)
+		args = append(args, *req.LatePenaltyMaxPercent)
+		argIndex++
+	}
+	if req.LateCutoffAt != nil {
+		sql += fmt.Sprintf(`, late_cutoff_at = $%d`, argIndex)
+		args = append(args, *req.LateCutoffAt)
+		argIndex++
+	}
 
 	if len(args) == 0 {
 		return fmt.Errorf("UpdateAssignmentPartial: no fields to update")
@@ -174,7 +203,8 @@ func (r *assignmentRepository) DeleteAssignment(ctx context.Context, collegeID i
 
 func (r *assignmentRepository) FindAssignmentsByCourse(ctx context.Context, collegeID int, courseID int, limit, offset uint64) ([]*models.Assignment, error) {
 	assignments := []*models.Assignment{}
-	sql := `SELECT id, course_id, college_id, title, description, due_date, max_points, created_at, updated_at
+	sql := `SELECT id, course_id, college_id, title, description, due_date, max_points, created_at, updated_at,
+			 	late_penalty_policy, late_penalty_percent, late_penalty_max_percent, late_cutoff_at
 			 FROM assignments
 			 WHERE college_id = $1 AND course_id = $2
 			 ORDER BY due_date ASC, created_at ASC
@@ -190,7 +220,8 @@ func (r *assignmentRepository) FindAssignmentsByCourse(ctx context.Context, coll
 func (r *assignmentRepository) FindAssignmentsByStudent(ctx context.Context, collegeID int, studentID int) ([]*models.Assignment, error) {
 	assignments := []*models.Assignment{}
 	// Join with enrollments to get assignments for courses the student is enrolled in
-	sql := `SELECT DISTINCT a.id, a.course_id, a.college_id, a.title, a.description, a.due_date, a.max_points, a.created_at, a.updated_at
+	sql := `SELECT DISTINCT a.id, a.course_id, a.college_id, a.title, a.description, a.due_date, a.max_points, a.created_at, a.updated_at,
+			 	a.late_penalty_policy, a.late_penalty_percent, a.late_penalty_max_percent, a.late_cutoff_at
 			 FROM assignments a
 			 JOIN enrollments e ON a.course_id = e.course_id
 			 WHERE a.college_id = $1
@@ -206,6 +237,19 @@ func (r *assignmentRepository) FindAssignmentsByStudent(ctx context.Context, col
 	return assignments, nil
 }
 
+// IsStudentActivelyEnrolled reports whether the student holds an active
+// enrollment in the course, the same rule FindAssignmentsByStudent applies
+func (r *assignmentRepository) IsStudentActivelyEnrolled(ctx context.Context, collegeID int, studentID int, courseID int) (bool, error) {
+	sql := `SELECT EXISTS (SELECT 1 FROM enrollments
+			WHERE college_id = $1 AND student_id = $2 AND course_id = $3 AND status = 'active')`
+
+	var enrolled bool
+	if err := r.DB.Pool.QueryRow(ctx, sql, collegeID, studentID, courseID).Scan(&enrolled); err != nil {
+		return false, fmt.Errorf("IsStudentActivelyEnrolled: failed to execute query: %w", err)
+	}
+	return enrolled, nil
+}
+
 func (r *assignmentRepository) CountAssignmentsByCourse(ctx context.Context, collegeID int, courseID int) (int, error) {
 	sql := `SELECT COUNT(*) FROM assignments WHERE college_id = $1 AND course_id = $2`
 	var count int
@@ -227,14 +271,15 @@ func (r *assignmentRepository) CreateSubmission(ctx context.Context, submission
 	}
 
 	// ON CONFLICT allows a student to re-submit, updating their submission.
-	sql := `INSERT INTO assignment_submissions (assignment_id, student_id, submission_time, content_text, file_path, created_at, updated_at)
-			 VALUES ($1, $2, $3, $4, $5, $6, $7)
+	sql := `INSERT INTO assignment_submissions (assignment_id, student_id, submission_time, content_text, file_path, created_at, updated_at, is_late)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 			 ON CONFLICT (assignment_id, student_id)
 			 DO UPDATE SET
 				 submission_time = EXCLUDED.submission_time,
 				 content_text = EXCLUDED.content_text,
 				 file_path = EXCLUDED.file_path,
-				 updated_at = EXCLUDED.updated_at
+				 updated_at = EXCLUDED.updated_at,
+				 is_late = EXCLUDED.is_late
 			 RETURNING id`
 
 	temp := struct {
@@ -243,7 +288,7 @@ func (r *assignmentRepository) CreateSubmission(ctx context.Context, submission
 
 	err := pgxscan.Get(ctx, r.DB.Pool, &temp, sql,
 		submission.AssignmentID, submission.StudentID, submission.SubmissionTime,
-		submission.ContentText, submission.FilePath, submission.CreatedAt, submission.UpdatedAt)
+		submission.ContentText, submission.FilePath, submission.CreatedAt, submission.UpdatedAt, submission.IsLate)
 
 	if err != nil {
 		return fmt.Errorf("CreateSubmission: failed to execute query or scan ID: %w", err)
@@ -254,7 +299,8 @@ func (r *assignmentRepository) CreateSubmission(ctx context.Context, submission
 
 func (r *assignmentRepository) GetSubmissionByID(ctx context.Context, submissionID int) (*models.AssignmentSubmission, error) {
 	submission := &models.AssignmentSubmission{}
-	sql := `SELECT id, assignment_id, student_id, submission_time, content_text, file_path, grade, feedback, created_at, updated_at
+	sql := `SELECT id, assignment_id, student_id, submission_time, content_text, file_path, grade, feedback, created_at, updated_at,
+			 	is_late, raw_grade, late_penalty
 			 FROM assignment_submissions
 			 WHERE id = $1`
 
@@ -270,7 +316,8 @@ func (r *assignmentRepository) GetSubmissionByID(ctx context.Context, submission
 
 func (r *assignmentRepository) GetSubmissionByStudentAndAssignment(ctx context.Context, studentID int, assignmentID int) (*models.AssignmentSubmission, error) {
 	submission := &models.AssignmentSubmission{}
-	sql := `SELECT id, assignment_id, student_id, submission_time, content_text, file_path, grade, feedback, created_at, updated_at
+	sql := `SELECT id, assignment_id, student_id, submission_time, content_text, file_path, grade, feedback, created_at, updated_at,
+			 	is_late, raw_grade, late_penalty
 			 FROM assignment_submissions
 			 WHERE student_id = $1 AND assignment_id = $2`
 
@@ -287,13 +334,17 @@ func (r *assignmentRepository) GetSubmissionByStudentAndAssignment(ctx context.C
 func (r *assignmentRepository) UpdateSubmission(ctx context.Context, submission *models.AssignmentSubmission) error {
 	submission.UpdatedAt = time.Now()
 
-	// This update is primarily for grading and feedback.
+	// This update is primarily for grading and feedback. graded_at keeps the
+	// time of the first grade so regrading does not reset grading turnaround.
 	sql := `UPDATE assignment_submissions
-			 SET grade = $1, feedback = $2, updated_at = $3
+			 SET grade = $1, feedback = $2, updated_at = $3,
+			     graded_at = CASE WHEN $1::int IS NULL THEN NULL ELSE COALESCE(graded_at, $3) END,
+			     is_late = $5, raw_grade = $6, late_penalty = $7
 			 WHERE id = $4`
 
 	cmdTag, err := r.DB.Pool.Exec(ctx, sql,
-		submission.Grade, submission.Feedback, submission.UpdatedAt, submission.ID)
+		submission.Grade, submission.Feedback, submission.UpdatedAt, submission.ID,
+		submission.IsLate, submission.RawGrade, submission.LatePenalty)
 
 	if err != nil {
 		return fmt.Errorf("UpdateSubmission: failed to execute query: %w", err)
@@ -306,7 +357,8 @@ func (r *assignmentRepository) UpdateSubmission(ctx context.Context, submission
 
 func (r *assignmentRepository) FindSubmissionsByAssignment(ctx context.Context, assignmentID int, limit, offset uint64) ([]*models.AssignmentSubmission, error) {
 	submissions := []*models.AssignmentSubmission{}
-	sql := `SELECT id, assignment_id, student_id, submission_time, content_text, file_path, grade, feedback, created_at, updated_at
+	sql := `SELECT id, assignment_id, student_id, submission_time, content_text, file_path, grade, feedback, created_at, updated_at,
+			 	is_late, raw_grade, late_penalty
 			 FROM assignment_submissions
 			 WHERE assignment_id = $1
 			 ORDER BY submission_time DESC
@@ -321,7 +373,8 @@ func (r *assignmentRepository) FindSubmissionsByAssignment(ctx context.Context,
 
 func (r *assignmentRepository) FindSubmissionsByStudent(ctx context.Context, studentID int, limit, offset uint64) ([]*models.AssignmentSubmission, error) {
 	submissions := []*models.AssignmentSubmission{}
-	sql := `SELECT id, assignment_id, student_id, submission_time, content_text, file_path, grade, feedback, created_at, updated_at
+	sql := `SELECT id, assignment_id, student_id, submission_time, content_text, file_path, grade, feedback, created_at, updated_at,
+			 	is_late, raw_grade, late_penalty
 			 FROM assignment_submissions
 			 WHERE student_id = $1
 			 ORDER BY submission_time DESC
@@ -347,3 +400,62 @@ func (r *assignmentRepository) CountPendingSubmissionsByCollege(ctx context.Cont
 	}
 	return count, nil
 }
+
+// --- Reminder Methods ---
+
+// ClaimAssignmentsDueBefore marks every not-yet-reminded assignment due between
+// now and until as reminded and returns them. Claiming in the UPDATE means two
+// overlapping runs never remind about the same assignment twice.
+func (r *assignmentRepository) ClaimAssignmentsDueBefore(ctx context.Context, until time.Time) ([]*models.Assignment, error) {
+	sql := `UPDATE assignments
+			SET due_reminder_sent_at = NOW()
+			WHERE due_reminder_sent_at IS NULL AND due_date > NOW() AND due_date <= $1
+			RETURNING id, course_id, college_id, title, COALESCE(description, '') AS description, due_date, max_points, created_at, updated_at`
+
+	assignments := []*models.Assignment{}
+	err := pgxscan.Select(ctx, r.DB.Pool, &assignments, sql, until)
+	if err != nil {
+		return nil, fmt.Errorf("ClaimAssignmentsDueBefore: failed to execute query: %w", err)
+	}
+	return assignments, nil
+}
+
+// ReleaseAssignmentReminder undoes ClaimAssignmentsDueBefore for one
+// assignment whose reminder could not be sent, so a later run picks it up again
+func (r *assignmentRepository) ReleaseAssignmentReminder(ctx context.Context, assignmentID int) error {
+	sql := `UPDATE assignments SET due_reminder_sent_at = NULL WHERE id = $1`
+	if _, err := r.DB.Pool.Exec(ctx, sql, assignmentID); err != nil {
+		return fmt.Errorf("ReleaseAssignmentReminder: failed to execute query: %w", err)
+	}
+	return nil
+}
+
+// ListReminderUserIDs returns the users of active students enrolled in the
+// assignment's course who haven't submitted yet and haven't turned assignment
+// reminders off.
+func (r *assignmentRepository) ListReminderUserIDs(ctx context.Context, assignment *models.Assignment) ([]int, error) {
+	sql := `SELECT s.user_id
+			FROM enrollments e
+			JOIN students s ON s.student_id = e.student_id AND s.is_active = TRUE
+			LEFT JOIN user_settings us ON us.user_id = s.user_id
+			LEFT JOIN assignment_submissions sub ON sub.assignment_id = $1 AND sub.student_id = e.student_id
+			WHERE e.course_id = $2 AND e.college_id = $3 AND e.status = 'active'
+			AND sub.id IS NULL
+			AND COALESCE(us.assignment_reminders, TRUE)`
+
+	rows, err := r.DB.Pool.Query(ctx, sql, assignment.ID, assignment.CourseID, assignment.CollegeID)
+	if err != nil {
+		return nil, fmt.Errorf("ListReminderUserIDs: failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	userIDs := make([]int, 0)
+	for rows.Next() {
+		var userID int
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("ListReminderUserIDs: failed to scan: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, rows.Err()
+}
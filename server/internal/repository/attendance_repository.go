@@ -2,10 +2,12 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 
 	"eduhub/server/internal/models"
@@ -15,12 +17,17 @@ type AttendanceRepository interface {
 	MarkAttendance(ctx context.Context, collegeID int, studentID int, courseID int, lectureID int) (bool, error)
 	UpdateAttendance(ctx context.Context, collegeID int, studentID int, courseID int, lectureID int, status string) error
 	SetAttendanceStatus(ctx context.Context, collegeID int, studentID, courseID int, lectureID int, status string) error
+	// set the status of several students for a course on one date in a single
+	// transaction, returning the entries left out because the student is not eligible
+	SetAttendanceForDate(ctx context.Context, collegeID int, courseID int, date time.Time, statuses []models.StudentAttendanceStatus) ([]models.RosterAttendanceResult, error)
 	FreezeAttendance(ctx context.Context, collegeID int, studentID int) error
 	UnFreezeAttendance(ctx context.Context, collegeID int, studentID int) error
 
 	// Get methods with pagination
 	// get attendnace by course of all students
 	GetAttendanceByCourse(ctx context.Context, collegeID int, courseID int, limit, offset uint64) ([]*models.Attendance, error)
+	// get attendance of the students in one section of a course
+	GetAttendanceBySection(ctx context.Context, collegeID int, courseID int, sectionID int, limit, offset uint64) ([]*models.Attendance, error)
 	// get attendnace of a particular student in a course
 	GetAttendanceStudentInCourse(ctx context.Context, collegeID int, studentID int, courseID int, limit, offset uint64) ([]*models.Attendance, error)
 	// get attendance of a student across all courses
@@ -28,6 +35,9 @@ type AttendanceRepository interface {
 	GetAttendanceByLecture(ctx context.Context, collegeID int, lectureID int, courseID int, limit, offset uint64) ([]*models.Attendance, error)
 }
 
+// ErrNoLectureOnDate is returned when a course has no lecture on the date attendance is being marked for
+var ErrNoLectureOnDate = errors.New("no lecture scheduled for the course on this date")
+
 type PoolExecutor interface {
 	pgxscan.Querier
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
@@ -64,6 +74,29 @@ LIMIT $3 OFFSET $4`
 	return attendances, nil
 }
 
+func (a *attendanceRepository) GetAttendanceBySection(
+	ctx context.Context,
+	collegeID int,
+	courseID int,
+	sectionID int,
+	limit, offset uint64,
+) ([]*models.Attendance, error) {
+	sql := `SELECT a.id, a.student_id, a.course_id, a.college_id, a.date, a.status, a.scanned_at, a.lecture_id
+FROM attendance a
+JOIN enrollments e ON e.college_id = a.college_id AND e.course_id = a.course_id AND e.student_id = a.student_id
+WHERE a.college_id = $1 AND a.course_id = $2 AND e.section_id = $3
+ORDER BY a.date DESC, a.student_id ASC
+LIMIT $4 OFFSET $5`
+
+	attendances := make([]*models.Attendance, 0)
+	err := pgxscan.Select(ctx, a.Pool, &attendances, sql, int32(collegeID), int32(courseID), int32(sectionID), int32(limit), int32(offset))
+	if err != nil {
+		return nil, fmt.Errorf("GetAttendanceBySection: failed to scan: %w", err)
+	}
+
+	return attendances, nil
+}
+
 func (a *attendanceRepository) MarkAttendance(ctx context.Context, collegeID int, studentID, courseID int, lectureID int) (bool, error) {
 	now := time.Now()
 	// Truncate date for the 'date' column if you only store the date part
@@ -84,7 +117,7 @@ func (a *attendanceRepository) MarkAttendance(ctx context.Context, collegeID int
 	RETURNING id, student_id, course_id, college_id, date, status, scanned_at, lecture_id`
 
 	var result models.Attendance
-	err := pgxscan.Get(ctx, a.Pool, &result, sql, int32(studentID), int32(courseID), int32(collegeID), int32(lectureID), attendanceDate, "Present", now)
+	err := pgxscan.Get(ctx, a.Pool, &result, sql, int32(studentID), int32(courseID), int32(collegeID), int32(lectureID), attendanceDate, models.AttendancePresent, now)
 	if err != nil {
 		return false, fmt.Errorf("MarkAttendance: failed to execute query: %w", err)
 	}
@@ -239,3 +272,119 @@ DO UPDATE SET status = EXCLUDED.status, scanned_at = EXCLUDED.scanned_at`
 
 	return nil
 }
+
+// SetAttendanceForDate records a roster against the course's first lecture on
+// date. Every row is written in one transaction, and the lecture is chosen the
+// same way each time, so re-submitting a roster updates the rows it wrote before.
+// Students are checked in the same transaction with one query; anyone not found,
+// inactive or not enrolled in the course is skipped and returned with the reason.
+func (a *attendanceRepository) SetAttendanceForDate(ctx context.Context, collegeID int, courseID int, date time.Time, statuses []models.StudentAttendanceStatus) ([]models.RosterAttendanceResult, error) {
+	beginner, ok := a.Pool.(BeginPool)
+	if !ok {
+		return nil, fmt.Errorf("SetAttendanceForDate: transaction support is required")
+	}
+
+	tx, err := beginner.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("SetAttendanceForDate: failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	attendanceDate := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	var lectureID int32
+	err = tx.QueryRow(ctx, `SELECT id FROM lectures
+WHERE college_id = $1 AND course_id = $2 AND start_time::date = $3
+ORDER BY start_time ASC, id ASC
+LIMIT 1`, int32(collegeID), int32(courseID), attendanceDate).Scan(&lectureID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNoLectureOnDate
+		}
+		return nil, fmt.Errorf("SetAttendanceForDate: failed to find lecture: %w", err)
+	}
+
+	studentIDs := make([]int32, 0, len(statuses))
+	for _, entry := range statuses {
+		studentIDs = append(studentIDs, int32(entry.StudentID))
+	}
+
+	// FOR SHARE keeps a student from being deactivated while the roster is written
+	rows, err := tx.Query(ctx, `SELECT s.student_id, s.is_active,
+    EXISTS(SELECT 1 FROM enrollments e
+           WHERE e.college_id = s.college_id AND e.student_id = s.student_id AND e.course_id = $2) AS enrolled
+FROM students s
+WHERE s.college_id = $1 AND s.student_id = ANY($3)
+FOR SHARE OF s`, int32(collegeID), int32(courseID), studentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("SetAttendanceForDate: failed to verify roster: %w", err)
+	}
+	type rosterState struct {
+		active   bool
+		enrolled bool
+	}
+	states := make(map[int]rosterState, len(statuses))
+	for rows.Next() {
+		var (
+			studentID int32
+			state     rosterState
+		)
+		if err := rows.Scan(&studentID, &state.active, &state.enrolled); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("SetAttendanceForDate: failed to scan roster: %w", err)
+		}
+		states[int(studentID)] = state
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("SetAttendanceForDate: failed to verify roster: %w", err)
+	}
+
+	sql := `INSERT INTO attendance (
+    student_id,
+    course_id,
+    college_id,
+    lecture_id,
+    date,
+    status,
+    scanned_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) ON CONFLICT (student_id, course_id, lecture_id, date, college_id)
+DO UPDATE SET status = EXCLUDED.status, scanned_at = EXCLUDED.scanned_at`
+
+	rejected := make([]models.RosterAttendanceResult, 0)
+	now := time.Now()
+	for _, entry := range statuses {
+		state, found := states[entry.StudentID]
+		reason := ""
+		switch {
+		case !found:
+			reason = "student not found"
+		case !state.active:
+			reason = "student is not active"
+		case !state.enrolled:
+			reason = fmt.Sprintf("student not enrolled in course %d", courseID)
+		}
+		if reason != "" {
+			rejected = append(rejected, models.RosterAttendanceResult{
+				StudentID: entry.StudentID,
+				Status:    entry.Status,
+				Error:     reason,
+			})
+			continue
+		}
+
+		_, err := tx.Exec(ctx, sql, int32(entry.StudentID), int32(courseID), int32(collegeID), lectureID, attendanceDate, entry.Status, now)
+		if err != nil {
+			return nil, fmt.Errorf("SetAttendanceForDate: failed to set attendance for student %d: %w", entry.StudentID, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("SetAttendanceForDate: failed to commit: %w", err)
+	}
+	return rejected, nil
+}
@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	"eduhub/server/internal/models"
+
 	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -51,6 +53,28 @@ func TestGetAttendanceByCourse(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestGetAttendanceBySection(t *testing.T) {
+	mock, repo, ctx := setupAttendanceTest(t)
+	defer mock.Close()
+
+	rows := pgxmock.NewRows([]string{
+		"id", "student_id", "course_id", "college_id", "date", "status", "scanned_at", "lecture_id",
+	}).
+		AddRow(1, 101, 2, 1, time.Now(), "Present", time.Now(), 10)
+
+	mock.ExpectQuery(`FROM attendance a JOIN enrollments e ON .* WHERE a.college_id = \$1 AND a.course_id = \$2 AND e.section_id = \$3`).
+		WithArgs(int32(1), int32(2), int32(7), int32(10), int32(0)).
+		WillReturnRows(rows)
+
+	attendances, err := repo.GetAttendanceBySection(ctx, 1, 2, 7, 10, 0)
+
+	require.NoError(t, err)
+	assert.Len(t, attendances, 1)
+	assert.Equal(t, 101, attendances[0].StudentID)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestGetAttendanceByCourse_Error(t *testing.T) {
 	mock, repo, ctx := setupAttendanceTest(t)
 	defer mock.Close()
@@ -352,3 +376,58 @@ func TestFreezeAttendance_Error(t *testing.T) {
 	// Ensure all expectations were met
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestSetAttendanceForDate(t *testing.T) {
+	mock, repo, ctx := setupAttendanceTest(t)
+	defer mock.Close()
+
+	date := time.Date(2026, 3, 9, 15, 30, 0, 0, time.UTC)
+	day := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id FROM lectures\s+WHERE college_id = \$1 AND course_id = \$2 AND start_time::date = \$3\s+ORDER BY start_time ASC, id ASC`).
+		WithArgs(int32(1), int32(2), day).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int32(40)))
+	mock.ExpectQuery(`SELECT s.student_id, s.is_active,.*FROM students s\s+WHERE s.college_id = \$1 AND s.student_id = ANY\(\$3\)\s+FOR SHARE OF s`).
+		WithArgs(int32(1), int32(2), []int32{101, 102, 103}).
+		WillReturnRows(pgxmock.NewRows([]string{"student_id", "is_active", "enrolled"}).
+			AddRow(int32(101), true, true).
+			AddRow(int32(102), true, true).
+			AddRow(int32(103), true, false))
+	mock.ExpectExec(`INSERT INTO attendance`).
+		WithArgs(int32(101), int32(2), int32(1), int32(40), day, "Present", pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(`INSERT INTO attendance`).
+		WithArgs(int32(102), int32(2), int32(1), int32(40), day, "Absent", pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	rejected, err := repo.SetAttendanceForDate(ctx, 1, 2, date, []models.StudentAttendanceStatus{
+		{StudentID: 101, Status: "Present"},
+		{StudentID: 102, Status: "Absent"},
+		{StudentID: 103, Status: "Present"},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, rejected, 1)
+	assert.Equal(t, 103, rejected[0].StudentID)
+	assert.Equal(t, "student not enrolled in course 2", rejected[0].Error)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSetAttendanceForDate_NoLecture(t *testing.T) {
+	mock, repo, ctx := setupAttendanceTest(t)
+	defer mock.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id FROM lectures`).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	_, err := repo.SetAttendanceForDate(ctx, 1, 2, time.Now(), []models.StudentAttendanceStatus{
+		{StudentID: 101, Status: "Present"},
+	})
+
+	assert.ErrorIs(t, err, ErrNoLectureOnDate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
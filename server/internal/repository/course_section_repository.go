@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"eduhub/server/internal/models"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrSectionNotFound is returned when a section does not exist in the course
+var ErrSectionNotFound = errors.New("section not found")
+
+// ErrSectionNameTaken is returned when the course already has a section of
+// that name
+var ErrSectionNameTaken = errors.New("course already has a section with this name")
+
+// ErrStudentNotInSection is returned by RemoveStudent when the student is not
+// in the section
+var ErrStudentNotInSection = errors.New("student is not in this section")
+
+// CourseSectionRepository stores the sections of a course and which section
+// each enrolled student is in
+type CourseSectionRepository interface {
+	CreateSection(ctx context.Context, section *models.CourseSection) error
+	GetSection(ctx context.Context, collegeID, courseID, sectionID int) (*models.CourseSection, error)
+	ListSections(ctx context.Context, collegeID, courseID int) ([]*models.CourseSection, error)
+	RenameSection(ctx context.Context, collegeID, courseID, sectionID int, name string) (*models.CourseSection, error)
+	// DeleteSection removes a section. Its students and quizzes fall back to
+	// the whole course.
+	DeleteSection(ctx context.Context, collegeID, courseID, sectionID int) error
+	// AssignStudents moves the students' enrollments in the course into the
+	// section and returns the students that were enrolled
+	AssignStudents(ctx context.Context, collegeID, courseID, sectionID int, studentIDs []int) ([]int, error)
+	// RemoveStudent takes a student out of the section, back to the course
+	RemoveStudent(ctx context.Context, collegeID, courseID, sectionID, studentID int) error
+}
+
+type courseSectionRepository struct {
+	DB *DB
+}
+
+func NewCourseSectionRepository(db *DB) CourseSectionRepository {
+	return &courseSectionRepository{DB: db}
+}
+
+const courseSectionSelect = `
+	SELECT cs.id, cs.college_id, cs.course_id, cs.name,
+		(SELECT COUNT(*) FROM enrollments e WHERE e.section_id = cs.id) AS student_count,
+		cs.created_at, cs.updated_at
+	FROM course_sections cs`
+
+func (r *courseSectionRepository) CreateSection(ctx context.Context, section *models.CourseSection) error {
+	err := r.DB.Pool.QueryRow(ctx, `INSERT INTO course_sections (college_id, course_id, name)
+		VALUES ($1, $2, $3) RETURNING id, created_at, updated_at`,
+		section.CollegeID, section.CourseID, section.Name).Scan(&section.ID, &section.CreatedAt, &section.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrSectionNameTaken
+		}
+		return fmt.Errorf("CreateSection: failed to execute query: %w", err)
+	}
+	return nil
+}
+
+func (r *courseSectionRepository) GetSection(ctx context.Context, collegeID, courseID, sectionID int) (*models.CourseSection, error) {
+	section := &models.CourseSection{}
+	err := pgxscan.Get(ctx, r.DB.Pool, section, courseSectionSelect+`
+		WHERE cs.id = $1 AND cs.course_id = $2 AND cs.college_id = $3`, sectionID, courseID, collegeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSectionNotFound
+		}
+		return nil, fmt.Errorf("GetSection: failed to execute query: %w", err)
+	}
+	return section, nil
+}
+
+func (r *courseSectionRepository) ListSections(ctx context.Context, collegeID, courseID int) ([]*models.CourseSection, error) {
+	sections := []*models.CourseSection{}
+	err := pgxscan.Select(ctx, r.DB.Pool, &sections, courseSectionSelect+`
+		WHERE cs.course_id = $1 AND cs.college_id = $2 ORDER BY cs.name`, courseID, collegeID)
+	if err != nil {
+		return nil, fmt.Errorf("ListSections: failed to execute query: %w", err)
+	}
+	return sections, nil
+}
+
+func (r *courseSectionRepository) RenameSection(ctx context.Context, collegeID, courseID, sectionID int, name string) (*models.CourseSection, error) {
+	tag, err := r.DB.Pool.Exec(ctx, `UPDATE course_sections SET name = $1, updated_at = NOW()
+		WHERE id = $2 AND course_id = $3 AND college_id = $4`, name, sectionID, courseID, collegeID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, ErrSectionNameTaken
+		}
+		return nil, fmt.Errorf("RenameSection: failed to execute query: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, ErrSectionNotFound
+	}
+	return r.GetSection(ctx, collegeID, courseID, sectionID)
+}
+
+func (r *courseSectionRepository) DeleteSection(ctx context.Context, collegeID, courseID, sectionID int) error {
+	var deleted int
+	err := r.DB.Pool.QueryRow(ctx, `
+		WITH target AS (
+			SELECT id FROM course_sections WHERE id = $1 AND course_id = $2 AND college_id = $3
+		), students AS (
+			UPDATE enrollments SET section_id = NULL, updated_at = NOW()
+			WHERE section_id IN (SELECT id FROM target)
+		), quizzes AS (
+			UPDATE quizzes SET section_id = NULL, updated_at = NOW()
+			WHERE section_id IN (SELECT id FROM target)
+		), removed AS (
+			DELETE FROM course_sections WHERE id IN (SELECT id FROM target) RETURNING id
+		)
+		SELECT COUNT(*) FROM removed`, sectionID, courseID, collegeID).Scan(&deleted)
+	if err != nil {
+		return fmt.Errorf("DeleteSection: failed to execute query: %w", err)
+	}
+	if deleted == 0 {
+		return ErrSectionNotFound
+	}
+	return nil
+}
+
+func (r *courseSectionRepository) AssignStudents(ctx context.Context, collegeID, courseID, sectionID int, studentIDs []int) ([]int, error) {
+	rows, err := r.DB.Pool.Query(ctx, `UPDATE enrollments SET section_id = $1, updated_at = NOW()
+		WHERE college_id = $2 AND course_id = $3 AND student_id = ANY($4)
+		RETURNING student_id`, sectionID, collegeID, courseID, studentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("AssignStudents: failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	assigned := make([]int, 0, len(studentIDs))
+	for rows.Next() {
+		var studentID int
+		if err := rows.Scan(&studentID); err != nil {
+			return nil, fmt.Errorf("AssignStudents: failed to scan student: %w", err)
+		}
+		assigned = append(assigned, studentID)
+	}
+	if err := rows.Err(); err != nil {
+		if isSectionFKViolation(err) {
+			return nil, ErrSectionNotFound
+		}
+		return nil, fmt.Errorf("AssignStudents: failed to execute query: %w", err)
+	}
+	return assigned, nil
+}
+
+func (r *courseSectionRepository) RemoveStudent(ctx context.Context, collegeID, courseID, sectionID, studentID int) error {
+	tag, err := r.DB.Pool.Exec(ctx, `UPDATE enrollments SET section_id = NULL, updated_at = NOW()
+		WHERE college_id = $1 AND course_id = $2 AND section_id = $3 AND student_id = $4`,
+		collegeID, courseID, sectionID, studentID)
+	if err != nil {
+		return fmt.Errorf("RemoveStudent: failed to execute query: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrStudentNotInSection
+	}
+	return nil
+}
+
+// isUniqueViolation reports whether err is a unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// isSectionFKViolation reports whether err is a reference to a section that
+// is not part of the row's course
+func isSectionFKViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503" &&
+		(pgErr.ConstraintName == "quizzes_section_fk" || pgErr.ConstraintName == "enrollments_section_fk")
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"eduhub/server/internal/models"
+)
+
+func TestCourseSectionRepository_CreateSectionNameTaken(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := NewCourseSectionRepository(&DB{Pool: mock})
+
+	mock.ExpectQuery(`INSERT INTO course_sections`).
+		WithArgs(1, 4, "A").
+		WillReturnError(&pgconn.PgError{Code: "23505"})
+
+	err = repo.CreateSection(context.Background(), &models.CourseSection{CollegeID: 1, CourseID: 4, Name: "A"})
+	assert.ErrorIs(t, err, ErrSectionNameTaken)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCourseSectionRepository_DeleteSection(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := NewCourseSectionRepository(&DB{Pool: mock})
+	ctx := context.Background()
+
+	mock.ExpectQuery(`(?s)UPDATE enrollments SET section_id = NULL.*UPDATE quizzes SET section_id = NULL.*DELETE FROM course_sections`).
+		WithArgs(9, 4, 1).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(1))
+	require.NoError(t, repo.DeleteSection(ctx, 1, 4, 9))
+
+	mock.ExpectQuery(`DELETE FROM course_sections`).
+		WithArgs(9, 4, 2).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
+	assert.ErrorIs(t, repo.DeleteSection(ctx, 2, 4, 9), ErrSectionNotFound)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCourseSectionRepository_AssignStudents(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := NewCourseSectionRepository(&DB{Pool: mock})
+	mock.ExpectQuery(`UPDATE enrollments SET section_id = \$1, updated_at = NOW\(\) WHERE college_id = \$2 AND course_id = \$3 AND student_id = ANY\(\$4\)`).
+		WithArgs(9, 1, 4, []int{5, 6, 7}).
+		WillReturnRows(pgxmock.NewRows([]string{"student_id"}).AddRow(5).AddRow(7))
+
+	assigned, err := repo.AssignStudents(context.Background(), 1, 4, 9, []int{5, 6, 7})
+	require.NoError(t, err)
+	assert.Equal(t, []int{5, 7}, assigned)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
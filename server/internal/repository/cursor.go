@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// keysetCursor is the position after which the next keyset page starts: the
+// ordering timestamp and id of the last row the client received. Clients only
+// ever see it as an opaque token.
+type keysetCursor struct {
+	At time.Time `json:"at"`
+	ID int       `json:"id"`
+}
+
+func encodeCursor(at time.Time, id int) string {
+	raw, _ := json.Marshal(keysetCursor{At: at, ID: id})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCursor parses a token from encodeCursor. An empty token is the first
+// page and decodes to nil.
+func decodeCursor(token string) (*keysetCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor keysetCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.ID <= 0 || cursor.At.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/georgysavva/scany/v2/pgxscan"
 	"github.com/jackc/pgx/v5"
@@ -37,6 +38,28 @@ func NewDB(pool *pgxpool.Pool) *DB {
 	}
 }
 
+// Ping verifies database connectivity with a lightweight SELECT 1 round trip
+func (db *DB) Ping(ctx context.Context) error {
+	if db == nil || db.Pool == nil {
+		return errors.New("database pool not initialized")
+	}
+	_, err := db.Pool.Exec(ctx, "SELECT 1")
+	return err
+}
+
+// Stat returns connection pool statistics when the underlying pool supports them.
+// Mocks used in tests do not, in which case ok is false.
+func (db *DB) Stat() (stat *pgxpool.Stat, ok bool) {
+	if db == nil || db.Pool == nil {
+		return nil, false
+	}
+	statter, ok := db.Pool.(interface{ Stat() *pgxpool.Stat })
+	if !ok {
+		return nil, false
+	}
+	return statter.Stat(), true
+}
+
 // Close calls the Close method on the pool
 func (db *DB) Close() {
 	if db.Pool != nil {
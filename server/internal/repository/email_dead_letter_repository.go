@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"eduhub/server/internal/models"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrEmailDeadLetterNotFound is returned when a dead letter does not exist in
+// the college
+var ErrEmailDeadLetterNotFound = errors.New("email dead letter not found")
+
+// ErrEmailDeadLetterResent is returned by ClaimDeadLetter when the letter has
+// already been resent or another resend of it is in progress
+var ErrEmailDeadLetterResent = errors.New("email has already been resent")
+
+type EmailDeadLetterRepository interface {
+	CreateDeadLetter(ctx context.Context, letter *models.EmailDeadLetter) error
+	// ListDeadLetters returns a college's dead letters, newest first. Resent
+	// letters are left out unless includeResent is set.
+	ListDeadLetters(ctx context.Context, collegeID int, includeResent bool, limit, offset int) ([]*models.EmailDeadLetter, error)
+	GetDeadLetter(ctx context.Context, collegeID, letterID int) (*models.EmailDeadLetter, error)
+	// ClaimDeadLetter marks a letter resent before it is sent again, so two
+	// concurrent resends cannot both go out
+	ClaimDeadLetter(ctx context.Context, collegeID, letterID int) (*models.EmailDeadLetter, error)
+	// RecordDeadLetterAttempt counts a manual resend of a claimed letter. A nil
+	// sendErr keeps it marked resent; otherwise the claim is cleared and its
+	// last error replaced.
+	RecordDeadLetterAttempt(ctx context.Context, collegeID, letterID int, sendErr error) error
+}
+
+type emailDeadLetterRepository struct {
+	DB *DB
+}
+
+func NewEmailDeadLetterRepository(db *DB) EmailDeadLetterRepository {
+	return &emailDeadLetterRepository{DB: db}
+}
+
+const emailDeadLetterColumns = `id, college_id, recipient, subject, body, attempts, last_error, created_at, last_attempt_at, resent_at`
+
+func (r *emailDeadLetterRepository) CreateDeadLetter(ctx context.Context, letter *models.EmailDeadLetter) error {
+	sql := `INSERT INTO email_dead_letters (college_id, recipient, subject, body, attempts, last_error)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id, created_at, last_attempt_at`
+
+	err := r.DB.Pool.QueryRow(ctx, sql,
+		letter.CollegeID,
+		letter.Recipient,
+		letter.Subject,
+		letter.Body,
+		letter.Attempts,
+		letter.LastError,
+	).Scan(&letter.ID, &letter.CreatedAt, &letter.LastAttemptAt)
+	if err != nil {
+		return fmt.Errorf("CreateDeadLetter: %w", err)
+	}
+	return nil
+}
+
+func (r *emailDeadLetterRepository) ListDeadLetters(ctx context.Context, collegeID int, includeResent bool, limit, offset int) ([]*models.EmailDeadLetter, error) {
+	sql := `SELECT ` + emailDeadLetterColumns + `
+			FROM email_dead_letters
+			WHERE college_id = $1 AND ($2 OR resent_at IS NULL)
+			ORDER BY created_at DESC, id DESC
+			LIMIT $3 OFFSET $4`
+
+	letters := []*models.EmailDeadLetter{}
+	if err := pgxscan.Select(ctx, r.DB.Pool, &letters, sql, collegeID, includeResent, limit, offset); err != nil {
+		return nil, fmt.Errorf("ListDeadLetters: %w", err)
+	}
+	return letters, nil
+}
+
+func (r *emailDeadLetterRepository) GetDeadLetter(ctx context.Context, collegeID, letterID int) (*models.EmailDeadLetter, error) {
+	sql := `SELECT ` + emailDeadLetterColumns + `
+			FROM email_dead_letters
+			WHERE id = $1 AND college_id = $2`
+
+	letter := &models.EmailDeadLetter{}
+	if err := pgxscan.Get(ctx, r.DB.Pool, letter, sql, letterID, collegeID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEmailDeadLetterNotFound
+		}
+		return nil, fmt.Errorf("GetDeadLetter: %w", err)
+	}
+	return letter, nil
+}
+
+func (r *emailDeadLetterRepository) ClaimDeadLetter(ctx context.Context, collegeID, letterID int) (*models.EmailDeadLetter, error) {
+	sql := `UPDATE email_dead_letters
+			SET resent_at = NOW()
+			WHERE id = $1 AND college_id = $2 AND resent_at IS NULL
+			RETURNING ` + emailDeadLetterColumns
+
+	letter := &models.EmailDeadLetter{}
+	err := pgxscan.Get(ctx, r.DB.Pool, letter, sql, letterID, collegeID)
+	if err == nil {
+		return letter, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("ClaimDeadLetter: %w", err)
+	}
+	// Nothing was claimed: tell a missing letter from one already resent
+	if _, err := r.GetDeadLetter(ctx, collegeID, letterID); err != nil {
+		return nil, err
+	}
+	return nil, ErrEmailDeadLetterResent
+}
+
+func (r *emailDeadLetterRepository) RecordDeadLetterAttempt(ctx context.Context, collegeID, letterID int, sendErr error) error {
+	sql := `UPDATE email_dead_letters
+			SET attempts = attempts + 1,
+			    last_attempt_at = NOW(),
+			    resent_at = CASE WHEN $3 THEN resent_at ELSE NULL END,
+			    last_error = CASE WHEN $3 THEN last_error ELSE $4 END
+			WHERE id = $1 AND college_id = $2`
+
+	var lastError string
+	if sendErr != nil {
+		lastError = sendErr.Error()
+	}
+	tag, err := r.DB.Pool.Exec(ctx, sql, letterID, collegeID, sendErr == nil, lastError)
+	if err != nil {
+		return fmt.Errorf("RecordDeadLetterAttempt: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrEmailDeadLetterNotFound
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailDeadLetterRepository_RecordDeadLetterAttempt(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := NewEmailDeadLetterRepository(&DB{Pool: mock})
+	ctx := context.Background()
+
+	mock.ExpectExec(`UPDATE email_dead_letters`).
+		WithArgs(5, 1, true, "").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	require.NoError(t, repo.RecordDeadLetterAttempt(ctx, 1, 5, nil))
+
+	mock.ExpectExec(`UPDATE email_dead_letters`).
+		WithArgs(5, 1, false, "smtp unavailable").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	require.NoError(t, repo.RecordDeadLetterAttempt(ctx, 1, 5, errors.New("smtp unavailable")))
+
+	// A letter of another college is not touched
+	mock.ExpectExec(`UPDATE email_dead_letters`).
+		WithArgs(5, 2, true, "").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	assert.ErrorIs(t, repo.RecordDeadLetterAttempt(ctx, 2, 5, nil), ErrEmailDeadLetterNotFound)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEmailDeadLetterRepository_ClaimDeadLetter(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := NewEmailDeadLetterRepository(&DB{Pool: mock})
+	ctx := context.Background()
+	columns := []string{"id", "college_id", "recipient", "subject", "body", "attempts", "last_error", "created_at", "last_attempt_at", "resent_at"}
+	now := time.Now()
+
+	mock.ExpectQuery(`UPDATE email_dead_letters\s+SET resent_at = NOW\(\)\s+WHERE id = \$1 AND college_id = \$2 AND resent_at IS NULL\s+RETURNING`).
+		WithArgs(5, 1).
+		WillReturnRows(pgxmock.NewRows(columns).AddRow(5, 1, "asha@example.com", "Hello", "<p>Hi</p>", 4, "smtp unavailable", now, now, &now))
+	letter, err := repo.ClaimDeadLetter(ctx, 1, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "asha@example.com", letter.Recipient)
+
+	// Already claimed: the letter exists, so it was resent
+	mock.ExpectQuery(`UPDATE email_dead_letters`).WithArgs(5, 1).WillReturnRows(pgxmock.NewRows(columns))
+	mock.ExpectQuery(`FROM email_dead_letters\s+WHERE id = \$1 AND college_id = \$2`).
+		WithArgs(5, 1).
+		WillReturnRows(pgxmock.NewRows(columns).AddRow(5, 1, "asha@example.com", "Hello", "<p>Hi</p>", 4, "", now, now, &now))
+	_, err = repo.ClaimDeadLetter(ctx, 1, 5)
+	assert.ErrorIs(t, err, ErrEmailDeadLetterResent)
+
+	// Missing letter
+	mock.ExpectQuery(`UPDATE email_dead_letters`).WithArgs(6, 1).WillReturnRows(pgxmock.NewRows(columns))
+	mock.ExpectQuery(`FROM email_dead_letters`).WithArgs(6, 1).WillReturnRows(pgxmock.NewRows(columns))
+	_, err = repo.ClaimDeadLetter(ctx, 1, 6)
+	assert.ErrorIs(t, err, ErrEmailDeadLetterNotFound)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
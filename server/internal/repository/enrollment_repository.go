@@ -91,7 +91,7 @@ func (e *enrollmentRepository) IsStudentEnrolled(ctx context.Context, collegeID
 
 // GetEnrollmentByID retrieves a specific enrollment by its ID, scoped by collegeID.
 func (e *enrollmentRepository) GetEnrollmentByID(ctx context.Context, collegeID int, enrollmentID int) (*models.Enrollment, error) {
-	sql := `SELECT id, student_id, course_id, college_id, enrollment_date, status, grade, created_at, updated_at FROM enrollments WHERE id = $1 AND college_id = $2`
+	sql := `SELECT id, student_id, course_id, college_id, enrollment_date, status, grade, section_id, created_at, updated_at FROM enrollments WHERE id = $1 AND college_id = $2`
 	args := []any{enrollmentID, collegeID}
 
 	enrollment := &models.Enrollment{}
@@ -110,7 +110,7 @@ func (e *enrollmentRepository) GetEnrollmentByID(ctx context.Context, collegeID
 
 // FindEnrollmentsByStudent retrieves all enrollment records for a specific student in a college.
 func (e *enrollmentRepository) FindEnrollmentsByStudent(ctx context.Context, collegeID int, studentID int, limit, offset uint64) ([]*models.Enrollment, error) {
-	sql := `SELECT id, student_id, course_id, college_id, enrollment_date, status, grade, created_at, updated_at FROM enrollments WHERE college_id = $1 AND student_id = $2 ORDER BY enrollment_date DESC, course_id ASC LIMIT $3 OFFSET $4`
+	sql := `SELECT id, student_id, course_id, college_id, enrollment_date, status, grade, section_id, created_at, updated_at FROM enrollments WHERE college_id = $1 AND student_id = $2 ORDER BY enrollment_date DESC, course_id ASC LIMIT $3 OFFSET $4`
 	args := []any{collegeID, studentID, limit, offset}
 
 	enrollments := []*models.Enrollment{}
@@ -126,7 +126,7 @@ func (e *enrollmentRepository) FindEnrollmentsByStudent(ctx context.Context, col
 
 // FindEnrollmentsByCourse retrieves all enrollment records for a specific course in a college with pagination.
 func (e *enrollmentRepository) FindEnrollmentsByCourse(ctx context.Context, collegeID int, courseID int, limit, offset uint64) ([]*models.Enrollment, error) {
-	sql := `SELECT id, student_id, course_id, college_id, enrollment_date, status, grade, created_at, updated_at FROM enrollments WHERE college_id = $1 AND course_id = $2 ORDER BY student_id ASC, enrollment_date DESC LIMIT $3 OFFSET $4`
+	sql := `SELECT id, student_id, course_id, college_id, enrollment_date, status, grade, section_id, created_at, updated_at FROM enrollments WHERE college_id = $1 AND course_id = $2 ORDER BY student_id ASC, enrollment_date DESC LIMIT $3 OFFSET $4`
 	args := []any{collegeID, courseID, limit, offset}
 
 	enrollments := []*models.Enrollment{}
@@ -140,7 +140,7 @@ func (e *enrollmentRepository) FindEnrollmentsByCourse(ctx context.Context, coll
 
 // FindEnrollmentsByCollege retrieves all enrollment records for a specific college with pagination.
 func (e *enrollmentRepository) FindEnrollmentsByCollege(ctx context.Context, collegeID int, limit, offset uint64) ([]*models.Enrollment, error) {
-	sql := `SELECT id, student_id, course_id, college_id, enrollment_date, status, grade, created_at, updated_at FROM enrollments WHERE college_id = $1 ORDER BY course_id ASC, student_id ASC, enrollment_date DESC LIMIT $2 OFFSET $3`
+	sql := `SELECT id, student_id, course_id, college_id, enrollment_date, status, grade, section_id, created_at, updated_at FROM enrollments WHERE college_id = $1 ORDER BY course_id ASC, student_id ASC, enrollment_date DESC LIMIT $2 OFFSET $3`
 	args := []any{collegeID, limit, offset}
 
 	enrollments := []*models.Enrollment{}
@@ -2,43 +2,141 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"eduhub/server/internal/models"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// ErrVersionConflict is returned by versioned updates when the row changed after the caller read it
+var ErrVersionConflict = errors.New("record was modified by someone else, reload and try again")
+
+// ErrInvigilatorAssigned is returned by AssignInvigilator when the user already supervises the exam
+var ErrInvigilatorAssigned = errors.New("invigilator is already assigned to this exam")
+
+// ErrEnrollmentNotFound is returned when the student is not enrolled in the exam
+var ErrEnrollmentNotFound = errors.New("enrollment not found")
+
+// ErrExamNotFound is returned when the exam does not exist in the college or
+// has been deleted
+var ErrExamNotFound = errors.New("exam not found")
+
+// ErrExamRoomNotFound is returned when the exam room does not exist in the college
+var ErrExamRoomNotFound = errors.New("room not found")
+
+// ErrAlreadyAppeared is returned by MarkAppeared when the student was already
+// checked in to the exam
+var ErrAlreadyAppeared = errors.New("student is already marked as appeared")
+
+// ErrEnrollmentDisqualified is returned by MarkAppeared for a student who was
+// disqualified from the exam
+var ErrEnrollmentDisqualified = errors.New("student is disqualified from this exam")
+
+// ErrQuestionPaperNotFound is returned by GetQuestionPaper when no paper was
+// uploaded for the set
+var ErrQuestionPaperNotFound = errors.New("question paper not found")
+
+// ErrExtensionCapExceeded is returned by GrantExtension when the grant would
+// take the student's extensions past the cap
+var ErrExtensionCapExceeded = errors.New("extension exceeds the maximum extra time for this exam")
+
+// ErrExamResultNotFound is returned by GetResult when the student has no result
+// for the exam, and by GetResultByID when there is no such result
+var ErrExamResultNotFound = errors.New("result not found")
+
+// ErrSeatAllocationInProgress is returned by AssignSeats while another
+// allocation for the same exam is still running
+var ErrSeatAllocationInProgress = errors.New("seat allocation is already in progress for this exam")
+
+// ErrSeatPlanStale is returned by AssignSeats when the exam's enrollments no
+// longer match the ones the seats were planned for
+var ErrSeatPlanStale = errors.New("exam enrollments changed since the seats were planned")
+
+// ErrExamNotCancellable is returned by CancelExam when the exam is already
+// cancelled or completed
+var ErrExamNotCancellable = errors.New("exam is already cancelled or completed")
+
+// ErrRoomBooked is returned by CreateExams when another exam took one of the
+// rooms for an overlapping time before the transaction could
+var ErrRoomBooked = errors.New("room is already booked for that time")
+
+// ErrInvalidResultSort is returned when a result listing asks for a sort
+// field or direction outside examResultSortColumns
+var ErrInvalidResultSort = errors.New("invalid result sort")
+
+// examResultSortColumns maps the sort fields clients may request to columns.
+// Only these are ever interpolated into ORDER BY.
+var examResultSortColumns = map[string]string{
+	"marks":        "marks_obtained",
+	"percentage":   "percentage",
+	"created_at":   "created_at",
+	"published_at": "published_at",
+}
+
 type ExamRepository interface {
 	// Exam CRUD
 	CreateExam(ctx context.Context, exam *models.Exam) error
+	CreateExams(ctx context.Context, exams []*models.Exam) error
+	ListStudentExamWindows(ctx context.Context, collegeID int, studentIDs []int, from, to time.Time) ([]*models.StudentExamWindow, error)
 	GetExamByID(ctx context.Context, collegeID, examID int) (*models.Exam, error)
-	ListExams(ctx context.Context, collegeID int, filters map[string]any, limit, offset int) ([]*models.Exam, error)
+	GetExamWithDetails(ctx context.Context, collegeID, examID int) (*models.ExamWithDetails, error)
+	ListExams(ctx context.Context, collegeID int, filter models.ExamFilter, limit, offset int) ([]*models.Exam, error)
+	ListExamsAfter(ctx context.Context, collegeID int, filter models.ExamFilter, cursor string, limit int) ([]*models.Exam, string, error)
 	UpdateExam(ctx context.Context, exam *models.Exam) error
 	DeleteExam(ctx context.Context, collegeID, examID int) error
+	RestoreExam(ctx context.Context, collegeID, examID int) error
+	CancelExam(ctx context.Context, exam *models.Exam, reason string) error
 	ListExamsByCourse(ctx context.Context, collegeID, courseID int, limit, offset int) ([]*models.Exam, error)
+	ListTimetableCourses(ctx context.Context, collegeID int, courseIDs []int) ([]*models.TimetableCourse, error)
 
 	// Exam Enrollment
 	EnrollStudent(ctx context.Context, enrollment *models.ExamEnrollment) error
+	EnrollCourseStudents(ctx context.Context, collegeID, examID int) ([]*models.ExamEnrollment, int, error)
+	MarkAppeared(ctx context.Context, collegeID, examID int, rollNo string) (*models.ExamEnrollment, error)
 	GetEnrollment(ctx context.Context, examID, studentID int) (*models.ExamEnrollment, error)
 	ListEnrollments(ctx context.Context, examID int) ([]*models.ExamEnrollment, error)
+	ListEnrollmentRollNos(ctx context.Context, collegeID, examID int) (map[int]string, error)
+	GetStudentLanguage(ctx context.Context, collegeID, studentID int) (string, error)
 	UpdateEnrollment(ctx context.Context, enrollment *models.ExamEnrollment) error
 	DeleteEnrollment(ctx context.Context, examID, studentID int) error
+	UpdateAccommodations(ctx context.Context, collegeID, examID, studentID int, accommodations models.ExamAccommodations) error
+	IssueHallTicket(ctx context.Context, enrollmentID int, fingerprint string) (*models.HallTicketIssue, error)
+	GetHallTicketIssue(ctx context.Context, collegeID, examID, studentID int) (*models.HallTicketIssue, error)
+	AssignSeats(ctx context.Context, collegeID, examID int, seats []*models.SeatAssignment) error
 	GetStudentEnrollments(ctx context.Context, studentID, collegeID int) ([]*models.ExamEnrollment, error)
+	CountStudentEnrollments(ctx context.Context, studentID, collegeID int) (int, error)
+	GetStudentExamEnrollments(ctx context.Context, studentID, collegeID int) ([]*models.StudentExamEnrollment, error)
+	ListExamClashes(ctx context.Context, collegeID int, studentID *int) ([]*models.ExamClash, error)
+	ListUpcomingExams(ctx context.Context, studentID, collegeID int, after time.Time) ([]*models.UpcomingExam, error)
 
 	// Exam Results
 	CreateResult(ctx context.Context, result *models.ExamResult) error
 	GetResult(ctx context.Context, examID, studentID int) (*models.ExamResult, error)
 	GetResultByID(ctx context.Context, resultID int) (*models.ExamResult, error)
+	ListResultHistory(ctx context.Context, collegeID, resultID int) ([]*models.ExamResultHistoryEntry, error)
 	ListResults(ctx context.Context, examID int) ([]*models.ExamResult, error)
+	ListResultSections(ctx context.Context, examID int) (map[int]string, error)
 	UpdateResult(ctx context.Context, result *models.ExamResult) error
-	GetStudentResults(ctx context.Context, studentID, collegeID int) ([]*models.ExamResult, error)
+	SaveResults(ctx context.Context, results []*models.ExamResult, allOrNothing bool) (map[int]error, error)
+	GetStudentResults(ctx context.Context, studentID, collegeID int, publishedOnly bool, filter models.ExamResultFilter) ([]*models.ExamResult, error)
+	GetStudentResultsAfter(ctx context.Context, studentID, collegeID int, publishedOnly bool, filter models.ExamResultFilter, cursor string, limit int) ([]*models.ExamResult, string, error)
+	CountStudentResults(ctx context.Context, studentID, collegeID int, publishedOnly bool) (int, error)
+	PublishResults(ctx context.Context, examID int) ([]int, error)
+	ListResultNotificationRecipients(ctx context.Context, collegeID int, studentIDs []int, includeParents bool) ([]*models.ResultNotificationRecipient, error)
+	ListResultInboxUserIDs(ctx context.Context, collegeID int, studentIDs []int, includeParents bool) ([]int, error)
 
 	// Revaluation Requests
 	CreateRevaluationRequest(ctx context.Context, request *models.RevaluationRequest) error
 	GetRevaluationRequest(ctx context.Context, requestID int) (*models.RevaluationRequest, error)
-	ListRevaluationRequests(ctx context.Context, collegeID int, filters map[string]any) ([]*models.RevaluationRequest, error)
+	ListRevaluationRequests(ctx context.Context, collegeID int, filter models.RevaluationRequestFilter) ([]*models.RevaluationRequest, error)
+	ListRevaluationRequestDetails(ctx context.Context, collegeID int, filter models.RevaluationRequestFilter) ([]*models.RevaluationRequestDetail, error)
 	UpdateRevaluationRequest(ctx context.Context, request *models.RevaluationRequest) error
 
 	// Exam Rooms
@@ -48,6 +146,27 @@ type ExamRepository interface {
 	UpdateRoom(ctx context.Context, room *models.ExamRoom) error
 	DeleteRoom(ctx context.Context, collegeID, roomID int) error
 	CheckRoomAvailability(ctx context.Context, roomID int, startTime, endTime string) (bool, error)
+	ListRoomBookings(ctx context.Context, collegeID int, from, to time.Time) ([]*models.RoomUtilization, error)
+
+	// Time Extensions
+	GrantExtension(ctx context.Context, extension *models.ExamTimeExtension, maxTotalMinutes int) (int, error)
+	ListExtensions(ctx context.Context, collegeID, examID int) ([]*models.ExamTimeExtension, error)
+
+	// Proctoring
+	RecordProctoringEvent(ctx context.Context, event *models.ExamProctoringEvent) error
+	ListProctoringEvents(ctx context.Context, collegeID, examID, studentID int) ([]*models.ExamProctoringEvent, error)
+
+	// Question Papers
+	SaveQuestionPaper(ctx context.Context, paper *models.ExamQuestionPaper) (string, error)
+	GetQuestionPaper(ctx context.Context, collegeID, examID, paperSet int) (*models.ExamQuestionPaper, error)
+	ListQuestionPapers(ctx context.Context, collegeID, examID int) ([]*models.ExamQuestionPaper, error)
+
+	// Invigilators
+	AssignInvigilator(ctx context.Context, invigilator *models.ExamInvigilator) error
+	ListInvigilators(ctx context.Context, collegeID, examID int) ([]*models.ExamInvigilator, error)
+	RemoveInvigilator(ctx context.Context, collegeID, examID, userID int) error
+	ListInvigilatorConflicts(ctx context.Context, userID, examID int, startTime, endTime time.Time) ([]*models.InvigilatorConflict, error)
+	ListAvailableInvigilators(ctx context.Context, collegeID int, startTime, endTime time.Time) ([]*models.InvigilatorCandidate, error)
 }
 
 type examRepository struct {
@@ -60,70 +179,230 @@ func NewExamRepository(db *DB) ExamRepository {
 
 // CreateExam creates a new exam
 func (r *examRepository) CreateExam(ctx context.Context, exam *models.Exam) error {
+	return insertExam(ctx, r.db.Pool, exam)
+}
+
+// CreateExams inserts several exams in one transaction; either all are
+// created or none are. The rooms are locked and re-checked inside the
+// transaction, so a booking made since the caller looked fails the whole
+// batch with ErrRoomBooked instead of double-booking the room.
+func (r *examRepository) CreateExams(ctx context.Context, exams []*models.Exam) error {
+	beginner, ok := r.db.Pool.(BeginPool)
+	if !ok {
+		return fmt.Errorf("transaction support is required")
+	}
+
+	tx, err := beginner.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	// Lock in ID order so two batches sharing rooms cannot deadlock
+	roomIDs := make([]int, 0, len(exams))
+	for _, exam := range exams {
+		if exam.RoomID != nil {
+			roomIDs = append(roomIDs, *exam.RoomID)
+		}
+	}
+	slices.Sort(roomIDs)
+	for _, roomID := range slices.Compact(roomIDs) {
+		if _, err := tx.Exec(ctx, `SELECT id FROM exam_rooms WHERE id = $1 FOR UPDATE`, roomID); err != nil {
+			return fmt.Errorf("failed to lock room %d: %w", roomID, err)
+		}
+	}
+
+	for _, exam := range exams {
+		if exam.RoomID != nil {
+			var count int
+			err := tx.QueryRow(ctx, roomBookingsSQL, *exam.RoomID, exam.StartTime, exam.EndTime).Scan(&count)
+			if err != nil {
+				return fmt.Errorf("failed to check room %d: %w", *exam.RoomID, err)
+			}
+			if count > 0 {
+				return fmt.Errorf("%w: room %d at %s", ErrRoomBooked, *exam.RoomID, exam.StartTime.Format(time.RFC3339))
+			}
+		}
+		if err := insertExam(ctx, tx, exam); err != nil {
+			return fmt.Errorf("failed to create exam for course %d: %w", exam.CourseID, err)
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func insertExam(ctx context.Context, q rowQuerier, exam *models.Exam) error {
 	sql := `
 		INSERT INTO exams (college_id, course_id, title, description, exam_type, start_time,
-			end_time, duration, total_marks, passing_marks, room_id, status, instructions,
-			allowed_materials, question_paper_sets, created_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
-		RETURNING id, created_at, updated_at`
+			end_time, duration, total_marks, passing_marks, passing_is_percentage, room_id, status, instructions,
+			allowed_materials, question_paper_sets, seating_scheme, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		RETURNING id, version, created_at, updated_at`
 
-	return r.db.Pool.QueryRow(ctx, sql,
+	materials, err := encodeAllowedMaterials(exam.AllowedMaterials)
+	if err != nil {
+		return err
+	}
+
+	return q.QueryRow(ctx, sql,
 		exam.CollegeID, exam.CourseID, exam.Title, exam.Description, exam.ExamType,
 		exam.StartTime, exam.EndTime, exam.Duration, exam.TotalMarks, exam.PassingMarks,
-		exam.RoomID, exam.Status, exam.Instructions, exam.AllowedMaterials,
-		exam.QuestionPaperSets, exam.CreatedBy,
-	).Scan(&exam.ID, &exam.CreatedAt, &exam.UpdatedAt)
+		exam.PassingIsPercentage, exam.RoomID, exam.Status, exam.Instructions, materials,
+		exam.QuestionPaperSets, exam.SeatingScheme, exam.CreatedBy,
+	).Scan(&exam.ID, &exam.Version, &exam.CreatedAt, &exam.UpdatedAt)
+}
+
+// encodeAllowedMaterials serialises the materials list for the JSONB column.
+// A nil slice is stored as [] so reads always return an array.
+func encodeAllowedMaterials(materials []string) (string, error) {
+	if materials == nil {
+		materials = []string{}
+	}
+	data, err := json.Marshal(materials)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode allowed materials: %w", err)
+	}
+	return string(data), nil
 }
 
 // GetExamByID retrieves an exam by ID
 func (r *examRepository) GetExamByID(ctx context.Context, collegeID, examID int) (*models.Exam, error) {
 	sql := `SELECT id, college_id, course_id, title, description, exam_type, start_time,
-			end_time, duration, total_marks, passing_marks, room_id, status, instructions,
-			allowed_materials, question_paper_sets, created_by, created_at, updated_at
-			FROM exams WHERE id = $1 AND college_id = $2`
+			end_time, duration, total_marks, passing_marks, passing_is_percentage, room_id, status, instructions,
+			allowed_materials, question_paper_sets, seating_scheme, created_by, version, deleted_at, cancellation_reason, cancelled_at,
+			created_at, updated_at
+			FROM exams WHERE id = $1 AND college_id = $2 AND deleted_at IS NULL`
 
 	exam := &models.Exam{}
 	err := r.db.Pool.QueryRow(ctx, sql, examID, collegeID).Scan(
 		&exam.ID, &exam.CollegeID, &exam.CourseID, &exam.Title, &exam.Description,
 		&exam.ExamType, &exam.StartTime, &exam.EndTime, &exam.Duration, &exam.TotalMarks,
-		&exam.PassingMarks, &exam.RoomID, &exam.Status, &exam.Instructions,
-		&exam.AllowedMaterials, &exam.QuestionPaperSets, &exam.CreatedBy,
-		&exam.CreatedAt, &exam.UpdatedAt,
+		&exam.PassingMarks, &exam.PassingIsPercentage, &exam.RoomID, &exam.Status, &exam.Instructions,
+		&exam.AllowedMaterials, &exam.QuestionPaperSets, &exam.SeatingScheme, &exam.CreatedBy,
+		&exam.Version, &exam.DeletedAt, &exam.CancellationReason, &exam.CancelledAt, &exam.CreatedAt, &exam.UpdatedAt,
 	)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrExamNotFound
+		}
 		return nil, fmt.Errorf("exam not found: %w", err)
 	}
 	return exam, nil
 }
 
-// ListExams retrieves exams with optional filters
-func (r *examRepository) ListExams(ctx context.Context, collegeID int, filters map[string]any, limit, offset int) ([]*models.Exam, error) {
+// GetExamWithDetails retrieves an exam by ID along with its course name and,
+// when a room is assigned, the room's name, number and location
+func (r *examRepository) GetExamWithDetails(ctx context.Context, collegeID, examID int) (*models.ExamWithDetails, error) {
+	sql := `SELECT e.id, e.college_id, e.course_id, e.title, e.description, e.exam_type, e.start_time,
+			e.end_time, e.duration, e.total_marks, e.passing_marks, e.passing_is_percentage, e.room_id, e.status, e.instructions,
+			e.allowed_materials, e.question_paper_sets, e.seating_scheme, e.created_by, e.version, e.deleted_at, e.cancellation_reason,
+			e.cancelled_at, e.created_at, e.updated_at,
+			c.name, er.room_name, er.room_number, er.location
+			FROM exams e
+			JOIN courses c ON c.id = e.course_id
+			LEFT JOIN exam_rooms er ON er.id = e.room_id AND er.college_id = e.college_id
+			WHERE e.id = $1 AND e.college_id = $2 AND e.deleted_at IS NULL`
+
+	exam := &models.ExamWithDetails{}
+	err := r.db.Pool.QueryRow(ctx, sql, examID, collegeID).Scan(
+		&exam.ID, &exam.CollegeID, &exam.CourseID, &exam.Title, &exam.Description,
+		&exam.ExamType, &exam.StartTime, &exam.EndTime, &exam.Duration, &exam.TotalMarks,
+		&exam.PassingMarks, &exam.PassingIsPercentage, &exam.RoomID, &exam.Status, &exam.Instructions,
+		&exam.AllowedMaterials, &exam.QuestionPaperSets, &exam.SeatingScheme, &exam.CreatedBy,
+		&exam.Version, &exam.DeletedAt, &exam.CancellationReason, &exam.CancelledAt, &exam.CreatedAt, &exam.UpdatedAt,
+		&exam.CourseName, &exam.RoomName, &exam.RoomNumber, &exam.RoomLocation,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrExamNotFound
+		}
+		return nil, fmt.Errorf("GetExamWithDetails: failed to query exam: %w", err)
+	}
+	return exam, nil
+}
+
+// ListExams retrieves a limit/offset page of the exams matching filter,
+// latest start time first
+func (r *examRepository) ListExams(ctx context.Context, collegeID int, filter models.ExamFilter, limit, offset int) ([]*models.Exam, error) {
+	return r.listExams(ctx, collegeID, filter, nil, limit, offset)
+}
+
+// ListExamsAfter is the keyset-paginated form of ListExams. It returns up to
+// limit exams following cursor (empty for the first page) together with the
+// cursor of the next page, which is empty after the last page. Unlike offsets,
+// cursors stay correct while exams are created or deleted between requests.
+func (r *examRepository) ListExamsAfter(ctx context.Context, collegeID int, filter models.ExamFilter, cursor string, limit int) ([]*models.Exam, string, error) {
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	// One extra row tells whether another page follows
+	exams, err := r.listExams(ctx, collegeID, filter, after, limit+1, 0)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(exams) <= limit {
+		return exams, "", nil
+	}
+	exams = exams[:limit]
+	last := exams[limit-1]
+	return exams, encodeCursor(last.StartTime, last.ID), nil
+}
+
+func (r *examRepository) listExams(ctx context.Context, collegeID int, filter models.ExamFilter, after *keysetCursor, limit, offset int) ([]*models.Exam, error) {
 	sql := `SELECT id, college_id, course_id, title, description, exam_type, start_time,
-			end_time, duration, total_marks, passing_marks, room_id, status, instructions,
-			allowed_materials, question_paper_sets, created_by, created_at, updated_at
+			end_time, duration, total_marks, passing_marks, passing_is_percentage, room_id, status, instructions,
+			allowed_materials, question_paper_sets, seating_scheme, created_by, version, deleted_at, cancellation_reason, cancelled_at,
+			created_at, updated_at
 			FROM exams WHERE college_id = $1`
 	args := []any{collegeID}
 	argCount := 1
 
+	if !filter.IncludeDeleted {
+		sql += " AND deleted_at IS NULL"
+	}
+
 	// Add optional filters
-	if courseID, ok := filters["course_id"]; ok {
+	if filter.CourseID != nil {
 		argCount++
 		sql += fmt.Sprintf(" AND course_id = $%d", argCount)
-		args = append(args, courseID)
+		args = append(args, *filter.CourseID)
 	}
-	if status, ok := filters["status"]; ok {
+	if filter.Status != nil {
 		argCount++
 		sql += fmt.Sprintf(" AND status = $%d", argCount)
-		args = append(args, status)
+		args = append(args, *filter.Status)
 	}
-	if examType, ok := filters["exam_type"]; ok {
+	if filter.ExamType != nil {
 		argCount++
 		sql += fmt.Sprintf(" AND exam_type = $%d", argCount)
-		args = append(args, examType)
+		args = append(args, *filter.ExamType)
+	}
+	if filter.From != nil {
+		argCount++
+		sql += fmt.Sprintf(" AND start_time >= $%d", argCount)
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		argCount++
+		sql += fmt.Sprintf(" AND start_time < $%d", argCount)
+		args = append(args, *filter.To)
+	}
+
+	if after != nil {
+		sql += fmt.Sprintf(" AND (start_time, id) < ($%d, $%d)", argCount+1, argCount+2)
+		args = append(args, after.At, after.ID)
+		argCount += 2
 	}
 
-	sql += " ORDER BY start_time DESC LIMIT $" + fmt.Sprintf("%d", argCount+1) + " OFFSET $" + fmt.Sprintf("%d", argCount+2)
-	args = append(args, limit, offset)
+	// id breaks ties between exams starting at the same time so pages never overlap
+	sql += fmt.Sprintf(" ORDER BY start_time DESC, id DESC LIMIT $%d", argCount+1)
+	args = append(args, limit)
+	if after == nil {
+		sql += fmt.Sprintf(" OFFSET $%d", argCount+2)
+		args = append(args, offset)
+	}
 
 	rows, err := r.db.Pool.Query(ctx, sql, args...)
 	if err != nil {
@@ -137,9 +416,9 @@ func (r *examRepository) ListExams(ctx context.Context, collegeID int, filters m
 		err := rows.Scan(
 			&exam.ID, &exam.CollegeID, &exam.CourseID, &exam.Title, &exam.Description,
 			&exam.ExamType, &exam.StartTime, &exam.EndTime, &exam.Duration, &exam.TotalMarks,
-			&exam.PassingMarks, &exam.RoomID, &exam.Status, &exam.Instructions,
-			&exam.AllowedMaterials, &exam.QuestionPaperSets, &exam.CreatedBy,
-			&exam.CreatedAt, &exam.UpdatedAt,
+			&exam.PassingMarks, &exam.PassingIsPercentage, &exam.RoomID, &exam.Status, &exam.Instructions,
+			&exam.AllowedMaterials, &exam.QuestionPaperSets, &exam.SeatingScheme, &exam.CreatedBy,
+			&exam.Version, &exam.DeletedAt, &exam.CancellationReason, &exam.CancelledAt, &exam.CreatedAt, &exam.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -152,16 +431,33 @@ func (r *examRepository) ListExams(ctx context.Context, collegeID int, filters m
 // UpdateExam updates an exam
 func (r *examRepository) UpdateExam(ctx context.Context, exam *models.Exam) error {
 	sql := `UPDATE exams SET title = $1, description = $2, exam_type = $3, start_time = $4,
-			end_time = $5, duration = $6, total_marks = $7, passing_marks = $8, room_id = $9,
-			status = $10, instructions = $11, allowed_materials = $12, question_paper_sets = $13
-			WHERE id = $14 AND college_id = $15`
+			end_time = $5, duration = $6, total_marks = $7, passing_marks = $8, passing_is_percentage = $9,
+			room_id = $10, status = $11, instructions = $12, allowed_materials = $13, question_paper_sets = $14,
+			seating_scheme = $15, version = version + 1
+			WHERE id = $16 AND college_id = $17 AND version = $18 AND deleted_at IS NULL
+			RETURNING version, updated_at`
 
-	result, err := r.db.Pool.Exec(ctx, sql,
+	materials, err := encodeAllowedMaterials(exam.AllowedMaterials)
+	if err != nil {
+		return err
+	}
+
+	err = r.db.Pool.QueryRow(ctx, sql,
 		exam.Title, exam.Description, exam.ExamType, exam.StartTime, exam.EndTime,
-		exam.Duration, exam.TotalMarks, exam.PassingMarks, exam.RoomID, exam.Status,
-		exam.Instructions, exam.AllowedMaterials, exam.QuestionPaperSets,
-		exam.ID, exam.CollegeID,
-	)
+		exam.Duration, exam.TotalMarks, exam.PassingMarks, exam.PassingIsPercentage, exam.RoomID, exam.Status,
+		exam.Instructions, materials, exam.QuestionPaperSets, exam.SeatingScheme,
+		exam.ID, exam.CollegeID, exam.Version,
+	).Scan(&exam.Version, &exam.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return versionMismatch(ctx, r.db.Pool, `SELECT EXISTS (SELECT 1 FROM exams WHERE id = $1 AND college_id = $2 AND deleted_at IS NULL)`, "exam not found", exam.ID, exam.CollegeID)
+	}
+	return err
+}
+
+// DeleteExam soft-deletes an exam; its enrollments and results are kept
+func (r *examRepository) DeleteExam(ctx context.Context, collegeID, examID int) error {
+	sql := `UPDATE exams SET deleted_at = NOW() WHERE id = $1 AND college_id = $2 AND deleted_at IS NULL`
+	result, err := r.db.Pool.Exec(ctx, sql, examID, collegeID)
 	if err != nil {
 		return err
 	}
@@ -171,41 +467,179 @@ func (r *examRepository) UpdateExam(ctx context.Context, exam *models.Exam) erro
 	return nil
 }
 
-// DeleteExam deletes an exam
-func (r *examRepository) DeleteExam(ctx context.Context, collegeID, examID int) error {
-	sql := `DELETE FROM exams WHERE id = $1 AND college_id = $2`
+// RestoreExam clears deleted_at on a soft-deleted exam
+func (r *examRepository) RestoreExam(ctx context.Context, collegeID, examID int) error {
+	sql := `UPDATE exams SET deleted_at = NULL WHERE id = $1 AND college_id = $2 AND deleted_at IS NOT NULL`
 	result, err := r.db.Pool.Exec(ctx, sql, examID, collegeID)
 	if err != nil {
 		return err
 	}
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("exam not found")
+		return fmt.Errorf("deleted %w", ErrExamNotFound)
 	}
 	return nil
 }
 
+// CancelExam marks an exam cancelled with the given reason, keeping the
+// record. It fails with ErrExamNotCancellable when the exam is already
+// cancelled or completed. exam is updated with the new status and version.
+func (r *examRepository) CancelExam(ctx context.Context, exam *models.Exam, reason string) error {
+	sql := `UPDATE exams SET status = 'cancelled', cancellation_reason = $1, cancelled_at = NOW(),
+			version = version + 1, updated_at = NOW()
+			WHERE id = $2 AND college_id = $3 AND deleted_at IS NULL AND status NOT IN ('cancelled', 'completed')
+			RETURNING status, cancellation_reason, cancelled_at, version, updated_at`
+
+	err := r.db.Pool.QueryRow(ctx, sql, reason, exam.ID, exam.CollegeID).Scan(
+		&exam.Status, &exam.CancellationReason, &exam.CancelledAt, &exam.Version, &exam.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrExamNotCancellable
+	}
+	return err
+}
+
 // ListExamsByCourse retrieves exams for a specific course
 func (r *examRepository) ListExamsByCourse(ctx context.Context, collegeID, courseID int, limit, offset int) ([]*models.Exam, error) {
-	return r.ListExams(ctx, collegeID, map[string]any{"course_id": courseID}, limit, offset)
+	return r.ListExams(ctx, collegeID, models.ExamFilter{CourseID: &courseID}, limit, offset)
 }
 
 // EnrollStudent enrolls a student in an exam
 func (r *examRepository) EnrollStudent(ctx context.Context, enrollment *models.ExamEnrollment) error {
 	sql := `INSERT INTO exam_enrollments (exam_id, student_id, college_id, seat_number,
-			room_number, question_paper_set, status)
-			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			room_number, question_paper_set, status,
+			extra_time_minutes, separate_room, scribe, accommodation_notes)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 			RETURNING id, enrollment_date, created_at, updated_at`
 
+	acc := enrollment.Accommodations
 	return r.db.Pool.QueryRow(ctx, sql,
 		enrollment.ExamID, enrollment.StudentID, enrollment.CollegeID, enrollment.SeatNumber,
 		enrollment.RoomNumber, enrollment.QuestionPaperSet, enrollment.Status,
+		acc.ExtraTimeMinutes, acc.SeparateRoom, acc.Scribe, acc.Notes,
 	).Scan(&enrollment.ID, &enrollment.EnrollmentDate, &enrollment.CreatedAt, &enrollment.UpdatedAt)
 }
 
+// EnrollCourseStudents enrolls every student with an active enrollment in the
+// exam's course in one transaction. Students already enrolled in the exam are
+// left alone. It returns the enrollments it created and the number of students
+// eligible from the course roster.
+func (r *examRepository) EnrollCourseStudents(ctx context.Context, collegeID, examID int) ([]*models.ExamEnrollment, int, error) {
+	beginner, ok := r.db.Pool.(BeginPool)
+	if !ok {
+		return nil, 0, fmt.Errorf("transaction support is required")
+	}
+
+	tx, err := beginner.Begin(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	// Lock the exam so it cannot be deleted or moved to another course mid-way
+	var courseID int
+	err = tx.QueryRow(ctx, `SELECT course_id FROM exams WHERE id = $1 AND college_id = $2 AND deleted_at IS NULL FOR UPDATE`,
+		examID, collegeID).Scan(&courseID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, 0, ErrExamNotFound
+		}
+		return nil, 0, fmt.Errorf("failed to lock exam: %w", err)
+	}
+
+	var eligible int
+	err = tx.QueryRow(ctx, `SELECT COUNT(DISTINCT student_id) FROM enrollments
+			WHERE course_id = $1 AND college_id = $2 AND status = 'active'`,
+		courseID, collegeID).Scan(&eligible)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count course enrollments: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, `INSERT INTO exam_enrollments (exam_id, student_id, college_id, status)
+			SELECT DISTINCT $1::int, student_id, $2::int, 'enrolled' FROM enrollments
+			WHERE course_id = $3 AND college_id = $2 AND status = 'active'
+			ON CONFLICT (exam_id, student_id) DO NOTHING
+			RETURNING id, student_id, enrollment_date, created_at, updated_at`,
+		examID, collegeID, courseID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to enroll course students: %w", err)
+	}
+	var created []*models.ExamEnrollment
+	for rows.Next() {
+		enrollment := &models.ExamEnrollment{ExamID: examID, CollegeID: collegeID, Status: "enrolled"}
+		if err := rows.Scan(&enrollment.ID, &enrollment.StudentID, &enrollment.EnrollmentDate,
+			&enrollment.CreatedAt, &enrollment.UpdatedAt); err != nil {
+			rows.Close()
+			return nil, 0, fmt.Errorf("failed to enroll course students: %w", err)
+		}
+		created = append(created, enrollment)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to enroll course students: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, 0, err
+	}
+	return created, eligible, nil
+}
+
+// MarkAppeared checks in the student with the given roll number, setting the
+// enrollment to appeared and stamping appeared_at. The roll number is matched
+// the way student imports store it, ignoring case and surrounding spaces.
+func (r *examRepository) MarkAppeared(ctx context.Context, collegeID, examID int, rollNo string) (*models.ExamEnrollment, error) {
+	beginner, ok := r.db.Pool.(BeginPool)
+	if !ok {
+		return nil, fmt.Errorf("transaction support is required")
+	}
+
+	tx, err := beginner.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	enrollment := &models.ExamEnrollment{ExamID: examID, CollegeID: collegeID}
+	err = tx.QueryRow(ctx, `SELECT en.id, en.student_id, en.status FROM exam_enrollments en
+			JOIN students s ON s.student_id = en.student_id AND s.college_id = en.college_id
+			WHERE en.exam_id = $1 AND en.college_id = $2 AND lower(trim(s.roll_no)) = lower(trim($3))
+			FOR UPDATE OF en`,
+		examID, collegeID, rollNo).Scan(&enrollment.ID, &enrollment.StudentID, &enrollment.Status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEnrollmentNotFound
+		}
+		return nil, fmt.Errorf("failed to look up enrollment: %w", err)
+	}
+	switch enrollment.Status {
+	case "appeared":
+		return nil, ErrAlreadyAppeared
+	case "disqualified":
+		return nil, ErrEnrollmentDisqualified
+	}
+
+	err = tx.QueryRow(ctx, `UPDATE exam_enrollments SET status = 'appeared', appeared_at = NOW(), updated_at = NOW()
+			WHERE id = $1 RETURNING appeared_at`, enrollment.ID).Scan(&enrollment.AppearedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark student as appeared: %w", err)
+	}
+	enrollment.Status = "appeared"
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return enrollment, nil
+}
+
 // GetEnrollment retrieves an enrollment
 func (r *examRepository) GetEnrollment(ctx context.Context, examID, studentID int) (*models.ExamEnrollment, error) {
 	sql := `SELECT id, exam_id, student_id, college_id, enrollment_date, seat_number,
-			room_number, question_paper_set, status, hall_ticket_generated, created_at, updated_at
+			room_number, question_paper_set, status, hall_ticket_generated, created_at, updated_at,
+			extra_time_minutes, separate_room, scribe, accommodation_notes, appeared_at, extension_minutes
 			FROM exam_enrollments WHERE exam_id = $1 AND student_id = $2`
 
 	enrollment := &models.ExamEnrollment{}
@@ -214,7 +648,12 @@ func (r *examRepository) GetEnrollment(ctx context.Context, examID, studentID in
 		&enrollment.EnrollmentDate, &enrollment.SeatNumber, &enrollment.RoomNumber,
 		&enrollment.QuestionPaperSet, &enrollment.Status, &enrollment.HallTicketGenerated,
 		&enrollment.CreatedAt, &enrollment.UpdatedAt,
+		&enrollment.Accommodations.ExtraTimeMinutes, &enrollment.Accommodations.SeparateRoom,
+		&enrollment.Accommodations.Scribe, &enrollment.Accommodations.Notes, &enrollment.AppearedAt, &enrollment.ExtensionMinutes,
 	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrEnrollmentNotFound
+	}
 	if err != nil {
 		return nil, fmt.Errorf("enrollment not found: %w", err)
 	}
@@ -224,7 +663,8 @@ func (r *examRepository) GetEnrollment(ctx context.Context, examID, studentID in
 // ListEnrollments retrieves all enrollments for an exam
 func (r *examRepository) ListEnrollments(ctx context.Context, examID int) ([]*models.ExamEnrollment, error) {
 	sql := `SELECT id, exam_id, student_id, college_id, enrollment_date, seat_number,
-			room_number, question_paper_set, status, hall_ticket_generated, created_at, updated_at
+			room_number, question_paper_set, status, hall_ticket_generated, created_at, updated_at,
+			extra_time_minutes, separate_room, scribe, accommodation_notes, appeared_at, extension_minutes
 			FROM exam_enrollments WHERE exam_id = $1 ORDER BY seat_number`
 
 	rows, err := r.db.Pool.Query(ctx, sql, examID)
@@ -241,6 +681,8 @@ func (r *examRepository) ListEnrollments(ctx context.Context, examID int) ([]*mo
 			&enrollment.EnrollmentDate, &enrollment.SeatNumber, &enrollment.RoomNumber,
 			&enrollment.QuestionPaperSet, &enrollment.Status, &enrollment.HallTicketGenerated,
 			&enrollment.CreatedAt, &enrollment.UpdatedAt,
+			&enrollment.Accommodations.ExtraTimeMinutes, &enrollment.Accommodations.SeparateRoom,
+			&enrollment.Accommodations.Scribe, &enrollment.Accommodations.Notes, &enrollment.AppearedAt, &enrollment.ExtensionMinutes,
 		)
 		if err != nil {
 			return nil, err
@@ -250,6 +692,47 @@ func (r *examRepository) ListEnrollments(ctx context.Context, examID int) ([]*mo
 	return enrollments, nil
 }
 
+// ListEnrollmentRollNos maps each student enrolled in the exam to their roll
+// number
+func (r *examRepository) ListEnrollmentRollNos(ctx context.Context, collegeID, examID int) (map[int]string, error) {
+	sql := `SELECT en.student_id, s.roll_no
+			FROM exam_enrollments en
+			JOIN students s ON s.student_id = en.student_id AND s.college_id = en.college_id
+			WHERE en.exam_id = $1 AND en.college_id = $2`
+
+	rows, err := r.db.Pool.Query(ctx, sql, examID, collegeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rollNos := make(map[int]string)
+	for rows.Next() {
+		var studentID int
+		var rollNo string
+		if err := rows.Scan(&studentID, &rollNo); err != nil {
+			return nil, err
+		}
+		rollNos[studentID] = rollNo
+	}
+	return rollNos, rows.Err()
+}
+
+// GetStudentLanguage returns the language the student chose in their
+// settings, or English when they never saved any
+func (r *examRepository) GetStudentLanguage(ctx context.Context, collegeID, studentID int) (string, error) {
+	sql := `SELECT COALESCE(us.language, 'en')
+			FROM students s
+			LEFT JOIN user_settings us ON us.user_id = s.user_id
+			WHERE s.college_id = $1 AND s.student_id = $2`
+
+	var language string
+	if err := r.db.Pool.QueryRow(ctx, sql, collegeID, studentID).Scan(&language); err != nil {
+		return "", err
+	}
+	return language, nil
+}
+
 // UpdateEnrollment updates an enrollment
 func (r *examRepository) UpdateEnrollment(ctx context.Context, enrollment *models.ExamEnrollment) error {
 	sql := `UPDATE exam_enrollments SET seat_number = $1, room_number = $2,
@@ -282,10 +765,137 @@ func (r *examRepository) DeleteEnrollment(ctx context.Context, examID, studentID
 	return nil
 }
 
+// UpdateAccommodations replaces the accommodations on a student's exam enrollment
+func (r *examRepository) UpdateAccommodations(ctx context.Context, collegeID, examID, studentID int, accommodations models.ExamAccommodations) error {
+	sql := `UPDATE exam_enrollments SET extra_time_minutes = $1, separate_room = $2, scribe = $3,
+			accommodation_notes = $4, updated_at = NOW()
+			WHERE exam_id = $5 AND student_id = $6 AND college_id = $7`
+
+	result, err := r.db.Pool.Exec(ctx, sql,
+		accommodations.ExtraTimeMinutes, accommodations.SeparateRoom, accommodations.Scribe,
+		accommodations.Notes, examID, studentID, collegeID,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrEnrollmentNotFound
+	}
+	return nil
+}
+
+// IssueHallTicket marks the enrollment's hall ticket as generated and returns
+// its version. A ticket whose contents differ from the latest version, as
+// identified by fingerprint, gets the next version and a new generated-at
+// time; regenerating an unchanged ticket returns the latest version as is.
+func (r *examRepository) IssueHallTicket(ctx context.Context, enrollmentID int, fingerprint string) (*models.HallTicketIssue, error) {
+	sql := `UPDATE exam_enrollments SET
+			hall_ticket_version = CASE WHEN hall_ticket_version = 0 OR hall_ticket_fingerprint IS DISTINCT FROM $2
+				THEN hall_ticket_version + 1 ELSE hall_ticket_version END,
+			hall_ticket_generated_at = CASE WHEN hall_ticket_version = 0 OR hall_ticket_fingerprint IS DISTINCT FROM $2
+				THEN NOW() ELSE hall_ticket_generated_at END,
+			hall_ticket_fingerprint = $2, hall_ticket_generated = TRUE, updated_at = NOW()
+			WHERE id = $1
+			RETURNING hall_ticket_version, hall_ticket_generated_at`
+
+	issue := &models.HallTicketIssue{}
+	err := r.db.Pool.QueryRow(ctx, sql, enrollmentID, fingerprint).Scan(&issue.Version, &issue.GeneratedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrEnrollmentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue hall ticket: %w", err)
+	}
+	return issue, nil
+}
+
+// GetHallTicketIssue returns the latest hall ticket version of a student's
+// exam enrollment
+func (r *examRepository) GetHallTicketIssue(ctx context.Context, collegeID, examID, studentID int) (*models.HallTicketIssue, error) {
+	sql := `SELECT hall_ticket_version, hall_ticket_generated_at
+			FROM exam_enrollments WHERE exam_id = $1 AND student_id = $2 AND college_id = $3`
+
+	issue := &models.HallTicketIssue{}
+	err := r.db.Pool.QueryRow(ctx, sql, examID, studentID, collegeID).Scan(&issue.Version, &issue.GeneratedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrEnrollmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return issue, nil
+}
+
+// AssignSeats writes a seat plan in one transaction. The exam row is locked
+// with NOWAIT so a concurrent allocation fails fast with
+// ErrSeatAllocationInProgress, and the enrollments are locked so nothing else
+// edits them mid-way. The plan must cover exactly the exam's enrollments.
+func (r *examRepository) AssignSeats(ctx context.Context, collegeID, examID int, seats []*models.SeatAssignment) error {
+	beginner, ok := r.db.Pool.(BeginPool)
+	if !ok {
+		return fmt.Errorf("transaction support is required")
+	}
+
+	tx, err := beginner.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var lockedID int
+	err = tx.QueryRow(ctx, `SELECT id FROM exams WHERE id = $1 AND college_id = $2 AND deleted_at IS NULL FOR UPDATE NOWAIT`,
+		examID, collegeID).Scan(&lockedID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		switch {
+		case errors.As(err, &pgErr) && pgErr.Code == "55P03":
+			return ErrSeatAllocationInProgress
+		case errors.Is(err, pgx.ErrNoRows):
+			return fmt.Errorf("exam not found")
+		}
+		return fmt.Errorf("failed to lock exam for seat allocation: %w", err)
+	}
+
+	rows, err := tx.Query(ctx, `SELECT id FROM exam_enrollments WHERE exam_id = $1 ORDER BY id FOR UPDATE`, examID)
+	if err != nil {
+		return fmt.Errorf("failed to lock exam enrollments: %w", err)
+	}
+	enrolled := make(map[int]bool)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to lock exam enrollments: %w", err)
+		}
+		enrolled[id] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to lock exam enrollments: %w", err)
+	}
+
+	if len(enrolled) != len(seats) {
+		return ErrSeatPlanStale
+	}
+	for _, seat := range seats {
+		if !enrolled[seat.EnrollmentID] {
+			return ErrSeatPlanStale
+		}
+		if _, err := tx.Exec(ctx, `UPDATE exam_enrollments SET seat_number = $1, room_number = $2, question_paper_set = $3 WHERE id = $4`,
+			seat.SeatNumber, seat.RoomNumber, seat.QuestionPaperSet, seat.EnrollmentID); err != nil {
+			return fmt.Errorf("failed to assign seat to student %d: %w", seat.StudentID, err)
+		}
+	}
+	return tx.Commit(ctx)
+}
+
 // GetStudentEnrollments retrieves all enrollments for a student
 func (r *examRepository) GetStudentEnrollments(ctx context.Context, studentID, collegeID int) ([]*models.ExamEnrollment, error) {
 	sql := `SELECT id, exam_id, student_id, college_id, enrollment_date, seat_number,
-			room_number, question_paper_set, status, hall_ticket_generated, created_at, updated_at
+			room_number, question_paper_set, status, hall_ticket_generated, created_at, updated_at,
+			extra_time_minutes, separate_room, scribe, accommodation_notes, appeared_at, extension_minutes
 			FROM exam_enrollments WHERE student_id = $1 AND college_id = $2
 			ORDER BY enrollment_date DESC`
 
@@ -303,6 +913,8 @@ func (r *examRepository) GetStudentEnrollments(ctx context.Context, studentID, c
 			&enrollment.EnrollmentDate, &enrollment.SeatNumber, &enrollment.RoomNumber,
 			&enrollment.QuestionPaperSet, &enrollment.Status, &enrollment.HallTicketGenerated,
 			&enrollment.CreatedAt, &enrollment.UpdatedAt,
+			&enrollment.Accommodations.ExtraTimeMinutes, &enrollment.Accommodations.SeparateRoom,
+			&enrollment.Accommodations.Scribe, &enrollment.Accommodations.Notes, &enrollment.AppearedAt, &enrollment.ExtensionMinutes,
 		)
 		if err != nil {
 			return nil, err
@@ -312,111 +924,655 @@ func (r *examRepository) GetStudentEnrollments(ctx context.Context, studentID, c
 	return enrollments, nil
 }
 
-// CreateResult creates an exam result
-func (r *examRepository) CreateResult(ctx context.Context, result *models.ExamResult) error {
-	sql := `INSERT INTO exam_results (exam_id, student_id, college_id, marks_obtained,
-			grade, percentage, result, remarks, evaluated_by, evaluated_at)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-			RETURNING id, created_at, updated_at`
-
-	return r.db.Pool.QueryRow(ctx, sql,
-		result.ExamID, result.StudentID, result.CollegeID, result.MarksObtained,
-		result.Grade, result.Percentage, result.Result, result.Remarks,
-		result.EvaluatedBy, result.EvaluatedAt,
-	).Scan(&result.ID, &result.CreatedAt, &result.UpdatedAt)
-}
-
-// GetResult retrieves a result
-func (r *examRepository) GetResult(ctx context.Context, examID, studentID int) (*models.ExamResult, error) {
-	sql := `SELECT id, exam_id, student_id, college_id, marks_obtained, grade, percentage,
-			result, remarks, evaluated_by, evaluated_at, revaluation_status, created_at, updated_at
-			FROM exam_results WHERE exam_id = $1 AND student_id = $2`
-
-	res := &models.ExamResult{}
-	err := r.db.Pool.QueryRow(ctx, sql, examID, studentID).Scan(
-		&res.ID, &res.ExamID, &res.StudentID, &res.CollegeID, &res.MarksObtained,
-		&res.Grade, &res.Percentage, &res.Result, &res.Remarks, &res.EvaluatedBy,
-		&res.EvaluatedAt, &res.RevaluationStatus, &res.CreatedAt, &res.UpdatedAt,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("result not found: %w", err)
-	}
-	return res, nil
-}
-
-// GetResultByID retrieves a result by its ID
-func (r *examRepository) GetResultByID(ctx context.Context, resultID int) (*models.ExamResult, error) {
-	sql := `SELECT id, exam_id, student_id, college_id, marks_obtained, grade, percentage,
-			result, remarks, evaluated_by, evaluated_at, revaluation_status, created_at, updated_at
-			FROM exam_results WHERE id = $1`
+// CountStudentEnrollments counts the enrollments GetStudentEnrollments returns
+func (r *examRepository) CountStudentEnrollments(ctx context.Context, studentID, collegeID int) (int, error) {
+	sql := `SELECT COUNT(*) FROM exam_enrollments WHERE student_id = $1 AND college_id = $2`
 
-	res := &models.ExamResult{}
-	err := r.db.Pool.QueryRow(ctx, sql, resultID).Scan(
-		&res.ID, &res.ExamID, &res.StudentID, &res.CollegeID, &res.MarksObtained,
-		&res.Grade, &res.Percentage, &res.Result, &res.Remarks, &res.EvaluatedBy,
-		&res.EvaluatedAt, &res.RevaluationStatus, &res.CreatedAt, &res.UpdatedAt,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("result not found: %w", err)
+	var count int
+	if err := r.db.Pool.QueryRow(ctx, sql, studentID, collegeID).Scan(&count); err != nil {
+		return 0, err
 	}
-	return res, nil
+	return count, nil
 }
 
-// ListResults retrieves all results for an exam
-func (r *examRepository) ListResults(ctx context.Context, examID int) ([]*models.ExamResult, error) {
-	sql := `SELECT id, exam_id, student_id, college_id, marks_obtained, grade, percentage,
-			result, remarks, evaluated_by, evaluated_at, revaluation_status, created_at, updated_at
-			FROM exam_results WHERE exam_id = $1 ORDER BY student_id`
+// GetStudentExamEnrollments retrieves a student's enrollments with each exam's
+// title, course, schedule and whether the student's result is published,
+// latest exam first. Enrollments in deleted exams are left out.
+func (r *examRepository) GetStudentExamEnrollments(ctx context.Context, studentID, collegeID int) ([]*models.StudentExamEnrollment, error) {
+	sql := `SELECT en.id, en.exam_id, en.student_id, en.college_id, en.enrollment_date, en.seat_number,
+			en.room_number, en.question_paper_set, en.status, en.hall_ticket_generated, en.created_at, en.updated_at,
+			en.extra_time_minutes, en.separate_room, en.scribe, en.accommodation_notes, en.appeared_at, en.extension_minutes,
+			ex.title, ex.exam_type, ex.course_id, c.name, ex.start_time, ex.end_time, ex.status,
+			EXISTS (SELECT 1 FROM exam_results res
+				WHERE res.exam_id = en.exam_id AND res.student_id = en.student_id
+				AND res.college_id = en.college_id AND res.published)
+			FROM exam_enrollments en
+			JOIN exams ex ON ex.id = en.exam_id AND ex.college_id = en.college_id
+			JOIN courses c ON c.id = ex.course_id
+			WHERE en.student_id = $1 AND en.college_id = $2 AND ex.deleted_at IS NULL
+			ORDER BY ex.start_time DESC, en.id DESC`
 
-	rows, err := r.db.Pool.Query(ctx, sql, examID)
+	rows, err := r.db.Pool.Query(ctx, sql, studentID, collegeID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var results []*models.ExamResult
+	enrollments := []*models.StudentExamEnrollment{}
 	for rows.Next() {
-		res := &models.ExamResult{}
+		enrollment := &models.StudentExamEnrollment{}
 		err := rows.Scan(
-			&res.ID, &res.ExamID, &res.StudentID, &res.CollegeID, &res.MarksObtained,
-			&res.Grade, &res.Percentage, &res.Result, &res.Remarks, &res.EvaluatedBy,
-			&res.EvaluatedAt, &res.RevaluationStatus, &res.CreatedAt, &res.UpdatedAt,
+			&enrollment.ID, &enrollment.ExamID, &enrollment.StudentID, &enrollment.CollegeID,
+			&enrollment.EnrollmentDate, &enrollment.SeatNumber, &enrollment.RoomNumber,
+			&enrollment.QuestionPaperSet, &enrollment.Status, &enrollment.HallTicketGenerated,
+			&enrollment.CreatedAt, &enrollment.UpdatedAt,
+			&enrollment.Accommodations.ExtraTimeMinutes, &enrollment.Accommodations.SeparateRoom,
+			&enrollment.Accommodations.Scribe, &enrollment.Accommodations.Notes, &enrollment.AppearedAt, &enrollment.ExtensionMinutes,
+			&enrollment.ExamTitle, &enrollment.ExamType, &enrollment.CourseID, &enrollment.CourseName,
+			&enrollment.StartTime, &enrollment.EndTime, &enrollment.ExamStatus, &enrollment.ResultPublished,
 		)
 		if err != nil {
 			return nil, err
 		}
-		results = append(results, res)
+		enrollments = append(enrollments, enrollment)
 	}
-	return results, nil
+	return enrollments, rows.Err()
 }
 
-// UpdateResult updates a result
-func (r *examRepository) UpdateResult(ctx context.Context, result *models.ExamResult) error {
-	sql := `UPDATE exam_results SET marks_obtained = $1, grade = $2, percentage = $3,
-			result = $4, remarks = $5, evaluated_by = $6, evaluated_at = $7,
-			revaluation_status = $8 WHERE id = $9`
-
-	res, err := r.db.Pool.Exec(ctx, sql,
+// ListTimetableCourses returns the requested courses of a college with the
+// IDs of students actively enrolled in each
+func (r *examRepository) ListTimetableCourses(ctx context.Context, collegeID int, courseIDs []int) ([]*models.TimetableCourse, error) {
+	sql := `SELECT c.id, c.name,
+				COALESCE(array_agg(DISTINCT e.student_id) FILTER (WHERE e.student_id IS NOT NULL), '{}')
+			FROM courses c
+			LEFT JOIN enrollments e ON e.course_id = c.id AND e.college_id = c.college_id
+				AND LOWER(e.status) NOT IN ('dropped', 'withdrawn')
+			WHERE c.college_id = $1 AND c.id = ANY($2)
+			GROUP BY c.id, c.name
+			ORDER BY c.id`
+
+	rows, err := r.db.Pool.Query(ctx, sql, collegeID, courseIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	courses := []*models.TimetableCourse{}
+	for rows.Next() {
+		course := &models.TimetableCourse{}
+		if err := rows.Scan(&course.CourseID, &course.Name, &course.StudentIDs); err != nil {
+			return nil, err
+		}
+		courses = append(courses, course)
+	}
+	return courses, rows.Err()
+}
+
+// ListUpcomingExams returns the student's enrolled exams starting after the
+// given time, soonest first, with seat and hall ticket details
+func (r *examRepository) ListUpcomingExams(ctx context.Context, studentID, collegeID int, after time.Time) ([]*models.UpcomingExam, error) {
+	sql := `SELECT e.id, e.course_id, e.title, e.exam_type, e.start_time,
+				e.end_time + make_interval(mins => en.extra_time_minutes),
+				e.duration, e.total_marks, e.instructions, e.allowed_materials,
+				en.id, en.status, en.seat_number, en.room_number, en.hall_ticket_generated,
+				en.extra_time_minutes
+			FROM exam_enrollments en
+			JOIN exams e ON e.id = en.exam_id AND e.college_id = en.college_id
+			WHERE en.student_id = $1 AND en.college_id = $2
+			AND e.start_time > $3
+			AND e.deleted_at IS NULL AND e.status <> 'cancelled'
+			ORDER BY e.start_time ASC`
+
+	rows, err := r.db.Pool.Query(ctx, sql, studentID, collegeID, after)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exams := []*models.UpcomingExam{}
+	for rows.Next() {
+		exam := &models.UpcomingExam{}
+		err := rows.Scan(
+			&exam.ExamID, &exam.CourseID, &exam.Title, &exam.ExamType, &exam.StartTime, &exam.EndTime,
+			&exam.Duration, &exam.TotalMarks, &exam.Instructions, &exam.AllowedMaterials,
+			&exam.EnrollmentID, &exam.EnrollmentStatus, &exam.SeatNumber, &exam.RoomNumber,
+			&exam.HallTicketGenerated, &exam.ExtraTimeMinutes,
+		)
+		if err != nil {
+			return nil, err
+		}
+		exams = append(exams, exam)
+	}
+	return exams, rows.Err()
+}
+
+// ListStudentExamWindows returns when the given students already sit live
+// exams overlapping [from, to), one row per student and exam
+func (r *examRepository) ListStudentExamWindows(ctx context.Context, collegeID int, studentIDs []int, from, to time.Time) ([]*models.StudentExamWindow, error) {
+	sql := `SELECT en.student_id, e.start_time, e.end_time
+			FROM exam_enrollments en
+			JOIN exams e ON e.id = en.exam_id AND e.college_id = en.college_id
+			WHERE en.college_id = $1 AND en.student_id = ANY($2)
+			AND en.status <> 'disqualified'
+			AND e.deleted_at IS NULL AND e.status <> 'cancelled'
+			AND e.start_time < $4 AND e.end_time > $3`
+
+	rows, err := r.db.Pool.Query(ctx, sql, collegeID, studentIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	windows := []*models.StudentExamWindow{}
+	for rows.Next() {
+		window := &models.StudentExamWindow{}
+		if err := rows.Scan(&window.StudentID, &window.StartTime, &window.EndTime); err != nil {
+			return nil, err
+		}
+		windows = append(windows, window)
+	}
+	return windows, rows.Err()
+}
+
+// ListExamClashes finds pairs of active exams with overlapping times that the
+// same student is enrolled in. A nil studentID scans the whole college.
+func (r *examRepository) ListExamClashes(ctx context.Context, collegeID int, studentID *int) ([]*models.ExamClash, error) {
+	sql := `SELECT ea.student_id,
+				a.id, a.title, a.start_time, a.end_time,
+				b.id, b.title, b.start_time, b.end_time
+			FROM exam_enrollments ea
+			JOIN exam_enrollments eb ON eb.student_id = ea.student_id
+				AND eb.college_id = ea.college_id AND eb.exam_id > ea.exam_id
+			JOIN exams a ON a.id = ea.exam_id
+			JOIN exams b ON b.id = eb.exam_id
+			WHERE ea.college_id = $1
+			AND ($2::int IS NULL OR ea.student_id = $2)
+			AND ea.status <> 'disqualified' AND eb.status <> 'disqualified'
+			AND a.deleted_at IS NULL AND b.deleted_at IS NULL
+			AND a.status <> 'cancelled' AND b.status <> 'cancelled'
+			AND a.start_time < b.end_time AND b.start_time < a.end_time
+			ORDER BY ea.student_id, a.start_time, b.start_time`
+
+	rows, err := r.db.Pool.Query(ctx, sql, collegeID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	clashes := []*models.ExamClash{}
+	for rows.Next() {
+		clash := &models.ExamClash{}
+		err := rows.Scan(
+			&clash.StudentID,
+			&clash.ExamID, &clash.ExamTitle, &clash.StartTime, &clash.EndTime,
+			&clash.ClashingExamID, &clash.ClashingExamTitle, &clash.ClashingStartTime, &clash.ClashingEndTime,
+		)
+		if err != nil {
+			return nil, err
+		}
+		clashes = append(clashes, clash)
+	}
+	return clashes, rows.Err()
+}
+
+// CreateResult creates an exam result
+func (r *examRepository) CreateResult(ctx context.Context, result *models.ExamResult) error {
+	return insertResult(ctx, r.db.Pool, result)
+}
+
+// rowQuerier is satisfied by both the pool and a pgx.Tx so result writes can
+// run standalone or inside SaveResults
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+func insertResult(ctx context.Context, q rowQuerier, result *models.ExamResult) error {
+	sql := `INSERT INTO exam_results (exam_id, student_id, college_id, marks_obtained,
+			grade, percentage, result, remarks, evaluated_by, evaluated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			RETURNING id, version, created_at, updated_at`
+
+	return q.QueryRow(ctx, sql,
+		result.ExamID, result.StudentID, result.CollegeID, result.MarksObtained,
+		result.Grade, result.Percentage, result.Result, result.Remarks,
+		result.EvaluatedBy, result.EvaluatedAt,
+	).Scan(&result.ID, &result.Version, &result.CreatedAt, &result.UpdatedAt)
+}
+
+// GetResult retrieves a result
+func (r *examRepository) GetResult(ctx context.Context, examID, studentID int) (*models.ExamResult, error) {
+	sql := `SELECT id, exam_id, student_id, college_id, marks_obtained, grade, percentage,
+			result, remarks, evaluated_by, evaluated_at, revaluation_status, version,
+			published, published_at, created_at, updated_at
+			FROM exam_results WHERE exam_id = $1 AND student_id = $2`
+
+	res := &models.ExamResult{}
+	err := r.db.Pool.QueryRow(ctx, sql, examID, studentID).Scan(
+		&res.ID, &res.ExamID, &res.StudentID, &res.CollegeID, &res.MarksObtained,
+		&res.Grade, &res.Percentage, &res.Result, &res.Remarks, &res.EvaluatedBy,
+		&res.EvaluatedAt, &res.RevaluationStatus, &res.Version, &res.Published, &res.PublishedAt,
+		&res.CreatedAt, &res.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrExamResultNotFound
+		}
+		return nil, fmt.Errorf("GetResult: failed to query result: %w", err)
+	}
+	return res, nil
+}
+
+// GetResultByID retrieves a result by its ID
+func (r *examRepository) GetResultByID(ctx context.Context, resultID int) (*models.ExamResult, error) {
+	sql := `SELECT id, exam_id, student_id, college_id, marks_obtained, grade, percentage,
+			result, remarks, evaluated_by, evaluated_at, revaluation_status, version,
+			published, published_at, created_at, updated_at
+			FROM exam_results WHERE id = $1`
+
+	res := &models.ExamResult{}
+	err := r.db.Pool.QueryRow(ctx, sql, resultID).Scan(
+		&res.ID, &res.ExamID, &res.StudentID, &res.CollegeID, &res.MarksObtained,
+		&res.Grade, &res.Percentage, &res.Result, &res.Remarks, &res.EvaluatedBy,
+		&res.EvaluatedAt, &res.RevaluationStatus, &res.Version, &res.Published, &res.PublishedAt,
+		&res.CreatedAt, &res.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrExamResultNotFound
+		}
+		return nil, fmt.Errorf("result not found: %w", err)
+	}
+	return res, nil
+}
+
+// ListResults retrieves all results for an exam
+func (r *examRepository) ListResults(ctx context.Context, examID int) ([]*models.ExamResult, error) {
+	sql := `SELECT id, exam_id, student_id, college_id, marks_obtained, grade, percentage,
+			result, remarks, evaluated_by, evaluated_at, revaluation_status, version,
+			published, published_at, created_at, updated_at
+			FROM exam_results WHERE exam_id = $1 ORDER BY student_id`
+
+	rows, err := r.db.Pool.Query(ctx, sql, examID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*models.ExamResult
+	for rows.Next() {
+		res := &models.ExamResult{}
+		err := rows.Scan(
+			&res.ID, &res.ExamID, &res.StudentID, &res.CollegeID, &res.MarksObtained,
+			&res.Grade, &res.Percentage, &res.Result, &res.Remarks, &res.EvaluatedBy,
+			&res.EvaluatedAt, &res.RevaluationStatus, &res.Version, &res.Published, &res.PublishedAt,
+			&res.CreatedAt, &res.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// ListResultSections maps each student with a result in the exam to their
+// section. Students without a section are left out.
+func (r *examRepository) ListResultSections(ctx context.Context, examID int) (map[int]string, error) {
+	sql := `SELECT r.student_id, s.section
+			FROM exam_results r
+			JOIN students s ON s.student_id = r.student_id AND s.college_id = r.college_id
+			WHERE r.exam_id = $1 AND trim(s.section) <> ''`
+
+	rows, err := r.db.Pool.Query(ctx, sql, examID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sections := make(map[int]string)
+	for rows.Next() {
+		var studentID int
+		var section string
+		if err := rows.Scan(&studentID, &section); err != nil {
+			return nil, err
+		}
+		sections[studentID] = strings.TrimSpace(section)
+	}
+	return sections, rows.Err()
+}
+
+// UpdateResult updates a result if it is still at result.Version and bumps
+// the version. The version it replaces is kept in the result's history.
+func (r *examRepository) UpdateResult(ctx context.Context, result *models.ExamResult) error {
+	beginner, ok := r.db.Pool.(BeginPool)
+	if !ok {
+		return fmt.Errorf("transaction support is required")
+	}
+
+	tx, err := beginner.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := updateResult(ctx, tx, result); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// updateResult must run in a transaction so the history entry and the
+// update commit together
+func updateResult(ctx context.Context, q rowQuerier, result *models.ExamResult) error {
+	if err := recordResultHistory(ctx, q, result.ID, result.Version); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return versionMismatch(ctx, q, `SELECT EXISTS (SELECT 1 FROM exam_results WHERE id = $1)`, "result not found", result.ID)
+		}
+		return err
+	}
+
+	sql := `UPDATE exam_results SET marks_obtained = $1, grade = $2, percentage = $3,
+			result = $4, remarks = $5, evaluated_by = $6, evaluated_at = $7,
+			revaluation_status = $8, published = $11, published_at = $12, version = version + 1
+			WHERE id = $9 AND version = $10
+			RETURNING version, updated_at`
+
+	err := q.QueryRow(ctx, sql,
 		result.MarksObtained, result.Grade, result.Percentage, result.Result,
 		result.Remarks, result.EvaluatedBy, result.EvaluatedAt,
-		result.RevaluationStatus, result.ID,
-	)
+		result.RevaluationStatus, result.ID, result.Version,
+		result.Published, result.PublishedAt,
+	).Scan(&result.Version, &result.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return versionMismatch(ctx, q, `SELECT EXISTS (SELECT 1 FROM exam_results WHERE id = $1)`, "result not found", result.ID)
+	}
+	return err
+}
+
+// SaveResults inserts new results (ID 0) and updates existing ones in a single
+// transaction. Each row runs under its own savepoint so one bad row does not
+// poison the rest; failures are returned keyed by student ID. With
+// allOrNothing the transaction is rolled back if any row failed, otherwise the
+// successful rows are committed.
+func (r *examRepository) SaveResults(ctx context.Context, results []*models.ExamResult, allOrNothing bool) (map[int]error, error) {
+	beginner, ok := r.db.Pool.(BeginPool)
+	if !ok {
+		return nil, fmt.Errorf("transaction support is required")
+	}
+
+	tx, err := beginner.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	failures := make(map[int]error)
+	for _, result := range results {
+		if err := saveResultInSavepoint(ctx, tx, result); err != nil {
+			failures[result.StudentID] = err
+		}
+	}
+
+	if allOrNothing && len(failures) > 0 {
+		return failures, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return failures, nil
+}
+
+func saveResultInSavepoint(ctx context.Context, tx pgx.Tx, result *models.ExamResult) error {
+	sp, err := tx.Begin(ctx)
 	if err != nil {
 		return err
 	}
-	if res.RowsAffected() == 0 {
-		return fmt.Errorf("result not found")
+	defer func() {
+		_ = sp.Rollback(ctx)
+	}()
+
+	if result.ID == 0 {
+		err = insertResult(ctx, sp, result)
+	} else {
+		err = updateResult(ctx, sp, result)
 	}
-	return nil
+	if err != nil {
+		return err
+	}
+	return sp.Commit(ctx)
+}
+
+// PublishResults makes every graded, unpublished result of the exam visible
+// to students in a single statement, so students never see a half-published
+// exam. Pending results stay hidden. It returns the students whose results
+// were published.
+func (r *examRepository) PublishResults(ctx context.Context, examID int) ([]int, error) {
+	rows, err := r.db.Pool.Query(ctx, `UPDATE exam_results
+			SET published = TRUE, published_at = NOW(), version = version + 1
+			WHERE exam_id = $1 AND published = FALSE AND result <> 'pending'
+			RETURNING student_id`, examID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to publish results: %w", err)
+	}
+	defer rows.Close()
+
+	studentIDs := make([]int, 0)
+	for rows.Next() {
+		var studentID int
+		if err := rows.Scan(&studentID); err != nil {
+			return nil, fmt.Errorf("failed to publish results: %w", err)
+		}
+		studentIDs = append(studentIDs, studentID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to publish results: %w", err)
+	}
+	return studentIDs, nil
+}
+
+// ListResultNotificationRecipients returns who to email about the given
+// students' results: each student, plus verified parents who receive
+// notifications when includeParents is set. Anyone who turned off email
+// notifications in their settings is left out.
+func (r *examRepository) ListResultNotificationRecipients(ctx context.Context, collegeID int, studentIDs []int, includeParents bool) ([]*models.ResultNotificationRecipient, error) {
+	sql := `SELECT s.student_id, su.name, su.name, su.email, FALSE, COALESCE(us.language, 'en')
+			FROM students s
+			JOIN users su ON su.id = s.user_id
+			LEFT JOIN user_settings us ON us.user_id = su.id
+			WHERE s.college_id = $1 AND s.student_id = ANY($2)
+			AND COALESCE(us.email_notifications, TRUE)
+			UNION ALL
+			SELECT s.student_id, su.name, pu.name, pu.email, TRUE, COALESCE(ps.language, 'en')
+			FROM parent_student_relationships psr
+			JOIN students s ON s.student_id = psr.student_id
+			JOIN users su ON su.id = s.user_id
+			JOIN users pu ON pu.id = psr.parent_user_id AND pu.is_active = TRUE
+			LEFT JOIN user_settings ps ON ps.user_id = pu.id
+			WHERE $3 AND psr.college_id = $1 AND psr.student_id = ANY($2)
+			AND psr.is_verified = TRUE AND psr.receive_notifications = TRUE
+			AND COALESCE(ps.email_notifications, TRUE)
+			ORDER BY 1, 5`
+
+	rows, err := r.db.Pool.Query(ctx, sql, collegeID, studentIDs, includeParents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list result notification recipients: %w", err)
+	}
+	defer rows.Close()
+
+	recipients := make([]*models.ResultNotificationRecipient, 0)
+	for rows.Next() {
+		rcpt := &models.ResultNotificationRecipient{}
+		if err := rows.Scan(&rcpt.StudentID, &rcpt.StudentName, &rcpt.Name, &rcpt.Email, &rcpt.IsParent, &rcpt.Locale); err != nil {
+			return nil, fmt.Errorf("failed to scan result notification recipient: %w", err)
+		}
+		recipients = append(recipients, rcpt)
+	}
+	return recipients, rows.Err()
+}
+
+// ListResultInboxUserIDs returns the user IDs whose in-app inbox should hear
+// about the given students' results. Unlike the email recipients, the email
+// setting doesn't apply here; parents still need a verified link that receives
+// notifications.
+func (r *examRepository) ListResultInboxUserIDs(ctx context.Context, collegeID int, studentIDs []int, includeParents bool) ([]int, error) {
+	sql := `SELECT s.user_id
+			FROM students s
+			WHERE s.college_id = $1 AND s.student_id = ANY($2)
+			UNION
+			SELECT psr.parent_user_id
+			FROM parent_student_relationships psr
+			JOIN users pu ON pu.id = psr.parent_user_id AND pu.is_active = TRUE
+			WHERE $3 AND psr.college_id = $1 AND psr.student_id = ANY($2)
+			AND psr.is_verified = TRUE AND psr.receive_notifications = TRUE`
+
+	rows, err := r.db.Pool.Query(ctx, sql, collegeID, studentIDs, includeParents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list result inbox users: %w", err)
+	}
+	defer rows.Close()
+
+	userIDs := make([]int, 0)
+	for rows.Next() {
+		var userID int
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan result inbox user: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, rows.Err()
+}
+
+// versionMismatch explains why a versioned UPDATE matched no rows: the row is
+// either gone (notFound) or was changed by another writer (ErrVersionConflict).
+func versionMismatch(ctx context.Context, q rowQuerier, existsSQL, notFound string, args ...any) error {
+	var exists bool
+	if err := q.QueryRow(ctx, existsSQL, args...).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return errors.New(notFound)
+	}
+	return ErrVersionConflict
+}
+
+// GetStudentResults retrieves all results for a student. With publishedOnly,
+// results that have not been published yet are left out. Course and date
+// filters apply to the result's exam.
+func (r *examRepository) GetStudentResults(ctx context.Context, studentID, collegeID int, publishedOnly bool, filter models.ExamResultFilter) ([]*models.ExamResult, error) {
+	return r.getStudentResults(ctx, studentID, collegeID, publishedOnly, filter, nil, 0)
+}
+
+// CountStudentResults counts the results GetStudentResults returns without a
+// filter
+func (r *examRepository) CountStudentResults(ctx context.Context, studentID, collegeID int, publishedOnly bool) (int, error) {
+	sql := `SELECT COUNT(*) FROM exam_results WHERE student_id = $1 AND college_id = $2`
+	if publishedOnly {
+		sql += " AND published = TRUE"
+	}
+
+	var count int
+	if err := r.db.Pool.QueryRow(ctx, sql, studentID, collegeID).Scan(&count); err != nil {
+		if isExamRelationMissing(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetStudentResultsAfter pages through a student's results by cursor, newest
+// first, returning up to limit results and the cursor of the next page (empty
+// after the last page). Cursors follow the default order only, so a filter
+// with a sort is rejected with ErrInvalidResultSort.
+func (r *examRepository) GetStudentResultsAfter(ctx context.Context, studentID, collegeID int, publishedOnly bool, filter models.ExamResultFilter, cursor string, limit int) ([]*models.ExamResult, string, error) {
+	if filter.SortBy != "" || filter.SortOrder != "" {
+		return nil, "", fmt.Errorf("%w: cursor pagination only supports the default order", ErrInvalidResultSort)
+	}
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	results, err := r.getStudentResults(ctx, studentID, collegeID, publishedOnly, filter, after, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(results) <= limit {
+		return results, "", nil
+	}
+	results = results[:limit]
+	last := results[limit-1]
+	return results, encodeCursor(last.CreatedAt, last.ID), nil
 }
 
-// GetStudentResults retrieves all results for a student
-func (r *examRepository) GetStudentResults(ctx context.Context, studentID, collegeID int) ([]*models.ExamResult, error) {
+// getStudentResults runs the result listing; after and a positive limit are
+// only used by keyset pagination.
+func (r *examRepository) getStudentResults(ctx context.Context, studentID, collegeID int, publishedOnly bool, filter models.ExamResultFilter, after *keysetCursor, limit int) ([]*models.ExamResult, error) {
+	orderBy := "created_at DESC, id DESC"
+	if filter.SortBy != "" || filter.SortOrder != "" {
+		sortBy := filter.SortBy
+		if sortBy == "" {
+			sortBy = "created_at"
+		}
+		column, ok := examResultSortColumns[sortBy]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown field %q", ErrInvalidResultSort, filter.SortBy)
+		}
+		direction := "DESC"
+		switch strings.ToLower(filter.SortOrder) {
+		case "", "desc":
+		case "asc":
+			direction = "ASC"
+		default:
+			return nil, fmt.Errorf("%w: order must be asc or desc", ErrInvalidResultSort)
+		}
+		// Ungraded results have no marks and sort last either way
+		orderBy = fmt.Sprintf("%s %s NULLS LAST, created_at DESC, id DESC", column, direction)
+	}
+
 	sql := `SELECT id, exam_id, student_id, college_id, marks_obtained, grade, percentage,
-			result, remarks, evaluated_by, evaluated_at, revaluation_status, created_at, updated_at
-			FROM exam_results WHERE student_id = $1 AND college_id = $2 ORDER BY created_at DESC`
+			result, remarks, evaluated_by, evaluated_at, revaluation_status, version,
+			published, published_at, created_at, updated_at
+			FROM exam_results WHERE student_id = $1 AND college_id = $2`
+	args := []any{studentID, collegeID}
+	if publishedOnly {
+		sql += " AND published = TRUE"
+	}
+	if filter.Result != nil {
+		args = append(args, *filter.Result)
+		sql += fmt.Sprintf(" AND result = $%d", len(args))
+	}
 
-	rows, err := r.db.Pool.Query(ctx, sql, studentID, collegeID)
+	var examConds []string
+	if filter.CourseID != nil {
+		args = append(args, *filter.CourseID)
+		examConds = append(examConds, fmt.Sprintf("course_id = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		examConds = append(examConds, fmt.Sprintf("start_time >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		examConds = append(examConds, fmt.Sprintf("start_time < $%d", len(args)))
+	}
+	if len(examConds) > 0 {
+		sql += " AND exam_id IN (SELECT id FROM exams WHERE college_id = $2 AND " + strings.Join(examConds, " AND ") + ")"
+	}
+	if after != nil {
+		args = append(args, after.At, after.ID)
+		sql += fmt.Sprintf(" AND (created_at, id) < ($%d, $%d)", len(args)-1, len(args))
+	}
+	sql += " ORDER BY " + orderBy
+	if limit > 0 {
+		args = append(args, limit)
+		sql += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := r.db.Pool.Query(ctx, sql, args...)
 	if err != nil {
 		if isExamRelationMissing(err) {
 			return []*models.ExamResult{}, nil
@@ -431,7 +1587,8 @@ func (r *examRepository) GetStudentResults(ctx context.Context, studentID, colle
 		err := rows.Scan(
 			&res.ID, &res.ExamID, &res.StudentID, &res.CollegeID, &res.MarksObtained,
 			&res.Grade, &res.Percentage, &res.Result, &res.Remarks, &res.EvaluatedBy,
-			&res.EvaluatedAt, &res.RevaluationStatus, &res.CreatedAt, &res.UpdatedAt,
+			&res.EvaluatedAt, &res.RevaluationStatus, &res.Version, &res.Published, &res.PublishedAt,
+			&res.CreatedAt, &res.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -441,16 +1598,25 @@ func (r *examRepository) GetStudentResults(ctx context.Context, studentID, colle
 	return results, nil
 }
 
-// CreateRevaluationRequest creates a revaluation request
+// CreateRevaluationRequest creates a revaluation request. A request carrying a
+// fee assignment is only created once the assignment's payments cover its fee;
+// see createPaidRevaluationRequest.
 func (r *examRepository) CreateRevaluationRequest(ctx context.Context, request *models.RevaluationRequest) error {
+	if request.FeeAssignmentID == nil {
+		return insertRevaluationRequest(ctx, r.db.Pool, request)
+	}
+	return r.createPaidRevaluationRequest(ctx, request)
+}
+
+func insertRevaluationRequest(ctx context.Context, q rowQuerier, request *models.RevaluationRequest) error {
 	sql := `INSERT INTO revaluation_requests (exam_result_id, student_id, college_id,
-			reason, previous_marks, requested_at)
-			VALUES ($1, $2, $3, $4, $5, NOW())
+			reason, previous_marks, fee_assignment_id, fee_amount, requested_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
 			RETURNING id, status, created_at, updated_at`
 
-	return r.db.Pool.QueryRow(ctx, sql,
+	return q.QueryRow(ctx, sql,
 		request.ExamResultID, request.StudentID, request.CollegeID,
-		request.Reason, request.PreviousMarks,
+		request.Reason, request.PreviousMarks, request.FeeAssignmentID, request.FeeAmount,
 	).Scan(&request.ID, &request.Status, &request.CreatedAt, &request.UpdatedAt)
 }
 
@@ -458,7 +1624,7 @@ func (r *examRepository) CreateRevaluationRequest(ctx context.Context, request *
 func (r *examRepository) GetRevaluationRequest(ctx context.Context, requestID int) (*models.RevaluationRequest, error) {
 	sql := `SELECT id, exam_result_id, student_id, college_id, reason, status,
 			previous_marks, revised_marks, reviewed_by, review_comments,
-			requested_at, reviewed_at, created_at, updated_at
+			requested_at, reviewed_at, fee_assignment_id, fee_amount, created_at, updated_at
 			FROM revaluation_requests WHERE id = $1`
 
 	req := &models.RevaluationRequest{}
@@ -466,7 +1632,7 @@ func (r *examRepository) GetRevaluationRequest(ctx context.Context, requestID in
 		&req.ID, &req.ExamResultID, &req.StudentID, &req.CollegeID, &req.Reason,
 		&req.Status, &req.PreviousMarks, &req.RevisedMarks, &req.ReviewedBy,
 		&req.ReviewComments, &req.RequestedAt, &req.ReviewedAt,
-		&req.CreatedAt, &req.UpdatedAt,
+		&req.FeeAssignmentID, &req.FeeAmount, &req.CreatedAt, &req.UpdatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("request not found: %w", err)
@@ -474,45 +1640,76 @@ func (r *examRepository) GetRevaluationRequest(ctx context.Context, requestID in
 	return req, nil
 }
 
-// ListRevaluationRequests retrieves revaluation requests with filters
-func (r *examRepository) ListRevaluationRequests(ctx context.Context, collegeID int, filters map[string]any) ([]*models.RevaluationRequest, error) {
+// ListRevaluationRequests retrieves the revaluation requests matching filter,
+// newest first
+func (r *examRepository) ListRevaluationRequests(ctx context.Context, collegeID int, filter models.RevaluationRequestFilter) ([]*models.RevaluationRequest, error) {
 	sql := `SELECT id, exam_result_id, student_id, college_id, reason, status,
 			previous_marks, revised_marks, reviewed_by, review_comments,
-			requested_at, reviewed_at, created_at, updated_at
+			requested_at, reviewed_at, fee_assignment_id, fee_amount, created_at, updated_at
 			FROM revaluation_requests WHERE college_id = $1`
-	args := []any{collegeID}
-	argCount := 1
+	where, args := revaluationFilterSQL("", filter, []any{collegeID})
+	sql += where + " ORDER BY requested_at DESC"
 
-	if status, ok := filters["status"]; ok {
-		argCount++
-		sql += fmt.Sprintf(" AND status = $%d", argCount)
-		args = append(args, status)
+	rows, err := r.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		if isExamRelationMissing(err) {
+			return []*models.RevaluationRequest{}, nil
+		}
+		return nil, err
 	}
-	if studentID, ok := filters["student_id"]; ok {
-		argCount++
-		sql += fmt.Sprintf(" AND student_id = $%d", argCount)
-		args = append(args, studentID)
+	defer rows.Close()
+
+	var requests []*models.RevaluationRequest
+	for rows.Next() {
+		req := &models.RevaluationRequest{}
+		err := rows.Scan(
+			&req.ID, &req.ExamResultID, &req.StudentID, &req.CollegeID, &req.Reason,
+			&req.Status, &req.PreviousMarks, &req.RevisedMarks, &req.ReviewedBy,
+			&req.ReviewComments, &req.RequestedAt, &req.ReviewedAt,
+			&req.FeeAssignmentID, &req.FeeAmount, &req.CreatedAt, &req.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, req)
 	}
+	return requests, nil
+}
 
-	sql += " ORDER BY requested_at DESC"
+// ListRevaluationRequestDetails is ListRevaluationRequests with each request's
+// exam title, student name and roll number, and the result's current marks
+func (r *examRepository) ListRevaluationRequestDetails(ctx context.Context, collegeID int, filter models.RevaluationRequestFilter) ([]*models.RevaluationRequestDetail, error) {
+	sql := `SELECT rr.id, rr.exam_result_id, rr.student_id, rr.college_id, rr.reason, rr.status,
+			rr.previous_marks, rr.revised_marks, rr.reviewed_by, rr.review_comments,
+			rr.requested_at, rr.reviewed_at, rr.fee_assignment_id, rr.fee_amount, rr.created_at, rr.updated_at,
+			er.exam_id, e.title, COALESCE(u.name, ''), COALESCE(s.roll_no, ''), er.marks_obtained
+			FROM revaluation_requests rr
+			JOIN exam_results er ON er.id = rr.exam_result_id AND er.college_id = rr.college_id
+			JOIN exams e ON e.id = er.exam_id AND e.college_id = rr.college_id
+			LEFT JOIN students s ON s.student_id = rr.student_id
+			LEFT JOIN users u ON u.id = s.user_id
+			WHERE rr.college_id = $1`
+	where, args := revaluationFilterSQL("rr.", filter, []any{collegeID})
+	sql += where + " ORDER BY rr.requested_at DESC"
 
 	rows, err := r.db.Pool.Query(ctx, sql, args...)
 	if err != nil {
 		if isExamRelationMissing(err) {
-			return []*models.RevaluationRequest{}, nil
+			return []*models.RevaluationRequestDetail{}, nil
 		}
 		return nil, err
 	}
 	defer rows.Close()
 
-	var requests []*models.RevaluationRequest
+	var requests []*models.RevaluationRequestDetail
 	for rows.Next() {
-		req := &models.RevaluationRequest{}
+		req := &models.RevaluationRequestDetail{}
 		err := rows.Scan(
 			&req.ID, &req.ExamResultID, &req.StudentID, &req.CollegeID, &req.Reason,
 			&req.Status, &req.PreviousMarks, &req.RevisedMarks, &req.ReviewedBy,
 			&req.ReviewComments, &req.RequestedAt, &req.ReviewedAt,
-			&req.CreatedAt, &req.UpdatedAt,
+			&req.FeeAssignmentID, &req.FeeAmount, &req.CreatedAt, &req.UpdatedAt,
+			&req.ExamID, &req.ExamTitle, &req.StudentName, &req.RollNo, &req.CurrentMarks,
 		)
 		if err != nil {
 			return nil, err
@@ -522,6 +1719,34 @@ func (r *examRepository) ListRevaluationRequests(ctx context.Context, collegeID
 	return requests, nil
 }
 
+// revaluationFilterSQL returns the conditions for filter on
+// revaluation_requests, whose columns carry prefix, and args extended with
+// their values
+func revaluationFilterSQL(prefix string, filter models.RevaluationRequestFilter, args []any) (string, []any) {
+	var sql string
+	if filter.Status != nil {
+		args = append(args, *filter.Status)
+		sql += fmt.Sprintf(" AND %sstatus = $%d", prefix, len(args))
+	}
+	if filter.StudentID != nil {
+		args = append(args, *filter.StudentID)
+		sql += fmt.Sprintf(" AND %sstudent_id = $%d", prefix, len(args))
+	}
+	if filter.ExamID != nil {
+		args = append(args, *filter.ExamID)
+		sql += fmt.Sprintf(" AND %sexam_result_id IN (SELECT id FROM exam_results WHERE exam_id = $%d)", prefix, len(args))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		sql += fmt.Sprintf(" AND %srequested_at >= $%d", prefix, len(args))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		sql += fmt.Sprintf(" AND %srequested_at < $%d", prefix, len(args))
+	}
+	return sql, args
+}
+
 func isExamRelationMissing(err error) bool {
 	if err == nil {
 		return false
@@ -575,7 +1800,10 @@ func (r *examRepository) GetRoomByID(ctx context.Context, collegeID, roomID int)
 		&room.Location, &room.Facilities, &room.IsActive, &room.CreatedAt, &room.UpdatedAt,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("room not found: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrExamRoomNotFound
+		}
+		return nil, fmt.Errorf("GetRoomByID: failed to query room: %w", err)
 	}
 	return room, nil
 }
@@ -643,10 +1871,11 @@ func (r *examRepository) DeleteRoom(ctx context.Context, collegeID, roomID int)
 	return nil
 }
 
-// CheckRoomAvailability checks if a room is available for a time slot
-func (r *examRepository) CheckRoomAvailability(ctx context.Context, roomID int, startTime, endTime string) (bool, error) {
-	sql := `SELECT COUNT(*) FROM exams
+// roomBookingsSQL counts the live exams holding a room at any point of the
+// window ($2, $3)
+const roomBookingsSQL = `SELECT COUNT(*) FROM exams
 			WHERE room_id = $1
+			AND deleted_at IS NULL
 			AND status NOT IN ('cancelled', 'completed')
 			AND (
 				(start_time <= $2 AND end_time >= $2) OR
@@ -654,10 +1883,377 @@ func (r *examRepository) CheckRoomAvailability(ctx context.Context, roomID int,
 				(start_time >= $2 AND end_time <= $3)
 			)`
 
+// CheckRoomAvailability checks if a room is available for a time slot
+func (r *examRepository) CheckRoomAvailability(ctx context.Context, roomID int, startTime, endTime string) (bool, error) {
 	var count int
-	err := r.db.Pool.QueryRow(ctx, sql, roomID, startTime, endTime).Scan(&count)
+	err := r.db.Pool.QueryRow(ctx, roomBookingsSQL, roomID, startTime, endTime).Scan(&count)
 	if err != nil {
 		return false, err
 	}
 	return count == 0, nil
 }
+
+// ListRoomBookings returns each room's exam count and booked hours within
+// [from, to), leaving AvailableHours and UtilizationPercent to the caller.
+// Unlike roomBookingsSQL it counts completed exams, since they did use the
+// room, and ignores exams that only touch the window. Inactive rooms are
+// listed only when they were booked.
+func (r *examRepository) ListRoomBookings(ctx context.Context, collegeID int, from, to time.Time) ([]*models.RoomUtilization, error) {
+	sql := `SELECT r.id AS room_id, r.room_number, r.room_name,
+			COUNT(e.id) AS exam_count,
+			COALESCE(SUM(EXTRACT(EPOCH FROM LEAST(e.end_time, $3) - GREATEST(e.start_time, $2)) / 3600), 0)::float8 AS booked_hours
+			FROM exam_rooms r
+			LEFT JOIN exams e ON e.room_id = r.id AND e.college_id = r.college_id
+				AND e.deleted_at IS NULL
+				AND e.status <> 'cancelled'
+				AND e.start_time < $3 AND e.end_time > $2
+			WHERE r.college_id = $1
+			GROUP BY r.id, r.room_number, r.room_name, r.is_active
+			HAVING r.is_active OR COUNT(e.id) > 0`
+
+	rows, err := r.db.Pool.Query(ctx, sql, collegeID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rooms []*models.RoomUtilization
+	for rows.Next() {
+		room := &models.RoomUtilization{}
+		if err := rows.Scan(&room.RoomID, &room.RoomNumber, &room.RoomName, &room.ExamCount, &room.BookedHours); err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, room)
+	}
+	return rooms, rows.Err()
+}
+
+// AssignInvigilator adds a supervisor to an exam
+func (r *examRepository) AssignInvigilator(ctx context.Context, invigilator *models.ExamInvigilator) error {
+	sql := `INSERT INTO exam_invigilators (exam_id, college_id, room_id, user_id, assigned_by)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (exam_id, user_id) DO NOTHING
+			RETURNING id, created_at`
+
+	err := r.db.Pool.QueryRow(ctx, sql,
+		invigilator.ExamID, invigilator.CollegeID, invigilator.RoomID,
+		invigilator.UserID, invigilator.AssignedBy,
+	).Scan(&invigilator.ID, &invigilator.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrInvigilatorAssigned
+	}
+	return err
+}
+
+// ListInvigilators lists an exam's supervisors with their names and rooms
+func (r *examRepository) ListInvigilators(ctx context.Context, collegeID, examID int) ([]*models.ExamInvigilator, error) {
+	sql := `SELECT ei.id, ei.exam_id, ei.college_id, ei.room_id, er.room_number,
+				ei.user_id, u.name, u.email, ei.assigned_by, ei.created_at
+			FROM exam_invigilators ei
+			JOIN users u ON u.id = ei.user_id
+			LEFT JOIN exam_rooms er ON er.id = ei.room_id
+			WHERE ei.exam_id = $1 AND ei.college_id = $2
+			ORDER BY er.room_number NULLS LAST, u.name`
+
+	rows, err := r.db.Pool.Query(ctx, sql, examID, collegeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invigilators := []*models.ExamInvigilator{}
+	for rows.Next() {
+		inv := &models.ExamInvigilator{}
+		err := rows.Scan(
+			&inv.ID, &inv.ExamID, &inv.CollegeID, &inv.RoomID, &inv.RoomNumber,
+			&inv.UserID, &inv.Name, &inv.Email, &inv.AssignedBy, &inv.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		invigilators = append(invigilators, inv)
+	}
+	return invigilators, rows.Err()
+}
+
+// RemoveInvigilator unassigns a supervisor from an exam
+func (r *examRepository) RemoveInvigilator(ctx context.Context, collegeID, examID, userID int) error {
+	sql := `DELETE FROM exam_invigilators WHERE exam_id = $1 AND user_id = $2 AND college_id = $3`
+	result, err := r.db.Pool.Exec(ctx, sql, examID, userID, collegeID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("invigilator not found")
+	}
+	return nil
+}
+
+// ListInvigilatorConflicts returns the other live exams the user supervises
+// that overlap the given window. examID is excluded so re-checking an
+// existing assignment does not report itself.
+func (r *examRepository) ListInvigilatorConflicts(ctx context.Context, userID, examID int, startTime, endTime time.Time) ([]*models.InvigilatorConflict, error) {
+	sql := `SELECT e.id, e.title, e.start_time, e.end_time
+			FROM exam_invigilators ei
+			JOIN exams e ON e.id = ei.exam_id
+			WHERE ei.user_id = $1 AND ei.exam_id <> $2
+			AND e.deleted_at IS NULL AND e.status <> 'cancelled'
+			AND e.start_time < $4 AND $3 < e.end_time
+			ORDER BY e.start_time`
+
+	rows, err := r.db.Pool.Query(ctx, sql, userID, examID, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	conflicts := []*models.InvigilatorConflict{}
+	for rows.Next() {
+		conflict := &models.InvigilatorConflict{}
+		if err := rows.Scan(&conflict.ExamID, &conflict.Title, &conflict.StartTime, &conflict.EndTime); err != nil {
+			return nil, err
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts, rows.Err()
+}
+
+// ListAvailableInvigilators lists active faculty teaching in the college who
+// supervise no live exam overlapping the given window
+func (r *examRepository) ListAvailableInvigilators(ctx context.Context, collegeID int, startTime, endTime time.Time) ([]*models.InvigilatorCandidate, error) {
+	sql := `SELECT DISTINCT u.id, u.name, u.email
+			FROM users u
+			JOIN courses c ON c.instructor_id = u.id
+			WHERE c.college_id = $1 AND u.is_active
+			AND NOT EXISTS (
+				SELECT 1 FROM exam_invigilators ei
+				JOIN exams e ON e.id = ei.exam_id
+				WHERE ei.user_id = u.id
+				AND e.deleted_at IS NULL AND e.status <> 'cancelled'
+				AND e.start_time < $3 AND $2 < e.end_time
+			)
+			ORDER BY u.name`
+
+	rows, err := r.db.Pool.Query(ctx, sql, collegeID, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := []*models.InvigilatorCandidate{}
+	for rows.Next() {
+		candidate := &models.InvigilatorCandidate{}
+		if err := rows.Scan(&candidate.UserID, &candidate.Name, &candidate.Email); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, rows.Err()
+}
+
+// SaveQuestionPaper stores the paper for its exam and set, replacing any
+// earlier upload. It returns the object key of the replaced file, or "" if
+// there was none, so the caller can remove it from storage.
+func (r *examRepository) SaveQuestionPaper(ctx context.Context, paper *models.ExamQuestionPaper) (string, error) {
+	sql := `WITH previous AS (
+				SELECT object_key FROM exam_question_papers
+				WHERE exam_id = $2 AND paper_set = $3 AND college_id = $1
+			)
+			INSERT INTO exam_question_papers (college_id, exam_id, paper_set, object_key, file_name,
+				content_type, size_bytes, uploaded_by)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			ON CONFLICT (exam_id, paper_set) DO UPDATE SET
+				object_key = EXCLUDED.object_key, file_name = EXCLUDED.file_name,
+				content_type = EXCLUDED.content_type, size_bytes = EXCLUDED.size_bytes,
+				uploaded_by = EXCLUDED.uploaded_by, updated_at = NOW()
+			RETURNING id, created_at, updated_at, COALESCE((SELECT object_key FROM previous), '')`
+
+	var replaced string
+	err := r.db.Pool.QueryRow(ctx, sql,
+		paper.CollegeID, paper.ExamID, paper.PaperSet, paper.ObjectKey, paper.FileName,
+		paper.ContentType, paper.SizeBytes, paper.UploadedBy,
+	).Scan(&paper.ID, &paper.CreatedAt, &paper.UpdatedAt, &replaced)
+	if err != nil {
+		return "", err
+	}
+	return replaced, nil
+}
+
+// GetQuestionPaper retrieves the paper uploaded for one set of an exam
+func (r *examRepository) GetQuestionPaper(ctx context.Context, collegeID, examID, paperSet int) (*models.ExamQuestionPaper, error) {
+	sql := `SELECT id, college_id, exam_id, paper_set, object_key, file_name, content_type,
+			size_bytes, uploaded_by, created_at, updated_at
+			FROM exam_question_papers WHERE exam_id = $1 AND paper_set = $2 AND college_id = $3`
+
+	paper := &models.ExamQuestionPaper{}
+	err := r.db.Pool.QueryRow(ctx, sql, examID, paperSet, collegeID).Scan(
+		&paper.ID, &paper.CollegeID, &paper.ExamID, &paper.PaperSet, &paper.ObjectKey,
+		&paper.FileName, &paper.ContentType, &paper.SizeBytes, &paper.UploadedBy,
+		&paper.CreatedAt, &paper.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrQuestionPaperNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return paper, nil
+}
+
+// ListQuestionPapers lists the papers uploaded for an exam by set
+func (r *examRepository) ListQuestionPapers(ctx context.Context, collegeID, examID int) ([]*models.ExamQuestionPaper, error) {
+	sql := `SELECT id, college_id, exam_id, paper_set, object_key, file_name, content_type,
+			size_bytes, uploaded_by, created_at, updated_at
+			FROM exam_question_papers WHERE exam_id = $1 AND college_id = $2
+			ORDER BY paper_set`
+
+	rows, err := r.db.Pool.Query(ctx, sql, examID, collegeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	papers := []*models.ExamQuestionPaper{}
+	for rows.Next() {
+		paper := &models.ExamQuestionPaper{}
+		err := rows.Scan(
+			&paper.ID, &paper.CollegeID, &paper.ExamID, &paper.PaperSet, &paper.ObjectKey,
+			&paper.FileName, &paper.ContentType, &paper.SizeBytes, &paper.UploadedBy,
+			&paper.CreatedAt, &paper.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		papers = append(papers, paper)
+	}
+	return papers, rows.Err()
+}
+
+// GrantExtension records extra time for the student's enrollment and adds it
+// to the enrollment's running total, which may not exceed maxTotalMinutes.
+// It returns the new total.
+func (r *examRepository) GrantExtension(ctx context.Context, extension *models.ExamTimeExtension, maxTotalMinutes int) (int, error) {
+	beginner, ok := r.db.Pool.(BeginPool)
+	if !ok {
+		return 0, fmt.Errorf("transaction support is required")
+	}
+
+	tx, err := beginner.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var total int
+	err = tx.QueryRow(ctx, `SELECT id, extension_minutes FROM exam_enrollments
+			WHERE exam_id = $1 AND student_id = $2 AND college_id = $3
+			FOR UPDATE`,
+		extension.ExamID, extension.StudentID, extension.CollegeID).Scan(&extension.EnrollmentID, &total)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrEnrollmentNotFound
+		}
+		return 0, fmt.Errorf("failed to look up enrollment: %w", err)
+	}
+	if total+extension.Minutes > maxTotalMinutes {
+		return 0, fmt.Errorf("%w: %d of %d minutes already granted", ErrExtensionCapExceeded, total, maxTotalMinutes)
+	}
+
+	err = tx.QueryRow(ctx, `INSERT INTO exam_time_extensions (college_id, exam_id, enrollment_id, student_id,
+				extra_minutes, reason, granted_by)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id, created_at`,
+		extension.CollegeID, extension.ExamID, extension.EnrollmentID, extension.StudentID,
+		extension.Minutes, extension.Reason, extension.GrantedBy,
+	).Scan(&extension.ID, &extension.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to record extension: %w", err)
+	}
+
+	err = tx.QueryRow(ctx, `UPDATE exam_enrollments SET extension_minutes = extension_minutes + $1, updated_at = NOW()
+			WHERE id = $2 RETURNING extension_minutes`,
+		extension.Minutes, extension.EnrollmentID).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update enrollment: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// ListExtensions lists the extensions granted in an exam, oldest first
+func (r *examRepository) ListExtensions(ctx context.Context, collegeID, examID int) ([]*models.ExamTimeExtension, error) {
+	sql := `SELECT id, college_id, exam_id, enrollment_id, student_id, extra_minutes, reason, granted_by, created_at
+			FROM exam_time_extensions WHERE exam_id = $1 AND college_id = $2
+			ORDER BY created_at, id`
+
+	rows, err := r.db.Pool.Query(ctx, sql, examID, collegeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	extensions := []*models.ExamTimeExtension{}
+	for rows.Next() {
+		extension := &models.ExamTimeExtension{}
+		err := rows.Scan(
+			&extension.ID, &extension.CollegeID, &extension.ExamID, &extension.EnrollmentID,
+			&extension.StudentID, &extension.Minutes, &extension.Reason, &extension.GrantedBy, &extension.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		extensions = append(extensions, extension)
+	}
+	return extensions, rows.Err()
+}
+
+// RecordProctoringEvent stores an event reported for a student's enrollment
+func (r *examRepository) RecordProctoringEvent(ctx context.Context, event *models.ExamProctoringEvent) error {
+	sql := `INSERT INTO exam_proctoring_events (college_id, exam_id, enrollment_id, student_id,
+				event_type, occurred_at, metadata)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id, created_at`
+
+	err := r.db.Pool.QueryRow(ctx, sql,
+		event.CollegeID, event.ExamID, event.EnrollmentID, event.StudentID,
+		event.EventType, event.OccurredAt, event.Metadata,
+	).Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record proctoring event: %w", err)
+	}
+	return nil
+}
+
+// ListProctoringEvents lists the events reported during a student's exam in
+// the order they occurred
+func (r *examRepository) ListProctoringEvents(ctx context.Context, collegeID, examID, studentID int) ([]*models.ExamProctoringEvent, error) {
+	sql := `SELECT id, college_id, exam_id, enrollment_id, student_id, event_type, occurred_at, metadata, created_at
+			FROM exam_proctoring_events
+			WHERE exam_id = $1 AND student_id = $2 AND college_id = $3
+			ORDER BY occurred_at, id`
+
+	rows, err := r.db.Pool.Query(ctx, sql, examID, studentID, collegeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*models.ExamProctoringEvent{}
+	for rows.Next() {
+		event := &models.ExamProctoringEvent{}
+		err := rows.Scan(
+			&event.ID, &event.CollegeID, &event.ExamID, &event.EnrollmentID, &event.StudentID,
+			&event.EventType, &event.OccurredAt, &event.Metadata, &event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,1096 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupExamTest(t *testing.T) (pgxmock.PgxPoolIface, ExamRepository, context.Context) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		mock.Close()
+	})
+
+	return mock, NewExamRepository(&DB{Pool: mock}), context.Background()
+}
+
+func testExam() *models.Exam {
+	return &models.Exam{
+		ID:                7,
+		CollegeID:         1,
+		Title:             "Midterm",
+		ExamType:          "midterm",
+		StartTime:         time.Now(),
+		EndTime:           time.Now().Add(2 * time.Hour),
+		Duration:          120,
+		TotalMarks:        100,
+		PassingMarks:      40,
+		Status:            "scheduled",
+		QuestionPaperSets: 1,
+		Version:           3,
+	}
+}
+
+func TestUpdateExam_BumpsVersion(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	exam := testExam()
+	now := time.Now()
+
+	mock.ExpectQuery(`UPDATE exams SET .* WHERE id = \$16 AND college_id = \$17 AND version = \$18`).
+		WithArgs(
+			pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(),
+			pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(),
+			pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(),
+			7, 1, 3,
+		).
+		WillReturnRows(pgxmock.NewRows([]string{"version", "updated_at"}).AddRow(4, now))
+
+	err := repo.UpdateExam(ctx, exam)
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, exam.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateExam_StaleVersionConflicts(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+
+	mock.ExpectQuery(`UPDATE exams SET`).
+		WithArgs(
+			pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(),
+			pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(),
+			pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(),
+			7, 1, 3,
+		).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM exams WHERE id = \$1 AND college_id = \$2 AND deleted_at IS NULL\)`).
+		WithArgs(7, 1).
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+
+	err := repo.UpdateExam(ctx, testExam())
+
+	assert.ErrorIs(t, err, ErrVersionConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateExam_MissingExamIsNotAConflict(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+
+	mock.ExpectQuery(`UPDATE exams SET`).
+		WithArgs(
+			pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(),
+			pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(),
+			pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(),
+			7, 1, 3,
+		).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectQuery(`SELECT EXISTS`).
+		WithArgs(7, 1).
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+
+	err := repo.UpdateExam(ctx, testExam())
+
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrVersionConflict)
+	assert.EqualError(t, err, "exam not found")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateResult_StaleVersionConflicts(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	marks := 55.0
+	result := &models.ExamResult{ID: 11, MarksObtained: &marks, Result: "pass", RevaluationStatus: "none", Version: 2}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT .* FROM exam_results WHERE id = \$1 AND version = \$2\s+FOR UPDATE`).
+		WithArgs(11, 2).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM exam_results WHERE id = \$1\)`).
+		WithArgs(11).
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	err := repo.UpdateResult(ctx, result)
+
+	assert.ErrorIs(t, err, ErrVersionConflict)
+	assert.Equal(t, 2, result.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateResult_RecordsHistory(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	oldMarks, marks := 48.0, 55.0
+	grade := "C"
+	now := time.Now()
+	result := &models.ExamResult{ID: 11, MarksObtained: &marks, Result: "pass", RevaluationStatus: "completed", Version: 2}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT .* FROM exam_results WHERE id = \$1 AND version = \$2\s+FOR UPDATE`).
+		WithArgs(11, 2).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "college_id", "exam_id", "student_id", "version", "marks_obtained", "grade",
+			"percentage", "result", "evaluated_by", "evaluated_at"}).
+			AddRow(11, 1, 4, 7, 2, &oldMarks, &grade, &oldMarks, "pass", (*int)(nil), (*time.Time)(nil)))
+	mock.ExpectQuery(`SELECT entry_hash FROM exam_result_history\s+WHERE result_id = \$1 ORDER BY id DESC LIMIT 1`).
+		WithArgs(11).
+		WillReturnRows(pgxmock.NewRows([]string{"entry_hash"}).AddRow("abc"))
+	mock.ExpectQuery(`INSERT INTO exam_result_history .* RETURNING id`).
+		WithArgs(11, 1, 4, 7, 2, &oldMarks, &grade, &oldMarks, "pass", (*int)(nil), (*time.Time)(nil),
+			pgxmock.AnyArg(), "abc", pgxmock.AnyArg()).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(5)))
+	mock.ExpectQuery(`UPDATE exam_results SET .* WHERE id = \$9 AND version = \$10`).
+		WithArgs(
+			pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(),
+			pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(),
+			11, 2, false, (*time.Time)(nil),
+		).
+		WillReturnRows(pgxmock.NewRows([]string{"version", "updated_at"}).AddRow(3, now))
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.UpdateResult(ctx, result))
+	assert.Equal(t, 3, result.Version)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestResultHistoryHash(t *testing.T) {
+	marks := 48.0
+	entry := &models.ExamResultHistoryEntry{ResultID: 11, CollegeID: 1, ExamID: 4, StudentID: 7, Version: 2,
+		MarksObtained: &marks, Result: "pass", SupersededAt: time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)}
+	hash := ResultHistoryHash(entry)
+	assert.Len(t, hash, 64)
+
+	// The database reads DECIMAL(10,2) marks back at two decimal places
+	stored := 48.000
+	entry.MarksObtained = &stored
+	assert.Equal(t, hash, ResultHistoryHash(entry))
+
+	changed := 49.0
+	entry.MarksObtained = &changed
+	assert.NotEqual(t, hash, ResultHistoryHash(entry))
+
+	entry.MarksObtained = &marks
+	entry.PrevHash = "abc"
+	assert.NotEqual(t, hash, ResultHistoryHash(entry), "the hash covers the previous entry")
+}
+
+func TestDeleteExam_SoftDeletes(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+
+	mock.ExpectExec(`UPDATE exams SET deleted_at = NOW\(\) WHERE id = \$1 AND college_id = \$2 AND deleted_at IS NULL`).
+		WithArgs(7, 1).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	require.NoError(t, repo.DeleteExam(ctx, 1, 7))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRestoreExam_RequiresDeletedExam(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+
+	mock.ExpectExec(`UPDATE exams SET deleted_at = NULL WHERE id = \$1 AND college_id = \$2 AND deleted_at IS NOT NULL`).
+		WithArgs(7, 1).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	err := repo.RestoreExam(ctx, 1, 7)
+
+	assert.EqualError(t, err, "deleted exam not found")
+	assert.ErrorIs(t, err, ErrExamNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetExamWithDetails(t *testing.T) {
+	columns := []string{
+		"id", "college_id", "course_id", "title", "description", "exam_type", "start_time",
+		"end_time", "duration", "total_marks", "passing_marks", "passing_is_percentage", "room_id", "status", "instructions",
+		"allowed_materials", "question_paper_sets", "seating_scheme", "created_by", "version", "deleted_at", "cancellation_reason", "cancelled_at",
+		"created_at", "updated_at",
+		"name", "room_name", "room_number", "location",
+	}
+	start := time.Date(2026, 5, 10, 9, 0, 0, 0, time.UTC)
+	row := func(roomID *int, roomName, roomNumber, location *string) []any {
+		return []any{
+			7, 1, 2, "Midterm", "", "midterm", start,
+			start.Add(2 * time.Hour), 120, 100.0, 40.0, false, roomID, "scheduled", "",
+			[]string{}, 1, "sequential", 3, 1, nil, nil, nil, start, start,
+			"Linear Algebra", roomName, roomNumber, location,
+		}
+	}
+
+	t.Run("with room", func(t *testing.T) {
+		mock, repo, ctx := setupExamTest(t)
+		roomID := 4
+		name, number, location := "Main Hall", "H-101", "Block A"
+		mock.ExpectQuery(`JOIN courses c ON c.id = e.course_id\s+LEFT JOIN exam_rooms er ON er.id = e.room_id`).
+			WithArgs(7, 1).
+			WillReturnRows(pgxmock.NewRows(columns).AddRow(row(&roomID, &name, &number, &location)...))
+
+		exam, err := repo.GetExamWithDetails(ctx, 1, 7)
+		require.NoError(t, err)
+		assert.Equal(t, "Linear Algebra", exam.CourseName)
+		assert.Equal(t, "Main Hall", *exam.RoomName)
+		assert.Equal(t, "H-101", *exam.RoomNumber)
+		assert.Equal(t, "Block A", *exam.RoomLocation)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("without room", func(t *testing.T) {
+		mock, repo, ctx := setupExamTest(t)
+		mock.ExpectQuery(`FROM exams e`).
+			WithArgs(7, 1).
+			WillReturnRows(pgxmock.NewRows(columns).AddRow(row(nil, nil, nil, nil)...))
+
+		exam, err := repo.GetExamWithDetails(ctx, 1, 7)
+		require.NoError(t, err)
+		assert.Equal(t, "Linear Algebra", exam.CourseName)
+		assert.Nil(t, exam.RoomID)
+		assert.Nil(t, exam.RoomName)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("exam of another college is not found", func(t *testing.T) {
+		mock, repo, ctx := setupExamTest(t)
+		mock.ExpectQuery(`FROM exams e`).
+			WithArgs(7, 2).
+			WillReturnError(pgx.ErrNoRows)
+
+		_, err := repo.GetExamWithDetails(ctx, 2, 7)
+		assert.ErrorIs(t, err, ErrExamNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestListExams_ExcludesDeletedByDefault(t *testing.T) {
+	columns := []string{
+		"id", "college_id", "course_id", "title", "description", "exam_type", "start_time",
+		"end_time", "duration", "total_marks", "passing_marks", "passing_is_percentage", "room_id", "status", "instructions",
+		"allowed_materials", "question_paper_sets", "seating_scheme", "created_by", "version", "deleted_at", "cancellation_reason", "cancelled_at",
+		"created_at", "updated_at",
+	}
+
+	t.Run("default", func(t *testing.T) {
+		mock, repo, ctx := setupExamTest(t)
+		mock.ExpectQuery(`FROM exams WHERE college_id = \$1 AND deleted_at IS NULL ORDER BY`).
+			WithArgs(1, 50, 0).
+			WillReturnRows(pgxmock.NewRows(columns))
+
+		_, err := repo.ListExams(ctx, 1, models.ExamFilter{}, 50, 0)
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("include deleted", func(t *testing.T) {
+		mock, repo, ctx := setupExamTest(t)
+		mock.ExpectQuery(`FROM exams WHERE college_id = \$1 ORDER BY`).
+			WithArgs(1, 50, 0).
+			WillReturnRows(pgxmock.NewRows(columns))
+
+		_, err := repo.ListExams(ctx, 1, models.ExamFilter{IncludeDeleted: true}, 50, 0)
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("every filter", func(t *testing.T) {
+		mock, repo, ctx := setupExamTest(t)
+		courseID, status, examType := 3, "scheduled", "midterm"
+		from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(`FROM exams WHERE college_id = \$1 AND deleted_at IS NULL AND course_id = \$2 AND status = \$3 AND exam_type = \$4 AND start_time >= \$5 AND start_time < \$6 ORDER BY start_time DESC, id DESC LIMIT \$7 OFFSET \$8`).
+			WithArgs(1, courseID, status, examType, from, to, 20, 40).
+			WillReturnRows(pgxmock.NewRows(columns))
+
+		_, err := repo.ListExams(ctx, 1, models.ExamFilter{
+			CourseID: &courseID,
+			Status:   &status,
+			ExamType: &examType,
+			From:     &from,
+			To:       &to,
+		}, 20, 40)
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("by course", func(t *testing.T) {
+		mock, repo, ctx := setupExamTest(t)
+		mock.ExpectQuery(`FROM exams WHERE college_id = \$1 AND deleted_at IS NULL AND course_id = \$2 ORDER BY`).
+			WithArgs(1, 3, 50, 0).
+			WillReturnRows(pgxmock.NewRows(columns))
+
+		_, err := repo.ListExamsByCourse(ctx, 1, 3, 50, 0)
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestListExamsAfter_PagesByCursor(t *testing.T) {
+	columns := []string{
+		"id", "college_id", "course_id", "title", "description", "exam_type", "start_time",
+		"end_time", "duration", "total_marks", "passing_marks", "passing_is_percentage", "room_id", "status", "instructions",
+		"allowed_materials", "question_paper_sets", "seating_scheme", "created_by", "version", "deleted_at", "cancellation_reason", "cancelled_at",
+		"created_at", "updated_at",
+	}
+	start := time.Date(2026, 5, 10, 9, 0, 0, 0, time.UTC)
+	row := func(id int) []any {
+		return []any{
+			id, 1, 2, "Midterm", "", "midterm", start,
+			start.Add(2 * time.Hour), 120, 100.0, 40.0, false, nil, "scheduled", "",
+			[]string{}, 1, "sequential", 3, 1, nil, nil, nil, start, start,
+		}
+	}
+
+	mock, repo, ctx := setupExamTest(t)
+	mock.ExpectQuery(`FROM exams WHERE college_id = \$1 AND deleted_at IS NULL ORDER BY start_time DESC, id DESC LIMIT \$2 OFFSET \$3$`).
+		WithArgs(1, 3, 0).
+		WillReturnRows(pgxmock.NewRows(columns).AddRow(row(9)...).AddRow(row(7)...).AddRow(row(4)...))
+
+	exams, next, err := repo.ListExamsAfter(ctx, 1, models.ExamFilter{}, "", 2)
+	require.NoError(t, err)
+	require.Len(t, exams, 2)
+	require.NotEmpty(t, next)
+
+	// The next page starts after the last exam returned, not at an offset
+	mock.ExpectQuery(`FROM exams WHERE college_id = \$1 AND deleted_at IS NULL AND \(start_time, id\) < \(\$2, \$3\) ORDER BY start_time DESC, id DESC LIMIT \$4$`).
+		WithArgs(1, start, 7, 3).
+		WillReturnRows(pgxmock.NewRows(columns).AddRow(row(4)...))
+
+	exams, next, err = repo.ListExamsAfter(ctx, 1, models.ExamFilter{}, next, 2)
+	require.NoError(t, err)
+	assert.Len(t, exams, 1)
+	assert.Empty(t, next)
+
+	_, _, err = repo.ListExamsAfter(ctx, 1, models.ExamFilter{}, "not-a-cursor", 2)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListUpcomingExams_FiltersFutureExams(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	now := time.Now()
+	seat := "A12"
+
+	mock.ExpectQuery(`WHERE en.student_id = \$1 AND en.college_id = \$2\s+AND e.start_time > \$3.*ORDER BY e.start_time ASC`).
+		WithArgs(4, 1, now).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "course_id", "title", "exam_type", "start_time", "end_time",
+			"duration", "total_marks", "instructions", "allowed_materials",
+			"id", "status", "seat_number", "room_number", "hall_ticket_generated",
+			"extra_time_minutes",
+		}).AddRow(
+			7, 3, "Midterm", "midterm", now.Add(24*time.Hour), now.Add(26*time.Hour),
+			120, 100.0, "", []string{"Calculator"},
+			21, "enrolled", &seat, (*string)(nil), true,
+			0,
+		))
+
+	exams, err := repo.ListUpcomingExams(ctx, 4, 1, now)
+
+	require.NoError(t, err)
+	require.Len(t, exams, 1)
+	assert.Equal(t, 7, exams[0].ExamID)
+	assert.Equal(t, "A12", *exams[0].SeatNumber)
+	assert.True(t, exams[0].HallTicketGenerated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAssignInvigilator_AlreadyAssigned(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	roomID := 4
+
+	mock.ExpectQuery(`INSERT INTO exam_invigilators .* ON CONFLICT \(exam_id, user_id\) DO NOTHING`).
+		WithArgs(7, 1, &roomID, 3, (*int)(nil)).
+		WillReturnError(pgx.ErrNoRows)
+
+	err := repo.AssignInvigilator(ctx, &models.ExamInvigilator{ExamID: 7, CollegeID: 1, RoomID: &roomID, UserID: 3})
+
+	assert.ErrorIs(t, err, ErrInvigilatorAssigned)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListInvigilatorConflicts_OverlappingWindow(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	start := time.Date(2026, 11, 2, 9, 0, 0, 0, time.UTC)
+	end := start.Add(3 * time.Hour)
+
+	mock.ExpectQuery(`WHERE ei.user_id = \$1 AND ei.exam_id <> \$2.*AND e.start_time < \$4 AND \$3 < e.end_time`).
+		WithArgs(3, 7, start, end).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "title", "start_time", "end_time"}).
+			AddRow(8, "Chemistry final", start.Add(time.Hour), end.Add(time.Hour)))
+
+	conflicts, err := repo.ListInvigilatorConflicts(ctx, 3, 7, start, end)
+
+	require.NoError(t, err)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, 8, conflicts[0].ExamID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPublishResults_SkipsPendingAndPublished(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+
+	mock.ExpectQuery(`UPDATE exam_results\s+SET published = TRUE, published_at = NOW\(\), version = version \+ 1\s+WHERE exam_id = \$1 AND published = FALSE AND result <> 'pending'\s+RETURNING student_id`).
+		WithArgs(7).
+		WillReturnRows(pgxmock.NewRows([]string{"student_id"}).AddRow(4).AddRow(9))
+
+	published, err := repo.PublishResults(ctx, 7)
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{4, 9}, published)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetStudentResults_PublishedOnly(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+
+	mock.ExpectQuery(`FROM exam_results WHERE student_id = \$1 AND college_id = \$2 AND published = TRUE ORDER BY created_at DESC`).
+		WithArgs(4, 1).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "exam_id", "student_id", "college_id", "marks_obtained", "grade", "percentage",
+			"result", "remarks", "evaluated_by", "evaluated_at", "revaluation_status", "version",
+			"published", "published_at", "created_at", "updated_at",
+		}))
+
+	results, err := repo.GetStudentResults(ctx, 4, 1, true, models.ExamResultFilter{})
+
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountStudentResults(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM exam_results WHERE student_id = \$1 AND college_id = \$2 AND published = TRUE$`).
+		WithArgs(4, 1).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM exam_results WHERE student_id = \$1 AND college_id = \$2$`).
+		WithArgs(4, 1).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(5))
+
+	published, err := repo.CountStudentResults(ctx, 4, 1, true)
+	require.NoError(t, err)
+	assert.Equal(t, 3, published)
+	all, err := repo.CountStudentResults(ctx, 4, 1, false)
+	require.NoError(t, err)
+	assert.Equal(t, 5, all)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountStudentEnrollments(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM exam_enrollments WHERE student_id = \$1 AND college_id = \$2`).
+		WithArgs(4, 1).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(6))
+
+	count, err := repo.CountStudentEnrollments(ctx, 4, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 6, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetStudentResults_FilterAndSort(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+
+	result := "pass"
+	courseID := 6
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`FROM exam_results WHERE student_id = \$1 AND college_id = \$2 AND result = \$3 `+
+		`AND exam_id IN \(SELECT id FROM exams WHERE college_id = \$2 AND course_id = \$4 AND start_time >= \$5 AND start_time < \$6\) `+
+		`ORDER BY marks_obtained ASC NULLS LAST, created_at DESC, id DESC`).
+		WithArgs(4, 1, result, courseID, from, to).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "exam_id", "student_id", "college_id", "marks_obtained", "grade", "percentage",
+			"result", "remarks", "evaluated_by", "evaluated_at", "revaluation_status", "version",
+			"published", "published_at", "created_at", "updated_at",
+		}))
+
+	_, err := repo.GetStudentResults(ctx, 4, 1, false, models.ExamResultFilter{
+		CourseID:  &courseID,
+		Result:    &result,
+		From:      &from,
+		To:        &to,
+		SortBy:    "marks",
+		SortOrder: "ASC",
+	})
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetStudentResultsAfter(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	createdAt := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	cursor := encodeCursor(createdAt, 15)
+
+	mock.ExpectQuery(`FROM exam_results WHERE student_id = \$1 AND college_id = \$2 AND published = TRUE `+
+		`AND \(created_at, id\) < \(\$3, \$4\) ORDER BY created_at DESC, id DESC LIMIT \$5`).
+		WithArgs(4, 1, createdAt, 15, 21).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "exam_id", "student_id", "college_id", "marks_obtained", "grade", "percentage",
+			"result", "remarks", "evaluated_by", "evaluated_at", "revaluation_status", "version",
+			"published", "published_at", "created_at", "updated_at",
+		}))
+
+	results, next, err := repo.GetStudentResultsAfter(ctx, 4, 1, true, models.ExamResultFilter{}, cursor, 20)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Empty(t, next)
+
+	// Cursors only follow the default order
+	_, _, err = repo.GetStudentResultsAfter(ctx, 4, 1, true, models.ExamResultFilter{SortBy: "marks"}, cursor, 20)
+	assert.ErrorIs(t, err, ErrInvalidResultSort)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetStudentResults_RejectsUnknownSort(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+
+	_, err := repo.GetStudentResults(ctx, 4, 1, false, models.ExamResultFilter{SortBy: "marks_obtained; DROP TABLE exam_results"})
+	assert.ErrorIs(t, err, ErrInvalidResultSort)
+
+	_, err = repo.GetStudentResults(ctx, 4, 1, false, models.ExamResultFilter{SortBy: "marks", SortOrder: "sideways"})
+	assert.ErrorIs(t, err, ErrInvalidResultSort)
+
+	// Neither request reaches the database
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAssignSeats(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+
+	set := 1
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id FROM exams WHERE id = \$1 AND college_id = \$2 AND deleted_at IS NULL FOR UPDATE NOWAIT`).
+		WithArgs(4, 1).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(4))
+	mock.ExpectQuery(`SELECT id FROM exam_enrollments WHERE exam_id = \$1 ORDER BY id FOR UPDATE`).
+		WithArgs(4).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(10))
+	mock.ExpectExec(`UPDATE exam_enrollments SET seat_number = \$1, room_number = \$2, question_paper_set = \$3 WHERE id = \$4`).
+		WithArgs("S001", (*string)(nil), &set, 10).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectCommit()
+
+	err := repo.AssignSeats(ctx, 1, 4, []*models.SeatAssignment{
+		{EnrollmentID: 10, StudentID: 5, SeatNumber: "S001", QuestionPaperSet: &set},
+	})
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAssignSeats_InProgress(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FOR UPDATE NOWAIT`).
+		WithArgs(4, 1).
+		WillReturnError(&pgconn.PgError{Code: "55P03", Message: "could not obtain lock on row"})
+	mock.ExpectRollback()
+
+	err := repo.AssignSeats(ctx, 1, 4, nil)
+
+	assert.ErrorIs(t, err, ErrSeatAllocationInProgress)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAssignSeats_StalePlan(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+
+	// A student enrolled after the plan was made
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FOR UPDATE NOWAIT`).
+		WithArgs(4, 1).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(4))
+	mock.ExpectQuery(`SELECT id FROM exam_enrollments`).
+		WithArgs(4).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(10).AddRow(11))
+	mock.ExpectRollback()
+
+	err := repo.AssignSeats(ctx, 1, 4, []*models.SeatAssignment{{EnrollmentID: 10, SeatNumber: "S001"}})
+
+	assert.ErrorIs(t, err, ErrSeatPlanStale)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEnrollCourseStudents_SkipsAlreadyEnrolled(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT course_id FROM exams WHERE id = \$1 AND college_id = \$2 AND deleted_at IS NULL FOR UPDATE`).
+		WithArgs(4, 1).
+		WillReturnRows(pgxmock.NewRows([]string{"course_id"}).AddRow(9))
+	mock.ExpectQuery(`SELECT COUNT\(DISTINCT student_id\) FROM enrollments`).
+		WithArgs(9, 1).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(3))
+	// One of the three course students is already enrolled in the exam
+	mock.ExpectQuery(`INSERT INTO exam_enrollments .* ON CONFLICT \(exam_id, student_id\) DO NOTHING`).
+		WithArgs(4, 1, 9).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "student_id", "enrollment_date", "created_at", "updated_at"}).
+			AddRow(31, 5, now, now, now).
+			AddRow(32, 6, now, now, now))
+	mock.ExpectCommit()
+
+	created, eligible, err := repo.EnrollCourseStudents(ctx, 1, 4)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, eligible)
+	require.Len(t, created, 2)
+	assert.Equal(t, 5, created[0].StudentID)
+	assert.Equal(t, 4, created[0].ExamID)
+	assert.Equal(t, "enrolled", created[1].Status)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEnrollCourseStudents_ExamNotFound(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT course_id FROM exams`).
+		WithArgs(4, 1).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	_, _, err := repo.EnrollCourseStudents(ctx, 1, 4)
+
+	assert.ErrorIs(t, err, ErrExamNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMarkAppeared(t *testing.T) {
+	lookup := `SELECT en.id, en.student_id, en.status FROM exam_enrollments en\s+JOIN students s .*lower\(trim\(s.roll_no\)\) = lower\(trim\(\$3\)\)\s+FOR UPDATE OF en`
+
+	t.Run("checks the student in", func(t *testing.T) {
+		mock, repo, ctx := setupExamTest(t)
+		now := time.Now()
+		mock.ExpectBegin()
+		mock.ExpectQuery(lookup).
+			WithArgs(4, 1, "cs-042").
+			WillReturnRows(pgxmock.NewRows([]string{"id", "student_id", "status"}).AddRow(21, 5, "enrolled"))
+		mock.ExpectQuery(`UPDATE exam_enrollments SET status = 'appeared', appeared_at = NOW\(\)`).
+			WithArgs(21).
+			WillReturnRows(pgxmock.NewRows([]string{"appeared_at"}).AddRow(&now))
+		mock.ExpectCommit()
+
+		enrollment, err := repo.MarkAppeared(ctx, 1, 4, "cs-042")
+		require.NoError(t, err)
+		assert.Equal(t, 5, enrollment.StudentID)
+		assert.Equal(t, "appeared", enrollment.Status)
+		require.NotNil(t, enrollment.AppearedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already appeared", func(t *testing.T) {
+		mock, repo, ctx := setupExamTest(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(lookup).
+			WithArgs(4, 1, "cs-042").
+			WillReturnRows(pgxmock.NewRows([]string{"id", "student_id", "status"}).AddRow(21, 5, "appeared"))
+		mock.ExpectRollback()
+
+		_, err := repo.MarkAppeared(ctx, 1, 4, "cs-042")
+		assert.ErrorIs(t, err, ErrAlreadyAppeared)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not enrolled", func(t *testing.T) {
+		mock, repo, ctx := setupExamTest(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(lookup).
+			WithArgs(4, 1, "cs-999").
+			WillReturnError(pgx.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := repo.MarkAppeared(ctx, 1, 4, "cs-999")
+		assert.ErrorIs(t, err, ErrEnrollmentNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetStudentExamEnrollments(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	columns := []string{
+		"id", "exam_id", "student_id", "college_id", "enrollment_date", "seat_number",
+		"room_number", "question_paper_set", "status", "hall_ticket_generated", "created_at", "updated_at",
+		"extra_time_minutes", "separate_room", "scribe", "accommodation_notes", "appeared_at", "extension_minutes",
+		"title", "exam_type", "course_id", "name", "start_time", "end_time", "status", "exists",
+	}
+	start := time.Date(2026, 5, 10, 9, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`JOIN exams ex ON ex.id = en.exam_id .* WHERE en.student_id = \$1 AND en.college_id = \$2 AND ex.deleted_at IS NULL`).
+		WithArgs(5, 1).
+		WillReturnRows(pgxmock.NewRows(columns).
+			AddRow(11, 7, 5, 1, start, nil, nil, nil, "appeared", true, start, start,
+				0, false, false, "", &start, 0,
+				"Midterm", "midterm", 2, "Linear Algebra", start, start.Add(2*time.Hour), "completed", true).
+			AddRow(12, 8, 5, 1, start, nil, nil, nil, "enrolled", false, start, start,
+				0, false, false, "", nil, 0,
+				"Quiz 1", "quiz", 3, "Physics", start.Add(48*time.Hour), start.Add(49*time.Hour), "scheduled", false))
+
+	enrollments, err := repo.GetStudentExamEnrollments(ctx, 5, 1)
+	require.NoError(t, err)
+	require.Len(t, enrollments, 2)
+	assert.Equal(t, "Midterm", enrollments[0].ExamTitle)
+	assert.Equal(t, "Linear Algebra", enrollments[0].CourseName)
+	assert.Equal(t, "appeared", enrollments[0].Status)
+	assert.Equal(t, "completed", enrollments[0].ExamStatus)
+	assert.True(t, enrollments[0].ResultPublished)
+	assert.False(t, enrollments[1].ResultPublished)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListResultSections(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	mock.ExpectQuery(`SELECT r.student_id, s.section FROM exam_results r JOIN students s .* WHERE r.exam_id = \$1`).
+		WithArgs(7).
+		WillReturnRows(pgxmock.NewRows([]string{"student_id", "section"}).
+			AddRow(5, "A ").
+			AddRow(6, "B"))
+
+	sections, err := repo.ListResultSections(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]string{5: "A", 6: "B"}, sections)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListEnrollmentRollNos(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	mock.ExpectQuery(`SELECT en.student_id, s.roll_no FROM exam_enrollments en\s+JOIN students s .* WHERE en.exam_id = \$1 AND en.college_id = \$2`).
+		WithArgs(7, 1).
+		WillReturnRows(pgxmock.NewRows([]string{"student_id", "roll_no"}).
+			AddRow(5, "CS-001").
+			AddRow(6, "CS-002"))
+
+	rollNos, err := repo.ListEnrollmentRollNos(ctx, 1, 7)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]string{5: "CS-001", 6: "CS-002"}, rollNos)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetStudentLanguage(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	mock.ExpectQuery(`SELECT COALESCE\(us.language, 'en'\) FROM students s\s+LEFT JOIN user_settings us .* WHERE s.college_id = \$1 AND s.student_id = \$2`).
+		WithArgs(1, 5).
+		WillReturnRows(pgxmock.NewRows([]string{"language"}).AddRow("hi"))
+
+	language, err := repo.GetStudentLanguage(ctx, 1, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "hi", language)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIssueHallTicket(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	now := time.Now()
+	mock.ExpectQuery(`UPDATE exam_enrollments SET hall_ticket_version = CASE WHEN hall_ticket_version = 0 OR hall_ticket_fingerprint IS DISTINCT FROM \$2 .* WHERE id = \$1 RETURNING hall_ticket_version, hall_ticket_generated_at`).
+		WithArgs(9, "abc").
+		WillReturnRows(pgxmock.NewRows([]string{"hall_ticket_version", "hall_ticket_generated_at"}).AddRow(2, &now))
+
+	issue, err := repo.IssueHallTicket(ctx, 9, "abc")
+	require.NoError(t, err)
+	assert.Equal(t, 2, issue.Version)
+	assert.Equal(t, now, *issue.GeneratedAt)
+
+	mock.ExpectQuery(`UPDATE exam_enrollments SET hall_ticket_version`).
+		WithArgs(10, "abc").
+		WillReturnError(pgx.ErrNoRows)
+	_, err = repo.IssueHallTicket(ctx, 10, "abc")
+	assert.ErrorIs(t, err, ErrEnrollmentNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCancelExam(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	now := time.Now()
+	reason := "Hall flooded"
+	mock.ExpectQuery(`UPDATE exams SET status = 'cancelled', cancellation_reason = \$1, .* WHERE id = \$2 AND college_id = \$3 AND deleted_at IS NULL AND status NOT IN \('cancelled', 'completed'\)`).
+		WithArgs(reason, 7, 1).
+		WillReturnRows(pgxmock.NewRows([]string{"status", "cancellation_reason", "cancelled_at", "version", "updated_at"}).
+			AddRow("cancelled", &reason, &now, 3, now))
+
+	exam := &models.Exam{ID: 7, CollegeID: 1, Status: "scheduled", Version: 2}
+	require.NoError(t, repo.CancelExam(ctx, exam, reason))
+	assert.Equal(t, "cancelled", exam.Status)
+	assert.Equal(t, reason, *exam.CancellationReason)
+	assert.Equal(t, 3, exam.Version)
+
+	mock.ExpectQuery(`UPDATE exams SET status = 'cancelled'`).
+		WithArgs(reason, 7, 1).
+		WillReturnError(pgx.ErrNoRows)
+	assert.ErrorIs(t, repo.CancelExam(ctx, exam, reason), ErrExamNotCancellable)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveQuestionPaper(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	now := time.Now()
+	paper := &models.ExamQuestionPaper{
+		CollegeID: 1, ExamID: 7, PaperSet: 2, ObjectKey: "1/exams/7/papers/set2_b.pdf",
+		FileName: "B.pdf", ContentType: "application/pdf", SizeBytes: 2048, UploadedBy: 3,
+	}
+	mock.ExpectQuery(`WITH previous AS .* INSERT INTO exam_question_papers .* ON CONFLICT \(exam_id, paper_set\) DO UPDATE`).
+		WithArgs(1, 7, 2, "1/exams/7/papers/set2_b.pdf", "B.pdf", "application/pdf", int64(2048), 3).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "created_at", "updated_at", "coalesce"}).
+			AddRow(4, now, now, "1/exams/7/papers/set2_a.pdf"))
+
+	replaced, err := repo.SaveQuestionPaper(ctx, paper)
+	require.NoError(t, err)
+	assert.Equal(t, 4, paper.ID)
+	assert.Equal(t, "1/exams/7/papers/set2_a.pdf", replaced)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetQuestionPaper_NotFound(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	mock.ExpectQuery(`FROM exam_question_papers WHERE exam_id = \$1 AND paper_set = \$2 AND college_id = \$3`).
+		WithArgs(7, 2, 1).
+		WillReturnError(pgx.ErrNoRows)
+
+	_, err := repo.GetQuestionPaper(ctx, 1, 7, 2)
+	assert.ErrorIs(t, err, ErrQuestionPaperNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGrantExtension(t *testing.T) {
+	extension := func() *models.ExamTimeExtension {
+		return &models.ExamTimeExtension{CollegeID: 1, ExamID: 4, StudentID: 7, Minutes: 15, Reason: "Laptop crashed", GrantedBy: 11}
+	}
+
+	t.Run("records the grant and the running total", func(t *testing.T) {
+		mock, repo, ctx := setupExamTest(t)
+		now := time.Now()
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT id, extension_minutes FROM exam_enrollments .* FOR UPDATE`).
+			WithArgs(4, 7, 1).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "extension_minutes"}).AddRow(9, 20))
+		mock.ExpectQuery(`INSERT INTO exam_time_extensions`).
+			WithArgs(1, 4, 9, 7, 15, "Laptop crashed", 11).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(3, now))
+		mock.ExpectQuery(`UPDATE exam_enrollments SET extension_minutes = extension_minutes \+ \$1`).
+			WithArgs(15, 9).
+			WillReturnRows(pgxmock.NewRows([]string{"extension_minutes"}).AddRow(35))
+		mock.ExpectCommit()
+
+		ext := extension()
+		total, err := repo.GrantExtension(ctx, ext, 60)
+		require.NoError(t, err)
+		assert.Equal(t, 35, total)
+		assert.Equal(t, 3, ext.ID)
+		assert.Equal(t, 9, ext.EnrollmentID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("cap exceeded", func(t *testing.T) {
+		mock, repo, ctx := setupExamTest(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT id, extension_minutes FROM exam_enrollments`).
+			WithArgs(4, 7, 1).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "extension_minutes"}).AddRow(9, 50))
+		mock.ExpectRollback()
+
+		_, err := repo.GrantExtension(ctx, extension(), 60)
+		assert.ErrorIs(t, err, ErrExtensionCapExceeded)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not enrolled", func(t *testing.T) {
+		mock, repo, ctx := setupExamTest(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT id, extension_minutes FROM exam_enrollments`).
+			WithArgs(4, 7, 1).
+			WillReturnError(pgx.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := repo.GrantExtension(ctx, extension(), 60)
+		assert.ErrorIs(t, err, ErrEnrollmentNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestListRevaluationRequests_Filters(t *testing.T) {
+	columns := []string{
+		"id", "exam_result_id", "student_id", "college_id", "reason", "status",
+		"previous_marks", "revised_marks", "reviewed_by", "review_comments",
+		"requested_at", "reviewed_at", "fee_assignment_id", "fee_amount", "created_at", "updated_at",
+	}
+
+	t.Run("no filter", func(t *testing.T) {
+		mock, repo, ctx := setupExamTest(t)
+		mock.ExpectQuery(`FROM revaluation_requests WHERE college_id = \$1 ORDER BY requested_at DESC$`).
+			WithArgs(1).
+			WillReturnRows(pgxmock.NewRows(columns))
+
+		_, err := repo.ListRevaluationRequests(ctx, 1, models.RevaluationRequestFilter{})
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("every filter", func(t *testing.T) {
+		mock, repo, ctx := setupExamTest(t)
+		status, studentID, examID := "pending", 4, 7
+		from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(`WHERE college_id = \$1 AND status = \$2 AND student_id = \$3 AND exam_result_id IN \(SELECT id FROM exam_results WHERE exam_id = \$4\) AND requested_at >= \$5 AND requested_at < \$6 ORDER BY`).
+			WithArgs(1, status, studentID, examID, from, to).
+			WillReturnRows(pgxmock.NewRows(columns))
+
+		_, err := repo.ListRevaluationRequests(ctx, 1, models.RevaluationRequestFilter{
+			Status:    &status,
+			StudentID: &studentID,
+			ExamID:    &examID,
+			From:      &from,
+			To:        &to,
+		})
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestListRevaluationRequestDetails_JoinsExamAndStudent(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	requested := time.Date(2026, 5, 12, 10, 0, 0, 0, time.UTC)
+	marks := 38.5
+	examID := 7
+
+	mock.ExpectQuery(`e.title, COALESCE\(u.name, ''\), COALESCE\(s.roll_no, ''\), er.marks_obtained\s+FROM revaluation_requests rr\s+JOIN exam_results er .*WHERE rr.college_id = \$1 AND rr.exam_result_id IN \(SELECT id FROM exam_results WHERE exam_id = \$2\) ORDER BY rr.requested_at DESC$`).
+		WithArgs(1, examID).
+		WillReturnRows(pgxmock.NewRows([]string{
+			"id", "exam_result_id", "student_id", "college_id", "reason", "status",
+			"previous_marks", "revised_marks", "reviewed_by", "review_comments",
+			"requested_at", "reviewed_at", "fee_assignment_id", "fee_amount", "created_at", "updated_at",
+			"exam_id", "title", "name", "roll_no", "marks_obtained",
+		}).AddRow(
+			3, 11, 4, 1, "Question 5 was not marked", "pending",
+			38.5, nil, nil, "",
+			requested, nil, nil, nil, requested, requested,
+			7, "Midterm", "Asha Rao", "CS-042", &marks,
+		))
+
+	requests, err := repo.ListRevaluationRequestDetails(ctx, 1, models.RevaluationRequestFilter{ExamID: &examID})
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	assert.Equal(t, 11, requests[0].ExamResultID)
+	assert.Equal(t, "Midterm", requests[0].ExamTitle)
+	assert.Equal(t, "Asha Rao", requests[0].StudentName)
+	assert.Equal(t, "CS-042", requests[0].RollNo)
+	require.NotNil(t, requests[0].CurrentMarks)
+	assert.Equal(t, 38.5, *requests[0].CurrentMarks)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateRevaluationRequest_Fee(t *testing.T) {
+	newRequest := func() *models.RevaluationRequest {
+		assignmentID, fee := 6, 250.0
+		return &models.RevaluationRequest{
+			ExamResultID: 11, StudentID: 4, CollegeID: 1, Reason: "Question 5 was not marked",
+			PreviousMarks: 38.5, FeeAssignmentID: &assignmentID, FeeAmount: &fee,
+		}
+	}
+
+	t.Run("created once the payments cover the fee", func(t *testing.T) {
+		mock, repo, ctx := setupExamTest(t)
+		now := time.Now()
+		mock.ExpectBegin()
+		mock.ExpectQuery(`FROM fee_assignments fa WHERE fa.id = \$1 AND fa.student_id = \$2\s+FOR UPDATE`).
+			WithArgs(6, 4).
+			WillReturnRows(pgxmock.NewRows([]string{"credit"}).AddRow(250.0))
+		mock.ExpectQuery(`INSERT INTO revaluation_requests`).
+			WithArgs(11, 4, 1, "Question 5 was not marked", 38.5, pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "status", "created_at", "updated_at"}).AddRow(3, "pending", now, now))
+		mock.ExpectCommit()
+
+		request := newRequest()
+		require.NoError(t, repo.CreateRevaluationRequest(ctx, request))
+		assert.Equal(t, 3, request.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not created while the fee is unpaid", func(t *testing.T) {
+		mock, repo, ctx := setupExamTest(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`FROM fee_assignments fa`).
+			WithArgs(6, 4).
+			WillReturnRows(pgxmock.NewRows([]string{"credit"}).AddRow(100.0))
+		mock.ExpectRollback()
+
+		err := repo.CreateRevaluationRequest(ctx, newRequest())
+		assert.ErrorIs(t, err, ErrRevaluationFeeUnpaid)
+		var due *RevaluationFeeDueError
+		require.ErrorAs(t, err, &due)
+		assert.Equal(t, 6, due.FeeAssignmentID)
+		assert.Equal(t, 150.0, due.AmountDue)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("without a fee no credit is checked", func(t *testing.T) {
+		mock, repo, ctx := setupExamTest(t)
+		now := time.Now()
+		mock.ExpectQuery(`INSERT INTO revaluation_requests`).
+			WithArgs(11, 4, 1, "Question 5 was not marked", 38.5, pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "status", "created_at", "updated_at"}).AddRow(3, "pending", now, now))
+
+		request := newRequest()
+		request.FeeAssignmentID, request.FeeAmount = nil, nil
+		require.NoError(t, repo.CreateRevaluationRequest(ctx, request))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestListRoomBookings_ClipsExamsToWindow(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	from := time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	mock.ExpectQuery(`LEAST\(e.end_time, \$3\) - GREATEST\(e.start_time, \$2\).*FROM exam_rooms r\s+LEFT JOIN exams e .*AND e.status <> 'cancelled'\s+AND e.start_time < \$3 AND e.end_time > \$2\s+WHERE r.college_id = \$1`).
+		WithArgs(1, from, to).
+		WillReturnRows(pgxmock.NewRows([]string{"room_id", "room_number", "room_name", "exam_count", "booked_hours"}).
+			AddRow(2, "A-1", "Main Hall", 3, 7.5).
+			AddRow(5, "B-4", "Lab", 0, 0.0))
+
+	rooms, err := repo.ListRoomBookings(ctx, 1, from, to)
+	require.NoError(t, err)
+	require.Len(t, rooms, 2)
+	assert.Equal(t, "A-1", rooms[0].RoomNumber)
+	assert.Equal(t, 3, rooms[0].ExamCount)
+	assert.Equal(t, 7.5, rooms[0].BookedHours)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordProctoringEvent(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	at := time.Date(2026, 5, 4, 9, 30, 0, 0, time.UTC)
+	event := &models.ExamProctoringEvent{
+		CollegeID: 1, ExamID: 4, EnrollmentID: 9, StudentID: 7,
+		EventType: models.ProctoringTabSwitch, OccurredAt: at, Metadata: models.JSONMap{"count": 2},
+	}
+	mock.ExpectQuery(`INSERT INTO exam_proctoring_events .* RETURNING id, created_at`).
+		WithArgs(1, 4, 9, 7, "tab_switch", at, event.Metadata).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(int64(3), at))
+
+	require.NoError(t, repo.RecordProctoringEvent(ctx, event))
+	assert.Equal(t, int64(3), event.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListProctoringEvents(t *testing.T) {
+	mock, repo, ctx := setupExamTest(t)
+	at := time.Date(2026, 5, 4, 9, 30, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT .* FROM exam_proctoring_events\s+WHERE exam_id = \$1 AND student_id = \$2 AND college_id = \$3\s+ORDER BY occurred_at, id`).
+		WithArgs(4, 7, 1).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "college_id", "exam_id", "enrollment_id", "student_id", "event_type", "occurred_at", "metadata", "created_at"}).
+			AddRow(int64(3), 1, 4, 9, 7, "disconnect", at, models.JSONMap(nil), at))
+
+	events, err := repo.ListProctoringEvents(ctx, 1, 4, 7)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, models.ProctoringDisconnect, events[0].EventType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
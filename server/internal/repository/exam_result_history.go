@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"eduhub/server/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ResultHistoryHash is the hash of a result history entry chained to the
+// entry before it. Marks are hashed at the column's two decimal places and
+// times in microseconds, the precision they are stored at.
+func ResultHistoryHash(entry *models.ExamResultHistoryEntry) string {
+	fields := []string{
+		entry.PrevHash,
+		strconv.Itoa(entry.ResultID),
+		strconv.Itoa(entry.CollegeID),
+		strconv.Itoa(entry.ExamID),
+		strconv.Itoa(entry.StudentID),
+		strconv.Itoa(entry.Version),
+		hashFloat(entry.MarksObtained),
+		hashString(entry.Grade),
+		hashFloat(entry.Percentage),
+		strconv.Quote(entry.Result),
+		hashInt(entry.EvaluatedBy),
+		hashTime(entry.EvaluatedAt),
+		strconv.FormatInt(entry.SupersededAt.UnixMicro(), 10),
+	}
+	sum := sha256.Sum256([]byte(strings.Join(fields, "|")))
+	return hex.EncodeToString(sum[:])
+}
+
+func hashFloat(v *float64) string {
+	if v == nil {
+		return "null"
+	}
+	return strconv.FormatFloat(*v, 'f', 2, 64)
+}
+
+func hashString(v *string) string {
+	if v == nil {
+		return "null"
+	}
+	return strconv.Quote(*v)
+}
+
+func hashInt(v *int) string {
+	if v == nil {
+		return "null"
+	}
+	return strconv.Itoa(*v)
+}
+
+func hashTime(v *time.Time) string {
+	if v == nil {
+		return "null"
+	}
+	return strconv.FormatInt(v.UnixMicro(), 10)
+}
+
+// recordResultHistory locks the result at version and appends it to the
+// result's history before an update replaces it. It must run in the update's
+// transaction; the row lock keeps concurrent updates from forking the chain.
+// pgx.ErrNoRows means the result is not at version.
+func recordResultHistory(ctx context.Context, q rowQuerier, resultID, version int) error {
+	entry := &models.ExamResultHistoryEntry{}
+	err := q.QueryRow(ctx, `SELECT id, college_id, exam_id, student_id, version, marks_obtained, grade,
+				percentage, result, evaluated_by, evaluated_at
+			FROM exam_results WHERE id = $1 AND version = $2
+			FOR UPDATE`, resultID, version).Scan(
+		&entry.ResultID, &entry.CollegeID, &entry.ExamID, &entry.StudentID, &entry.Version,
+		&entry.MarksObtained, &entry.Grade, &entry.Percentage, &entry.Result,
+		&entry.EvaluatedBy, &entry.EvaluatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = q.QueryRow(ctx, `SELECT entry_hash FROM exam_result_history
+			WHERE result_id = $1 ORDER BY id DESC LIMIT 1`, resultID).Scan(&entry.PrevHash)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to read result history: %w", err)
+	}
+
+	entry.SupersededAt = time.Now().UTC().Truncate(time.Microsecond)
+	entry.EntryHash = ResultHistoryHash(entry)
+	err = q.QueryRow(ctx, `INSERT INTO exam_result_history (result_id, college_id, exam_id, student_id, version,
+				marks_obtained, grade, percentage, result, evaluated_by, evaluated_at,
+				superseded_at, prev_hash, entry_hash)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			RETURNING id`,
+		entry.ResultID, entry.CollegeID, entry.ExamID, entry.StudentID, entry.Version,
+		entry.MarksObtained, entry.Grade, entry.Percentage, entry.Result, entry.EvaluatedBy, entry.EvaluatedAt,
+		entry.SupersededAt, entry.PrevHash, entry.EntryHash,
+	).Scan(&entry.ID)
+	if err != nil {
+		return fmt.Errorf("failed to record result history: %w", err)
+	}
+	return nil
+}
+
+// ListResultHistory lists the replaced versions of a result, oldest first
+func (r *examRepository) ListResultHistory(ctx context.Context, collegeID, resultID int) ([]*models.ExamResultHistoryEntry, error) {
+	sql := `SELECT id, result_id, college_id, exam_id, student_id, version, marks_obtained, grade,
+			percentage, result, evaluated_by, evaluated_at, superseded_at, prev_hash, entry_hash
+			FROM exam_result_history WHERE result_id = $1 AND college_id = $2
+			ORDER BY id`
+
+	rows, err := r.db.Pool.Query(ctx, sql, resultID, collegeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []*models.ExamResultHistoryEntry{}
+	for rows.Next() {
+		entry := &models.ExamResultHistoryEntry{}
+		err := rows.Scan(
+			&entry.ID, &entry.ResultID, &entry.CollegeID, &entry.ExamID, &entry.StudentID, &entry.Version,
+			&entry.MarksObtained, &entry.Grade, &entry.Percentage, &entry.Result,
+			&entry.EvaluatedBy, &entry.EvaluatedAt, &entry.SupersededAt, &entry.PrevHash, &entry.EntryHash,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
@@ -31,13 +31,19 @@ type FeeRepository interface {
 	GetFeePayment(ctx context.Context, paymentID int) (*models.FeePayment, error)
 	GetStudentPayments(ctx context.Context, studentID int) ([]*models.FeePayment, error)
 	UpdatePaymentStatus(ctx context.Context, paymentID int, status string, transactionID *string) error
-	UpdatePaymentStatusByTransactionID(ctx context.Context, orderID string, status string, paymentID *string) error
+	GetFeePaymentByTransactionID(ctx context.Context, transactionID string) (*models.FeePayment, error)
 
 	// Summary operations
 	GetStudentFeesSummary(ctx context.Context, studentID int) (*models.StudentFeesSummary, error)
 	GetTotalPaidAmount(ctx context.Context, assignmentID int) (float64, error)
+
+	// Scope checks
+	StudentInCollege(ctx context.Context, studentID int, collegeID int) (bool, error)
 }
 
+// ErrPaymentNotFound is returned when no fee payment matches the lookup
+var ErrPaymentNotFound = errors.New("payment not found")
+
 type feeRepository struct {
 	DB *DB
 }
@@ -360,30 +366,32 @@ func (r *feeRepository) GetTotalPaidAmount(ctx context.Context, assignmentID int
 	return total, nil
 }
 
-// UpdatePaymentStatusByTransactionID updates payment status by the Razorpay order ID (transaction_id)
-// This is used for webhook processing where we receive order_id instead of internal payment_id
-func (r *feeRepository) UpdatePaymentStatusByTransactionID(ctx context.Context, orderID string, status string, paymentID *string) error {
-	var sql string
-	var result interface{ RowsAffected() int64 }
-	var err error
-
-	if paymentID != nil {
-		// Update both status and the actual Razorpay payment ID
-		sql = `UPDATE fee_payments SET payment_status = $1, transaction_id = $2, updated_at = $3 
-		       WHERE transaction_id = $4 OR (transaction_id IS NULL AND id IN (
-		           SELECT id FROM fee_payments WHERE payment_status = 'pending' LIMIT 1
-		       ))`
-		result, err = r.DB.Pool.Exec(ctx, sql, status, *paymentID, time.Now(), orderID)
-	} else {
-		sql = `UPDATE fee_payments SET payment_status = $1, updated_at = $2 WHERE transaction_id = $3`
-		result, err = r.DB.Pool.Exec(ctx, sql, status, time.Now(), orderID)
-	}
+// GetFeePaymentByTransactionID finds a payment by its gateway transaction ID.
+// Online payments store the Razorpay order ID here until the payment is
+// verified, after which it holds the Razorpay payment ID.
+func (r *feeRepository) GetFeePaymentByTransactionID(ctx context.Context, transactionID string) (*models.FeePayment, error) {
+	sql := `SELECT * FROM fee_payments WHERE transaction_id = $1`
 
+	payment := &models.FeePayment{}
+	err := pgxscan.Get(ctx, r.DB.Pool, payment, sql, transactionID)
 	if err != nil {
-		return fmt.Errorf("UpdatePaymentStatusByTransactionID: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPaymentNotFound
+		}
+		return nil, fmt.Errorf("GetFeePaymentByTransactionID: %w", err)
 	}
-	if result.RowsAffected() == 0 {
-		return fmt.Errorf("payment with order_id %s not found", orderID)
+	return payment, nil
+}
+
+// StudentInCollege reports whether the student is enrolled in the college.
+// Fee assignments and payments carry no college of their own, so callers use
+// this to keep admins and parents inside their college's ledger.
+func (r *feeRepository) StudentInCollege(ctx context.Context, studentID int, collegeID int) (bool, error) {
+	sql := `SELECT EXISTS (SELECT 1 FROM students WHERE student_id = $1 AND college_id = $2)`
+
+	var exists bool
+	if err := r.DB.Pool.QueryRow(ctx, sql, studentID, collegeID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("StudentInCollege: %w", err)
 	}
-	return nil
+	return exists, nil
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"eduhub/server/internal/models"
@@ -11,13 +12,16 @@ import (
 
 type NotificationRepository interface {
 	CreateNotification(ctx context.Context, notification *models.Notification) error
-	GetNotificationsByUser(ctx context.Context, collegeID, userID int, unreadOnly bool, limit int) ([]*models.Notification, error)
+	CreateNotificationsForUsers(ctx context.Context, userIDs []int, notification *models.Notification) (int, error)
+	GetNotificationsByUser(ctx context.Context, collegeID, userID int, unreadOnly bool, limit, offset int) ([]*models.Notification, error)
 	MarkAsRead(ctx context.Context, collegeID, notificationID, userID int) error
 	MarkAllAsRead(ctx context.Context, collegeID, userID int) error
 	DeleteNotification(ctx context.Context, collegeID, notificationID, userID int) error
 	GetUnreadCount(ctx context.Context, collegeID, userID int) (int, error)
 }
 
+var ErrNotificationNotFound = errors.New("notification not found")
+
 type notificationRepository struct {
 	DB *DB
 }
@@ -30,8 +34,8 @@ func (r *notificationRepository) CreateNotification(ctx context.Context, notific
 	now := time.Now()
 	notification.CreatedAt = now
 
-	sql := `INSERT INTO notifications (user_id, college_id, title, message, type, is_read, created_at)
-			VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	sql := `INSERT INTO notifications (user_id, college_id, title, message, type, related_entity_type, related_entity_id, is_read, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
 
 	var id int
 	err := r.DB.Pool.QueryRow(ctx, sql,
@@ -40,6 +44,8 @@ func (r *notificationRepository) CreateNotification(ctx context.Context, notific
 		notification.Title,
 		notification.Message,
 		notification.Type,
+		notification.RelatedEntityType,
+		notification.RelatedEntityID,
 		false,
 		notification.CreatedAt,
 	).Scan(&id)
@@ -52,35 +58,63 @@ func (r *notificationRepository) CreateNotification(ctx context.Context, notific
 	return nil
 }
 
-func (r *notificationRepository) GetNotificationsByUser(ctx context.Context, collegeID, userID int, unreadOnly bool, limit int) ([]*models.Notification, error) {
-	var sql string
-	var args []any
-
-	if unreadOnly {
-		sql = `SELECT id, user_id, college_id, title, message, type, is_read, created_at
-			   FROM notifications WHERE college_id = $1 AND user_id = $2 AND is_read = false
-			   ORDER BY created_at DESC LIMIT $3`
-		args = []any{collegeID, userID, limit}
-	} else {
-		sql = `SELECT id, user_id, college_id, title, message, type, is_read, created_at
-			   FROM notifications WHERE college_id = $1 AND user_id = $2
-			   ORDER BY created_at DESC LIMIT $3`
-		args = []any{collegeID, userID, limit}
+// CreateNotificationsForUsers inserts a copy of notification for every user in
+// a single statement and returns how many rows were written.
+func (r *notificationRepository) CreateNotificationsForUsers(ctx context.Context, userIDs []int, notification *models.Notification) (int, error) {
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+	notification.CreatedAt = time.Now()
+
+	sql := `INSERT INTO notifications (user_id, college_id, title, message, type, related_entity_type, related_entity_id, is_read, created_at)
+			SELECT DISTINCT u.id, $2, $3, $4, $5, $6, $7, false, $8
+			FROM unnest($1::int[]) AS u(id)`
+
+	tag, err := r.DB.Pool.Exec(ctx, sql,
+		userIDs,
+		notification.CollegeID,
+		notification.Title,
+		notification.Message,
+		notification.Type,
+		notification.RelatedEntityType,
+		notification.RelatedEntityID,
+		notification.CreatedAt,
+	)
+	if err != nil {
+		return 0, err
 	}
+	return int(tag.RowsAffected()), nil
+}
 
-	var notifications []*models.Notification
-	err := pgxscan.Select(ctx, r.DB.Pool, &notifications, sql, args...)
+// GetNotificationsByUser lists a user's inbox with unread notifications first,
+// newest first within each group.
+func (r *notificationRepository) GetNotificationsByUser(ctx context.Context, collegeID, userID int, unreadOnly bool, limit, offset int) ([]*models.Notification, error) {
+	sql := `SELECT id, user_id, college_id, title, message, type, is_read, read_at, related_entity_type, related_entity_id, created_at
+			FROM notifications
+			WHERE college_id = $1 AND user_id = $2 AND ($3 = false OR is_read = false)
+			ORDER BY is_read ASC, created_at DESC, id DESC
+			LIMIT $4 OFFSET $5`
+
+	notifications := []*models.Notification{}
+	err := pgxscan.Select(ctx, r.DB.Pool, &notifications, sql, collegeID, userID, unreadOnly, limit, offset)
 	return notifications, err
 }
 
 func (r *notificationRepository) MarkAsRead(ctx context.Context, collegeID, notificationID, userID int) error {
-	sql := `UPDATE notifications SET is_read = true WHERE id = $1 AND college_id = $2 AND user_id = $3`
-	_, err := r.DB.Pool.Exec(ctx, sql, notificationID, collegeID, userID)
-	return err
+	sql := `UPDATE notifications SET is_read = true, read_at = COALESCE(read_at, NOW())
+			WHERE id = $1 AND college_id = $2 AND user_id = $3`
+	tag, err := r.DB.Pool.Exec(ctx, sql, notificationID, collegeID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotificationNotFound
+	}
+	return nil
 }
 
 func (r *notificationRepository) MarkAllAsRead(ctx context.Context, collegeID, userID int) error {
-	sql := `UPDATE notifications SET is_read = true WHERE college_id = $1 AND user_id = $2 AND is_read = false`
+	sql := `UPDATE notifications SET is_read = true, read_at = NOW() WHERE college_id = $1 AND user_id = $2 AND is_read = false`
 	_, err := r.DB.Pool.Exec(ctx, sql, collegeID, userID)
 	return err
 }
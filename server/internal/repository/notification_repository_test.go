@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupNotificationTest(t *testing.T) (pgxmock.PgxPoolIface, NotificationRepository, context.Context) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		mock.Close()
+	})
+
+	return mock, NewNotificationRepository(&DB{Pool: mock}), context.Background()
+}
+
+func TestGetNotificationsByUser_UnreadFirst(t *testing.T) {
+	mock, repo, ctx := setupNotificationTest(t)
+
+	now := time.Now()
+	columns := []string{"id", "user_id", "college_id", "title", "message", "type", "is_read", "read_at", "related_entity_type", "related_entity_id", "created_at"}
+	entityType := "exam"
+	examID := 12
+	mock.ExpectQuery(`FROM notifications\s+WHERE college_id = \$1 AND user_id = \$2 AND \(\$3 = false OR is_read = false\)\s+ORDER BY is_read ASC, created_at DESC, id DESC\s+LIMIT \$4 OFFSET \$5`).
+		WithArgs(1, 7, false, 20, 40).
+		WillReturnRows(pgxmock.NewRows(columns).
+			AddRow(3, 7, 1, "Results published: Midterm", "Results are out", "info", false, nil, &entityType, &examID, now).
+			AddRow(2, 7, 1, "Welcome", "Hello", "info", true, &now, nil, nil, now.Add(-time.Hour)))
+
+	notifications, err := repo.GetNotificationsByUser(ctx, 1, 7, false, 20, 40)
+
+	require.NoError(t, err)
+	require.Len(t, notifications, 2)
+	assert.False(t, notifications[0].IsRead)
+	assert.Equal(t, 12, *notifications[0].RelatedEntityID)
+	assert.True(t, notifications[1].IsRead)
+	assert.Nil(t, notifications[1].RelatedEntityType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMarkNotificationAsRead_NotFound(t *testing.T) {
+	mock, repo, ctx := setupNotificationTest(t)
+
+	// Another user's notification matches no rows
+	mock.ExpectExec(`UPDATE notifications SET is_read = true, read_at = COALESCE\(read_at, NOW\(\)\)`).
+		WithArgs(3, 1, 8).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	err := repo.MarkAsRead(ctx, 1, 3, 8)
+
+	assert.ErrorIs(t, err, ErrNotificationNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
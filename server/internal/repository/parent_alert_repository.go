@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"eduhub/server/internal/models"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+)
+
+type ParentAlertRepository interface {
+	ListCollegesWithParentNotifications(ctx context.Context) ([]int, error)
+	FindLowAttendanceRecipients(ctx context.Context, criteria models.LowAttendanceCriteria) ([]*models.LowAttendanceRecipient, error)
+	ClaimAttendanceAlert(ctx context.Context, alert *models.ParentAttendanceAlert) (bool, error)
+	ReleaseAttendanceAlert(ctx context.Context, alertID int) error
+}
+
+type parentAlertRepository struct {
+	DB *DB
+}
+
+func NewParentAlertRepository(db *DB) ParentAlertRepository {
+	return &parentAlertRepository{DB: db}
+}
+
+func (r *parentAlertRepository) ListCollegesWithParentNotifications(ctx context.Context) ([]int, error) {
+	sql := `SELECT DISTINCT college_id
+			FROM parent_student_relationships
+			WHERE is_verified = TRUE AND receive_notifications = TRUE
+			ORDER BY college_id`
+
+	var collegeIDs []int
+	if err := pgxscan.Select(ctx, r.DB.Pool, &collegeIDs, sql); err != nil {
+		return nil, fmt.Errorf("ListCollegesWithParentNotifications: %w", err)
+	}
+	return collegeIDs, nil
+}
+
+func (r *parentAlertRepository) FindLowAttendanceRecipients(ctx context.Context, criteria models.LowAttendanceCriteria) ([]*models.LowAttendanceRecipient, error) {
+	sql := `WITH rates AS (
+				SELECT student_id,
+				       COUNT(*) AS total_sessions,
+				       COUNT(*) FILTER (WHERE ` + models.AttendancePresentSQL("status") + `) AS present_count
+				FROM attendance
+				WHERE college_id = $1 AND date >= $2
+				GROUP BY student_id
+			)
+			SELECT psr.id AS relationship_id,
+			       psr.parent_user_id,
+			       pu.name AS parent_name,
+			       pu.email AS parent_email,
+			       s.student_id,
+			       su.name AS student_name,
+			       s.roll_no,
+			       psr.college_id,
+			       rates.total_sessions,
+			       rates.present_count,
+			       ROUND(rates.present_count::numeric * 100 / rates.total_sessions, 2)::float8 AS attendance_rate
+			FROM rates
+			JOIN parent_student_relationships psr
+			  ON psr.student_id = rates.student_id
+			 AND psr.college_id = $1
+			 AND psr.is_verified = TRUE
+			 AND psr.receive_notifications = TRUE
+			JOIN students s ON s.student_id = psr.student_id AND s.is_active = TRUE
+			JOIN users su ON su.id = s.user_id
+			JOIN users pu ON pu.id = psr.parent_user_id AND pu.is_active = TRUE
+			WHERE rates.total_sessions >= $3
+			  AND rates.present_count::numeric * 100 / rates.total_sessions < $4
+			  AND NOT EXISTS (
+				SELECT 1 FROM parent_attendance_alerts paa
+				WHERE paa.relationship_id = psr.id AND paa.sent_at >= $5
+			  )
+			ORDER BY s.roll_no, psr.id`
+
+	var recipients []*models.LowAttendanceRecipient
+	err := pgxscan.Select(ctx, r.DB.Pool, &recipients, sql,
+		criteria.CollegeID,
+		criteria.WindowStart,
+		criteria.MinSessions,
+		criteria.Threshold,
+		criteria.CooldownStart,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("FindLowAttendanceRecipients: %w", err)
+	}
+	return recipients, nil
+}
+
+// ClaimAttendanceAlert records the alert before it is sent. It reports false
+// when the relationship already has an alert for alert.PeriodStart, i.e.
+// another run claimed it first.
+func (r *parentAlertRepository) ClaimAttendanceAlert(ctx context.Context, alert *models.ParentAttendanceAlert) (bool, error) {
+	sql := `INSERT INTO parent_attendance_alerts (relationship_id, parent_user_id, student_id, college_id, attendance_rate, threshold, period_start)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (relationship_id, period_start) DO NOTHING
+			RETURNING id, sent_at`
+
+	err := r.DB.Pool.QueryRow(ctx, sql,
+		alert.RelationshipID,
+		alert.ParentUserID,
+		alert.StudentID,
+		alert.CollegeID,
+		alert.AttendanceRate,
+		alert.Threshold,
+		alert.PeriodStart,
+	).Scan(&alert.ID, &alert.SentAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("ClaimAttendanceAlert: %w", err)
+	}
+	return true, nil
+}
+
+// ReleaseAttendanceAlert drops a claim whose email could not be sent so the
+// next run retries it
+func (r *parentAlertRepository) ReleaseAttendanceAlert(ctx context.Context, alertID int) error {
+	if _, err := r.DB.Pool.Exec(ctx, `DELETE FROM parent_attendance_alerts WHERE id = $1`, alertID); err != nil {
+		return fmt.Errorf("ReleaseAttendanceAlert: %w", err)
+	}
+	return nil
+}
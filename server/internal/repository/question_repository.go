@@ -69,14 +69,19 @@ func (r *questionRepository) CreateQuestion(ctx context.Context, question *model
 			type,
 			points,
 			correct_answer,
+			scoring_mode,
 			created_at,
 			updated_at
 		)
-			VALUES ($1, $2, $3, $4, $2, $3, $4, $5, $6, $7) RETURNING id`
+			VALUES ($1, $2, $3, $4, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
+
+	if question.ScoringMode == "" {
+		question.ScoringMode = models.ScoringAllOrNothing
+	}
 
 	// Prepare arguments in correct order
 	args := []any{question.QuizID, question.Text, question.Type, question.Points,
-		question.CorrectAnswer, question.CreatedAt, question.UpdatedAt}
+		question.CorrectAnswer, question.ScoringMode, question.CreatedAt, question.UpdatedAt}
 
 	// Execute query and scan the returned ID
 	temp := struct {
@@ -102,7 +107,7 @@ func (r *questionRepository) GetQuestionByID(ctx context.Context, collegeID int,
 			COALESCE(q.text, q.question_text) AS text,
 			COALESCE(q.type, q.question_type) AS type,
 			COALESCE(q.points, q.marks) AS points,
-			q.correct_answer, q.created_at, q.updated_at
+			q.correct_answer, q.scoring_mode, q.created_at, q.updated_at
 			FROM questions q
 			JOIN quizzes qu ON q.quiz_id = qu.id
 			WHERE q.id = $1 AND qu.college_id = $2`
@@ -135,9 +140,14 @@ func (r *questionRepository) UpdateQuestion(ctx context.Context, collegeID int,
 				type = $2,
 				points = $3,
 				correct_answer = $4,
-				updated_at = $5
-			WHERE id = $6 AND quiz_id IN (SELECT id FROM quizzes WHERE college_id = $7)`
-	args := []any{question.Text, question.Type, question.Points, question.CorrectAnswer, question.UpdatedAt,
+				scoring_mode = $5,
+				updated_at = $6
+			WHERE id = $7 AND quiz_id IN (SELECT id FROM quizzes WHERE college_id = $8)`
+
+	if question.ScoringMode == "" {
+		question.ScoringMode = models.ScoringAllOrNothing
+	}
+	args := []any{question.Text, question.Type, question.Points, question.CorrectAnswer, question.ScoringMode, question.UpdatedAt,
 		question.ID, collegeID}
 
 	cmdTag, err := r.DB.Pool.Exec(ctx, sql, args...)
@@ -182,7 +192,7 @@ func (r *questionRepository) FindQuestionsByQuiz(ctx context.Context, collegeID
 			COALESCE(q.text, q.question_text) AS text,
 			COALESCE(q.type, q.question_type) AS type,
 			COALESCE(q.points, q.marks) AS points,
-			q.correct_answer, q.created_at, q.updated_at
+			q.correct_answer, q.scoring_mode, q.created_at, q.updated_at
 			FROM questions q
 			JOIN quizzes qu ON q.quiz_id = qu.id
 			WHERE q.quiz_id = $1 AND qu.college_id = $2
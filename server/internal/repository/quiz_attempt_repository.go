@@ -40,6 +40,10 @@ type QuizAttemptRepository interface {
 	// CountQuizAttemptsByQuiz returns the total number of attempts for a quiz.
 	// Used for pagination calculations.
 	CountQuizAttemptsByQuiz(ctx context.Context, collegeID int, quizID int) (int, error)
+
+	// CloseAbandonedQuizAttempts moves in-progress attempts whose time ran out
+	// more than grace before now to status and returns them.
+	CloseAbandonedQuizAttempts(ctx context.Context, now time.Time, grace time.Duration, status models.QuizAttemptStatus) ([]*models.ClosedQuizAttempt, error)
 }
 
 // quizAttemptRepository implements the QuizAttemptRepository interface.
@@ -197,3 +201,34 @@ func (r *quizAttemptRepository) CountQuizAttemptsByQuiz(ctx context.Context, col
 
 	return count, nil
 }
+
+// CloseAbandonedQuizAttempts moves every in-progress attempt whose time ran out
+// more than grace before now to status and returns them. An attempt's time
+// runs out at its start plus the quiz's time limit, or for untimed quizzes when
+// the quiz closes; untimed quizzes that never close are left alone. Closing in
+// the UPDATE means overlapping runs never close the same attempt twice.
+func (r *quizAttemptRepository) CloseAbandonedQuizAttempts(ctx context.Context, now time.Time, grace time.Duration, status models.QuizAttemptStatus) ([]*models.ClosedQuizAttempt, error) {
+	sql := `WITH closed AS (
+				UPDATE quiz_attempts qa
+				SET status = $2, end_time = $1, updated_at = $1
+				FROM quizzes q
+				WHERE qa.quiz_id = q.id AND qa.status = 'in_progress'
+				AND CASE WHEN q.time_limit_minutes > 0
+					THEN qa.start_time + make_interval(mins => q.time_limit_minutes, secs => $3) < $1
+					ELSE q.available_until IS NOT NULL AND q.available_until + make_interval(secs => $3) < $1
+				END
+				RETURNING qa.id, qa.student_id, qa.quiz_id, qa.college_id, qa.start_time, qa.end_time,
+					qa.score, qa.status, qa.created_at, qa.updated_at, q.title AS quiz_title
+			)
+			SELECT closed.*, s.user_id
+			FROM closed
+			JOIN students s ON s.student_id = closed.student_id
+			ORDER BY closed.id`
+
+	attempts := []*models.ClosedQuizAttempt{}
+	err := pgxscan.Select(ctx, r.DB.Pool, &attempts, sql, now, status, grace.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("CloseAbandonedQuizAttempts: failed to execute query: %w", err)
+	}
+	return attempts, nil
+}
@@ -1,9 +1,13 @@
 package repository
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"eduhub/server/internal/models"
 )
@@ -39,4 +43,28 @@ func TestQuizAttemptModelValidation(t *testing.T) {
 	assert.Equal(t, 1, attempt.CollegeID)
 	assert.Equal(t, 3, attempt.CourseID)
 	assert.Equal(t, models.QuizAttemptStatusInProgress, attempt.Status)
-}
\ No newline at end of file
+}
+func TestCloseAbandonedQuizAttempts(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := NewQuizAttemptRepository(&DB{Pool: mock})
+
+	now := time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC)
+	started := now.Add(-2 * time.Hour)
+	score := 0
+	mock.ExpectQuery(`WITH closed AS \( UPDATE quiz_attempts qa SET status = \$2, end_time = \$1, updated_at = \$1 FROM quizzes q WHERE qa.quiz_id = q.id AND qa.status = 'in_progress'`).
+		WithArgs(now, models.QuizAttemptStatusExpired, 300.0).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "student_id", "quiz_id", "college_id", "start_time", "end_time",
+			"score", "status", "created_at", "updated_at", "quiz_title", "user_id"}).
+			AddRow(4, 101, 2, 1, started, now, &score, models.QuizAttemptStatusExpired, started, now, "Unit test", 77))
+
+	attempts, err := repo.CloseAbandonedQuizAttempts(context.Background(), now, 5*time.Minute, models.QuizAttemptStatusExpired)
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+	assert.Equal(t, 4, attempts[0].ID)
+	assert.Equal(t, models.QuizAttemptStatusExpired, attempts[0].Status)
+	assert.Equal(t, 77, attempts[0].UserID)
+	assert.Equal(t, "Unit test", attempts[0].QuizTitle)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -13,6 +13,13 @@ import (
 	"github.com/jackc/pgx/v5"
 )
 
+// ErrQuizNotFound is returned when the quiz does not exist in the college
+var ErrQuizNotFound = errors.New("quiz not found")
+
+// ErrQuizExtensionNotFound is returned by DeleteQuizExtension when the student
+// has no extension for the quiz
+var ErrQuizExtensionNotFound = errors.New("quiz extension not found")
+
 // QuizRepository defines the interface for quiz data operations.
 // It provides methods for creating, reading, updating, and deleting quiz records
 // with proper college-based isolation and parameterized queries for security.
@@ -43,6 +50,22 @@ type QuizRepository interface {
 
 	// CountQuizzesByCourse returns the total number of quizzes for a course.
 	CountQuizzesByCourse(ctx context.Context, collegeID int, courseID int) (int, error)
+
+	// UpsertQuizExtension grants or replaces a student's extension for a quiz.
+	UpsertQuizExtension(ctx context.Context, extension *models.QuizExtension) error
+
+	// GetQuizExtension returns the student's extension for a quiz, or nil if none was granted.
+	GetQuizExtension(ctx context.Context, collegeID int, quizID int, studentID int) (*models.QuizExtension, error)
+
+	// ListQuizExtensions lists all extensions granted for a quiz.
+	ListQuizExtensions(ctx context.Context, collegeID int, quizID int) ([]*models.QuizExtension, error)
+
+	// DeleteQuizExtension revokes a student's extension for a quiz.
+	DeleteQuizExtension(ctx context.Context, collegeID int, quizID int, studentID int) error
+
+	// IsQuizOpenToStudent reports whether the student may take the quiz: it is
+	// open to the whole course, or to the section the student is in.
+	IsQuizOpenToStudent(ctx context.Context, collegeID int, quizID int, studentID int) (bool, error)
 }
 
 // quizRepository implements the QuizRepository interface.
@@ -70,12 +93,14 @@ func (r *quizRepository) CreateQuiz(ctx context.Context, quiz *models.Quiz) erro
 	quiz.UpdatedAt = now
 
 	// SQL query with parameterized placeholders
-	sql := `INSERT INTO quizzes (college_id, course_id, title, description, time_limit_minutes, due_date, created_at, updated_at)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
+	sql := `INSERT INTO quizzes (college_id, course_id, title, description, time_limit_minutes, due_date,
+			available_from, available_until, section_id, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id`
 
 	// Prepare arguments in correct order
 	args := []any{quiz.CollegeID, quiz.CourseID, quiz.Title, quiz.Description,
-				 quiz.TimeLimitMinutes, quiz.DueDate, quiz.CreatedAt, quiz.UpdatedAt}
+				 quiz.TimeLimitMinutes, quiz.DueDate, quiz.AvailableFrom, quiz.AvailableUntil,
+				 quiz.SectionID, quiz.CreatedAt, quiz.UpdatedAt}
 
 	// Execute query and scan the returned ID
 	temp := struct {
@@ -83,6 +108,9 @@ func (r *quizRepository) CreateQuiz(ctx context.Context, quiz *models.Quiz) erro
 	}{}
 	err := pgxscan.Get(ctx, r.DB.Pool, &temp, sql, args...)
 	if err != nil {
+		if isSectionFKViolation(err) {
+			return ErrSectionNotFound
+		}
 		return fmt.Errorf("CreateQuiz: failed to execute query: %w", err)
 	}
 
@@ -102,14 +130,15 @@ func (r *quizRepository) GetQuizByID(ctx context.Context, collegeID int, quizID
 	quiz := &models.Quiz{}
 
 	// Query with college isolation
-	sql := `SELECT id, college_id, course_id, title, description, time_limit_minutes, due_date, created_at, updated_at
+	sql := `SELECT id, college_id, course_id, title, description, time_limit_minutes, due_date,
+			available_from, available_until, section_id, created_at, updated_at
 			FROM quizzes WHERE id = $1 AND college_id = $2`
 	args := []any{quizID, collegeID}
 
 	err := pgxscan.Get(ctx, r.DB.Pool, quiz, sql, args...)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, fmt.Errorf("GetQuizByID: quiz not found (id: %d, college: %d)", quizID, collegeID)
+			return nil, fmt.Errorf("GetQuizByID: %w (id: %d, college: %d)", ErrQuizNotFound, quizID, collegeID)
 		}
 		return nil, fmt.Errorf("GetQuizByID: failed to execute query: %w", err)
 	}
@@ -129,19 +158,23 @@ func (r *quizRepository) UpdateQuiz(ctx context.Context, quiz *models.Quiz) erro
 	quiz.UpdatedAt = time.Now()
 
 	// Update query with college isolation
-	sql := `UPDATE quizzes SET title = $1, description = $2, time_limit_minutes = $3, due_date = $4, updated_at = $5
-			WHERE id = $6 AND college_id = $7`
+	sql := `UPDATE quizzes SET title = $1, description = $2, time_limit_minutes = $3, due_date = $4,
+			available_from = $5, available_until = $6, section_id = $7, updated_at = $8
+			WHERE id = $9 AND college_id = $10`
 	args := []any{quiz.Title, quiz.Description, quiz.TimeLimitMinutes, quiz.DueDate,
-				 quiz.UpdatedAt, quiz.ID, quiz.CollegeID}
+				 quiz.AvailableFrom, quiz.AvailableUntil, quiz.SectionID, quiz.UpdatedAt, quiz.ID, quiz.CollegeID}
 
 	cmdTag, err := r.DB.Pool.Exec(ctx, sql, args...)
 	if err != nil {
+		if isSectionFKViolation(err) {
+			return ErrSectionNotFound
+		}
 		return fmt.Errorf("UpdateQuiz: failed to execute query: %w", err)
 	}
 
 	// Check if any rows were affected
 	if cmdTag.RowsAffected() == 0 {
-		return fmt.Errorf("UpdateQuiz: quiz not found or no changes (id: %d, college: %d)", quiz.ID, quiz.CollegeID)
+		return fmt.Errorf("UpdateQuiz: %w (id: %d, college: %d)", ErrQuizNotFound, quiz.ID, quiz.CollegeID)
 	}
 
 	return nil
@@ -169,7 +202,8 @@ func (r *quizRepository) UpdateQuizPartial(ctx context.Context, collegeID int, q
 
 	// Check if at least one field is being updated
 	hasUpdates := req.Title != nil || req.Description != nil || req.TimeLimitMinutes != nil ||
-				 req.DueDate != nil || req.CollegeID != nil || req.CourseID != nil
+				 req.DueDate != nil || req.CollegeID != nil || req.CourseID != nil ||
+				 req.AvailableFrom != nil || req.AvailableUntil != nil || req.SectionID != nil
 	if !hasUpdates {
 		return fmt.Errorf("UpdateQuizPartial: at least one field must be provided for update")
 	}
@@ -210,6 +244,26 @@ func (r *quizRepository) UpdateQuizPartial(ctx context.Context, collegeID int, q
 		setClauses = append(setClauses, fmt.Sprintf("due_date = $%d", paramCount))
 		args = append(args, *req.DueDate)
 	}
+	if req.AvailableFrom != nil {
+		paramCount++
+		setClauses = append(setClauses, fmt.Sprintf("available_from = $%d", paramCount))
+		args = append(args, *req.AvailableFrom)
+	}
+	if req.AvailableUntil != nil {
+		paramCount++
+		setClauses = append(setClauses, fmt.Sprintf("available_until = $%d", paramCount))
+		args = append(args, *req.AvailableUntil)
+	}
+	if req.SectionID != nil {
+		// Section 0 opens the quiz to the whole course again
+		var sectionID *int
+		if *req.SectionID > 0 {
+			sectionID = req.SectionID
+		}
+		paramCount++
+		setClauses = append(setClauses, fmt.Sprintf("section_id = $%d", paramCount))
+		args = append(args, sectionID)
+	}
 
 	// Add WHERE clause parameters
 	args = append(args, quizID, collegeID)
@@ -217,18 +271,21 @@ func (r *quizRepository) UpdateQuizPartial(ctx context.Context, collegeID int, q
 	// Build final SQL query
 	sql := fmt.Sprintf(`UPDATE quizzes SET %s WHERE id = $%d AND college_id = $%d`,
 		strings.Join(setClauses, ", "),
-		len(setClauses)+1,
-		len(setClauses)+2)
+		paramCount+1,
+		paramCount+2)
 
 	// Execute the update
 	cmdTag, err := r.DB.Pool.Exec(ctx, sql, args...)
 	if err != nil {
+		if isSectionFKViolation(err) {
+			return ErrSectionNotFound
+		}
 		return fmt.Errorf("UpdateQuizPartial: failed to execute query: %w", err)
 	}
 
 	// Check if the quiz was found and updated
 	if cmdTag.RowsAffected() == 0 {
-		return fmt.Errorf("UpdateQuizPartial: quiz not found or no changes (id: %d, college: %d)", quizID, collegeID)
+		return fmt.Errorf("UpdateQuizPartial: %w (id: %d, college: %d)", ErrQuizNotFound, quizID, collegeID)
 	}
 
 	return nil
@@ -252,7 +309,7 @@ func (r *quizRepository) DeleteQuiz(ctx context.Context, collegeID int, quizID i
 
 	// Check if any rows were affected
 	if cmdTag.RowsAffected() == 0 {
-		return fmt.Errorf("DeleteQuiz: quiz not found (id: %d, college: %d)", quizID, collegeID)
+		return fmt.Errorf("DeleteQuiz: %w (id: %d, college: %d)", ErrQuizNotFound, quizID, collegeID)
 	}
 
 	return nil
@@ -268,7 +325,8 @@ func (r *quizRepository) FindQuizzesByCourse(ctx context.Context, collegeID int,
 	
 	quizzes := []*models.Quiz{}
 
-	sql := `SELECT id, college_id, course_id, title, description, time_limit_minutes, due_date, created_at, updated_at
+	sql := `SELECT id, college_id, course_id, title, description, time_limit_minutes, due_date,
+			available_from, available_until, section_id, created_at, updated_at
 			FROM quizzes
 			WHERE college_id = $1 AND course_id = $2
 			ORDER BY due_date DESC, created_at DESC
@@ -304,3 +362,108 @@ func (r *quizRepository) CountQuizzesByCourse(ctx context.Context, collegeID int
 
 	return temp.Count, nil
 }
+
+// UpsertQuizExtension grants a student an extension for a quiz, replacing any
+// earlier extension for the same student.
+func (r *quizRepository) UpsertQuizExtension(ctx context.Context, extension *models.QuizExtension) error {
+	// Check if database connection is available
+	if r.DB == nil || r.DB.Pool == nil {
+		return fmt.Errorf("database connection is required")
+	}
+
+	sql := `INSERT INTO quiz_extensions (quiz_id, student_id, college_id, available_until, reason, granted_by)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (quiz_id, student_id)
+			DO UPDATE SET available_until = EXCLUDED.available_until,
+						 reason = EXCLUDED.reason,
+						 granted_by = EXCLUDED.granted_by,
+						 updated_at = NOW()
+			RETURNING id, created_at, updated_at`
+	args := []any{extension.QuizID, extension.StudentID, extension.CollegeID,
+		extension.AvailableUntil, extension.Reason, extension.GrantedBy}
+
+	err := r.DB.Pool.QueryRow(ctx, sql, args...).Scan(&extension.ID, &extension.CreatedAt, &extension.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("UpsertQuizExtension: failed to execute query: %w", err)
+	}
+	return nil
+}
+
+// GetQuizExtension returns the student's extension for a quiz.
+// Returns nil without an error when no extension was granted.
+func (r *quizRepository) GetQuizExtension(ctx context.Context, collegeID int, quizID int, studentID int) (*models.QuizExtension, error) {
+	// Check if database connection is available
+	if r.DB == nil || r.DB.Pool == nil {
+		return nil, fmt.Errorf("database connection is required")
+	}
+
+	extension := &models.QuizExtension{}
+	sql := `SELECT id, quiz_id, student_id, college_id, available_until, COALESCE(reason, '') AS reason,
+			granted_by, created_at, updated_at
+			FROM quiz_extensions WHERE quiz_id = $1 AND student_id = $2 AND college_id = $3`
+
+	err := pgxscan.Get(ctx, r.DB.Pool, extension, sql, quizID, studentID, collegeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("GetQuizExtension: failed to execute query: %w", err)
+	}
+	return extension, nil
+}
+
+// ListQuizExtensions lists the extensions granted for a quiz, latest deadline first.
+func (r *quizRepository) ListQuizExtensions(ctx context.Context, collegeID int, quizID int) ([]*models.QuizExtension, error) {
+	// Check if database connection is available
+	if r.DB == nil || r.DB.Pool == nil {
+		return nil, fmt.Errorf("database connection is required")
+	}
+
+	extensions := []*models.QuizExtension{}
+	sql := `SELECT id, quiz_id, student_id, college_id, available_until, COALESCE(reason, '') AS reason,
+			granted_by, created_at, updated_at
+			FROM quiz_extensions WHERE quiz_id = $1 AND college_id = $2
+			ORDER BY available_until DESC, student_id`
+
+	if err := pgxscan.Select(ctx, r.DB.Pool, &extensions, sql, quizID, collegeID); err != nil {
+		return nil, fmt.Errorf("ListQuizExtensions: failed to execute query: %w", err)
+	}
+	return extensions, nil
+}
+
+// DeleteQuizExtension revokes a student's extension for a quiz.
+func (r *quizRepository) DeleteQuizExtension(ctx context.Context, collegeID int, quizID int, studentID int) error {
+	// Check if database connection is available
+	if r.DB == nil || r.DB.Pool == nil {
+		return fmt.Errorf("database connection is required")
+	}
+
+	sql := `DELETE FROM quiz_extensions WHERE quiz_id = $1 AND student_id = $2 AND college_id = $3`
+	cmdTag, err := r.DB.Pool.Exec(ctx, sql, quizID, studentID, collegeID)
+	if err != nil {
+		return fmt.Errorf("DeleteQuizExtension: failed to execute query: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return fmt.Errorf("DeleteQuizExtension: %w (quiz: %d, student: %d)", ErrQuizExtensionNotFound, quizID, studentID)
+	}
+	return nil
+}
+
+// IsQuizOpenToStudent reports whether a section-only quiz is open to the
+// student. Quizzes without a section are open to everyone in the course.
+func (r *quizRepository) IsQuizOpenToStudent(ctx context.Context, collegeID int, quizID int, studentID int) (bool, error) {
+	sql := `SELECT q.section_id IS NULL OR EXISTS (
+				SELECT 1 FROM enrollments e
+				WHERE e.college_id = q.college_id AND e.course_id = q.course_id
+				AND e.section_id = q.section_id AND e.student_id = $3)
+			FROM quizzes q WHERE q.id = $1 AND q.college_id = $2`
+
+	var open bool
+	if err := r.DB.Pool.QueryRow(ctx, sql, quizID, collegeID, studentID).Scan(&open); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, fmt.Errorf("IsQuizOpenToStudent: %w (id: %d, college: %d)", ErrQuizNotFound, quizID, collegeID)
+		}
+		return false, fmt.Errorf("IsQuizOpenToStudent: failed to execute query: %w", err)
+	}
+	return open, nil
+}
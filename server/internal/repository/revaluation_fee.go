@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"eduhub/server/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrRevaluationFeeUnpaid is wrapped by every RevaluationFeeDueError
+var ErrRevaluationFeeUnpaid = errors.New("revaluation fee has not been paid")
+
+// RevaluationFeeDueError is returned by CreateRevaluationRequest when the
+// payments on the fee assignment do not yet cover the request's fee
+type RevaluationFeeDueError struct {
+	FeeAssignmentID int
+	AmountDue       float64
+}
+
+func (e *RevaluationFeeDueError) Error() string {
+	return fmt.Sprintf("%v: %.2f due", ErrRevaluationFeeUnpaid, e.AmountDue)
+}
+
+func (e *RevaluationFeeDueError) Unwrap() error {
+	return ErrRevaluationFeeUnpaid
+}
+
+// createPaidRevaluationRequest creates a request paid from its fee assignment.
+// The assignment's credit is its waiver plus completed payments, less the
+// fees of the requests already paid from it. Locking the assignment keeps two
+// requests from spending the same payment.
+func (r *examRepository) createPaidRevaluationRequest(ctx context.Context, request *models.RevaluationRequest) error {
+	if request.FeeAmount == nil {
+		return errors.New("a paid revaluation request needs a fee amount")
+	}
+
+	beginner, ok := r.db.Pool.(BeginPool)
+	if !ok {
+		return fmt.Errorf("transaction support is required")
+	}
+
+	tx, err := beginner.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var credit float64
+	err = tx.QueryRow(ctx, `SELECT COALESCE(fa.waiver_amount, 0)
+				+ COALESCE((SELECT SUM(amount) FROM fee_payments
+					WHERE fee_assignment_id = fa.id AND payment_status = 'completed'), 0)
+				- COALESCE((SELECT SUM(fee_amount) FROM revaluation_requests
+					WHERE fee_assignment_id = fa.id), 0)
+			FROM fee_assignments fa WHERE fa.id = $1 AND fa.student_id = $2
+			FOR UPDATE`, *request.FeeAssignmentID, request.StudentID).Scan(&credit)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("fee assignment not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read revaluation fee credit: %w", err)
+	}
+
+	// Amounts are stored in cents; rounding keeps float error from leaving a
+	// fraction of a cent due
+	if due := math.Round((*request.FeeAmount-credit)*100) / 100; due > 0 {
+		return &RevaluationFeeDueError{FeeAssignmentID: *request.FeeAssignmentID, AmountDue: due}
+	}
+
+	if err := insertRevaluationRequest(ctx, tx, request); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"eduhub/server/internal/models"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+type SearchRepository interface {
+	// Search matches students, courses and exams in one query, returning at
+	// most perType results of each type and limit results overall
+	Search(ctx context.Context, scope models.SearchScope, term string, perType, limit int) ([]*models.SearchResult, error)
+}
+
+type searchRepository struct {
+	DB *DB
+}
+
+func NewSearchRepository(db *DB) SearchRepository {
+	return &searchRepository{DB: db}
+}
+
+// searchRankSQL ranks a column against the search term: exact, prefix, then contains
+func searchRankSQL(columns ...string) string {
+	exact := make([]string, len(columns))
+	prefix := make([]string, len(columns))
+	for i, col := range columns {
+		exact[i] = fmt.Sprintf("LOWER(%s) = $2", col)
+		prefix[i] = fmt.Sprintf("%s ILIKE $3 || '%%'", col)
+	}
+	return fmt.Sprintf("CASE WHEN %s THEN 0 WHEN %s THEN 1 ELSE 2 END",
+		strings.Join(exact, " OR "), strings.Join(prefix, " OR "))
+}
+
+func (r *searchRepository) Search(ctx context.Context, scope models.SearchScope, term string, perType, limit int) ([]*models.SearchResult, error) {
+	sql := `(SELECT 'student' AS type, s.student_id AS id, u.name AS title, s.roll_no AS subtitle,
+			` + searchRankSQL("u.name", "s.roll_no") + ` AS rank
+		FROM students s
+		JOIN users u ON u.id = s.user_id
+		WHERE $7 AND s.college_id = $1
+			AND (u.name ILIKE '%' || $3 || '%' OR s.roll_no ILIKE '%' || $3 || '%')
+			AND ($5 = 0 OR EXISTS (
+				SELECT 1 FROM enrollments e
+				JOIN courses c ON c.id = e.course_id
+				WHERE e.student_id = s.student_id AND c.instructor_id = $5))
+		ORDER BY rank, title
+		LIMIT $4)
+	UNION ALL
+	(SELECT 'course' AS type, c.id, c.name AS title, COALESCE(u.name, '') AS subtitle,
+			` + searchRankSQL("c.name") + ` AS rank
+		FROM courses c
+		LEFT JOIN users u ON u.id = c.instructor_id
+		WHERE c.college_id = $1 AND c.name ILIKE '%' || $3 || '%'
+		ORDER BY rank, title
+		LIMIT $4)
+	UNION ALL
+	(SELECT 'exam' AS type, x.id, x.title, c.name AS subtitle,
+			` + searchRankSQL("x.title") + ` AS rank
+		FROM exams x
+		JOIN courses c ON c.id = x.course_id
+		WHERE x.college_id = $1 AND x.deleted_at IS NULL AND x.title ILIKE '%' || $3 || '%'
+			AND ($5 = 0 OR c.instructor_id = $5)
+			AND ($6 = 0 OR EXISTS (
+				SELECT 1 FROM enrollments e
+				WHERE e.student_id = $6 AND e.course_id = x.course_id))
+		ORDER BY rank, title
+		LIMIT $4)
+	ORDER BY rank, type, title
+	LIMIT $8`
+
+	lowered := strings.ToLower(term)
+	args := []any{scope.CollegeID, lowered, escapeLikePattern(lowered), perType,
+		scope.InstructorID, scope.StudentID, scope.IncludeStudents, limit}
+
+	results := make([]*models.SearchResult, 0)
+	if err := pgxscan.Select(ctx, r.DB.Pool, &results, sql, args...); err != nil {
+		return nil, fmt.Errorf("Search: %w", err)
+	}
+	return results, nil
+}
+
+// escapeLikePattern escapes LIKE wildcards so the term is matched literally
+func escapeLikePattern(term string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(term)
+}
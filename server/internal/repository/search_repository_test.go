@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"eduhub/server/internal/models"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchRepository_Search(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewSearchRepository(&DB{Pool: mock})
+	scope := models.SearchScope{CollegeID: 1, IncludeStudents: true, InstructorID: 5}
+
+	rows := pgxmock.NewRows([]string{"type", "id", "title", "subtitle", "rank"}).
+		AddRow("course", 3, "Algebra I", "Dr. Rao", 1).
+		AddRow("student", 8, "Alina Das", "CS-101", 1)
+
+	mock.ExpectQuery(`(?s)FROM students s.*UNION ALL.*FROM courses c.*UNION ALL.*FROM exams x.*ORDER BY rank, type, title\s+LIMIT \$8`).
+		WithArgs(1, "al_g", `al\_g`, 10, 5, 0, true, 20).
+		WillReturnRows(rows)
+
+	results, err := repo.Search(context.Background(), scope, "Al_G", 10, 20)
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, models.SearchTypeCourse, results[0].Type)
+	assert.Equal(t, "CS-101", results[1].Subtitle)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEscapeLikePattern(t *testing.T) {
+	assert.Equal(t, `50\% off\_now \\o`, escapeLikePattern(`50% off_now \o`))
+}
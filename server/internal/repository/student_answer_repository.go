@@ -21,6 +21,10 @@ type StudentAnswerRepository interface {
 	// Uses UPSERT to handle conflicts on (quiz_attempt_id, question_id).
 	CreateStudentAnswer(ctx context.Context, answer *models.StudentAnswer) error
 
+	// AutosaveStudentAnswers upserts a batch of in-progress answers in one transaction,
+	// stamping each with savedAt as its LastSavedAt.
+	AutosaveStudentAnswers(ctx context.Context, answers []*models.StudentAnswer, savedAt time.Time) error
+
 	// GetStudentAnswerByID retrieves a student answer by its ID with college isolation.
 	// Returns an error if the answer is not found or doesn't belong to the college.
 	GetStudentAnswerByID(ctx context.Context, collegeID int, answerID int) (*models.StudentAnswer, error)
@@ -53,10 +57,45 @@ func NewStudentAnswerRepository(db *DB) StudentAnswerRepository {
 // Uses UPSERT (ON CONFLICT) to handle duplicate answers for the same question in the same attempt.
 // Uses parameterized queries to prevent SQL injection.
 func (r *studentAnswerRepository) CreateStudentAnswer(ctx context.Context, answer *models.StudentAnswer) error {
+	if err := upsertStudentAnswer(ctx, r.DB.Pool, answer, time.Now()); err != nil {
+		return fmt.Errorf("CreateStudentAnswer: failed to execute query: %w", err)
+	}
+	return nil
+}
+
+// AutosaveStudentAnswers upserts a batch of answers for an in-progress attempt.
+// Either every answer is saved or none is, so a recovered attempt never mixes
+// two autosaves.
+func (r *studentAnswerRepository) AutosaveStudentAnswers(ctx context.Context, answers []*models.StudentAnswer, savedAt time.Time) error {
+	beginner, ok := r.DB.Pool.(BeginPool)
+	if !ok {
+		return fmt.Errorf("AutosaveStudentAnswers: database pool does not support transactions")
+	}
+	tx, err := beginner.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("AutosaveStudentAnswers: failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, answer := range answers {
+		if err := upsertStudentAnswer(ctx, tx, answer, savedAt); err != nil {
+			return fmt.Errorf("AutosaveStudentAnswers: failed to save answer for question %d: %w", answer.QuestionID, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("AutosaveStudentAnswers: failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// upsertStudentAnswer inserts or replaces the answer for its (attempt, question)
+// pair, using now for the timestamps and LastSavedAt.
+func upsertStudentAnswer(ctx context.Context, q pgxscan.Querier, answer *models.StudentAnswer, now time.Time) error {
 	// Set timestamps
-	now := time.Now()
 	answer.CreatedAt = now
 	answer.UpdatedAt = now
+	answer.LastSavedAt = &now
 	selectedOptionID := firstSelectedOptionID(answer.SelectedOptionID)
 
 	// SQL query with UPSERT to handle conflicts
@@ -70,9 +109,10 @@ func (r *studentAnswerRepository) CreateStudentAnswer(ctx context.Context, answe
 			marks_awarded,
 			points_awarded,
 			created_at,
-			updated_at
+			updated_at,
+			last_saved_at
 		)
-			VALUES ($1, $1, $2, $3, $4, $5, $6, $6, $7, $8)
+			VALUES ($1, $1, $2, $3, $4, $5, $6, $6, $7, $8, $9)
 			ON CONFLICT (quiz_attempt_id, question_id)
 			DO UPDATE SET attempt_id = EXCLUDED.attempt_id,
 						 quiz_attempt_id = EXCLUDED.quiz_attempt_id,
@@ -81,21 +121,21 @@ func (r *studentAnswerRepository) CreateStudentAnswer(ctx context.Context, answe
 						 is_correct = EXCLUDED.is_correct,
 						 marks_awarded = EXCLUDED.marks_awarded,
 						 points_awarded = EXCLUDED.points_awarded,
-						 updated_at = EXCLUDED.updated_at
+						 updated_at = EXCLUDED.updated_at,
+						 last_saved_at = EXCLUDED.last_saved_at
 			RETURNING id`
 
 	// Prepare arguments in correct order
 	args := []any{answer.QuizAttemptID, answer.QuestionID, selectedOptionID,
 		answer.AnswerText, answer.IsCorrect, answer.PointsAwarded,
-		answer.CreatedAt, answer.UpdatedAt}
+		answer.CreatedAt, answer.UpdatedAt, answer.LastSavedAt}
 
 	// Execute query and scan the returned ID
 	temp := struct {
 		ID int `db:"id"`
 	}{}
-	err := pgxscan.Get(ctx, r.DB.Pool, &temp, sql, args...)
-	if err != nil {
-		return fmt.Errorf("CreateStudentAnswer: failed to execute query: %w", err)
+	if err := pgxscan.Get(ctx, q, &temp, sql, args...); err != nil {
+		return err
 	}
 
 	// Set the generated ID on the answer object
@@ -116,6 +156,7 @@ func (r *studentAnswerRepository) GetStudentAnswerByID(ctx context.Context, coll
 			sa.answer_text,
 			sa.is_correct,
 			COALESCE(sa.points_awarded, sa.marks_awarded) AS points_awarded,
+			sa.last_saved_at,
 			sa.created_at,
 			sa.updated_at
 			FROM student_answers sa
@@ -172,6 +213,7 @@ func (r *studentAnswerRepository) FindStudentAnswersByAttempt(ctx context.Contex
 			sa.answer_text,
 			sa.is_correct,
 			COALESCE(sa.points_awarded, sa.marks_awarded) AS points_awarded,
+			sa.last_saved_at,
 			sa.created_at,
 			sa.updated_at
 			FROM student_answers sa
@@ -202,6 +244,7 @@ func (r *studentAnswerRepository) GetStudentAnswerForQuestion(ctx context.Contex
 			sa.answer_text,
 			sa.is_correct,
 			COALESCE(sa.points_awarded, sa.marks_awarded) AS points_awarded,
+			sa.last_saved_at,
 			sa.created_at,
 			sa.updated_at
 			FROM student_answers sa
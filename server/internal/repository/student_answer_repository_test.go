@@ -1,9 +1,14 @@
 package repository
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/pashagolub/pgxmock/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"eduhub/server/internal/models"
 )
@@ -35,4 +40,31 @@ func TestStudentAnswerModelValidation(t *testing.T) {
 	assert.Equal(t, 2, answer.QuizAttemptID)
 	assert.Equal(t, 3, answer.QuestionID)
 	assert.Equal(t, "Test Answer", answer.AnswerText)
-}
\ No newline at end of file
+}
+
+func TestAutosaveStudentAnswers_RollsBackOnFailure(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+	repo := NewStudentAnswerRepository(&DB{Pool: mock})
+	savedAt := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO student_answers`).
+		WithArgs(9, 1, pgxmock.AnyArg(), "draft", pgxmock.AnyArg(), pgxmock.AnyArg(), savedAt, savedAt, &savedAt).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(41))
+	mock.ExpectQuery(`INSERT INTO student_answers`).
+		WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	answers := []*models.StudentAnswer{
+		{QuizAttemptID: 9, QuestionID: 1, AnswerText: "draft"},
+		{QuizAttemptID: 9, QuestionID: 2},
+	}
+	err = repo.AutosaveStudentAnswers(context.Background(), answers, savedAt)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "question 2")
+	assert.Equal(t, &savedAt, answers[0].LastSavedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
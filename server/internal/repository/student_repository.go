@@ -27,8 +27,26 @@ type StudentRepository interface {
 	FindAllStudentsByCollege(ctx context.Context, collegeID int, limit, offset uint64) ([]*models.Student, error)
 	CountStudentsByCollege(ctx context.Context, collegeID int) (int, error)
 	UpdateStudentPartial(ctx context.Context, collegeID int, studentID int, req *models.UpdateStudentRequest) error
+
+	// Bulk import
+	FindStudentIDByRollNo(ctx context.Context, collegeID int, rollNo string) (int, error)
+	CreateStudentWithUser(ctx context.Context, user *models.User, student *models.Student) error
+
+	// Audited activation changes
+	ChangeStudentStatus(ctx context.Context, change *models.StudentStatusChange) error
+	ListStudentStatusHistory(ctx context.Context, collegeID int, studentID int) ([]*models.StudentStatusChange, error)
 }
 
+var (
+	ErrStudentNotFound = errors.New("student not found")
+	ErrRollNoExists    = errors.New("roll number already exists in this college")
+	ErrEmailInUse      = errors.New("email is already used by another account")
+)
+
+// ErrStudentStatusUnchanged is returned when a student is already in the
+// requested state, so no audit entry is written for a no-op.
+var ErrStudentStatusUnchanged = errors.New("student is already in the requested state")
+
 type studentRepository struct {
 	Pool PoolIface
 }
@@ -75,7 +93,7 @@ func (s *studentRepository) CreateStudent(ctx context.Context, student *models.S
 }
 
 func (s *studentRepository) GetStudentByRollNo(ctx context.Context, collegeID int, rollNo string) (*models.Student, error) {
-	sql := `SELECT student_id, user_id, college_id, kratos_identity_id, enrollment_year, roll_no, is_active, created_at, updated_at
+	sql := `SELECT student_id, user_id, college_id, kratos_identity_id, enrollment_year, roll_no, section, is_active, created_at, updated_at
 FROM students
 WHERE roll_no = $1 AND college_id = $2`
 
@@ -109,7 +127,7 @@ WHERE student_id = $1 AND college_id = $2`
 }
 
 func (s *studentRepository) FindAllStudentsByCollege(ctx context.Context, collegeID int, limit, offset uint64) ([]*models.Student, error) {
-	sql := `SELECT student_id, user_id, college_id, kratos_identity_id, enrollment_year, roll_no, is_active, created_at, updated_at
+	sql := `SELECT student_id, user_id, college_id, kratos_identity_id, enrollment_year, roll_no, section, is_active, created_at, updated_at
 FROM students
 WHERE college_id = $1
 ORDER BY roll_no ASC
@@ -139,7 +157,7 @@ WHERE college_id = $1`
 }
 
 func (s *studentRepository) GetStudentByID(ctx context.Context, collegeID int, studentID int) (*models.Student, error) {
-	sql := `SELECT student_id, user_id, college_id, kratos_identity_id, enrollment_year, roll_no, is_active, created_at, updated_at
+	sql := `SELECT student_id, user_id, college_id, kratos_identity_id, enrollment_year, roll_no, section, is_active, created_at, updated_at
 FROM students
 WHERE student_id = $1 AND college_id = $2`
 
@@ -221,7 +239,7 @@ WHERE roll_no = $1`
 }
 
 func (s *studentRepository) FindByKratosID(ctx context.Context, kratosID string) (*models.Student, error) {
-	sql := `SELECT student_id, user_id, college_id, kratos_identity_id, enrollment_year, roll_no, is_active, created_at, updated_at
+	sql := `SELECT student_id, user_id, college_id, kratos_identity_id, enrollment_year, roll_no, section, is_active, created_at, updated_at
 FROM students
 WHERE kratos_identity_id = $1`
 
@@ -262,6 +280,12 @@ func (s *studentRepository) UpdateStudentPartial(ctx context.Context, collegeID
 		args = append(args, *req.RollNo)
 		argIndex++
 	}
+	if req.Section != nil {
+		// An empty section clears it
+		sql += fmt.Sprintf(`, section = NULLIF(trim($%d), '')`, argIndex)
+		args = append(args, *req.Section)
+		argIndex++
+	}
 	if req.IsActive != nil {
 		sql += fmt.Sprintf(`, is_active = $%d`, argIndex)
 		args = append(args, *req.IsActive)
@@ -278,3 +302,163 @@ func (s *studentRepository) UpdateStudentPartial(ctx context.Context, collegeID
 
 	return nil
 }
+
+// ChangeStudentStatus sets is_active to change.IsActive and appends the change to
+// student_status_history in one transaction. The student row is locked while the
+// previous state is read, and change is filled with PreviousIsActive, ID and
+// ChangedAt on success.
+func (s *studentRepository) ChangeStudentStatus(ctx context.Context, change *models.StudentStatusChange) error {
+	beginner, ok := s.Pool.(BeginPool)
+	if !ok {
+		return fmt.Errorf("transaction support is required")
+	}
+
+	tx, err := beginner.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var current struct {
+		IsActive bool `db:"is_active"`
+	}
+	err = pgxscan.Get(ctx, tx, &current, `SELECT is_active
+FROM students
+WHERE student_id = $1 AND college_id = $2
+FOR UPDATE`, change.StudentID, change.CollegeID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return ErrStudentNotFound
+		}
+		return fmt.Errorf("ChangeStudentStatus: failed to lock student: %w", err)
+	}
+	if current.IsActive == change.IsActive {
+		return ErrStudentStatusUnchanged
+	}
+	change.PreviousIsActive = current.IsActive
+
+	_, err = tx.Exec(ctx, `UPDATE students
+SET is_active = $1,
+    updated_at = NOW()
+WHERE student_id = $2 AND college_id = $3`, change.IsActive, change.StudentID, change.CollegeID)
+	if err != nil {
+		return fmt.Errorf("ChangeStudentStatus: failed to update student: %w", err)
+	}
+
+	err = tx.QueryRow(ctx, `INSERT INTO student_status_history
+    (student_id, college_id, action, previous_is_active, is_active, reason, changed_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, changed_at`,
+		change.StudentID,
+		change.CollegeID,
+		change.Action,
+		change.PreviousIsActive,
+		change.IsActive,
+		change.Reason,
+		change.ChangedBy,
+	).Scan(&change.ID, &change.ChangedAt)
+	if err != nil {
+		return fmt.Errorf("ChangeStudentStatus: failed to record history: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (s *studentRepository) ListStudentStatusHistory(ctx context.Context, collegeID int, studentID int) ([]*models.StudentStatusChange, error) {
+	sql := `SELECT id, student_id, college_id, action, previous_is_active, is_active, reason, changed_by, changed_at
+FROM student_status_history
+WHERE student_id = $1 AND college_id = $2
+ORDER BY changed_at DESC, id DESC`
+
+	history := []*models.StudentStatusChange{}
+	err := pgxscan.Select(ctx, s.Pool, &history, sql, studentID, collegeID)
+	if err != nil {
+		return nil, fmt.Errorf("ListStudentStatusHistory: failed to execute query: %w", err)
+	}
+	return history, nil
+}
+
+// FindStudentIDByRollNo returns the ID of the student with rollNo in the
+// college, or 0 if there is none. Roll numbers are compared ignoring case and
+// surrounding space, the same way the CSV import de-duplicates a file.
+func (s *studentRepository) FindStudentIDByRollNo(ctx context.Context, collegeID int, rollNo string) (int, error) {
+	sql := `SELECT student_id FROM students
+WHERE college_id = $1 AND lower(trim(roll_no)) = lower(trim($2))
+ORDER BY student_id ASC
+LIMIT 1`
+
+	var studentID int
+	err := s.Pool.QueryRow(ctx, sql, collegeID, rollNo).Scan(&studentID)
+	if err != nil {
+		if pgxscan.NotFound(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("FindStudentIDByRollNo: failed to execute query: %w", err)
+	}
+	return studentID, nil
+}
+
+// CreateStudentWithUser inserts the user and the student linked to it in one
+// transaction, so a failed import row never leaves a user without a student.
+// It returns ErrRollNoExists or ErrEmailInUse instead of tripping the unique
+// constraints.
+func (s *studentRepository) CreateStudentWithUser(ctx context.Context, user *models.User, student *models.Student) error {
+	beginner, ok := s.Pool.(BeginPool)
+	if !ok {
+		return fmt.Errorf("transaction support is required")
+	}
+
+	tx, err := beginner.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var taken struct {
+		RollNo bool `db:"roll_no_taken"`
+		Email  bool `db:"email_taken"`
+	}
+	err = pgxscan.Get(ctx, tx, &taken, `SELECT
+    EXISTS (SELECT 1 FROM students WHERE college_id = $1 AND lower(trim(roll_no)) = lower(trim($2))) AS roll_no_taken,
+    EXISTS (SELECT 1 FROM users WHERE email = $3 OR kratos_identity_id = $4) AS email_taken`,
+		student.CollegeID, student.RollNo, user.Email, user.KratosIdentityID)
+	if err != nil {
+		return fmt.Errorf("CreateStudentWithUser: failed to check duplicates: %w", err)
+	}
+	if taken.RollNo {
+		return ErrRollNoExists
+	}
+	if taken.Email {
+		return ErrEmailInUse
+	}
+
+	now := time.Now()
+	user.CreatedAt, user.UpdatedAt = now, now
+	err = tx.QueryRow(ctx, `INSERT INTO users (name, role, email, kratos_identity_id, is_active, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id`,
+		user.Name, user.Role, user.Email, user.KratosIdentityID, user.IsActive, user.CreatedAt, user.UpdatedAt,
+	).Scan(&user.ID)
+	if err != nil {
+		return fmt.Errorf("CreateStudentWithUser: failed to insert user: %w", err)
+	}
+
+	student.UserID = user.ID
+	student.KratosIdentityID = user.KratosIdentityID
+	student.CreatedAt, student.UpdatedAt = now, now
+	err = tx.QueryRow(ctx, `INSERT INTO students (user_id, college_id, kratos_identity_id, enrollment_year, roll_no, is_active, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING student_id`,
+		student.UserID, student.CollegeID, student.KratosIdentityID, student.EnrollmentYear,
+		student.RollNo, student.IsActive, student.CreatedAt, student.UpdatedAt,
+	).Scan(&student.StudentID)
+	if err != nil {
+		return fmt.Errorf("CreateStudentWithUser: failed to insert student: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
@@ -317,3 +317,125 @@ func TestFindByKratosID_NotFound(t *testing.T) {
 	assert.Nil(t, student)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestChangeStudentStatus(t *testing.T) {
+	mock, _, repo, ctx := setupStudentTest(t)
+	defer mock.Close()
+
+	changedAt := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT is_active\s+FROM students\s+WHERE student_id = \$1 AND college_id = \$2\s+FOR UPDATE`).
+		WithArgs(7, 1).
+		WillReturnRows(pgxmock.NewRows([]string{"is_active"}).AddRow(true))
+	mock.ExpectExec(`UPDATE students\s+SET is_active = \$1`).
+		WithArgs(false, 7, 1).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectQuery(`INSERT INTO student_status_history`).
+		WithArgs(7, 1, models.StudentStatusDeactivate, true, false, "fee default", 3).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "changed_at"}).AddRow(11, changedAt))
+	mock.ExpectCommit()
+
+	change := &models.StudentStatusChange{
+		StudentID: 7,
+		CollegeID: 1,
+		Action:    models.StudentStatusDeactivate,
+		IsActive:  false,
+		Reason:    "fee default",
+		ChangedBy: 3,
+	}
+	err := repo.ChangeStudentStatus(ctx, change)
+
+	require.NoError(t, err)
+	assert.True(t, change.PreviousIsActive)
+	assert.Equal(t, 11, change.ID)
+	assert.Equal(t, changedAt, change.ChangedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChangeStudentStatus_Unchanged(t *testing.T) {
+	mock, _, repo, ctx := setupStudentTest(t)
+	defer mock.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT is_active\s+FROM students`).
+		WithArgs(7, 1).
+		WillReturnRows(pgxmock.NewRows([]string{"is_active"}).AddRow(true))
+	mock.ExpectRollback()
+
+	err := repo.ChangeStudentStatus(ctx, &models.StudentStatusChange{
+		StudentID: 7,
+		CollegeID: 1,
+		Action:    models.StudentStatusReactivate,
+		IsActive:  true,
+		ChangedBy: 3,
+	})
+
+	assert.ErrorIs(t, err, ErrStudentStatusUnchanged)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestChangeStudentStatus_NotFound(t *testing.T) {
+	mock, _, repo, ctx := setupStudentTest(t)
+	defer mock.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT is_active\s+FROM students`).
+		WithArgs(7, 2).
+		WillReturnRows(pgxmock.NewRows([]string{"is_active"}))
+	mock.ExpectRollback()
+
+	err := repo.ChangeStudentStatus(ctx, &models.StudentStatusChange{
+		StudentID: 7,
+		CollegeID: 2,
+		Action:    models.StudentStatusDeactivate,
+		Reason:    "left college",
+		ChangedBy: 3,
+	})
+
+	assert.ErrorIs(t, err, ErrStudentNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateStudentWithUser(t *testing.T) {
+	mock, _, repo, ctx := setupStudentTest(t)
+	defer mock.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT\s+EXISTS \(SELECT 1 FROM students`).
+		WithArgs(1, "CS-010", "ravi@example.com", "kratos-1").
+		WillReturnRows(pgxmock.NewRows([]string{"roll_no_taken", "email_taken"}).AddRow(false, false))
+	mock.ExpectQuery(`INSERT INTO users`).
+		WithArgs("Ravi Kumar", "student", "ravi@example.com", "kratos-1", true, pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(42))
+	mock.ExpectQuery(`INSERT INTO students`).
+		WithArgs(42, 1, "kratos-1", 2025, "CS-010", true, pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnRows(pgxmock.NewRows([]string{"student_id"}).AddRow(9))
+	mock.ExpectCommit()
+
+	user := &models.User{Name: "Ravi Kumar", Role: "student", Email: "ravi@example.com", KratosIdentityID: "kratos-1", IsActive: true}
+	student := &models.Student{CollegeID: 1, EnrollmentYear: 2025, RollNo: "CS-010", IsActive: true}
+	err := repo.CreateStudentWithUser(ctx, user, student)
+
+	require.NoError(t, err)
+	assert.Equal(t, 42, user.ID)
+	assert.Equal(t, 42, student.UserID)
+	assert.Equal(t, 9, student.StudentID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateStudentWithUser_EmailInUse(t *testing.T) {
+	mock, _, repo, ctx := setupStudentTest(t)
+	defer mock.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT\s+EXISTS \(SELECT 1 FROM students`).
+		WillReturnRows(pgxmock.NewRows([]string{"roll_no_taken", "email_taken"}).AddRow(false, true))
+	mock.ExpectRollback()
+
+	err := repo.CreateStudentWithUser(ctx,
+		&models.User{Email: "ravi@example.com", KratosIdentityID: "kratos-1"},
+		&models.Student{CollegeID: 1, RollNo: "CS-010"})
+
+	assert.ErrorIs(t, err, ErrEmailInUse)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -16,6 +16,8 @@ type WebhookRepository interface {
 	GetWebhookByID(ctx context.Context, collegeID, webhookID int) (*models.Webhook, error)
 	UpdateWebhook(ctx context.Context, webhook *models.Webhook) error
 	DeleteWebhook(ctx context.Context, collegeID, webhookID int) error
+	CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	RecordWebhookOutcome(ctx context.Context, webhookID int, delivery *models.WebhookDelivery) error
 }
 
 type webhookRepository struct {
@@ -110,3 +112,37 @@ func (r *webhookRepository) DeleteWebhook(ctx context.Context, collegeID, webhoo
 	_, err := r.DB.Pool.Exec(ctx, sql, webhookID, collegeID)
 	return err
 }
+
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	sql := `INSERT INTO webhook_deliveries (webhook_id, event_type, payload, attempt_number, response_status, response_body, error_message, delivered_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, created_at`
+
+	return r.DB.Pool.QueryRow(ctx, sql,
+		delivery.WebhookID,
+		delivery.EventType,
+		delivery.Payload,
+		delivery.AttemptNumber,
+		delivery.ResponseStatus,
+		delivery.ResponseBody,
+		delivery.ErrorMessage,
+		delivery.DeliveredAt,
+	).Scan(&delivery.ID, &delivery.CreatedAt)
+}
+
+// RecordWebhookOutcome stores the final attempt of a delivery on the webhook.
+// failure_count counts consecutive failed deliveries and resets on success.
+func (r *webhookRepository) RecordWebhookOutcome(ctx context.Context, webhookID int, delivery *models.WebhookDelivery) error {
+	sql := `UPDATE webhooks
+			SET last_triggered_at = NOW(), last_payload = $2, last_response_status = $3, last_response_body = $4,
+				failure_count = CASE WHEN $5 THEN 0 ELSE COALESCE(failure_count, 0) + 1 END
+			WHERE id = $1`
+
+	_, err := r.DB.Pool.Exec(ctx, sql,
+		webhookID,
+		delivery.Payload,
+		delivery.ResponseStatus,
+		delivery.ResponseBody,
+		delivery.DeliveredAt != nil,
+	)
+	return err
+}
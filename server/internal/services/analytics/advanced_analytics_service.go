@@ -4,19 +4,30 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"slices"
+	"sync"
 	"time"
 
 	"eduhub/server/internal/config"
+	"eduhub/server/internal/models"
 	"eduhub/server/internal/repository"
+
+	"github.com/jackc/pgx/v5"
 )
 
 type AdvancedAnalyticsService interface {
 	GetStudentProgression(ctx context.Context, collegeID, studentID int) (*StudentProgression, error)
 	GetCourseEngagement(ctx context.Context, collegeID, courseID int) (*CourseEngagement, error)
-	GetPredictiveInsights(ctx context.Context, collegeID int) (*PredictiveInsights, error)
+	// GetPredictiveInsights analyses the whole college, or only the students
+	// enrolled in courseID when it is set
+	GetPredictiveInsights(ctx context.Context, collegeID int, courseID *int) (*PredictiveInsights, error)
 	GetLearningAnalytics(ctx context.Context, collegeID int, startDate, endDate *time.Time) (*LearningAnalytics, error)
 	GetPerformanceTrends(ctx context.Context, collegeID int, entityType string, entityID int) ([]PerformanceTrend, error)
 	GetComparativeAnalysis(ctx context.Context, collegeID int, courseIDs []int) (*ComparativeAnalysis, error)
+	ReloadConfig() (*config.AnalyticsConfig, error)
+	ListCourseSkills(ctx context.Context, collegeID int) ([]CourseSkillMapping, error)
+	SetCourseSkill(ctx context.Context, collegeID, courseID int, skill string) (*CourseSkillMapping, error)
+	DeleteCourseSkill(ctx context.Context, collegeID, courseID int) error
 }
 
 type StudentProgression struct {
@@ -48,14 +59,26 @@ type SkillPoint struct {
 }
 
 type CourseEngagement struct {
-	CourseID            int               `json:"course_id"`
-	TotalStudents       int               `json:"total_students"`
-	ActiveStudents      int               `json:"active_students"`
-	EngagementRate      float64           `json:"engagement_rate"`
-	ActivityBreakdown   map[string]int    `json:"activity_breakdown"`
-	PeakActivityHours   []int             `json:"peak_activity_hours"`
-	DropoutRiskStudents []int             `json:"dropout_risk_students"`
-	EngagementTimeline  []EngagementPoint `json:"engagement_timeline"`
+	CourseID            int                  `json:"course_id"`
+	TotalStudents       int                  `json:"total_students"`
+	ActiveStudents      int                  `json:"active_students"`
+	EngagementRate      float64              `json:"engagement_rate"`
+	ActivityBreakdown   map[string]int       `json:"activity_breakdown"`
+	PeakActivityHours   []int                `json:"peak_activity_hours"`
+	DropoutRiskStudents []int                `json:"dropout_risk_students"`
+	DropoutRisk         []DropoutRiskStudent `json:"dropout_risk"`
+	EngagementTimeline  []EngagementPoint    `json:"engagement_timeline"`
+}
+
+// DropoutRiskStudent is a student flagged by GetCourseEngagement with the
+// factors that flagged them and the values behind those factors. A value is
+// nil when the student has no attendance or grades in the course.
+type DropoutRiskStudent struct {
+	StudentID         int      `json:"student_id"`
+	RiskFactors       []string `json:"risk_factors"`
+	AttendanceRate    *float64 `json:"attendance_rate"`
+	AverageGrade      *float64 `json:"average_grade"`
+	DaysSinceActivity *int     `json:"days_since_activity"`
 }
 
 type EngagementPoint struct {
@@ -157,8 +180,13 @@ type CourseComparison struct {
 }
 
 type advancedAnalyticsService struct {
-	db              *repository.DB
-	basicAnalytics  AnalyticsService
+	db             *repository.DB
+	basicAnalytics AnalyticsService
+
+	// configMu guards analyticsConfig, which ReloadConfig swaps at runtime.
+	// The config itself is never mutated, so readers take the pointer once
+	// and use it for the whole calculation.
+	configMu        sync.RWMutex
 	analyticsConfig *config.AnalyticsConfig
 }
 
@@ -166,8 +194,47 @@ func NewAdvancedAnalyticsService(db *repository.DB, basicAnalytics AnalyticsServ
 	return &advancedAnalyticsService{
 		db:              db,
 		basicAnalytics:  basicAnalytics,
-		analyticsConfig: config.LoadAnalyticsConfig(),
+		analyticsConfig: readAnalyticsConfig(),
+	}
+}
+
+// readAnalyticsConfig reads the analytics config at startup. A bad override
+// file should not stop the server, so it falls back to the environment.
+func readAnalyticsConfig() *config.AnalyticsConfig {
+	cfg, err := config.ReadAnalyticsConfig()
+	if err != nil {
+		return config.LoadAnalyticsConfig()
+	}
+	return cfg
+}
+
+// riskConfigReceiver is implemented by services that flag at-risk students
+// with the analytics config, so ReloadConfig reaches them too
+type riskConfigReceiver interface {
+	setRiskConfig(cfg *config.AnalyticsConfig)
+}
+
+// ReloadConfig re-reads the analytics config and swaps it in. Calculations
+// already running keep the config they started with.
+func (s *advancedAnalyticsService) ReloadConfig() (*config.AnalyticsConfig, error) {
+	cfg, err := config.ReadAnalyticsConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload analytics config: %w", err)
+	}
+
+	s.configMu.Lock()
+	s.analyticsConfig = cfg
+	s.configMu.Unlock()
+	if receiver, ok := s.basicAnalytics.(riskConfigReceiver); ok {
+		receiver.setRiskConfig(cfg)
 	}
+	return cfg, nil
+}
+
+func (s *advancedAnalyticsService) currentConfig() *config.AnalyticsConfig {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+	return s.analyticsConfig
 }
 
 func (s *advancedAnalyticsService) GetStudentProgression(ctx context.Context, collegeID, studentID int) (*StudentProgression, error) {
@@ -214,7 +281,7 @@ func (s *advancedAnalyticsService) GetCourseEngagement(ctx context.Context, coll
 	engagement.TotalStudents = totalStudents
 
 	// Calculate active students (students with recent activity)
-	activeStudents, err := s.getActiveStudents(ctx, collegeID, courseID, 30) // last 30 days
+	activeStudents, err := s.getActiveStudents(ctx, collegeID, courseID, activeStudentWindowDays)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get active students: %w", err)
 	}
@@ -243,7 +310,11 @@ func (s *advancedAnalyticsService) GetCourseEngagement(ctx context.Context, coll
 	if err != nil {
 		return nil, fmt.Errorf("failed to get dropout risk students: %w", err)
 	}
-	engagement.DropoutRiskStudents = dropoutRisk
+	engagement.DropoutRisk = dropoutRisk
+	engagement.DropoutRiskStudents = make([]int, len(dropoutRisk))
+	for i, student := range dropoutRisk {
+		engagement.DropoutRiskStudents[i] = student.StudentID
+	}
 
 	// Get engagement timeline
 	timeline, err := s.getEngagementTimeline(ctx, collegeID, courseID)
@@ -255,18 +326,18 @@ func (s *advancedAnalyticsService) GetCourseEngagement(ctx context.Context, coll
 	return engagement, nil
 }
 
-func (s *advancedAnalyticsService) GetPredictiveInsights(ctx context.Context, collegeID int) (*PredictiveInsights, error) {
+func (s *advancedAnalyticsService) GetPredictiveInsights(ctx context.Context, collegeID int, courseID *int) (*PredictiveInsights, error) {
 	insights := &PredictiveInsights{}
 
 	// Identify at-risk students
-	atRiskStudents, err := s.identifyAtRiskStudents(ctx, collegeID)
+	atRiskStudents, err := s.identifyAtRiskStudents(ctx, collegeID, courseID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to identify at-risk students: %w", err)
 	}
 	insights.AtRiskStudents = atRiskStudents
 
 	// Predict course completion rates
-	completionRates, err := s.predictCourseCompletionRates(ctx, collegeID)
+	completionRates, err := s.predictCourseCompletionRates(ctx, collegeID, courseID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to predict completion rates: %w", err)
 	}
@@ -356,6 +427,7 @@ func (s *advancedAnalyticsService) GetComparativeAnalysis(ctx context.Context, c
 
 // Helper methods (simplified implementations)
 
+// getGradeProgression returns the student's latest 12 monthly averages, oldest first
 func (s *advancedAnalyticsService) getGradeProgression(ctx context.Context, collegeID, studentID int) ([]GradeProgressPoint, error) {
 	query := `
 		SELECT DATE_TRUNC('month', created_at) as month, AVG(percentage) as avg_grade
@@ -381,13 +453,15 @@ func (s *advancedAnalyticsService) getGradeProgression(ctx context.Context, coll
 		points = append(points, point)
 	}
 
+	slices.SortFunc(points, func(a, b GradeProgressPoint) int { return a.Date.Compare(b.Date) })
 	return points, nil
 }
 
+// getAttendanceTrends returns the student's latest 12 weekly attendance rates, oldest first
 func (s *advancedAnalyticsService) getAttendanceTrends(ctx context.Context, collegeID, studentID int) ([]AttendanceTrendPoint, error) {
 	query := `
 		SELECT DATE_TRUNC('week', date) as week,
-		       COALESCE(SUM(CASE WHEN status = 'Present' THEN 1 ELSE 0 END)::float / COUNT(*) * 100, 0) as attendance_rate
+		       COALESCE(SUM(CASE WHEN ` + models.AttendancePresentSQL("status") + ` THEN 1 ELSE 0 END)::float / COUNT(*) * 100, 0) as attendance_rate
 		FROM attendance
 		WHERE college_id = $1 AND student_id = $2
 		GROUP BY DATE_TRUNC('week', date)
@@ -409,6 +483,7 @@ func (s *advancedAnalyticsService) getAttendanceTrends(ctx context.Context, coll
 		points = append(points, point)
 	}
 
+	slices.SortFunc(points, func(a, b AttendanceTrendPoint) int { return a.Date.Compare(b.Date) })
 	return points, nil
 }
 
@@ -417,10 +492,13 @@ func (s *advancedAnalyticsService) analyzeOverallTrend(grades []GradeProgressPoi
 		return "insufficient_data"
 	}
 
-	// Simple trend analysis - compare first half with second half
+	// Simple trend analysis - compare the older half with the newer half.
+	// Sort a copy so the halves never depend on the caller's ordering.
+	grades = slices.Clone(grades)
+	slices.SortFunc(grades, func(a, b GradeProgressPoint) int { return a.Date.Compare(b.Date) })
 	mid := len(grades) / 2
-	firstHalf := grades[mid:]
-	secondHalf := grades[:mid]
+	firstHalf := grades[:mid]
+	secondHalf := grades[mid:]
 
 	var firstAvg, secondAvg float64
 	for _, g := range firstHalf {
@@ -455,7 +533,7 @@ func (s *advancedAnalyticsService) generateStudentRecommendations(trend string,
 
 	// Check attendance
 	if len(attendance) > 0 {
-		recent := attendance[0]
+		recent := attendance[len(attendance)-1]
 		if recent.AttendanceRate < 75 {
 			recommendations = append(recommendations, "Improve attendance - currently below 75%")
 		}
@@ -470,6 +548,9 @@ func (s *advancedAnalyticsService) getTotalStudents(ctx context.Context, college
 	return count, err
 }
 
+// activeStudentWindowDays is how recent activity must be to count a student as active
+const activeStudentWindowDays = 30
+
 func (s *advancedAnalyticsService) getActiveStudents(ctx context.Context, collegeID, courseID, days int) (int, error) {
 	query := `
 		SELECT COUNT(DISTINCT student_id) FROM (
@@ -550,79 +631,114 @@ func (s *advancedAnalyticsService) getPeakActivityHours(ctx context.Context, col
 	return hours, nil
 }
 
-func (s *advancedAnalyticsService) getDropoutRiskStudents(ctx context.Context, collegeID, courseID int) ([]int, error) {
+// getDropoutRiskStudents flags enrolled students whose attendance or grades
+// in the course are below the configured thresholds, or who have not attended
+// it for InactivityDays
+func (s *advancedAnalyticsService) getDropoutRiskStudents(ctx context.Context, collegeID, courseID int) ([]DropoutRiskStudent, error) {
+	cfg := s.currentConfig()
 	query := `
-		SELECT DISTINCT e.student_id
+		SELECT DISTINCT ON (e.student_id)
+			e.student_id,
+			att.attendance_rate,
+			gr.average_grade,
+			CURRENT_DATE - att.last_date AS days_since_activity
 		FROM enrollments e
+		LEFT JOIN LATERAL (
+			SELECT SUM(CASE WHEN ` + models.AttendancePresentSQL("a.status") + ` THEN 1 ELSE 0 END)::float8 * 100 / NULLIF(COUNT(*), 0) AS attendance_rate,
+			       MAX(a.date) AS last_date
+			FROM attendance a
+			WHERE a.college_id = e.college_id AND a.course_id = e.course_id AND a.student_id = e.student_id
+		) att ON TRUE
+		LEFT JOIN LATERAL (
+			SELECT AVG(g.percentage)::float8 AS average_grade
+			FROM grades g
+			WHERE g.college_id = e.college_id AND g.course_id = e.course_id AND g.student_id = e.student_id
+		) gr ON TRUE
 		WHERE e.college_id = $1 AND e.course_id = $2
 		AND (
-			-- Low attendance (< 60%)
-			EXISTS (
-				SELECT 1 FROM attendance a
-				WHERE a.college_id = e.college_id AND a.course_id = e.course_id AND a.student_id = e.student_id
-				GROUP BY a.student_id
-				HAVING COALESCE(SUM(CASE WHEN a.status = 'Present' THEN 1 ELSE 0 END)::float / NULLIF(COUNT(*),0),0) < 0.6
-			)
-			OR
-			-- Low grades (< 50%)
-			EXISTS (
-				SELECT 1 FROM grades g
-				WHERE g.college_id = e.college_id AND g.course_id = e.course_id AND g.student_id = e.student_id
-				GROUP BY g.student_id
-				HAVING COALESCE(AVG(g.percentage),0) < 50
-			)
-			OR
-			-- No recent activity (last 14 days)
-			NOT EXISTS (
-				SELECT 1 FROM attendance a
-				WHERE a.college_id = e.college_id AND a.course_id = e.course_id AND a.student_id = e.student_id
-				AND a.date >= CURRENT_DATE - INTERVAL '14 days'
-			)
-		)`
-
-	rows, err := s.db.Pool.Query(ctx, query, collegeID, courseID)
+			att.attendance_rate < $3
+			OR gr.average_grade < $4
+			OR att.last_date IS NULL
+			OR att.last_date <= CURRENT_DATE - $5::int
+		)
+		ORDER BY e.student_id`
+
+	rows, err := s.db.Pool.Query(ctx, query, collegeID, courseID,
+		cfg.AttendanceRiskThreshold, cfg.GradeRiskThreshold, cfg.InactivityDays)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	students := make([]int, 0)
+	students := make([]DropoutRiskStudent, 0)
 	for rows.Next() {
-		var studentID int
-		if err := rows.Scan(&studentID); err != nil {
+		var student DropoutRiskStudent
+		if err := rows.Scan(&student.StudentID, &student.AttendanceRate, &student.AverageGrade, &student.DaysSinceActivity); err != nil {
 			continue
 		}
-		students = append(students, studentID)
+		if explainDropoutRisk(&student, cfg) {
+			students = append(students, student)
+		}
 	}
 
-	return students, nil
+	return students, rows.Err()
+}
+
+// explainDropoutRisk fills in the factors that put the student at risk under
+// cfg and reports whether there are any
+func explainDropoutRisk(student *DropoutRiskStudent, cfg *config.AnalyticsConfig) bool {
+	student.RiskFactors = make([]string, 0)
+	if student.AttendanceRate != nil && *student.AttendanceRate < cfg.AttendanceRiskThreshold {
+		student.RiskFactors = append(student.RiskFactors, "Poor attendance")
+	}
+	if student.AverageGrade != nil && *student.AverageGrade < cfg.GradeRiskThreshold {
+		student.RiskFactors = append(student.RiskFactors, "Low grades")
+	}
+	if student.DaysSinceActivity == nil || *student.DaysSinceActivity >= cfg.InactivityDays {
+		student.RiskFactors = append(student.RiskFactors, "No recent activity")
+	}
+	return len(student.RiskFactors) > 0
 }
 
-func (s *advancedAnalyticsService) identifyAtRiskStudents(ctx context.Context, collegeID int) ([]RiskStudent, error) {
+// identifyAtRiskStudents scores every student in the college, or when courseID
+// is set only the students enrolled in it, on their grades and attendance in
+// that course
+func (s *advancedAnalyticsService) identifyAtRiskStudents(ctx context.Context, collegeID int, courseID *int) ([]RiskStudent, error) {
+	args := []any{collegeID}
+	var enrollmentJoin, gradeScope, attendanceScope string
+	if courseID != nil {
+		args = append(args, *courseID)
+		enrollmentJoin = "JOIN enrollments e ON e.student_id = s.id AND e.college_id = $1 AND e.course_id = $2"
+		gradeScope = " AND g.course_id = $2"
+		attendanceScope = " AND a.course_id = $2"
+	}
+
 	query := `
 		SELECT
 			s.id as student_id,
 			COALESCE(AVG(g.percentage), 0) as avg_grade,
-			COALESCE(AVG(CASE WHEN a.status = 'Present' THEN 100 ELSE 0 END), 0) as attendance_rate,
+			COALESCE(AVG(CASE WHEN ` + models.AttendancePresentSQL("a.status") + ` THEN 100 ELSE 0 END), 0) as attendance_rate,
 			COUNT(DISTINCT CASE WHEN g.created_at >= CURRENT_DATE - INTERVAL '30 days' THEN g.id END) as recent_grades,
 			COUNT(DISTINCT CASE WHEN a.date >= CURRENT_DATE - INTERVAL '30 days' THEN a.id END) as recent_attendance
 		FROM students s
-		LEFT JOIN grades g ON g.student_id = s.id AND g.college_id = $1
-		LEFT JOIN attendance a ON a.student_id = s.id AND a.college_id = $1
+		` + enrollmentJoin + `
+		LEFT JOIN grades g ON g.student_id = s.id AND g.college_id = $1` + gradeScope + `
+		LEFT JOIN attendance a ON a.student_id = s.id AND a.college_id = $1` + attendanceScope + `
 		WHERE s.college_id = $1
 		GROUP BY s.id
 		HAVING (
 			COALESCE(AVG(g.percentage), 0) < 60 OR
-			COALESCE(AVG(CASE WHEN a.status = 'Present' THEN 100 ELSE 0 END), 0) < 70 OR
+			COALESCE(AVG(CASE WHEN ` + models.AttendancePresentSQL("a.status") + ` THEN 100 ELSE 0 END), 0) < 70 OR
 			COUNT(DISTINCT CASE WHEN g.created_at >= CURRENT_DATE - INTERVAL '30 days' THEN g.id END) = 0
 		)`
 
-	rows, err := s.db.Pool.Query(ctx, query, collegeID)
+	rows, err := s.db.Pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	cfg := s.currentConfig()
 	riskStudents := make([]RiskStudent, 0)
 	for rows.Next() {
 		var studentID int
@@ -645,10 +761,10 @@ func (s *advancedAnalyticsService) identifyAtRiskStudents(ctx context.Context, c
 		}
 
 		riskLevel := "medium"
-		probability := s.calculateRiskProbability(avgGrade, attendanceRate, recentGrades, recentAttendance)
-		if probability >= s.analyticsConfig.RiskLevelHighThreshold {
+		probability := calculateRiskProbability(cfg, avgGrade, attendanceRate, recentGrades, recentAttendance)
+		if probability >= cfg.RiskLevelHighThreshold {
 			riskLevel = "high"
-		} else if probability < s.analyticsConfig.RiskLevelLowThreshold {
+		} else if probability < cfg.RiskLevelLowThreshold {
 			riskLevel = "low"
 		}
 
@@ -673,7 +789,14 @@ func (s *advancedAnalyticsService) identifyAtRiskStudents(ctx context.Context, c
 	return riskStudents, nil
 }
 
-func (s *advancedAnalyticsService) predictCourseCompletionRates(ctx context.Context, collegeID int) ([]CompletionRate, error) {
+func (s *advancedAnalyticsService) predictCourseCompletionRates(ctx context.Context, collegeID int, courseID *int) ([]CompletionRate, error) {
+	args := []any{collegeID}
+	var courseScope string
+	if courseID != nil {
+		args = append(args, *courseID)
+		courseScope = " AND c.id = $2"
+	}
+
 	query := `
 		SELECT
 			c.id as course_id,
@@ -684,10 +807,10 @@ func (s *advancedAnalyticsService) predictCourseCompletionRates(ctx context.Cont
 		FROM courses c
 		LEFT JOIN enrollments e ON e.course_id = c.id AND e.college_id = c.college_id
 		LEFT JOIN grades g ON g.course_id = c.id AND g.student_id = e.student_id AND g.college_id = c.college_id
-		WHERE c.college_id = $1
+		WHERE c.college_id = $1` + courseScope + `
 		GROUP BY c.id, c.name, c.created_at`
 
-	rows, err := s.db.Pool.Query(ctx, query, collegeID)
+	rows, err := s.db.Pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -742,8 +865,7 @@ func (s *advancedAnalyticsService) generatePredictiveRecommendations(atRiskStude
 	return recommendations
 }
 
-func (s *advancedAnalyticsService) calculateRiskProbability(avgGrade, attendanceRate float64, recentGrades, recentAttendance int) float64 {
-	cfg := s.analyticsConfig
+func calculateRiskProbability(cfg *config.AnalyticsConfig, avgGrade, attendanceRate float64, recentGrades, recentAttendance int) float64 {
 	score := 0.0
 
 	switch {
@@ -842,7 +964,7 @@ func (s *advancedAnalyticsService) identifyLearningPatterns(ctx context.Context,
 		LEFT JOIN grades g ON g.student_id = s.id AND g.college_id = s.college_id
 		WHERE s.college_id = $1
 		GROUP BY s.id
-		HAVING COALESCE(AVG(CASE WHEN a.status = 'Present' THEN 100 ELSE 0 END), 0) >= 80`
+		HAVING COALESCE(AVG(CASE WHEN ` + models.AttendancePresentSQL("a.status") + ` THEN 100 ELSE 0 END), 0) >= 80`
 
 	var studentCount int
 	var successRate float64
@@ -925,27 +1047,15 @@ func (s *advancedAnalyticsService) compareCourses(ctx context.Context, collegeID
 	comparison.CourseName1 = name1
 	comparison.CourseName2 = name2
 
-	// Compare various metrics
-	metrics := []string{"avg_grade", "attendance_rate", "completion_rate", "engagement_rate"}
-
-	for _, metric := range metrics {
-		var value1, value2 float64
-
-		switch metric {
-		case "avg_grade":
-			if err := s.db.Pool.QueryRow(ctx, "SELECT COALESCE(AVG(percentage),0) FROM grades WHERE course_id = $1 AND college_id = $2", courseID1, collegeID).Scan(&value1); err != nil {
-				return nil, err
-			}
-			if err := s.db.Pool.QueryRow(ctx, "SELECT COALESCE(AVG(percentage),0) FROM grades WHERE course_id = $1 AND college_id = $2", courseID2, collegeID).Scan(&value2); err != nil {
-				return nil, err
-			}
-		case "attendance_rate":
-			if err := s.db.Pool.QueryRow(ctx, "SELECT COALESCE(AVG(CASE WHEN status = 'Present' THEN 100 ELSE 0 END),0) FROM attendance WHERE course_id = $1 AND college_id = $2", courseID1, collegeID).Scan(&value1); err != nil {
-				return nil, err
-			}
-			if err := s.db.Pool.QueryRow(ctx, "SELECT COALESCE(AVG(CASE WHEN status = 'Present' THEN 100 ELSE 0 END),0) FROM attendance WHERE course_id = $1 AND college_id = $2", courseID2, collegeID).Scan(&value2); err != nil {
-				return nil, err
-			}
+	// Compare various metrics, all expressed as percentages
+	for _, metric := range comparisonMetrics {
+		value1, err := s.courseComparisonMetric(ctx, collegeID, courseID1, metric)
+		if err != nil {
+			return nil, err
+		}
+		value2, err := s.courseComparisonMetric(ctx, collegeID, courseID2, metric)
+		if err != nil {
+			return nil, err
 		}
 
 		comparison.Metrics[metric+"_1"] = value1
@@ -964,6 +1074,54 @@ func (s *advancedAnalyticsService) compareCourses(ctx context.Context, collegeID
 	return comparison, nil
 }
 
+// comparisonMetrics are the per-course percentages compareCourses reports
+var comparisonMetrics = []string{"avg_grade", "attendance_rate", "completion_rate", "engagement_rate"}
+
+// courseComparisonMetric computes one comparison metric for a course:
+//   - avg_grade: mean grade percentage
+//   - attendance_rate: share of attendance records marked present
+//   - completion_rate: share of expected assignment submissions (every
+//     assignment times every enrolled student) that were actually made
+//   - engagement_rate: share of enrolled students active in the last
+//     activeStudentWindowDays days, as in GetCourseEngagement
+func (s *advancedAnalyticsService) courseComparisonMetric(ctx context.Context, collegeID, courseID int, metric string) (float64, error) {
+	var value float64
+	var err error
+
+	switch metric {
+	case "avg_grade":
+		err = s.db.Pool.QueryRow(ctx, "SELECT COALESCE(AVG(percentage),0) FROM grades WHERE college_id = $1 AND course_id = $2", collegeID, courseID).Scan(&value)
+	case "attendance_rate":
+		err = s.db.Pool.QueryRow(ctx, "SELECT COALESCE(AVG(CASE WHEN "+models.AttendancePresentSQL("status")+" THEN 100 ELSE 0 END),0) FROM attendance WHERE college_id = $1 AND course_id = $2", collegeID, courseID).Scan(&value)
+	case "completion_rate":
+		query := `
+			SELECT COALESCE(
+				COUNT(DISTINCT (s.assignment_id, s.student_id))::float * 100 / NULLIF(
+					(SELECT COUNT(*) FROM assignments WHERE college_id = $1 AND course_id = $2) *
+					(SELECT COUNT(*) FROM enrollments WHERE college_id = $1 AND course_id = $2), 0),
+				0)
+			FROM assignment_submissions s
+			JOIN assignments a ON a.id = s.assignment_id
+			JOIN enrollments e ON e.student_id = s.student_id AND e.course_id = a.course_id AND e.college_id = a.college_id
+			WHERE a.college_id = $1 AND a.course_id = $2`
+		err = s.db.Pool.QueryRow(ctx, query, collegeID, courseID).Scan(&value)
+	case "engagement_rate":
+		total, totalErr := s.getTotalStudents(ctx, collegeID, courseID)
+		if totalErr != nil || total == 0 {
+			return 0, totalErr
+		}
+		active, activeErr := s.getActiveStudents(ctx, collegeID, courseID, activeStudentWindowDays)
+		if activeErr != nil {
+			return 0, activeErr
+		}
+		value = float64(active) / float64(total) * 100
+	default:
+		return 0, fmt.Errorf("unknown comparison metric %q", metric)
+	}
+
+	return value, err
+}
+
 func (s *advancedAnalyticsService) generateComparativeInsights(comparisons []CourseComparison) []string {
 	insights := make([]string, 0)
 
@@ -1005,6 +1163,28 @@ func abs(x float64) float64 {
 	return x
 }
 
+// performanceTrendSeries reads (period, value) rows into a chronological
+// series. The queries fetch the latest periods newest first, so the points are
+// sorted oldest first before each ChangeRate is taken against the period before it.
+func performanceTrendSeries(rows pgx.Rows, metric string) []PerformanceTrend {
+	series := make([]PerformanceTrend, 0)
+	for rows.Next() {
+		point := PerformanceTrend{Metric: metric}
+		if err := rows.Scan(&point.Date, &point.Value); err != nil {
+			continue
+		}
+		series = append(series, point)
+	}
+
+	slices.SortFunc(series, func(a, b PerformanceTrend) int { return a.Date.Compare(b.Date) })
+	for i := 1; i < len(series); i++ {
+		if prev := series[i-1].Value; prev > 0 {
+			series[i].ChangeRate = (series[i].Value - prev) / prev * 100
+		}
+	}
+	return series
+}
+
 func (s *advancedAnalyticsService) getStudentPerformanceTrends(ctx context.Context, collegeID, studentID int) ([]PerformanceTrend, error) {
 	trends := make([]PerformanceTrend, 0)
 
@@ -1025,36 +1205,13 @@ func (s *advancedAnalyticsService) getStudentPerformanceTrends(ctx context.Conte
 	}
 	defer rows.Close()
 
-	var prevValue float64
-	first := true
-	for rows.Next() {
-		var date time.Time
-		var value float64
-		if err := rows.Scan(&date, &value); err != nil {
-			continue
-		}
-
-		changeRate := 0.0
-		if !first {
-			changeRate = ((value - prevValue) / prevValue) * 100
-		}
-
-		trends = append(trends, PerformanceTrend{
-			Date:       date,
-			Metric:     "average_grade",
-			Value:      value,
-			ChangeRate: changeRate,
-		})
-
-		prevValue = value
-		first = false
-	}
+	trends = append(trends, performanceTrendSeries(rows, "average_grade")...)
 
 	// Attendance trends
 	attendanceQuery := `
 		SELECT
 			DATE_TRUNC('week', date) as week,
-			COALESCE(AVG(CASE WHEN status = 'Present' THEN 100 ELSE 0 END), 0) as attendance_rate
+			COALESCE(AVG(CASE WHEN ` + models.AttendancePresentSQL("status") + ` THEN 100 ELSE 0 END), 0) as attendance_rate
 		FROM attendance
 		WHERE college_id = $1 AND student_id = $2
 		GROUP BY DATE_TRUNC('week', date)
@@ -1067,30 +1224,7 @@ func (s *advancedAnalyticsService) getStudentPerformanceTrends(ctx context.Conte
 	}
 	defer rows.Close()
 
-	prevValue = 0
-	first = true
-	for rows.Next() {
-		var date time.Time
-		var value float64
-		if err := rows.Scan(&date, &value); err != nil {
-			continue
-		}
-
-		changeRate := 0.0
-		if !first && prevValue > 0 {
-			changeRate = ((value - prevValue) / prevValue) * 100
-		}
-
-		trends = append(trends, PerformanceTrend{
-			Date:       date,
-			Metric:     "attendance_rate",
-			Value:      value,
-			ChangeRate: changeRate,
-		})
-
-		prevValue = value
-		first = false
-	}
+	trends = append(trends, performanceTrendSeries(rows, "attendance_rate")...)
 
 	return trends, nil
 }
@@ -1115,30 +1249,7 @@ func (s *advancedAnalyticsService) getCoursePerformanceTrends(ctx context.Contex
 	}
 	defer rows.Close()
 
-	var prevValue float64
-	first := true
-	for rows.Next() {
-		var date time.Time
-		var value float64
-		if err := rows.Scan(&date, &value); err != nil {
-			continue
-		}
-
-		changeRate := 0.0
-		if !first && prevValue > 0 {
-			changeRate = ((value - prevValue) / prevValue) * 100
-		}
-
-		trends = append(trends, PerformanceTrend{
-			Date:       date,
-			Metric:     "course_average_grade",
-			Value:      value,
-			ChangeRate: changeRate,
-		})
-
-		prevValue = value
-		first = false
-	}
+	trends = append(trends, performanceTrendSeries(rows, "course_average_grade")...)
 
 	// Enrollment trends
 	enrollmentQuery := `
@@ -1157,45 +1268,26 @@ func (s *advancedAnalyticsService) getCoursePerformanceTrends(ctx context.Contex
 	}
 	defer rows.Close()
 
-	prevValue = 0
-	first = true
-	for rows.Next() {
-		var date time.Time
-		var value float64
-		if err := rows.Scan(&date, &value); err != nil {
-			continue
-		}
-
-		changeRate := 0.0
-		if !first && prevValue > 0 {
-			changeRate = ((value - prevValue) / prevValue) * 100
-		}
-
-		trends = append(trends, PerformanceTrend{
-			Date:       date,
-			Metric:     "enrollment_count",
-			Value:      value,
-			ChangeRate: changeRate,
-		})
-
-		prevValue = value
-		first = false
-	}
+	trends = append(trends, performanceTrendSeries(rows, "enrollment_count")...)
 
 	return trends, nil
 }
 
-// getSkillDevelopment calculates skill development over time based on grades and performance
+// getSkillDevelopment calculates skill development over time based on grades and performance.
+// Courses mapped in course_skill_mappings roll up into their named skill;
+// unmapped courses are reported under the course name.
 func (s *advancedAnalyticsService) getSkillDevelopment(ctx context.Context, collegeID, studentID int) ([]SkillPoint, error) {
+	cfg := s.currentConfig()
 	query := `
 		SELECT 
-			c.name as skill_area,
+			COALESCE(m.skill_name, c.name) as skill_area,
 			DATE_TRUNC('month', g.created_at) as month,
 			AVG(g.percentage) as avg_score
 		FROM grades g
 		JOIN courses c ON c.id = g.course_id AND c.college_id = g.college_id
+		LEFT JOIN course_skill_mappings m ON m.course_id = c.id AND m.college_id = g.college_id
 		WHERE g.college_id = $1 AND g.student_id = $2
-		GROUP BY c.name, DATE_TRUNC('month', g.created_at)
+		GROUP BY COALESCE(m.skill_name, c.name), DATE_TRUNC('month', g.created_at)
 		ORDER BY month DESC
 		LIMIT 12`
 
@@ -1217,7 +1309,7 @@ func (s *advancedAnalyticsService) getSkillDevelopment(ctx context.Context, coll
 
 		skillPoints = append(skillPoints, SkillPoint{
 			Skill: skillArea,
-			Level: avgScore / 25, // Convert to 0-4 scale
+			Level: skillLevel(cfg, avgScore),
 			Date:  month,
 		})
 	}
@@ -1225,6 +1317,11 @@ func (s *advancedAnalyticsService) getSkillDevelopment(ctx context.Context, coll
 	return skillPoints, nil
 }
 
+// skillLevel maps an average percentage linearly onto 0..SkillLevelScale
+func skillLevel(cfg *config.AnalyticsConfig, avgScore float64) float64 {
+	return avgScore / 100 * cfg.SkillLevelScale
+}
+
 // getEngagementTimeline retrieves engagement data over time for a course
 func (s *advancedAnalyticsService) getEngagementTimeline(ctx context.Context, collegeID, courseID int) ([]EngagementPoint, error) {
 	query := `
@@ -0,0 +1,270 @@
+package analytics
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/config"
+	"eduhub/server/internal/repository"
+
+	"github.com/pashagolub/pgxmock/v4"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadConfigChangesRiskScores(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "analytics.env")
+	require.NoError(t, os.WriteFile(path, []byte("ANALYTICS_RISK_GRADE_LOW_WEIGHT=0.30\n"), 0o600))
+	t.Setenv("ANALYTICS_CONFIG_FILE", path)
+
+	svc := NewAdvancedAnalyticsService(nil, nil).(*advancedAnalyticsService)
+	before := calculateRiskProbability(svc.currentConfig(), 55, 90, 3, 5)
+	assert.Equal(t, 0.30, before)
+
+	require.NoError(t, os.WriteFile(path, []byte("# tuned\nANALYTICS_RISK_GRADE_LOW_WEIGHT=0.60\n"), 0o600))
+	cfg, err := svc.ReloadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 0.60, cfg.RiskWeightGradeLow)
+
+	after := calculateRiskProbability(svc.currentConfig(), 55, 90, 3, 5)
+	assert.Equal(t, 0.60, after)
+}
+
+func TestReloadConfigKeepsOldConfigOnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "analytics.env")
+	require.NoError(t, os.WriteFile(path, []byte("ANALYTICS_RISK_MIN_SCORE=0.05\n"), 0o600))
+	t.Setenv("ANALYTICS_CONFIG_FILE", path)
+
+	svc := NewAdvancedAnalyticsService(nil, nil).(*advancedAnalyticsService)
+	original := svc.currentConfig()
+
+	// A line without '=' fails to parse
+	require.NoError(t, os.WriteFile(path, []byte("ANALYTICS_RISK_MIN_SCORE 0.9\n"), 0o600))
+	_, err := svc.ReloadConfig()
+	assert.Error(t, err)
+	assert.Same(t, original, svc.currentConfig())
+}
+
+func TestCalculateRiskProbabilityClampsToConfig(t *testing.T) {
+	cfg := config.LoadAnalyticsConfig()
+	assert.Equal(t, cfg.RiskMinScore, calculateRiskProbability(cfg, 95, 100, 5, 10))
+}
+
+func TestReloadConfigRejectsInvalidWeights(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "analytics.env")
+	require.NoError(t, os.WriteFile(path, []byte("ANALYTICS_RISK_MIN_SCORE=0.05\n"), 0o600))
+	t.Setenv("ANALYTICS_CONFIG_FILE", path)
+
+	svc := NewAdvancedAnalyticsService(nil, nil).(*advancedAnalyticsService)
+	original := svc.currentConfig()
+
+	// Min above max fails validation
+	require.NoError(t, os.WriteFile(path, []byte("ANALYTICS_RISK_MIN_SCORE=0.9\nANALYTICS_RISK_MAX_SCORE=0.5\n"), 0o600))
+	_, err := svc.ReloadConfig()
+	assert.Error(t, err)
+	assert.Same(t, original, svc.currentConfig())
+}
+
+func TestAnalyzeOverallTrendImproving(t *testing.T) {
+	svc := &advancedAnalyticsService{}
+	month := func(m time.Month) time.Time { return time.Date(2026, m, 1, 0, 0, 0, 0, time.UTC) }
+
+	// Newest first, as the grade query returns them
+	grades := []GradeProgressPoint{
+		{Date: month(6), AverageGPA: 3.8},
+		{Date: month(5), AverageGPA: 3.5},
+		{Date: month(4), AverageGPA: 3.1},
+		{Date: month(3), AverageGPA: 2.6},
+		{Date: month(2), AverageGPA: 2.2},
+		{Date: month(1), AverageGPA: 2.0},
+	}
+	assert.Equal(t, "improving", svc.analyzeOverallTrend(grades, nil))
+	assert.Equal(t, month(6), grades[0].Date, "caller's slice must not be reordered")
+
+	slices.Reverse(grades)
+	assert.Equal(t, "improving", svc.analyzeOverallTrend(grades, nil))
+}
+
+func TestStudentPerformanceTrendsAreChronological(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	svc := &advancedAnalyticsService{db: &repository.DB{Pool: mock}}
+	week := func(d int) time.Time { return time.Date(2026, 9, d, 0, 0, 0, 0, time.UTC) }
+
+	mock.ExpectQuery(`FROM grades`).
+		WithArgs(1, 7).
+		WillReturnRows(pgxmock.NewRows([]string{"week", "avg_grade"}).
+			AddRow(week(21), 80.0).
+			AddRow(week(14), 60.0))
+	mock.ExpectQuery(`FROM attendance`).
+		WithArgs(1, 7).
+		WillReturnRows(pgxmock.NewRows([]string{"week", "attendance_rate"}))
+
+	trends, err := svc.getStudentPerformanceTrends(context.Background(), 1, 7)
+
+	require.NoError(t, err)
+	require.Len(t, trends, 2)
+	assert.Equal(t, week(14), trends[0].Date)
+	assert.Zero(t, trends[0].ChangeRate)
+	assert.InDelta(t, 33.333, trends[1].ChangeRate, 0.001)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCompareCoursesFlagsCompletionGap(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	svc := &advancedAnalyticsService{db: &repository.DB{Pool: mock}}
+	value := func(v float64) *pgxmock.Rows { return pgxmock.NewRows([]string{"value"}).AddRow(v) }
+	count := func(n int) *pgxmock.Rows { return pgxmock.NewRows([]string{"count"}).AddRow(n) }
+
+	mock.ExpectQuery(`SELECT name FROM courses`).WithArgs(11, 1).
+		WillReturnRows(pgxmock.NewRows([]string{"name"}).AddRow("Algebra"))
+	mock.ExpectQuery(`SELECT name FROM courses`).WithArgs(12, 1).
+		WillReturnRows(pgxmock.NewRows([]string{"name"}).AddRow("Biology"))
+	for _, courseID := range []int{11, 12} {
+		mock.ExpectQuery(`FROM grades`).WithArgs(1, courseID).WillReturnRows(value(72))
+	}
+	for _, courseID := range []int{11, 12} {
+		mock.ExpectQuery(`FROM attendance`).WithArgs(1, courseID).WillReturnRows(value(88))
+	}
+	mock.ExpectQuery(`FROM assignment_submissions s`).WithArgs(1, 11).WillReturnRows(value(95))
+	mock.ExpectQuery(`FROM assignment_submissions s`).WithArgs(1, 12).WillReturnRows(value(40))
+	for _, courseID := range []int{11, 12} {
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM enrollments`).WithArgs(1, courseID).WillReturnRows(count(20))
+		mock.ExpectQuery(`active_students`).WithArgs(1, courseID).WillReturnRows(count(15))
+	}
+
+	comparison, err := svc.compareCourses(context.Background(), 1, 11, 12)
+
+	require.NoError(t, err)
+	assert.Equal(t, 95.0, comparison.Metrics["completion_rate_1"])
+	assert.Equal(t, 40.0, comparison.Metrics["completion_rate_2"])
+	assert.Equal(t, 75.0, comparison.Metrics["engagement_rate_1"])
+	require.Len(t, comparison.SignificantDiff, 1)
+	assert.Contains(t, comparison.SignificantDiff[0], "Algebra performs better in completion_rate")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExplainDropoutRisk(t *testing.T) {
+	cfg := config.LoadAnalyticsConfig()
+	value := func(v float64) *float64 { return &v }
+	days := func(d int) *int { return &d }
+
+	t.Run("names every trigger with its value", func(t *testing.T) {
+		student := DropoutRiskStudent{StudentID: 4, AttendanceRate: value(45), AverageGrade: value(38.5), DaysSinceActivity: days(30)}
+		require.True(t, explainDropoutRisk(&student, cfg))
+		assert.Equal(t, []string{"Poor attendance", "Low grades", "No recent activity"}, student.RiskFactors)
+	})
+
+	t.Run("engaged student is not flagged", func(t *testing.T) {
+		student := DropoutRiskStudent{StudentID: 5, AttendanceRate: value(92), AverageGrade: value(71), DaysSinceActivity: days(7)}
+		assert.False(t, explainDropoutRisk(&student, cfg))
+		assert.Empty(t, student.RiskFactors)
+	})
+
+	t.Run("student who never attended is inactive", func(t *testing.T) {
+		student := DropoutRiskStudent{StudentID: 6, AverageGrade: value(80)}
+		require.True(t, explainDropoutRisk(&student, cfg))
+		assert.Equal(t, []string{"No recent activity"}, student.RiskFactors)
+	})
+}
+
+func TestDropoutRiskThresholdsAreConfigurable(t *testing.T) {
+	students := func() []DropoutRiskStudent {
+		value := func(v float64) *float64 { return &v }
+		recent := 2
+		return []DropoutRiskStudent{
+			{StudentID: 1, AttendanceRate: value(95), AverageGrade: value(45), DaysSinceActivity: &recent},
+			{StudentID: 2, AttendanceRate: value(95), AverageGrade: value(58), DaysSinceActivity: &recent},
+			{StudentID: 3, AttendanceRate: value(95), AverageGrade: value(72), DaysSinceActivity: &recent},
+		}
+	}
+	flagged := func(cfg *config.AnalyticsConfig) []int {
+		ids := []int{}
+		for _, student := range students() {
+			if explainDropoutRisk(&student, cfg) {
+				ids = append(ids, student.StudentID)
+			}
+		}
+		return ids
+	}
+
+	cfg := config.LoadAnalyticsConfig()
+	assert.Equal(t, []int{1}, flagged(cfg))
+
+	raised := *cfg
+	raised.GradeRiskThreshold = 60
+	require.NoError(t, raised.Validate())
+	assert.Equal(t, []int{1, 2}, flagged(&raised))
+
+	t.Run("thresholds are bound into the query", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		svc := &advancedAnalyticsService{db: &repository.DB{Pool: mock}, analyticsConfig: &raised}
+
+		mock.ExpectQuery(`FROM enrollments e`).
+			WithArgs(1, 4, 60.0, 60.0, 14).
+			WillReturnRows(pgxmock.NewRows([]string{"student_id", "attendance_rate", "average_grade", "days_since_activity"}).
+				AddRow(2, ptr(95.0), ptr(58.0), ptr(2)))
+
+		risk, err := svc.getDropoutRiskStudents(context.Background(), 1, 4)
+		require.NoError(t, err)
+		require.Len(t, risk, 1)
+		assert.Equal(t, []string{"Low grades"}, risk[0].RiskFactors)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestIdentifyAtRiskStudentsScopedToCourse(t *testing.T) {
+	columns := []string{"student_id", "avg_grade", "attendance_rate", "recent_grades", "recent_attendance"}
+
+	t.Run("college wide", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		svc := &advancedAnalyticsService{db: &repository.DB{Pool: mock}, analyticsConfig: config.LoadAnalyticsConfig()}
+
+		mock.ExpectQuery(`FROM students s\s+LEFT JOIN grades g ON g.student_id = s.id AND g.college_id = \$1\s+LEFT JOIN attendance a ON a.student_id = s.id AND a.college_id = \$1\s+WHERE`).
+			WithArgs(1).
+			WillReturnRows(pgxmock.NewRows(columns).AddRow(5, 45.0, 90.0, 1, 4))
+
+		risk, err := svc.identifyAtRiskStudents(context.Background(), 1, nil)
+		require.NoError(t, err)
+		require.Len(t, risk, 1)
+		assert.Equal(t, []string{"Low grades"}, risk[0].RiskFactors)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("one course", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		svc := &advancedAnalyticsService{db: &repository.DB{Pool: mock}, analyticsConfig: config.LoadAnalyticsConfig()}
+
+		mock.ExpectQuery(`FROM students s\s+JOIN enrollments e ON e.student_id = s.id AND e.college_id = \$1 AND e.course_id = \$2\s+`+
+			`LEFT JOIN grades g ON g.student_id = s.id AND g.college_id = \$1 AND g.course_id = \$2\s+`+
+			`LEFT JOIN attendance a ON a.student_id = s.id AND a.college_id = \$1 AND a.course_id = \$2\s+WHERE`).
+			WithArgs(1, 4).
+			WillReturnRows(pgxmock.NewRows(columns).AddRow(5, 80.0, 50.0, 1, 4))
+
+		risk, err := svc.identifyAtRiskStudents(context.Background(), 1, ptr(4))
+		require.NoError(t, err)
+		require.Len(t, risk, 1)
+		assert.Equal(t, []string{"Poor attendance"}, risk[0].RiskFactors)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
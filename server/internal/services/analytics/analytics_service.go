@@ -4,9 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sync"
 	"time"
 
+	"eduhub/server/internal/config"
+	"eduhub/server/internal/models"
 	"eduhub/server/internal/repository"
+
+	"github.com/jackc/pgx/v5"
 )
 
 type StudentPerformanceMetrics struct {
@@ -18,6 +23,20 @@ type StudentPerformanceMetrics struct {
 	QuizzesCompleted     int            `json:"quizzes_completed"`
 	AverageQuizScore     float64        `json:"average_quiz_score"`
 	CourseMetrics        []CourseMetric `json:"course_metrics,omitempty"`
+	// ExamMetrics is only filled when exams are requested. Exam results live
+	// in exam_results, apart from the grades table, and faculty may also
+	// record an exam's marks as an "exam" grade, so they are reported beside
+	// OverallGPA rather than folded into it.
+	ExamMetrics *ExamPerformance `json:"exam_metrics,omitempty"`
+}
+
+// ExamPerformance summarizes a student's published exam results. Absent
+// counts as not passed; only results with marks feed the average.
+type ExamPerformance struct {
+	ExamsTaken            int     `json:"exams_taken"`
+	ExamsPassed           int     `json:"exams_passed"`
+	PassRate              float64 `json:"pass_rate"`
+	AverageExamPercentage float64 `json:"average_exam_percentage"`
 }
 
 type CourseMetric struct {
@@ -29,6 +48,7 @@ type CourseMetric struct {
 
 type CourseAnalytics struct {
 	CourseID             int     `json:"course_id"`
+	SectionID            *int    `json:"section_id,omitempty"`
 	TotalStudents        int     `json:"total_students"`
 	AverageAttendance    float64 `json:"average_attendance"`
 	AverageGrade         float64 `json:"average_grade"`
@@ -46,6 +66,9 @@ type CollegeDashboard struct {
 	OverallGPA          float64 `json:"overall_gpa"`
 	ActiveAnnouncements int     `json:"active_announcements"`
 	UpcomingEvents      int     `json:"upcoming_events"`
+	// PendingGrading is the college's grading backlog: ungraded assignment
+	// submissions, submitted quiz attempts and exam results without marks
+	PendingGrading int `json:"pending_grading"`
 }
 
 type AttendanceTrend struct {
@@ -61,11 +84,19 @@ type GradeDistribution struct {
 }
 
 type AnalyticsService interface {
-	GetStudentPerformance(ctx context.Context, collegeID, studentID int, courseID *int) (*StudentPerformanceMetrics, error)
-	GetCourseAnalytics(ctx context.Context, collegeID, courseID int) (*CourseAnalytics, error)
+	GetStudentPerformance(ctx context.Context, collegeID, studentID int, courseID *int, includeExams bool) (*StudentPerformanceMetrics, error)
+	GetCourseAnalytics(ctx context.Context, collegeID, courseID int, sectionID *int) (*CourseAnalytics, error)
+	GetCourseAnalyticsHistory(ctx context.Context, collegeID, courseID int) ([]CourseAnalyticsPeriod, error)
 	GetCollegeDashboard(ctx context.Context, collegeID int) (*CollegeDashboard, error)
+	CourseAnalyticsVersion(ctx context.Context, collegeID, courseID int, sectionID *int) (*DataVersion, error)
+	CollegeDashboardVersion(ctx context.Context, collegeID int) (*DataVersion, error)
 	GetAttendanceTrends(ctx context.Context, collegeID int, courseID *int) ([]AttendanceTrend, error)
+	GetStudentAttendanceTrends(ctx context.Context, collegeID, studentID int, courseID *int) ([]AttendanceTrend, error)
 	GetGradeDistribution(ctx context.Context, collegeID, courseID int) ([]GradeDistribution, error)
+	CaptureDashboardSnapshot(ctx context.Context, collegeID int) (*DashboardSnapshot, error)
+	CaptureAllDashboardSnapshots(ctx context.Context) (int, error)
+	GetDashboardTrend(ctx context.Context, collegeID, limit int) ([]DashboardSnapshot, error)
+	GetFacultyWorkload(ctx context.Context, collegeID, facultyID int) (*FacultyWorkload, error)
 }
 
 type analyticsService struct {
@@ -75,6 +106,13 @@ type analyticsService struct {
 	courseRepo     repository.CourseRepository
 	assignmentRepo repository.AssignmentRepository
 	db             *repository.DB
+
+	// snapshotRetentionDays is how long dashboard snapshots are kept; 0 keeps them forever
+	snapshotRetentionDays int
+	// riskConfig supplies the thresholds that flag students at risk; it is
+	// swapped when the advanced analytics config is reloaded
+	riskConfigMu sync.RWMutex
+	riskConfig   *config.AnalyticsConfig
 }
 
 func NewAnalyticsService(
@@ -84,18 +122,24 @@ func NewAnalyticsService(
 	courseRepo repository.CourseRepository,
 	assignmentRepo repository.AssignmentRepository,
 	db *repository.DB,
+	snapshotRetentionDays int,
 ) AnalyticsService {
 	return &analyticsService{
-		studentRepo:    studentRepo,
-		attendanceRepo: attendanceRepo,
-		gradeRepo:      gradeRepo,
-		courseRepo:     courseRepo,
-		assignmentRepo: assignmentRepo,
-		db:             db,
+		studentRepo:           studentRepo,
+		attendanceRepo:        attendanceRepo,
+		gradeRepo:             gradeRepo,
+		courseRepo:            courseRepo,
+		assignmentRepo:        assignmentRepo,
+		db:                    db,
+		snapshotRetentionDays: snapshotRetentionDays,
+		riskConfig:            readAnalyticsConfig(),
 	}
 }
 
-func (s *analyticsService) GetStudentPerformance(ctx context.Context, collegeID, studentID int, courseID *int) (*StudentPerformanceMetrics, error) {
+// GetStudentPerformance aggregates a student's grades, attendance,
+// assignments and quizzes. With includeExams it also summarizes their
+// published exam results in ExamMetrics.
+func (s *analyticsService) GetStudentPerformance(ctx context.Context, collegeID, studentID int, courseID *int, includeExams bool) (*StudentPerformanceMetrics, error) {
 	metrics := &StudentPerformanceMetrics{StudentID: studentID}
 
 	// Combine all sequential queries into a single optimized query
@@ -111,6 +155,14 @@ func (s *analyticsService) GetStudentPerformance(ctx context.Context, collegeID,
 	metrics.QuizzesCompleted = quizzesCompleted
 	metrics.AverageQuizScore = averageQuizScore
 
+	if includeExams {
+		examMetrics, err := s.studentExamPerformance(ctx, collegeID, studentID, courseID)
+		if err != nil {
+			return nil, err
+		}
+		metrics.ExamMetrics = examMetrics
+	}
+
 	// Populate course metrics only when courseID filter is not provided
 	if courseID == nil {
 		courseMetrics, err := s.studentCourseMetrics(ctx, collegeID, studentID)
@@ -156,7 +208,7 @@ func (s *analyticsService) getAllPerformanceMetrics(ctx context.Context, college
 			),
 			attendance_stats AS (
 				SELECT 
-					COALESCE(SUM(CASE WHEN a.status = 'Present' THEN 1 ELSE 0 END), 0) as present,
+					COALESCE(SUM(CASE WHEN `+models.AttendancePresentSQL("a.status")+` THEN 1 ELSE 0 END), 0) as present,
 					COUNT(*) as total
 				FROM attendance a
 				WHERE a.college_id = $1 AND a.student_id = $2%[2]s
@@ -214,46 +266,48 @@ func (s *analyticsService) getAllPerformanceMetrics(ctx context.Context, college
 	return avgGradeVal, attendanceRateVal, submitted, totalAssignments, quizzesCompleted, avgQuizScoreVal, nil
 }
 
-func (s *analyticsService) GetCourseAnalytics(ctx context.Context, collegeID, courseID int) (*CourseAnalytics, error) {
-	analytics := &CourseAnalytics{CourseID: courseID}
+// GetCourseAnalytics aggregates the whole course, or only the students of
+// one section when sectionID is set
+func (s *analyticsService) GetCourseAnalytics(ctx context.Context, collegeID, courseID int, sectionID *int) (*CourseAnalytics, error) {
+	analytics := &CourseAnalytics{CourseID: courseID, SectionID: sectionID}
 
-	totalStudents, err := s.countEnrollments(ctx, collegeID, courseID)
+	totalStudents, err := s.countEnrollments(ctx, collegeID, courseID, sectionID, allTime)
 	if err != nil {
 		return nil, err
 	}
 	analytics.TotalStudents = totalStudents
 
-	avgAttendance, err := s.courseAttendanceRate(ctx, collegeID, courseID)
+	avgAttendance, err := s.courseAttendanceRate(ctx, collegeID, courseID, sectionID, allTime)
 	if err != nil {
 		return nil, err
 	}
 	analytics.AverageAttendance = avgAttendance
 
-	avgGrade, err := s.courseAverageGrade(ctx, collegeID, courseID)
+	avgGrade, err := s.courseAverageGrade(ctx, collegeID, courseID, sectionID, allTime)
 	if err != nil {
 		return nil, err
 	}
 	analytics.AverageGrade = PercentageToGPA(avgGrade)
 
-	assignmentSubmissionRate, err := s.courseAssignmentSubmissionRate(ctx, collegeID, courseID, totalStudents)
+	assignmentSubmissionRate, err := s.courseAssignmentSubmissionRate(ctx, collegeID, courseID, sectionID, totalStudents, allTime)
 	if err != nil {
 		return nil, err
 	}
 	analytics.AssignmentSubmission = assignmentSubmissionRate
 
-	quizParticipation, err := s.courseQuizParticipation(ctx, collegeID, courseID, totalStudents)
+	quizParticipation, err := s.courseQuizParticipation(ctx, collegeID, courseID, sectionID, totalStudents, allTime)
 	if err != nil {
 		return nil, err
 	}
 	analytics.QuizParticipation = quizParticipation
 
-	topPerformers, err := s.topPerformers(ctx, collegeID, courseID, 5)
+	topPerformers, err := s.topPerformers(ctx, collegeID, courseID, sectionID, 5)
 	if err != nil {
 		return nil, err
 	}
 	analytics.TopPerformers = topPerformers
 
-	studentsAtRisk, err := s.studentsAtRisk(ctx, collegeID, courseID)
+	studentsAtRisk, err := s.studentsAtRisk(ctx, collegeID, courseID, sectionID)
 	if err != nil {
 		return nil, err
 	}
@@ -297,12 +351,18 @@ func (s *analyticsService) GetCollegeDashboard(ctx context.Context, collegeID in
 		return nil, fmt.Errorf("GetCollegeDashboard: failed to count events: %w", err)
 	}
 
+	pendingGrading, err := s.collegePendingGrading(ctx, collegeID)
+	if err != nil {
+		return nil, err
+	}
+	dashboard.PendingGrading = pendingGrading
+
 	return dashboard, nil
 }
 
 func (s *analyticsService) GetAttendanceTrends(ctx context.Context, collegeID int, courseID *int) ([]AttendanceTrend, error) {
 	query := `SELECT date, 
-        COALESCE(SUM(CASE WHEN status = 'Present' THEN 1 ELSE 0 END),0) AS present,
+        COALESCE(SUM(CASE WHEN ` + models.AttendancePresentSQL("status") + ` THEN 1 ELSE 0 END),0) AS present,
         COUNT(*) AS expected
         FROM attendance
         WHERE college_id = $1 AND date >= (CURRENT_DATE - INTERVAL '14 day')`
@@ -321,12 +381,52 @@ func (s *analyticsService) GetAttendanceTrends(ctx context.Context, collegeID in
 	}
 	defer rows.Close()
 
+	trends, err := scanAttendanceTrends(rows)
+	if err != nil {
+		return nil, fmt.Errorf("GetAttendanceTrends: %w", err)
+	}
+	return trends, nil
+}
+
+// GetStudentAttendanceTrends returns one student's daily attendance over the
+// same window as GetAttendanceTrends. Every day of the window is included;
+// days without classes have nothing expected and a zero rate.
+func (s *analyticsService) GetStudentAttendanceTrends(ctx context.Context, collegeID, studentID int, courseID *int) ([]AttendanceTrend, error) {
+	courseFilter := ""
+	args := []any{collegeID, studentID}
+	if courseID != nil {
+		courseFilter = " AND a.course_id = $3"
+		args = append(args, *courseID)
+	}
+
+	query := `SELECT d.day::date AS date,
+        COALESCE(SUM(CASE WHEN ` + models.AttendancePresentSQL("a.status") + ` THEN 1 ELSE 0 END),0) AS present,
+        COUNT(a.id) AS expected
+        FROM generate_series(CURRENT_DATE - INTERVAL '14 day', CURRENT_DATE, INTERVAL '1 day') AS d(day)
+        LEFT JOIN attendance a ON a.date = d.day::date AND a.college_id = $1 AND a.student_id = $2` + courseFilter + `
+        GROUP BY d.day ORDER BY d.day`
+
+	rows, err := s.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("GetStudentAttendanceTrends: failed to query attendance: %w", err)
+	}
+	defer rows.Close()
+
+	trends, err := scanAttendanceTrends(rows)
+	if err != nil {
+		return nil, fmt.Errorf("GetStudentAttendanceTrends: %w", err)
+	}
+	return trends, nil
+}
+
+// scanAttendanceTrends reads (date, present, expected) rows into trends
+func scanAttendanceTrends(rows pgx.Rows) ([]AttendanceTrend, error) {
 	trends := make([]AttendanceTrend, 0)
 	for rows.Next() {
 		var trend AttendanceTrend
 		var present, expected int
 		if err := rows.Scan(&trend.Date, &present, &expected); err != nil {
-			return nil, fmt.Errorf("GetAttendanceTrends: failed to scan row: %w", err)
+			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 
 		trend.TotalPresent = present
@@ -337,7 +437,9 @@ func (s *analyticsService) GetAttendanceTrends(ctx context.Context, collegeID in
 
 		trends = append(trends, trend)
 	}
-
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %w", err)
+	}
 	return trends, nil
 }
 
@@ -397,7 +499,7 @@ func (s *analyticsService) averageGradePercentage(ctx context.Context, collegeID
 
 func (s *analyticsService) attendanceRate(ctx context.Context, collegeID, studentID int, courseID *int) (float64, error) {
 	query := `SELECT 
-        COALESCE(SUM(CASE WHEN status = 'Present' THEN 1 ELSE 0 END),0) AS present,
+        COALESCE(SUM(CASE WHEN ` + models.AttendancePresentSQL("status") + ` THEN 1 ELSE 0 END),0) AS present,
         COUNT(*) AS total
         FROM attendance WHERE college_id = $1 AND student_id = $2`
 	args := []any{collegeID, studentID}
@@ -475,10 +577,43 @@ func (s *analyticsService) quizStats(ctx context.Context, collegeID, studentID i
 	return count, 0, nil
 }
 
+// studentExamPerformance summarizes the student's published, decided exam
+// results, leaving out pending ones and cancelled or deleted exams
+func (s *analyticsService) studentExamPerformance(ctx context.Context, collegeID, studentID int, courseID *int) (*ExamPerformance, error) {
+	query := `SELECT COUNT(*),
+        COUNT(*) FILTER (WHERE r.result = 'pass'),
+        AVG(r.percentage)
+        FROM exam_results r
+        JOIN exams x ON x.id = r.exam_id AND x.college_id = r.college_id
+        WHERE r.college_id = $1 AND r.student_id = $2 AND r.published = TRUE
+        AND r.result IN ('pass','fail','absent')
+        AND x.deleted_at IS NULL AND x.status <> 'cancelled'`
+	args := []any{collegeID, studentID}
+
+	if courseID != nil {
+		query += " AND x.course_id = $3"
+		args = append(args, *courseID)
+	}
+
+	performance := &ExamPerformance{}
+	var avg sql.NullFloat64
+	if err := s.db.Pool.QueryRow(ctx, query, args...).Scan(&performance.ExamsTaken, &performance.ExamsPassed, &avg); err != nil {
+		return nil, fmt.Errorf("studentExamPerformance: query failed: %w", err)
+	}
+
+	if performance.ExamsTaken > 0 {
+		performance.PassRate = roundFloat(float64(performance.ExamsPassed)/float64(performance.ExamsTaken)*100, 2)
+	}
+	if avg.Valid {
+		performance.AverageExamPercentage = roundFloat(avg.Float64, 2)
+	}
+	return performance, nil
+}
+
 func (s *analyticsService) studentCourseMetrics(ctx context.Context, collegeID, studentID int) ([]CourseMetric, error) {
 	query := `SELECT c.id, c.name,
 		COALESCE(AVG(g.percentage),0) AS avg_percentage,
-		COALESCE(SUM(CASE WHEN a.status = 'Present' THEN 1 ELSE 0 END)::float / NULLIF(COUNT(a.id),0) * 100, 0) AS attendance_rate
+		COALESCE(SUM(CASE WHEN ` + models.AttendancePresentSQL("a.status") + ` THEN 1 ELSE 0 END)::float / NULLIF(COUNT(a.id),0) * 100, 0) AS attendance_rate
         FROM courses c
         JOIN enrollments e ON e.course_id = c.id AND e.student_id = $2
         LEFT JOIN grades g ON g.course_id = c.id AND g.student_id = e.student_id AND g.college_id = $1
@@ -515,18 +650,40 @@ func (s *analyticsService) studentCourseMetrics(ctx context.Context, collegeID,
 	return metrics, nil
 }
 
-func (s *analyticsService) countEnrollments(ctx context.Context, collegeID, courseID int) (int, error) {
+// sectionFilter keeps studentColumn to the students of one section of the
+// course, with its placeholder numbered argN. A nil section keeps everyone.
+func sectionFilter(studentColumn string, sectionID *int, argN int) (string, []any) {
+	if sectionID == nil {
+		return "", nil
+	}
+	return fmt.Sprintf(" AND %s IN (SELECT student_id FROM enrollments WHERE college_id = $1 AND course_id = $2 AND section_id = $%d)",
+		studentColumn, argN), []any{*sectionID}
+}
+
+// countEnrollments counts the course's students, only those enrolled before
+// the window ends when it is bounded
+func (s *analyticsService) countEnrollments(ctx context.Context, collegeID, courseID int, sectionID *int, window dateWindow) (int, error) {
 	var total int
-	if err := s.db.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM enrollments WHERE college_id = $1 AND course_id = $2`, collegeID, courseID).Scan(&total); err != nil {
+	filter, args := window.before("enrollment_date", 3)
+	if sectionID != nil {
+		filter += fmt.Sprintf(" AND section_id = $%d", 3+len(args))
+		args = append(args, *sectionID)
+	}
+	if err := s.db.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM enrollments WHERE college_id = $1 AND course_id = $2`+filter,
+		append([]any{collegeID, courseID}, args...)...).Scan(&total); err != nil {
 		return 0, fmt.Errorf("countEnrollments: query failed: %w", err)
 	}
 	return total, nil
 }
 
-func (s *analyticsService) courseAttendanceRate(ctx context.Context, collegeID, courseID int) (float64, error) {
+func (s *analyticsService) courseAttendanceRate(ctx context.Context, collegeID, courseID int, sectionID *int, window dateWindow) (float64, error) {
 	var present, total int
-	if err := s.db.Pool.QueryRow(ctx, `SELECT COALESCE(SUM(CASE WHEN status = 'Present' THEN 1 ELSE 0 END),0) AS present,
-        COUNT(*) AS total FROM attendance WHERE college_id = $1 AND course_id = $2`, collegeID, courseID).Scan(&present, &total); err != nil {
+	filter, args := window.within("date", 3)
+	section, sectionArgs := sectionFilter("student_id", sectionID, 3+len(args))
+	filter, args = filter+section, append(args, sectionArgs...)
+	if err := s.db.Pool.QueryRow(ctx, `SELECT COALESCE(SUM(CASE WHEN `+models.AttendancePresentSQL("status")+` THEN 1 ELSE 0 END),0) AS present,
+        COUNT(*) AS total FROM attendance WHERE college_id = $1 AND course_id = $2`+filter,
+		append([]any{collegeID, courseID}, args...)...).Scan(&present, &total); err != nil {
 		return 0, fmt.Errorf("courseAttendanceRate: query failed: %w", err)
 	}
 	if total == 0 {
@@ -535,9 +692,13 @@ func (s *analyticsService) courseAttendanceRate(ctx context.Context, collegeID,
 	return roundFloat(float64(present)/float64(total)*100, 2), nil
 }
 
-func (s *analyticsService) courseAverageGrade(ctx context.Context, collegeID, courseID int) (float64, error) {
+func (s *analyticsService) courseAverageGrade(ctx context.Context, collegeID, courseID int, sectionID *int, window dateWindow) (float64, error) {
 	var avg sql.NullFloat64
-	if err := s.db.Pool.QueryRow(ctx, `SELECT COALESCE(AVG(percentage),0) FROM grades WHERE college_id = $1 AND course_id = $2`, collegeID, courseID).Scan(&avg); err != nil {
+	filter, args := window.within("COALESCE(graded_at, created_at)", 3)
+	section, sectionArgs := sectionFilter("student_id", sectionID, 3+len(args))
+	filter, args = filter+section, append(args, sectionArgs...)
+	if err := s.db.Pool.QueryRow(ctx, `SELECT COALESCE(AVG(percentage),0) FROM grades WHERE college_id = $1 AND course_id = $2`+filter,
+		append([]any{collegeID, courseID}, args...)...).Scan(&avg); err != nil {
 		return 0, fmt.Errorf("courseAverageGrade: query failed: %w", err)
 	}
 	if avg.Valid {
@@ -546,13 +707,19 @@ func (s *analyticsService) courseAverageGrade(ctx context.Context, collegeID, co
 	return 0, nil
 }
 
-func (s *analyticsService) courseAssignmentSubmissionRate(ctx context.Context, collegeID, courseID, totalStudents int) (float64, error) {
+// courseAssignmentSubmissionRate compares submissions with the assignments
+// due, or failing a due date created, within the window
+func (s *analyticsService) courseAssignmentSubmissionRate(ctx context.Context, collegeID, courseID int, sectionID *int, totalStudents int, window dateWindow) (float64, error) {
 	if totalStudents == 0 {
 		return 0, nil
 	}
 
+	filter, args := window.within("COALESCE(a.due_date, a.created_at)", 3)
+	args = append([]any{collegeID, courseID}, args...)
+	section, sectionArgs := sectionFilter("s.student_id", sectionID, len(args)+1)
+
 	var totalAssignments int
-	if err := s.db.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM assignments WHERE college_id = $1 AND course_id = $2`, collegeID, courseID).Scan(&totalAssignments); err != nil {
+	if err := s.db.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM assignments a WHERE a.college_id = $1 AND a.course_id = $2`+filter, args...).Scan(&totalAssignments); err != nil {
 		return 0, fmt.Errorf("courseAssignmentSubmissionRate: failed to count assignments: %w", err)
 	}
 
@@ -563,7 +730,7 @@ func (s *analyticsService) courseAssignmentSubmissionRate(ctx context.Context, c
 	var submissions int
 	if err := s.db.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM assignment_submissions s
         JOIN assignments a ON a.id = s.assignment_id
-        WHERE a.college_id = $1 AND a.course_id = $2`, collegeID, courseID).Scan(&submissions); err != nil {
+        WHERE a.college_id = $1 AND a.course_id = $2`+filter+section, append(args, sectionArgs...)...).Scan(&submissions); err != nil {
 		return 0, fmt.Errorf("courseAssignmentSubmissionRate: failed to count submissions: %w", err)
 	}
 
@@ -575,13 +742,25 @@ func (s *analyticsService) courseAssignmentSubmissionRate(ctx context.Context, c
 	return roundFloat(float64(submissions)/float64(denominator)*100, 2), nil
 }
 
-func (s *analyticsService) courseQuizParticipation(ctx context.Context, collegeID, courseID, totalStudents int) (float64, error) {
+// courseQuizParticipation compares attempts with the quizzes created within
+// the window. For a section only the course-wide quizzes and the section's
+// own count.
+func (s *analyticsService) courseQuizParticipation(ctx context.Context, collegeID, courseID int, sectionID *int, totalStudents int, window dateWindow) (float64, error) {
 	if totalStudents == 0 {
 		return 0, nil
 	}
 
+	filter, args := window.within("created_at", 3)
+	args = append([]any{collegeID, courseID}, args...)
+	attemptFilter := ""
+	if sectionID != nil {
+		filter += fmt.Sprintf(" AND (section_id IS NULL OR section_id = $%d)", len(args)+1)
+		args = append(args, *sectionID)
+		attemptFilter, _ = sectionFilter("qa.student_id", sectionID, len(args))
+	}
+
 	var totalQuizzes int
-	if err := s.db.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM quizzes WHERE college_id = $1 AND course_id = $2`, collegeID, courseID).Scan(&totalQuizzes); err != nil {
+	if err := s.db.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM quizzes WHERE college_id = $1 AND course_id = $2`+filter, args...).Scan(&totalQuizzes); err != nil {
 		return 0, fmt.Errorf("courseQuizParticipation: failed to count quizzes: %w", err)
 	}
 
@@ -591,8 +770,8 @@ func (s *analyticsService) courseQuizParticipation(ctx context.Context, collegeI
 
 	var attempts int
 	if err := s.db.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM quiz_attempts qa
-        WHERE qa.college_id = $1 AND qa.quiz_id IN (SELECT id FROM quizzes WHERE college_id = $1 AND course_id = $2)
-        AND qa.status IN ('submitted','graded')`, collegeID, courseID).Scan(&attempts); err != nil {
+        WHERE qa.college_id = $1 AND qa.quiz_id IN (SELECT id FROM quizzes WHERE college_id = $1 AND course_id = $2`+filter+`)
+        AND qa.status IN ('submitted','graded')`+attemptFilter, args...).Scan(&attempts); err != nil {
 		return 0, fmt.Errorf("courseQuizParticipation: failed to count attempts: %w", err)
 	}
 
@@ -604,10 +783,11 @@ func (s *analyticsService) courseQuizParticipation(ctx context.Context, collegeI
 	return roundFloat(float64(attempts)/float64(denominator)*100, 2), nil
 }
 
-func (s *analyticsService) topPerformers(ctx context.Context, collegeID, courseID, limit int) ([]int, error) {
-	query := `SELECT student_id FROM grades WHERE college_id = $1 AND course_id = $2
+func (s *analyticsService) topPerformers(ctx context.Context, collegeID, courseID int, sectionID *int, limit int) ([]int, error) {
+	section, sectionArgs := sectionFilter("student_id", sectionID, 4)
+	query := `SELECT student_id FROM grades WHERE college_id = $1 AND course_id = $2` + section + `
         ORDER BY percentage DESC LIMIT $3`
-	rows, err := s.db.Pool.Query(ctx, query, collegeID, courseID, limit)
+	rows, err := s.db.Pool.Query(ctx, query, append([]any{collegeID, courseID, limit}, sectionArgs...)...)
 	if err != nil {
 		return nil, fmt.Errorf("topPerformers: query failed: %w", err)
 	}
@@ -625,25 +805,50 @@ func (s *analyticsService) topPerformers(ctx context.Context, collegeID, courseI
 	return performers, nil
 }
 
-func (s *analyticsService) studentsAtRisk(ctx context.Context, collegeID, courseID int) ([]int, error) {
+func (s *analyticsService) setRiskConfig(cfg *config.AnalyticsConfig) {
+	s.riskConfigMu.Lock()
+	defer s.riskConfigMu.Unlock()
+	s.riskConfig = cfg
+}
+
+func (s *analyticsService) currentRiskConfig() *config.AnalyticsConfig {
+	s.riskConfigMu.RLock()
+	defer s.riskConfigMu.RUnlock()
+	if s.riskConfig == nil {
+		return config.LoadAnalyticsConfig()
+	}
+	return s.riskConfig
+}
+
+// studentsAtRisk lists enrolled students whose attendance rate or average
+// grade in the course is below the configured risk thresholds. Grades use
+// CourseGradeRiskThreshold rather than the dropout-risk GradeRiskThreshold.
+func (s *analyticsService) studentsAtRisk(ctx context.Context, collegeID, courseID int, sectionID *int) ([]int, error) {
+	cfg := s.currentRiskConfig()
+	args := []any{collegeID, courseID, cfg.AttendanceRiskThreshold / 100, cfg.CourseGradeRiskThreshold}
+	section := ""
+	if sectionID != nil {
+		section = " AND e.section_id = $5"
+		args = append(args, *sectionID)
+	}
 	query := `SELECT student_id FROM enrollments e
-        WHERE e.college_id = $1 AND e.course_id = $2
+        WHERE e.college_id = $1 AND e.course_id = $2` + section + `
         AND (
             EXISTS (
                 SELECT 1 FROM attendance a
                 WHERE a.college_id = e.college_id AND a.course_id = e.course_id AND a.student_id = e.student_id
                 GROUP BY a.student_id
-                HAVING COALESCE(SUM(CASE WHEN a.status = 'Present' THEN 1 ELSE 0 END)::float / NULLIF(COUNT(*),0),0) < 0.6
+                HAVING COALESCE(SUM(CASE WHEN ` + models.AttendancePresentSQL("a.status") + ` THEN 1 ELSE 0 END)::float / NULLIF(COUNT(*),0),0) < $3
             )
             OR EXISTS (
                 SELECT 1 FROM grades g
                 WHERE g.college_id = e.college_id AND g.course_id = e.course_id AND g.student_id = e.student_id
                 GROUP BY g.student_id
-                HAVING COALESCE(AVG(g.percentage),0) < 60
+                HAVING COALESCE(AVG(g.percentage),0) < $4
             )
         )`
 
-	rows, err := s.db.Pool.Query(ctx, query, collegeID, courseID)
+	rows, err := s.db.Pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("studentsAtRisk: query failed: %w", err)
 	}
@@ -663,7 +868,7 @@ func (s *analyticsService) studentsAtRisk(ctx context.Context, collegeID, course
 
 func (s *analyticsService) overallAttendanceRate(ctx context.Context, collegeID int) (float64, error) {
 	var present, total int
-	if err := s.db.Pool.QueryRow(ctx, `SELECT COALESCE(SUM(CASE WHEN status = 'Present' THEN 1 ELSE 0 END),0), COUNT(*)
+	if err := s.db.Pool.QueryRow(ctx, `SELECT COALESCE(SUM(CASE WHEN `+models.AttendancePresentSQL("status")+` THEN 1 ELSE 0 END),0), COUNT(*)
         FROM attendance WHERE college_id = $1`, collegeID).Scan(&present, &total); err != nil {
 		return 0, fmt.Errorf("overallAttendanceRate: query failed: %w", err)
 	}
@@ -675,6 +880,27 @@ func (s *analyticsService) overallAttendanceRate(ctx context.Context, collegeID
 	return roundFloat(float64(present)/float64(total)*100, 2), nil
 }
 
+// collegePendingGrading counts the college's work still waiting for a grade,
+// by the same rules as a faculty member's PendingGrading
+func (s *analyticsService) collegePendingGrading(ctx context.Context, collegeID int) (int, error) {
+	var pending int
+	err := s.db.Pool.QueryRow(ctx, `SELECT
+            (SELECT COUNT(*) FROM assignment_submissions s
+                JOIN assignments a ON a.id = s.assignment_id
+                WHERE a.college_id = $1 AND s.grade IS NULL)
+            + (SELECT COUNT(*) FROM quiz_attempts
+                WHERE college_id = $1 AND status = 'submitted')
+            + (SELECT COUNT(*) FROM exam_results r
+                JOIN exams x ON x.id = r.exam_id
+                WHERE r.college_id = $1 AND x.deleted_at IS NULL
+                    AND r.result = 'pending' AND r.marks_obtained IS NULL)`,
+		collegeID).Scan(&pending)
+	if err != nil {
+		return 0, fmt.Errorf("collegePendingGrading: query failed: %w", err)
+	}
+	return pending, nil
+}
+
 func (s *analyticsService) overallAveragePercentage(ctx context.Context, collegeID int) (float64, error) {
 	var avg sql.NullFloat64
 	if err := s.db.Pool.QueryRow(ctx, `SELECT COALESCE(AVG(percentage),0) FROM grades WHERE college_id = $1`, collegeID).Scan(&avg); err != nil {
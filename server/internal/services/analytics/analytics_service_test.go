@@ -0,0 +1,181 @@
+package analytics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/repository"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Rows stored as "PRESENT" or "present" must count the same as "Present"
+func TestAttendanceQueriesIgnoreStatusCase(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	svc := &analyticsService{db: &repository.DB{Pool: mock}}
+
+	mock.ExpectQuery(`CASE WHEN LOWER\(TRIM\(status\)\) = 'present' THEN 1`).
+		WithArgs(1).
+		WillReturnRows(pgxmock.NewRows([]string{"present", "total"}).AddRow(3, 4))
+
+	rate, err := svc.overallAttendanceRate(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 75.0, rate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// A section's figures only count the students enrolled in that section
+func TestCourseAnalyticsSectionScope(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	svc := &analyticsService{db: &repository.DB{Pool: mock}}
+	ctx := context.Background()
+	section := 7
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM enrollments WHERE college_id = \$1 AND course_id = \$2 AND section_id = \$3`).
+		WithArgs(1, 4, section).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(12))
+	mock.ExpectQuery(`FROM attendance WHERE college_id = \$1 AND course_id = \$2 AND student_id IN \(SELECT student_id FROM enrollments WHERE college_id = \$1 AND course_id = \$2 AND section_id = \$3\)`).
+		WithArgs(1, 4, section).
+		WillReturnRows(pgxmock.NewRows([]string{"present", "total"}).AddRow(9, 10))
+
+	total, err := svc.countEnrollments(ctx, 1, 4, &section, allTime)
+	require.NoError(t, err)
+	assert.Equal(t, 12, total)
+
+	rate, err := svc.courseAttendanceRate(ctx, 1, 4, &section, allTime)
+	require.NoError(t, err)
+	assert.Equal(t, 90.0, rate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// The default course at-risk list keeps its original cutoffs: attendance
+// below 60% or an average grade below 60
+func TestStudentsAtRiskDefaultCutoffs(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	for _, key := range []string{"ANALYTICS_CONFIG_FILE", "ANALYTICS_ATTENDANCE_RISK_THRESHOLD", "ANALYTICS_COURSE_GRADE_RISK_THRESHOLD"} {
+		t.Setenv(key, "")
+	}
+	svc := &analyticsService{db: &repository.DB{Pool: mock}}
+
+	mock.ExpectQuery(`FROM enrollments e\s+WHERE e.college_id = \$1 AND e.course_id = \$2`).
+		WithArgs(1, 4, 0.6, 60.0).
+		WillReturnRows(pgxmock.NewRows([]string{"student_id"}).AddRow(8).AddRow(9))
+
+	ids, err := svc.studentsAtRisk(context.Background(), 1, 4, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []int{8, 9}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Days without attendance rows still appear in a student's trend
+func TestGetStudentAttendanceTrends(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	svc := &analyticsService{db: &repository.DB{Pool: mock}}
+	courseID := 4
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`FROM generate_series\(.*\) AS d\(day\) LEFT JOIN attendance a ON a.date = d.day::date AND a.college_id = \$1 AND a.student_id = \$2 AND a.course_id = \$3`).
+		WithArgs(1, 9, courseID).
+		WillReturnRows(pgxmock.NewRows([]string{"date", "present", "expected"}).
+			AddRow(day, 1, 2).
+			AddRow(day.AddDate(0, 0, 1), 0, 0))
+
+	trends, err := svc.GetStudentAttendanceTrends(context.Background(), 1, 9, &courseID)
+
+	require.NoError(t, err)
+	require.Len(t, trends, 2)
+	assert.Equal(t, 50.0, trends[0].AttendanceRate)
+	assert.Equal(t, 0, trends[1].TotalExpected)
+	assert.Equal(t, 0.0, trends[1].AttendanceRate)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Exam results are only read when asked for, and then only published,
+// decided results of live exams in the requested course count
+func TestGetStudentPerformanceExamResults(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	svc := &analyticsService{db: &repository.DB{Pool: mock}}
+	ctx := context.Background()
+	course := 4
+
+	expectPerformance := func() {
+		mock.ExpectQuery(`WITH\s+grade_stats AS`).
+			WithArgs(1, 9, course, course, course, course).
+			WillReturnRows(pgxmock.NewRows([]string{"avg", "rate", "submitted", "total", "quizzes", "quiz_avg"}).
+				AddRow(80.0, 90.0, 3, 4, 2, 70.0))
+	}
+
+	expectPerformance()
+	metrics, err := svc.GetStudentPerformance(ctx, 1, 9, &course, false)
+	require.NoError(t, err)
+	assert.Nil(t, metrics.ExamMetrics)
+
+	expectPerformance()
+	mock.ExpectQuery(`(?s)FROM exam_results r\s+JOIN exams x .* r.published = TRUE\s+AND r.result IN \('pass','fail','absent'\)\s+AND x.deleted_at IS NULL AND x.status <> 'cancelled' AND x.course_id = \$3`).
+		WithArgs(1, 9, course).
+		WillReturnRows(pgxmock.NewRows([]string{"count", "passed", "avg"}).AddRow(3, 2, 64.666))
+	metrics, err = svc.GetStudentPerformance(ctx, 1, 9, &course, true)
+	require.NoError(t, err)
+	require.NotNil(t, metrics.ExamMetrics)
+	assert.Equal(t, 3, metrics.ExamMetrics.ExamsTaken)
+	assert.Equal(t, 2, metrics.ExamMetrics.ExamsPassed)
+	assert.Equal(t, 66.67, metrics.ExamMetrics.PassRate)
+	assert.Equal(t, 64.67, metrics.ExamMetrics.AverageExamPercentage)
+	assert.Equal(t, PercentageToGPA(80.0), metrics.OverallGPA, "exam results stay out of the GPA")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// The grading backlog is counted across the whole college, beside the
+// existing dashboard figures
+func TestGetCollegeDashboardPendingGrading(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	svc := &analyticsService{db: &repository.DB{Pool: mock}}
+
+	count := func(pattern string, n int) {
+		mock.ExpectQuery(pattern).WithArgs(1).WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(n))
+	}
+	count(`FROM students WHERE college_id = \$1`, 120)
+	count(`SELECT COUNT\(\*\) FROM courses`, 8)
+	count(`COUNT\(DISTINCT instructor_id\)`, 5)
+	mock.ExpectQuery(`FROM attendance WHERE college_id = \$1`).
+		WithArgs(1).
+		WillReturnRows(pgxmock.NewRows([]string{"present", "total"}).AddRow(9, 10))
+	mock.ExpectQuery(`FROM grades WHERE college_id = \$1`).
+		WithArgs(1).
+		WillReturnRows(pgxmock.NewRows([]string{"avg"}).AddRow(82.0))
+	count(`FROM announcements`, 2)
+	count(`FROM calendar_events`, 1)
+	count(`(?s)WHERE a.college_id = \$1 AND s.grade IS NULL.*`+
+		`WHERE college_id = \$1 AND status = 'submitted'.*`+
+		`WHERE r.college_id = \$1 AND x.deleted_at IS NULL\s+AND r.result = 'pending' AND r.marks_obtained IS NULL`, 14)
+
+	dashboard, err := svc.GetCollegeDashboard(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 120, dashboard.TotalStudents)
+	assert.Equal(t, 90.0, dashboard.AverageAttendance)
+	assert.Equal(t, 14, dashboard.PendingGrading)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
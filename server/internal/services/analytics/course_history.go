@@ -0,0 +1,129 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// courseHistoryMaxMonths bounds how far back GetCourseAnalyticsHistory goes
+const courseHistoryMaxMonths = 24
+
+// dateWindow is a half-open [From, To) range the course aggregates can be
+// limited to. The zero window covers all time.
+type dateWindow struct {
+	From time.Time
+	To   time.Time
+}
+
+// allTime is the unbounded window used for the course snapshot
+var allTime = dateWindow{}
+
+// within returns a filter keeping column inside the window, with its
+// placeholders numbered from argN, and the matching arguments
+func (w dateWindow) within(column string, argN int) (string, []any) {
+	if w.From.IsZero() {
+		return "", nil
+	}
+	return fmt.Sprintf(" AND %s >= $%d AND %s < $%d", column, argN, column, argN+1), []any{w.From, w.To}
+}
+
+// before returns a filter keeping column earlier than the end of the window
+func (w dateWindow) before(column string, argN int) (string, []any) {
+	if w.To.IsZero() {
+		return "", nil
+	}
+	return fmt.Sprintf(" AND %s < $%d", column, argN), []any{w.To}
+}
+
+// CourseAnalyticsPeriod holds a course's metrics for one month
+type CourseAnalyticsPeriod struct {
+	Period               string    `json:"period"` // YYYY-MM
+	Start                time.Time `json:"start"`
+	End                  time.Time `json:"end"`
+	TotalStudents        int       `json:"total_students"`
+	AverageAttendance    float64   `json:"average_attendance"`
+	AverageGrade         float64   `json:"average_grade"`
+	AssignmentSubmission float64   `json:"assignment_submission_rate"`
+	QuizParticipation    float64   `json:"quiz_participation_rate"`
+}
+
+// GetCourseAnalyticsHistory reports a course's metrics month by month, from
+// the first month with attendance or grades up to the current one and at most
+// courseHistoryMaxMonths back, oldest first. Courses are not tied to terms, so
+// months are the grouping. A course with no activity has an empty history.
+func (s *analyticsService) GetCourseAnalyticsHistory(ctx context.Context, collegeID, courseID int) ([]CourseAnalyticsPeriod, error) {
+	var first sql.NullTime
+	err := s.db.Pool.QueryRow(ctx, `SELECT LEAST(
+            (SELECT MIN(date)::timestamptz FROM attendance WHERE college_id = $1 AND course_id = $2),
+            (SELECT MIN(COALESCE(graded_at, created_at))::timestamptz FROM grades WHERE college_id = $1 AND course_id = $2))`,
+		collegeID, courseID).Scan(&first)
+	if err != nil {
+		return nil, fmt.Errorf("GetCourseAnalyticsHistory: failed to find first activity: %w", err)
+	}
+	if !first.Valid {
+		return []CourseAnalyticsPeriod{}, nil
+	}
+
+	windows := monthlyWindows(first.Time, time.Now(), courseHistoryMaxMonths)
+	history := make([]CourseAnalyticsPeriod, 0, len(windows))
+	for _, window := range windows {
+		period, err := s.courseAnalyticsForWindow(ctx, collegeID, courseID, window)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, *period)
+	}
+	return history, nil
+}
+
+// courseAnalyticsForWindow runs the course aggregates over one window
+func (s *analyticsService) courseAnalyticsForWindow(ctx context.Context, collegeID, courseID int, window dateWindow) (*CourseAnalyticsPeriod, error) {
+	period := &CourseAnalyticsPeriod{
+		Period: window.From.Format("2006-01"),
+		Start:  window.From,
+		End:    window.To,
+	}
+
+	totalStudents, err := s.countEnrollments(ctx, collegeID, courseID, nil, window)
+	if err != nil {
+		return nil, err
+	}
+	period.TotalStudents = totalStudents
+
+	if period.AverageAttendance, err = s.courseAttendanceRate(ctx, collegeID, courseID, nil, window); err != nil {
+		return nil, err
+	}
+
+	avgGrade, err := s.courseAverageGrade(ctx, collegeID, courseID, nil, window)
+	if err != nil {
+		return nil, err
+	}
+	period.AverageGrade = PercentageToGPA(avgGrade)
+
+	if period.AssignmentSubmission, err = s.courseAssignmentSubmissionRate(ctx, collegeID, courseID, nil, totalStudents, window); err != nil {
+		return nil, err
+	}
+	if period.QuizParticipation, err = s.courseQuizParticipation(ctx, collegeID, courseID, nil, totalStudents, window); err != nil {
+		return nil, err
+	}
+	return period, nil
+}
+
+// monthlyWindows splits the calendar months from the one containing first to
+// the one containing now into windows, keeping only the latest max of them
+func monthlyWindows(first, now time.Time, max int) []dateWindow {
+	first = first.In(now.Location())
+	start := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, now.Location())
+	end := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, 1, 0)
+	if earliest := end.AddDate(0, -max, 0); start.Before(earliest) {
+		start = earliest
+	}
+
+	var windows []dateWindow
+	for month := start; month.Before(end); month = month.AddDate(0, 1, 0) {
+		windows = append(windows, dateWindow{From: month, To: month.AddDate(0, 1, 0)})
+	}
+	return windows
+}
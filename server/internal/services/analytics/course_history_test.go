@@ -0,0 +1,103 @@
+package analytics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/repository"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonthlyWindows(t *testing.T) {
+	now := time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC)
+
+	t.Run("one window per month up to now", func(t *testing.T) {
+		windows := monthlyWindows(time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC), now, 24)
+		require.Len(t, windows, 3)
+		assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), windows[0].From)
+		assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), windows[0].To)
+		assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), windows[2].To)
+	})
+
+	t.Run("capped to the latest months", func(t *testing.T) {
+		windows := monthlyWindows(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), now, 6)
+		require.Len(t, windows, 6)
+		assert.Equal(t, time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), windows[0].From)
+	})
+}
+
+func TestDateWindowFilters(t *testing.T) {
+	filter, args := allTime.within("date", 3)
+	assert.Empty(t, filter)
+	assert.Empty(t, args)
+
+	w := dateWindow{From: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)}
+	filter, args = w.within("date", 3)
+	assert.Equal(t, " AND date >= $3 AND date < $4", filter)
+	assert.Equal(t, []any{w.From, w.To}, args)
+
+	filter, args = w.before("enrollment_date", 3)
+	assert.Equal(t, " AND enrollment_date < $3", filter)
+	assert.Equal(t, []any{w.To}, args)
+}
+
+func TestGetCourseAnalyticsHistory(t *testing.T) {
+	t.Run("no activity", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		svc := &analyticsService{db: &repository.DB{Pool: mock}}
+
+		mock.ExpectQuery(`SELECT LEAST`).
+			WithArgs(1, 2).
+			WillReturnRows(pgxmock.NewRows([]string{"least"}).AddRow(nil))
+
+		history, err := svc.GetCourseAnalyticsHistory(context.Background(), 1, 2)
+		require.NoError(t, err)
+		assert.Empty(t, history)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("current month only", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+		svc := &analyticsService{db: &repository.DB{Pool: mock}}
+
+		now := time.Now()
+		from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		to := from.AddDate(0, 1, 0)
+
+		mock.ExpectQuery(`SELECT LEAST`).
+			WithArgs(1, 2).
+			WillReturnRows(pgxmock.NewRows([]string{"least"}).AddRow(from))
+		mock.ExpectQuery(`FROM enrollments WHERE college_id = \$1 AND course_id = \$2 AND enrollment_date < \$3`).
+			WithArgs(1, 2, to).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(4))
+		mock.ExpectQuery(`FROM attendance WHERE college_id = \$1 AND course_id = \$2 AND date >= \$3 AND date < \$4`).
+			WithArgs(1, 2, from, to).
+			WillReturnRows(pgxmock.NewRows([]string{"present", "total"}).AddRow(3, 4))
+		mock.ExpectQuery(`FROM grades WHERE college_id = \$1 AND course_id = \$2 AND COALESCE\(graded_at, created_at\) >= \$3`).
+			WithArgs(1, 2, from, to).
+			WillReturnRows(pgxmock.NewRows([]string{"avg"}).AddRow(82.0))
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM assignments a WHERE .* AND COALESCE\(a.due_date, a.created_at\) >= \$3`).
+			WithArgs(1, 2, from, to).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM quizzes WHERE .* AND created_at >= \$3`).
+			WithArgs(1, 2, from, to).
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
+
+		history, err := svc.GetCourseAnalyticsHistory(context.Background(), 1, 2)
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		assert.Equal(t, from.Format("2006-01"), history[0].Period)
+		assert.Equal(t, 4, history[0].TotalStudents)
+		assert.Equal(t, 75.0, history[0].AverageAttendance)
+		assert.Equal(t, 3.3, history[0].AverageGrade)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
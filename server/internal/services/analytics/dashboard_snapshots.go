@@ -0,0 +1,147 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultDashboardTrendLimit = 30
+	maxDashboardTrendLimit     = 366
+)
+
+// DashboardSnapshot is the college dashboard as it stood on one day
+type DashboardSnapshot struct {
+	CollegeID    int       `json:"college_id"`
+	SnapshotDate time.Time `json:"snapshot_date"`
+	CollegeDashboard
+	CapturedAt time.Time `json:"captured_at"`
+}
+
+// CaptureDashboardSnapshot stores today's dashboard for the college, replacing
+// any snapshot already taken today, then drops snapshots past the retention period
+func (s *analyticsService) CaptureDashboardSnapshot(ctx context.Context, collegeID int) (*DashboardSnapshot, error) {
+	dashboard, err := s.GetCollegeDashboard(ctx, collegeID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &DashboardSnapshot{CollegeID: collegeID, CollegeDashboard: *dashboard}
+	query := `INSERT INTO dashboard_snapshots (college_id, snapshot_date, total_students, total_courses, total_faculty,
+            average_attendance, overall_gpa, active_announcements, upcoming_events, pending_grading, captured_at)
+        VALUES ($1, CURRENT_DATE, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+        ON CONFLICT (college_id, snapshot_date) DO UPDATE SET
+            total_students = EXCLUDED.total_students,
+            total_courses = EXCLUDED.total_courses,
+            total_faculty = EXCLUDED.total_faculty,
+            average_attendance = EXCLUDED.average_attendance,
+            overall_gpa = EXCLUDED.overall_gpa,
+            active_announcements = EXCLUDED.active_announcements,
+            upcoming_events = EXCLUDED.upcoming_events,
+            pending_grading = EXCLUDED.pending_grading,
+            captured_at = EXCLUDED.captured_at
+        RETURNING snapshot_date, captured_at`
+	err = s.db.Pool.QueryRow(ctx, query,
+		collegeID, dashboard.TotalStudents, dashboard.TotalCourses, dashboard.TotalFaculty,
+		dashboard.AverageAttendance, dashboard.OverallGPA, dashboard.ActiveAnnouncements, dashboard.UpcomingEvents,
+		dashboard.PendingGrading,
+	).Scan(&snapshot.SnapshotDate, &snapshot.CapturedAt)
+	if err != nil {
+		return nil, fmt.Errorf("CaptureDashboardSnapshot: failed to save snapshot: %w", err)
+	}
+
+	if err := s.pruneDashboardSnapshots(ctx, collegeID); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// CaptureAllDashboardSnapshots captures today's snapshot for every college.
+// A failing college does not stop the rest; their errors are joined.
+func (s *analyticsService) CaptureAllDashboardSnapshots(ctx context.Context) (int, error) {
+	rows, err := s.db.Pool.Query(ctx, `SELECT id FROM colleges ORDER BY id`)
+	if err != nil {
+		return 0, fmt.Errorf("CaptureAllDashboardSnapshots: failed to list colleges: %w", err)
+	}
+	var collegeIDs []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("CaptureAllDashboardSnapshots: failed to scan college: %w", err)
+		}
+		collegeIDs = append(collegeIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("CaptureAllDashboardSnapshots: failed to list colleges: %w", err)
+	}
+
+	captured := 0
+	var errs []error
+	for _, collegeID := range collegeIDs {
+		if _, err := s.CaptureDashboardSnapshot(ctx, collegeID); err != nil {
+			errs = append(errs, fmt.Errorf("college %d: %w", collegeID, err))
+			continue
+		}
+		captured++
+	}
+	return captured, errors.Join(errs...)
+}
+
+// GetDashboardTrend returns the college's last limit snapshots, oldest first
+func (s *analyticsService) GetDashboardTrend(ctx context.Context, collegeID, limit int) ([]DashboardSnapshot, error) {
+	if limit <= 0 {
+		limit = defaultDashboardTrendLimit
+	}
+	if limit > maxDashboardTrendLimit {
+		limit = maxDashboardTrendLimit
+	}
+
+	query := `SELECT college_id, snapshot_date, total_students, total_courses, total_faculty,
+            average_attendance, overall_gpa, active_announcements, upcoming_events, pending_grading, captured_at
+        FROM dashboard_snapshots
+        WHERE college_id = $1
+        ORDER BY snapshot_date DESC
+        LIMIT $2`
+	rows, err := s.db.Pool.Query(ctx, query, collegeID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("GetDashboardTrend: failed to query snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := make([]DashboardSnapshot, 0)
+	for rows.Next() {
+		var snap DashboardSnapshot
+		if err := rows.Scan(&snap.CollegeID, &snap.SnapshotDate, &snap.TotalStudents, &snap.TotalCourses,
+			&snap.TotalFaculty, &snap.AverageAttendance, &snap.OverallGPA, &snap.ActiveAnnouncements,
+			&snap.UpcomingEvents, &snap.PendingGrading, &snap.CapturedAt); err != nil {
+			return nil, fmt.Errorf("GetDashboardTrend: failed to scan row: %w", err)
+		}
+		snapshots = append(snapshots, snap)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("GetDashboardTrend: failed to read snapshots: %w", err)
+	}
+
+	// Newest rows were fetched so the limit keeps the latest days; charts want them in date order
+	for i, j := 0, len(snapshots)-1; i < j; i, j = i+1, j-1 {
+		snapshots[i], snapshots[j] = snapshots[j], snapshots[i]
+	}
+	return snapshots, nil
+}
+
+func (s *analyticsService) pruneDashboardSnapshots(ctx context.Context, collegeID int) error {
+	if s.snapshotRetentionDays <= 0 {
+		return nil
+	}
+	_, err := s.db.Pool.Exec(ctx,
+		`DELETE FROM dashboard_snapshots WHERE college_id = $1 AND snapshot_date < CURRENT_DATE - $2::int`,
+		collegeID, s.snapshotRetentionDays)
+	if err != nil {
+		return fmt.Errorf("CaptureDashboardSnapshot: failed to prune old snapshots: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package analytics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/repository"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func snapshotColumns() []string {
+	return []string{
+		"college_id", "snapshot_date", "total_students", "total_courses", "total_faculty",
+		"average_attendance", "overall_gpa", "active_announcements", "upcoming_events", "pending_grading", "captured_at",
+	}
+}
+
+func TestGetDashboardTrendReturnsOldestFirst(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	svc := &analyticsService{db: &repository.DB{Pool: mock}}
+	today := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	yesterday := today.AddDate(0, 0, -1)
+
+	mock.ExpectQuery(`FROM dashboard_snapshots\s+WHERE college_id = \$1\s+ORDER BY snapshot_date DESC\s+LIMIT \$2`).
+		WithArgs(1, defaultDashboardTrendLimit).
+		WillReturnRows(pgxmock.NewRows(snapshotColumns()).
+			AddRow(1, today, 120, 8, 5, 91.5, 3.2, 2, 1, 14, today).
+			AddRow(1, yesterday, 118, 8, 5, 90.0, 3.1, 3, 2, 9, yesterday))
+
+	trend, err := svc.GetDashboardTrend(context.Background(), 1, 0)
+
+	require.NoError(t, err)
+	require.Len(t, trend, 2)
+	assert.Equal(t, yesterday, trend[0].SnapshotDate)
+	assert.Equal(t, 120, trend[1].TotalStudents)
+	assert.Equal(t, 9, trend[0].PendingGrading)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetDashboardTrendCapsLimit(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	svc := &analyticsService{db: &repository.DB{Pool: mock}}
+	mock.ExpectQuery(`FROM dashboard_snapshots`).
+		WithArgs(1, maxDashboardTrendLimit).
+		WillReturnRows(pgxmock.NewRows(snapshotColumns()))
+
+	trend, err := svc.GetDashboardTrend(context.Background(), 1, 5000)
+
+	require.NoError(t, err)
+	assert.Empty(t, trend)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPruneDashboardSnapshots(t *testing.T) {
+	t.Run("deletes past retention", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		svc := &analyticsService{db: &repository.DB{Pool: mock}, snapshotRetentionDays: 90}
+		mock.ExpectExec(`DELETE FROM dashboard_snapshots WHERE college_id = \$1 AND snapshot_date < CURRENT_DATE - \$2::int`).
+			WithArgs(1, 90).
+			WillReturnResult(pgxmock.NewResult("DELETE", 4))
+
+		require.NoError(t, svc.pruneDashboardSnapshots(context.Background(), 1))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("zero retention keeps everything", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		svc := &analyticsService{db: &repository.DB{Pool: mock}}
+		require.NoError(t, svc.pruneDashboardSnapshots(context.Background(), 1))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,101 @@
+package analytics
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// DataVersion identifies the state of the rows an analytics response is built
+// from, so clients can revalidate a cached copy instead of refetching it
+type DataVersion struct {
+	ETag string
+	// LastModified is the latest updated_at among those rows; zero when there
+	// are none
+	LastModified time.Time
+}
+
+// CourseAnalyticsVersion returns the version of the data behind
+// GetCourseAnalytics. It changes whenever a row is added, updated or removed
+// in any of the course's tables, or when the at-risk thresholds are reloaded.
+func (s *analyticsService) CourseAnalyticsVersion(ctx context.Context, collegeID, courseID int, sectionID *int) (*DataVersion, error) {
+	query := `SELECT MAX(changed_at), COALESCE(SUM(row_count),0), 0 FROM (
+            SELECT MAX(updated_at)::timestamptz AS changed_at, COUNT(*) AS row_count FROM enrollments WHERE college_id = $1 AND course_id = $2
+            UNION ALL SELECT MAX(updated_at)::timestamptz, COUNT(*) FROM attendance WHERE college_id = $1 AND course_id = $2
+            UNION ALL SELECT MAX(updated_at)::timestamptz, COUNT(*) FROM grades WHERE college_id = $1 AND course_id = $2
+            UNION ALL SELECT MAX(updated_at)::timestamptz, COUNT(*) FROM assignments WHERE college_id = $1 AND course_id = $2
+            UNION ALL SELECT MAX(s.updated_at)::timestamptz, COUNT(*) FROM assignment_submissions s
+                JOIN assignments a ON a.id = s.assignment_id
+                WHERE a.college_id = $1 AND a.course_id = $2
+            UNION ALL SELECT MAX(updated_at)::timestamptz, COUNT(*) FROM quizzes WHERE college_id = $1 AND course_id = $2
+            UNION ALL SELECT MAX(qa.updated_at)::timestamptz, COUNT(*) FROM quiz_attempts qa
+                JOIN quizzes q ON q.id = qa.quiz_id
+                WHERE qa.college_id = $1 AND q.course_id = $2
+        ) versions`
+
+	cfg := s.currentRiskConfig()
+	section := 0
+	if sectionID != nil {
+		section = *sectionID
+	}
+	scope := fmt.Sprintf("course:%d:%d:%d:%g:%g", collegeID, courseID, section, cfg.AttendanceRiskThreshold, cfg.CourseGradeRiskThreshold)
+	version, err := s.dataVersion(ctx, scope, query, collegeID, courseID)
+	if err != nil {
+		return nil, fmt.Errorf("CourseAnalyticsVersion: %w", err)
+	}
+	return version, nil
+}
+
+// CollegeDashboardVersion returns the version of the data behind
+// GetCollegeDashboard. Besides row changes it also moves when an announcement
+// expires or an event starts, since both drop out of the dashboard's counts
+// without their rows being touched.
+func (s *analyticsService) CollegeDashboardVersion(ctx context.Context, collegeID int) (*DataVersion, error) {
+	query := `SELECT MAX(changed_at), COALESCE(SUM(row_count),0), COALESCE(SUM(live_count),0) FROM (
+            SELECT MAX(updated_at)::timestamptz AS changed_at, COUNT(*) AS row_count, 0 AS live_count FROM students WHERE college_id = $1
+            UNION ALL SELECT MAX(updated_at)::timestamptz, COUNT(*), 0 FROM courses WHERE college_id = $1
+            UNION ALL SELECT MAX(updated_at)::timestamptz, COUNT(*), 0 FROM attendance WHERE college_id = $1
+            UNION ALL SELECT MAX(updated_at)::timestamptz, COUNT(*), 0 FROM grades WHERE college_id = $1
+            UNION ALL SELECT MAX(s.updated_at)::timestamptz, COUNT(*), 0 FROM assignment_submissions s
+                JOIN assignments a ON a.id = s.assignment_id
+                WHERE a.college_id = $1
+            UNION ALL SELECT MAX(updated_at)::timestamptz, COUNT(*), 0 FROM quiz_attempts WHERE college_id = $1
+            UNION ALL SELECT MAX(updated_at)::timestamptz, COUNT(*), 0 FROM exam_results WHERE college_id = $1
+            UNION ALL SELECT MAX(updated_at)::timestamptz, COUNT(*),
+                COUNT(*) FILTER (WHERE is_published = TRUE AND (expires_at IS NULL OR expires_at > NOW()))
+                FROM announcements WHERE college_id = $1
+            UNION ALL SELECT MAX(updated_at)::timestamptz, COUNT(*),
+                COUNT(*) FILTER (WHERE start_time >= NOW())
+                FROM calendar_events WHERE college_id = $1
+        ) versions`
+
+	version, err := s.dataVersion(ctx, fmt.Sprintf("dashboard:%d", collegeID), query, collegeID)
+	if err != nil {
+		return nil, fmt.Errorf("CollegeDashboardVersion: %w", err)
+	}
+	return version, nil
+}
+
+// dataVersion runs a version query returning the latest change, the number
+// of rows and the number of rows whose visibility depends on the clock, and
+// hashes them with scope into a weak ETag. Counting rows catches deletes,
+// which leave no updated_at behind.
+func (s *analyticsService) dataVersion(ctx context.Context, scope, query string, args ...any) (*DataVersion, error) {
+	var changedAt *time.Time
+	var rowCount, liveCount int64
+	if err := s.db.Pool.QueryRow(ctx, query, args...).Scan(&changedAt, &rowCount, &liveCount); err != nil {
+		return nil, fmt.Errorf("failed to read data version: %w", err)
+	}
+
+	version := &DataVersion{}
+	var changed int64
+	if changedAt != nil {
+		version.LastModified = changedAt.UTC()
+		changed = changedAt.UnixNano()
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%d|%d|%d", scope, changed, rowCount, liveCount)))
+	version.ETag = `W/"` + hex.EncodeToString(sum[:12]) + `"`
+	return version, nil
+}
@@ -0,0 +1,82 @@
+package analytics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/config"
+	"eduhub/server/internal/repository"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Any change to the rows, including a delete, or to the clock-dependent
+// counts must produce a new ETag
+func TestCollegeDashboardVersion(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	svc := &analyticsService{db: &repository.DB{Pool: mock}}
+	ctx := context.Background()
+	changed := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+
+	expect := func(rows, live int64) {
+		mock.ExpectQuery(`FROM calendar_events WHERE college_id = \$1`).
+			WithArgs(1).
+			WillReturnRows(pgxmock.NewRows([]string{"max", "rows", "live"}).AddRow(&changed, rows, live))
+	}
+	expect(40, 3)
+	expect(40, 3)
+	expect(39, 3)
+	expect(40, 2)
+
+	base, err := svc.CollegeDashboardVersion(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, changed, base.LastModified)
+	assert.Regexp(t, `^W/"[0-9a-f]{24}"$`, base.ETag)
+
+	same, err := svc.CollegeDashboardVersion(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, base.ETag, same.ETag)
+
+	deleted, err := svc.CollegeDashboardVersion(ctx, 1)
+	require.NoError(t, err)
+	assert.NotEqual(t, base.ETag, deleted.ETag)
+
+	expired, err := svc.CollegeDashboardVersion(ctx, 1)
+	require.NoError(t, err)
+	assert.NotEqual(t, base.ETag, expired.ETag)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// A course without any rows still gets an ETag but no Last-Modified, and
+// sections of the same course get different tags
+func TestCourseAnalyticsVersion(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	svc := &analyticsService{db: &repository.DB{Pool: mock}, riskConfig: &config.AnalyticsConfig{AttendanceRiskThreshold: 75, CourseGradeRiskThreshold: 60}}
+	ctx := context.Background()
+
+	for range 2 {
+		mock.ExpectQuery(`FROM quiz_attempts qa\s+JOIN quizzes q ON q.id = qa.quiz_id\s+WHERE qa.college_id = \$1 AND q.course_id = \$2`).
+			WithArgs(1, 4).
+			WillReturnRows(pgxmock.NewRows([]string{"max", "rows", "live"}).AddRow(nil, int64(0), int64(0)))
+	}
+
+	whole, err := svc.CourseAnalyticsVersion(ctx, 1, 4, nil)
+	require.NoError(t, err)
+	assert.True(t, whole.LastModified.IsZero())
+	assert.NotEmpty(t, whole.ETag)
+
+	section := 7
+	scoped, err := svc.CourseAnalyticsVersion(ctx, 1, 4, &section)
+	require.NoError(t, err)
+	assert.NotEqual(t, whole.ETag, scoped.ETag)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,136 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// FacultyCourseLoad is one course a faculty member teaches
+type FacultyCourseLoad struct {
+	CourseID         int    `json:"course_id"`
+	CourseName       string `json:"course_name"`
+	EnrolledStudents int    `json:"enrolled_students"`
+}
+
+// PendingGrading counts work submitted to a faculty member's courses that
+// still needs a grade
+type PendingGrading struct {
+	Assignments  int `json:"assignments"`
+	QuizAttempts int `json:"quiz_attempts"`
+	ExamResults  int `json:"exam_results"`
+	Total        int `json:"total"`
+}
+
+// FacultyWorkload summarises the teaching and grading load of one faculty member
+type FacultyWorkload struct {
+	FacultyID          int                 `json:"faculty_id"`
+	CoursesTaught      int                 `json:"courses_taught"`
+	Courses            []FacultyCourseLoad `json:"courses"`
+	TotalStudents      int                 `json:"total_students"`
+	AssignmentsCreated int                 `json:"assignments_created"`
+	QuizzesCreated     int                 `json:"quizzes_created"`
+	ExamsCreated       int                 `json:"exams_created"`
+	// AverageGradingHours is the mean time from assignment submission to its
+	// first grade; nil until something has been graded
+	AverageGradingHours *float64       `json:"average_grading_hours"`
+	PendingGrading      PendingGrading `json:"pending_grading"`
+}
+
+// GetFacultyWorkload reports the courses, students, assessments and grading
+// backlog of a faculty member, found through courses.instructor_id
+func (s *analyticsService) GetFacultyWorkload(ctx context.Context, collegeID, facultyID int) (*FacultyWorkload, error) {
+	workload := &FacultyWorkload{FacultyID: facultyID}
+
+	courses, err := s.facultyCourses(ctx, collegeID, facultyID)
+	if err != nil {
+		return nil, err
+	}
+	workload.Courses = courses
+	workload.CoursesTaught = len(courses)
+
+	// A student taking two of the faculty's courses is counted once
+	err = s.db.Pool.QueryRow(ctx, `SELECT COUNT(DISTINCT e.student_id)
+        FROM enrollments e
+        JOIN courses c ON c.id = e.course_id
+        WHERE c.college_id = $1 AND c.instructor_id = $2 AND e.college_id = $1`,
+		collegeID, facultyID).Scan(&workload.TotalStudents)
+	if err != nil {
+		return nil, fmt.Errorf("GetFacultyWorkload: failed to count students: %w", err)
+	}
+
+	err = s.db.Pool.QueryRow(ctx, `SELECT
+            (SELECT COUNT(*) FROM assignments a JOIN courses c ON c.id = a.course_id
+                WHERE c.college_id = $1 AND c.instructor_id = $2),
+            (SELECT COUNT(*) FROM quizzes q JOIN courses c ON c.id = q.course_id
+                WHERE c.college_id = $1 AND c.instructor_id = $2),
+            (SELECT COUNT(*) FROM exams x JOIN courses c ON c.id = x.course_id
+                WHERE c.college_id = $1 AND c.instructor_id = $2 AND x.deleted_at IS NULL)`,
+		collegeID, facultyID).Scan(&workload.AssignmentsCreated, &workload.QuizzesCreated, &workload.ExamsCreated)
+	if err != nil {
+		return nil, fmt.Errorf("GetFacultyWorkload: failed to count assessments: %w", err)
+	}
+
+	var avgHours sql.NullFloat64
+	err = s.db.Pool.QueryRow(ctx, `SELECT AVG(EXTRACT(EPOCH FROM (s.graded_at - s.submission_time)) / 3600)
+        FROM assignment_submissions s
+        JOIN assignments a ON a.id = s.assignment_id
+        JOIN courses c ON c.id = a.course_id
+        WHERE c.college_id = $1 AND c.instructor_id = $2 AND s.graded_at IS NOT NULL`,
+		collegeID, facultyID).Scan(&avgHours)
+	if err != nil {
+		return nil, fmt.Errorf("GetFacultyWorkload: failed to compute grading turnaround: %w", err)
+	}
+	if avgHours.Valid {
+		hours := roundFloat(avgHours.Float64, 2)
+		workload.AverageGradingHours = &hours
+	}
+
+	pending := &workload.PendingGrading
+	err = s.db.Pool.QueryRow(ctx, `SELECT
+            (SELECT COUNT(*) FROM assignment_submissions s
+                JOIN assignments a ON a.id = s.assignment_id
+                JOIN courses c ON c.id = a.course_id
+                WHERE c.college_id = $1 AND c.instructor_id = $2 AND s.grade IS NULL),
+            (SELECT COUNT(*) FROM quiz_attempts qa
+                JOIN quizzes q ON q.id = qa.quiz_id
+                JOIN courses c ON c.id = q.course_id
+                WHERE c.college_id = $1 AND c.instructor_id = $2 AND qa.status = 'submitted'),
+            (SELECT COUNT(*) FROM exam_results r
+                JOIN exams x ON x.id = r.exam_id
+                JOIN courses c ON c.id = x.course_id
+                WHERE c.college_id = $1 AND c.instructor_id = $2 AND x.deleted_at IS NULL
+                    AND r.result = 'pending' AND r.marks_obtained IS NULL)`,
+		collegeID, facultyID).Scan(&pending.Assignments, &pending.QuizAttempts, &pending.ExamResults)
+	if err != nil {
+		return nil, fmt.Errorf("GetFacultyWorkload: failed to count pending grading: %w", err)
+	}
+	pending.Total = pending.Assignments + pending.QuizAttempts + pending.ExamResults
+
+	return workload, nil
+}
+
+func (s *analyticsService) facultyCourses(ctx context.Context, collegeID, facultyID int) ([]FacultyCourseLoad, error) {
+	query := `SELECT c.id, c.name, COUNT(e.student_id)
+        FROM courses c
+        LEFT JOIN enrollments e ON e.course_id = c.id AND e.college_id = c.college_id
+        WHERE c.college_id = $1 AND c.instructor_id = $2
+        GROUP BY c.id, c.name
+        ORDER BY c.name`
+
+	rows, err := s.db.Pool.Query(ctx, query, collegeID, facultyID)
+	if err != nil {
+		return nil, fmt.Errorf("facultyCourses: query failed: %w", err)
+	}
+	defer rows.Close()
+
+	courses := make([]FacultyCourseLoad, 0)
+	for rows.Next() {
+		var course FacultyCourseLoad
+		if err := rows.Scan(&course.CourseID, &course.CourseName, &course.EnrolledStudents); err != nil {
+			return nil, fmt.Errorf("facultyCourses: scan failed: %w", err)
+		}
+		courses = append(courses, course)
+	}
+	return courses, rows.Err()
+}
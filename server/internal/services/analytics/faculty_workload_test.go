@@ -0,0 +1,71 @@
+package analytics
+
+import (
+	"context"
+	"testing"
+
+	"eduhub/server/internal/repository"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFacultyWorkload(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	svc := &analyticsService{db: &repository.DB{Pool: mock}}
+
+	mock.ExpectQuery(`(?s)FROM courses c\s+LEFT JOIN enrollments e .* WHERE c.college_id = \$1 AND c.instructor_id = \$2`).
+		WithArgs(1, 9).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "name", "count"}).
+			AddRow(3, "Chemistry", 25).
+			AddRow(2, "Physics", 30))
+	mock.ExpectQuery(`SELECT COUNT\(DISTINCT e.student_id\)`).
+		WithArgs(1, 9).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(48))
+	mock.ExpectQuery(`(?s)FROM assignments a .* FROM quizzes q .* FROM exams x`).
+		WithArgs(1, 9).
+		WillReturnRows(pgxmock.NewRows([]string{"assignments", "quizzes", "exams"}).AddRow(6, 4, 2))
+	mock.ExpectQuery(`AVG\(EXTRACT\(EPOCH FROM \(s.graded_at - s.submission_time\)\) / 3600\)`).
+		WithArgs(1, 9).
+		WillReturnRows(pgxmock.NewRows([]string{"avg"}).AddRow(36.456))
+	mock.ExpectQuery(`(?s)s.grade IS NULL.*qa.status = 'submitted'.*r.result = 'pending'`).
+		WithArgs(1, 9).
+		WillReturnRows(pgxmock.NewRows([]string{"assignments", "quizzes", "exams"}).AddRow(5, 2, 1))
+
+	workload, err := svc.GetFacultyWorkload(context.Background(), 1, 9)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, workload.CoursesTaught)
+	assert.Equal(t, 48, workload.TotalStudents, "students in both courses are counted once")
+	assert.Equal(t, 6, workload.AssignmentsCreated)
+	assert.Equal(t, 2, workload.ExamsCreated)
+	require.NotNil(t, workload.AverageGradingHours)
+	assert.Equal(t, 36.46, *workload.AverageGradingHours)
+	assert.Equal(t, 8, workload.PendingGrading.Total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetFacultyWorkloadNothingGraded(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	svc := &analyticsService{db: &repository.DB{Pool: mock}}
+
+	mock.ExpectQuery(`FROM courses c`).WithArgs(1, 9).WillReturnRows(pgxmock.NewRows([]string{"id", "name", "count"}))
+	mock.ExpectQuery(`COUNT\(DISTINCT`).WithArgs(1, 9).WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(`FROM assignments a`).WithArgs(1, 9).WillReturnRows(pgxmock.NewRows([]string{"a", "q", "e"}).AddRow(0, 0, 0))
+	mock.ExpectQuery(`AVG`).WithArgs(1, 9).WillReturnRows(pgxmock.NewRows([]string{"avg"}).AddRow(nil))
+	mock.ExpectQuery(`s.grade IS NULL`).WithArgs(1, 9).WillReturnRows(pgxmock.NewRows([]string{"a", "q", "e"}).AddRow(0, 0, 0))
+
+	workload, err := svc.GetFacultyWorkload(context.Background(), 1, 9)
+
+	require.NoError(t, err)
+	assert.Empty(t, workload.Courses)
+	assert.Nil(t, workload.AverageGradingHours)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,103 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	// ErrCourseNotFound is returned when a skill mapping names a course outside the college
+	ErrCourseNotFound = errors.New("course not found")
+	// ErrSkillMappingNotFound is returned when removing a mapping that does not exist
+	ErrSkillMappingNotFound = errors.New("skill mapping not found")
+	// ErrInvalidSkillName is returned for a blank or overlong skill name
+	ErrInvalidSkillName = errors.New("invalid skill name")
+)
+
+// maxSkillNameLength matches course_skill_mappings.skill_name
+const maxSkillNameLength = 100
+
+// CourseSkillMapping rolls a course up into a named skill for progression reports
+type CourseSkillMapping struct {
+	CourseID   int       `json:"course_id"`
+	CourseName string    `json:"course_name"`
+	Skill      string    `json:"skill"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ListCourseSkills returns every course-to-skill mapping in the college
+func (s *advancedAnalyticsService) ListCourseSkills(ctx context.Context, collegeID int) ([]CourseSkillMapping, error) {
+	rows, err := s.db.Pool.Query(ctx, `SELECT m.course_id, c.name, m.skill_name, m.updated_at
+        FROM course_skill_mappings m
+        JOIN courses c ON c.id = m.course_id
+        WHERE m.college_id = $1
+        ORDER BY m.skill_name, c.name`, collegeID)
+	if err != nil {
+		return nil, fmt.Errorf("ListCourseSkills: %w", err)
+	}
+	defer rows.Close()
+
+	mappings := make([]CourseSkillMapping, 0)
+	for rows.Next() {
+		var m CourseSkillMapping
+		if err := rows.Scan(&m.CourseID, &m.CourseName, &m.Skill, &m.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("ListCourseSkills: failed to scan mapping: %w", err)
+		}
+		mappings = append(mappings, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ListCourseSkills: %w", err)
+	}
+	return mappings, nil
+}
+
+// SetCourseSkill maps a course onto a skill, replacing any existing mapping
+func (s *advancedAnalyticsService) SetCourseSkill(ctx context.Context, collegeID, courseID int, skill string) (*CourseSkillMapping, error) {
+	skill = strings.TrimSpace(skill)
+	if skill == "" {
+		return nil, fmt.Errorf("%w: skill is required", ErrInvalidSkillName)
+	}
+	if len(skill) > maxSkillNameLength {
+		return nil, fmt.Errorf("%w: skill must be at most %d characters", ErrInvalidSkillName, maxSkillNameLength)
+	}
+
+	// The SELECT yields no row for a course in another college, so nothing is
+	// inserted and RETURNING reports ErrNoRows
+	mapping := &CourseSkillMapping{CourseID: courseID}
+	err := s.db.Pool.QueryRow(ctx, `WITH course AS (
+            SELECT id, name FROM courses WHERE id = $2 AND college_id = $1
+        ), upserted AS (
+            INSERT INTO course_skill_mappings (college_id, course_id, skill_name)
+            SELECT $1, id, $3 FROM course
+            ON CONFLICT (college_id, course_id)
+            DO UPDATE SET skill_name = EXCLUDED.skill_name, updated_at = CURRENT_TIMESTAMP
+            RETURNING skill_name, updated_at
+        )
+        SELECT course.name, upserted.skill_name, upserted.updated_at FROM course, upserted`,
+		collegeID, courseID, skill).Scan(&mapping.CourseName, &mapping.Skill, &mapping.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrCourseNotFound
+		}
+		return nil, fmt.Errorf("SetCourseSkill: %w", err)
+	}
+	return mapping, nil
+}
+
+// DeleteCourseSkill removes a course's mapping so it reports under its own name again
+func (s *advancedAnalyticsService) DeleteCourseSkill(ctx context.Context, collegeID, courseID int) error {
+	tag, err := s.db.Pool.Exec(ctx, `DELETE FROM course_skill_mappings WHERE college_id = $1 AND course_id = $2`,
+		collegeID, courseID)
+	if err != nil {
+		return fmt.Errorf("DeleteCourseSkill: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSkillMappingNotFound
+	}
+	return nil
+}
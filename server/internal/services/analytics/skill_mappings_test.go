@@ -0,0 +1,70 @@
+package analytics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/config"
+	"eduhub/server/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkillDevelopmentRollsUpMappedCourses(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	cfg := config.LoadAnalyticsConfig()
+	cfg.SkillLevelScale = 10
+	svc := &advancedAnalyticsService{db: &repository.DB{Pool: mock}, analyticsConfig: cfg}
+	month := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`(?s)COALESCE\(m.skill_name, c.name\).*LEFT JOIN course_skill_mappings m ON m.course_id = c.id`).
+		WithArgs(1, 7).
+		WillReturnRows(pgxmock.NewRows([]string{"skill_area", "month", "avg_score"}).
+			AddRow("Mathematics", month, 80.0).
+			AddRow("Art History", month, 65.0))
+
+	points, err := svc.getSkillDevelopment(context.Background(), 1, 7)
+
+	require.NoError(t, err)
+	require.Len(t, points, 2)
+	assert.Equal(t, "Mathematics", points[0].Skill)
+	assert.InDelta(t, 8.0, points[0].Level, 1e-9)
+	assert.InDelta(t, 6.5, points[1].Level, 1e-9)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSkillLevelDefaultScale(t *testing.T) {
+	cfg := config.LoadAnalyticsConfig()
+	assert.InDelta(t, 3.0, skillLevel(cfg, 75), 1e-9)
+	assert.InDelta(t, 4.0, skillLevel(cfg, 100), 1e-9)
+}
+
+func TestSetCourseSkill(t *testing.T) {
+	t.Run("rejects a blank skill", func(t *testing.T) {
+		svc := &advancedAnalyticsService{}
+		_, err := svc.SetCourseSkill(context.Background(), 1, 3, "   ")
+		assert.ErrorIs(t, err, ErrInvalidSkillName)
+	})
+
+	t.Run("course outside the college", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		svc := &advancedAnalyticsService{db: &repository.DB{Pool: mock}}
+		mock.ExpectQuery(`INSERT INTO course_skill_mappings`).
+			WithArgs(1, 3, "Mathematics").
+			WillReturnError(pgx.ErrNoRows)
+
+		_, err = svc.SetCourseSkill(context.Background(), 1, 3, " Mathematics ")
+		assert.ErrorIs(t, err, ErrCourseNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
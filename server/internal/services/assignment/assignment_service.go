@@ -8,6 +8,7 @@ import (
 
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/repository"
+	"eduhub/server/internal/services/notification"
 	"eduhub/server/internal/storage"
 )
 
@@ -18,7 +19,7 @@ type AssignmentService interface {
 	GetAssignmentsByStudent(ctx context.Context, collegeID, studentID int) ([]*models.Assignment, error)
 	UpdateAssignment(ctx context.Context, collegeID, assignmentID int, req *models.UpdateAssignmentRequest) error
 	DeleteAssignment(ctx context.Context, collegeID, assignmentID int) error
-	SubmitAssignment(ctx context.Context, submission *models.AssignmentSubmission) error
+	SubmitAssignment(ctx context.Context, collegeID int, submission *models.AssignmentSubmission) error
 	GradeSubmission(ctx context.Context, collegeID, submissionID int, grade *int, feedback *string) error
 	GetSubmissionByStudentAndAssignment(ctx context.Context, studentID, assignmentID int) (*models.AssignmentSubmission, error)
 	CountPendingSubmissionsByCollege(ctx context.Context, collegeID int) (int, error)
@@ -26,8 +27,15 @@ type AssignmentService interface {
 	// Enhanced grading features
 	BulkGradeSubmissions(ctx context.Context, collegeID int, grades map[int]*GradeInput) error
 	GetSubmissionsByAssignment(ctx context.Context, collegeID, assignmentID int) ([]*models.AssignmentSubmission, error)
+	// CalculateLatePenalty returns the percentage of the grade deducted under the assignment's late policy
 	CalculateLatePenalty(submission *models.AssignmentSubmission, assignment *models.Assignment) int
 	GetGradingStats(ctx context.Context, collegeID, assignmentID int) (*GradingStats, error)
+
+	// UploadSubmissionFile stores a submission file in object storage and records its key on the student's submission
+	UploadSubmissionFile(ctx context.Context, collegeID, courseID, assignmentID, studentID int, file *SubmissionFile) (*SubmissionUpload, error)
+
+	// NotifyDueSoon reminds students about assignments due within window that they haven't submitted
+	NotifyDueSoon(ctx context.Context, window time.Duration) (int, error)
 }
 
 // GradeInput represents grading input for a submission
@@ -48,13 +56,26 @@ type GradingStats struct {
 type assignmentService struct {
 	repo        repository.AssignmentRepository
 	minioClient *storage.MinioClient
+	store       submissionStore
+	uploadCfg   SubmissionUploadConfig
+	latePolicy  LatePolicy
+	// inbox receives due-soon reminders; nil disables them
+	inbox notification.Notifier
 }
 
-func NewAssignmentService(repo repository.AssignmentRepository, minioClient *storage.MinioClient) *assignmentService {
-	return &assignmentService{
+func NewAssignmentService(repo repository.AssignmentRepository, minioClient *storage.MinioClient, uploadCfg SubmissionUploadConfig, latePolicy LatePolicy, inbox notification.Notifier) *assignmentService {
+	svc := &assignmentService{
 		repo:        repo,
 		minioClient: minioClient,
+		uploadCfg:   uploadCfg.withDefaults(),
+		latePolicy:  latePolicy.withDefaults(),
+		inbox:       inbox,
+	}
+	// Leave store nil rather than wrapping a nil client so uploads report storage as unconfigured
+	if minioClient != nil {
+		svc.store = minioClient
 	}
+	return svc
 }
 
 func (a *assignmentService) CreateAssignment(ctx context.Context, assignment *models.Assignment) error {
@@ -64,6 +85,9 @@ func (a *assignmentService) CreateAssignment(ctx context.Context, assignment *mo
 	if assignment.CourseID == 0 || assignment.CollegeID == 0 {
 		return errors.New("courseID or collegeID cannot be 0")
 	}
+	if err := validateLatePolicy(assignment.LatePenaltyPolicy, assignment.LatePenaltyPercent, assignment.LatePenaltyMaxPercent, &assignment.DueDate, assignment.LateCutoffAt); err != nil {
+		return err
+	}
 	return a.repo.CreateAssignment(ctx, assignment)
 }
 func (a *assignmentService) GetAssignment(ctx context.Context, collegeID, assignmentID int) (*models.Assignment, error) {
@@ -88,6 +112,10 @@ func (a *assignmentService) UpdateAssignment(ctx context.Context, collegeID, ass
 	if collegeID == 0 || assignmentID == 0 {
 		return errors.New("invalid collegeID or assignmentID")
 	}
+	if err := validateLatePolicy(req.LatePenaltyPolicy, req.LatePenaltyPercent, req.LatePenaltyMaxPercent, req.DueDate, req.LateCutoffAt); err != nil {
+		return err
+	}
+	req.ID = &assignmentID
 	return a.repo.UpdateAssignmentPartial(ctx, collegeID, req)
 }
 
@@ -95,14 +123,29 @@ func (a *assignmentService) DeleteAssignment(ctx context.Context, collegeID, ass
 	return a.repo.DeleteAssignment(ctx, collegeID, assignmentID)
 }
 
-func (a *assignmentService) SubmitAssignment(ctx context.Context, submission *models.AssignmentSubmission) error {
+func (a *assignmentService) SubmitAssignment(ctx context.Context, collegeID int, submission *models.AssignmentSubmission) error {
 	if submission.AssignmentID == 0 || submission.StudentID == 0 {
 		return errors.New("assignmentID and studentID are required")
 	}
+
+	assignment, err := a.repo.GetAssignmentByID(ctx, collegeID, submission.AssignmentID)
+	if err != nil {
+		return err
+	}
+	if assignment == nil {
+		return ErrAssignmentNotFound
+	}
+
 	submission.SubmissionTime = time.Now()
+	submission.IsLate, err = a.checkSubmissionWindow(assignment, submission.SubmissionTime)
+	if err != nil {
+		return err
+	}
 	return a.repo.CreateSubmission(ctx, submission)
 }
 
+// GradeSubmission records the grader's mark. A late submission's grade is the
+// mark less the late penalty; the mark itself is kept as the raw grade.
 func (a *assignmentService) GradeSubmission(ctx context.Context, collegeID, submissionID int, grade *int, feedback *string) error {
 	submission, err := a.repo.GetSubmissionByID(ctx, submissionID)
 	if err != nil {
@@ -110,7 +153,15 @@ func (a *assignmentService) GradeSubmission(ctx context.Context, collegeID, subm
 	}
 
 	if grade != nil {
-		submission.Grade = grade
+		// Looking the assignment up by college also keeps graders to their own college
+		assignment, err := a.repo.GetAssignmentByID(ctx, collegeID, submission.AssignmentID)
+		if err != nil {
+			return err
+		}
+		if assignment == nil {
+			return ErrAssignmentNotFound
+		}
+		a.applyLatePenalty(submission, assignment, *grade)
 	}
 	if feedback != nil {
 		submission.Feedback = feedback
@@ -154,18 +205,7 @@ func (a *assignmentService) GetSubmissionsByAssignment(ctx context.Context, coll
 
 // CalculateLatePenalty calculates grade penalty for late submissions
 func (a *assignmentService) CalculateLatePenalty(submission *models.AssignmentSubmission, assignment *models.Assignment) int {
-	if submission.SubmissionTime.Before(assignment.DueDate) {
-		return 0 // Not late
-	}
-
-	// Calculate hours late
-	hoursLate := int(submission.SubmissionTime.Sub(assignment.DueDate).Hours())
-
-	// Default penalty: 10% per day, max 50%
-	daysLate := (hoursLate / 24) + 1
-	penalty := min(daysLate*10, 50)
-
-	return penalty
+	return a.latePolicy.forAssignment(assignment).penaltyPercent(submission.SubmissionTime, assignment.DueDate)
 }
 
 func (a *assignmentService) GetGradingStats(ctx context.Context, collegeID, assignmentID int) (*GradingStats, error) {
@@ -204,3 +244,50 @@ func (a *assignmentService) GetGradingStats(ctx context.Context, collegeID, assi
 
 	return stats, nil
 }
+
+// NotifyDueSoon sends an in-app reminder for every assignment falling due in the
+// next window to the enrolled students who haven't submitted. Each assignment
+// is claimed before notifying, so it is only ever announced once; it returns
+// how many assignments were announced. An assignment whose recipients can't be
+// loaded has its claim released so the next run retries it.
+func (a *assignmentService) NotifyDueSoon(ctx context.Context, window time.Duration) (int, error) {
+	if a.inbox == nil {
+		return 0, nil
+	}
+	if window <= 0 {
+		return 0, fmt.Errorf("reminder window must be positive")
+	}
+
+	assignments, err := a.repo.ClaimAssignmentsDueBefore(ctx, time.Now().Add(window))
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	announced := 0
+	for _, assignment := range assignments {
+		userIDs, err := a.repo.ListReminderUserIDs(ctx, assignment)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list reminder recipients of assignment %d: %w", assignment.ID, err))
+			if err := a.repo.ReleaseAssignmentReminder(ctx, assignment.ID); err != nil {
+				errs = append(errs, fmt.Errorf("failed to release reminder claim of assignment %d: %w", assignment.ID, err))
+			}
+			continue
+		}
+		announced++
+		if len(userIDs) == 0 {
+			continue
+		}
+
+		entityType := models.NotificationEntityAssignment
+		assignmentID := assignment.ID
+		a.inbox.Notify(ctx, assignment.CollegeID, userIDs, &models.Notification{
+			Title:             fmt.Sprintf("Assignment due soon: %s", assignment.Title),
+			Message:           fmt.Sprintf("%s is due %s. You haven't submitted it yet.", assignment.Title, assignment.DueDate.Format("Mon, 02 Jan 15:04 MST")),
+			Type:              "warning",
+			RelatedEntityType: &entityType,
+			RelatedEntityID:   &assignmentID,
+		})
+	}
+	return announced, errors.Join(errs...)
+}
@@ -0,0 +1,127 @@
+package assignment
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"eduhub/server/internal/models"
+)
+
+var (
+	ErrSubmissionClosed  = errors.New("the submission deadline for this assignment has passed")
+	ErrInvalidLatePolicy = errors.New("invalid late penalty policy")
+)
+
+// LatePolicy is the default late submission policy; each assignment may
+// override any part of it. A zero LatePolicy deducts 10% per started day late,
+// capped at 50%, and never closes submissions.
+type LatePolicy struct {
+	Policy      string // one of models.LatePenalty*
+	Percent     int    // deducted once (flat) or per started day late (per_day)
+	MaxPercent  int    // cap on the total deduction; 0 means 100
+	CutoffAfter time.Duration
+}
+
+func (p LatePolicy) withDefaults() LatePolicy {
+	if p.Policy == "" {
+		p.Policy = models.LatePenaltyPerDay
+		p.Percent = 10
+		p.MaxPercent = 50
+	}
+	if p.MaxPercent <= 0 {
+		p.MaxPercent = 100
+	}
+	return p
+}
+
+// forAssignment applies the assignment's overrides to the default policy
+func (p LatePolicy) forAssignment(assignment *models.Assignment) LatePolicy {
+	if assignment.LatePenaltyPolicy != nil {
+		p.Policy = *assignment.LatePenaltyPolicy
+	}
+	if assignment.LatePenaltyPercent != nil {
+		p.Percent = *assignment.LatePenaltyPercent
+	}
+	if assignment.LatePenaltyMaxPercent != nil {
+		p.MaxPercent = *assignment.LatePenaltyMaxPercent
+	}
+	return p
+}
+
+// penaltyPercent is the percentage of the grade deducted for work submitted at submittedAt
+func (p LatePolicy) penaltyPercent(submittedAt, dueDate time.Time) int {
+	if !submittedAt.After(dueDate) {
+		return 0
+	}
+
+	var percent int
+	switch p.Policy {
+	case models.LatePenaltyFlat:
+		percent = p.Percent
+	case models.LatePenaltyPerDay:
+		daysLate := int(submittedAt.Sub(dueDate)/(24*time.Hour)) + 1
+		percent = daysLate * p.Percent
+	default:
+		return 0
+	}
+	return min(percent, p.MaxPercent, 100)
+}
+
+// submissionCutoff returns when the assignment stops accepting submissions.
+// The assignment's own cutoff wins over the default grace period.
+func (p LatePolicy) submissionCutoff(assignment *models.Assignment) (time.Time, bool) {
+	if assignment.LateCutoffAt != nil {
+		return *assignment.LateCutoffAt, true
+	}
+	if p.CutoffAfter > 0 {
+		return assignment.DueDate.Add(p.CutoffAfter), true
+	}
+	return time.Time{}, false
+}
+
+// checkSubmissionWindow rejects work submitted after the cutoff and reports
+// whether it is late
+func (a *assignmentService) checkSubmissionWindow(assignment *models.Assignment, submittedAt time.Time) (bool, error) {
+	if cutoff, ok := a.latePolicy.submissionCutoff(assignment); ok && submittedAt.After(cutoff) {
+		return false, fmt.Errorf("%w (closed %s)", ErrSubmissionClosed, cutoff.Format(time.RFC3339))
+	}
+	return submittedAt.After(assignment.DueDate), nil
+}
+
+// applyLatePenalty records rawGrade on the submission and sets its grade to
+// rawGrade less the late penalty, rounded to the nearest point
+func (a *assignmentService) applyLatePenalty(submission *models.AssignmentSubmission, assignment *models.Assignment, rawGrade int) {
+	percent := a.CalculateLatePenalty(submission, assignment)
+	deduction := 0
+	if rawGrade > 0 {
+		deduction = (rawGrade*percent + 50) / 100
+	}
+	grade := rawGrade - deduction
+
+	submission.IsLate = submission.SubmissionTime.After(assignment.DueDate)
+	submission.RawGrade = &rawGrade
+	submission.LatePenalty = &deduction
+	submission.Grade = &grade
+}
+
+// validateLatePolicy checks per-assignment overrides before they are stored
+func validateLatePolicy(policy *string, percent, maxPercent *int, dueDate, cutoff *time.Time) error {
+	if policy != nil {
+		switch *policy {
+		case models.LatePenaltyNone, models.LatePenaltyFlat, models.LatePenaltyPerDay:
+		default:
+			return fmt.Errorf("%w: policy must be none, flat or per_day", ErrInvalidLatePolicy)
+		}
+	}
+	if percent != nil && (*percent < 0 || *percent > 100) {
+		return fmt.Errorf("%w: late_penalty_percent must be between 0 and 100", ErrInvalidLatePolicy)
+	}
+	if maxPercent != nil && (*maxPercent < 0 || *maxPercent > 100) {
+		return fmt.Errorf("%w: late_penalty_max_percent must be between 0 and 100", ErrInvalidLatePolicy)
+	}
+	if dueDate != nil && cutoff != nil && cutoff.Before(*dueDate) {
+		return fmt.Errorf("%w: late_cutoff_at cannot be before the due date", ErrInvalidLatePolicy)
+	}
+	return nil
+}
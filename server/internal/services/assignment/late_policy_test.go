@@ -0,0 +1,119 @@
+package assignment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type gradingRepo struct {
+	repository.AssignmentRepository
+	assignment *models.Assignment
+	submission *models.AssignmentSubmission
+	created    *models.AssignmentSubmission
+	updated    *models.AssignmentSubmission
+}
+
+func (r *gradingRepo) GetAssignmentByID(ctx context.Context, collegeID, assignmentID int) (*models.Assignment, error) {
+	return r.assignment, nil
+}
+
+func (r *gradingRepo) GetSubmissionByID(ctx context.Context, submissionID int) (*models.AssignmentSubmission, error) {
+	return r.submission, nil
+}
+
+func (r *gradingRepo) CreateSubmission(ctx context.Context, submission *models.AssignmentSubmission) error {
+	r.created = submission
+	return nil
+}
+
+func (r *gradingRepo) UpdateSubmission(ctx context.Context, submission *models.AssignmentSubmission) error {
+	r.updated = submission
+	return nil
+}
+
+func TestCalculateLatePenalty(t *testing.T) {
+	due := time.Date(2026, 3, 1, 17, 0, 0, 0, time.UTC)
+	flat := models.LatePenaltyFlat
+	none := models.LatePenaltyNone
+	twenty := 20
+
+	tests := []struct {
+		name       string
+		policy     LatePolicy
+		assignment *models.Assignment
+		submitted  time.Time
+		want       int
+	}{
+		{"on time", LatePolicy{}, &models.Assignment{DueDate: due}, due, 0},
+		{"first day late", LatePolicy{}, &models.Assignment{DueDate: due}, due.Add(time.Minute), 10},
+		{"third day late", LatePolicy{}, &models.Assignment{DueDate: due}, due.Add(50 * time.Hour), 30},
+		{"per day is capped", LatePolicy{}, &models.Assignment{DueDate: due}, due.Add(30 * 24 * time.Hour), 50},
+		{"flat ignores how late", LatePolicy{Policy: models.LatePenaltyFlat, Percent: 15}, &models.Assignment{DueDate: due}, due.Add(72 * time.Hour), 15},
+		{"assignment overrides policy", LatePolicy{}, &models.Assignment{DueDate: due, LatePenaltyPolicy: &flat, LatePenaltyPercent: &twenty}, due.Add(72 * time.Hour), 20},
+		{"assignment waives penalty", LatePolicy{}, &models.Assignment{DueDate: due, LatePenaltyPolicy: &none}, due.Add(72 * time.Hour), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewAssignmentService(&gradingRepo{}, nil, SubmissionUploadConfig{}, tt.policy, nil)
+			got := svc.CalculateLatePenalty(&models.AssignmentSubmission{SubmissionTime: tt.submitted}, tt.assignment)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGradeSubmission_AppliesLatePenalty(t *testing.T) {
+	due := time.Now().Add(-36 * time.Hour)
+	repo := &gradingRepo{
+		assignment: &models.Assignment{ID: 3, CollegeID: 1, DueDate: due},
+		submission: &models.AssignmentSubmission{ID: 8, AssignmentID: 3, SubmissionTime: due.Add(30 * time.Hour)},
+	}
+	svc := NewAssignmentService(repo, nil, SubmissionUploadConfig{}, LatePolicy{}, nil)
+
+	grade := 85
+	require.NoError(t, svc.GradeSubmission(context.Background(), 1, 8, &grade, nil))
+
+	// Two started days late at 10% a day
+	require.NotNil(t, repo.updated)
+	assert.True(t, repo.updated.IsLate)
+	assert.Equal(t, 85, *repo.updated.RawGrade)
+	assert.Equal(t, 17, *repo.updated.LatePenalty)
+	assert.Equal(t, 68, *repo.updated.Grade)
+}
+
+func TestSubmitAssignment_Cutoff(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("late submission before the cutoff is accepted and flagged", func(t *testing.T) {
+		repo := &gradingRepo{assignment: &models.Assignment{ID: 3, DueDate: time.Now().Add(-time.Hour)}}
+		svc := NewAssignmentService(repo, nil, SubmissionUploadConfig{}, LatePolicy{CutoffAfter: 48 * time.Hour}, nil)
+
+		require.NoError(t, svc.SubmitAssignment(ctx, 1, &models.AssignmentSubmission{AssignmentID: 3, StudentID: 5}))
+		assert.True(t, repo.created.IsLate)
+	})
+
+	t.Run("submission after the default cutoff is rejected", func(t *testing.T) {
+		repo := &gradingRepo{assignment: &models.Assignment{ID: 3, DueDate: time.Now().Add(-72 * time.Hour)}}
+		svc := NewAssignmentService(repo, nil, SubmissionUploadConfig{}, LatePolicy{CutoffAfter: 48 * time.Hour}, nil)
+
+		err := svc.SubmitAssignment(ctx, 1, &models.AssignmentSubmission{AssignmentID: 3, StudentID: 5})
+		assert.ErrorIs(t, err, ErrSubmissionClosed)
+		assert.Nil(t, repo.created)
+	})
+
+	t.Run("assignment cutoff overrides the default", func(t *testing.T) {
+		cutoff := time.Now().Add(-time.Minute)
+		repo := &gradingRepo{assignment: &models.Assignment{ID: 3, DueDate: time.Now().Add(-time.Hour), LateCutoffAt: &cutoff}}
+		svc := NewAssignmentService(repo, nil, SubmissionUploadConfig{}, LatePolicy{}, nil)
+
+		err := svc.SubmitAssignment(ctx, 1, &models.AssignmentSubmission{AssignmentID: 3, StudentID: 5})
+		assert.ErrorIs(t, err, ErrSubmissionClosed)
+	})
+}
@@ -0,0 +1,110 @@
+package assignment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type reminderRepo struct {
+	repository.AssignmentRepository
+	due       []*models.Assignment
+	pending   map[int][]int
+	claimedTo time.Time
+	failFor   map[int]bool
+	released  []int
+}
+
+func (r *reminderRepo) ClaimAssignmentsDueBefore(ctx context.Context, until time.Time) ([]*models.Assignment, error) {
+	r.claimedTo = until
+	return r.due, nil
+}
+
+func (r *reminderRepo) ListReminderUserIDs(ctx context.Context, assignment *models.Assignment) ([]int, error) {
+	if r.failFor[assignment.ID] {
+		return nil, errors.New("connection reset")
+	}
+	return r.pending[assignment.ID], nil
+}
+
+func (r *reminderRepo) ReleaseAssignmentReminder(ctx context.Context, assignmentID int) error {
+	r.released = append(r.released, assignmentID)
+	return nil
+}
+
+type recordingInbox struct {
+	userIDs       [][]int
+	notifications []*models.Notification
+}
+
+func (i *recordingInbox) Notify(ctx context.Context, collegeID int, userIDs []int, notification *models.Notification) {
+	i.userIDs = append(i.userIDs, userIDs)
+	i.notifications = append(i.notifications, notification)
+}
+
+func TestNotifyDueSoon(t *testing.T) {
+	due := time.Now().Add(6 * time.Hour)
+	repo := &reminderRepo{
+		due: []*models.Assignment{
+			{ID: 1, CollegeID: 2, CourseID: 3, Title: "Lab report", DueDate: due},
+			{ID: 4, CollegeID: 2, CourseID: 3, Title: "Essay", DueDate: due},
+		},
+		// Everyone already submitted the essay
+		pending: map[int][]int{1: {10, 11}},
+	}
+	inbox := &recordingInbox{}
+	svc := NewAssignmentService(repo, nil, SubmissionUploadConfig{}, LatePolicy{}, inbox)
+
+	announced, err := svc.NotifyDueSoon(context.Background(), 24*time.Hour)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, announced)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), repo.claimedTo, time.Minute)
+	require.Len(t, inbox.notifications, 1)
+	assert.Equal(t, []int{10, 11}, inbox.userIDs[0])
+	assert.Equal(t, "Assignment due soon: Lab report", inbox.notifications[0].Title)
+	assert.Equal(t, models.NotificationEntityAssignment, *inbox.notifications[0].RelatedEntityType)
+	assert.Equal(t, 1, *inbox.notifications[0].RelatedEntityID)
+}
+
+func TestNotifyDueSoon_RecipientLookupFailure(t *testing.T) {
+	due := time.Now().Add(6 * time.Hour)
+	repo := &reminderRepo{
+		due: []*models.Assignment{
+			{ID: 1, CollegeID: 2, CourseID: 3, Title: "Lab report", DueDate: due},
+			{ID: 4, CollegeID: 2, CourseID: 3, Title: "Essay", DueDate: due},
+			{ID: 7, CollegeID: 2, CourseID: 5, Title: "Problem set", DueDate: due},
+		},
+		pending: map[int][]int{1: {10}, 7: {12}},
+		failFor: map[int]bool{4: true},
+	}
+	inbox := &recordingInbox{}
+	svc := NewAssignmentService(repo, nil, SubmissionUploadConfig{}, LatePolicy{}, inbox)
+
+	announced, err := svc.NotifyDueSoon(context.Background(), 24*time.Hour)
+
+	// The failure doesn't stop the rest of the batch, and its claim is
+	// released so the next run retries it
+	require.Error(t, err)
+	assert.Equal(t, 2, announced)
+	assert.Equal(t, []int{4}, repo.released)
+	require.Len(t, inbox.notifications, 2)
+	assert.Equal(t, "Assignment due soon: Problem set", inbox.notifications[1].Title)
+}
+
+func TestNotifyDueSoon_WithoutInbox(t *testing.T) {
+	// The embedded nil repository would panic if anything were claimed
+	svc := NewAssignmentService(&reminderRepo{}, nil, SubmissionUploadConfig{}, LatePolicy{}, nil)
+
+	announced, err := svc.NotifyDueSoon(context.Background(), time.Hour)
+
+	require.NoError(t, err)
+	assert.Zero(t, announced)
+}
@@ -0,0 +1,256 @@
+package assignment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"eduhub/server/internal/config"
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/services/filescan"
+)
+
+var (
+	ErrStorageNotConfigured = errors.New("file storage is not configured")
+	ErrAssignmentNotFound   = errors.New("assignment not found")
+	ErrSubmissionFileEmpty  = errors.New("submission file is empty")
+	ErrSubmissionTooLarge   = errors.New("submission file exceeds the size limit")
+	ErrSubmissionFileType   = errors.New("submission file type is not allowed")
+	ErrSubmissionRejected   = errors.New("submission file was rejected by the file scanner")
+	ErrNotEnrolledInCourse  = errors.New("student is not enrolled in the assignment's course")
+)
+
+const (
+	defaultSubmissionMaxSize   = 25 << 20
+	defaultSubmissionURLExpiry = 3600
+	maxSubmissionFileNameLen   = 100
+)
+
+// SubmissionUploadConfig limits what students may upload for an assignment.
+// Zero values fall back to a 25 MiB limit, config.DefaultSubmissionMIMETypes,
+// one-hour download links and no scanning.
+type SubmissionUploadConfig struct {
+	MaxSizeBytes     int64
+	AllowedTypes     []string
+	URLExpirySeconds int64
+	Scanner          filescan.FileScanner
+}
+
+func (c SubmissionUploadConfig) withDefaults() SubmissionUploadConfig {
+	if c.MaxSizeBytes <= 0 {
+		c.MaxSizeBytes = defaultSubmissionMaxSize
+	}
+	if len(c.AllowedTypes) == 0 {
+		c.AllowedTypes = config.DefaultSubmissionMIMETypes
+	}
+	if c.URLExpirySeconds <= 0 {
+		c.URLExpirySeconds = defaultSubmissionURLExpiry
+	}
+	if c.Scanner == nil {
+		c.Scanner = filescan.NoopScanner{}
+	}
+	return c
+}
+
+// SubmissionFile is a file received from the client for an assignment submission.
+// Reader must be seekable because the file is read again for scanning after upload.
+type SubmissionFile struct {
+	Name        string
+	ContentType string
+	Size        int64
+	Reader      io.ReadSeeker
+}
+
+// SubmissionUpload describes a stored submission file
+type SubmissionUpload struct {
+	Submission       *models.AssignmentSubmission `json:"submission"`
+	ObjectKey        string                       `json:"object_key"`
+	DownloadURL      string                       `json:"download_url"`
+	URLExpirySeconds int64                        `json:"url_expiry_seconds"`
+}
+
+// submissionStore is the subset of the Minio client used for submission uploads
+type submissionStore interface {
+	UploadFromReader(ctx context.Context, reader io.Reader, bucketName, objectName string, size int64, contentType string) error
+	GetPresignedURL(ctx context.Context, bucketName, objectName string, expirySeconds int64) (string, error)
+	Remove(ctx context.Context, bucketName, objectName string) error
+	GetBucketName() string
+}
+
+func (a *assignmentService) UploadSubmissionFile(ctx context.Context, collegeID, courseID, assignmentID, studentID int, file *SubmissionFile) (*SubmissionUpload, error) {
+	if a.store == nil {
+		return nil, ErrStorageNotConfigured
+	}
+	if collegeID == 0 || courseID == 0 || assignmentID == 0 || studentID == 0 {
+		return nil, errors.New("collegeID, courseID, assignmentID and studentID are required")
+	}
+	if file == nil || file.Size <= 0 {
+		return nil, ErrSubmissionFileEmpty
+	}
+	if file.Size > a.uploadCfg.MaxSizeBytes {
+		return nil, fmt.Errorf("%w of %d MiB", ErrSubmissionTooLarge, a.uploadCfg.MaxSizeBytes>>20)
+	}
+
+	contentType, err := detectSubmissionType(file, a.uploadCfg.AllowedTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment, err := a.repo.GetAssignmentByID(ctx, collegeID, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment == nil || assignment.CourseID != courseID {
+		return nil, ErrAssignmentNotFound
+	}
+	enrolled, err := a.repo.IsStudentActivelyEnrolled(ctx, collegeID, studentID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	if !enrolled {
+		return nil, ErrNotEnrolledInCourse
+	}
+
+	now := time.Now()
+	isLate, err := a.checkSubmissionWindow(assignment, now)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket := a.store.GetBucketName()
+	key := submissionObjectKey(collegeID, courseID, assignmentID, studentID, now, file.Name)
+	if err := a.store.UploadFromReader(ctx, file.Reader, bucket, key, file.Size, contentType); err != nil {
+		return nil, fmt.Errorf("failed to upload submission file: %w", err)
+	}
+
+	// The submission only points at the object once the scanner has cleared it
+	if err := a.scanSubmissionFile(ctx, key, contentType, file); err != nil {
+		a.removeObject(bucket, key)
+		return nil, err
+	}
+
+	// Re-uploading replaces the file but must not drop text the student already submitted
+	existing, err := a.repo.GetSubmissionByStudentAndAssignment(ctx, studentID, assignmentID)
+	if err != nil {
+		a.removeObject(bucket, key)
+		return nil, err
+	}
+	submission := &models.AssignmentSubmission{
+		AssignmentID:   assignmentID,
+		StudentID:      studentID,
+		SubmissionTime: now,
+		FilePath:       &key,
+		IsLate:         isLate,
+	}
+	if existing != nil {
+		submission.ContentText = existing.ContentText
+	}
+	if err := a.repo.CreateSubmission(ctx, submission); err != nil {
+		a.removeObject(bucket, key)
+		return nil, err
+	}
+
+	url, err := a.store.GetPresignedURL(ctx, bucket, key, a.uploadCfg.URLExpirySeconds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate download URL: %w", err)
+	}
+
+	return &SubmissionUpload{
+		Submission:       submission,
+		ObjectKey:        key,
+		DownloadURL:      url,
+		URLExpirySeconds: a.uploadCfg.URLExpirySeconds,
+	}, nil
+}
+
+// detectSubmissionType sniffs the file's type from its first bytes instead
+// of trusting the Content-Type the client sent. The declared type is kept
+// when it is allowed and the content matches it, since DOCX and PPTX files
+// sniff as ZIP; otherwise the sniffed type itself must be allowed.
+func detectSubmissionType(file *SubmissionFile, allowed []string) (string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file.Reader, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", fmt.Errorf("failed to read submission file: %w", err)
+	}
+	if _, err := file.Reader.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to rewind submission file: %w", err)
+	}
+
+	sniffed, _, err := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	if err != nil {
+		return "", fmt.Errorf("%w: unrecognised content", ErrSubmissionFileType)
+	}
+	if declared, _, err := mime.ParseMediaType(file.ContentType); err == nil {
+		declared = strings.ToLower(declared)
+		if slices.Contains(allowed, declared) && filescan.Matches(declared, sniffed) {
+			return declared, nil
+		}
+	}
+	if slices.Contains(allowed, sniffed) {
+		return sniffed, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrSubmissionFileType, sniffed)
+}
+
+func (a *assignmentService) scanSubmissionFile(ctx context.Context, key, contentType string, file *SubmissionFile) error {
+	if _, err := file.Reader.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind submission file for scanning: %w", err)
+	}
+
+	result, err := a.uploadCfg.Scanner.Scan(ctx, filescan.Object{
+		Key:         key,
+		Name:        file.Name,
+		ContentType: contentType,
+		Size:        file.Size,
+		Reader:      file.Reader,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan submission file: %w", err)
+	}
+	if !result.Clean {
+		return fmt.Errorf("%w: %s", ErrSubmissionRejected, result.Reason)
+	}
+	return nil
+}
+
+// removeObject deletes an uploaded object that could not be attached to a submission.
+// It uses a fresh context because the request context may already be cancelled.
+func (a *assignmentService) removeObject(bucket, key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_ = a.store.Remove(ctx, bucket, key)
+}
+
+// submissionObjectKey builds collegeID/courseID/assignmentID/studentID/<unix>_<name>.
+// The timestamp keeps earlier uploads addressable when a student resubmits.
+func submissionObjectKey(collegeID, courseID, assignmentID, studentID int, now time.Time, name string) string {
+	return fmt.Sprintf("%d/%d/%d/%d/%d_%s", collegeID, courseID, assignmentID, studentID, now.Unix(), sanitizeFileName(name))
+}
+
+func sanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	cleaned := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+	cleaned = strings.Trim(cleaned, ".")
+	if cleaned == "" {
+		return "file"
+	}
+	if len(cleaned) > maxSubmissionFileNameLen {
+		cleaned = cleaned[len(cleaned)-maxSubmissionFileNameLen:]
+	}
+	return cleaned
+}
@@ -0,0 +1,208 @@
+package assignment
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+	"eduhub/server/internal/services/filescan"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAssignmentRepo struct {
+	repository.AssignmentRepository
+	assignment  *models.Assignment
+	existing    *models.AssignmentSubmission
+	created     *models.AssignmentSubmission
+	createErr   error
+	notEnrolled bool
+}
+
+func (r *fakeAssignmentRepo) IsStudentActivelyEnrolled(ctx context.Context, collegeID, studentID, courseID int) (bool, error) {
+	return !r.notEnrolled, nil
+}
+
+func (r *fakeAssignmentRepo) GetAssignmentByID(ctx context.Context, collegeID, assignmentID int) (*models.Assignment, error) {
+	return r.assignment, nil
+}
+
+func (r *fakeAssignmentRepo) GetSubmissionByStudentAndAssignment(ctx context.Context, studentID, assignmentID int) (*models.AssignmentSubmission, error) {
+	return r.existing, nil
+}
+
+func (r *fakeAssignmentRepo) CreateSubmission(ctx context.Context, submission *models.AssignmentSubmission) error {
+	if r.createErr != nil {
+		return r.createErr
+	}
+	submission.ID = 99
+	r.created = submission
+	return nil
+}
+
+type fakeSubmissionStore struct {
+	uploaded    map[string]string
+	removed     []string
+	contentType string
+	expiry      int64
+}
+
+func (s *fakeSubmissionStore) UploadFromReader(ctx context.Context, reader io.Reader, bucketName, objectName string, size int64, contentType string) error {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	s.uploaded[objectName] = string(data)
+	s.contentType = contentType
+	return nil
+}
+
+func (s *fakeSubmissionStore) GetPresignedURL(ctx context.Context, bucketName, objectName string, expirySeconds int64) (string, error) {
+	s.expiry = expirySeconds
+	return "https://storage.example.com/" + bucketName + "/" + objectName + "?sig", nil
+}
+
+func (s *fakeSubmissionStore) Remove(ctx context.Context, bucketName, objectName string) error {
+	s.removed = append(s.removed, objectName)
+	return nil
+}
+
+func (s *fakeSubmissionStore) GetBucketName() string { return "eduhub" }
+
+type rejectingScanner struct{}
+
+func (rejectingScanner) Scan(ctx context.Context, obj filescan.Object) (filescan.Result, error) {
+	if _, err := io.ReadAll(obj.Reader); err != nil {
+		return filescan.Result{}, err
+	}
+	return filescan.Result{Reason: "infected"}, nil
+}
+
+func newUploadTestService(repo *fakeAssignmentRepo) (*assignmentService, *fakeSubmissionStore) {
+	store := &fakeSubmissionStore{uploaded: map[string]string{}}
+	svc := NewAssignmentService(repo, nil, SubmissionUploadConfig{
+		MaxSizeBytes:     1024,
+		AllowedTypes:     []string{"application/pdf"},
+		URLExpirySeconds: 600,
+	}, LatePolicy{}, nil)
+	svc.store = store
+	return svc, store
+}
+
+func pdf(name, body string) *SubmissionFile {
+	return &SubmissionFile{Name: name, ContentType: "application/pdf", Size: int64(len(body)), Reader: strings.NewReader(body)}
+}
+
+func TestUploadSubmissionFile(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stores the file under the submission key and records it", func(t *testing.T) {
+		text := "see attached"
+		repo := &fakeAssignmentRepo{
+			assignment: &models.Assignment{ID: 3, CourseID: 2, CollegeID: 1},
+			existing:   &models.AssignmentSubmission{ID: 99, ContentText: &text},
+		}
+		svc, store := newUploadTestService(repo)
+
+		upload, err := svc.UploadSubmissionFile(ctx, 1, 2, 3, 4, pdf("../../My Essay.pdf", "%PDF-1.7"))
+		require.NoError(t, err)
+
+		assert.Regexp(t, `^1/2/3/4/\d+_My_Essay\.pdf$`, upload.ObjectKey)
+		assert.Equal(t, "%PDF-1.7", store.uploaded[upload.ObjectKey])
+		assert.Equal(t, "application/pdf", store.contentType)
+		assert.Equal(t, int64(600), store.expiry)
+		assert.Contains(t, upload.DownloadURL, upload.ObjectKey)
+
+		require.NotNil(t, repo.created)
+		assert.Equal(t, upload.ObjectKey, *repo.created.FilePath)
+		assert.Equal(t, &text, repo.created.ContentText)
+	})
+
+	t.Run("rejects oversized files before uploading", func(t *testing.T) {
+		repo := &fakeAssignmentRepo{assignment: &models.Assignment{CourseID: 2}}
+		svc, store := newUploadTestService(repo)
+
+		_, err := svc.UploadSubmissionFile(ctx, 1, 2, 3, 4, pdf("a.pdf", strings.Repeat("x", 2048)))
+		assert.ErrorIs(t, err, ErrSubmissionTooLarge)
+		assert.Empty(t, store.uploaded)
+	})
+
+	t.Run("rejects disallowed content types", func(t *testing.T) {
+		repo := &fakeAssignmentRepo{assignment: &models.Assignment{CourseID: 2}}
+		svc, _ := newUploadTestService(repo)
+
+		file := pdf("a.exe", "MZ")
+		file.ContentType = "application/x-msdownload"
+		_, err := svc.UploadSubmissionFile(ctx, 1, 2, 3, 4, file)
+		assert.ErrorIs(t, err, ErrSubmissionFileType)
+	})
+
+	t.Run("type comes from the content, not the declared header", func(t *testing.T) {
+		repo := &fakeAssignmentRepo{assignment: &models.Assignment{CourseID: 2}}
+		svc, store := newUploadTestService(repo)
+
+		// Claims to be a PDF but is HTML
+		_, err := svc.UploadSubmissionFile(ctx, 1, 2, 3, 4, pdf("a.pdf", "<html><script>alert(1)</script></html>"))
+		assert.ErrorIs(t, err, ErrSubmissionFileType)
+		assert.Empty(t, store.uploaded)
+
+		// A real PDF sent with a generic header is stored as a PDF
+		file := pdf("b.pdf", "%PDF-1.7")
+		file.ContentType = "application/octet-stream"
+		_, err = svc.UploadSubmissionFile(ctx, 1, 2, 3, 4, file)
+		require.NoError(t, err)
+		assert.Equal(t, "application/pdf", store.contentType)
+	})
+
+	t.Run("student must be enrolled in the course", func(t *testing.T) {
+		repo := &fakeAssignmentRepo{assignment: &models.Assignment{CourseID: 2}, notEnrolled: true}
+		svc, store := newUploadTestService(repo)
+
+		_, err := svc.UploadSubmissionFile(ctx, 1, 2, 3, 4, pdf("a.pdf", "%PDF-1.4"))
+		assert.ErrorIs(t, err, ErrNotEnrolledInCourse)
+		assert.Empty(t, store.uploaded)
+	})
+
+	t.Run("assignment from another course is not found", func(t *testing.T) {
+		repo := &fakeAssignmentRepo{assignment: &models.Assignment{CourseID: 7}}
+		svc, store := newUploadTestService(repo)
+
+		_, err := svc.UploadSubmissionFile(ctx, 1, 2, 3, 4, pdf("a.pdf", "%PDF-1.4"))
+		assert.ErrorIs(t, err, ErrAssignmentNotFound)
+		assert.Empty(t, store.uploaded)
+	})
+
+	t.Run("object is removed when the submission cannot be saved", func(t *testing.T) {
+		repo := &fakeAssignmentRepo{
+			assignment: &models.Assignment{CourseID: 2},
+			createErr:  errors.New("db down"),
+		}
+		svc, store := newUploadTestService(repo)
+
+		_, err := svc.UploadSubmissionFile(ctx, 1, 2, 3, 4, pdf("a.pdf", "%PDF-1.4"))
+		require.Error(t, err)
+		assert.Len(t, store.removed, 1)
+	})
+
+	t.Run("rejected file is deleted and not recorded", func(t *testing.T) {
+		repo := &fakeAssignmentRepo{assignment: &models.Assignment{CourseID: 2}}
+		svc, store := newUploadTestService(repo)
+		svc.uploadCfg.Scanner = rejectingScanner{}
+
+		_, err := svc.UploadSubmissionFile(ctx, 1, 2, 3, 4, pdf("a.pdf", "%PDF-1.4"))
+		assert.ErrorIs(t, err, ErrSubmissionRejected)
+		assert.Len(t, store.removed, 1)
+		assert.Nil(t, repo.created)
+	})
+
+	t.Run("storage not configured", func(t *testing.T) {
+		svc := NewAssignmentService(&fakeAssignmentRepo{}, nil, SubmissionUploadConfig{}, LatePolicy{}, nil)
+		_, err := svc.UploadSubmissionFile(ctx, 1, 2, 3, 4, pdf("a.pdf", "%PDF-1.4"))
+		assert.ErrorIs(t, err, ErrStorageNotConfigured)
+	})
+}
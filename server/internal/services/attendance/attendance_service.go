@@ -3,6 +3,7 @@ package attendance
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"eduhub/server/internal/cache"
 	"eduhub/server/internal/models"
@@ -10,15 +11,16 @@ import (
 )
 
 const (
-	Present = "Present"
-	Absent  = "Absent"
-	Freezed = "Freezed"
+	Present = models.AttendancePresent
+	Absent  = models.AttendanceAbsent
+	Freezed = models.AttendanceFreezed
 )
 
 type AttendanceService interface {
 	GenerateQRCode(ctx context.Context, collegeID, courseID int, lectureID int) (string, error)
 	GetAttendanceByLecture(ctx context.Context, collegeID, courseID int, lectureID int, limit, offset uint64) ([]*models.Attendance, error)
 	GetAttendanceByCourse(ctx context.Context, collegeID, courseID int, limit, offset uint64) ([]*models.Attendance, error)
+	GetAttendanceBySection(ctx context.Context, collegeID, courseID, sectionID int, limit, offset uint64) ([]*models.Attendance, error)
 	GetAttendanceByStudent(ctx context.Context, collegeID, studentID int, limit, offset uint64) ([]*models.Attendance, error)
 	GetAttendanceByStudentAndCourse(ctx context.Context, collegeID, studentID int, courseID int, limit, offset uint64) ([]*models.Attendance, error)
 	MarkAttendance(ctx context.Context, collegeID int, studentID int, courseID int, lectureID int) (bool, error)
@@ -27,6 +29,7 @@ type AttendanceService interface {
 	VerifyStudentStateAndEnrollment(ctx context.Context, collegeID, studentID, courseID int) (bool, error)
 	ProcessQRCode(ctx context.Context, collegeID int, studentID int, qrCodeContent string) error
 	MarkBulkAttendance(ctx context.Context, collegeID, courseID, lectureID int, studentStatuses []models.StudentAttendanceStatus) error
+	MarkRosterAttendance(ctx context.Context, collegeID, courseID int, date time.Time, studentStatuses []models.StudentAttendanceStatus) ([]models.RosterAttendanceResult, error)
 }
 type attendanceService struct {
 	repo           repository.AttendanceRepository
@@ -63,6 +66,11 @@ func (a *attendanceService) GetAttendanceByCourse(ctx context.Context, collegeID
 	return a.repo.GetAttendanceByCourse(ctx, collegeID, courseID, limit, offset)
 }
 
+// to get attendance of the students in one section of a course
+func (a *attendanceService) GetAttendanceBySection(ctx context.Context, collegeID int, courseID int, sectionID int, limit, offset uint64) ([]*models.Attendance, error) {
+	return a.repo.GetAttendanceBySection(ctx, collegeID, courseID, sectionID, limit, offset)
+}
+
 func (a *attendanceService) GetAttendanceByStudent(ctx context.Context, collegeID int, studentID int, limit uint64, offset uint64) ([]*models.Attendance, error) {
 	return a.repo.GetAttendanceStudent(ctx, collegeID, studentID, limit, offset)
 }
@@ -120,14 +128,14 @@ func (a *attendanceService) GenerateAndProcessQRCode(ctx context.Context, colleg
 }
 
 func (a *attendanceService) UpdateAttendanceStatus(ctx context.Context, collegeID, studentID int, courseID int, lectureID int, newStatus string) (bool, error) {
-	// Validate newStatus if necessary (e.g., ensure it's one of "Present", "Absent", "Late", etc.)
-	validStatuses := map[string]bool{Present: true, Absent: true, Freezed: true, "Late": true, "Excused": true}
-	if !validStatuses[newStatus] {
+	// Store the canonical casing so "present" and "Present" are counted alike
+	status, ok := models.NormalizeAttendanceStatus(newStatus)
+	if !ok {
 		return false, fmt.Errorf("invalid attendance status: %s", newStatus)
 	}
 
 	// Directly update the specific attendance record
-	err := a.repo.UpdateAttendance(ctx, collegeID, studentID, courseID, lectureID, newStatus)
+	err := a.repo.UpdateAttendance(ctx, collegeID, studentID, courseID, lectureID, status)
 	if err != nil {
 		return false, fmt.Errorf("failed to update attendance status: %w", err)
 	}
@@ -164,6 +172,49 @@ func (a *attendanceService) MarkBulkAttendance(ctx context.Context, collegeID, c
 	return nil
 }
 
+// MarkRosterAttendance records a whole roster for a course on one date.
+// Entries with an unknown status or a repeated student are left out here; the
+// repository checks that the rest are found, active and enrolled in the same
+// transaction that writes them. Every entry left out is returned with the reason.
+func (a *attendanceService) MarkRosterAttendance(ctx context.Context, collegeID, courseID int, date time.Time, studentStatuses []models.StudentAttendanceStatus) ([]models.RosterAttendanceResult, error) {
+	rejected := make([]models.RosterAttendanceResult, 0)
+	valid := make([]models.StudentAttendanceStatus, 0, len(studentStatuses))
+	seen := make(map[int]bool, len(studentStatuses))
+
+	reject := func(entry models.StudentAttendanceStatus, reason string) {
+		rejected = append(rejected, models.RosterAttendanceResult{
+			StudentID: entry.StudentID,
+			Status:    entry.Status,
+			Error:     reason,
+		})
+	}
+
+	for _, entry := range studentStatuses {
+		status, ok := models.NormalizeAttendanceStatus(entry.Status)
+		if !ok || status == Freezed {
+			reject(entry, "unknown attendance status")
+			continue
+		}
+		if seen[entry.StudentID] {
+			reject(entry, "student appears more than once in the roster")
+			continue
+		}
+		seen[entry.StudentID] = true
+
+		valid = append(valid, models.StudentAttendanceStatus{StudentID: entry.StudentID, Status: status})
+	}
+
+	if len(valid) == 0 {
+		return rejected, nil
+	}
+
+	ineligible, err := a.repo.SetAttendanceForDate(ctx, collegeID, courseID, date, valid)
+	if err != nil {
+		return nil, err
+	}
+	return append(rejected, ineligible...), nil
+}
+
 func (a *attendanceService) FreezeAttendance(ctx context.Context, collegeID, studentID int) (bool, error) {
 	err := a.repo.FreezeAttendance(ctx, collegeID, studentID)
 	if err != nil {
@@ -0,0 +1,67 @@
+package attendance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkRosterAttendance_MixedRoster(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	svc := NewAttendanceService(repository.NewAttendanceRepository(mock), nil, nil)
+	day := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id FROM lectures`).
+		WithArgs(int32(1), int32(2), day).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int32(40)))
+	// 101 and 105 are eligible, 102 is inactive, 103 is not enrolled and 104 does not exist
+	mock.ExpectQuery(`FROM students s`).
+		WithArgs(int32(1), int32(2), []int32{101, 102, 103, 104, 105}).
+		WillReturnRows(pgxmock.NewRows([]string{"student_id", "is_active", "enrolled"}).
+			AddRow(int32(101), true, true).
+			AddRow(int32(102), false, true).
+			AddRow(int32(103), true, false).
+			AddRow(int32(105), true, true))
+	mock.ExpectExec(`INSERT INTO attendance`).
+		WithArgs(int32(101), int32(2), int32(1), int32(40), day, Present, pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(`INSERT INTO attendance`).
+		WithArgs(int32(105), int32(2), int32(1), int32(40), day, Absent, pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	rejected, err := svc.MarkRosterAttendance(context.Background(), 1, 2, day, []models.StudentAttendanceStatus{
+		{StudentID: 101, Status: "present"},
+		{StudentID: 102, Status: "Present"},
+		{StudentID: 103, Status: "Absent"},
+		{StudentID: 104, Status: "Present"},
+		{StudentID: 105, Status: "Absent"},
+		{StudentID: 101, Status: "Absent"},
+		{StudentID: 106, Status: "Late"},
+	})
+
+	require.NoError(t, err)
+	reasons := make(map[int]string, len(rejected))
+	for _, r := range rejected {
+		reasons[r.StudentID] = r.Error
+	}
+	assert.Equal(t, map[int]string{
+		101: "student appears more than once in the roster",
+		102: "student is not active",
+		103: "student not enrolled in course 2",
+		104: "student not found",
+		106: "unknown attendance status",
+	}, reasons)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
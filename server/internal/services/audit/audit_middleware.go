@@ -83,6 +83,7 @@ func shouldSkipAudit(c echo.Context) bool {
 		"/health",
 		"/ready",
 		"/alive",
+		"/version",
 		"/swagger",
 		"/docs",
 		"/auth/login",
@@ -92,6 +93,7 @@ func shouldSkipAudit(c echo.Context) bool {
 		"/auth/password-reset",
 		"/auth/verify-email",
 		"/api/notifications/ws", // WebSocket connections
+		"/api/v1/payments/razorpay/webhook",
 	}
 
 	for _, skipPath := range skipPaths {
@@ -104,6 +104,45 @@ func (k *kratosService) FindIdentityByEmail(ctx context.Context, email string) (
 	return &identities[0], nil
 }
 
+// CreateIdentity creates an identity through the Kratos admin API without
+// credentials. The user sets a password through the recovery flow.
+func (k *kratosService) CreateIdentity(ctx context.Context, traits Traits) (*Identity, error) {
+	data, err := json.Marshal(map[string]any{
+		"schema_id": "default",
+		"state":     "active",
+		"traits":    traits,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal identity: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/identities", k.AdminURL)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create identity request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := k.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create identity: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("create identity failed with status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	var identity Identity
+	if err := json.NewDecoder(resp.Body).Decode(&identity); err != nil {
+		return nil, fmt.Errorf("failed to decode identity response: %w", err)
+	}
+
+	return &identity, nil
+}
+
 // DeleteIdentity removes an identity from the Kratos admin API.
 func (k *kratosService) DeleteIdentity(ctx context.Context, identityID string) error {
 	if identityID == "" {
@@ -3,6 +3,7 @@ package batch
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -10,6 +11,7 @@ import (
 
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/repository"
+	"eduhub/server/internal/services/auth"
 )
 
 type BatchResult struct {
@@ -19,46 +21,142 @@ type BatchResult struct {
 }
 
 type BatchService interface {
-	ImportStudents(ctx context.Context, collegeID int, students []models.Student) (*BatchResult, error)
+	ImportStudents(ctx context.Context, collegeID int, records [][]string) (*StudentImportReport, error)
 	ExportStudents(ctx context.Context, collegeID int, courseID *int) (string, error)
 	ImportGrades(ctx context.Context, collegeID, courseID int, records [][]string) (*BatchResult, error)
 	ExportGrades(ctx context.Context, collegeID, courseID int) (string, error)
 	BulkEnroll(ctx context.Context, collegeID, courseID int, studentIDs []int) (*BatchResult, error)
 }
 
+// IdentityProvider is the part of the Kratos admin API used to give imported
+// students a login.
+type IdentityProvider interface {
+	FindIdentityByEmail(ctx context.Context, email string) (*auth.Identity, error)
+	CreateIdentity(ctx context.Context, traits auth.Traits) (*auth.Identity, error)
+}
+
 type batchService struct {
 	studentRepo    repository.StudentRepository
 	enrollmentRepo repository.EnrollmentRepository
 	gradeRepo      repository.GradeRepository
+	identities     IdentityProvider
 }
 
 func NewBatchService(
 	studentRepo repository.StudentRepository,
 	enrollmentRepo repository.EnrollmentRepository,
 	gradeRepo repository.GradeRepository,
+	identities IdentityProvider,
 ) BatchService {
 	return &batchService{
 		studentRepo:    studentRepo,
 		enrollmentRepo: enrollmentRepo,
 		gradeRepo:      gradeRepo,
+		identities:     identities,
 	}
 }
 
-func (s *batchService) ImportStudents(ctx context.Context, collegeID int, students []models.Student) (*BatchResult, error) {
-	result := &BatchResult{}
+// ImportStudents creates a user, Kratos identity and student for every CSV row
+// (roll_no, name, email, enrollment_year) after the header. The header must
+// name those columns in that order. Rows are handled independently and
+// re-running an import skips roll numbers that already exist, so a partially
+// failed file can simply be uploaded again.
+func (s *batchService) ImportStudents(ctx context.Context, collegeID int, records [][]string) (*StudentImportReport, error) {
+	if s.identities == nil {
+		return nil, fmt.Errorf("ImportStudents: identity provider not configured")
+	}
 
-	for _, student := range students {
-		student.CollegeID = collegeID
-		err := s.studentRepo.CreateStudent(ctx, &student)
+	report := &StudentImportReport{Rows: []StudentImportRow{}}
+	if len(records) == 0 {
+		return report, nil
+	}
+	if err := validateStudentImportHeader(records[0]); err != nil {
+		return nil, err
+	}
+
+	seenRollNos := make(map[string]int)
+	seenEmails := make(map[string]int)
+	for i, record := range records[1:] {
+		line := i + 2
+		row, err := parseStudentImportRecord(record)
+		row.Line = line
+		if err == nil {
+			if first, ok := seenRollNos[rollNoKey(row.RollNo)]; ok {
+				err = fmt.Errorf("duplicate roll_no, already on line %d", first)
+			} else if first, ok := seenEmails[row.Email]; ok {
+				err = fmt.Errorf("duplicate email, already on line %d", first)
+			}
+		}
 		if err != nil {
-			result.Failed++
-			result.Errors = append(result.Errors, fmt.Sprintf("Failed to create student %s: %v", student.RollNo, err))
-		} else {
-			result.Success++
+			report.add(row, StudentImportFailed, err.Error())
+			continue
 		}
+		seenRollNos[rollNoKey(row.RollNo)] = line
+		seenEmails[row.Email] = line
+
+		s.importStudent(ctx, collegeID, &row, report)
 	}
 
-	return result, nil
+	return report, nil
+}
+
+func (s *batchService) importStudent(ctx context.Context, collegeID int, row *StudentImportRow, report *StudentImportReport) {
+	existingID, err := s.studentRepo.FindStudentIDByRollNo(ctx, collegeID, row.RollNo)
+	if err != nil {
+		report.add(*row, StudentImportFailed, err.Error())
+		return
+	}
+	if existingID > 0 {
+		row.StudentID = existingID
+		report.add(*row, StudentImportSkipped, repository.ErrRollNoExists.Error())
+		return
+	}
+
+	// Reuse the identity when an earlier run created it but failed afterwards
+	identity, err := s.identities.FindIdentityByEmail(ctx, row.Email)
+	if err != nil {
+		report.add(*row, StudentImportFailed, err.Error())
+		return
+	}
+	if identity == nil {
+		first, last := splitName(row.Name)
+		identity, err = s.identities.CreateIdentity(ctx, auth.Traits{
+			Email:   row.Email,
+			Name:    auth.Name{First: first, Last: last},
+			Role:    "student",
+			College: auth.College{ID: strconv.Itoa(collegeID)},
+			RollNo:  row.RollNo,
+		})
+		if err != nil {
+			report.add(*row, StudentImportFailed, err.Error())
+			return
+		}
+	}
+
+	user := &models.User{
+		Name:             row.Name,
+		Role:             "student",
+		Email:            row.Email,
+		KratosIdentityID: identity.ID,
+		IsActive:         true,
+	}
+	student := &models.Student{
+		CollegeID:      collegeID,
+		EnrollmentYear: row.EnrollmentYear,
+		RollNo:         row.RollNo,
+		IsActive:       true,
+	}
+	if err := s.studentRepo.CreateStudentWithUser(ctx, user, student); err != nil {
+		if errors.Is(err, repository.ErrRollNoExists) {
+			report.add(*row, StudentImportSkipped, err.Error())
+			return
+		}
+		report.add(*row, StudentImportFailed, err.Error())
+		return
+	}
+
+	row.StudentID = student.StudentID
+	report.add(*row, StudentImportCreated, "")
 }
 
 func (s *batchService) ExportStudents(ctx context.Context, collegeID int, courseID *int) (string, error) {
@@ -0,0 +1,184 @@
+package batch
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+	"eduhub/server/internal/services/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type importStudentRepo struct {
+	repository.StudentRepository
+	byRollNo map[string]int
+	nextID   int
+}
+
+// FindStudentIDByRollNo matches case-insensitively like the real repository
+func (r *importStudentRepo) FindStudentIDByRollNo(ctx context.Context, collegeID int, rollNo string) (int, error) {
+	for existing, id := range r.byRollNo {
+		if strings.EqualFold(existing, rollNo) {
+			return id, nil
+		}
+	}
+	return 0, nil
+}
+
+func (r *importStudentRepo) CreateStudentWithUser(ctx context.Context, user *models.User, student *models.Student) error {
+	if id, _ := r.FindStudentIDByRollNo(ctx, student.CollegeID, student.RollNo); id > 0 {
+		return repository.ErrRollNoExists
+	}
+	r.nextID++
+	student.StudentID = r.nextID
+	r.byRollNo[student.RollNo] = r.nextID
+	return nil
+}
+
+type fakeIdentities struct {
+	byEmail map[string]*auth.Identity
+	created int
+}
+
+func (f *fakeIdentities) FindIdentityByEmail(ctx context.Context, email string) (*auth.Identity, error) {
+	return f.byEmail[email], nil
+}
+
+func (f *fakeIdentities) CreateIdentity(ctx context.Context, traits auth.Traits) (*auth.Identity, error) {
+	f.created++
+	identity := &auth.Identity{ID: "kratos-" + traits.RollNo, Traits: traits}
+	f.byEmail[traits.Email] = identity
+	return identity, nil
+}
+
+func TestImportStudents(t *testing.T) {
+	repo := &importStudentRepo{byRollNo: map[string]int{"CS-001": 7}, nextID: 100}
+	identities := &fakeIdentities{byEmail: map[string]*auth.Identity{}}
+	svc := NewBatchService(repo, nil, nil, identities)
+
+	records := [][]string{
+		{"roll_no", "name", "email", "enrollment_year"},
+		{"CS-001", "Existing Student", "existing@example.com", "2024"},
+		{"CS-002", "Asha  Rao", " Asha@Example.com ", "2024"},
+		{"CS-003", "Bad Email", "not-an-email", "2024"},
+		{"CS-002", "Copy Row", "copy@example.com", "2024"},
+		{"CS-004", "Old Year", "old@example.com", "1900"},
+		{"CS-005", "Too Short"},
+	}
+
+	report, err := svc.ImportStudents(context.Background(), 1, records)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, 4, report.Failed)
+	require.Len(t, report.Rows, 6)
+
+	assert.Equal(t, StudentImportSkipped, report.Rows[0].Status)
+	assert.Equal(t, 7, report.Rows[0].StudentID)
+
+	assert.Equal(t, StudentImportCreated, report.Rows[1].Status)
+	assert.Equal(t, 3, report.Rows[1].Line)
+	assert.Equal(t, "asha@example.com", report.Rows[1].Email)
+	assert.Equal(t, 101, report.Rows[1].StudentID)
+	assert.Equal(t, "Asha", identities.byEmail["asha@example.com"].Traits.Name.First)
+	assert.Equal(t, "Rao", identities.byEmail["asha@example.com"].Traits.Name.Last)
+
+	assert.Contains(t, report.Rows[2].Reason, "invalid email")
+	assert.Contains(t, report.Rows[3].Reason, "duplicate roll_no, already on line 3")
+	assert.Contains(t, report.Rows[4].Reason, "invalid enrollment_year")
+	assert.Contains(t, report.Rows[5].Reason, "expected 4 columns")
+	assert.Equal(t, "CS-005", report.Rows[5].RollNo)
+}
+
+func TestImportStudentsIsIdempotent(t *testing.T) {
+	repo := &importStudentRepo{byRollNo: map[string]int{}}
+	identities := &fakeIdentities{byEmail: map[string]*auth.Identity{}}
+	svc := NewBatchService(repo, nil, nil, identities)
+
+	records := [][]string{
+		{"roll_no", "name", "email", "enrollment_year"},
+		{"CS-010", "Ravi Kumar", "ravi@example.com", "2025"},
+		{"CS-011", "Meera", "meera@example.com", "2025"},
+	}
+
+	first, err := svc.ImportStudents(context.Background(), 1, records)
+	require.NoError(t, err)
+	assert.Equal(t, 2, first.Created)
+
+	second, err := svc.ImportStudents(context.Background(), 1, records)
+	require.NoError(t, err)
+	assert.Equal(t, 0, second.Created)
+	assert.Equal(t, 2, second.Skipped)
+	assert.Equal(t, 2, identities.created)
+	assert.Len(t, repo.byRollNo, 2)
+}
+
+func TestImportStudentsReusesExistingIdentity(t *testing.T) {
+	repo := &importStudentRepo{byRollNo: map[string]int{}}
+	// Left behind by an earlier run that failed after creating the identity
+	identities := &fakeIdentities{byEmail: map[string]*auth.Identity{
+		"ravi@example.com": {ID: "kratos-existing"},
+	}}
+	svc := NewBatchService(repo, nil, nil, identities)
+
+	report, err := svc.ImportStudents(context.Background(), 1, [][]string{
+		{"roll_no", "name", "email", "enrollment_year"},
+		{"CS-010", "Ravi Kumar", "ravi@example.com", "2025"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 0, identities.created)
+}
+
+func TestImportStudentsRejectsBadHeader(t *testing.T) {
+	identities := &fakeIdentities{byEmail: map[string]*auth.Identity{}}
+	svc := NewBatchService(&importStudentRepo{byRollNo: map[string]int{}}, nil, nil, identities)
+
+	for _, header := range [][]string{
+		{"name", "roll_no", "email", "enrollment_year"},
+		{"roll_no", "name", "email"},
+		{"roll_no", "name", "email", "year"},
+		{"CS-010", "Ravi Kumar", "ravi@example.com", "2025"},
+	} {
+		_, err := svc.ImportStudents(context.Background(), 1, [][]string{
+			header,
+			{"CS-010", "Ravi Kumar", "ravi@example.com", "2025"},
+		})
+		assert.ErrorIs(t, err, ErrInvalidImportHeader, "header %v", header)
+	}
+	assert.Equal(t, 0, identities.created)
+
+	// Surrounding space, case and a UTF-8 byte order mark are tolerated
+	report, err := svc.ImportStudents(context.Background(), 1, [][]string{
+		{"\ufeffRoll_No", " name", "EMAIL ", "enrollment_year"},
+		{"CS-010", "Ravi Kumar", "ravi@example.com", "2025"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+}
+
+func TestImportStudentsMatchesRollNoIgnoringCase(t *testing.T) {
+	repo := &importStudentRepo{byRollNo: map[string]int{"CS-001": 7}, nextID: 100}
+	identities := &fakeIdentities{byEmail: map[string]*auth.Identity{}}
+	svc := NewBatchService(repo, nil, nil, identities)
+
+	report, err := svc.ImportStudents(context.Background(), 1, [][]string{
+		{"roll_no", "name", "email", "enrollment_year"},
+		{"cs-001", "Existing Student", "existing@example.com", "2024"},
+		{"CS-002", "Asha Rao", "asha@example.com", "2024"},
+		{"cs-002", "Copy Row", "copy@example.com", "2024"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, report.Rows, 3)
+	assert.Equal(t, StudentImportSkipped, report.Rows[0].Status)
+	assert.Equal(t, 7, report.Rows[0].StudentID)
+	assert.Equal(t, StudentImportCreated, report.Rows[1].Status)
+	assert.Equal(t, StudentImportFailed, report.Rows[2].Status)
+	assert.Contains(t, report.Rows[2].Reason, "duplicate roll_no, already on line 3")
+}
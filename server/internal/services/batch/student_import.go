@@ -0,0 +1,125 @@
+package batch
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StudentImportStatus is the outcome of a single CSV row
+type StudentImportStatus string
+
+const (
+	StudentImportCreated StudentImportStatus = "created"
+	StudentImportSkipped StudentImportStatus = "skipped"
+	StudentImportFailed  StudentImportStatus = "failed"
+)
+
+const maxRollNoLength = 50
+
+// studentImportHeader is the header row a student import file must start with
+var studentImportHeader = []string{"roll_no", "name", "email", "enrollment_year"}
+
+// ErrInvalidImportHeader is returned when the first CSV row does not name the
+// expected columns in the expected order
+var ErrInvalidImportHeader = errors.New("invalid CSV header")
+
+// StudentImportRow reports what happened to one line of the uploaded CSV
+type StudentImportRow struct {
+	Line           int                 `json:"line"`
+	RollNo         string              `json:"roll_no"`
+	Name           string              `json:"name,omitempty"`
+	Email          string              `json:"email,omitempty"`
+	EnrollmentYear int                 `json:"enrollment_year,omitempty"`
+	Status         StudentImportStatus `json:"status"`
+	StudentID      int                 `json:"student_id,omitempty"`
+	Reason         string              `json:"reason,omitempty"`
+}
+
+// StudentImportReport summarises a bulk student import
+type StudentImportReport struct {
+	Created int                `json:"created"`
+	Skipped int                `json:"skipped"`
+	Failed  int                `json:"failed"`
+	Rows    []StudentImportRow `json:"rows"`
+}
+
+func (r *StudentImportReport) add(row StudentImportRow, status StudentImportStatus, reason string) {
+	row.Status = status
+	row.Reason = reason
+	switch status {
+	case StudentImportCreated:
+		r.Created++
+	case StudentImportSkipped:
+		r.Skipped++
+	default:
+		r.Failed++
+	}
+	r.Rows = append(r.Rows, row)
+}
+
+// validateStudentImportHeader rejects a file whose columns are missing,
+// renamed or out of order, since every row is read by position.
+func validateStudentImportHeader(header []string) error {
+	got := make([]string, len(header))
+	for i, name := range header {
+		got[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+	}
+	if strings.Join(got, ",") != strings.Join(studentImportHeader, ",") {
+		return fmt.Errorf("%w: expected %s, got %s", ErrInvalidImportHeader,
+			strings.Join(studentImportHeader, ","), strings.Join(got, ","))
+	}
+	return nil
+}
+
+// rollNoKey is how roll numbers are compared, both within a file and against
+// existing students: surrounding space is ignored and case does not matter.
+func rollNoKey(rollNo string) string {
+	return strings.ToLower(strings.TrimSpace(rollNo))
+}
+
+// parseStudentImportRecord validates a roll_no, name, email, enrollment_year row.
+// The returned row holds whatever could be read even when err is set, so the
+// report can still point at the offending roll number.
+func parseStudentImportRecord(record []string) (StudentImportRow, error) {
+	var row StudentImportRow
+	if len(record) > 0 {
+		row.RollNo = strings.TrimSpace(record[0])
+	}
+	if len(record) < 4 {
+		return row, fmt.Errorf("expected 4 columns: roll_no, name, email, enrollment_year")
+	}
+	row.Name = strings.Join(strings.Fields(record[1]), " ")
+	row.Email = strings.ToLower(strings.TrimSpace(record[2]))
+
+	if row.RollNo == "" {
+		return row, fmt.Errorf("roll_no is required")
+	}
+	if len(row.RollNo) > maxRollNoLength {
+		return row, fmt.Errorf("roll_no must be at most %d characters", maxRollNoLength)
+	}
+	if row.Name == "" {
+		return row, fmt.Errorf("name is required")
+	}
+	if addr, err := mail.ParseAddress(row.Email); err != nil || addr.Address != row.Email {
+		return row, fmt.Errorf("invalid email %q", strings.TrimSpace(record[2]))
+	}
+
+	year, err := strconv.Atoi(strings.TrimSpace(record[3]))
+	if err != nil || year < 1947 || year > time.Now().Year()+1 {
+		return row, fmt.Errorf("invalid enrollment_year %q", strings.TrimSpace(record[3]))
+	}
+	row.EnrollmentYear = year
+
+	return row, nil
+}
+
+// splitName splits a full name into the first and last name Kratos traits
+// expect. Everything after the first word is treated as the last name.
+func splitName(name string) (string, string) {
+	first, last, _ := strings.Cut(name, " ")
+	return first, last
+}
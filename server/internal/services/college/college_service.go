@@ -6,6 +6,7 @@ import (
 
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/repository"
+	"eduhub/server/internal/validation"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -30,7 +31,7 @@ type collegeService struct {
 func NewCollegeService(collegeRepo repository.CollegeRepository) CollegeService {
 	return &collegeService{
 		collegeRepo: collegeRepo,
-		validate:    validator.New(),
+		validate:    validation.New(),
 	}
 }
 
@@ -13,6 +13,7 @@ import (
 
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/repository"
+	"eduhub/server/internal/validation"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -67,7 +68,7 @@ func NewCourseService(
 		courseRepo:  courseRepo,
 		collegeRepo: collegeRepo,
 		userRepo:    userRepo,
-		validate:    validator.New(),
+		validate:    validation.New(),
 	}
 }
 
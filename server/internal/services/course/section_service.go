@@ -0,0 +1,139 @@
+package course
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+	"eduhub/server/internal/validation"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ErrCourseNotFound is returned when the course is not part of the college
+var ErrCourseNotFound = errors.New("course not found")
+
+// ErrSectionNotFound is returned when the section is not part of the course
+var ErrSectionNotFound = repository.ErrSectionNotFound
+
+// ErrSectionNameTaken is returned when the course already has a section of
+// that name
+var ErrSectionNameTaken = repository.ErrSectionNameTaken
+
+// ErrStudentNotInSection is returned by UnassignStudent when the student is
+// not in the section
+var ErrStudentNotInSection = repository.ErrStudentNotInSection
+
+// SectionAssignment reports which students were moved into a section. Students
+// not enrolled in the course are left out and listed separately.
+type SectionAssignment struct {
+	SectionID   int   `json:"section_id"`
+	Assigned    []int `json:"assigned"`
+	NotEnrolled []int `json:"not_enrolled"`
+}
+
+// SectionService manages the sections a course is split into. Course-level
+// queries keep covering every section; a student is in at most one section
+// of a course.
+type SectionService interface {
+	ListSections(ctx context.Context, collegeID, courseID int) ([]*models.CourseSection, error)
+	CreateSection(ctx context.Context, collegeID, courseID int, req *models.CourseSectionRequest) (*models.CourseSection, error)
+	RenameSection(ctx context.Context, collegeID, courseID, sectionID int, req *models.CourseSectionRequest) (*models.CourseSection, error)
+	DeleteSection(ctx context.Context, collegeID, courseID, sectionID int) error
+	AssignStudents(ctx context.Context, collegeID, courseID, sectionID int, req *models.AssignSectionRequest) (*SectionAssignment, error)
+	UnassignStudent(ctx context.Context, collegeID, courseID, sectionID, studentID int) error
+}
+
+type sectionService struct {
+	sectionRepo repository.CourseSectionRepository
+	courseRepo  repository.CourseRepository
+	validate    *validator.Validate
+}
+
+func NewSectionService(sectionRepo repository.CourseSectionRepository, courseRepo repository.CourseRepository) SectionService {
+	return &sectionService{
+		sectionRepo: sectionRepo,
+		courseRepo:  courseRepo,
+		validate:    validation.New(),
+	}
+}
+
+func (s *sectionService) ListSections(ctx context.Context, collegeID, courseID int) ([]*models.CourseSection, error) {
+	if err := s.requireCourse(ctx, collegeID, courseID); err != nil {
+		return nil, err
+	}
+	return s.sectionRepo.ListSections(ctx, collegeID, courseID)
+}
+
+func (s *sectionService) CreateSection(ctx context.Context, collegeID, courseID int, req *models.CourseSectionRequest) (*models.CourseSection, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := s.requireCourse(ctx, collegeID, courseID); err != nil {
+		return nil, err
+	}
+
+	section := &models.CourseSection{CollegeID: collegeID, CourseID: courseID, Name: req.Name}
+	if err := s.sectionRepo.CreateSection(ctx, section); err != nil {
+		return nil, err
+	}
+	return section, nil
+}
+
+func (s *sectionService) RenameSection(ctx context.Context, collegeID, courseID, sectionID int, req *models.CourseSectionRequest) (*models.CourseSection, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	return s.sectionRepo.RenameSection(ctx, collegeID, courseID, sectionID, req.Name)
+}
+
+func (s *sectionService) DeleteSection(ctx context.Context, collegeID, courseID, sectionID int) error {
+	return s.sectionRepo.DeleteSection(ctx, collegeID, courseID, sectionID)
+}
+
+// AssignStudents moves enrolled students into the section, out of whichever
+// section of the course they were in before
+func (s *sectionService) AssignStudents(ctx context.Context, collegeID, courseID, sectionID int, req *models.AssignSectionRequest) (*SectionAssignment, error) {
+	if err := s.validate.Struct(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if _, err := s.sectionRepo.GetSection(ctx, collegeID, courseID, sectionID); err != nil {
+		return nil, err
+	}
+
+	assigned, err := s.sectionRepo.AssignStudents(ctx, collegeID, courseID, sectionID, req.StudentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SectionAssignment{SectionID: sectionID, Assigned: assigned, NotEnrolled: []int{}}
+	enrolled := make(map[int]bool, len(assigned))
+	for _, id := range assigned {
+		enrolled[id] = true
+	}
+	for _, id := range req.StudentIDs {
+		if !enrolled[id] {
+			enrolled[id] = true
+			result.NotEnrolled = append(result.NotEnrolled, id)
+		}
+	}
+	return result, nil
+}
+
+func (s *sectionService) UnassignStudent(ctx context.Context, collegeID, courseID, sectionID, studentID int) error {
+	return s.sectionRepo.RemoveStudent(ctx, collegeID, courseID, sectionID, studentID)
+}
+
+// requireCourse checks the course belongs to the college
+func (s *sectionService) requireCourse(ctx context.Context, collegeID, courseID int) error {
+	course, err := s.courseRepo.FindCourseByID(ctx, collegeID, courseID)
+	if err != nil || course == nil {
+		return ErrCourseNotFound
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package course
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+	"eduhub/server/internal/validation"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sectionRepo struct {
+	repository.CourseSectionRepository
+	sections map[int]*models.CourseSection
+	enrolled map[int]bool
+	assigned map[int]int
+}
+
+func (r *sectionRepo) CreateSection(ctx context.Context, section *models.CourseSection) error {
+	for _, existing := range r.sections {
+		if existing.CourseID == section.CourseID && existing.Name == section.Name {
+			return repository.ErrSectionNameTaken
+		}
+	}
+	section.ID = len(r.sections) + 1
+	r.sections[section.ID] = section
+	return nil
+}
+
+func (r *sectionRepo) GetSection(ctx context.Context, collegeID, courseID, sectionID int) (*models.CourseSection, error) {
+	section, ok := r.sections[sectionID]
+	if !ok || section.CollegeID != collegeID || section.CourseID != courseID {
+		return nil, repository.ErrSectionNotFound
+	}
+	return section, nil
+}
+
+func (r *sectionRepo) AssignStudents(ctx context.Context, collegeID, courseID, sectionID int, studentIDs []int) ([]int, error) {
+	assigned := []int{}
+	for _, id := range studentIDs {
+		if r.enrolled[id] {
+			r.assigned[id] = sectionID
+			assigned = append(assigned, id)
+		}
+	}
+	return assigned, nil
+}
+
+type sectionCourseRepo struct {
+	repository.CourseRepository
+}
+
+func (r *sectionCourseRepo) FindCourseByID(ctx context.Context, collegeID int, courseID int) (*models.Course, error) {
+	if collegeID != 1 || courseID != 4 {
+		return nil, errors.New("course not found")
+	}
+	return &models.Course{ID: courseID, CollegeID: collegeID}, nil
+}
+
+func newSectionFixture() (SectionService, *sectionRepo) {
+	repo := &sectionRepo{
+		sections: map[int]*models.CourseSection{},
+		enrolled: map[int]bool{5: true, 6: true},
+		assigned: map[int]int{},
+	}
+	return NewSectionService(repo, &sectionCourseRepo{}), repo
+}
+
+func TestCreateSection(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("trims the name", func(t *testing.T) {
+		svc, _ := newSectionFixture()
+		section, err := svc.CreateSection(ctx, 1, 4, &models.CourseSectionRequest{Name: "  A  "})
+		require.NoError(t, err)
+		assert.Equal(t, "A", section.Name)
+		assert.Equal(t, 4, section.CourseID)
+	})
+
+	t.Run("blank name", func(t *testing.T) {
+		svc, _ := newSectionFixture()
+		_, err := svc.CreateSection(ctx, 1, 4, &models.CourseSectionRequest{Name: "   "})
+		assert.Contains(t, validation.Fields(err), "name")
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		svc, _ := newSectionFixture()
+		_, err := svc.CreateSection(ctx, 1, 4, &models.CourseSectionRequest{Name: "A"})
+		require.NoError(t, err)
+		_, err = svc.CreateSection(ctx, 1, 4, &models.CourseSectionRequest{Name: "A"})
+		assert.ErrorIs(t, err, ErrSectionNameTaken)
+	})
+
+	t.Run("course of another college", func(t *testing.T) {
+		svc, _ := newSectionFixture()
+		_, err := svc.CreateSection(ctx, 2, 4, &models.CourseSectionRequest{Name: "A"})
+		assert.ErrorIs(t, err, ErrCourseNotFound)
+	})
+}
+
+func TestAssignStudents(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reports students not enrolled", func(t *testing.T) {
+		svc, repo := newSectionFixture()
+		section, err := svc.CreateSection(ctx, 1, 4, &models.CourseSectionRequest{Name: "A"})
+		require.NoError(t, err)
+
+		result, err := svc.AssignStudents(ctx, 1, 4, section.ID, &models.AssignSectionRequest{StudentIDs: []int{5, 7, 6, 7}})
+		require.NoError(t, err)
+		assert.Equal(t, []int{5, 6}, result.Assigned)
+		assert.Equal(t, []int{7}, result.NotEnrolled)
+		assert.Equal(t, map[int]int{5: section.ID, 6: section.ID}, repo.assigned)
+	})
+
+	t.Run("section of another course", func(t *testing.T) {
+		svc, repo := newSectionFixture()
+		repo.sections[9] = &models.CourseSection{ID: 9, CollegeID: 1, CourseID: 8, Name: "A"}
+		_, err := svc.AssignStudents(ctx, 1, 4, 9, &models.AssignSectionRequest{StudentIDs: []int{5}})
+		assert.ErrorIs(t, err, ErrSectionNotFound)
+		assert.Empty(t, repo.assigned)
+	})
+
+	t.Run("no students", func(t *testing.T) {
+		svc, _ := newSectionFixture()
+		_, err := svc.AssignStudents(ctx, 1, 4, 1, &models.AssignSectionRequest{})
+		assert.Contains(t, validation.Fields(err), "student_ids")
+	})
+}
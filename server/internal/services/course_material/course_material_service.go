@@ -7,6 +7,7 @@ import (
 
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/repository"
+	"eduhub/server/internal/validation"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -53,7 +54,7 @@ func NewCourseMaterialService(
 		materialRepo: materialRepo,
 		fileRepo:     fileRepo,
 		studentRepo:  studentRepo,
-		validate:     validator.New(),
+		validate:     validation.New(),
 	}
 }
 
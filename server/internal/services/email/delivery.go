@@ -0,0 +1,181 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// defaultMaxAttempts is how many times a background email is tried before
+	// it is dead-lettered
+	defaultMaxAttempts = 4
+	// defaultRetryBackoff is the wait before the first retry; it doubles after each attempt
+	defaultRetryBackoff = 5 * time.Second
+	// defaultMaxInFlight caps how many queued emails are sent at once, so a
+	// large batch such as a result publish doesn't flood the mail server
+	defaultMaxInFlight = 20
+	// maxDeadLetterPageSize caps one page of ListDeadLetters
+	maxDeadLetterPageSize = 100
+)
+
+var (
+	ErrEmailDeadLetterNotFound = repository.ErrEmailDeadLetterNotFound
+	// ErrEmailAlreadyResent is returned when retrying a dead letter that has
+	// already been resent or is being resent
+	ErrEmailAlreadyResent = repository.ErrEmailDeadLetterResent
+)
+
+// DeliveryService sends emails in the background. A send is retried with
+// exponential backoff and, if every attempt fails, kept as a dead letter that
+// an admin can inspect and resend. Flows that must know the email went out
+// use EmailService directly instead.
+type DeliveryService interface {
+	// SendAsync queues an email and returns at once. Only an invalid
+	// recipient is reported; delivery failures end up as dead letters.
+	SendAsync(ctx context.Context, collegeID int, to, subject, body string) error
+	// SendTemplateAsync renders a template like EmailService.SendTemplate and
+	// queues the result
+	SendTemplateAsync(ctx context.Context, collegeID int, to, templateName string, data any) error
+	// SendSecretTemplateAsync is SendTemplateAsync for a message carrying a
+	// secret, such as a one-time link. It is retried the same way but never
+	// dead-lettered, so the secret is not stored where admins can read it; a
+	// final failure is only logged.
+	SendSecretTemplateAsync(ctx context.Context, collegeID int, to, templateName string, data any) error
+	ListDeadLetters(ctx context.Context, collegeID int, includeResent bool, limit, offset int) ([]*models.EmailDeadLetter, error)
+	// RetryDeadLetter resends a dead letter once, synchronously, and records
+	// the outcome on it. The letter is claimed first, so concurrent retries
+	// send it at most once.
+	RetryDeadLetter(ctx context.Context, collegeID, letterID int) (*models.EmailDeadLetter, error)
+}
+
+type deliveryService struct {
+	emailService EmailService
+	repo         repository.EmailDeadLetterRepository
+	maxAttempts  int
+	retryBackoff time.Duration
+	// inFlight holds a token for each send in progress
+	inFlight chan struct{}
+	logger   zerolog.Logger
+}
+
+// NewDeliveryService creates a DeliveryService that sends through emailService
+// and stores dead letters in repo
+func NewDeliveryService(emailService EmailService, repo repository.EmailDeadLetterRepository, logger zerolog.Logger) DeliveryService {
+	return &deliveryService{
+		emailService: emailService,
+		repo:         repo,
+		maxAttempts:  defaultMaxAttempts,
+		retryBackoff: defaultRetryBackoff,
+		inFlight:     make(chan struct{}, defaultMaxInFlight),
+		logger:       logger,
+	}
+}
+
+func (s *deliveryService) SendAsync(ctx context.Context, collegeID int, to, subject, body string) error {
+	return s.queue(ctx, collegeID, to, subject, body, true)
+}
+
+func (s *deliveryService) SendTemplateAsync(ctx context.Context, collegeID int, to, templateName string, data any) error {
+	subject, body, err := Render(templateName, data)
+	if err != nil {
+		return err
+	}
+	return s.queue(ctx, collegeID, to, subject, body, true)
+}
+
+func (s *deliveryService) SendSecretTemplateAsync(ctx context.Context, collegeID int, to, templateName string, data any) error {
+	subject, body, err := Render(templateName, data)
+	if err != nil {
+		return err
+	}
+	return s.queue(ctx, collegeID, to, subject, body, false)
+}
+
+func (s *deliveryService) queue(ctx context.Context, collegeID int, to, subject, body string, deadLetter bool) error {
+	if _, err := ParseRecipients(to); err != nil {
+		return err
+	}
+
+	// Delivery outlives the request that queued it
+	go s.deliver(context.WithoutCancel(ctx), &models.EmailDeadLetter{
+		CollegeID: collegeID,
+		Recipient: to,
+		Subject:   subject,
+		Body:      body,
+	}, deadLetter)
+	return nil
+}
+
+// deliver sends msg, retrying with exponential backoff. When the last attempt
+// fails msg is stored as a dead letter, or with deadLetter unset only logged.
+func (s *deliveryService) deliver(ctx context.Context, msg *models.EmailDeadLetter, deadLetter bool) {
+	backoff := s.retryBackoff
+	for attempt := 1; ; attempt++ {
+		s.inFlight <- struct{}{}
+		err := s.emailService.SendEmail(ctx, msg.Recipient, msg.Subject, msg.Body)
+		<-s.inFlight
+		if err == nil {
+			return
+		}
+
+		var rcptErr *RecipientError
+		if attempt >= s.maxAttempts || errors.As(err, &rcptErr) {
+			if !deadLetter {
+				s.logger.Error().Err(err).Int("college_id", msg.CollegeID).Int("attempts", attempt).
+					Msg("failed to send email; it holds a secret so it was not dead-lettered")
+				return
+			}
+			msg.Attempts = attempt
+			msg.LastError = err.Error()
+			if recErr := s.repo.CreateDeadLetter(ctx, msg); recErr != nil {
+				s.logger.Error().Err(recErr).Str("send_error", err.Error()).Int("college_id", msg.CollegeID).
+					Msg("failed to dead-letter email; the message is lost")
+				return
+			}
+			s.logger.Warn().Err(err).Int("college_id", msg.CollegeID).Int("dead_letter_id", msg.ID).
+				Int("attempts", attempt).Msg("email dead-lettered")
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (s *deliveryService) ListDeadLetters(ctx context.Context, collegeID int, includeResent bool, limit, offset int) ([]*models.EmailDeadLetter, error) {
+	if limit <= 0 || limit > maxDeadLetterPageSize {
+		limit = maxDeadLetterPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.repo.ListDeadLetters(ctx, collegeID, includeResent, limit, offset)
+}
+
+func (s *deliveryService) RetryDeadLetter(ctx context.Context, collegeID, letterID int) (*models.EmailDeadLetter, error) {
+	letter, err := s.repo.ClaimDeadLetter(ctx, collegeID, letterID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A failed send clears the claim so the letter can be retried again
+	sendErr := s.emailService.SendEmail(ctx, letter.Recipient, letter.Subject, letter.Body)
+	if err := s.repo.RecordDeadLetterAttempt(ctx, collegeID, letterID, sendErr); err != nil {
+		return nil, err
+	}
+	if sendErr != nil {
+		return nil, fmt.Errorf("failed to resend email: %w", sendErr)
+	}
+	return s.repo.GetDeadLetter(ctx, collegeID, letterID)
+}
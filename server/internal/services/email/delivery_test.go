@@ -0,0 +1,209 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyEmailService fails its first `failures` sends
+type flakyEmailService struct {
+	EmailService
+	mu       sync.Mutex
+	failures int
+	calls    int
+}
+
+func (s *flakyEmailService) SendEmail(ctx context.Context, to, subject, body string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if s.calls <= s.failures {
+		return errors.New("smtp unavailable")
+	}
+	return nil
+}
+
+type deadLetterRepo struct {
+	repository.EmailDeadLetterRepository
+	mu       sync.Mutex
+	letters  map[int]*models.EmailDeadLetter
+	attempts []error
+}
+
+func (r *deadLetterRepo) CreateDeadLetter(ctx context.Context, letter *models.EmailDeadLetter) error {
+	letter.ID = len(r.letters) + 1
+	r.letters[letter.ID] = letter
+	return nil
+}
+
+func (r *deadLetterRepo) GetDeadLetter(ctx context.Context, collegeID, letterID int) (*models.EmailDeadLetter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	letter, ok := r.letters[letterID]
+	if !ok || letter.CollegeID != collegeID {
+		return nil, repository.ErrEmailDeadLetterNotFound
+	}
+	return letter, nil
+}
+
+func (r *deadLetterRepo) ClaimDeadLetter(ctx context.Context, collegeID, letterID int) (*models.EmailDeadLetter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	letter, ok := r.letters[letterID]
+	if !ok || letter.CollegeID != collegeID {
+		return nil, repository.ErrEmailDeadLetterNotFound
+	}
+	if letter.ResentAt != nil {
+		return nil, repository.ErrEmailDeadLetterResent
+	}
+	now := time.Now()
+	letter.ResentAt = &now
+	return letter, nil
+}
+
+func (r *deadLetterRepo) RecordDeadLetterAttempt(ctx context.Context, collegeID, letterID int, sendErr error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attempts = append(r.attempts, sendErr)
+	if sendErr != nil {
+		r.letters[letterID].ResentAt = nil
+	}
+	return nil
+}
+
+func newTestDeliveryService(mailer EmailService, repo *deadLetterRepo) *deliveryService {
+	return &deliveryService{
+		emailService: mailer,
+		repo:         repo,
+		maxAttempts:  defaultMaxAttempts,
+		retryBackoff: time.Millisecond,
+		inFlight:     make(chan struct{}, defaultMaxInFlight),
+		logger:       zerolog.Nop(),
+	}
+}
+
+func TestDeliverRetriesUntilSent(t *testing.T) {
+	mailer := &flakyEmailService{failures: 2}
+	repo := &deadLetterRepo{letters: map[int]*models.EmailDeadLetter{}}
+
+	newTestDeliveryService(mailer, repo).deliver(context.Background(), &models.EmailDeadLetter{CollegeID: 1, Recipient: "asha@example.com"}, true)
+
+	assert.Equal(t, 3, mailer.calls)
+	assert.Empty(t, repo.letters)
+}
+
+func TestDeliverDeadLettersAfterMaxAttempts(t *testing.T) {
+	mailer := &flakyEmailService{failures: defaultMaxAttempts}
+	repo := &deadLetterRepo{letters: map[int]*models.EmailDeadLetter{}}
+
+	newTestDeliveryService(mailer, repo).deliver(context.Background(), &models.EmailDeadLetter{
+		CollegeID: 1, Recipient: "asha@example.com", Subject: "Hello", Body: "<p>Hi</p>",
+	}, true)
+
+	assert.Equal(t, defaultMaxAttempts, mailer.calls)
+	require.Contains(t, repo.letters, 1)
+	assert.Equal(t, defaultMaxAttempts, repo.letters[1].Attempts)
+	assert.Equal(t, "smtp unavailable", repo.letters[1].LastError)
+	assert.Equal(t, "Hello", repo.letters[1].Subject)
+}
+
+func TestDeliverKeepsSecretsOutOfDeadLetters(t *testing.T) {
+	mailer := &flakyEmailService{failures: defaultMaxAttempts}
+	repo := &deadLetterRepo{letters: map[int]*models.EmailDeadLetter{}}
+
+	newTestDeliveryService(mailer, repo).deliver(context.Background(), &models.EmailDeadLetter{
+		CollegeID: 1, Recipient: "ravi@example.com", Subject: "Confirm", Body: `<a href="https://app.example.com/verify?token=secret">`,
+	}, false)
+
+	assert.Equal(t, defaultMaxAttempts, mailer.calls)
+	assert.Empty(t, repo.letters)
+}
+
+func TestSendAsyncRejectsInvalidRecipient(t *testing.T) {
+	mailer := &flakyEmailService{}
+	svc := newTestDeliveryService(mailer, &deadLetterRepo{letters: map[int]*models.EmailDeadLetter{}})
+
+	err := svc.SendAsync(context.Background(), 1, "not-an-address", "Hello", "<p>Hi</p>")
+	assert.ErrorIs(t, err, ErrInvalidRecipient)
+}
+
+func TestRetryDeadLetter(t *testing.T) {
+	newRepo := func() *deadLetterRepo {
+		return &deadLetterRepo{letters: map[int]*models.EmailDeadLetter{
+			1: {ID: 1, CollegeID: 1, Recipient: "asha@example.com", Subject: "Hello", Body: "<p>Hi</p>", Attempts: 4},
+		}}
+	}
+
+	t.Run("resends and marks the letter", func(t *testing.T) {
+		repo := newRepo()
+		letter, err := newTestDeliveryService(&flakyEmailService{}, repo).RetryDeadLetter(context.Background(), 1, 1)
+		require.NoError(t, err)
+		assert.NotNil(t, letter.ResentAt)
+		assert.Equal(t, []error{nil}, repo.attempts)
+	})
+
+	t.Run("records a failed resend and releases the letter", func(t *testing.T) {
+		repo := newRepo()
+		svc := newTestDeliveryService(&flakyEmailService{failures: 1}, repo)
+		_, err := svc.RetryDeadLetter(context.Background(), 1, 1)
+		assert.ErrorContains(t, err, "smtp unavailable")
+		require.Len(t, repo.attempts, 1)
+		assert.Error(t, repo.attempts[0])
+
+		// The failed resend does not block the next one
+		_, err = svc.RetryDeadLetter(context.Background(), 1, 1)
+		require.NoError(t, err)
+	})
+
+	t.Run("concurrent retries send once", func(t *testing.T) {
+		mailer := &flakyEmailService{}
+		svc := newTestDeliveryService(mailer, newRepo())
+
+		var wg sync.WaitGroup
+		errs := make([]error, 5)
+		for i := range errs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, errs[i] = svc.RetryDeadLetter(context.Background(), 1, 1)
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Equal(t, 1, mailer.calls)
+		resent := 0
+		for _, err := range errs {
+			if err == nil {
+				resent++
+			} else {
+				assert.ErrorIs(t, err, ErrEmailAlreadyResent)
+			}
+		}
+		assert.Equal(t, 1, resent)
+	})
+
+	t.Run("letter of another college is not found", func(t *testing.T) {
+		_, err := newTestDeliveryService(&flakyEmailService{}, newRepo()).RetryDeadLetter(context.Background(), 2, 1)
+		assert.ErrorIs(t, err, ErrEmailDeadLetterNotFound)
+	})
+
+	t.Run("resent letter is not sent twice", func(t *testing.T) {
+		repo := newRepo()
+		svc := newTestDeliveryService(&flakyEmailService{}, repo)
+		_, err := svc.RetryDeadLetter(context.Background(), 1, 1)
+		require.NoError(t, err)
+
+		_, err = svc.RetryDeadLetter(context.Background(), 1, 1)
+		assert.ErrorIs(t, err, ErrEmailAlreadyResent)
+	})
+}
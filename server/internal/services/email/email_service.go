@@ -1,15 +1,17 @@
 package email
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"html/template"
 	"net/smtp"
+	"strings"
 )
 
 type EmailService interface {
 	SendEmail(ctx context.Context, to, subject, body string) error
+	// SendTemplate renders one of the embedded templates (see the Template
+	// constants) with data and sends it with the template's own subject
+	SendTemplate(ctx context.Context, to, templateName string, data any) error
 	SendTemplateEmail(ctx context.Context, to, subject, templateName string, data any) error
 	SendBulkEmail(ctx context.Context, recipients []string, subject, body string) error
 	SendWelcomeEmail(ctx context.Context, to, name string) error
@@ -24,85 +26,55 @@ type emailService struct {
 	smtpUsername string
 	smtpPassword string
 	fromAddress  string
-	templates    map[string]*template.Template
 }
 
 func NewEmailService(host, port, username, password, fromAddress string) EmailService {
-	service := &emailService{
+	return &emailService{
 		smtpHost:     host,
 		smtpPort:     port,
 		smtpUsername: username,
 		smtpPassword: password,
 		fromAddress:  fromAddress,
-		templates:    make(map[string]*template.Template),
 	}
-
-	// Load email templates
-	service.loadTemplates()
-
-	return service
-}
-
-func (s *emailService) loadTemplates() {
-	// Welcome email template
-	welcomeTmpl := `
-	<html>
-		<body>
-			<h2>Welcome to EduHub, {{.Name}}!</h2>
-			<p>Your account has been successfully created.</p>
-			<p>You can now log in and access all features.</p>
-		</body>
-	</html>
-	`
-	s.templates["welcome"], _ = template.New("welcome").Parse(welcomeTmpl)
-
-	// Password reset template
-	resetTmpl := `
-	<html>
-		<body>
-			<h2>Password Reset Request</h2>
-			<p>Click the link below to reset your password:</p>
-			<p><a href="{{.ResetLink}}">Reset Password</a></p>
-			<p>This link will expire in 24 hours.</p>
-		</body>
-	</html>
-	`
-	s.templates["reset"], _ = template.New("reset").Parse(resetTmpl)
-
-	// Grade notification template
-	gradeTmpl := `
-	<html>
-		<body>
-			<h2>New Grade Posted</h2>
-			<p>Hello {{.StudentName}},</p>
-			<p>A new grade has been posted for <strong>{{.CourseName}}</strong>.</p>
-			<p>Your grade: <strong>{{.Grade}}</strong></p>
-		</body>
-	</html>
-	`
-	s.templates["grade"], _ = template.New("grade").Parse(gradeTmpl)
 }
 
+// SendEmail sends body to every address in to, a comma-separated RFC 5322
+// address list. An address that does not parse fails the whole send with a
+// *RecipientError naming it.
 func (s *emailService) SendEmail(ctx context.Context, to, subject, body string) error {
+	recipients, err := ParseRecipients(to)
+	if err != nil {
+		return err
+	}
+	// A line break in the subject would let it add headers of its own
+	subject = headerValue(subject)
+
 	if s.smtpHost == "" {
 		// Email not configured, return error instead of failing silently
 		return fmt.Errorf("SMTP not configured: cannot send email to %s", to)
 	}
 
+	toHeader := make([]string, len(recipients))
+	addresses := make([]string, len(recipients))
+	for i, rcpt := range recipients {
+		toHeader[i] = rcpt.String()
+		addresses[i] = rcpt.Address
+	}
+
 	// Compose email
 	msg := fmt.Appendf(nil, "From: %s\r\n"+
 		"To: %s\r\n"+
 		"Subject: %s\r\n"+
 		"Content-Type: text/html; charset=UTF-8\r\n"+
 		"\r\n"+
-		"%s\r\n", s.fromAddress, to, subject, body)
+		"%s\r\n", s.fromAddress, strings.Join(toHeader, ", "), subject, body)
 
 	// SMTP authentication
 	auth := smtp.PlainAuth("", s.smtpUsername, s.smtpPassword, s.smtpHost)
 
 	// Send email
 	addr := fmt.Sprintf("%s:%s", s.smtpHost, s.smtpPort)
-	err := smtp.SendMail(addr, auth, s.fromAddress, []string{to}, msg)
+	err = smtp.SendMail(addr, auth, s.fromAddress, addresses, msg)
 	if err != nil {
 		return fmt.Errorf("failed to send email: %w", err)
 	}
@@ -110,18 +82,21 @@ func (s *emailService) SendEmail(ctx context.Context, to, subject, body string)
 	return nil
 }
 
-func (s *emailService) SendTemplateEmail(ctx context.Context, to, subject, templateName string, data any) error {
-	tmpl, ok := s.templates[templateName]
-	if !ok {
-		return fmt.Errorf("template %s not found", templateName)
+func (s *emailService) SendTemplate(ctx context.Context, to, templateName string, data any) error {
+	subject, body, err := Render(templateName, data)
+	if err != nil {
+		return err
 	}
+	return s.SendEmail(ctx, to, subject, body)
+}
 
-	var body bytes.Buffer
-	if err := tmpl.Execute(&body, data); err != nil {
+// SendTemplateEmail sends a template with subject in place of the template's own
+func (s *emailService) SendTemplateEmail(ctx context.Context, to, subject, templateName string, data any) error {
+	_, body, err := Render(templateName, data)
+	if err != nil {
 		return err
 	}
-
-	return s.SendEmail(ctx, to, subject, body.String())
+	return s.SendEmail(ctx, to, subject, body)
 }
 
 func (s *emailService) SendBulkEmail(ctx context.Context, recipients []string, subject, body string) error {
@@ -143,12 +118,12 @@ func (s *emailService) SendBulkEmail(ctx context.Context, recipients []string, s
 
 func (s *emailService) SendWelcomeEmail(ctx context.Context, to, name string) error {
 	data := map[string]string{"Name": name}
-	return s.SendTemplateEmail(ctx, to, "Welcome to EduHub", "welcome", data)
+	return s.SendTemplate(ctx, to, TemplateWelcome, data)
 }
 
 func (s *emailService) SendPasswordResetEmail(ctx context.Context, to, resetLink string) error {
 	data := map[string]string{"ResetLink": resetLink}
-	return s.SendTemplateEmail(ctx, to, "Password Reset Request", "reset", data)
+	return s.SendTemplate(ctx, to, TemplatePasswordReset, data)
 }
 
 func (s *emailService) SendGradeNotification(ctx context.Context, to, studentName, courseName string, grade float64) error {
@@ -157,10 +132,13 @@ func (s *emailService) SendGradeNotification(ctx context.Context, to, studentNam
 		"CourseName":  courseName,
 		"Grade":       grade,
 	}
-	return s.SendTemplateEmail(ctx, to, "New Grade Posted", "grade", data)
+	return s.SendTemplate(ctx, to, TemplateGrade, data)
 }
 
 func (s *emailService) SendAnnouncementEmail(ctx context.Context, recipients []string, announcement string) error {
-	body := fmt.Sprintf("<html><body><h2>New Announcement</h2><p>%s</p></body></html>", announcement)
-	return s.SendBulkEmail(ctx, recipients, "New Announcement", body)
+	subject, body, err := Render(TemplateAnnouncement, map[string]string{"Announcement": announcement})
+	if err != nil {
+		return err
+	}
+	return s.SendBulkEmail(ctx, recipients, subject, body)
 }
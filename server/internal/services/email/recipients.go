@@ -0,0 +1,48 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// ErrInvalidRecipient is wrapped by every RecipientError
+var ErrInvalidRecipient = errors.New("invalid recipient address")
+
+// RecipientError names the recipient address an email was rejected for
+type RecipientError struct {
+	Address string
+	Detail  string
+}
+
+func (e *RecipientError) Error() string {
+	return fmt.Sprintf("%v %q: %s", ErrInvalidRecipient, e.Address, e.Detail)
+}
+
+func (e *RecipientError) Unwrap() error {
+	return ErrInvalidRecipient
+}
+
+// ParseRecipients parses a comma-separated RFC 5322 address list such as
+// "Asha <asha@example.com>, ravi@example.com". Addresses with line breaks are
+// rejected outright since they could add headers to the message.
+func ParseRecipients(to string) ([]*mail.Address, error) {
+	for _, part := range strings.Split(to, ",") {
+		if strings.ContainsAny(part, "\r\n") {
+			return nil, &RecipientError{Address: strings.TrimSpace(part), Detail: "contains a line break"}
+		}
+	}
+
+	recipients, err := mail.ParseAddressList(to)
+	if err == nil {
+		return recipients, nil
+	}
+	// The list error does not say which address failed, so look for it
+	for _, part := range strings.Split(to, ",") {
+		if _, partErr := mail.ParseAddress(part); partErr != nil {
+			return nil, &RecipientError{Address: strings.TrimSpace(part), Detail: strings.TrimPrefix(partErr.Error(), "mail: ")}
+		}
+	}
+	return nil, &RecipientError{Address: strings.TrimSpace(to), Detail: strings.TrimPrefix(err.Error(), "mail: ")}
+}
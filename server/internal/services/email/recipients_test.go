@@ -0,0 +1,55 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecipients(t *testing.T) {
+	t.Run("parses an address list", func(t *testing.T) {
+		recipients, err := ParseRecipients(`Asha <asha@example.com>, ravi@example.com`)
+		require.NoError(t, err)
+		require.Len(t, recipients, 2)
+		assert.Equal(t, "Asha", recipients[0].Name)
+		assert.Equal(t, "asha@example.com", recipients[0].Address)
+		assert.Equal(t, "ravi@example.com", recipients[1].Address)
+	})
+
+	t.Run("names the invalid address", func(t *testing.T) {
+		_, err := ParseRecipients("asha@example.com, not-an-address")
+		var rcptErr *RecipientError
+		require.ErrorAs(t, err, &rcptErr)
+		assert.Equal(t, "not-an-address", rcptErr.Address)
+		assert.ErrorIs(t, err, ErrInvalidRecipient)
+	})
+
+	t.Run("rejects line breaks", func(t *testing.T) {
+		_, err := ParseRecipients("asha@example.com\nBcc: victim@example.com")
+		var rcptErr *RecipientError
+		require.ErrorAs(t, err, &rcptErr)
+		assert.Equal(t, "contains a line break", rcptErr.Detail)
+	})
+
+	t.Run("rejects an empty list", func(t *testing.T) {
+		_, err := ParseRecipients(" ")
+		assert.ErrorIs(t, err, ErrInvalidRecipient)
+	})
+}
+
+func TestSendEmailValidatesRecipients(t *testing.T) {
+	svc := NewEmailService("", "", "", "", "noreply@example.com")
+
+	err := svc.SendEmail(context.Background(), "parent@example.com, parent@", "Hello", "<p>Hi</p>")
+	var rcptErr *RecipientError
+	require.ErrorAs(t, err, &rcptErr)
+	assert.Equal(t, "parent@", rcptErr.Address)
+
+	// Valid recipients get as far as the missing SMTP configuration
+	err = svc.SendEmail(context.Background(), "parent@example.com", "Hello", "<p>Hi</p>")
+	assert.False(t, errors.Is(err, ErrInvalidRecipient))
+	assert.ErrorContains(t, err, "SMTP not configured")
+}
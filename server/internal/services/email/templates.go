@@ -0,0 +1,170 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html"
+	"html/template"
+	"path"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"eduhub/server/internal/i18n"
+)
+
+// Template names accepted by SendTemplate. Each names a file under templates/
+// that defines a "subject" and a "body" template.
+const (
+	TemplateWelcome         = "welcome"
+	TemplatePasswordReset   = "reset"
+	TemplateGrade           = "grade"
+	TemplateAnnouncement    = "announcement"
+	TemplateParentContact   = "parent_contact"
+	TemplateResultPublished = "result_published"
+	TemplateExamCancelled   = "exam_cancelled"
+	TemplateAttendanceAlert = "attendance_alert"
+	TemplateParentLink      = "parent_link"
+)
+
+//go:embed templates/*.html
+var templateFiles embed.FS
+
+// ParentContactData fills the parent_contact template
+type ParentContactData struct {
+	Subject    string
+	ParentName string
+	Phone      string
+	Message    string
+}
+
+// ResultPublishedData fills the result_published template. StudentName is
+// only shown to parents. Locale picks the language, defaulting to English.
+type ResultPublishedData struct {
+	Name        string
+	StudentName string
+	IsParent    bool
+	ExamTitle   string
+	ResultsURL  string
+	Locale      string
+}
+
+// ExamCancelledData fills the exam_cancelled template
+type ExamCancelledData struct {
+	Name      string
+	ExamTitle string
+	StartTime time.Time
+	Reason    string
+}
+
+// AttendanceAlertData fills the attendance_alert template
+type AttendanceAlertData struct {
+	ParentName     string
+	StudentName    string
+	RollNo         string
+	PresentCount   int
+	TotalSessions  int
+	WindowDays     int
+	AttendanceRate float64
+	Threshold      float64
+}
+
+// ParentLinkData fills the parent_link template
+type ParentLinkData struct {
+	ParentName     string
+	Relation       string
+	VerifyURL      string
+	ExpiresInHours int
+}
+
+// emailTemplate is one template file: the subject is rendered as plain text
+// and the body as auto-escaped HTML
+type emailTemplate struct {
+	subject *texttemplate.Template
+	body    *template.Template
+}
+
+var templateFuncs = map[string]any{
+	// lines escapes s and keeps its line breaks
+	"lines": func(s string) template.HTML {
+		return template.HTML(strings.ReplaceAll(html.EscapeString(s), "\n", "<br/>"))
+	},
+	// strong escapes s and prints it in bold, for use as an argument of t
+	"strong": func(s string) template.HTML {
+		return template.HTML("<strong>" + html.EscapeString(s) + "</strong>")
+	},
+	// t translates a key into the locale. Its arguments are escaped, but the
+	// translation itself is trusted like the template around it.
+	"t": func(locale, key string, args ...any) template.HTML {
+		for i, arg := range args {
+			if s, ok := arg.(string); ok {
+				args[i] = html.EscapeString(s)
+			}
+		}
+		return template.HTML(i18n.T(locale, key, args...))
+	},
+}
+
+// subjectFuncs are templateFuncs for the plain text subject, where nothing
+// is escaped
+var subjectFuncs = map[string]any{
+	"lines":  func(s string) string { return s },
+	"strong": func(s string) string { return s },
+	"t":      i18n.T,
+}
+
+// templates holds every embedded template by name; a template that does not
+// parse fails at startup rather than on first send
+var templates = mustLoadTemplates()
+
+func mustLoadTemplates() map[string]*emailTemplate {
+	files, err := templateFiles.ReadDir("templates")
+	if err != nil {
+		panic(err)
+	}
+
+	loaded := make(map[string]*emailTemplate, len(files))
+	for _, file := range files {
+		src, err := templateFiles.ReadFile(path.Join("templates", file.Name()))
+		if err != nil {
+			panic(err)
+		}
+		name := strings.TrimSuffix(file.Name(), path.Ext(file.Name()))
+		loaded[name] = &emailTemplate{
+			subject: texttemplate.Must(texttemplate.New(name).Funcs(subjectFuncs).Parse(string(src))).Lookup("subject"),
+			body:    template.Must(template.New(name).Funcs(templateFuncs).Parse(string(src))).Lookup("body"),
+		}
+		if loaded[name].subject == nil || loaded[name].body == nil {
+			panic(fmt.Sprintf("email template %s must define subject and body", name))
+		}
+	}
+	return loaded
+}
+
+// Render executes the named template with data and returns its subject and
+// HTML body. Values in data are escaped in the body; the subject is plain
+// text and has line breaks removed so it cannot add headers.
+func Render(templateName string, data any) (subject, body string, err error) {
+	tmpl, ok := templates[templateName]
+	if !ok {
+		return "", "", fmt.Errorf("template %s not found", templateName)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.subject.Execute(&buf, data); err != nil {
+		return "", "", fmt.Errorf("failed to render %s subject: %w", templateName, err)
+	}
+	subject = headerValue(buf.String())
+
+	buf.Reset()
+	if err := tmpl.body.Execute(&buf, data); err != nil {
+		return "", "", fmt.Errorf("failed to render %s body: %w", templateName, err)
+	}
+	return subject, buf.String(), nil
+}
+
+// headerValue folds line breaks into spaces so a value cannot end its header
+func headerValue(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
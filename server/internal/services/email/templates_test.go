@@ -0,0 +1,84 @@
+package email
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	t.Run("every template renders", func(t *testing.T) {
+		data := map[string]any{
+			TemplateWelcome:         map[string]string{"Name": "Asha"},
+			TemplatePasswordReset:   map[string]string{"ResetLink": "https://app.example.com/reset"},
+			TemplateGrade:           map[string]any{"StudentName": "Asha", "CourseName": "Physics", "Grade": 91.5},
+			TemplateAnnouncement:    map[string]string{"Announcement": "Campus closed"},
+			TemplateParentContact:   ParentContactData{Subject: "Hello", Message: "Please call"},
+			TemplateResultPublished: ResultPublishedData{Name: "Asha", ExamTitle: "Midterm"},
+			TemplateExamCancelled:   ExamCancelledData{Name: "Asha", ExamTitle: "Midterm", StartTime: time.Now()},
+			TemplateAttendanceAlert: AttendanceAlertData{ParentName: "Ravi", StudentName: "Asha", AttendanceRate: 61.5, Threshold: 75},
+			TemplateParentLink:      ParentLinkData{ParentName: "Ravi", Relation: "father", VerifyURL: "https://app.example.com/verify?token=abc", ExpiresInHours: 48},
+		}
+		require.Len(t, data, len(templates))
+		for name, d := range data {
+			subject, body, err := Render(name, d)
+			require.NoError(t, err, name)
+			assert.NotEmpty(t, subject, name)
+			assert.Contains(t, body, "<html>", name)
+		}
+	})
+
+	t.Run("values are escaped in the body", func(t *testing.T) {
+		_, body, err := Render(TemplateParentContact, ParentContactData{
+			Subject:    "Hello",
+			ParentName: `<img src=x onerror="alert(1)">`,
+			Message:    "Line one\n<script>alert(2)</script>",
+		})
+		require.NoError(t, err)
+		assert.NotContains(t, body, "<img")
+		assert.NotContains(t, body, "<script>")
+		assert.Contains(t, body, "Line one<br/>&lt;script&gt;")
+	})
+
+	t.Run("line breaks cannot add headers through the subject", func(t *testing.T) {
+		subject, _, err := Render(TemplateParentContact, ParentContactData{Subject: "Hello\r\nBcc: victim@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, "Hello Bcc: victim@example.com", subject)
+	})
+
+	t.Run("numbers are formatted", func(t *testing.T) {
+		_, body, err := Render(TemplateAttendanceAlert, AttendanceAlertData{
+			StudentName: "Asha", PresentCount: 8, TotalSessions: 13, WindowDays: 30, AttendanceRate: 61.538, Threshold: 75,
+		})
+		require.NoError(t, err)
+		assert.Contains(t, body, "attended 8 of 13 sessions in the last 30 days, an attendance rate of 61.54%. This is below the expected 75%.")
+	})
+
+	t.Run("result published is translated", func(t *testing.T) {
+		data := ResultPublishedData{Name: "Ravi", StudentName: "Asha", IsParent: true, ExamTitle: "Midterm", Locale: "hi-IN"}
+		subject, body, err := Render(TemplateResultPublished, data)
+		require.NoError(t, err)
+		assert.Equal(t, "परिणाम प्रकाशित: Midterm", subject)
+		assert.Contains(t, body, "प्रिय Ravi,")
+		assert.Contains(t, body, "<strong>Midterm</strong> में Asha का परिणाम प्रकाशित हो गया है।")
+
+		data.Locale = "xx"
+		subject, body, err = Render(TemplateResultPublished, data)
+		require.NoError(t, err)
+		assert.Equal(t, "Results published: Midterm", subject)
+		assert.Contains(t, body, "Asha's result for <strong>Midterm</strong> has been published.")
+	})
+
+	t.Run("translated arguments are escaped", func(t *testing.T) {
+		_, body, err := Render(TemplateResultPublished, ResultPublishedData{Name: "<b>Asha</b>", ExamTitle: "Midterm", Locale: "hi"})
+		require.NoError(t, err)
+		assert.NotContains(t, body, "<b>")
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		_, _, err := Render("missing", nil)
+		assert.Error(t, err)
+	})
+}
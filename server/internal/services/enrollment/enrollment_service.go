@@ -6,6 +6,7 @@ import (
 
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/repository"
+	"eduhub/server/internal/validation"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -29,7 +30,7 @@ type enrollmentService struct {
 func NewEnrollmentService(enrollmentRepo repository.EnrollmentRepository) EnrollmentService {
 	return &enrollmentService{
 		enrollmentRepo: enrollmentRepo,
-		validate:       validator.New(),
+		validate:       validation.New(),
 	}
 }
 
@@ -0,0 +1,110 @@
+package exam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+	"eduhub/server/internal/services/webhook"
+)
+
+const maxCancellationReasonLen = 500
+
+// ErrExamNotCancellable is returned by CancelExam for an exam that is already
+// cancelled or completed
+var ErrExamNotCancellable = repository.ErrExamNotCancellable
+
+// ErrInvalidCancellation is returned by CancelExam for a missing or oversized
+// reason
+var ErrInvalidCancellation = errors.New("invalid exam cancellation")
+
+// CancelExam cancels an exam that has not been completed, keeping its record
+// and enrollments. Cancelled exams no longer hold their room, and enrolled
+// students are told in their inbox and by email. Notification problems never
+// undo the cancellation.
+func (s *examService) CancelExam(ctx context.Context, collegeID, examID int, reason string) (*models.Exam, error) {
+	if collegeID == 0 || examID == 0 {
+		return nil, errors.New("invalid college ID or exam ID")
+	}
+	reason = strings.TrimSpace(reason)
+	switch {
+	case reason == "":
+		return nil, fmt.Errorf("%w: a reason is required", ErrInvalidCancellation)
+	case len(reason) > maxCancellationReasonLen:
+		return nil, fmt.Errorf("%w: reason must be at most %d characters", ErrInvalidCancellation, maxCancellationReasonLen)
+	}
+
+	exam, err := s.repo.GetExamByID(ctx, collegeID, examID)
+	if err != nil {
+		return nil, err
+	}
+	if exam.Status == "cancelled" || exam.Status == "completed" {
+		return nil, fmt.Errorf("%w: exam is %s", ErrExamNotCancellable, exam.Status)
+	}
+	if err := s.repo.CancelExam(ctx, exam, reason); err != nil {
+		return nil, err
+	}
+
+	s.emit(ctx, collegeID, webhook.EventExamCancelled, map[string]any{
+		"exam_id":    exam.ID,
+		"exam_title": exam.Title,
+		"course_id":  exam.CourseID,
+		"reason":     reason,
+	})
+	s.notifyExamCancelled(ctx, collegeID, exam, reason)
+	return exam, nil
+}
+
+// notifyExamCancelled tells every student enrolled in the exam that it was
+// cancelled, in their inbox and by email
+func (s *examService) notifyExamCancelled(ctx context.Context, collegeID int, exam *models.Exam, reason string) {
+	if s.inbox == nil && s.notifier == nil {
+		return
+	}
+	enrollments, err := s.repo.ListEnrollments(ctx, exam.ID)
+	if err != nil {
+		s.logger.Error().Err(err).Int("exam_id", exam.ID).Msg("failed to load enrollments of cancelled exam")
+		return
+	}
+	if len(enrollments) == 0 {
+		return
+	}
+	studentIDs := make([]int, len(enrollments))
+	for i, enrollment := range enrollments {
+		studentIDs[i] = enrollment.StudentID
+	}
+
+	if s.inbox != nil {
+		userIDs, err := s.repo.ListResultInboxUserIDs(ctx, collegeID, studentIDs, false)
+		if err != nil {
+			s.logger.Error().Err(err).Int("exam_id", exam.ID).Msg("failed to load cancellation inbox users")
+		} else {
+			entityType := models.NotificationEntityExam
+			examID := exam.ID
+			s.inbox.Notify(ctx, collegeID, userIDs, &models.Notification{
+				Title:             fmt.Sprintf("Exam cancelled: %s", exam.Title),
+				Message:           fmt.Sprintf("%s has been cancelled. Reason: %s", exam.Title, reason),
+				Type:              "warning",
+				RelatedEntityType: &entityType,
+				RelatedEntityID:   &examID,
+			})
+		}
+	}
+
+	if s.notifier == nil {
+		return
+	}
+	recipients, err := s.repo.ListResultNotificationRecipients(ctx, collegeID, studentIDs, false)
+	if err != nil {
+		s.logger.Error().Err(err).Int("exam_id", exam.ID).Msg("failed to load cancellation email recipients")
+		return
+	}
+	go func() {
+		notifyCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), resultEmailTimeout)
+		defer cancel()
+		s.notifier.NotifyCancelled(notifyCtx, exam, reason, recipients)
+	}()
+}
@@ -0,0 +1,114 @@
+package exam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cancelRepo struct {
+	repository.ExamRepository
+	exam      *models.Exam
+	cancelled string
+}
+
+func (r *cancelRepo) GetExamByID(ctx context.Context, collegeID, examID int) (*models.Exam, error) {
+	if r.exam.CollegeID != collegeID || r.exam.ID != examID {
+		return nil, repository.ErrExamNotFound
+	}
+	exam := *r.exam
+	return &exam, nil
+}
+
+func (r *cancelRepo) CancelExam(ctx context.Context, exam *models.Exam, reason string) error {
+	r.cancelled = reason
+	exam.Status = "cancelled"
+	exam.CancellationReason = &reason
+	return nil
+}
+
+func (r *cancelRepo) ListEnrollments(ctx context.Context, examID int) ([]*models.ExamEnrollment, error) {
+	return []*models.ExamEnrollment{{StudentID: 5}, {StudentID: 6}}, nil
+}
+
+func (r *cancelRepo) ListResultInboxUserIDs(ctx context.Context, collegeID int, studentIDs []int, includeParents bool) ([]int, error) {
+	return []int{105, 106}, nil
+}
+
+func (r *cancelRepo) ListResultNotificationRecipients(ctx context.Context, collegeID int, studentIDs []int, includeParents bool) ([]*models.ResultNotificationRecipient, error) {
+	return []*models.ResultNotificationRecipient{
+		{StudentID: 5, Name: "Asha", Email: "asha@example.com"},
+		{StudentID: 6, Name: "Dev", Email: "dev@example.com"},
+	}, nil
+}
+
+func TestCancelExam(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 5, 10, 9, 0, 0, 0, time.UTC)
+	newFixture := func(status string) (*examService, *cancelRepo, *recordingInbox, *recordingDelivery) {
+		repo := &cancelRepo{exam: &models.Exam{ID: 7, CollegeID: 1, Title: "Midterm", Status: status, StartTime: start}}
+		inbox := &recordingInbox{}
+		mailer := &recordingDelivery{sent: map[string]string{}}
+		svc := &examService{
+			repo:     repo,
+			inbox:    inbox,
+			notifier: NewResultNotifier(mailer, "", false, zerolog.Nop()),
+			logger:   zerolog.Nop(),
+		}
+		return svc, repo, inbox, mailer
+	}
+
+	t.Run("cancels and notifies enrolled students", func(t *testing.T) {
+		svc, repo, inbox, mailer := newFixture("scheduled")
+		exam, err := svc.CancelExam(ctx, 1, 7, "  Hall flooded ")
+		require.NoError(t, err)
+		assert.Equal(t, "cancelled", exam.Status)
+		assert.Equal(t, "Hall flooded", repo.cancelled)
+
+		require.Len(t, inbox.notifications, 1)
+		assert.Equal(t, []int{105, 106}, inbox.userIDs[0])
+		assert.Equal(t, "Exam cancelled: Midterm", inbox.notifications[0].Title)
+
+		assert.Eventually(t, func() bool {
+			mailer.mu.Lock()
+			defer mailer.mu.Unlock()
+			return len(mailer.sent) == 2
+		}, time.Second, 10*time.Millisecond)
+		mailer.mu.Lock()
+		defer mailer.mu.Unlock()
+		assert.Contains(t, mailer.sent["asha@example.com"], "Reason: Hall flooded")
+	})
+
+	t.Run("completed exam", func(t *testing.T) {
+		svc, repo, inbox, _ := newFixture("completed")
+		_, err := svc.CancelExam(ctx, 1, 7, "too late")
+		assert.ErrorIs(t, err, ErrExamNotCancellable)
+		assert.Empty(t, repo.cancelled)
+		assert.Empty(t, inbox.notifications)
+	})
+
+	t.Run("already cancelled", func(t *testing.T) {
+		svc, _, _, _ := newFixture("cancelled")
+		_, err := svc.CancelExam(ctx, 1, 7, "again")
+		assert.ErrorIs(t, err, ErrExamNotCancellable)
+	})
+
+	t.Run("reason required", func(t *testing.T) {
+		svc, _, _, _ := newFixture("scheduled")
+		_, err := svc.CancelExam(ctx, 1, 7, " ")
+		assert.ErrorIs(t, err, ErrInvalidCancellation)
+	})
+
+	t.Run("exam of another college", func(t *testing.T) {
+		svc, _, _, _ := newFixture("scheduled")
+		_, err := svc.CancelExam(ctx, 2, 7, "Hall flooded")
+		assert.ErrorIs(t, err, ErrExamNotFound)
+	})
+}
@@ -0,0 +1,86 @@
+package exam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+)
+
+// ErrAlreadyAppeared is returned by CheckInStudent for a student who was
+// already checked in
+var ErrAlreadyAppeared = repository.ErrAlreadyAppeared
+
+// ErrEnrollmentDisqualified is returned by CheckInStudent for a student who
+// was disqualified from the exam
+var ErrEnrollmentDisqualified = repository.ErrEnrollmentDisqualified
+
+// ErrExamNotInProgress is returned by CheckInStudent outside the exam's
+// scheduled window or once it is cancelled or completed
+var ErrExamNotInProgress = errors.New("exam is not in progress")
+
+// ErrNotInvigilator is returned by CheckInStudent and GrantExtension when the
+// caller does not supervise the exam
+var ErrNotInvigilator = errors.New("only the exam's invigilators can check students in")
+
+// CheckInStudent marks the student with rollNo as having appeared for the
+// exam. The exam must be under way, and unless admin is set the caller must
+// be one of its invigilators.
+func (s *examService) CheckInStudent(ctx context.Context, collegeID, examID, userID int, admin bool, rollNo string) (*models.ExamEnrollment, error) {
+	rollNo = strings.TrimSpace(rollNo)
+	if collegeID == 0 || examID == 0 || rollNo == "" {
+		return nil, errors.New("college ID, exam ID and roll number are required")
+	}
+
+	exam, err := s.repo.GetExamByID(ctx, collegeID, examID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkExamInProgress(exam, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if !admin {
+		if err := s.requireInvigilator(ctx, collegeID, examID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	enrollment, err := s.repo.MarkAppeared(ctx, collegeID, examID, rollNo)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateExamStats(ctx, examID)
+	return enrollment, nil
+}
+
+// requireInvigilator returns ErrNotInvigilator unless userID supervises the exam
+func (s *examService) requireInvigilator(ctx context.Context, collegeID, examID, userID int) error {
+	invigilators, err := s.repo.ListInvigilators(ctx, collegeID, examID)
+	if err != nil {
+		return fmt.Errorf("failed to load invigilators: %w", err)
+	}
+	for _, invigilator := range invigilators {
+		if invigilator.UserID == userID {
+			return nil
+		}
+	}
+	return ErrNotInvigilator
+}
+
+// checkExamInProgress accepts an exam that is neither cancelled nor completed
+// and whose scheduled window contains now
+func checkExamInProgress(exam *models.Exam, now time.Time) error {
+	if exam.Status == "cancelled" || exam.Status == "completed" {
+		return fmt.Errorf("%w: exam is %s", ErrExamNotInProgress, exam.Status)
+	}
+	if now.Before(exam.StartTime) || now.After(exam.EndTime) {
+		return fmt.Errorf("%w: it runs from %s to %s", ErrExamNotInProgress,
+			exam.StartTime.Format("2006-01-02 15:04"), exam.EndTime.Format("2006-01-02 15:04"))
+	}
+	return nil
+}
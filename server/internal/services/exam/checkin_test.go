@@ -0,0 +1,105 @@
+package exam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type checkInRepo struct {
+	repository.ExamRepository
+	exam         *models.Exam
+	invigilators []*models.ExamInvigilator
+	markErr      error
+	marked       string
+}
+
+func (r *checkInRepo) GetExamByID(ctx context.Context, collegeID, examID int) (*models.Exam, error) {
+	return r.exam, nil
+}
+
+func (r *checkInRepo) ListInvigilators(ctx context.Context, collegeID, examID int) ([]*models.ExamInvigilator, error) {
+	return r.invigilators, nil
+}
+
+func (r *checkInRepo) MarkAppeared(ctx context.Context, collegeID, examID int, rollNo string) (*models.ExamEnrollment, error) {
+	if r.markErr != nil {
+		return nil, r.markErr
+	}
+	r.marked = rollNo
+	now := time.Now()
+	return &models.ExamEnrollment{ExamID: examID, StudentID: 5, Status: "appeared", AppearedAt: &now}, nil
+}
+
+func newCheckInRepo() *checkInRepo {
+	start := time.Now().Add(-30 * time.Minute)
+	return &checkInRepo{
+		exam:         &models.Exam{ID: 7, CollegeID: 1, Status: "scheduled", StartTime: start, EndTime: start.Add(3 * time.Hour)},
+		invigilators: []*models.ExamInvigilator{{ExamID: 7, UserID: 11}},
+	}
+}
+
+func TestCheckInStudent(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("assigned invigilator checks a student in", func(t *testing.T) {
+		repo := newCheckInRepo()
+		svc := &examService{repo: repo}
+		enrollment, err := svc.CheckInStudent(ctx, 1, 7, 11, false, " CS-042 ")
+		require.NoError(t, err)
+		assert.Equal(t, "appeared", enrollment.Status)
+		assert.NotNil(t, enrollment.AppearedAt)
+		assert.Equal(t, "CS-042", repo.marked)
+	})
+
+	t.Run("admin need not be an invigilator", func(t *testing.T) {
+		svc := &examService{repo: newCheckInRepo()}
+		_, err := svc.CheckInStudent(ctx, 1, 7, 99, true, "CS-042")
+		assert.NoError(t, err)
+	})
+
+	t.Run("faculty not supervising the exam is refused", func(t *testing.T) {
+		repo := newCheckInRepo()
+		svc := &examService{repo: repo}
+		_, err := svc.CheckInStudent(ctx, 1, 7, 12, false, "CS-042")
+		assert.ErrorIs(t, err, ErrNotInvigilator)
+		assert.Empty(t, repo.marked)
+	})
+
+	t.Run("exam not yet started", func(t *testing.T) {
+		repo := newCheckInRepo()
+		repo.exam.StartTime = time.Now().Add(time.Hour)
+		repo.exam.EndTime = time.Now().Add(4 * time.Hour)
+		svc := &examService{repo: repo}
+		_, err := svc.CheckInStudent(ctx, 1, 7, 11, false, "CS-042")
+		assert.ErrorIs(t, err, ErrExamNotInProgress)
+	})
+
+	t.Run("cancelled exam", func(t *testing.T) {
+		repo := newCheckInRepo()
+		repo.exam.Status = "cancelled"
+		svc := &examService{repo: repo}
+		_, err := svc.CheckInStudent(ctx, 1, 7, 11, false, "CS-042")
+		assert.ErrorIs(t, err, ErrExamNotInProgress)
+	})
+
+	t.Run("already appeared", func(t *testing.T) {
+		repo := newCheckInRepo()
+		repo.markErr = repository.ErrAlreadyAppeared
+		svc := &examService{repo: repo}
+		_, err := svc.CheckInStudent(ctx, 1, 7, 11, false, "CS-042")
+		assert.ErrorIs(t, err, ErrAlreadyAppeared)
+	})
+
+	t.Run("roll number is required", func(t *testing.T) {
+		svc := &examService{repo: newCheckInRepo()}
+		_, err := svc.CheckInStudent(ctx, 1, 7, 11, false, "  ")
+		assert.Error(t, err)
+	})
+}
@@ -2,52 +2,136 @@ package exam
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"eduhub/server/internal/cache"
+	"eduhub/server/internal/i18n"
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/repository"
+	"eduhub/server/internal/services/notification"
+	"eduhub/server/internal/services/webhook"
+
+	"github.com/rs/zerolog"
 )
 
+// ErrVersionConflict is returned by UpdateExam and UpdateResult when the record
+// changed since the caller read it
+var ErrVersionConflict = repository.ErrVersionConflict
+
+// ErrSeatAllocationInProgress is returned by AllocateSeats while another
+// allocation of the same exam is running
+var ErrSeatAllocationInProgress = repository.ErrSeatAllocationInProgress
+
+// ErrSeatPlanStale is returned by AllocateSeats when students enrolled or
+// withdrew while the seats were being planned
+var ErrSeatPlanStale = repository.ErrSeatPlanStale
+
+// ErrInvalidResultFilter is returned by GetStudentResults for an unknown
+// result status, an inverted date range or a sort outside the allowlist
+var ErrInvalidResultFilter = errors.New("invalid result filter")
+
+// ErrExamNotFound is returned when the exam does not exist in the college
+var ErrExamNotFound = repository.ErrExamNotFound
+
+// ErrExamRoomNotFound is returned when the exam room does not exist in the college
+var ErrExamRoomNotFound = repository.ErrExamRoomNotFound
+
+// ErrExamResultNotFound is returned when the student has no result for the exam
+var ErrExamResultNotFound = repository.ErrExamResultNotFound
+
+// ErrInvalidCursor is returned by the cursor-paginated listings for a
+// malformed or tampered cursor
+var ErrInvalidCursor = repository.ErrInvalidCursor
+
+// ErrExamInPast is returned by CreateExam for a scheduled exam whose start
+// time has already passed, unless the caller asked to backfill it
+var ErrExamInPast = errors.New("exam start time is in the past")
+
+// ErrDurationMismatch is returned by CreateExam when the exam duration does
+// not fit the window between its start and end times
+var ErrDurationMismatch = errors.New("exam duration does not match its start and end times")
+
+// durationTolerance is how far an exam's duration may stray from its
+// scheduled window, leaving room for reading time or an early finish
+const durationTolerance = 15 * time.Minute
+
+// CreateExamOptions adjusts the checks CreateExam applies
+type CreateExamOptions struct {
+	// Backfill allows recording an exam that already took place
+	Backfill bool
+}
+
 type ExamService interface {
 	// Exam Management
-	CreateExam(ctx context.Context, exam *models.Exam) error
+	CreateExam(ctx context.Context, exam *models.Exam, opts CreateExamOptions) error
 	GetExam(ctx context.Context, collegeID, examID int) (*models.Exam, error)
-	ListExams(ctx context.Context, collegeID int, filters map[string]any, limit, offset int) ([]*models.Exam, error)
+	GetExamWithDetails(ctx context.Context, collegeID, examID int) (*models.ExamWithDetails, error)
+	ListExams(ctx context.Context, collegeID int, filter models.ExamFilter, limit, offset int) ([]*models.Exam, error)
+	ListExamsAfter(ctx context.Context, collegeID int, filter models.ExamFilter, cursor string, limit int) ([]*models.Exam, string, error)
 	ListExamsByCourse(ctx context.Context, collegeID, courseID int, limit, offset int) ([]*models.Exam, error)
 	UpdateExam(ctx context.Context, exam *models.Exam) error
 	DeleteExam(ctx context.Context, collegeID, examID int) error
+	RestoreExam(ctx context.Context, collegeID, examID int) error
+	CancelExam(ctx context.Context, collegeID, examID int, reason string) (*models.Exam, error)
 	GetExamStats(ctx context.Context, collegeID, examID int) (*ExamStats, error)
+	GenerateTimetable(ctx context.Context, collegeID int, req *TimetableRequest) (*TimetableProposal, error)
+	ConfirmTimetable(ctx context.Context, collegeID, createdBy int, req *TimetableRequest) ([]*models.Exam, error)
 
 	// Enrollment Management
 	EnrollStudent(ctx context.Context, enrollment *models.ExamEnrollment) error
 	EnrollMultipleStudents(ctx context.Context, examID, collegeID int, studentIDs []int) error
+	EnrollCourse(ctx context.Context, examID, collegeID int) (*CourseEnrollmentSummary, error)
 	GetEnrollment(ctx context.Context, examID, studentID int) (*models.ExamEnrollment, error)
 	ListEnrollments(ctx context.Context, examID int) ([]*models.ExamEnrollment, error)
 	UpdateEnrollment(ctx context.Context, enrollment *models.ExamEnrollment) error
 	DeleteEnrollment(ctx context.Context, examID, studentID int) error
 	GetStudentEnrollments(ctx context.Context, studentID, collegeID int) ([]*models.ExamEnrollment, error)
+	CountStudentEnrollments(ctx context.Context, studentID, collegeID int) (int, error)
+	GetStudentExamEnrollments(ctx context.Context, studentID, collegeID int) ([]*models.StudentExamEnrollment, error)
+	GetUpcomingExams(ctx context.Context, studentID, collegeID int) ([]*models.UpcomingExam, error)
+	DetectStudentExamClashes(ctx context.Context, collegeID, studentID int) ([]*models.ExamClash, error)
+	DetectCollegeExamClashes(ctx context.Context, collegeID int) ([]*models.ExamClash, error)
 
 	// Seat Allocation
-	AllocateSeats(ctx context.Context, examID int) error
-	GenerateHallTicket(ctx context.Context, examID, studentID int) (*models.HallTicketResponse, error)
+	AllocateSeats(ctx context.Context, collegeID, examID int) error
+	AllocateSeatsPreview(ctx context.Context, collegeID, examID int) (*SeatAllocationPreview, error)
+	ConfirmSeatAllocation(ctx context.Context, collegeID, examID int, planID string) error
+	SetAccommodations(ctx context.Context, collegeID, examID, studentID int, accommodations models.ExamAccommodations) error
+	GenerateHallTicket(ctx context.Context, examID, studentID int, locale string) (*models.HallTicketResponse, error)
 	GenerateAllHallTickets(ctx context.Context, examID int) error
+	VerifyHallTicket(ctx context.Context, collegeID, examID, studentID, version int) (*models.HallTicketVerification, error)
 
 	// Result Management
 	CreateResult(ctx context.Context, result *models.ExamResult) error
 	GetResult(ctx context.Context, examID, studentID int) (*models.ExamResult, error)
 	ListResults(ctx context.Context, examID int) ([]*models.ExamResult, error)
 	UpdateResult(ctx context.Context, result *models.ExamResult) error
-	GetStudentResults(ctx context.Context, studentID, collegeID int) ([]*models.ExamResult, error)
-	BulkGradeResults(ctx context.Context, examID int, results map[int]*ResultInput) error
+	GetResultHistory(ctx context.Context, collegeID, examID, studentID int) (*ResultHistory, error)
+	GetStudentResults(ctx context.Context, studentID, collegeID int, publishedOnly bool, filter models.ExamResultFilter) ([]*models.ExamResult, error)
+	CountStudentResults(ctx context.Context, studentID, collegeID int, publishedOnly bool) (int, error)
+	GetStudentResultsAfter(ctx context.Context, studentID, collegeID int, publishedOnly bool, filter models.ExamResultFilter, cursor string, limit int) ([]*models.ExamResult, string, error)
+	PublishResults(ctx context.Context, collegeID, examID int) (int, error)
+	BulkGradeResults(ctx context.Context, collegeID, examID int, results map[int]*ResultInput, mode BulkGradeMode) (*BulkGradeReport, error)
+	ImportResults(ctx context.Context, collegeID, examID int, records [][]string, mode BulkGradeMode) (*ResultsImportReport, error)
 	CalculateGrade(marks, totalMarks float64) string
 	GetResultStats(ctx context.Context, examID int) (*ResultStats, error)
+	GetGroupedResultStats(ctx context.Context, examID int, groupBy string) (*GroupedResultStats, error)
 
 	// Revaluation Management
-	CreateRevaluationRequest(ctx context.Context, request *models.RevaluationRequest) error
+	CreateRevaluationRequest(ctx context.Context, request *models.RevaluationRequest, opts CreateRevaluationOptions) error
 	GetRevaluationRequest(ctx context.Context, requestID int) (*models.RevaluationRequest, error)
-	ListRevaluationRequests(ctx context.Context, collegeID int, filters map[string]any) ([]*models.RevaluationRequest, error)
+	ListRevaluationRequests(ctx context.Context, collegeID int, filter models.RevaluationRequestFilter) ([]*models.RevaluationRequest, error)
+	// ListRevaluationRequestDetails also returns each request's exam title,
+	// student name and roll number, and current marks
+	ListRevaluationRequestDetails(ctx context.Context, collegeID int, filter models.RevaluationRequestFilter) ([]*models.RevaluationRequestDetail, error)
+	ListStudentRevaluationRequests(ctx context.Context, collegeID, studentID int) ([]*StudentRevaluationRequest, error)
 	UpdateRevaluationRequest(ctx context.Context, request *models.RevaluationRequest) error
 	ApproveRevaluationRequest(ctx context.Context, requestID int, reviewedBy int, revisedMarks float64, comments string) error
 	RejectRevaluationRequest(ctx context.Context, requestID int, reviewedBy int, comments string) error
@@ -59,6 +143,20 @@ type ExamService interface {
 	UpdateRoom(ctx context.Context, room *models.ExamRoom) error
 	DeleteRoom(ctx context.Context, collegeID, roomID int) error
 	CheckRoomAvailability(ctx context.Context, roomID int, startTime, endTime string) (bool, error)
+	GetRoomUtilization(ctx context.Context, collegeID int, startDate, endDate time.Time) ([]*models.RoomUtilization, error)
+
+	// Invigilation
+	AssignInvigilator(ctx context.Context, invigilator *models.ExamInvigilator) error
+	ListInvigilators(ctx context.Context, collegeID, examID int) ([]*models.ExamInvigilator, error)
+	RemoveInvigilator(ctx context.Context, collegeID, examID, userID int) error
+	SuggestInvigilators(ctx context.Context, collegeID, examID int) (*InvigilatorSuggestion, error)
+	CheckInStudent(ctx context.Context, collegeID, examID, userID int, admin bool, rollNo string) (*models.ExamEnrollment, error)
+	GrantExtension(ctx context.Context, collegeID, examID, userID int, admin bool, req GrantExtensionRequest) (*ExtensionGrant, error)
+	ListExtensions(ctx context.Context, collegeID, examID int) ([]*models.ExamTimeExtension, error)
+
+	// Proctoring
+	RecordProctoringEvent(ctx context.Context, collegeID, examID, studentID int, req ProctoringEventRequest) (*models.ExamProctoringEvent, error)
+	ReviewProctoringEvents(ctx context.Context, collegeID, examID, studentID int) (*ProctoringReview, error)
 }
 
 // ResultInput represents input for grading an exam
@@ -67,16 +165,70 @@ type ResultInput struct {
 	Remarks       string
 }
 
-// ExamStats represents statistics for an exam
+// BulkGradeMode controls what BulkGradeResults does when some rows fail
+type BulkGradeMode string
+
+const (
+	// BulkGradeAllOrNothing writes nothing unless every student is graded
+	BulkGradeAllOrNothing BulkGradeMode = "all_or_nothing"
+	// BulkGradePartial commits the students that succeeded and reports the rest
+	BulkGradePartial BulkGradeMode = "partial"
+)
+
+// BulkGradeOutcome is the result of grading one student in a batch
+type BulkGradeOutcome struct {
+	StudentID int    `json:"student_id"`
+	Status    string `json:"status"` // graded, failed, skipped
+	Error     string `json:"error,omitempty"`
+}
+
+// BulkGradeReport summarises a BulkGradeResults call
+type BulkGradeReport struct {
+	Mode      BulkGradeMode      `json:"mode"`
+	Committed bool               `json:"committed"`
+	Graded    int                `json:"graded"`
+	Failed    int                `json:"failed"`
+	Outcomes  []BulkGradeOutcome `json:"outcomes"`
+}
+
+// CourseEnrollmentSummary reports what EnrollCourse did with the course roster
+type CourseEnrollmentSummary struct {
+	Eligible int `json:"eligible"` // Students actively enrolled in the course
+	Enrolled int `json:"enrolled"` // Newly enrolled in the exam
+	Skipped  int `json:"skipped"`  // Already enrolled in the exam
+}
+
+// ExamStats represents statistics for an exam. The marks, grade distribution
+// and histogram only count results that have been graded.
 type ExamStats struct {
-	TotalEnrolled    int
-	Appeared         int
-	Absent           int
-	ResultsPublished int
-	AverageMarks     float64
-	PassRate         float64
+	TotalEnrolled     int
+	Appeared          int
+	Absent            int
+	ResultsPublished  int
+	AverageMarks      float64
+	PassRate          float64
+	MedianMarks       float64
+	HighestMarks      float64
+	LowestMarks       float64
+	GradeDistribution map[string]int
+	MarkHistogram     []MarkBucket
 }
 
+// MarkBucket counts graded results whose percentage of the exam's total marks
+// is at least From and below To. The top bucket also includes 100%.
+type MarkBucket struct {
+	From  float64
+	To    float64
+	Count int
+}
+
+// markBucketWidth is the width of each histogram bucket in percentage points,
+// giving ten buckets: 0-10, 10-20, ... 90-100
+const markBucketWidth = 10
+
+// gradeLetters lists every grade CalculateGrade can return, best first
+var gradeLetters = []string{"A+", "A", "B+", "B", "C+", "C", "F"}
+
 // ResultStats represents statistics for exam results
 type ResultStats struct {
 	TotalStudents  int
@@ -89,11 +241,63 @@ type ResultStats struct {
 	LowestMarks    float64
 }
 
+// ResultStatsGroupBySection groups result statistics by the students' section
+const ResultStatsGroupBySection = "section"
+
+// UnassignedGroup holds the students without a section
+const UnassignedGroup = "unassigned"
+
+// ErrInvalidStatsGrouping is returned by GetGroupedResultStats for an
+// unsupported groupBy
+var ErrInvalidStatsGrouping = errors.New("unsupported result stats grouping")
+
+// ResultStatsGroup is the result statistics of one group of students
+type ResultStatsGroup struct {
+	Name  string       `json:"name"`
+	Stats *ResultStats `json:"stats"`
+}
+
+// GroupedResultStats is the result statistics of a whole exam and of each
+// group of its students
+type GroupedResultStats struct {
+	GroupBy string              `json:"group_by"`
+	Overall *ResultStats        `json:"overall"`
+	Groups  []*ResultStatsGroup `json:"groups"`
+}
+
 type examService struct {
 	repo        repository.ExamRepository
 	studentRepo repository.StudentRepository
 	courseRepo  repository.CourseRepository
 	userRepo    repository.UserRepository
+
+	// feeRepo charges revaluation fees on the fee ledger; nil disables them
+	feeRepo repository.FeeRepository
+
+	// studentsPerInvigilator sizes invigilation staffing; zero means the default
+	studentsPerInvigilator int
+
+	// maxExtensionMinutes caps the extensions one student can be granted in an
+	// exam; zero means the default
+	maxExtensionMinutes int
+
+	// revaluationWindowDays is how long after publication a result can be
+	// sent for revaluation; zero means the default
+	revaluationWindowDays int
+
+	// notifier emails students when results are published; nil disables it
+	notifier *ResultNotifier
+
+	// events publishes exam events to webhooks; nil disables it
+	events webhook.Emitter
+
+	// inbox posts in-app notifications to students; nil disables it
+	inbox notification.Notifier
+
+	// statsCache holds computed ExamStats per exam; nil when Redis is disabled
+	statsCache cache.Cache
+
+	logger zerolog.Logger
 }
 
 func NewExamService(
@@ -101,12 +305,30 @@ func NewExamService(
 	studentRepo repository.StudentRepository,
 	courseRepo repository.CourseRepository,
 	userRepo repository.UserRepository,
+	feeRepo repository.FeeRepository,
+	studentsPerInvigilator int,
+	maxExtensionMinutes int,
+	revaluationWindowDays int,
+	notifier *ResultNotifier,
+	events webhook.Emitter,
+	inbox notification.Notifier,
+	statsCache cache.Cache,
+	logger zerolog.Logger,
 ) ExamService {
 	return &examService{
-		repo:        repo,
-		studentRepo: studentRepo,
-		courseRepo:  courseRepo,
-		userRepo:    userRepo,
+		repo:                   repo,
+		studentRepo:            studentRepo,
+		courseRepo:             courseRepo,
+		userRepo:               userRepo,
+		feeRepo:                feeRepo,
+		studentsPerInvigilator: studentsPerInvigilator,
+		maxExtensionMinutes:    maxExtensionMinutes,
+		revaluationWindowDays:  revaluationWindowDays,
+		notifier:               notifier,
+		events:                 events,
+		inbox:                  inbox,
+		statsCache:             statsCache,
+		logger:                 logger,
 	}
 }
 
@@ -114,8 +336,42 @@ func NewExamService(
 // Exam Management
 // ===========================
 
-func (s *examService) CreateExam(ctx context.Context, exam *models.Exam) error {
-	// Validation
+func (s *examService) CreateExam(ctx context.Context, exam *models.Exam, opts CreateExamOptions) error {
+	if err := validateNewExam(exam); err != nil {
+		return err
+	}
+
+	// Set default status if not provided
+	if exam.Status == "" {
+		exam.Status = "scheduled"
+	}
+	if err := validateExamSchedule(exam, time.Now(), opts.Backfill); err != nil {
+		return err
+	}
+
+	return s.repo.CreateExam(ctx, exam)
+}
+
+// validateExamSchedule rejects a scheduled exam that starts before now unless
+// it is being backfilled, and a duration that differs from the exam window by
+// more than durationTolerance
+func validateExamSchedule(exam *models.Exam, now time.Time, backfill bool) error {
+	if exam.Status == "scheduled" && exam.StartTime.Before(now) && !backfill {
+		return ErrExamInPast
+	}
+	window := exam.EndTime.Sub(exam.StartTime)
+	diff := time.Duration(exam.Duration)*time.Minute - window
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > durationTolerance {
+		return fmt.Errorf("%w: %d minutes against a %d minute window", ErrDurationMismatch, exam.Duration, int(window.Minutes()))
+	}
+	return nil
+}
+
+// validateNewExam checks the fields required to create an exam
+func validateNewExam(exam *models.Exam) error {
 	if exam.Title == "" {
 		return errors.New("exam title is required")
 	}
@@ -134,16 +390,10 @@ func (s *examService) CreateExam(ctx context.Context, exam *models.Exam) error {
 	if exam.TotalMarks <= 0 {
 		return errors.New("total marks must be positive")
 	}
-	if exam.PassingMarks < 0 || exam.PassingMarks > exam.TotalMarks {
-		return errors.New("passing marks must be between 0 and total marks")
-	}
-
-	// Set default status if not provided
-	if exam.Status == "" {
-		exam.Status = "scheduled"
+	if err := validatePassingMarks(exam); err != nil {
+		return err
 	}
-
-	return s.repo.CreateExam(ctx, exam)
+	return validateExamMetadata(exam)
 }
 
 func (s *examService) GetExam(ctx context.Context, collegeID, examID int) (*models.Exam, error) {
@@ -153,14 +403,37 @@ func (s *examService) GetExam(ctx context.Context, collegeID, examID int) (*mode
 	return s.repo.GetExamByID(ctx, collegeID, examID)
 }
 
-func (s *examService) ListExams(ctx context.Context, collegeID int, filters map[string]any, limit, offset int) ([]*models.Exam, error) {
+// GetExamWithDetails is GetExam with the course and room names joined in
+func (s *examService) GetExamWithDetails(ctx context.Context, collegeID, examID int) (*models.ExamWithDetails, error) {
+	if collegeID == 0 || examID == 0 {
+		return nil, errors.New("invalid college ID or exam ID")
+	}
+	return s.repo.GetExamWithDetails(ctx, collegeID, examID)
+}
+
+func (s *examService) ListExams(ctx context.Context, collegeID int, filter models.ExamFilter, limit, offset int) ([]*models.Exam, error) {
 	if collegeID == 0 {
 		return nil, errors.New("college ID is required")
 	}
 	if limit <= 0 {
 		limit = 50
 	}
-	return s.repo.ListExams(ctx, collegeID, filters, limit, offset)
+	return s.repo.ListExams(ctx, collegeID, filter, limit, offset)
+}
+
+// ListExamsAfter lists exams a page at a time by cursor rather than offset.
+// Prefer it for clients that walk the whole list, such as infinite scroll or
+// exports: offsets skip or repeat exams when rows change between requests and
+// get slower the deeper the page. Offsets remain the simpler choice for
+// jumping to a numbered page.
+func (s *examService) ListExamsAfter(ctx context.Context, collegeID int, filter models.ExamFilter, cursor string, limit int) ([]*models.Exam, string, error) {
+	if collegeID == 0 {
+		return nil, "", errors.New("college ID is required")
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	return s.repo.ListExamsAfter(ctx, collegeID, filter, cursor, limit)
 }
 
 func (s *examService) ListExamsByCourse(ctx context.Context, collegeID, courseID int, limit, offset int) ([]*models.Exam, error) {
@@ -177,6 +450,9 @@ func (s *examService) UpdateExam(ctx context.Context, exam *models.Exam) error {
 	if exam.ID == 0 || exam.CollegeID == 0 {
 		return errors.New("invalid exam ID or college ID")
 	}
+	if exam.Version < 1 {
+		return errors.New("exam version is required")
+	}
 
 	// Validate if exam exists
 	_, err := s.repo.GetExamByID(ctx, exam.CollegeID, exam.ID)
@@ -188,11 +464,74 @@ func (s *examService) UpdateExam(ctx context.Context, exam *models.Exam) error {
 	if exam.Title != "" && exam.StartTime.After(exam.EndTime) {
 		return errors.New("start time must be before end time")
 	}
-	if exam.TotalMarks > 0 && exam.PassingMarks > exam.TotalMarks {
-		return errors.New("passing marks cannot exceed total marks")
+	if exam.TotalMarks > 0 || exam.PassingIsPercentage {
+		if err := validatePassingMarks(exam); err != nil {
+			return err
+		}
+	}
+	if err := validateExamMetadata(exam); err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateExam(ctx, exam); err != nil {
+		return err
+	}
+	// Passing and total marks feed the pass rate and distribution
+	s.invalidateExamStats(ctx, exam.ID)
+	return nil
+}
+
+// validatePassingMarks checks the pass mark against its mode: a percentage
+// must lie between 0 and 100, a mark between 0 and total marks
+func validatePassingMarks(exam *models.Exam) error {
+	if exam.PassingIsPercentage {
+		if exam.PassingMarks < 0 || exam.PassingMarks > 100 {
+			return errors.New("passing percentage must be between 0 and 100")
+		}
+		return nil
+	}
+	if exam.PassingMarks < 0 || exam.PassingMarks > exam.TotalMarks {
+		return errors.New("passing marks must be between 0 and total marks")
 	}
+	return nil
+}
 
-	return s.repo.UpdateExam(ctx, exam)
+const (
+	maxAllowedMaterials      = 20
+	maxAllowedMaterialLength = 100
+)
+
+// validateExamMetadata checks question paper sets and the seating scheme,
+// which defaults to sequential, and normalises the allowed materials list
+// (trimmed, blanks dropped, never nil so it stores as []).
+func validateExamMetadata(exam *models.Exam) error {
+	if exam.QuestionPaperSets < 1 {
+		return errors.New("question paper sets must be at least 1")
+	}
+	switch exam.SeatingScheme {
+	case "":
+		exam.SeatingScheme = models.SeatingSequential
+	case models.SeatingSequential, models.SeatingRoomPrefixed, models.SeatingRollSorted:
+	default:
+		return fmt.Errorf("unknown seating scheme %q", exam.SeatingScheme)
+	}
+
+	materials := make([]string, 0, len(exam.AllowedMaterials))
+	for _, m := range exam.AllowedMaterials {
+		m = strings.TrimSpace(m)
+		if m == "" {
+			continue
+		}
+		if len(m) > maxAllowedMaterialLength {
+			return fmt.Errorf("allowed material %q exceeds %d characters", m, maxAllowedMaterialLength)
+		}
+		materials = append(materials, m)
+	}
+	if len(materials) > maxAllowedMaterials {
+		return fmt.Errorf("at most %d allowed materials may be listed", maxAllowedMaterials)
+	}
+	exam.AllowedMaterials = materials
+	return nil
 }
 
 func (s *examService) DeleteExam(ctx context.Context, collegeID, examID int) error {
@@ -202,18 +541,31 @@ func (s *examService) DeleteExam(ctx context.Context, collegeID, examID int) err
 	return s.repo.DeleteExam(ctx, collegeID, examID)
 }
 
+func (s *examService) RestoreExam(ctx context.Context, collegeID, examID int) error {
+	if collegeID == 0 || examID == 0 {
+		return errors.New("invalid college ID or exam ID")
+	}
+	return s.repo.RestoreExam(ctx, collegeID, examID)
+}
+
+// GetExamStats summarizes the exam's enrollments and results. The exam is
+// always looked up, so the college check never comes from the cache; the
+// stats themselves are cached until a write to the exam invalidates them.
 func (s *examService) GetExamStats(ctx context.Context, collegeID, examID int) (*ExamStats, error) {
-	enrollments, err := s.repo.ListEnrollments(ctx, examID)
+	exam, err := s.repo.GetExamByID(ctx, collegeID, examID)
 	if err != nil {
 		return nil, err
 	}
+	if stats := s.cachedExamStats(ctx, examID); stats != nil {
+		return stats, nil
+	}
 
-	results, err := s.repo.ListResults(ctx, examID)
+	enrollments, err := s.repo.ListEnrollments(ctx, examID)
 	if err != nil {
 		return nil, err
 	}
 
-	exam, err := s.repo.GetExamByID(ctx, collegeID, examID)
+	results, err := s.repo.ListResults(ctx, examID)
 	if err != nil {
 		return nil, err
 	}
@@ -234,13 +586,15 @@ func (s *examService) GetExamStats(ctx context.Context, collegeID, examID int) (
 	// Calculate result stats
 	var totalMarks float64
 	passCount := 0
+	marks := make([]float64, 0, len(results))
 	for _, result := range results {
 		if result.MarksObtained != nil {
 			stats.ResultsPublished++
 			totalMarks += *result.MarksObtained
-			if *result.MarksObtained >= exam.PassingMarks {
+			if exam.Passes(*result.MarksObtained) {
 				passCount++
 			}
+			marks = append(marks, *result.MarksObtained)
 		}
 	}
 
@@ -249,9 +603,52 @@ func (s *examService) GetExamStats(ctx context.Context, collegeID, examID int) (
 		stats.PassRate = float64(passCount) / float64(stats.ResultsPublished) * 100
 	}
 
+	s.addMarkDistribution(stats, marks, exam.TotalMarks)
+
+	s.cacheExamStats(ctx, examID, stats)
 	return stats, nil
 }
 
+// addMarkDistribution fills in the median, range, grade counts and histogram
+// from the graded marks. Grades and buckets are skipped when the exam has no
+// total marks to take a percentage of.
+func (s *examService) addMarkDistribution(stats *ExamStats, marks []float64, totalMarks float64) {
+	stats.GradeDistribution = make(map[string]int, len(gradeLetters))
+	for _, grade := range gradeLetters {
+		stats.GradeDistribution[grade] = 0
+	}
+	stats.MarkHistogram = make([]MarkBucket, 0, 100/markBucketWidth)
+	for from := 0; from < 100; from += markBucketWidth {
+		stats.MarkHistogram = append(stats.MarkHistogram, MarkBucket{From: float64(from), To: float64(from + markBucketWidth)})
+	}
+
+	if len(marks) == 0 {
+		return
+	}
+
+	sorted := append([]float64(nil), marks...)
+	sort.Float64s(sorted)
+	stats.LowestMarks = sorted[0]
+	stats.HighestMarks = sorted[len(sorted)-1]
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		stats.MedianMarks = (sorted[mid-1] + sorted[mid]) / 2
+	} else {
+		stats.MedianMarks = sorted[mid]
+	}
+
+	if totalMarks <= 0 {
+		return
+	}
+	for _, m := range marks {
+		stats.GradeDistribution[s.CalculateGrade(m, totalMarks)]++
+
+		bucket := int(m / totalMarks * 100 / markBucketWidth)
+		bucket = max(0, min(bucket, len(stats.MarkHistogram)-1))
+		stats.MarkHistogram[bucket].Count++
+	}
+}
+
 // ===========================
 // Enrollment Management
 // ===========================
@@ -275,7 +672,48 @@ func (s *examService) EnrollStudent(ctx context.Context, enrollment *models.Exam
 		enrollment.Status = "enrolled"
 	}
 
-	return s.repo.EnrollStudent(ctx, enrollment)
+	if err := s.repo.EnrollStudent(ctx, enrollment); err != nil {
+		return err
+	}
+	s.invalidateExamStats(ctx, enrollment.ExamID)
+	// Emit a copy; the clash check below keeps writing to enrollment
+	s.emit(ctx, enrollment.CollegeID, webhook.EventExamEnrollmentCreated, *enrollment)
+
+	// Overlapping exams are reported back as a warning; the enrollment stands
+	// either way and a failed check must not undo it
+	clashes, err := s.DetectStudentExamClashes(ctx, enrollment.CollegeID, enrollment.StudentID)
+	if err == nil {
+		for _, clash := range clashes {
+			if clash.ExamID == enrollment.ExamID || clash.ClashingExamID == enrollment.ExamID {
+				enrollment.Clashes = append(enrollment.Clashes, clash)
+			}
+		}
+	}
+	return nil
+}
+
+// GetUpcomingExams lists the student's enrolled exams that have not started yet
+func (s *examService) GetUpcomingExams(ctx context.Context, studentID, collegeID int) ([]*models.UpcomingExam, error) {
+	if studentID == 0 || collegeID == 0 {
+		return nil, errors.New("student ID and college ID are required")
+	}
+	return s.repo.ListUpcomingExams(ctx, studentID, collegeID, time.Now())
+}
+
+// DetectStudentExamClashes returns pairs of overlapping exams the student is enrolled in
+func (s *examService) DetectStudentExamClashes(ctx context.Context, collegeID, studentID int) ([]*models.ExamClash, error) {
+	if collegeID == 0 || studentID == 0 {
+		return nil, errors.New("college ID and student ID are required")
+	}
+	return s.repo.ListExamClashes(ctx, collegeID, &studentID)
+}
+
+// DetectCollegeExamClashes returns every student exam clash in the college
+func (s *examService) DetectCollegeExamClashes(ctx context.Context, collegeID int) ([]*models.ExamClash, error) {
+	if collegeID == 0 {
+		return nil, errors.New("college ID is required")
+	}
+	return s.repo.ListExamClashes(ctx, collegeID, nil)
 }
 
 func (s *examService) EnrollMultipleStudents(ctx context.Context, examID, collegeID int, studentIDs []int) error {
@@ -294,12 +732,38 @@ func (s *examService) EnrollMultipleStudents(ctx context.Context, examID, colleg
 			Status:    "enrolled",
 		}
 		// Continue on error to enroll as many as possible
-		_ = s.repo.EnrollStudent(ctx, enrollment)
+		if err := s.repo.EnrollStudent(ctx, enrollment); err == nil {
+			s.emit(ctx, collegeID, webhook.EventExamEnrollmentCreated, *enrollment)
+		}
 	}
+	s.invalidateExamStats(ctx, examID)
 
 	return nil
 }
 
+// EnrollCourse enrolls every student actively enrolled in the exam's course,
+// skipping those already enrolled in the exam
+func (s *examService) EnrollCourse(ctx context.Context, examID, collegeID int) (*CourseEnrollmentSummary, error) {
+	if examID == 0 || collegeID == 0 {
+		return nil, errors.New("exam ID and college ID are required")
+	}
+
+	created, eligible, err := s.repo.EnrollCourseStudents(ctx, collegeID, examID)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateExamStats(ctx, examID)
+	for _, enrollment := range created {
+		s.emit(ctx, collegeID, webhook.EventExamEnrollmentCreated, *enrollment)
+	}
+
+	return &CourseEnrollmentSummary{
+		Eligible: eligible,
+		Enrolled: len(created),
+		Skipped:  eligible - len(created),
+	}, nil
+}
+
 func (s *examService) GetEnrollment(ctx context.Context, examID, studentID int) (*models.ExamEnrollment, error) {
 	if examID == 0 || studentID == 0 {
 		return nil, errors.New("exam ID and student ID are required")
@@ -318,14 +782,22 @@ func (s *examService) UpdateEnrollment(ctx context.Context, enrollment *models.E
 	if enrollment.ID == 0 {
 		return errors.New("enrollment ID is required")
 	}
-	return s.repo.UpdateEnrollment(ctx, enrollment)
+	if err := s.repo.UpdateEnrollment(ctx, enrollment); err != nil {
+		return err
+	}
+	s.invalidateExamStats(ctx, enrollment.ExamID)
+	return nil
 }
 
 func (s *examService) DeleteEnrollment(ctx context.Context, examID, studentID int) error {
 	if examID == 0 || studentID == 0 {
 		return errors.New("exam ID and student ID are required")
 	}
-	return s.repo.DeleteEnrollment(ctx, examID, studentID)
+	if err := s.repo.DeleteEnrollment(ctx, examID, studentID); err != nil {
+		return err
+	}
+	s.invalidateExamStats(ctx, examID)
+	return nil
 }
 
 func (s *examService) GetStudentEnrollments(ctx context.Context, studentID, collegeID int) ([]*models.ExamEnrollment, error) {
@@ -335,46 +807,47 @@ func (s *examService) GetStudentEnrollments(ctx context.Context, studentID, coll
 	return s.repo.GetStudentEnrollments(ctx, studentID, collegeID)
 }
 
+// CountStudentEnrollments counts a student's exam enrollments without
+// loading them
+func (s *examService) CountStudentEnrollments(ctx context.Context, studentID, collegeID int) (int, error) {
+	if studentID == 0 || collegeID == 0 {
+		return 0, errors.New("student ID and college ID are required")
+	}
+	return s.repo.CountStudentEnrollments(ctx, studentID, collegeID)
+}
+
+// GetStudentExamEnrollments is GetStudentEnrollments with the exam title,
+// course, schedule and result availability joined in
+func (s *examService) GetStudentExamEnrollments(ctx context.Context, studentID, collegeID int) ([]*models.StudentExamEnrollment, error) {
+	if studentID == 0 || collegeID == 0 {
+		return nil, errors.New("student ID and college ID are required")
+	}
+	return s.repo.GetStudentExamEnrollments(ctx, studentID, collegeID)
+}
+
 // ===========================
 // Seat Allocation
 // ===========================
 
-func (s *examService) AllocateSeats(ctx context.Context, examID int) error {
-	enrollments, err := s.repo.ListEnrollments(ctx, examID)
+// AllocateSeats numbers the exam's seats by its seating scheme and cycles the
+// question paper sets. The plan is written in one locked transaction, so a
+// second allocation started meanwhile gets ErrSeatAllocationInProgress
+// instead of interleaving its writes.
+func (s *examService) AllocateSeats(ctx context.Context, collegeID, examID int) error {
+	exam, enrollments, strategy, err := s.loadSeating(ctx, collegeID, examID)
 	if err != nil {
 		return err
 	}
-
 	if len(enrollments) == 0 {
 		return nil
 	}
 
-	// Fetch exam to get question paper sets configuration
-	exam, err := s.repo.GetExamByID(ctx, enrollments[0].CollegeID, examID)
-	if err != nil {
-		return fmt.Errorf("failed to fetch exam for seat allocation: %w", err)
-	}
-
-	// Simple sequential seat allocation
-	for i, enrollment := range enrollments {
-		seatNum := fmt.Sprintf("S%03d", i+1)
-		enrollment.SeatNumber = &seatNum
-
-		// Assign question paper set (cycle through available sets)
-		if exam.QuestionPaperSets > 0 {
-			set := (i % exam.QuestionPaperSets) + 1
-			enrollment.QuestionPaperSet = &set
-		}
-
-		if err := s.repo.UpdateEnrollment(ctx, enrollment); err != nil {
-			return fmt.Errorf("failed to update enrollment for student %d: %w", enrollment.StudentID, err)
-		}
-	}
-
-	return nil
+	return s.repo.AssignSeats(ctx, collegeID, examID, planSeats(exam, enrollments, strategy))
 }
 
-func (s *examService) GenerateHallTicket(ctx context.Context, examID, studentID int) (*models.HallTicketResponse, error) {
+// GenerateHallTicket issues the student's hall ticket with its labels in
+// locale, or in the student's own language when locale is empty
+func (s *examService) GenerateHallTicket(ctx context.Context, examID, studentID int, locale string) (*models.HallTicketResponse, error) {
 	enrollment, err := s.repo.GetEnrollment(ctx, examID, studentID)
 	if err != nil {
 		return nil, err
@@ -408,6 +881,13 @@ func (s *examService) GenerateHallTicket(ctx context.Context, examID, studentID
 		Instructions: exam.Instructions,
 	}
 
+	// Extra time and extensions move this student's end time, not the exam's
+	if extra := enrollment.ExtraMinutes(); extra > 0 {
+		hallTicket.ExtraTimeMinutes = extra
+		hallTicket.EndTime = enrollment.EndTime(exam)
+	}
+	hallTicket.Accommodations = accommodationLines(i18n.DefaultLocale, enrollment.Accommodations)
+
 	if enrollment.SeatNumber != nil {
 		hallTicket.SeatNumber = *enrollment.SeatNumber
 	}
@@ -418,15 +898,59 @@ func (s *examService) GenerateHallTicket(ctx context.Context, examID, studentID
 		hallTicket.QuestionPaperSet = *enrollment.QuestionPaperSet
 	}
 
-	// Mark hall ticket as generated
-	enrollment.HallTicketGenerated = true
-	if err := s.repo.UpdateEnrollment(ctx, enrollment); err != nil {
+	// Mark hall ticket as generated. A change to anything on the ticket, such
+	// as a new seat, issues a new version and invalidates the older ones.
+	fingerprint, err := hallTicketFingerprint(hallTicket)
+	if err != nil {
+		return nil, err
+	}
+	issue, err := s.repo.IssueHallTicket(ctx, enrollment.ID, fingerprint)
+	if err != nil {
 		return nil, err
 	}
+	enrollment.HallTicketGenerated = true
+	hallTicket.Version = issue.Version
+	if issue.GeneratedAt != nil {
+		hallTicket.GeneratedAt = *issue.GeneratedAt
+	}
 
+	s.localizeHallTicket(ctx, hallTicket, enrollment, locale)
 	return hallTicket, nil
 }
 
+// hallTicketFingerprint identifies the contents of a hall ticket before it is
+// given a version
+func hallTicketFingerprint(ticket *models.HallTicketResponse) (string, error) {
+	contents := *ticket
+	contents.Version = 0
+	contents.GeneratedAt = time.Time{}
+	contents.Locale = ""
+	contents.Labels = nil
+	data, err := json.Marshal(contents)
+	if err != nil {
+		return "", fmt.Errorf("failed to fingerprint hall ticket: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// VerifyHallTicket checks a presented hall ticket version against the latest
+// one issued to the student. Only the latest version is valid.
+func (s *examService) VerifyHallTicket(ctx context.Context, collegeID, examID, studentID, version int) (*models.HallTicketVerification, error) {
+	issue, err := s.repo.GetHallTicketIssue(ctx, collegeID, examID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.HallTicketVerification{
+		ExamID:         examID,
+		StudentID:      studentID,
+		Version:        version,
+		CurrentVersion: issue.Version,
+		Valid:          issue.Version > 0 && version == issue.Version,
+		GeneratedAt:    issue.GeneratedAt,
+	}, nil
+}
+
 func (s *examService) GenerateAllHallTickets(ctx context.Context, examID int) error {
 	enrollments, err := s.repo.ListEnrollments(ctx, examID)
 	if err != nil {
@@ -434,7 +958,7 @@ func (s *examService) GenerateAllHallTickets(ctx context.Context, examID int) er
 	}
 
 	for _, enrollment := range enrollments {
-		_, err := s.GenerateHallTicket(ctx, examID, enrollment.StudentID)
+		_, err := s.GenerateHallTicket(ctx, examID, enrollment.StudentID, i18n.DefaultLocale)
 		if err != nil {
 			// Log error but continue with others
 			continue
@@ -462,6 +986,21 @@ func (s *examService) CreateResult(ctx context.Context, result *models.ExamResul
 		return err
 	}
 
+	if err := s.applyMarks(exam, result); err != nil {
+		return err
+	}
+
+	if err := s.repo.CreateResult(ctx, result); err != nil {
+		return err
+	}
+	s.invalidateExamStats(ctx, result.ExamID)
+	return nil
+}
+
+// applyMarks validates the marks against the exam and fills in percentage,
+// grade, pass/fail and the evaluation time. A newly graded result is hidden
+// from the student until PublishResults is called.
+func (s *examService) applyMarks(exam *models.Exam, result *models.ExamResult) error {
 	if result.MarksObtained != nil {
 		if *result.MarksObtained < 0 || *result.MarksObtained > exam.TotalMarks {
 			return errors.New("marks obtained must be between 0 and total marks")
@@ -475,7 +1014,7 @@ func (s *examService) CreateResult(ctx context.Context, result *models.ExamResul
 		result.Grade = &grade
 
 		// Determine pass/fail
-		if *result.MarksObtained >= exam.PassingMarks {
+		if exam.Passes(*result.MarksObtained) {
 			result.Result = "pass"
 		} else {
 			result.Result = "fail"
@@ -487,8 +1026,9 @@ func (s *examService) CreateResult(ctx context.Context, result *models.ExamResul
 	// Set evaluation time
 	now := time.Now()
 	result.EvaluatedAt = &now
-
-	return s.repo.CreateResult(ctx, result)
+	result.Published = false
+	result.PublishedAt = nil
+	return nil
 }
 
 func (s *examService) GetResult(ctx context.Context, examID, studentID int) (*models.ExamResult, error) {
@@ -505,46 +1045,232 @@ func (s *examService) ListResults(ctx context.Context, examID int) ([]*models.Ex
 	return s.repo.ListResults(ctx, examID)
 }
 
+// UpdateResult re-grades an existing result. result.Version must be the
+// version the caller read; ErrVersionConflict means someone else saved first.
 func (s *examService) UpdateResult(ctx context.Context, result *models.ExamResult) error {
 	if result.ID == 0 {
 		return errors.New("result ID is required")
 	}
-	return s.repo.UpdateResult(ctx, result)
+	if result.Version < 1 {
+		return errors.New("result version is required")
+	}
+
+	exam, err := s.repo.GetExamByID(ctx, result.CollegeID, result.ExamID)
+	if err != nil {
+		return err
+	}
+	if err := s.applyMarks(exam, result); err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateResult(ctx, result); err != nil {
+		return err
+	}
+	s.invalidateExamStats(ctx, result.ExamID)
+	return nil
 }
 
-func (s *examService) GetStudentResults(ctx context.Context, studentID, collegeID int) ([]*models.ExamResult, error) {
+// GetStudentResults lists a student's results. Student-facing callers pass
+// publishedOnly so results still being graded stay hidden.
+func (s *examService) GetStudentResults(ctx context.Context, studentID, collegeID int, publishedOnly bool, filter models.ExamResultFilter) ([]*models.ExamResult, error) {
+	if err := validateResultFilter(studentID, collegeID, filter); err != nil {
+		return nil, err
+	}
+
+	results, err := s.repo.GetStudentResults(ctx, studentID, collegeID, publishedOnly, filter)
+	if errors.Is(err, repository.ErrInvalidResultSort) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidResultFilter, err)
+	}
+	return results, err
+}
+
+// CountStudentResults counts a student's results without loading them. With
+// publishedOnly unpublished results are not counted, as in GetStudentResults.
+func (s *examService) CountStudentResults(ctx context.Context, studentID, collegeID int, publishedOnly bool) (int, error) {
 	if studentID == 0 || collegeID == 0 {
-		return nil, errors.New("student ID and college ID are required")
+		return 0, errors.New("student ID and college ID are required")
 	}
-	return s.repo.GetStudentResults(ctx, studentID, collegeID)
+	return s.repo.CountStudentResults(ctx, studentID, collegeID, publishedOnly)
 }
 
-func (s *examService) BulkGradeResults(ctx context.Context, examID int, results map[int]*ResultInput) error {
-	for studentID, resultInput := range results {
+// GetStudentResultsAfter is GetStudentResults paged by cursor, newest first.
+// It cannot be combined with a custom sort.
+func (s *examService) GetStudentResultsAfter(ctx context.Context, studentID, collegeID int, publishedOnly bool, filter models.ExamResultFilter, cursor string, limit int) ([]*models.ExamResult, string, error) {
+	if err := validateResultFilter(studentID, collegeID, filter); err != nil {
+		return nil, "", err
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+
+	results, next, err := s.repo.GetStudentResultsAfter(ctx, studentID, collegeID, publishedOnly, filter, cursor, limit)
+	if errors.Is(err, repository.ErrInvalidResultSort) {
+		return nil, "", fmt.Errorf("%w: %v", ErrInvalidResultFilter, err)
+	}
+	return results, next, err
+}
+
+func validateResultFilter(studentID, collegeID int, filter models.ExamResultFilter) error {
+	if studentID == 0 || collegeID == 0 {
+		return errors.New("student ID and college ID are required")
+	}
+	if filter.Result != nil {
+		switch *filter.Result {
+		case "pass", "fail", "absent", "pending":
+		default:
+			return fmt.Errorf("%w: result must be pass, fail, absent or pending", ErrInvalidResultFilter)
+		}
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return fmt.Errorf("%w: from must be before to", ErrInvalidResultFilter)
+	}
+	return nil
+}
+
+// PublishResults releases all graded results of an exam to its students at
+// once and returns how many were published. The affected students are then
+// emailed in the background; email problems never undo the publish.
+func (s *examService) PublishResults(ctx context.Context, collegeID, examID int) (int, error) {
+	if collegeID == 0 || examID == 0 {
+		return 0, errors.New("invalid college ID or exam ID")
+	}
+	exam, err := s.repo.GetExamByID(ctx, collegeID, examID)
+	if err != nil {
+		return 0, err
+	}
+
+	studentIDs, err := s.repo.PublishResults(ctx, examID)
+	if err != nil {
+		return 0, err
+	}
+	if len(studentIDs) > 0 {
+		s.emit(ctx, collegeID, webhook.EventResultPublished, map[string]any{
+			"exam_id":     exam.ID,
+			"exam_title":  exam.Title,
+			"course_id":   exam.CourseID,
+			"student_ids": studentIDs,
+		})
+		s.notifyResultsPublished(ctx, collegeID, exam, studentIDs)
+	}
+	if s.notifier == nil || len(studentIDs) == 0 {
+		return len(studentIDs), nil
+	}
+
+	recipients, err := s.repo.ListResultNotificationRecipients(ctx, collegeID, studentIDs, s.notifier.includeParents)
+	if err != nil {
+		s.logger.Error().Err(err).Int("exam_id", examID).Msg("failed to load result notification recipients")
+		return len(studentIDs), nil
+	}
+	go func() {
+		notifyCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), resultEmailTimeout)
+		defer cancel()
+		s.notifier.Notify(notifyCtx, exam, recipients)
+	}()
+	return len(studentIDs), nil
+}
+
+// BulkGradeResults grades a batch of students in one transaction. Every mark
+// is validated against the exam before anything is written. In
+// BulkGradeAllOrNothing mode a single failure leaves the batch unwritten; in
+// BulkGradePartial mode the successful rows are committed. Either way the
+// report lists the outcome for each student.
+func (s *examService) BulkGradeResults(ctx context.Context, collegeID, examID int, results map[int]*ResultInput, mode BulkGradeMode) (*BulkGradeReport, error) {
+	if collegeID == 0 || examID == 0 {
+		return nil, errors.New("invalid college ID or exam ID")
+	}
+	if mode == "" {
+		mode = BulkGradeAllOrNothing
+	}
+	if mode != BulkGradeAllOrNothing && mode != BulkGradePartial {
+		return nil, fmt.Errorf("invalid bulk grade mode %q", mode)
+	}
+	if len(results) == 0 {
+		return nil, errors.New("no results to grade")
+	}
+
+	exam, err := s.repo.GetExamByID(ctx, collegeID, examID)
+	if err != nil {
+		return nil, err
+	}
+
+	studentIDs := make([]int, 0, len(results))
+	for studentID := range results {
+		studentIDs = append(studentIDs, studentID)
+	}
+	sort.Ints(studentIDs)
+
+	failures := make(map[int]error)
+	toSave := make([]*models.ExamResult, 0, len(studentIDs))
+	for _, studentID := range studentIDs {
+		input := results[studentID]
+		if studentID <= 0 || input == nil {
+			failures[studentID] = errors.New("invalid student ID or marks")
+			continue
+		}
+
 		result, err := s.repo.GetResult(ctx, examID, studentID)
-		if err != nil {
-			// If result doesn't exist, create it
+		switch {
+		case errors.Is(err, repository.ErrExamResultNotFound):
+			// No result yet for this student, create one
 			result = &models.ExamResult{
 				ExamID:    examID,
 				StudentID: studentID,
+				CollegeID: collegeID,
 			}
+		case err != nil:
+			return nil, fmt.Errorf("failed to load result for student %d: %w", studentID, err)
+		case result.CollegeID != collegeID:
+			failures[studentID] = errors.New("result belongs to another college")
+			continue
 		}
 
-		result.MarksObtained = &resultInput.MarksObtained
-		result.Remarks = resultInput.Remarks
+		marks := input.MarksObtained
+		result.MarksObtained = &marks
+		result.Remarks = input.Remarks
+		if err := s.applyMarks(exam, result); err != nil {
+			failures[studentID] = err
+			continue
+		}
+		toSave = append(toSave, result)
+	}
 
-		if result.ID == 0 {
-			if err := s.CreateResult(ctx, result); err != nil {
-				continue
-			}
-		} else {
-			if err := s.repo.UpdateResult(ctx, result); err != nil {
-				continue
-			}
+	allOrNothing := mode == BulkGradeAllOrNothing
+	committed := false
+	if len(toSave) > 0 && (!allOrNothing || len(failures) == 0) {
+		writeFailures, err := s.repo.SaveResults(ctx, toSave, allOrNothing)
+		if err != nil {
+			return nil, err
+		}
+		for studentID, writeErr := range writeFailures {
+			failures[studentID] = writeErr
 		}
+		committed = !allOrNothing || len(writeFailures) == 0
+		s.invalidateExamStats(ctx, examID)
 	}
 
-	return nil
+	report := &BulkGradeReport{
+		Mode:      mode,
+		Committed: committed,
+		Outcomes:  make([]BulkGradeOutcome, 0, len(studentIDs)),
+	}
+	for _, studentID := range studentIDs {
+		outcome := BulkGradeOutcome{StudentID: studentID}
+		switch err, failed := failures[studentID]; {
+		case failed:
+			outcome.Status = "failed"
+			outcome.Error = err.Error()
+			report.Failed++
+		case committed:
+			outcome.Status = "graded"
+			report.Graded++
+		default:
+			// Valid, but rolled back with the rest of the batch
+			outcome.Status = "skipped"
+		}
+		report.Outcomes = append(report.Outcomes, outcome)
+	}
+
+	return report, nil
 }
 
 func (s *examService) CalculateGrade(marks, totalMarks float64) string {
@@ -573,7 +1299,57 @@ func (s *examService) GetResultStats(ctx context.Context, examID int) (*ResultSt
 	if err != nil {
 		return nil, err
 	}
+	return resultStats(results), nil
+}
+
+// GetGroupedResultStats returns the exam's result statistics overall and per
+// group, sorted by group name. Only grouping by section is supported;
+// students without a section are counted in the UnassignedGroup, which comes
+// last.
+func (s *examService) GetGroupedResultStats(ctx context.Context, examID int, groupBy string) (*GroupedResultStats, error) {
+	if groupBy != ResultStatsGroupBySection {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidStatsGrouping, groupBy)
+	}
+
+	results, err := s.repo.ListResults(ctx, examID)
+	if err != nil {
+		return nil, err
+	}
+	sections, err := s.repo.ListResultSections(ctx, examID)
+	if err != nil {
+		return nil, err
+	}
+
+	byGroup := make(map[string][]*models.ExamResult)
+	for _, result := range results {
+		name, ok := sections[result.StudentID]
+		if !ok {
+			name = UnassignedGroup
+		}
+		byGroup[name] = append(byGroup[name], result)
+	}
+
+	groups := make([]*ResultStatsGroup, 0, len(byGroup))
+	for name, groupResults := range byGroup {
+		groups = append(groups, &ResultStatsGroup{Name: name, Stats: resultStats(groupResults)})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if (groups[i].Name == UnassignedGroup) != (groups[j].Name == UnassignedGroup) {
+			return groups[j].Name == UnassignedGroup
+		}
+		return groups[i].Name < groups[j].Name
+	})
+
+	return &GroupedResultStats{
+		GroupBy: groupBy,
+		Overall: resultStats(results),
+		Groups:  groups,
+	}, nil
+}
 
+// resultStats aggregates a set of results. Average and pass percentage only
+// count graded (passed or failed) results.
+func resultStats(results []*models.ExamResult) *ResultStats {
 	stats := &ResultStats{
 		TotalStudents: len(results),
 		LowestMarks:   999999, // Initialize with high value
@@ -614,27 +1390,64 @@ func (s *examService) GetResultStats(ctx context.Context, examID int) (*ResultSt
 		stats.LowestMarks = 0
 	}
 
-	return stats, nil
+	return stats
 }
 
 // ===========================
 // Revaluation Management
 // ===========================
 
-func (s *examService) CreateRevaluationRequest(ctx context.Context, request *models.RevaluationRequest) error {
+// CreateRevaluationRequest asks for one of the student's own published results
+// to be revalued; the result's current marks become the previous marks.
+// Requests close a configured number of days after the result is published
+// unless opts override the deadline. When the college charges for
+// revaluation the request is only created once the fee is paid, and until
+// then a *RevaluationFeeDueError names the fee assignment to pay and the
+// amount due.
+func (s *examService) CreateRevaluationRequest(ctx context.Context, request *models.RevaluationRequest, opts CreateRevaluationOptions) error {
 	if request.ExamResultID == 0 || request.StudentID == 0 {
 		return errors.New("exam result ID and student ID are required")
 	}
-	if request.Reason == "" {
+	if request.CollegeID == 0 {
+		return errors.New("college ID is required")
+	}
+	if strings.TrimSpace(request.Reason) == "" {
 		return errors.New("reason is required")
 	}
 
-	// Set default status
-	if request.Status == "" {
-		request.Status = "pending"
+	result, err := s.repo.GetResultByID(ctx, request.ExamResultID)
+	if err != nil {
+		return err
+	}
+	// Another student's result, or one the student cannot see yet, is
+	// reported as missing rather than forbidden
+	if result.StudentID != request.StudentID || result.CollegeID != request.CollegeID || !result.Published {
+		return ErrExamResultNotFound
+	}
+	if result.MarksObtained == nil {
+		return errors.New("result has not been graded")
+	}
+	if !opts.OverrideDeadline {
+		if err := s.checkRevaluationWindow(result, time.Now()); err != nil {
+			return err
+		}
 	}
+	request.PreviousMarks = *result.MarksObtained
+	request.Status = "pending"
 
-	return s.repo.CreateRevaluationRequest(ctx, request)
+	assignment, err := s.attachRevaluationFee(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.CreateRevaluationRequest(ctx, request)
+	var due *RevaluationFeeDueError
+	if errors.As(err, &due) {
+		if err := s.chargeRevaluationFee(ctx, assignment, due.AmountDue); err != nil {
+			return err
+		}
+	}
+	return err
 }
 
 func (s *examService) GetRevaluationRequest(ctx context.Context, requestID int) (*models.RevaluationRequest, error) {
@@ -644,11 +1457,18 @@ func (s *examService) GetRevaluationRequest(ctx context.Context, requestID int)
 	return s.repo.GetRevaluationRequest(ctx, requestID)
 }
 
-func (s *examService) ListRevaluationRequests(ctx context.Context, collegeID int, filters map[string]any) ([]*models.RevaluationRequest, error) {
+func (s *examService) ListRevaluationRequests(ctx context.Context, collegeID int, filter models.RevaluationRequestFilter) ([]*models.RevaluationRequest, error) {
+	if collegeID == 0 {
+		return nil, errors.New("college ID is required")
+	}
+	return s.repo.ListRevaluationRequests(ctx, collegeID, filter)
+}
+
+func (s *examService) ListRevaluationRequestDetails(ctx context.Context, collegeID int, filter models.RevaluationRequestFilter) ([]*models.RevaluationRequestDetail, error) {
 	if collegeID == 0 {
 		return nil, errors.New("college ID is required")
 	}
-	return s.repo.ListRevaluationRequests(ctx, collegeID, filters)
+	return s.repo.ListRevaluationRequestDetails(ctx, collegeID, filter)
 }
 
 func (s *examService) UpdateRevaluationRequest(ctx context.Context, request *models.RevaluationRequest) error {
@@ -689,17 +1509,22 @@ func (s *examService) ApproveRevaluationRequest(ctx context.Context, requestID i
 	grade := s.CalculateGrade(revisedMarks, exam.TotalMarks)
 	result.Grade = &grade
 
-	if revisedMarks >= exam.PassingMarks {
-		result.Result = "Pass"
+	if exam.Passes(revisedMarks) {
+		result.Result = "pass"
 	} else {
-		result.Result = "Fail"
+		result.Result = "fail"
 	}
 
 	if err := s.repo.UpdateResult(ctx, result); err != nil {
 		return fmt.Errorf("failed to update exam result: %w", err)
 	}
+	s.invalidateExamStats(ctx, result.ExamID)
 
-	return s.repo.UpdateRevaluationRequest(ctx, request)
+	if err := s.repo.UpdateRevaluationRequest(ctx, request); err != nil {
+		return err
+	}
+	s.notifyRevaluationDecided(ctx, request, exam)
+	return nil
 }
 
 func (s *examService) RejectRevaluationRequest(ctx context.Context, requestID int, reviewedBy int, comments string) error {
@@ -714,7 +1539,11 @@ func (s *examService) RejectRevaluationRequest(ctx context.Context, requestID in
 	now := time.Now()
 	request.ReviewedAt = &now
 
-	return s.repo.UpdateRevaluationRequest(ctx, request)
+	if err := s.repo.UpdateRevaluationRequest(ctx, request); err != nil {
+		return err
+	}
+	s.notifyRevaluationDecided(ctx, request, nil)
+	return nil
 }
 
 // ===========================
@@ -769,3 +1598,10 @@ func (s *examService) CheckRoomAvailability(ctx context.Context, roomID int, sta
 	}
 	return s.repo.CheckRoomAvailability(ctx, roomID, startTime, endTime)
 }
+
+// emit publishes a webhook event when an emitter is configured
+func (s *examService) emit(ctx context.Context, collegeID int, event string, data any) {
+	if s.events != nil {
+		s.events.Emit(ctx, collegeID, event, data)
+	}
+}
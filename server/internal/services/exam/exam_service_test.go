@@ -0,0 +1,499 @@
+package exam
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+	"eduhub/server/internal/services/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateExamMetadata(t *testing.T) {
+	t.Run("normalises allowed materials", func(t *testing.T) {
+		exam := &models.Exam{QuestionPaperSets: 2, AllowedMaterials: []string{" Calculator ", "", "Notes"}}
+		require.NoError(t, validateExamMetadata(exam))
+		assert.Equal(t, []string{"Calculator", "Notes"}, exam.AllowedMaterials)
+	})
+
+	t.Run("nil materials become an empty list", func(t *testing.T) {
+		exam := &models.Exam{QuestionPaperSets: 1}
+		require.NoError(t, validateExamMetadata(exam))
+		assert.NotNil(t, exam.AllowedMaterials)
+		assert.Empty(t, exam.AllowedMaterials)
+	})
+
+	t.Run("question paper sets must be at least one", func(t *testing.T) {
+		exam := &models.Exam{QuestionPaperSets: 0}
+		assert.Error(t, validateExamMetadata(exam))
+	})
+
+	t.Run("overlong material is rejected", func(t *testing.T) {
+		exam := &models.Exam{QuestionPaperSets: 1, AllowedMaterials: []string{strings.Repeat("x", maxAllowedMaterialLength+1)}}
+		assert.Error(t, validateExamMetadata(exam))
+	})
+
+	t.Run("seating scheme defaults to sequential", func(t *testing.T) {
+		exam := &models.Exam{QuestionPaperSets: 1}
+		require.NoError(t, validateExamMetadata(exam))
+		assert.Equal(t, models.SeatingSequential, exam.SeatingScheme)
+	})
+
+	t.Run("unknown seating scheme is rejected", func(t *testing.T) {
+		exam := &models.Exam{QuestionPaperSets: 1, SeatingScheme: "alphabetical"}
+		assert.Error(t, validateExamMetadata(exam))
+	})
+}
+
+// bulkGradeRepo records SaveResults calls; the embedded interface panics on
+// anything else the test did not expect
+type bulkGradeRepo struct {
+	repository.ExamRepository
+	saved        []*models.ExamResult
+	allOrNothing bool
+	writeErrors  map[int]error
+	getErr       error
+}
+
+func (r *bulkGradeRepo) GetExamByID(ctx context.Context, collegeID, examID int) (*models.Exam, error) {
+	return &models.Exam{ID: examID, CollegeID: collegeID, TotalMarks: 100, PassingMarks: 40}, nil
+}
+
+func (r *bulkGradeRepo) GetResult(ctx context.Context, examID, studentID int) (*models.ExamResult, error) {
+	if r.getErr != nil {
+		return nil, r.getErr
+	}
+	return nil, repository.ErrExamResultNotFound
+}
+
+func (r *bulkGradeRepo) SaveResults(ctx context.Context, results []*models.ExamResult, allOrNothing bool) (map[int]error, error) {
+	r.saved = results
+	r.allOrNothing = allOrNothing
+	return r.writeErrors, nil
+}
+
+func TestBulkGradeResults(t *testing.T) {
+	ctx := context.Background()
+	input := func() map[int]*ResultInput {
+		return map[int]*ResultInput{
+			1: {MarksObtained: 75},
+			2: {MarksObtained: 130}, // above total marks
+			3: {MarksObtained: 20},
+		}
+	}
+
+	t.Run("all or nothing writes nothing when a mark is invalid", func(t *testing.T) {
+		repo := &bulkGradeRepo{}
+		svc := &examService{repo: repo}
+
+		report, err := svc.BulkGradeResults(ctx, 1, 9, input(), BulkGradeAllOrNothing)
+		require.NoError(t, err)
+
+		assert.Nil(t, repo.saved)
+		assert.False(t, report.Committed)
+		assert.Equal(t, 1, report.Failed)
+		assert.Equal(t, 0, report.Graded)
+		assert.Equal(t, "skipped", report.Outcomes[0].Status)
+		assert.Equal(t, "failed", report.Outcomes[1].Status)
+	})
+
+	t.Run("partial commits the valid marks", func(t *testing.T) {
+		repo := &bulkGradeRepo{}
+		svc := &examService{repo: repo}
+
+		report, err := svc.BulkGradeResults(ctx, 1, 9, input(), BulkGradePartial)
+		require.NoError(t, err)
+
+		require.Len(t, repo.saved, 2)
+		assert.False(t, repo.allOrNothing)
+		assert.Equal(t, 1, repo.saved[0].CollegeID)
+		assert.Equal(t, "pass", repo.saved[0].Result)
+		assert.Equal(t, "fail", repo.saved[1].Result)
+		assert.True(t, report.Committed)
+		assert.Equal(t, 2, report.Graded)
+		assert.Equal(t, 1, report.Failed)
+	})
+
+	t.Run("write failures roll back an all or nothing batch", func(t *testing.T) {
+		repo := &bulkGradeRepo{writeErrors: map[int]error{3: errors.New("boom")}}
+		svc := &examService{repo: repo}
+
+		report, err := svc.BulkGradeResults(ctx, 1, 9, map[int]*ResultInput{
+			1: {MarksObtained: 75},
+			3: {MarksObtained: 20},
+		}, "")
+		require.NoError(t, err)
+
+		assert.True(t, repo.allOrNothing)
+		assert.False(t, report.Committed)
+		assert.Equal(t, BulkGradeAllOrNothing, report.Mode)
+		assert.Equal(t, "skipped", report.Outcomes[0].Status)
+		assert.Equal(t, "boom", report.Outcomes[1].Error)
+	})
+
+	t.Run("database errors are returned instead of treated as new results", func(t *testing.T) {
+		repo := &bulkGradeRepo{getErr: errors.New("connection reset")}
+		svc := &examService{repo: repo}
+
+		_, err := svc.BulkGradeResults(ctx, 1, 9, input(), BulkGradePartial)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "connection reset")
+		assert.Nil(t, repo.saved)
+	})
+
+	t.Run("unknown mode is rejected", func(t *testing.T) {
+		svc := &examService{repo: &bulkGradeRepo{}}
+		_, err := svc.BulkGradeResults(ctx, 1, 9, input(), "sometimes")
+		assert.Error(t, err)
+	})
+}
+
+type clashRepo struct {
+	repository.ExamRepository
+	clashes  []*models.ExamClash
+	enrolled bool
+}
+
+func (r *clashRepo) GetEnrollment(ctx context.Context, examID, studentID int) (*models.ExamEnrollment, error) {
+	return nil, errors.New("enrollment not found")
+}
+
+func (r *clashRepo) EnrollStudent(ctx context.Context, enrollment *models.ExamEnrollment) error {
+	r.enrolled = true
+	return nil
+}
+
+func (r *clashRepo) ListExamClashes(ctx context.Context, collegeID int, studentID *int) ([]*models.ExamClash, error) {
+	return r.clashes, nil
+}
+
+func TestEnrollStudentWarnsOnClash(t *testing.T) {
+	repo := &clashRepo{clashes: []*models.ExamClash{
+		{StudentID: 4, ExamID: 2, ClashingExamID: 5},
+		{StudentID: 4, ExamID: 7, ClashingExamID: 8}, // unrelated to the new enrollment
+	}}
+	svc := &examService{repo: repo}
+
+	enrollment := &models.ExamEnrollment{ExamID: 5, StudentID: 4, CollegeID: 1}
+	require.NoError(t, svc.EnrollStudent(context.Background(), enrollment))
+
+	assert.True(t, repo.enrolled)
+	require.Len(t, enrollment.Clashes, 1)
+	assert.Equal(t, 2, enrollment.Clashes[0].ExamID)
+}
+
+type createExamRepo struct {
+	repository.ExamRepository
+	created *models.Exam
+}
+
+func (r *createExamRepo) CreateExam(ctx context.Context, exam *models.Exam) error {
+	r.created = exam
+	return nil
+}
+
+func TestCreateExamSchedule(t *testing.T) {
+	ctx := context.Background()
+	newExam := func(start time.Time, duration int) *models.Exam {
+		return &models.Exam{
+			CollegeID:         1,
+			CourseID:          2,
+			Title:             "Algebra final",
+			StartTime:         start,
+			EndTime:           start.Add(3 * time.Hour),
+			Duration:          duration,
+			TotalMarks:        100,
+			PassingMarks:      40,
+			QuestionPaperSets: 1,
+		}
+	}
+	tomorrow := time.Now().Add(24 * time.Hour)
+	lastWeek := time.Now().Add(-7 * 24 * time.Hour)
+
+	t.Run("future exam is created", func(t *testing.T) {
+		repo := &createExamRepo{}
+		svc := &examService{repo: repo}
+		require.NoError(t, svc.CreateExam(ctx, newExam(tomorrow, 180), CreateExamOptions{}))
+		require.NotNil(t, repo.created)
+		assert.Equal(t, "scheduled", repo.created.Status)
+	})
+
+	t.Run("past scheduled exam is rejected", func(t *testing.T) {
+		repo := &createExamRepo{}
+		svc := &examService{repo: repo}
+		err := svc.CreateExam(ctx, newExam(lastWeek, 180), CreateExamOptions{})
+		assert.ErrorIs(t, err, ErrExamInPast)
+		assert.Nil(t, repo.created)
+	})
+
+	t.Run("past exam is allowed when backfilling", func(t *testing.T) {
+		repo := &createExamRepo{}
+		svc := &examService{repo: repo}
+		require.NoError(t, svc.CreateExam(ctx, newExam(lastWeek, 180), CreateExamOptions{Backfill: true}))
+		assert.NotNil(t, repo.created)
+	})
+
+	t.Run("past completed exam is not a scheduling error", func(t *testing.T) {
+		exam := newExam(lastWeek, 180)
+		exam.Status = "completed"
+		assert.NoError(t, validateExamSchedule(exam, time.Now(), false))
+	})
+
+	t.Run("duration within tolerance is accepted", func(t *testing.T) {
+		assert.NoError(t, validateExamSchedule(newExam(tomorrow, 170), time.Now(), false))
+		assert.NoError(t, validateExamSchedule(newExam(tomorrow, 195), time.Now(), false))
+	})
+
+	t.Run("mismatched duration is rejected", func(t *testing.T) {
+		repo := &createExamRepo{}
+		svc := &examService{repo: repo}
+		err := svc.CreateExam(ctx, newExam(tomorrow, 60), CreateExamOptions{})
+		assert.ErrorIs(t, err, ErrDurationMismatch)
+		assert.Nil(t, repo.created)
+
+		err = svc.CreateExam(ctx, newExam(lastWeek, 240), CreateExamOptions{Backfill: true})
+		assert.ErrorIs(t, err, ErrDurationMismatch)
+	})
+}
+
+type publishRepo struct {
+	repository.ExamRepository
+	updated   *models.ExamResult
+	published []int
+	examErr   error
+}
+
+func (r *publishRepo) GetExamByID(ctx context.Context, collegeID, examID int) (*models.Exam, error) {
+	if r.examErr != nil {
+		return nil, r.examErr
+	}
+	return &models.Exam{ID: examID, CollegeID: collegeID, TotalMarks: 100, PassingMarks: 40}, nil
+}
+
+func (r *publishRepo) UpdateResult(ctx context.Context, result *models.ExamResult) error {
+	r.updated = result
+	return nil
+}
+
+func (r *publishRepo) PublishResults(ctx context.Context, examID int) ([]int, error) {
+	return r.published, nil
+}
+
+type recordingEmitter struct {
+	events    []string
+	collegeID int
+}
+
+func (e *recordingEmitter) Emit(ctx context.Context, collegeID int, event string, data any) {
+	e.events = append(e.events, event)
+	e.collegeID = collegeID
+}
+
+func TestResultPublishing(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("regrading hides a published result", func(t *testing.T) {
+		repo := &publishRepo{}
+		svc := &examService{repo: repo}
+		marks := 82.0
+		result := &models.ExamResult{ID: 5, ExamID: 9, StudentID: 3, CollegeID: 1, MarksObtained: &marks, Version: 2, Published: true}
+
+		require.NoError(t, svc.UpdateResult(ctx, result))
+		require.NotNil(t, repo.updated)
+		assert.False(t, repo.updated.Published)
+		assert.Nil(t, repo.updated.PublishedAt)
+	})
+
+	t.Run("publish reports the number released", func(t *testing.T) {
+		svc := &examService{repo: &publishRepo{published: []int{3, 4, 7}}}
+
+		count, err := svc.PublishResults(ctx, 1, 9)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("publish emits a result.published event", func(t *testing.T) {
+		events := &recordingEmitter{}
+		svc := &examService{repo: &publishRepo{published: []int{3, 4}}, events: events}
+
+		_, err := svc.PublishResults(ctx, 1, 9)
+		require.NoError(t, err)
+		require.Len(t, events.events, 1)
+		assert.Equal(t, webhook.EventResultPublished, events.events[0])
+		assert.Equal(t, 1, events.collegeID)
+	})
+
+	t.Run("publishing nothing emits no event", func(t *testing.T) {
+		events := &recordingEmitter{}
+		svc := &examService{repo: &publishRepo{}, events: events}
+
+		_, err := svc.PublishResults(ctx, 1, 9)
+		require.NoError(t, err)
+		assert.Empty(t, events.events)
+	})
+
+	t.Run("publish requires an exam in the college", func(t *testing.T) {
+		svc := &examService{repo: &publishRepo{examErr: errors.New("exam not found")}}
+
+		_, err := svc.PublishResults(ctx, 1, 9)
+		assert.Error(t, err)
+	})
+}
+
+type statsRepo struct {
+	repository.ExamRepository
+	results  []*models.ExamResult
+	sections map[int]string
+}
+
+func (r *statsRepo) GetExamByID(ctx context.Context, collegeID, examID int) (*models.Exam, error) {
+	return &models.Exam{ID: examID, CollegeID: collegeID, TotalMarks: 50, PassingMarks: 20}, nil
+}
+
+func (r *statsRepo) ListEnrollments(ctx context.Context, examID int) ([]*models.ExamEnrollment, error) {
+	return []*models.ExamEnrollment{{Status: "appeared"}, {Status: "appeared"}, {Status: "appeared"}, {Status: "appeared"}, {Status: "absent"}}, nil
+}
+
+func (r *statsRepo) ListResults(ctx context.Context, examID int) ([]*models.ExamResult, error) {
+	return r.results, nil
+}
+
+func (r *statsRepo) ListResultSections(ctx context.Context, examID int) (map[int]string, error) {
+	return r.sections, nil
+}
+
+func TestGetExamStatsDistribution(t *testing.T) {
+	marks := func(v float64) *float64 { return &v }
+	repo := &statsRepo{results: []*models.ExamResult{
+		{MarksObtained: marks(50)}, // 100%, top bucket
+		{MarksObtained: marks(31)}, // 62%
+		{MarksObtained: marks(12)}, // 24%
+		{MarksObtained: marks(44)}, // 88%
+		{},                         // not graded yet
+	}}
+	svc := &examService{repo: repo}
+
+	stats, err := svc.GetExamStats(context.Background(), 1, 3)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, stats.ResultsPublished)
+	assert.Equal(t, 37.5, stats.MedianMarks)
+	assert.Equal(t, 50.0, stats.HighestMarks)
+	assert.Equal(t, 12.0, stats.LowestMarks)
+
+	assert.Equal(t, map[string]int{"A+": 1, "A": 1, "B+": 0, "B": 1, "C+": 0, "C": 0, "F": 1}, stats.GradeDistribution)
+
+	require.Len(t, stats.MarkHistogram, 10)
+	assert.Equal(t, MarkBucket{From: 90, To: 100, Count: 1}, stats.MarkHistogram[9])
+	assert.Equal(t, 1, stats.MarkHistogram[8].Count)
+	assert.Equal(t, 1, stats.MarkHistogram[6].Count)
+	assert.Equal(t, 1, stats.MarkHistogram[2].Count)
+	assert.Equal(t, 0, stats.MarkHistogram[0].Count)
+}
+
+func TestGetGroupedResultStats(t *testing.T) {
+	marks := func(v float64) *float64 { return &v }
+	repo := &statsRepo{
+		results: []*models.ExamResult{
+			{StudentID: 1, MarksObtained: marks(40), Result: "pass"},
+			{StudentID: 2, MarksObtained: marks(10), Result: "fail"},
+			{StudentID: 3, MarksObtained: marks(30), Result: "pass"},
+			{StudentID: 4, MarksObtained: marks(45), Result: "pass"},
+			{StudentID: 5, MarksObtained: marks(15), Result: "fail"},
+		},
+		sections: map[int]string{1: "B", 2: "B", 3: "A"},
+	}
+	svc := &examService{repo: repo}
+
+	stats, err := svc.GetGroupedResultStats(context.Background(), 3, ResultStatsGroupBySection)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, stats.Overall.TotalStudents)
+	assert.Equal(t, 60.0, stats.Overall.PassPercentage)
+	assert.Equal(t, 28.0, stats.Overall.AverageMarks)
+
+	require.Len(t, stats.Groups, 3)
+	assert.Equal(t, []string{"A", "B", UnassignedGroup},
+		[]string{stats.Groups[0].Name, stats.Groups[1].Name, stats.Groups[2].Name})
+	assert.Equal(t, 100.0, stats.Groups[0].Stats.PassPercentage)
+	assert.Equal(t, 30.0, stats.Groups[0].Stats.AverageMarks)
+	assert.Equal(t, 50.0, stats.Groups[1].Stats.PassPercentage)
+	assert.Equal(t, 25.0, stats.Groups[1].Stats.AverageMarks)
+	assert.Equal(t, 2, stats.Groups[2].Stats.TotalStudents)
+	assert.Equal(t, 30.0, stats.Groups[2].Stats.AverageMarks)
+
+	_, err = svc.GetGroupedResultStats(context.Background(), 3, "hostel")
+	assert.ErrorIs(t, err, ErrInvalidStatsGrouping)
+}
+
+// seatingRepo stands in for the exam row lock: AssignSeats fails fast like
+// FOR UPDATE NOWAIT while another allocation holds it
+type seatingRepo struct {
+	repository.ExamRepository
+	lock     sync.Mutex
+	entered  chan struct{}
+	release  chan struct{}
+	assigned [][]*models.SeatAssignment
+}
+
+func (r *seatingRepo) GetExamByID(ctx context.Context, collegeID, examID int) (*models.Exam, error) {
+	return &models.Exam{ID: examID, CollegeID: collegeID, QuestionPaperSets: 2}, nil
+}
+
+func (r *seatingRepo) ListEnrollments(ctx context.Context, examID int) ([]*models.ExamEnrollment, error) {
+	return []*models.ExamEnrollment{
+		{ID: 12, ExamID: examID, StudentID: 7},
+		{ID: 10, ExamID: examID, StudentID: 5},
+		{ID: 11, ExamID: examID, StudentID: 6},
+	}, nil
+}
+
+func (r *seatingRepo) AssignSeats(ctx context.Context, collegeID, examID int, seats []*models.SeatAssignment) error {
+	if !r.lock.TryLock() {
+		return repository.ErrSeatAllocationInProgress
+	}
+	defer r.lock.Unlock()
+
+	r.entered <- struct{}{}
+	<-r.release
+	r.assigned = append(r.assigned, seats)
+	return nil
+}
+
+func TestAllocateSeats_Concurrent(t *testing.T) {
+	repo := &seatingRepo{entered: make(chan struct{}), release: make(chan struct{})}
+	svc := &examService{repo: repo}
+	ctx := context.Background()
+
+	first := make(chan error, 1)
+	go func() {
+		first <- svc.AllocateSeats(ctx, 1, 4)
+	}()
+	<-repo.entered
+
+	// The first allocation still holds the lock
+	err := svc.AllocateSeats(ctx, 1, 4)
+	assert.ErrorIs(t, err, ErrSeatAllocationInProgress)
+
+	close(repo.release)
+	require.NoError(t, <-first)
+
+	require.Len(t, repo.assigned, 1)
+	seats := repo.assigned[0]
+	require.Len(t, seats, 3)
+	taken := map[string]bool{}
+	for i, seat := range seats {
+		assert.False(t, taken[seat.SeatNumber], "seat %s assigned twice", seat.SeatNumber)
+		taken[seat.SeatNumber] = true
+		assert.Equal(t, 10+i, seat.EnrollmentID)
+		assert.Equal(t, i%2+1, *seat.QuestionPaperSet)
+	}
+	assert.Equal(t, "S001", seats[0].SeatNumber)
+}
@@ -0,0 +1,108 @@
+package exam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+)
+
+// DefaultMaxExtensionMinutes is used when the service is built without a cap
+const DefaultMaxExtensionMinutes = 60
+
+const maxExtensionReasonLen = 500
+
+// ErrInvalidExtension is returned by GrantExtension for a non-positive or
+// oversized extension or a missing reason
+var ErrInvalidExtension = errors.New("invalid exam time extension")
+
+// ErrExtensionCapExceeded is returned by GrantExtension when the student's
+// extensions would exceed the configured maximum
+var ErrExtensionCapExceeded = repository.ErrExtensionCapExceeded
+
+// GrantExtensionRequest asks for extra time for one student
+type GrantExtensionRequest struct {
+	StudentID int    `json:"student_id"`
+	Minutes   int    `json:"minutes"`
+	Reason    string `json:"reason"`
+}
+
+// ExtensionGrant is a recorded extension with the student's resulting total
+// extension time and end time
+type ExtensionGrant struct {
+	Extension             *models.ExamTimeExtension `json:"extension"`
+	TotalExtensionMinutes int                       `json:"total_extension_minutes"`
+	EndTime               time.Time                 `json:"end_time"`
+}
+
+// GrantExtension gives a student extra time in an exam, on top of any
+// accommodations. Unless admin is set the caller must be one of the exam's
+// invigilators. A student's extensions in one exam may add up to at most the
+// configured cap.
+func (s *examService) GrantExtension(ctx context.Context, collegeID, examID, userID int, admin bool, req GrantExtensionRequest) (*ExtensionGrant, error) {
+	if collegeID == 0 || examID == 0 || req.StudentID == 0 {
+		return nil, errors.New("college ID, exam ID and student ID are required")
+	}
+	maxMinutes := s.maxExtensionMinutes
+	if maxMinutes <= 0 {
+		maxMinutes = DefaultMaxExtensionMinutes
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	switch {
+	case req.Minutes <= 0 || req.Minutes > maxMinutes:
+		return nil, fmt.Errorf("%w: minutes must be between 1 and %d", ErrInvalidExtension, maxMinutes)
+	case req.Reason == "":
+		return nil, fmt.Errorf("%w: a reason is required", ErrInvalidExtension)
+	case len(req.Reason) > maxExtensionReasonLen:
+		return nil, fmt.Errorf("%w: reason must be at most %d characters", ErrInvalidExtension, maxExtensionReasonLen)
+	}
+
+	exam, err := s.repo.GetExamByID(ctx, collegeID, examID)
+	if err != nil {
+		return nil, err
+	}
+	if exam.Status == "cancelled" || exam.Status == "completed" {
+		return nil, fmt.Errorf("%w: exam is %s", ErrExamNotInProgress, exam.Status)
+	}
+	if !admin {
+		if err := s.requireInvigilator(ctx, collegeID, examID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	extension := &models.ExamTimeExtension{
+		CollegeID: collegeID,
+		ExamID:    examID,
+		StudentID: req.StudentID,
+		Minutes:   req.Minutes,
+		Reason:    req.Reason,
+		GrantedBy: userID,
+	}
+	total, err := s.repo.GrantExtension(ctx, extension, maxMinutes)
+	if err != nil {
+		return nil, err
+	}
+
+	// The enrollment's accommodations also count towards the end time
+	enrollment, err := s.repo.GetEnrollment(ctx, examID, req.StudentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload enrollment: %w", err)
+	}
+	return &ExtensionGrant{
+		Extension:             extension,
+		TotalExtensionMinutes: total,
+		EndTime:               enrollment.EndTime(exam),
+	}, nil
+}
+
+// ListExtensions lists the extensions granted in an exam, oldest first
+func (s *examService) ListExtensions(ctx context.Context, collegeID, examID int) ([]*models.ExamTimeExtension, error) {
+	if _, err := s.repo.GetExamByID(ctx, collegeID, examID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListExtensions(ctx, collegeID, examID)
+}
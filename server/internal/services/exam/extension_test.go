@@ -0,0 +1,122 @@
+package exam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type extensionRepo struct {
+	repository.ExamRepository
+	exam         *models.Exam
+	enrollment   *models.ExamEnrollment
+	invigilators []*models.ExamInvigilator
+	granted      *models.ExamTimeExtension
+	maxTotal     int
+}
+
+func (r *extensionRepo) GetExamByID(ctx context.Context, collegeID, examID int) (*models.Exam, error) {
+	return r.exam, nil
+}
+
+func (r *extensionRepo) ListInvigilators(ctx context.Context, collegeID, examID int) ([]*models.ExamInvigilator, error) {
+	return r.invigilators, nil
+}
+
+func (r *extensionRepo) GrantExtension(ctx context.Context, extension *models.ExamTimeExtension, maxTotalMinutes int) (int, error) {
+	if r.enrollment.ExtensionMinutes+extension.Minutes > maxTotalMinutes {
+		return 0, repository.ErrExtensionCapExceeded
+	}
+	r.granted = extension
+	r.maxTotal = maxTotalMinutes
+	r.enrollment.ExtensionMinutes += extension.Minutes
+	return r.enrollment.ExtensionMinutes, nil
+}
+
+func (r *extensionRepo) GetEnrollment(ctx context.Context, examID, studentID int) (*models.ExamEnrollment, error) {
+	return r.enrollment, nil
+}
+
+func newExtensionRepo() *extensionRepo {
+	start := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	return &extensionRepo{
+		exam: &models.Exam{ID: 4, CollegeID: 1, Status: "ongoing", StartTime: start, EndTime: start.Add(3 * time.Hour)},
+		enrollment: &models.ExamEnrollment{ExamID: 4, StudentID: 7, CollegeID: 1,
+			Accommodations: models.ExamAccommodations{ExtraTimeMinutes: 30}},
+		invigilators: []*models.ExamInvigilator{{ExamID: 4, UserID: 11}},
+	}
+}
+
+func TestGrantExtension(t *testing.T) {
+	ctx := context.Background()
+	req := GrantExtensionRequest{StudentID: 7, Minutes: 15, Reason: " Laptop crashed "}
+
+	t.Run("invigilator grants extra time", func(t *testing.T) {
+		repo := newExtensionRepo()
+		svc := &examService{repo: repo, maxExtensionMinutes: 40}
+		grant, err := svc.GrantExtension(ctx, 1, 4, 11, false, req)
+		require.NoError(t, err)
+		assert.Equal(t, "Laptop crashed", repo.granted.Reason)
+		assert.Equal(t, 11, repo.granted.GrantedBy)
+		assert.Equal(t, 40, repo.maxTotal)
+		assert.Equal(t, 15, grant.TotalExtensionMinutes)
+		// 12:00 end + 30 accommodation + 15 extension
+		assert.Equal(t, time.Date(2026, 5, 4, 12, 45, 0, 0, time.UTC), grant.EndTime)
+	})
+
+	t.Run("cumulative cap", func(t *testing.T) {
+		repo := newExtensionRepo()
+		repo.enrollment.ExtensionMinutes = 30
+		svc := &examService{repo: repo, maxExtensionMinutes: 40}
+		_, err := svc.GrantExtension(ctx, 1, 4, 11, false, req)
+		assert.ErrorIs(t, err, ErrExtensionCapExceeded)
+	})
+
+	t.Run("default cap", func(t *testing.T) {
+		repo := newExtensionRepo()
+		svc := &examService{repo: repo}
+		_, err := svc.GrantExtension(ctx, 1, 4, 11, false, GrantExtensionRequest{StudentID: 7, Minutes: DefaultMaxExtensionMinutes + 1, Reason: "power cut"})
+		assert.ErrorIs(t, err, ErrInvalidExtension)
+	})
+
+	t.Run("must be positive", func(t *testing.T) {
+		svc := &examService{repo: newExtensionRepo()}
+		_, err := svc.GrantExtension(ctx, 1, 4, 11, false, GrantExtensionRequest{StudentID: 7, Minutes: 0, Reason: "power cut"})
+		assert.ErrorIs(t, err, ErrInvalidExtension)
+	})
+
+	t.Run("reason required", func(t *testing.T) {
+		svc := &examService{repo: newExtensionRepo()}
+		_, err := svc.GrantExtension(ctx, 1, 4, 11, false, GrantExtensionRequest{StudentID: 7, Minutes: 10, Reason: "  "})
+		assert.ErrorIs(t, err, ErrInvalidExtension)
+	})
+
+	t.Run("other staff are refused", func(t *testing.T) {
+		repo := newExtensionRepo()
+		svc := &examService{repo: repo}
+		_, err := svc.GrantExtension(ctx, 1, 4, 12, false, req)
+		assert.ErrorIs(t, err, ErrNotInvigilator)
+		assert.Nil(t, repo.granted)
+	})
+
+	t.Run("admins need not invigilate", func(t *testing.T) {
+		repo := newExtensionRepo()
+		svc := &examService{repo: repo}
+		_, err := svc.GrantExtension(ctx, 1, 4, 12, true, req)
+		require.NoError(t, err)
+	})
+
+	t.Run("cancelled exam", func(t *testing.T) {
+		repo := newExtensionRepo()
+		repo.exam.Status = "cancelled"
+		svc := &examService{repo: repo}
+		_, err := svc.GrantExtension(ctx, 1, 4, 11, false, req)
+		assert.ErrorIs(t, err, ErrExamNotInProgress)
+	})
+}
@@ -0,0 +1,37 @@
+package exam
+
+import (
+	"context"
+
+	"eduhub/server/internal/i18n"
+	"eduhub/server/internal/models"
+)
+
+// hallTicketLabelKeys are the printed labels of a hall ticket, keyed as in
+// HallTicketResponse.Labels
+var hallTicketLabelKeys = []string{
+	"title", "exam", "student_name", "exam_date", "start_time", "end_time", "duration",
+	"seat_number", "room_number", "question_paper_set", "instructions", "accommodations", "version",
+}
+
+// localizeHallTicket prints an issued ticket's labels and accommodations in
+// locale, falling back to the student's own language and then to English.
+// It runs after the ticket is fingerprinted so the language never issues a
+// new version.
+func (s *examService) localizeHallTicket(ctx context.Context, ticket *models.HallTicketResponse, enrollment *models.ExamEnrollment, locale string) {
+	if locale == "" {
+		language, err := s.repo.GetStudentLanguage(ctx, enrollment.CollegeID, enrollment.StudentID)
+		if err != nil {
+			s.logger.Warn().Err(err).Int("student_id", enrollment.StudentID).Msg("failed to load student language for hall ticket")
+		}
+		locale = language
+	}
+	locale = i18n.Normalize(locale)
+
+	ticket.Locale = locale
+	ticket.Labels = make(map[string]string, len(hallTicketLabelKeys))
+	for _, key := range hallTicketLabelKeys {
+		ticket.Labels[key] = i18n.T(locale, "hall_ticket."+key)
+	}
+	ticket.Accommodations = accommodationLines(locale, enrollment.Accommodations)
+}
@@ -0,0 +1,70 @@
+package exam
+
+import (
+	"context"
+	"fmt"
+
+	"eduhub/server/internal/models"
+)
+
+// notifyResultsPublished puts a notification in the inbox of every student
+// whose result was just published, and of their parents when result emails go
+// to parents as well
+func (s *examService) notifyResultsPublished(ctx context.Context, collegeID int, exam *models.Exam, studentIDs []int) {
+	if s.inbox == nil {
+		return
+	}
+	includeParents := s.notifier != nil && s.notifier.includeParents
+	userIDs, err := s.repo.ListResultInboxUserIDs(ctx, collegeID, studentIDs, includeParents)
+	if err != nil {
+		s.logger.Error().Err(err).Int("exam_id", exam.ID).Msg("failed to load result inbox users")
+		return
+	}
+
+	entityType := models.NotificationEntityExam
+	examID := exam.ID
+	s.inbox.Notify(ctx, collegeID, userIDs, &models.Notification{
+		Title:             fmt.Sprintf("Results published: %s", exam.Title),
+		Message:           fmt.Sprintf("Results for %s are now available.", exam.Title),
+		Type:              "info",
+		RelatedEntityType: &entityType,
+		RelatedEntityID:   &examID,
+	})
+}
+
+// notifyRevaluationDecided tells the student who asked for a revaluation that
+// it was approved or rejected. exam is optional and only used for the wording.
+func (s *examService) notifyRevaluationDecided(ctx context.Context, request *models.RevaluationRequest, exam *models.Exam) {
+	if s.inbox == nil || s.studentRepo == nil {
+		return
+	}
+	student, err := s.studentRepo.GetStudentByID(ctx, request.CollegeID, request.StudentID)
+	if err != nil || student == nil {
+		s.logger.Error().Err(err).Int("revaluation_id", request.ID).Msg("failed to load student for revaluation notification")
+		return
+	}
+
+	subject := "your exam"
+	if exam != nil {
+		subject = exam.Title
+	}
+	notification := &models.Notification{
+		Title: "Revaluation request " + request.Status,
+		Type:  "info",
+	}
+	if request.Status == "approved" && request.RevisedMarks != nil {
+		notification.Type = "success"
+		notification.Message = fmt.Sprintf("Your revaluation request for %s was approved. Revised marks: %g.", subject, *request.RevisedMarks)
+	} else {
+		notification.Message = fmt.Sprintf("Your revaluation request for %s was %s.", subject, request.Status)
+	}
+	if request.ReviewComments != "" {
+		notification.Message += " Reviewer comments: " + request.ReviewComments
+	}
+	entityType := models.NotificationEntityRevaluation
+	requestID := request.ID
+	notification.RelatedEntityType = &entityType
+	notification.RelatedEntityID = &requestID
+
+	s.inbox.Notify(ctx, request.CollegeID, []int{student.UserID}, notification)
+}
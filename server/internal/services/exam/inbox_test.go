@@ -0,0 +1,84 @@
+package exam
+
+import (
+	"context"
+	"testing"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingInbox struct {
+	userIDs       [][]int
+	notifications []*models.Notification
+}
+
+func (i *recordingInbox) Notify(ctx context.Context, collegeID int, userIDs []int, notification *models.Notification) {
+	i.userIDs = append(i.userIDs, userIDs)
+	i.notifications = append(i.notifications, notification)
+}
+
+type inboxExamRepo struct {
+	repository.ExamRepository
+	request *models.RevaluationRequest
+}
+
+func (r *inboxExamRepo) GetExamByID(ctx context.Context, collegeID, examID int) (*models.Exam, error) {
+	return &models.Exam{ID: examID, CollegeID: collegeID, Title: "Midterm"}, nil
+}
+
+func (r *inboxExamRepo) PublishResults(ctx context.Context, examID int) ([]int, error) {
+	return []int{21, 22}, nil
+}
+
+func (r *inboxExamRepo) ListResultInboxUserIDs(ctx context.Context, collegeID int, studentIDs []int, includeParents bool) ([]int, error) {
+	return []int{121, 122}, nil
+}
+
+func (r *inboxExamRepo) GetRevaluationRequest(ctx context.Context, requestID int) (*models.RevaluationRequest, error) {
+	return r.request, nil
+}
+
+func (r *inboxExamRepo) UpdateRevaluationRequest(ctx context.Context, request *models.RevaluationRequest) error {
+	return nil
+}
+
+type inboxStudentRepo struct {
+	repository.StudentRepository
+}
+
+func (r *inboxStudentRepo) GetStudentByID(ctx context.Context, collegeID, studentID int) (*models.Student, error) {
+	return &models.Student{StudentID: studentID, CollegeID: collegeID, UserID: 500 + studentID}, nil
+}
+
+func TestPublishResultsNotifiesInbox(t *testing.T) {
+	inbox := &recordingInbox{}
+	svc := &examService{repo: &inboxExamRepo{}, inbox: inbox}
+
+	published, err := svc.PublishResults(context.Background(), 1, 9)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+	require.Len(t, inbox.notifications, 1)
+	assert.Equal(t, []int{121, 122}, inbox.userIDs[0])
+	assert.Equal(t, "Results published: Midterm", inbox.notifications[0].Title)
+	assert.Equal(t, 9, *inbox.notifications[0].RelatedEntityID)
+}
+
+func TestRejectRevaluationNotifiesStudent(t *testing.T) {
+	inbox := &recordingInbox{}
+	repo := &inboxExamRepo{request: &models.RevaluationRequest{ID: 3, StudentID: 7, CollegeID: 1, Status: "pending"}}
+	svc := &examService{repo: repo, studentRepo: &inboxStudentRepo{}, inbox: inbox}
+
+	err := svc.RejectRevaluationRequest(context.Background(), 3, 40, "marks verified")
+
+	require.NoError(t, err)
+	require.Len(t, inbox.notifications, 1)
+	assert.Equal(t, []int{507}, inbox.userIDs[0])
+	assert.Equal(t, "Revaluation request rejected", inbox.notifications[0].Title)
+	assert.Contains(t, inbox.notifications[0].Message, "Reviewer comments: marks verified")
+	assert.Equal(t, models.NotificationEntityRevaluation, *inbox.notifications[0].RelatedEntityType)
+}
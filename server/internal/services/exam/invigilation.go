@@ -0,0 +1,159 @@
+package exam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+)
+
+// ErrInvigilatorAssigned is returned when the user already supervises the exam
+var ErrInvigilatorAssigned = repository.ErrInvigilatorAssigned
+
+// ErrInvigilatorConflict is returned when the user supervises another exam at
+// an overlapping time
+var ErrInvigilatorConflict = errors.New("invigilator is already assigned to an overlapping exam")
+
+// DefaultStudentsPerInvigilator is used when the service is built without a ratio
+const DefaultStudentsPerInvigilator = 30
+
+// invigilatorRoles are the user roles that may supervise an exam
+var invigilatorRoles = map[string]bool{"faculty": true, "instructor": true, "admin": true}
+
+// InvigilatorSuggestion says how many invigilators an exam needs and who is free
+type InvigilatorSuggestion struct {
+	ExamID                 int                            `json:"exam_id"`
+	StudentsPerInvigilator int                            `json:"students_per_invigilator"`
+	EnrolledStudents       int                            `json:"enrolled_students"`
+	RoomCapacity           int                            `json:"room_capacity,omitempty"`
+	OverCapacity           bool                           `json:"over_capacity"` // more students enrolled than the room seats
+	Required               int                            `json:"required"`
+	Assigned               int                            `json:"assigned"`
+	Shortfall              int                            `json:"shortfall"`
+	Candidates             []*models.InvigilatorCandidate `json:"candidates"`
+}
+
+// AssignInvigilator puts a staff member on an exam. The room defaults to the
+// exam's room, and the assignment is refused if the user already supervises
+// an exam that overlaps this one.
+func (s *examService) AssignInvigilator(ctx context.Context, invigilator *models.ExamInvigilator) error {
+	if invigilator.CollegeID == 0 || invigilator.ExamID == 0 || invigilator.UserID == 0 {
+		return errors.New("college ID, exam ID and user ID are required")
+	}
+
+	exam, err := s.repo.GetExamByID(ctx, invigilator.CollegeID, invigilator.ExamID)
+	if err != nil {
+		return fmt.Errorf("exam not found: %w", err)
+	}
+	if exam.Status == "cancelled" || exam.Status == "completed" {
+		return fmt.Errorf("cannot assign invigilators to a %s exam", exam.Status)
+	}
+
+	if invigilator.RoomID == nil {
+		invigilator.RoomID = exam.RoomID
+	} else if _, err := s.repo.GetRoomByID(ctx, invigilator.CollegeID, *invigilator.RoomID); err != nil {
+		return fmt.Errorf("room %d not found", *invigilator.RoomID)
+	}
+
+	if s.userRepo != nil {
+		user, err := s.userRepo.GetUserByID(ctx, invigilator.UserID)
+		if err != nil {
+			return fmt.Errorf("user not found: %w", err)
+		}
+		if !user.IsActive || !invigilatorRoles[user.Role] {
+			return errors.New("only active faculty or admin users can invigilate")
+		}
+	}
+
+	conflicts, err := s.repo.ListInvigilatorConflicts(ctx, invigilator.UserID, exam.ID, exam.StartTime, exam.EndTime)
+	if err != nil {
+		return fmt.Errorf("failed to check invigilator schedule: %w", err)
+	}
+	if len(conflicts) > 0 {
+		c := conflicts[0]
+		return fmt.Errorf("%w: %q from %s to %s", ErrInvigilatorConflict, c.Title,
+			c.StartTime.Format("2006-01-02 15:04"), c.EndTime.Format("2006-01-02 15:04"))
+	}
+
+	return s.repo.AssignInvigilator(ctx, invigilator)
+}
+
+func (s *examService) ListInvigilators(ctx context.Context, collegeID, examID int) ([]*models.ExamInvigilator, error) {
+	if collegeID == 0 || examID == 0 {
+		return nil, errors.New("invalid college ID or exam ID")
+	}
+	return s.repo.ListInvigilators(ctx, collegeID, examID)
+}
+
+func (s *examService) RemoveInvigilator(ctx context.Context, collegeID, examID, userID int) error {
+	if collegeID == 0 || examID == 0 || userID == 0 {
+		return errors.New("college ID, exam ID and user ID are required")
+	}
+	return s.repo.RemoveInvigilator(ctx, collegeID, examID, userID)
+}
+
+// SuggestInvigilators works out how many invigilators the exam needs from the
+// students-per-invigilator ratio. Before anyone is enrolled the exam room's
+// capacity stands in for the head count, so staffing can be planned early.
+func (s *examService) SuggestInvigilators(ctx context.Context, collegeID, examID int) (*InvigilatorSuggestion, error) {
+	if collegeID == 0 || examID == 0 {
+		return nil, errors.New("invalid college ID or exam ID")
+	}
+
+	exam, err := s.repo.GetExamByID(ctx, collegeID, examID)
+	if err != nil {
+		return nil, fmt.Errorf("exam not found: %w", err)
+	}
+
+	enrollments, err := s.repo.ListEnrollments(ctx, examID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load enrollments: %w", err)
+	}
+	assigned, err := s.repo.ListInvigilators(ctx, collegeID, examID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load invigilators: %w", err)
+	}
+
+	ratio := s.studentsPerInvigilator
+	if ratio <= 0 {
+		ratio = DefaultStudentsPerInvigilator
+	}
+	suggestion := &InvigilatorSuggestion{
+		ExamID:                 examID,
+		StudentsPerInvigilator: ratio,
+		Assigned:               len(assigned),
+	}
+	for _, enrollment := range enrollments {
+		if enrollment.Status != "disqualified" {
+			suggestion.EnrolledStudents++
+		}
+	}
+
+	headCount := suggestion.EnrolledStudents
+	if exam.RoomID != nil {
+		room, err := s.repo.GetRoomByID(ctx, collegeID, *exam.RoomID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load exam room: %w", err)
+		}
+		suggestion.RoomCapacity = room.Capacity
+		suggestion.OverCapacity = suggestion.EnrolledStudents > room.Capacity
+		if headCount == 0 {
+			headCount = room.Capacity
+		}
+	}
+
+	suggestion.Required = (headCount + ratio - 1) / ratio
+	if suggestion.Required > suggestion.Assigned {
+		suggestion.Shortfall = suggestion.Required - suggestion.Assigned
+	}
+
+	// Already assigned staff are busy during this exam, so they never show up
+	// as candidates
+	suggestion.Candidates, err = s.repo.ListAvailableInvigilators(ctx, collegeID, exam.StartTime, exam.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load available invigilators: %w", err)
+	}
+	return suggestion, nil
+}
@@ -0,0 +1,138 @@
+package exam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type invigilationRepo struct {
+	repository.ExamRepository
+	exam        *models.Exam
+	room        *models.ExamRoom
+	enrollments []*models.ExamEnrollment
+	assigned    []*models.ExamInvigilator
+	conflicts   []*models.InvigilatorConflict
+	candidates  []*models.InvigilatorCandidate
+}
+
+func (r *invigilationRepo) GetExamByID(ctx context.Context, collegeID, examID int) (*models.Exam, error) {
+	return r.exam, nil
+}
+
+func (r *invigilationRepo) GetRoomByID(ctx context.Context, collegeID, roomID int) (*models.ExamRoom, error) {
+	return r.room, nil
+}
+
+func (r *invigilationRepo) ListEnrollments(ctx context.Context, examID int) ([]*models.ExamEnrollment, error) {
+	return r.enrollments, nil
+}
+
+func (r *invigilationRepo) ListInvigilators(ctx context.Context, collegeID, examID int) ([]*models.ExamInvigilator, error) {
+	return r.assigned, nil
+}
+
+func (r *invigilationRepo) ListInvigilatorConflicts(ctx context.Context, userID, examID int, startTime, endTime time.Time) ([]*models.InvigilatorConflict, error) {
+	return r.conflicts, nil
+}
+
+func (r *invigilationRepo) ListAvailableInvigilators(ctx context.Context, collegeID int, startTime, endTime time.Time) ([]*models.InvigilatorCandidate, error) {
+	return r.candidates, nil
+}
+
+func (r *invigilationRepo) AssignInvigilator(ctx context.Context, invigilator *models.ExamInvigilator) error {
+	r.assigned = append(r.assigned, invigilator)
+	return nil
+}
+
+func newInvigilationRepo() *invigilationRepo {
+	roomID := 4
+	start := time.Date(2026, 11, 2, 9, 0, 0, 0, time.UTC)
+	return &invigilationRepo{
+		exam: &models.Exam{ID: 7, CollegeID: 1, Status: "scheduled", RoomID: &roomID, StartTime: start, EndTime: start.Add(3 * time.Hour)},
+		room: &models.ExamRoom{ID: roomID, RoomNumber: "A1", Capacity: 60, IsActive: true},
+	}
+}
+
+func TestAssignInvigilator(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("defaults to the exam room", func(t *testing.T) {
+		repo := newInvigilationRepo()
+		svc := &examService{repo: repo}
+
+		inv := &models.ExamInvigilator{CollegeID: 1, ExamID: 7, UserID: 3}
+		require.NoError(t, svc.AssignInvigilator(ctx, inv))
+		require.NotNil(t, inv.RoomID)
+		assert.Equal(t, 4, *inv.RoomID)
+		assert.Len(t, repo.assigned, 1)
+	})
+
+	t.Run("overlapping exam is a double assignment", func(t *testing.T) {
+		repo := newInvigilationRepo()
+		repo.conflicts = []*models.InvigilatorConflict{{ExamID: 8, Title: "Chemistry final", StartTime: repo.exam.StartTime, EndTime: repo.exam.EndTime}}
+		svc := &examService{repo: repo}
+
+		err := svc.AssignInvigilator(ctx, &models.ExamInvigilator{CollegeID: 1, ExamID: 7, UserID: 3})
+		assert.ErrorIs(t, err, ErrInvigilatorConflict)
+		assert.Contains(t, err.Error(), "Chemistry final")
+		assert.Empty(t, repo.assigned)
+	})
+
+	t.Run("cancelled exams cannot be staffed", func(t *testing.T) {
+		repo := newInvigilationRepo()
+		repo.exam.Status = "cancelled"
+		svc := &examService{repo: repo}
+
+		assert.Error(t, svc.AssignInvigilator(ctx, &models.ExamInvigilator{CollegeID: 1, ExamID: 7, UserID: 3}))
+	})
+}
+
+func TestSuggestInvigilators(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("sized from enrollments with the configured ratio", func(t *testing.T) {
+		repo := newInvigilationRepo()
+		for i := 0; i < 45; i++ {
+			repo.enrollments = append(repo.enrollments, &models.ExamEnrollment{StudentID: i + 1, Status: "enrolled"})
+		}
+		repo.enrollments = append(repo.enrollments, &models.ExamEnrollment{StudentID: 99, Status: "disqualified"})
+		repo.assigned = []*models.ExamInvigilator{{UserID: 3}}
+		svc := &examService{repo: repo, studentsPerInvigilator: 20}
+
+		suggestion, err := svc.SuggestInvigilators(ctx, 1, 7)
+		require.NoError(t, err)
+		assert.Equal(t, 45, suggestion.EnrolledStudents)
+		assert.Equal(t, 3, suggestion.Required)
+		assert.Equal(t, 2, suggestion.Shortfall)
+		assert.False(t, suggestion.OverCapacity)
+	})
+
+	t.Run("room capacity stands in before enrollment", func(t *testing.T) {
+		svc := &examService{repo: newInvigilationRepo()}
+
+		suggestion, err := svc.SuggestInvigilators(ctx, 1, 7)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultStudentsPerInvigilator, suggestion.StudentsPerInvigilator)
+		assert.Equal(t, 60, suggestion.RoomCapacity)
+		assert.Equal(t, 2, suggestion.Required)
+	})
+
+	t.Run("flags more students than seats", func(t *testing.T) {
+		repo := newInvigilationRepo()
+		repo.room.Capacity = 2
+		repo.enrollments = []*models.ExamEnrollment{{Status: "enrolled"}, {Status: "enrolled"}, {Status: "appeared"}}
+		svc := &examService{repo: repo}
+
+		suggestion, err := svc.SuggestInvigilators(ctx, 1, 7)
+		require.NoError(t, err)
+		assert.True(t, suggestion.OverCapacity)
+		assert.Equal(t, 1, suggestion.Required)
+	})
+}
@@ -0,0 +1,93 @@
+package exam
+
+import (
+	"context"
+	"testing"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type passingRepo struct {
+	repository.ExamRepository
+	exam    *models.Exam
+	created *models.ExamResult
+	results []*models.ExamResult
+}
+
+func (r *passingRepo) GetExamByID(ctx context.Context, collegeID, examID int) (*models.Exam, error) {
+	return r.exam, nil
+}
+
+func (r *passingRepo) CreateResult(ctx context.Context, result *models.ExamResult) error {
+	r.created = result
+	return nil
+}
+
+func (r *passingRepo) ListEnrollments(ctx context.Context, examID int) ([]*models.ExamEnrollment, error) {
+	return nil, nil
+}
+
+func (r *passingRepo) ListResults(ctx context.Context, examID int) ([]*models.ExamResult, error) {
+	return r.results, nil
+}
+
+func TestValidatePassingMarks(t *testing.T) {
+	t.Run("absolute marks are bounded by total marks", func(t *testing.T) {
+		assert.NoError(t, validatePassingMarks(&models.Exam{TotalMarks: 50, PassingMarks: 50}))
+		assert.EqualError(t, validatePassingMarks(&models.Exam{TotalMarks: 50, PassingMarks: 60}), "passing marks must be between 0 and total marks")
+		assert.Error(t, validatePassingMarks(&models.Exam{TotalMarks: 50, PassingMarks: -1}))
+	})
+
+	t.Run("percentages are bounded by 100", func(t *testing.T) {
+		assert.NoError(t, validatePassingMarks(&models.Exam{TotalMarks: 50, PassingMarks: 60, PassingIsPercentage: true}))
+		assert.EqualError(t, validatePassingMarks(&models.Exam{TotalMarks: 200, PassingMarks: 150, PassingIsPercentage: true}), "passing percentage must be between 0 and 100")
+		assert.Error(t, validatePassingMarks(&models.Exam{TotalMarks: 50, PassingMarks: -1, PassingIsPercentage: true}))
+	})
+}
+
+func TestCreateResultPassingMode(t *testing.T) {
+	ctx := context.Background()
+	marks := func(v float64) *float64 { return &v }
+	grade := func(exam *models.Exam, obtained float64) string {
+		repo := &passingRepo{exam: exam}
+		svc := &examService{repo: repo}
+		require.NoError(t, svc.CreateResult(ctx, &models.ExamResult{ExamID: 3, StudentID: 5, CollegeID: 1, MarksObtained: marks(obtained)}))
+		return repo.created.Result
+	}
+
+	t.Run("absolute pass mark", func(t *testing.T) {
+		exam := &models.Exam{ID: 3, CollegeID: 1, TotalMarks: 50, PassingMarks: 40}
+		assert.Equal(t, "pass", grade(exam, 40))
+		assert.Equal(t, "fail", grade(exam, 39.5))
+	})
+
+	t.Run("percentage pass mark", func(t *testing.T) {
+		// 40% of 50 is 20 marks
+		exam := &models.Exam{ID: 3, CollegeID: 1, TotalMarks: 50, PassingMarks: 40, PassingIsPercentage: true}
+		assert.Equal(t, "pass", grade(exam, 20))
+		assert.Equal(t, "fail", grade(exam, 19.5))
+	})
+}
+
+func TestGetExamStatsPassingMode(t *testing.T) {
+	marks := func(v float64) *float64 { return &v }
+	results := []*models.ExamResult{{MarksObtained: marks(15)}, {MarksObtained: marks(25)}, {MarksObtained: marks(45)}, {MarksObtained: marks(50)}}
+
+	t.Run("absolute pass mark", func(t *testing.T) {
+		repo := &passingRepo{exam: &models.Exam{ID: 3, CollegeID: 1, TotalMarks: 50, PassingMarks: 40}, results: results}
+		stats, err := (&examService{repo: repo}).GetExamStats(context.Background(), 1, 3)
+		require.NoError(t, err)
+		assert.Equal(t, 50.0, stats.PassRate)
+	})
+
+	t.Run("percentage pass mark", func(t *testing.T) {
+		repo := &passingRepo{exam: &models.Exam{ID: 3, CollegeID: 1, TotalMarks: 50, PassingMarks: 40, PassingIsPercentage: true}, results: results}
+		stats, err := (&examService{repo: repo}).GetExamStats(context.Background(), 1, 3)
+		require.NoError(t, err)
+		assert.Equal(t, 75.0, stats.PassRate)
+	})
+}
@@ -0,0 +1,137 @@
+package exam
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"eduhub/server/internal/models"
+)
+
+// ErrInvalidProctoringEvent is returned by RecordProctoringEvent for an
+// unknown event type, oversized metadata or an implausible timestamp
+var ErrInvalidProctoringEvent = errors.New("invalid proctoring event")
+
+const (
+	// maxProctoringMetadataBytes bounds the encoded metadata of one event
+	maxProctoringMetadataBytes = 2048
+
+	// proctoringClockSkew is how far ahead of the server a client's clock may
+	// run before its timestamps are rejected
+	proctoringClockSkew = time.Minute
+)
+
+var proctoringEventTypes = map[string]bool{
+	models.ProctoringTabSwitch:       true,
+	models.ProctoringFaceNotDetected: true,
+	models.ProctoringDisconnect:      true,
+}
+
+// ProctoringEventRequest is an event a proctoring client reports. OccurredAt
+// defaults to when the server receives it.
+type ProctoringEventRequest struct {
+	EventType  string         `json:"event_type"`
+	OccurredAt *time.Time     `json:"occurred_at"`
+	Metadata   models.JSONMap `json:"metadata"`
+}
+
+// ProctoringReview is what faculty see of a student's proctoring events
+type ProctoringReview struct {
+	ExamID    int                           `json:"exam_id"`
+	StudentID int                           `json:"student_id"`
+	Counts    map[string]int                `json:"counts"` // events per type
+	Events    []*models.ExamProctoringEvent `json:"events"`
+}
+
+// RecordProctoringEvent stores an event reported during the student's own
+// attempt. The student must be enrolled and not disqualified, and the exam
+// must be running, counting the student's extra time and extensions.
+func (s *examService) RecordProctoringEvent(ctx context.Context, collegeID, examID, studentID int, req ProctoringEventRequest) (*models.ExamProctoringEvent, error) {
+	if collegeID == 0 || examID == 0 || studentID == 0 {
+		return nil, errors.New("college ID, exam ID and student ID are required")
+	}
+	if !proctoringEventTypes[req.EventType] {
+		return nil, fmt.Errorf("%w: unknown event type %q", ErrInvalidProctoringEvent, req.EventType)
+	}
+	if req.Metadata != nil {
+		encoded, err := json.Marshal(req.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidProctoringEvent, err)
+		}
+		if len(encoded) > maxProctoringMetadataBytes {
+			return nil, fmt.Errorf("%w: metadata must be at most %d bytes", ErrInvalidProctoringEvent, maxProctoringMetadataBytes)
+		}
+	}
+
+	exam, err := s.repo.GetExamByID(ctx, collegeID, examID)
+	if err != nil {
+		return nil, err
+	}
+	enrollment, err := s.repo.GetEnrollment(ctx, examID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment.CollegeID != collegeID {
+		return nil, ErrEnrollmentNotFound
+	}
+	if enrollment.Status == "disqualified" {
+		return nil, ErrEnrollmentDisqualified
+	}
+
+	now := time.Now()
+	end := enrollment.EndTime(exam)
+	if exam.Status == "cancelled" || exam.Status == "completed" {
+		return nil, fmt.Errorf("%w: exam is %s", ErrExamNotInProgress, exam.Status)
+	}
+	if now.Before(exam.StartTime) || !now.Before(end) {
+		return nil, fmt.Errorf("%w: your exam runs from %s to %s", ErrExamNotInProgress,
+			exam.StartTime.Format("2006-01-02 15:04"), end.Format("2006-01-02 15:04"))
+	}
+
+	occurredAt := now
+	if req.OccurredAt != nil {
+		occurredAt = *req.OccurredAt
+		if occurredAt.Before(exam.StartTime) || occurredAt.After(now.Add(proctoringClockSkew)) {
+			return nil, fmt.Errorf("%w: occurred_at must fall within the exam", ErrInvalidProctoringEvent)
+		}
+	}
+
+	event := &models.ExamProctoringEvent{
+		CollegeID:    collegeID,
+		ExamID:       examID,
+		EnrollmentID: enrollment.ID,
+		StudentID:    studentID,
+		EventType:    req.EventType,
+		OccurredAt:   occurredAt,
+		Metadata:     req.Metadata,
+	}
+	if err := s.repo.RecordProctoringEvent(ctx, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// ReviewProctoringEvents lists a student's proctoring events in an exam in
+// the order they occurred, with a count per event type
+func (s *examService) ReviewProctoringEvents(ctx context.Context, collegeID, examID, studentID int) (*ProctoringReview, error) {
+	if _, err := s.repo.GetExamByID(ctx, collegeID, examID); err != nil {
+		return nil, err
+	}
+	events, err := s.repo.ListProctoringEvents(ctx, collegeID, examID, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(proctoringEventTypes))
+	for _, event := range events {
+		counts[event.EventType]++
+	}
+	return &ProctoringReview{
+		ExamID:    examID,
+		StudentID: studentID,
+		Counts:    counts,
+		Events:    events,
+	}, nil
+}
@@ -0,0 +1,138 @@
+package exam
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type proctoringRepo struct {
+	repository.ExamRepository
+	exam       *models.Exam
+	enrollment *models.ExamEnrollment
+	recorded   []*models.ExamProctoringEvent
+}
+
+func (r *proctoringRepo) GetExamByID(ctx context.Context, collegeID, examID int) (*models.Exam, error) {
+	return r.exam, nil
+}
+
+func (r *proctoringRepo) GetEnrollment(ctx context.Context, examID, studentID int) (*models.ExamEnrollment, error) {
+	if r.enrollment == nil || r.enrollment.StudentID != studentID {
+		return nil, ErrEnrollmentNotFound
+	}
+	return r.enrollment, nil
+}
+
+func (r *proctoringRepo) RecordProctoringEvent(ctx context.Context, event *models.ExamProctoringEvent) error {
+	event.ID = int64(len(r.recorded) + 1)
+	r.recorded = append(r.recorded, event)
+	return nil
+}
+
+func (r *proctoringRepo) ListProctoringEvents(ctx context.Context, collegeID, examID, studentID int) ([]*models.ExamProctoringEvent, error) {
+	return r.recorded, nil
+}
+
+// newProctoringRepo holds an exam that started an hour ago and ends in two
+func newProctoringRepo() *proctoringRepo {
+	start := time.Now().Add(-time.Hour)
+	return &proctoringRepo{
+		exam:       &models.Exam{ID: 4, CollegeID: 1, Status: "ongoing", StartTime: start, EndTime: start.Add(3 * time.Hour)},
+		enrollment: &models.ExamEnrollment{ID: 9, ExamID: 4, StudentID: 7, CollegeID: 1, Status: "enrolled"},
+	}
+}
+
+func TestRecordProctoringEvent(t *testing.T) {
+	ctx := context.Background()
+	req := ProctoringEventRequest{EventType: models.ProctoringTabSwitch, Metadata: models.JSONMap{"tab_title": "Search"}}
+
+	t.Run("records the student's event", func(t *testing.T) {
+		repo := newProctoringRepo()
+		svc := &examService{repo: repo}
+		event, err := svc.RecordProctoringEvent(ctx, 1, 4, 7, req)
+		require.NoError(t, err)
+		assert.Equal(t, 9, event.EnrollmentID)
+		assert.Equal(t, "Search", event.Metadata["tab_title"])
+		assert.WithinDuration(t, time.Now(), event.OccurredAt, time.Second)
+		assert.Len(t, repo.recorded, 1)
+	})
+
+	t.Run("keeps the client's timestamp", func(t *testing.T) {
+		repo := newProctoringRepo()
+		at := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+		svc := &examService{repo: repo}
+		event, err := svc.RecordProctoringEvent(ctx, 1, 4, 7, ProctoringEventRequest{EventType: models.ProctoringDisconnect, OccurredAt: &at})
+		require.NoError(t, err)
+		assert.Equal(t, at, event.OccurredAt)
+	})
+
+	t.Run("rejects bad events", func(t *testing.T) {
+		before := newProctoringRepo().exam.StartTime.Add(-time.Minute)
+		future := time.Now().Add(time.Hour)
+		for name, bad := range map[string]ProctoringEventRequest{
+			"unknown type":  {EventType: "screenshot"},
+			"huge metadata": {EventType: models.ProctoringTabSwitch, Metadata: models.JSONMap{"x": strings.Repeat("a", maxProctoringMetadataBytes)}},
+			"before start":  {EventType: models.ProctoringTabSwitch, OccurredAt: &before},
+			"in the future": {EventType: models.ProctoringTabSwitch, OccurredAt: &future},
+		} {
+			repo := newProctoringRepo()
+			svc := &examService{repo: repo}
+			_, err := svc.RecordProctoringEvent(ctx, 1, 4, 7, bad)
+			assert.ErrorIs(t, err, ErrInvalidProctoringEvent, name)
+			assert.Empty(t, repo.recorded, name)
+		}
+	})
+
+	t.Run("only during the student's exam", func(t *testing.T) {
+		repo := newProctoringRepo()
+		repo.exam.EndTime = time.Now().Add(-time.Minute)
+		svc := &examService{repo: repo}
+		_, err := svc.RecordProctoringEvent(ctx, 1, 4, 7, req)
+		assert.ErrorIs(t, err, ErrExamNotInProgress)
+
+		repo.enrollment.Accommodations.ExtraTimeMinutes = 30
+		_, err = svc.RecordProctoringEvent(ctx, 1, 4, 7, req)
+		assert.NoError(t, err, "extra time keeps the attempt open")
+
+		repo.exam.Status = "cancelled"
+		_, err = svc.RecordProctoringEvent(ctx, 1, 4, 7, req)
+		assert.ErrorIs(t, err, ErrExamNotInProgress)
+	})
+
+	t.Run("only for an enrolled student's own attempt", func(t *testing.T) {
+		repo := newProctoringRepo()
+		svc := &examService{repo: repo}
+		_, err := svc.RecordProctoringEvent(ctx, 1, 4, 8, req)
+		assert.ErrorIs(t, err, ErrEnrollmentNotFound)
+
+		_, err = svc.RecordProctoringEvent(ctx, 2, 4, 7, req)
+		assert.ErrorIs(t, err, ErrEnrollmentNotFound, "another college's enrollment")
+
+		repo.enrollment.Status = "disqualified"
+		_, err = svc.RecordProctoringEvent(ctx, 1, 4, 7, req)
+		assert.ErrorIs(t, err, ErrEnrollmentDisqualified)
+	})
+}
+
+func TestReviewProctoringEvents(t *testing.T) {
+	repo := newProctoringRepo()
+	repo.recorded = []*models.ExamProctoringEvent{
+		{ID: 1, StudentID: 7, EventType: models.ProctoringTabSwitch},
+		{ID: 2, StudentID: 7, EventType: models.ProctoringDisconnect},
+		{ID: 3, StudentID: 7, EventType: models.ProctoringTabSwitch},
+	}
+	svc := &examService{repo: repo}
+
+	review, err := svc.ReviewProctoringEvents(context.Background(), 1, 4, 7)
+	require.NoError(t, err)
+	assert.Len(t, review.Events, 3)
+	assert.Equal(t, map[string]int{"tab_switch": 2, "disconnect": 1}, review.Counts)
+}
@@ -0,0 +1,199 @@
+package exam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+	"eduhub/server/internal/services/storage"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// ErrQuestionPaperNotFound is returned when no paper was uploaded for the set
+var ErrQuestionPaperNotFound = repository.ErrQuestionPaperNotFound
+
+// ErrInvalidPaperSet is returned for a set outside 1..QuestionPaperSets
+var ErrInvalidPaperSet = errors.New("invalid question paper set")
+
+// ErrQuestionPaperLocked is returned by UploadQuestionPaper once the exam has
+// started
+var ErrQuestionPaperLocked = errors.New("question papers cannot be changed after the exam has started")
+
+// ErrQuestionPaperUnavailable is returned by GetStudentQuestionPaper outside
+// the exam window
+var ErrQuestionPaperUnavailable = errors.New("question paper is only available during the exam")
+
+// ErrPaperSetNotAssigned is returned by GetStudentQuestionPaper when the exam
+// has several sets and the student was not given one
+var ErrPaperSetNotAssigned = errors.New("no question paper set is assigned to the student")
+
+// QuestionPaperUpload is a question paper file being uploaded
+type QuestionPaperUpload struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	Body        io.Reader
+}
+
+// QuestionPaperDelivery is a student's link to their question paper. The URL
+// expires when the student's exam time ends.
+type QuestionPaperDelivery struct {
+	ExamID   int    `json:"exam_id"`
+	PaperSet int    `json:"paper_set"`
+	FileName string `json:"file_name"`
+	storage.PresignedURL
+}
+
+// QuestionPaperService stores question papers and releases them to students
+// only while their exam is running
+type QuestionPaperService interface {
+	UploadQuestionPaper(ctx context.Context, collegeID, examID, paperSet, uploadedBy int, upload QuestionPaperUpload) (*models.ExamQuestionPaper, error)
+	ListQuestionPapers(ctx context.Context, collegeID, examID int) ([]*models.ExamQuestionPaper, error)
+	GetStudentQuestionPaper(ctx context.Context, collegeID, examID, studentID int) (*QuestionPaperDelivery, error)
+}
+
+type questionPaperService struct {
+	repo    repository.ExamRepository
+	storage storage.StorageService
+	logger  zerolog.Logger
+	now     func() time.Time
+}
+
+// NewQuestionPaperService creates a question paper service storing files in
+// storageService
+func NewQuestionPaperService(repo repository.ExamRepository, storageService storage.StorageService, logger zerolog.Logger) QuestionPaperService {
+	return &questionPaperService{
+		repo:    repo,
+		storage: storageService,
+		logger:  logger,
+		now:     time.Now,
+	}
+}
+
+// UploadQuestionPaper stores the paper for one set of an exam, replacing any
+// earlier upload. Papers can only be changed before the exam starts.
+func (s *questionPaperService) UploadQuestionPaper(ctx context.Context, collegeID, examID, paperSet, uploadedBy int, upload QuestionPaperUpload) (*models.ExamQuestionPaper, error) {
+	exam, err := s.repo.GetExamByID(ctx, collegeID, examID)
+	if err != nil {
+		return nil, err
+	}
+	if paperSet < 1 || paperSet > exam.QuestionPaperSets {
+		return nil, fmt.Errorf("%w: the exam has %d set(s)", ErrInvalidPaperSet, exam.QuestionPaperSets)
+	}
+	if !s.now().Before(exam.StartTime) {
+		return nil, ErrQuestionPaperLocked
+	}
+
+	// Papers sit outside every user's prefix, so AuthorizeObjectKey only
+	// lets admins and faculty of the college presign them directly
+	objectKey := fmt.Sprintf("%d/exams/%d/papers/set%d_%s%s", collegeID, examID, paperSet,
+		uuid.NewString(), strings.ToLower(filepath.Ext(upload.FileName)))
+	if _, err := s.storage.UploadFile(ctx, objectKey, upload.Body, upload.Size, upload.ContentType); err != nil {
+		return nil, err
+	}
+
+	paper := &models.ExamQuestionPaper{
+		CollegeID:   collegeID,
+		ExamID:      examID,
+		PaperSet:    paperSet,
+		ObjectKey:   objectKey,
+		FileName:    upload.FileName,
+		ContentType: upload.ContentType,
+		SizeBytes:   upload.Size,
+		UploadedBy:  uploadedBy,
+	}
+	replaced, err := s.repo.SaveQuestionPaper(ctx, paper)
+	if err != nil {
+		s.removeFile(ctx, objectKey)
+		return nil, fmt.Errorf("failed to save question paper: %w", err)
+	}
+	if replaced != "" {
+		s.removeFile(ctx, replaced)
+	}
+	return paper, nil
+}
+
+// ListQuestionPapers lists the papers uploaded for an exam
+func (s *questionPaperService) ListQuestionPapers(ctx context.Context, collegeID, examID int) ([]*models.ExamQuestionPaper, error) {
+	if _, err := s.repo.GetExamByID(ctx, collegeID, examID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListQuestionPapers(ctx, collegeID, examID)
+}
+
+// GetStudentQuestionPaper returns a link to the paper of the set assigned to
+// the student. It is only given to enrolled students, from the exam's start
+// until its end plus any extra time or extensions the student has.
+func (s *questionPaperService) GetStudentQuestionPaper(ctx context.Context, collegeID, examID, studentID int) (*QuestionPaperDelivery, error) {
+	exam, err := s.repo.GetExamByID(ctx, collegeID, examID)
+	if err != nil {
+		return nil, err
+	}
+	enrollment, err := s.repo.GetEnrollment(ctx, examID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if enrollment.CollegeID != collegeID {
+		return nil, ErrEnrollmentNotFound
+	}
+	if enrollment.Status == "disqualified" {
+		return nil, ErrEnrollmentDisqualified
+	}
+
+	now := s.now()
+	end := enrollment.EndTime(exam)
+	if exam.Status == "cancelled" || now.Before(exam.StartTime) || !now.Before(end) {
+		return nil, ErrQuestionPaperUnavailable
+	}
+
+	paperSet, err := assignedPaperSet(exam, enrollment)
+	if err != nil {
+		return nil, err
+	}
+	paper, err := s.repo.GetQuestionPaper(ctx, collegeID, examID, paperSet)
+	if err != nil {
+		return nil, err
+	}
+
+	// Access was checked above, so presign as the college. The link lives
+	// until the student's time runs out, rounded up to whole seconds.
+	expiry := end.Sub(now).Truncate(time.Second) + time.Second
+	presigned, err := s.storage.GetPresignedURL(ctx, paper.ObjectKey,
+		storage.ObjectAccess{CollegeID: collegeID, Privileged: true}, expiry)
+	if err != nil {
+		return nil, err
+	}
+	return &QuestionPaperDelivery{
+		ExamID:       examID,
+		PaperSet:     paperSet,
+		FileName:     paper.FileName,
+		PresignedURL: *presigned,
+	}, nil
+}
+
+// assignedPaperSet is the student's set, or the only set of a single-set exam
+func assignedPaperSet(exam *models.Exam, enrollment *models.ExamEnrollment) (int, error) {
+	if enrollment.QuestionPaperSet != nil {
+		return *enrollment.QuestionPaperSet, nil
+	}
+	if exam.QuestionPaperSets <= 1 {
+		return 1, nil
+	}
+	return 0, ErrPaperSetNotAssigned
+}
+
+// removeFile deletes an object that is no longer referenced. Failures only
+// leave an orphaned file behind, so they are logged rather than returned.
+func (s *questionPaperService) removeFile(ctx context.Context, objectKey string) {
+	if err := s.storage.DeleteFile(ctx, objectKey); err != nil {
+		s.logger.Warn().Err(err).Str("object_key", objectKey).Msg("failed to delete question paper file")
+	}
+}
@@ -0,0 +1,245 @@
+package exam
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+	"eduhub/server/internal/services/storage"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type questionPaperRepo struct {
+	repository.ExamRepository
+	exam       *models.Exam
+	enrollment *models.ExamEnrollment
+	papers     map[int]*models.ExamQuestionPaper
+	saveErr    error
+}
+
+func (r *questionPaperRepo) GetExamByID(ctx context.Context, collegeID, examID int) (*models.Exam, error) {
+	if r.exam.CollegeID != collegeID || r.exam.ID != examID {
+		return nil, repository.ErrExamNotFound
+	}
+	return r.exam, nil
+}
+
+func (r *questionPaperRepo) GetEnrollment(ctx context.Context, examID, studentID int) (*models.ExamEnrollment, error) {
+	if r.enrollment == nil || r.enrollment.StudentID != studentID {
+		return nil, repository.ErrEnrollmentNotFound
+	}
+	return r.enrollment, nil
+}
+
+func (r *questionPaperRepo) SaveQuestionPaper(ctx context.Context, paper *models.ExamQuestionPaper) (string, error) {
+	if r.saveErr != nil {
+		return "", r.saveErr
+	}
+	replaced := ""
+	if previous, ok := r.papers[paper.PaperSet]; ok {
+		replaced = previous.ObjectKey
+	}
+	r.papers[paper.PaperSet] = paper
+	return replaced, nil
+}
+
+func (r *questionPaperRepo) GetQuestionPaper(ctx context.Context, collegeID, examID, paperSet int) (*models.ExamQuestionPaper, error) {
+	paper, ok := r.papers[paperSet]
+	if !ok {
+		return nil, repository.ErrQuestionPaperNotFound
+	}
+	return paper, nil
+}
+
+type questionPaperStorage struct {
+	storage.StorageService
+	uploaded []string
+	deleted  []string
+	expiry   time.Duration
+}
+
+func (s *questionPaperStorage) UploadFile(ctx context.Context, objectKey string, reader io.Reader, size int64, contentType string) (string, error) {
+	s.uploaded = append(s.uploaded, objectKey)
+	return "http://minio/" + objectKey, nil
+}
+
+func (s *questionPaperStorage) DeleteFile(ctx context.Context, objectKey string) error {
+	s.deleted = append(s.deleted, objectKey)
+	return nil
+}
+
+func (s *questionPaperStorage) GetPresignedURL(ctx context.Context, objectKey string, access storage.ObjectAccess, expiry time.Duration) (*storage.PresignedURL, error) {
+	if err := storage.AuthorizeObjectKey(objectKey, access); err != nil {
+		return nil, err
+	}
+	s.expiry = expiry
+	return &storage.PresignedURL{URL: "http://minio/signed/" + objectKey, ExpiresIn: int64(expiry / time.Second)}, nil
+}
+
+func newQuestionPaperFixture(now time.Time, start time.Time) (*questionPaperService, *questionPaperRepo, *questionPaperStorage) {
+	repo := &questionPaperRepo{
+		exam: &models.Exam{ID: 7, CollegeID: 1, Status: "scheduled", QuestionPaperSets: 2,
+			StartTime: start, EndTime: start.Add(2 * time.Hour)},
+		papers: map[int]*models.ExamQuestionPaper{},
+	}
+	store := &questionPaperStorage{}
+	svc := NewQuestionPaperService(repo, store, zerolog.Nop()).(*questionPaperService)
+	svc.now = func() time.Time { return now }
+	return svc, repo, store
+}
+
+func TestUploadQuestionPaper(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 5, 10, 8, 0, 0, 0, time.UTC)
+	upload := func() QuestionPaperUpload {
+		return QuestionPaperUpload{FileName: "Set-A.PDF", ContentType: "application/pdf", Size: 4, Body: strings.NewReader("%PDF")}
+	}
+
+	t.Run("stores the paper under the exam", func(t *testing.T) {
+		svc, repo, store := newQuestionPaperFixture(now, now.Add(time.Hour))
+		paper, err := svc.UploadQuestionPaper(ctx, 1, 7, 2, 3, upload())
+		require.NoError(t, err)
+		assert.Equal(t, 2, paper.PaperSet)
+		assert.Equal(t, "Set-A.PDF", paper.FileName)
+		assert.True(t, strings.HasPrefix(paper.ObjectKey, "1/exams/7/papers/set2_"))
+		assert.True(t, strings.HasSuffix(paper.ObjectKey, ".pdf"))
+		assert.Equal(t, []string{paper.ObjectKey}, store.uploaded)
+		assert.Same(t, paper, repo.papers[2])
+	})
+
+	t.Run("replacing a paper deletes the old file", func(t *testing.T) {
+		svc, _, store := newQuestionPaperFixture(now, now.Add(time.Hour))
+		first, err := svc.UploadQuestionPaper(ctx, 1, 7, 1, 3, upload())
+		require.NoError(t, err)
+		_, err = svc.UploadQuestionPaper(ctx, 1, 7, 1, 3, upload())
+		require.NoError(t, err)
+		assert.Equal(t, []string{first.ObjectKey}, store.deleted)
+	})
+
+	t.Run("failed save removes the upload", func(t *testing.T) {
+		svc, repo, store := newQuestionPaperFixture(now, now.Add(time.Hour))
+		repo.saveErr = errors.New("db down")
+		_, err := svc.UploadQuestionPaper(ctx, 1, 7, 1, 3, upload())
+		require.Error(t, err)
+		assert.Equal(t, store.uploaded, store.deleted)
+	})
+
+	t.Run("set out of range", func(t *testing.T) {
+		svc, _, store := newQuestionPaperFixture(now, now.Add(time.Hour))
+		_, err := svc.UploadQuestionPaper(ctx, 1, 7, 3, 3, upload())
+		assert.ErrorIs(t, err, ErrInvalidPaperSet)
+		assert.Empty(t, store.uploaded)
+	})
+
+	t.Run("locked once the exam started", func(t *testing.T) {
+		svc, _, _ := newQuestionPaperFixture(now, now)
+		_, err := svc.UploadQuestionPaper(ctx, 1, 7, 1, 3, upload())
+		assert.ErrorIs(t, err, ErrQuestionPaperLocked)
+	})
+
+	t.Run("exam of another college", func(t *testing.T) {
+		svc, _, _ := newQuestionPaperFixture(now, now.Add(time.Hour))
+		_, err := svc.UploadQuestionPaper(ctx, 2, 7, 1, 3, upload())
+		assert.ErrorIs(t, err, ErrExamNotFound)
+	})
+}
+
+func TestGetStudentQuestionPaper(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 5, 10, 9, 0, 0, 0, time.UTC)
+	set := 2
+
+	fixture := func(now time.Time) (*questionPaperService, *questionPaperRepo, *questionPaperStorage) {
+		svc, repo, store := newQuestionPaperFixture(now, start)
+		repo.enrollment = &models.ExamEnrollment{ExamID: 7, StudentID: 5, CollegeID: 1, Status: "enrolled", QuestionPaperSet: &set}
+		repo.papers[1] = &models.ExamQuestionPaper{PaperSet: 1, ObjectKey: "1/exams/7/papers/set1_a.pdf", FileName: "A.pdf"}
+		repo.papers[2] = &models.ExamQuestionPaper{PaperSet: 2, ObjectKey: "1/exams/7/papers/set2_b.pdf", FileName: "B.pdf"}
+		return svc, repo, store
+	}
+
+	t.Run("assigned set during the exam", func(t *testing.T) {
+		svc, _, store := fixture(start.Add(30 * time.Minute))
+		delivery, err := svc.GetStudentQuestionPaper(ctx, 1, 7, 5)
+		require.NoError(t, err)
+		assert.Equal(t, 2, delivery.PaperSet)
+		assert.Equal(t, "B.pdf", delivery.FileName)
+		assert.Contains(t, delivery.URL, "set2_b.pdf")
+		assert.Equal(t, 90*time.Minute+time.Second, store.expiry, "the link expires with the exam")
+	})
+
+	t.Run("before the exam starts", func(t *testing.T) {
+		svc, _, _ := fixture(start.Add(-time.Minute))
+		_, err := svc.GetStudentQuestionPaper(ctx, 1, 7, 5)
+		assert.ErrorIs(t, err, ErrQuestionPaperUnavailable)
+	})
+
+	t.Run("after the exam ends", func(t *testing.T) {
+		svc, _, _ := fixture(start.Add(2 * time.Hour))
+		_, err := svc.GetStudentQuestionPaper(ctx, 1, 7, 5)
+		assert.ErrorIs(t, err, ErrQuestionPaperUnavailable)
+	})
+
+	t.Run("extra time extends the window", func(t *testing.T) {
+		svc, repo, store := fixture(start.Add(2*time.Hour + 10*time.Minute))
+		repo.enrollment.Accommodations.ExtraTimeMinutes = 30
+		_, err := svc.GetStudentQuestionPaper(ctx, 1, 7, 5)
+		require.NoError(t, err)
+		assert.Equal(t, 20*time.Minute+time.Second, store.expiry)
+	})
+
+	t.Run("cancelled exam", func(t *testing.T) {
+		svc, repo, _ := fixture(start.Add(time.Minute))
+		repo.exam.Status = "cancelled"
+		_, err := svc.GetStudentQuestionPaper(ctx, 1, 7, 5)
+		assert.ErrorIs(t, err, ErrQuestionPaperUnavailable)
+	})
+
+	t.Run("student not enrolled", func(t *testing.T) {
+		svc, _, _ := fixture(start.Add(time.Minute))
+		_, err := svc.GetStudentQuestionPaper(ctx, 1, 7, 6)
+		assert.ErrorIs(t, err, ErrEnrollmentNotFound)
+	})
+
+	t.Run("disqualified student", func(t *testing.T) {
+		svc, repo, _ := fixture(start.Add(time.Minute))
+		repo.enrollment.Status = "disqualified"
+		_, err := svc.GetStudentQuestionPaper(ctx, 1, 7, 5)
+		assert.ErrorIs(t, err, ErrEnrollmentDisqualified)
+	})
+
+	t.Run("no set assigned on a multi-set exam", func(t *testing.T) {
+		svc, repo, _ := fixture(start.Add(time.Minute))
+		repo.enrollment.QuestionPaperSet = nil
+		_, err := svc.GetStudentQuestionPaper(ctx, 1, 7, 5)
+		assert.ErrorIs(t, err, ErrPaperSetNotAssigned)
+	})
+
+	t.Run("single-set exam needs no assignment", func(t *testing.T) {
+		svc, repo, _ := fixture(start.Add(time.Minute))
+		repo.exam.QuestionPaperSets = 1
+		repo.enrollment.QuestionPaperSet = nil
+		delivery, err := svc.GetStudentQuestionPaper(ctx, 1, 7, 5)
+		require.NoError(t, err)
+		assert.Equal(t, 1, delivery.PaperSet)
+	})
+
+	t.Run("paper not uploaded", func(t *testing.T) {
+		svc, repo, _ := fixture(start.Add(time.Minute))
+		delete(repo.papers, 2)
+		_, err := svc.GetStudentQuestionPaper(ctx, 1, 7, 5)
+		assert.ErrorIs(t, err, ErrQuestionPaperNotFound)
+	})
+}
+
+func TestQuestionPaperKeysAreNotStudentReadable(t *testing.T) {
+	err := storage.AuthorizeObjectKey("1/exams/7/papers/set1_a.pdf", storage.ObjectAccess{CollegeID: 1, UserID: 7, StudentID: 5})
+	assert.ErrorIs(t, err, storage.ErrObjectAccessDenied)
+}
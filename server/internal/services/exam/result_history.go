@@ -0,0 +1,48 @@
+package exam
+
+import (
+	"context"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+)
+
+// ResultHistory is a result with every version of it that updates replaced,
+// oldest first. Intact is false when the stored history no longer matches
+// its hash chain, and BrokenAt is then the first entry that does not.
+type ResultHistory struct {
+	Result   *models.ExamResult               `json:"result"`
+	Versions []*models.ExamResultHistoryEntry `json:"versions"`
+	Intact   bool                             `json:"intact"`
+	BrokenAt *int64                           `json:"broken_at,omitempty"`
+}
+
+// GetResultHistory returns the student's result in the exam with the
+// versions regrades and revaluations replaced, and checks the history for
+// tampering
+func (s *examService) GetResultHistory(ctx context.Context, collegeID, examID, studentID int) (*ResultHistory, error) {
+	result, err := s.repo.GetResult(ctx, examID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if result.CollegeID != collegeID {
+		return nil, ErrExamResultNotFound
+	}
+
+	versions, err := s.repo.ListResultHistory(ctx, collegeID, result.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &ResultHistory{Result: result, Versions: versions, Intact: true}
+	prev := ""
+	for _, entry := range versions {
+		if entry.PrevHash != prev || repository.ResultHistoryHash(entry) != entry.EntryHash {
+			history.Intact = false
+			history.BrokenAt = &entry.ID
+			break
+		}
+		prev = entry.EntryHash
+	}
+	return history, nil
+}
@@ -0,0 +1,86 @@
+package exam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type resultHistoryRepo struct {
+	repository.ExamRepository
+	result  *models.ExamResult
+	history []*models.ExamResultHistoryEntry
+}
+
+func (r *resultHistoryRepo) GetResult(ctx context.Context, examID, studentID int) (*models.ExamResult, error) {
+	return r.result, nil
+}
+
+func (r *resultHistoryRepo) ListResultHistory(ctx context.Context, collegeID, resultID int) ([]*models.ExamResultHistoryEntry, error) {
+	return r.history, nil
+}
+
+// chainedHistory builds a history of the given marks chained like the
+// repository writes it
+func chainedHistory(marks ...float64) []*models.ExamResultHistoryEntry {
+	var entries []*models.ExamResultHistoryEntry
+	prev := ""
+	for i, m := range marks {
+		m := m
+		entry := &models.ExamResultHistoryEntry{
+			ID: int64(i + 1), ResultID: 11, CollegeID: 1, ExamID: 4, StudentID: 7, Version: i + 1,
+			MarksObtained: &m, Result: "pass", PrevHash: prev,
+			SupersededAt: time.Date(2026, 5, 4+i, 9, 0, 0, 0, time.UTC),
+		}
+		entry.EntryHash = repository.ResultHistoryHash(entry)
+		prev = entry.EntryHash
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestGetResultHistory(t *testing.T) {
+	ctx := context.Background()
+	result := &models.ExamResult{ID: 11, CollegeID: 1, ExamID: 4, StudentID: 7, Version: 4}
+
+	t.Run("intact history", func(t *testing.T) {
+		svc := &examService{repo: &resultHistoryRepo{result: result, history: chainedHistory(40, 45, 48)}}
+		history, err := svc.GetResultHistory(ctx, 1, 4, 7)
+		require.NoError(t, err)
+		assert.True(t, history.Intact)
+		assert.Nil(t, history.BrokenAt)
+		assert.Len(t, history.Versions, 3)
+	})
+
+	t.Run("edited marks break the chain", func(t *testing.T) {
+		entries := chainedHistory(40, 45, 48)
+		edited := 60.0
+		entries[1].MarksObtained = &edited
+		svc := &examService{repo: &resultHistoryRepo{result: result, history: entries}}
+		history, err := svc.GetResultHistory(ctx, 1, 4, 7)
+		require.NoError(t, err)
+		assert.False(t, history.Intact)
+		assert.Equal(t, int64(2), *history.BrokenAt)
+	})
+
+	t.Run("a removed entry breaks the chain", func(t *testing.T) {
+		entries := chainedHistory(40, 45, 48)
+		svc := &examService{repo: &resultHistoryRepo{result: result, history: []*models.ExamResultHistoryEntry{entries[0], entries[2]}}}
+		history, err := svc.GetResultHistory(ctx, 1, 4, 7)
+		require.NoError(t, err)
+		assert.False(t, history.Intact)
+		assert.Equal(t, int64(3), *history.BrokenAt)
+	})
+
+	t.Run("another college's result", func(t *testing.T) {
+		svc := &examService{repo: &resultHistoryRepo{result: result}}
+		_, err := svc.GetResultHistory(ctx, 2, 4, 7)
+		assert.ErrorIs(t, err, ErrExamResultNotFound)
+	})
+}
@@ -0,0 +1,96 @@
+package exam
+
+import (
+	"context"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/services/email"
+
+	"github.com/rs/zerolog"
+)
+
+// resultEmailTimeout bounds a whole notification run started by a publish
+const resultEmailTimeout = 10 * time.Minute
+
+// ResultNotifier emails students, and optionally their parents, when exam
+// results are published. It also tells students about cancelled exams. Emails
+// go out through the delivery service, so a failed one is retried and then
+// kept as a dead letter.
+type ResultNotifier struct {
+	delivery       email.DeliveryService
+	resultsURL     string
+	includeParents bool
+	logger         zerolog.Logger
+}
+
+// NotifyReport summarises one notification run. Sent counts the emails queued
+// for delivery and Failed those that could not be queued.
+type NotifyReport struct {
+	Sent   int `json:"sent"`
+	Failed int `json:"failed"`
+}
+
+// NewResultNotifier creates a notifier that links recipients to resultsURL
+// and logs emails that could not be queued to logger
+func NewResultNotifier(delivery email.DeliveryService, resultsURL string, includeParents bool, logger zerolog.Logger) *ResultNotifier {
+	return &ResultNotifier{
+		delivery:       delivery,
+		resultsURL:     resultsURL,
+		includeParents: includeParents,
+		logger:         logger,
+	}
+}
+
+// Notify emails every recipient. An email that cannot be queued is logged and
+// counted but never stops the rest.
+func (n *ResultNotifier) Notify(ctx context.Context, exam *models.Exam, recipients []*models.ResultNotificationRecipient) NotifyReport {
+	return n.send(ctx, exam, recipients, "result", email.TemplateResultPublished, func(rcpt *models.ResultNotificationRecipient) any {
+		return email.ResultPublishedData{
+			Name:        rcpt.Name,
+			StudentName: rcpt.StudentName,
+			IsParent:    rcpt.IsParent,
+			ExamTitle:   exam.Title,
+			ResultsURL:  n.resultsURL,
+			Locale:      rcpt.Locale,
+		}
+	})
+}
+
+// NotifyCancelled emails every recipient that the exam was cancelled and why
+func (n *ResultNotifier) NotifyCancelled(ctx context.Context, exam *models.Exam, reason string, recipients []*models.ResultNotificationRecipient) NotifyReport {
+	return n.send(ctx, exam, recipients, "cancellation", email.TemplateExamCancelled, func(rcpt *models.ResultNotificationRecipient) any {
+		return email.ExamCancelledData{
+			Name:      rcpt.Name,
+			ExamTitle: exam.Title,
+			StartTime: exam.StartTime,
+			Reason:    reason,
+		}
+	})
+}
+
+// send queues an email to every recipient using the named email template.
+// kind names the notification in logs.
+func (n *ResultNotifier) send(ctx context.Context, exam *models.Exam, recipients []*models.ResultNotificationRecipient,
+	kind, templateName string, data func(*models.ResultNotificationRecipient) any) NotifyReport {
+	var report NotifyReport
+	for _, rcpt := range recipients {
+		if err := n.delivery.SendTemplateAsync(ctx, exam.CollegeID, rcpt.Email, templateName, data(rcpt)); err != nil {
+			report.Failed++
+			n.logger.Warn().Err(err).
+				Int("exam_id", exam.ID).
+				Int("student_id", rcpt.StudentID).
+				Bool("parent", rcpt.IsParent).
+				Msgf("failed to queue %s notification", kind)
+			continue
+		}
+		report.Sent++
+	}
+
+	n.logger.Info().
+		Int("exam_id", exam.ID).
+		Int("queued", report.Sent).
+		Int("failed", report.Failed).
+		Msgf("%s notifications queued", kind)
+	return report
+}
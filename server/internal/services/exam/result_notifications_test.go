@@ -0,0 +1,69 @@
+package exam
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/services/email"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingDelivery renders and records emails as they are queued
+type recordingDelivery struct {
+	email.DeliveryService
+	mu   sync.Mutex
+	sent map[string]string
+}
+
+func (d *recordingDelivery) SendTemplateAsync(ctx context.Context, collegeID int, to, templateName string, data any) error {
+	if _, err := email.ParseRecipients(to); err != nil {
+		return err
+	}
+	_, body, err := email.Render(templateName, data)
+	if err != nil {
+		return err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.sent[to] = body
+	return nil
+}
+
+func TestResultNotifier_Notify(t *testing.T) {
+	mailer := &recordingDelivery{sent: map[string]string{}}
+	notifier := NewResultNotifier(mailer, "https://app.example.com/exams", true, zerolog.Nop())
+	exam := &models.Exam{ID: 9, Title: "Midterm <Physics>"}
+
+	recipients := []*models.ResultNotificationRecipient{
+		{StudentID: 1, StudentName: "Asha", Name: "Asha", Email: "asha@example.com"},
+		{StudentID: 1, StudentName: "Asha", Name: "Ravi", Email: "ravi@example.com", IsParent: true},
+		{StudentID: 2, StudentName: "Dev", Name: "Dev", Email: "not-an-address"},
+	}
+
+	report := notifier.Notify(context.Background(), exam, recipients)
+	assert.Equal(t, len(recipients)-1, report.Sent)
+	assert.Equal(t, 1, report.Failed)
+
+	require.Contains(t, mailer.sent, "asha@example.com")
+	assert.Contains(t, mailer.sent["asha@example.com"], "Your result for <strong>Midterm &lt;Physics&gt;</strong>")
+	assert.Contains(t, mailer.sent["ravi@example.com"], "Asha's result")
+	assert.Contains(t, mailer.sent["asha@example.com"], "https://app.example.com/exams")
+}
+
+func TestResultNotifier_NotifyCancelled(t *testing.T) {
+	mailer := &recordingDelivery{sent: map[string]string{}}
+	notifier := NewResultNotifier(mailer, "", false, zerolog.Nop())
+	exam := &models.Exam{ID: 9, Title: "Midterm"}
+
+	report := notifier.NotifyCancelled(context.Background(), exam, "Hall <flooded>", []*models.ResultNotificationRecipient{
+		{StudentID: 1, Name: "Asha", Email: "asha@example.com"},
+	})
+	assert.Equal(t, 1, report.Sent)
+	assert.Contains(t, mailer.sent["asha@example.com"], "<strong>Midterm</strong>")
+	assert.Contains(t, mailer.sent["asha@example.com"], "Reason: Hall &lt;flooded&gt;")
+}
@@ -0,0 +1,214 @@
+package exam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// resultsImportColumns are the columns after the student column of a results
+// import file
+var resultsImportColumns = []string{"marks_obtained", "remarks"}
+
+const maxImportRemarksLength = 500
+
+// ErrInvalidResultsHeader is returned when the first CSV row of a results
+// import does not name the expected columns in the expected order
+var ErrInvalidResultsHeader = errors.New("invalid CSV header")
+
+// ResultsImportRow reports what happened to one line of an imported results
+// file
+type ResultsImportRow struct {
+	Line          int      `json:"line"`
+	RollNo        string   `json:"roll_no,omitempty"`
+	StudentID     int      `json:"student_id,omitempty"`
+	MarksObtained *float64 `json:"marks_obtained,omitempty"`
+	Status        string   `json:"status"` // graded, failed, skipped
+	Error         string   `json:"error,omitempty"`
+}
+
+// ResultsImportReport summarises an ImportResults call line by line
+type ResultsImportReport struct {
+	Mode      BulkGradeMode      `json:"mode"`
+	Committed bool               `json:"committed"`
+	Graded    int                `json:"graded"`
+	Failed    int                `json:"failed"`
+	Skipped   int                `json:"skipped"`
+	Rows      []ResultsImportRow `json:"rows"`
+}
+
+// ImportResults grades an exam from a CSV export of an offline grading sheet.
+// The header must be roll_no or student_id followed by marks_obtained and
+// remarks; a file with any other header is rejected as a whole. Roll numbers
+// are matched against the exam's enrollments, and every row must name an
+// enrolled student once with marks within the exam's total. The valid rows are
+// written through BulkGradeResults, so mode has the same meaning there: in
+// BulkGradeAllOrNothing mode a single bad line leaves the exam untouched.
+func (s *examService) ImportResults(ctx context.Context, collegeID, examID int, records [][]string, mode BulkGradeMode) (*ResultsImportReport, error) {
+	if collegeID == 0 || examID == 0 {
+		return nil, errors.New("invalid college ID or exam ID")
+	}
+	if mode == "" {
+		mode = BulkGradeAllOrNothing
+	}
+	if mode != BulkGradeAllOrNothing && mode != BulkGradePartial {
+		return nil, fmt.Errorf("invalid bulk grade mode %q", mode)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: file is empty", ErrInvalidResultsHeader)
+	}
+	byRollNo, err := parseResultsImportHeader(records[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return nil, errors.New("no results to import")
+	}
+
+	exam, err := s.repo.GetExamByID(ctx, collegeID, examID)
+	if err != nil {
+		return nil, err
+	}
+	rollNos, err := s.repo.ListEnrollmentRollNos(ctx, collegeID, examID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load exam enrollments: %w", err)
+	}
+	studentsByRollNo := make(map[string]int, len(rollNos))
+	for studentID, rollNo := range rollNos {
+		studentsByRollNo[importRollNoKey(rollNo)] = studentID
+	}
+
+	report := &ResultsImportReport{Mode: mode, Rows: make([]ResultsImportRow, 0, len(records)-1)}
+	inputs := make(map[int]*ResultInput)
+	seen := make(map[int]int)
+	for i, record := range records[1:] {
+		row := ResultsImportRow{Line: i + 2}
+		input, err := parseResultsImportRecord(record, byRollNo, &row)
+		if err == nil {
+			if byRollNo {
+				id, ok := studentsByRollNo[importRollNoKey(row.RollNo)]
+				if !ok {
+					err = fmt.Errorf("roll_no %q is not enrolled in this exam", row.RollNo)
+				}
+				row.StudentID = id
+			} else if _, ok := rollNos[row.StudentID]; !ok {
+				err = fmt.Errorf("student %d is not enrolled in this exam", row.StudentID)
+			}
+		}
+		if err == nil {
+			if first, ok := seen[row.StudentID]; ok {
+				err = fmt.Errorf("duplicate student, already on line %d", first)
+			} else if input.MarksObtained > exam.TotalMarks {
+				err = fmt.Errorf("marks_obtained must be at most %g", exam.TotalMarks)
+			}
+		}
+		if err != nil {
+			row.Status = "failed"
+			row.Error = err.Error()
+		} else {
+			seen[row.StudentID] = row.Line
+			inputs[row.StudentID] = input
+		}
+		report.Rows = append(report.Rows, row)
+	}
+
+	var outcomes map[int]BulkGradeOutcome
+	rejected := len(inputs) < len(report.Rows)
+	if len(inputs) > 0 && (mode == BulkGradePartial || !rejected) {
+		graded, err := s.BulkGradeResults(ctx, collegeID, examID, inputs, mode)
+		if err != nil {
+			return nil, err
+		}
+		report.Committed = graded.Committed
+		outcomes = make(map[int]BulkGradeOutcome, len(graded.Outcomes))
+		for _, outcome := range graded.Outcomes {
+			outcomes[outcome.StudentID] = outcome
+		}
+	}
+
+	for i := range report.Rows {
+		row := &report.Rows[i]
+		if row.Status == "" {
+			if outcome, ok := outcomes[row.StudentID]; ok {
+				row.Status = outcome.Status
+				row.Error = outcome.Error
+			} else {
+				// Valid, but the file was rejected because of other lines
+				row.Status = "skipped"
+			}
+		}
+		switch row.Status {
+		case "graded":
+			report.Graded++
+		case "failed":
+			report.Failed++
+		default:
+			report.Skipped++
+		}
+	}
+	return report, nil
+}
+
+// parseResultsImportHeader checks the header row and reports whether students
+// are identified by roll number rather than student ID
+func parseResultsImportHeader(header []string) (bool, error) {
+	got := make([]string, len(header))
+	for i, name := range header {
+		got[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+	}
+	if len(got) == len(resultsImportColumns)+1 &&
+		(got[0] == "roll_no" || got[0] == "student_id") &&
+		strings.Join(got[1:], ",") == strings.Join(resultsImportColumns, ",") {
+		return got[0] == "roll_no", nil
+	}
+	return false, fmt.Errorf("%w: expected roll_no or student_id followed by %s, got %s", ErrInvalidResultsHeader,
+		strings.Join(resultsImportColumns, ","), strings.Join(got, ","))
+}
+
+// parseResultsImportRecord reads the student, marks and remarks of one line
+// into row and returns the marks to grade. The row keeps whatever could be
+// read even when err is set, so the report can point at the student.
+func parseResultsImportRecord(record []string, byRollNo bool, row *ResultsImportRow) (*ResultInput, error) {
+	if len(record) > 0 {
+		id := strings.TrimSpace(record[0])
+		if byRollNo {
+			row.RollNo = id
+		} else if studentID, err := strconv.Atoi(id); err == nil {
+			row.StudentID = studentID
+		}
+	}
+	if len(record) != len(resultsImportColumns)+1 {
+		return nil, fmt.Errorf("expected %d columns, got %d", len(resultsImportColumns)+1, len(record))
+	}
+
+	switch {
+	case byRollNo && row.RollNo == "":
+		return nil, errors.New("roll_no is required")
+	case !byRollNo && row.StudentID <= 0:
+		return nil, fmt.Errorf("invalid student_id %q", strings.TrimSpace(record[0]))
+	}
+
+	marks, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+	if err != nil || math.IsNaN(marks) || math.IsInf(marks, 0) {
+		return nil, fmt.Errorf("invalid marks_obtained %q", strings.TrimSpace(record[1]))
+	}
+	row.MarksObtained = &marks
+	if marks < 0 {
+		return nil, errors.New("marks_obtained must not be negative")
+	}
+
+	remarks := strings.TrimSpace(record[2])
+	if len(remarks) > maxImportRemarksLength {
+		return nil, fmt.Errorf("remarks must be at most %d characters", maxImportRemarksLength)
+	}
+	return &ResultInput{MarksObtained: marks, Remarks: remarks}, nil
+}
+
+// importRollNoKey is how imported roll numbers are matched: surrounding space
+// is ignored and case does not matter, as at exam check-in
+func importRollNoKey(rollNo string) string {
+	return strings.ToLower(strings.TrimSpace(rollNo))
+}
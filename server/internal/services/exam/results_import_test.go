@@ -0,0 +1,103 @@
+package exam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type resultsImportRepo struct {
+	bulkGradeRepo
+	rollNos map[int]string
+}
+
+func (r *resultsImportRepo) ListEnrollmentRollNos(ctx context.Context, collegeID, examID int) (map[int]string, error) {
+	return r.rollNos, nil
+}
+
+func newResultsImportRepo() *resultsImportRepo {
+	return &resultsImportRepo{rollNos: map[int]string{1: "CS-001", 2: "CS-002", 3: "CS-003"}}
+}
+
+func TestImportResults(t *testing.T) {
+	ctx := context.Background()
+	header := []string{"\ufeffRoll_No", "marks_obtained", "remarks"}
+
+	t.Run("matches roll numbers and grades every line", func(t *testing.T) {
+		repo := newResultsImportRepo()
+		svc := &examService{repo: repo}
+
+		report, err := svc.ImportResults(ctx, 1, 9, [][]string{
+			header,
+			{" cs-001 ", "75", "Good"},
+			{"CS-003", "20.5", ""},
+		}, "")
+		require.NoError(t, err)
+
+		require.Len(t, repo.saved, 2)
+		assert.Equal(t, 1, repo.saved[0].StudentID)
+		assert.Equal(t, "Good", repo.saved[0].Remarks)
+		assert.Equal(t, 3, repo.saved[1].StudentID)
+		assert.True(t, report.Committed)
+		assert.Equal(t, 2, report.Graded)
+		assert.Equal(t, ResultsImportRow{Line: 2, RollNo: "cs-001", StudentID: 1, MarksObtained: report.Rows[0].MarksObtained, Status: "graded"}, report.Rows[0])
+	})
+
+	t.Run("one bad line leaves an all or nothing import unwritten", func(t *testing.T) {
+		repo := newResultsImportRepo()
+		svc := &examService{repo: repo}
+
+		report, err := svc.ImportResults(ctx, 1, 9, [][]string{
+			header,
+			{"CS-001", "75", ""},
+			{"CS-404", "60", ""},
+			{"CS-002", "130", ""},
+			{"CS-001", "80", ""},
+			{"CS-003", "abc", ""},
+			{"CS-003"},
+		}, BulkGradeAllOrNothing)
+		require.NoError(t, err)
+
+		assert.Nil(t, repo.saved)
+		assert.False(t, report.Committed)
+		assert.Equal(t, 1, report.Skipped)
+		assert.Equal(t, 5, report.Failed)
+		assert.Equal(t, "skipped", report.Rows[0].Status)
+		assert.Contains(t, report.Rows[1].Error, "not enrolled")
+		assert.Contains(t, report.Rows[2].Error, "at most 100")
+		assert.Contains(t, report.Rows[3].Error, "already on line 2")
+		assert.Contains(t, report.Rows[4].Error, "invalid marks_obtained")
+		assert.Contains(t, report.Rows[5].Error, "expected 3 columns")
+	})
+
+	t.Run("partial import commits the valid lines", func(t *testing.T) {
+		repo := newResultsImportRepo()
+		svc := &examService{repo: repo}
+
+		report, err := svc.ImportResults(ctx, 1, 9, [][]string{
+			{"student_id", "marks_obtained", "remarks"},
+			{"2", "45", ""},
+			{"8", "50", ""},
+		}, BulkGradePartial)
+		require.NoError(t, err)
+
+		require.Len(t, repo.saved, 1)
+		assert.Equal(t, 2, repo.saved[0].StudentID)
+		assert.True(t, report.Committed)
+		assert.Equal(t, 1, report.Graded)
+		assert.Equal(t, 1, report.Failed)
+		assert.Equal(t, "failed", report.Rows[1].Status)
+	})
+
+	t.Run("malformed header rejects the file", func(t *testing.T) {
+		svc := &examService{repo: newResultsImportRepo()}
+
+		_, err := svc.ImportResults(ctx, 1, 9, [][]string{
+			{"roll_no", "remarks", "marks_obtained"},
+			{"CS-001", "Good", "75"},
+		}, "")
+		assert.ErrorIs(t, err, ErrInvalidResultsHeader)
+	})
+}
@@ -0,0 +1,141 @@
+package exam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+)
+
+// RevaluationFeeType is the fee type of the fee structure a college
+// charges once per revaluation request. Colleges without one charge nothing.
+const RevaluationFeeType = "revaluation"
+
+// ErrRevaluationFeeUnpaid is wrapped by every RevaluationFeeDueError
+var ErrRevaluationFeeUnpaid = repository.ErrRevaluationFeeUnpaid
+
+// RevaluationFeeDueError is returned by CreateRevaluationRequest until the
+// student has paid AmountDue against the fee assignment FeeAssignmentID
+type RevaluationFeeDueError = repository.RevaluationFeeDueError
+
+// StudentRevaluationRequest is what a student sees of their own revaluation
+// request: its status and, once decided, the revised marks and comments
+type StudentRevaluationRequest struct {
+	ID             int        `json:"id"`
+	ExamResultID   int        `json:"exam_result_id"`
+	ExamID         int        `json:"exam_id"`
+	ExamTitle      string     `json:"exam_title"`
+	Reason         string     `json:"reason"`
+	Status         string     `json:"status"` // pending, approved, rejected, completed
+	PreviousMarks  float64    `json:"previous_marks"`
+	RevisedMarks   *float64   `json:"revised_marks,omitempty"`
+	ReviewComments string     `json:"review_comments,omitempty"`
+	FeeAmount      *float64   `json:"fee_amount,omitempty"`
+	RequestedAt    time.Time  `json:"requested_at"`
+	ReviewedAt     *time.Time `json:"reviewed_at,omitempty"`
+}
+
+// ListStudentRevaluationRequests lists the student's own revaluation
+// requests, newest first
+func (s *examService) ListStudentRevaluationRequests(ctx context.Context, collegeID, studentID int) ([]*StudentRevaluationRequest, error) {
+	if collegeID == 0 || studentID == 0 {
+		return nil, errors.New("college ID and student ID are required")
+	}
+	details, err := s.repo.ListRevaluationRequestDetails(ctx, collegeID, models.RevaluationRequestFilter{StudentID: &studentID})
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]*StudentRevaluationRequest, 0, len(details))
+	for _, d := range details {
+		requests = append(requests, &StudentRevaluationRequest{
+			ID:             d.ID,
+			ExamResultID:   d.ExamResultID,
+			ExamID:         d.ExamID,
+			ExamTitle:      d.ExamTitle,
+			Reason:         d.Reason,
+			Status:         d.Status,
+			PreviousMarks:  d.PreviousMarks,
+			RevisedMarks:   d.RevisedMarks,
+			ReviewComments: d.ReviewComments,
+			FeeAmount:      d.FeeAmount,
+			RequestedAt:    d.RequestedAt,
+			ReviewedAt:     d.ReviewedAt,
+		})
+	}
+	return requests, nil
+}
+
+// attachRevaluationFee charges the request the college's revaluation fee, if
+// it has one, from the student's revaluation fee assignment, which is created
+// on the student's first request. It returns the assignment, or nil when no
+// fee applies.
+func (s *examService) attachRevaluationFee(ctx context.Context, request *models.RevaluationRequest) (*models.FeeAssignment, error) {
+	if s.feeRepo == nil {
+		return nil, nil
+	}
+
+	feeType := RevaluationFeeType
+	structures, err := s.feeRepo.ListFeeStructures(ctx, models.FeeFilter{CollegeID: request.CollegeID, FeeType: &feeType, Limit: 1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load revaluation fee: %w", err)
+	}
+	if len(structures) == 0 {
+		return nil, nil
+	}
+	fee := structures[0]
+
+	assignments, err := s.feeRepo.GetStudentFeeAssignments(ctx, request.StudentID)
+	if err != nil {
+		return nil, err
+	}
+	var assignment *models.FeeAssignment
+	for _, a := range assignments {
+		if a.FeeStructureID == fee.ID {
+			assignment = a
+			break
+		}
+	}
+	if assignment == nil {
+		assignment = &models.FeeAssignment{
+			StudentID:      request.StudentID,
+			FeeStructureID: fee.ID,
+			Amount:         fee.Amount,
+			DueDate:        fee.DueDate,
+		}
+		if err := s.feeRepo.AssignFeeToStudent(ctx, assignment); err != nil {
+			return nil, err
+		}
+	}
+
+	request.FeeAssignmentID = &assignment.ID
+	request.FeeAmount = &fee.Amount
+	return assignment, nil
+}
+
+// chargeRevaluationFee raises the assignment's amount so the student owes at
+// least due on it. One assignment carries every revaluation fee the student
+// is charged, and payments beyond what an assignment is owed are refused.
+func (s *examService) chargeRevaluationFee(ctx context.Context, assignment *models.FeeAssignment, due float64) error {
+	outstanding := assignment.Amount - assignment.WaiverAmount - assignment.PaidAmount
+	if outstanding >= due {
+		return nil
+	}
+
+	assignment.Amount += due - outstanding
+	if err := s.feeRepo.AssignFeeToStudent(ctx, assignment); err != nil {
+		return fmt.Errorf("failed to charge revaluation fee: %w", err)
+	}
+	// Raising the amount reopens an assignment that was paid in full
+	status := "pending"
+	if assignment.PaidAmount > 0 {
+		status = "partial"
+	}
+	if err := s.feeRepo.UpdateFeeAssignmentStatus(ctx, assignment.ID, status); err != nil {
+		return fmt.Errorf("failed to charge revaluation fee: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package exam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type revaluationRepo struct {
+	repository.ExamRepository
+	result  *models.ExamResult
+	created *models.RevaluationRequest
+	credit  float64 // paid towards the fee assignment and not yet spent
+	filter  models.RevaluationRequestFilter
+	details []*models.RevaluationRequestDetail
+}
+
+func (r *revaluationRepo) GetResultByID(ctx context.Context, resultID int) (*models.ExamResult, error) {
+	return r.result, nil
+}
+
+func (r *revaluationRepo) CreateRevaluationRequest(ctx context.Context, request *models.RevaluationRequest) error {
+	if request.FeeAmount != nil && r.credit < *request.FeeAmount {
+		return &RevaluationFeeDueError{FeeAssignmentID: *request.FeeAssignmentID, AmountDue: *request.FeeAmount - r.credit}
+	}
+	r.created = request
+	return nil
+}
+
+func (r *revaluationRepo) ListRevaluationRequestDetails(ctx context.Context, collegeID int, filter models.RevaluationRequestFilter) ([]*models.RevaluationRequestDetail, error) {
+	r.filter = filter
+	return r.details, nil
+}
+
+type revaluationFeeRepo struct {
+	repository.FeeRepository
+	structures  []*models.FeeStructure
+	assignments []*models.FeeAssignment
+	assigned    *models.FeeAssignment
+	status      string
+}
+
+func (r *revaluationFeeRepo) ListFeeStructures(ctx context.Context, filter models.FeeFilter) ([]*models.FeeStructure, error) {
+	if filter.FeeType == nil || *filter.FeeType != RevaluationFeeType {
+		return nil, nil
+	}
+	return r.structures, nil
+}
+
+func (r *revaluationFeeRepo) GetStudentFeeAssignments(ctx context.Context, studentID int) ([]*models.FeeAssignment, error) {
+	return r.assignments, nil
+}
+
+func (r *revaluationFeeRepo) AssignFeeToStudent(ctx context.Context, assignment *models.FeeAssignment) error {
+	if assignment.ID == 0 {
+		assignment.ID = 6
+	}
+	r.assigned = assignment
+	return nil
+}
+
+func (r *revaluationFeeRepo) UpdateFeeAssignmentStatus(ctx context.Context, assignmentID int, status string) error {
+	r.status = status
+	return nil
+}
+
+func TestCreateRevaluationRequest(t *testing.T) {
+	ctx := context.Background()
+	marks := 38.5
+	publishedAt := time.Now().Add(-24 * time.Hour)
+	publishedResult := func() *models.ExamResult {
+		return &models.ExamResult{ID: 11, ExamID: 7, StudentID: 4, CollegeID: 1, MarksObtained: &marks, Published: true, PublishedAt: &publishedAt}
+	}
+	newRequest := func() *models.RevaluationRequest {
+		return &models.RevaluationRequest{ExamResultID: 11, StudentID: 4, CollegeID: 1, Reason: "Question 5 was not marked"}
+	}
+	revaluationFee := []*models.FeeStructure{{ID: 2, CollegeID: 1, Amount: 250, FeeType: RevaluationFeeType}}
+
+	t.Run("only the student's own published result can be revalued", func(t *testing.T) {
+		for name, result := range map[string]*models.ExamResult{
+			"another student": {ID: 11, StudentID: 5, CollegeID: 1, MarksObtained: &marks, Published: true},
+			"another college": {ID: 11, StudentID: 4, CollegeID: 2, MarksObtained: &marks, Published: true},
+			"unpublished":     {ID: 11, StudentID: 4, CollegeID: 1, MarksObtained: &marks},
+		} {
+			repo := &revaluationRepo{result: result}
+			svc := &examService{repo: repo}
+			err := svc.CreateRevaluationRequest(ctx, newRequest(), CreateRevaluationOptions{})
+			assert.ErrorIs(t, err, ErrExamResultNotFound, name)
+			assert.Nil(t, repo.created, name)
+		}
+	})
+
+	t.Run("no fee when the college charges none", func(t *testing.T) {
+		repo := &revaluationRepo{result: publishedResult()}
+		svc := &examService{repo: repo, feeRepo: &revaluationFeeRepo{}}
+		request := newRequest()
+		request.PreviousMarks = 90 // taken from the result, not the client
+
+		require.NoError(t, svc.CreateRevaluationRequest(ctx, request, CreateRevaluationOptions{}))
+		require.NotNil(t, repo.created)
+		assert.Equal(t, 38.5, repo.created.PreviousMarks)
+		assert.Equal(t, "pending", repo.created.Status)
+		assert.Nil(t, repo.created.FeeAssignmentID)
+	})
+
+	t.Run("first request assigns the fee and waits for payment", func(t *testing.T) {
+		repo := &revaluationRepo{result: publishedResult()}
+		fees := &revaluationFeeRepo{structures: revaluationFee}
+		svc := &examService{repo: repo, feeRepo: fees}
+
+		err := svc.CreateRevaluationRequest(ctx, newRequest(), CreateRevaluationOptions{})
+		var due *RevaluationFeeDueError
+		require.ErrorAs(t, err, &due)
+		assert.Equal(t, 6, due.FeeAssignmentID)
+		assert.Equal(t, 250.0, due.AmountDue)
+		assert.Nil(t, repo.created)
+		require.NotNil(t, fees.assigned)
+		assert.Equal(t, 250.0, fees.assigned.Amount)
+		assert.Empty(t, fees.status)
+	})
+
+	t.Run("paid fee creates the request", func(t *testing.T) {
+		repo := &revaluationRepo{result: publishedResult(), credit: 250}
+		fees := &revaluationFeeRepo{
+			structures:  revaluationFee,
+			assignments: []*models.FeeAssignment{{ID: 6, StudentID: 4, FeeStructureID: 2, Amount: 250, PaidAmount: 250, Status: "paid"}},
+		}
+		svc := &examService{repo: repo, feeRepo: fees}
+
+		require.NoError(t, svc.CreateRevaluationRequest(ctx, newRequest(), CreateRevaluationOptions{}))
+		require.NotNil(t, repo.created)
+		assert.Equal(t, 6, *repo.created.FeeAssignmentID)
+		assert.Equal(t, 250.0, *repo.created.FeeAmount)
+		assert.Nil(t, fees.assigned)
+	})
+
+	t.Run("another request charges the fee again", func(t *testing.T) {
+		// The earlier payment was spent on the student's first request
+		repo := &revaluationRepo{result: publishedResult()}
+		fees := &revaluationFeeRepo{
+			structures:  revaluationFee,
+			assignments: []*models.FeeAssignment{{ID: 6, StudentID: 4, FeeStructureID: 2, Amount: 250, PaidAmount: 250, Status: "paid"}},
+		}
+		svc := &examService{repo: repo, feeRepo: fees}
+
+		err := svc.CreateRevaluationRequest(ctx, newRequest(), CreateRevaluationOptions{})
+		assert.ErrorIs(t, err, ErrRevaluationFeeUnpaid)
+		require.NotNil(t, fees.assigned)
+		assert.Equal(t, 500.0, fees.assigned.Amount)
+		assert.Equal(t, "partial", fees.status)
+	})
+}
+
+func TestListStudentRevaluationRequests(t *testing.T) {
+	revised := 44.0
+	repo := &revaluationRepo{details: []*models.RevaluationRequestDetail{{
+		RevaluationRequest: models.RevaluationRequest{
+			ID: 3, ExamResultID: 11, StudentID: 4, Status: "approved", PreviousMarks: 38.5,
+			RevisedMarks: &revised, ReviewComments: "Question 5 re-marked",
+		},
+		ExamID:    7,
+		ExamTitle: "Midterm",
+	}}}
+	svc := &examService{repo: repo}
+
+	requests, err := svc.ListStudentRevaluationRequests(context.Background(), 1, 4)
+	require.NoError(t, err)
+	require.NotNil(t, repo.filter.StudentID)
+	assert.Equal(t, 4, *repo.filter.StudentID)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "Midterm", requests[0].ExamTitle)
+	assert.Equal(t, "approved", requests[0].Status)
+	assert.Equal(t, 44.0, *requests[0].RevisedMarks)
+	assert.Equal(t, "Question 5 re-marked", requests[0].ReviewComments)
+}
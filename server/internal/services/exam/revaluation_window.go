@@ -0,0 +1,46 @@
+package exam
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"eduhub/server/internal/models"
+)
+
+// DefaultRevaluationWindowDays is used when the service is built without a
+// revaluation window
+const DefaultRevaluationWindowDays = 7
+
+// ErrRevaluationWindowClosed is returned by CreateRevaluationRequest once the
+// result's revaluation deadline has passed
+var ErrRevaluationWindowClosed = errors.New("the revaluation window for this result has closed")
+
+// CreateRevaluationOptions adjusts the checks CreateRevaluationRequest applies
+type CreateRevaluationOptions struct {
+	// OverrideDeadline allows a request after the revaluation window closed
+	OverrideDeadline bool
+}
+
+// revaluationDeadline is when the window to request revaluation of result
+// closes, counted from its publication. ok is false for a result without a
+// publication time, which has no deadline.
+func (s *examService) revaluationDeadline(result *models.ExamResult) (deadline time.Time, ok bool) {
+	if result.PublishedAt == nil {
+		return time.Time{}, false
+	}
+	days := s.revaluationWindowDays
+	if days <= 0 {
+		days = DefaultRevaluationWindowDays
+	}
+	return result.PublishedAt.AddDate(0, 0, days), true
+}
+
+// checkRevaluationWindow rejects a request made after result's deadline
+func (s *examService) checkRevaluationWindow(result *models.ExamResult, now time.Time) error {
+	deadline, ok := s.revaluationDeadline(result)
+	if ok && now.After(deadline) {
+		return fmt.Errorf("%w: requests were due by %s", ErrRevaluationWindowClosed, deadline.Format("2006-01-02 15:04 MST"))
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package exam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevaluationWindow(t *testing.T) {
+	ctx := context.Background()
+	marks := 38.5
+	publishedDaysAgo := func(days int) *models.ExamResult {
+		publishedAt := time.Now().AddDate(0, 0, -days)
+		return &models.ExamResult{ID: 11, ExamID: 7, StudentID: 4, CollegeID: 1, MarksObtained: &marks, Published: true, PublishedAt: &publishedAt}
+	}
+	newRequest := func() *models.RevaluationRequest {
+		return &models.RevaluationRequest{ExamResultID: 11, StudentID: 4, CollegeID: 1, Reason: "Question 5 was not marked"}
+	}
+
+	t.Run("request within the window is created", func(t *testing.T) {
+		repo := &revaluationRepo{result: publishedDaysAgo(6)}
+		svc := &examService{repo: repo}
+		require.NoError(t, svc.CreateRevaluationRequest(ctx, newRequest(), CreateRevaluationOptions{}))
+		assert.NotNil(t, repo.created)
+	})
+
+	t.Run("request after the window is rejected with the deadline", func(t *testing.T) {
+		result := publishedDaysAgo(8)
+		repo := &revaluationRepo{result: result}
+		svc := &examService{repo: repo}
+
+		err := svc.CreateRevaluationRequest(ctx, newRequest(), CreateRevaluationOptions{})
+		assert.ErrorIs(t, err, ErrRevaluationWindowClosed)
+		deadline := result.PublishedAt.AddDate(0, 0, DefaultRevaluationWindowDays)
+		assert.Contains(t, err.Error(), deadline.Format("2006-01-02 15:04"))
+		assert.Nil(t, repo.created)
+	})
+
+	t.Run("window length is configurable", func(t *testing.T) {
+		repo := &revaluationRepo{result: publishedDaysAgo(8)}
+		svc := &examService{repo: repo, revaluationWindowDays: 14}
+		require.NoError(t, svc.CreateRevaluationRequest(ctx, newRequest(), CreateRevaluationOptions{}))
+
+		repo = &revaluationRepo{result: publishedDaysAgo(4)}
+		svc = &examService{repo: repo, revaluationWindowDays: 3}
+		assert.ErrorIs(t, svc.CreateRevaluationRequest(ctx, newRequest(), CreateRevaluationOptions{}), ErrRevaluationWindowClosed)
+	})
+
+	t.Run("override accepts a late request", func(t *testing.T) {
+		repo := &revaluationRepo{result: publishedDaysAgo(30)}
+		svc := &examService{repo: repo}
+		require.NoError(t, svc.CreateRevaluationRequest(ctx, newRequest(), CreateRevaluationOptions{OverrideDeadline: true}))
+		assert.NotNil(t, repo.created)
+	})
+
+	t.Run("expired requests are not charged a fee", func(t *testing.T) {
+		fees := &revaluationFeeRepo{structures: []*models.FeeStructure{{ID: 2, CollegeID: 1, Amount: 250, FeeType: RevaluationFeeType}}}
+		svc := &examService{repo: &revaluationRepo{result: publishedDaysAgo(8)}, feeRepo: fees}
+		assert.ErrorIs(t, svc.CreateRevaluationRequest(ctx, newRequest(), CreateRevaluationOptions{}), ErrRevaluationWindowClosed)
+		assert.Nil(t, fees.assigned)
+	})
+}
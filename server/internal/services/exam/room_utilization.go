@@ -0,0 +1,57 @@
+package exam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"eduhub/server/internal/models"
+)
+
+// ErrInvalidUtilizationWindow is returned by GetRoomUtilization when the
+// window is empty, inverted or longer than maxUtilizationDays
+var ErrInvalidUtilizationWindow = errors.New("invalid utilization window")
+
+// maxUtilizationDays bounds one utilization report to about a year
+const maxUtilizationDays = 366
+
+// GetRoomUtilization reports, for every active or booked exam room, how many
+// exams it held in [startDate, endDate) and what share of the window's hours
+// they took up. Rooms are sorted busiest first, then by room number.
+func (s *examService) GetRoomUtilization(ctx context.Context, collegeID int, startDate, endDate time.Time) ([]*models.RoomUtilization, error) {
+	if collegeID == 0 {
+		return nil, errors.New("college ID is required")
+	}
+	if !endDate.After(startDate) {
+		return nil, fmt.Errorf("%w: end date must be after start date", ErrInvalidUtilizationWindow)
+	}
+	if endDate.Sub(startDate) > maxUtilizationDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: window cannot exceed %d days", ErrInvalidUtilizationWindow, maxUtilizationDays)
+	}
+
+	rooms, err := s.repo.ListRoomBookings(ctx, collegeID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load room bookings: %w", err)
+	}
+
+	available := endDate.Sub(startDate).Hours()
+	for _, room := range rooms {
+		room.AvailableHours = available
+		room.UtilizationPercent = roundTo2(room.BookedHours / available * 100)
+		room.BookedHours = roundTo2(room.BookedHours)
+	}
+	sort.SliceStable(rooms, func(i, j int) bool {
+		if rooms[i].UtilizationPercent != rooms[j].UtilizationPercent {
+			return rooms[i].UtilizationPercent > rooms[j].UtilizationPercent
+		}
+		return rooms[i].RoomNumber < rooms[j].RoomNumber
+	})
+	return rooms, nil
+}
+
+func roundTo2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
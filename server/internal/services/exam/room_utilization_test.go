@@ -0,0 +1,65 @@
+package exam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type utilizationRepo struct {
+	repository.ExamRepository
+	rooms    []*models.RoomUtilization
+	from, to time.Time
+}
+
+func (r *utilizationRepo) ListRoomBookings(ctx context.Context, collegeID int, from, to time.Time) ([]*models.RoomUtilization, error) {
+	r.from, r.to = from, to
+	return r.rooms, nil
+}
+
+func TestGetRoomUtilization(t *testing.T) {
+	start := time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 5) // 120 hours
+
+	repo := &utilizationRepo{rooms: []*models.RoomUtilization{
+		{RoomID: 1, RoomNumber: "B-2", ExamCount: 1, BookedHours: 3},
+		{RoomID: 2, RoomNumber: "A-1", ExamCount: 0},
+		{RoomID: 3, RoomNumber: "C-7", ExamCount: 4, BookedHours: 12},
+		{RoomID: 4, RoomNumber: "A-9", ExamCount: 1, BookedHours: 3},
+	}}
+	svc := &examService{repo: repo}
+
+	rooms, err := svc.GetRoomUtilization(context.Background(), 1, start, end)
+	require.NoError(t, err)
+	assert.Equal(t, start, repo.from)
+	assert.Equal(t, end, repo.to)
+
+	numbers := make([]string, len(rooms))
+	for i, room := range rooms {
+		numbers[i] = room.RoomNumber
+		assert.Equal(t, 120.0, room.AvailableHours)
+	}
+	// Busiest first; equally busy rooms by room number
+	assert.Equal(t, []string{"C-7", "A-9", "B-2", "A-1"}, numbers)
+	assert.Equal(t, 10.0, rooms[0].UtilizationPercent)
+	assert.Equal(t, 2.5, rooms[1].UtilizationPercent)
+	assert.Equal(t, 0.0, rooms[3].UtilizationPercent)
+
+	t.Run("rejects an empty or inverted window", func(t *testing.T) {
+		_, err := svc.GetRoomUtilization(context.Background(), 1, start, start)
+		assert.ErrorIs(t, err, ErrInvalidUtilizationWindow)
+		_, err = svc.GetRoomUtilization(context.Background(), 1, end, start)
+		assert.ErrorIs(t, err, ErrInvalidUtilizationWindow)
+	})
+
+	t.Run("rejects a window over a year", func(t *testing.T) {
+		_, err := svc.GetRoomUtilization(context.Background(), 1, start, start.AddDate(2, 0, 0))
+		assert.ErrorIs(t, err, ErrInvalidUtilizationWindow)
+	})
+}
@@ -0,0 +1,99 @@
+package exam
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+
+	"eduhub/server/internal/models"
+)
+
+// seatingStrategy is how an exam's seating scheme orders and numbers seats.
+// planSeats still decides the seating groups; the strategy orders students
+// within a group and names the series each seat is numbered in. Seats are
+// numbered from 1 within each series, so distinct series keep numbers unique.
+type seatingStrategy interface {
+	// compare orders two enrollments of the same seating group
+	compare(a, b *models.ExamEnrollment) int
+	// series is the prefix of the enrollment's seat number; prefix is the
+	// default series of its group, S for the main hall and X for a separate room
+	series(enrollment *models.ExamEnrollment, prefix string) string
+}
+
+// seatingStrategy returns the strategy for the exam's seating scheme, loading
+// roll numbers when the scheme sorts by them
+func (s *examService) seatingStrategy(ctx context.Context, exam *models.Exam) (seatingStrategy, error) {
+	switch exam.SeatingScheme {
+	case models.SeatingRoomPrefixed:
+		return roomPrefixedSeating{}, nil
+	case models.SeatingRollSorted:
+		rollNos, err := s.repo.ListEnrollmentRollNos(ctx, exam.CollegeID, exam.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load roll numbers for seating: %w", err)
+		}
+		return rollSortedSeating{rollNos: rollNos}, nil
+	default:
+		return sequentialSeating{}, nil
+	}
+}
+
+// sequentialSeating numbers seats in enrollment order: S001, S002, ...
+type sequentialSeating struct{}
+
+func (sequentialSeating) compare(a, b *models.ExamEnrollment) int {
+	return a.ID - b.ID
+}
+
+func (sequentialSeating) series(_ *models.ExamEnrollment, prefix string) string {
+	return prefix
+}
+
+// roomPrefixedSeating numbers each room on its own, prefixed with the room
+// number: A-001, A-002, B-001, ... Students without a room fall back to the
+// sequential series.
+type roomPrefixedSeating struct{}
+
+func (roomPrefixedSeating) compare(a, b *models.ExamEnrollment) int {
+	if c := cmp.Compare(seatRoom(a), seatRoom(b)); c != 0 {
+		return c
+	}
+	return a.ID - b.ID
+}
+
+func (roomPrefixedSeating) series(enrollment *models.ExamEnrollment, prefix string) string {
+	if room := seatRoom(enrollment); room != "" {
+		return room + "-"
+	}
+	return prefix
+}
+
+// rollSortedSeating numbers seats in roll number order within each group.
+// Roll numbers compare as text; students without one sit last.
+type rollSortedSeating struct {
+	rollNos map[int]string
+}
+
+func (r rollSortedSeating) compare(a, b *models.ExamEnrollment) int {
+	ra, rb := r.rollNos[a.StudentID], r.rollNos[b.StudentID]
+	if (ra == "") != (rb == "") {
+		if ra == "" {
+			return 1
+		}
+		return -1
+	}
+	if c := cmp.Compare(ra, rb); c != 0 {
+		return c
+	}
+	return a.ID - b.ID
+}
+
+func (rollSortedSeating) series(_ *models.ExamEnrollment, prefix string) string {
+	return prefix
+}
+
+func seatRoom(enrollment *models.ExamEnrollment) string {
+	if enrollment.RoomNumber == nil {
+		return ""
+	}
+	return *enrollment.RoomNumber
+}
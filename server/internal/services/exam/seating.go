@@ -0,0 +1,245 @@
+package exam
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"eduhub/server/internal/i18n"
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+)
+
+// ErrInvalidAccommodations is returned by SetAccommodations for out-of-range values
+var ErrInvalidAccommodations = errors.New("invalid exam accommodations")
+
+// ErrEnrollmentNotFound is returned when the student is not enrolled in the exam
+var ErrEnrollmentNotFound = repository.ErrEnrollmentNotFound
+
+const (
+	maxExtraTimeMinutes      = 240
+	maxAccommodationNotesLen = 500
+	separateRoomSeatPrefix   = "X"
+	mainHallSeatPrefix       = "S"
+)
+
+// SeatAllocationPreview is a proposed seat plan that has not been written.
+// Passing PlanID to ConfirmSeatAllocation applies exactly this plan, or fails
+// with ErrSeatPlanStale if the exam or its enrollments changed since.
+type SeatAllocationPreview struct {
+	ExamID            int                      `json:"exam_id"`
+	PlanID            string                   `json:"plan_id"`
+	EnrolledStudents  int                      `json:"enrolled_students"`
+	RoomCapacity      int                      `json:"room_capacity,omitempty"`
+	OverCapacity      bool                     `json:"over_capacity"`       // more students enrolled than the room seats
+	ChangedSeats      int                      `json:"changed_seats"`       // students who already had a different seat
+	IssuedHallTickets int                      `json:"issued_hall_tickets"` // of those, how many already hold a hall ticket
+	SeparateRoomSeats int                      `json:"separate_room_seats"`
+	UnplacedSeparate  int                      `json:"unplaced_separate_room"` // separate-room students with no room of their own yet
+	Seats             []*models.SeatAssignment `json:"seats"`
+}
+
+// AllocateSeatsPreview computes the seat plan AllocateSeats would write,
+// without writing it, and flags problems worth fixing first
+func (s *examService) AllocateSeatsPreview(ctx context.Context, collegeID, examID int) (*SeatAllocationPreview, error) {
+	exam, enrollments, strategy, err := s.loadSeating(ctx, collegeID, examID)
+	if err != nil {
+		return nil, err
+	}
+
+	seats := planSeats(exam, enrollments, strategy)
+	preview := &SeatAllocationPreview{
+		ExamID:           examID,
+		PlanID:           seatPlanID(exam, seats),
+		EnrolledStudents: len(enrollments),
+		Seats:            seats,
+	}
+
+	if exam.RoomID != nil {
+		room, err := s.repo.GetRoomByID(ctx, collegeID, *exam.RoomID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load exam room: %w", err)
+		}
+		preview.RoomCapacity = room.Capacity
+		preview.OverCapacity = len(enrollments) > room.Capacity
+	}
+
+	byID := make(map[int]*models.ExamEnrollment, len(enrollments))
+	for _, enrollment := range enrollments {
+		byID[enrollment.ID] = enrollment
+	}
+	sharedRooms := make(map[string]bool)
+	for _, enrollment := range enrollments {
+		if !enrollment.Accommodations.SeparateRoom && enrollment.RoomNumber != nil {
+			sharedRooms[*enrollment.RoomNumber] = true
+		}
+	}
+	for _, seat := range seats {
+		enrollment := byID[seat.EnrollmentID]
+		if enrollment.Accommodations.SeparateRoom {
+			preview.SeparateRoomSeats++
+			if seat.RoomNumber == nil || sharedRooms[*seat.RoomNumber] {
+				preview.UnplacedSeparate++
+			}
+		}
+		seat.PreviousSeatNumber = enrollment.SeatNumber
+		if enrollment.SeatNumber != nil && *enrollment.SeatNumber != seat.SeatNumber {
+			preview.ChangedSeats++
+			if enrollment.HallTicketGenerated {
+				preview.IssuedHallTickets++
+			}
+		}
+	}
+	return preview, nil
+}
+
+// ConfirmSeatAllocation writes the plan returned by AllocateSeatsPreview. The
+// plan is recomputed rather than taken from the client, and is only written
+// if it still matches planID.
+func (s *examService) ConfirmSeatAllocation(ctx context.Context, collegeID, examID int, planID string) error {
+	if planID == "" {
+		return fmt.Errorf("plan ID is required")
+	}
+
+	exam, enrollments, strategy, err := s.loadSeating(ctx, collegeID, examID)
+	if err != nil {
+		return err
+	}
+
+	seats := planSeats(exam, enrollments, strategy)
+	if seatPlanID(exam, seats) != planID {
+		return ErrSeatPlanStale
+	}
+	return s.repo.AssignSeats(ctx, collegeID, examID, seats)
+}
+
+// loadSeating fetches the exam, for its question paper sets, its enrollments
+// and the strategy for its seating scheme
+func (s *examService) loadSeating(ctx context.Context, collegeID, examID int) (*models.Exam, []*models.ExamEnrollment, seatingStrategy, error) {
+	exam, err := s.repo.GetExamByID(ctx, collegeID, examID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to fetch exam for seat allocation: %w", err)
+	}
+
+	enrollments, err := s.repo.ListEnrollments(ctx, examID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	strategy, err := s.seatingStrategy(ctx, exam)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return exam, enrollments, strategy, nil
+}
+
+// planSeats numbers the enrollments' seats as the strategy orders them, so
+// the same enrollments always get the same plan. Students with extra time sit
+// together after everyone else, so the rest can leave without disturbing them,
+// and students needing a separate room get their own X-numbered series. Rooms
+// already set on an enrollment are kept.
+func planSeats(exam *models.Exam, enrollments []*models.ExamEnrollment, strategy seatingStrategy) []*models.SeatAssignment {
+	ordered := slices.Clone(enrollments)
+	slices.SortFunc(ordered, func(a, b *models.ExamEnrollment) int {
+		if ga, gb := seatingGroup(a), seatingGroup(b); ga != gb {
+			return ga - gb
+		}
+		return strategy.compare(a, b)
+	})
+
+	seats := make([]*models.SeatAssignment, 0, len(ordered))
+	taken := make(map[string]int)
+	for i, enrollment := range ordered {
+		prefix := mainHallSeatPrefix
+		if enrollment.Accommodations.SeparateRoom {
+			prefix = separateRoomSeatPrefix
+		}
+		series := strategy.series(enrollment, prefix)
+		taken[series]++
+		seatNumber := fmt.Sprintf("%s%03d", series, taken[series])
+
+		seat := &models.SeatAssignment{
+			EnrollmentID: enrollment.ID,
+			StudentID:    enrollment.StudentID,
+			SeatNumber:   seatNumber,
+			RoomNumber:   enrollment.RoomNumber,
+		}
+
+		// Assign question paper set (cycle through available sets)
+		if exam.QuestionPaperSets > 0 {
+			set := (i % exam.QuestionPaperSets) + 1
+			seat.QuestionPaperSet = &set
+		}
+		seats = append(seats, seat)
+	}
+	return seats
+}
+
+// seatingGroup orders the main hall first, then extra-time students, then
+// students sitting in a separate room
+func seatingGroup(enrollment *models.ExamEnrollment) int {
+	switch {
+	case enrollment.Accommodations.SeparateRoom:
+		return 2
+	case enrollment.Accommodations.ExtraTimeMinutes > 0:
+		return 1
+	}
+	return 0
+}
+
+// SetAccommodations replaces a student's accommodations for an exam. Seats
+// are not moved until the next allocation.
+func (s *examService) SetAccommodations(ctx context.Context, collegeID, examID, studentID int, accommodations models.ExamAccommodations) error {
+	if collegeID == 0 || examID == 0 || studentID == 0 {
+		return errors.New("college ID, exam ID and student ID are required")
+	}
+	accommodations.Notes = strings.TrimSpace(accommodations.Notes)
+	if accommodations.ExtraTimeMinutes < 0 || accommodations.ExtraTimeMinutes > maxExtraTimeMinutes {
+		return fmt.Errorf("%w: extra time must be between 0 and %d minutes", ErrInvalidAccommodations, maxExtraTimeMinutes)
+	}
+	if len(accommodations.Notes) > maxAccommodationNotesLen {
+		return fmt.Errorf("%w: notes must be at most %d characters", ErrInvalidAccommodations, maxAccommodationNotesLen)
+	}
+	return s.repo.UpdateAccommodations(ctx, collegeID, examID, studentID, accommodations)
+}
+
+// accommodationLines describes accommodations for printing on a hall ticket.
+// Notes are printed as written.
+func accommodationLines(locale string, accommodations models.ExamAccommodations) []string {
+	var lines []string
+	if accommodations.ExtraTimeMinutes > 0 {
+		lines = append(lines, i18n.T(locale, "hall_ticket.extra_time", accommodations.ExtraTimeMinutes))
+	}
+	if accommodations.SeparateRoom {
+		lines = append(lines, i18n.T(locale, "hall_ticket.separate_room"))
+	}
+	if accommodations.Scribe {
+		lines = append(lines, i18n.T(locale, "hall_ticket.scribe"))
+	}
+	if accommodations.Notes != "" {
+		lines = append(lines, accommodations.Notes)
+	}
+	return lines
+}
+
+// seatPlanID fingerprints a plan together with the exam it was made for, so
+// any change to the enrollments, rooms or paper sets yields a different ID
+func seatPlanID(exam *models.Exam, seats []*models.SeatAssignment) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "exam:%d:%d\n", exam.ID, exam.QuestionPaperSets)
+	for _, seat := range seats {
+		room, set := "", 0
+		if seat.RoomNumber != nil {
+			room = *seat.RoomNumber
+		}
+		if seat.QuestionPaperSet != nil {
+			set = *seat.QuestionPaperSet
+		}
+		fmt.Fprintf(h, "%d:%d:%s:%q:%d\n", seat.EnrollmentID, seat.StudentID, seat.SeatNumber, room, set)
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
@@ -0,0 +1,335 @@
+package exam
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type previewRepo struct {
+	repository.ExamRepository
+	enrollments []*models.ExamEnrollment
+	assigned    []*models.SeatAssignment
+}
+
+func (r *previewRepo) GetExamByID(ctx context.Context, collegeID, examID int) (*models.Exam, error) {
+	roomID := 2
+	return &models.Exam{ID: examID, CollegeID: collegeID, QuestionPaperSets: 1, RoomID: &roomID}, nil
+}
+
+func (r *previewRepo) GetRoomByID(ctx context.Context, collegeID, roomID int) (*models.ExamRoom, error) {
+	return &models.ExamRoom{ID: roomID, Capacity: 1}, nil
+}
+
+func (r *previewRepo) ListEnrollments(ctx context.Context, examID int) ([]*models.ExamEnrollment, error) {
+	return r.enrollments, nil
+}
+
+func (r *previewRepo) AssignSeats(ctx context.Context, collegeID, examID int, seats []*models.SeatAssignment) error {
+	r.assigned = seats
+	return nil
+}
+
+func TestAllocateSeatsPreview(t *testing.T) {
+	oldSeat := "S001"
+	repo := &previewRepo{enrollments: []*models.ExamEnrollment{
+		{ID: 21, StudentID: 8, SeatNumber: &oldSeat, HallTicketGenerated: true},
+		{ID: 20, StudentID: 9},
+	}}
+	svc := &examService{repo: repo}
+	ctx := context.Background()
+
+	preview, err := svc.AllocateSeatsPreview(ctx, 1, 4)
+	require.NoError(t, err)
+
+	assert.Nil(t, repo.assigned, "preview must not write")
+	require.Len(t, preview.Seats, 2)
+	assert.Equal(t, 9, preview.Seats[0].StudentID)
+	assert.Equal(t, "S002", preview.Seats[1].SeatNumber)
+	assert.Equal(t, &oldSeat, preview.Seats[1].PreviousSeatNumber)
+	assert.Equal(t, 1, preview.ChangedSeats)
+	assert.Equal(t, 1, preview.IssuedHallTickets)
+	assert.True(t, preview.OverCapacity)
+	assert.NotEmpty(t, preview.PlanID)
+
+	t.Run("confirm applies the previewed plan", func(t *testing.T) {
+		require.NoError(t, svc.ConfirmSeatAllocation(ctx, 1, 4, preview.PlanID))
+		require.Len(t, repo.assigned, 2)
+		assert.Equal(t, "S001", repo.assigned[0].SeatNumber)
+		assert.Equal(t, 20, repo.assigned[0].EnrollmentID)
+	})
+
+	t.Run("confirm refuses a plan made before enrollments changed", func(t *testing.T) {
+		repo.assigned = nil
+		repo.enrollments = append(repo.enrollments, &models.ExamEnrollment{ID: 22, StudentID: 10})
+
+		err := svc.ConfirmSeatAllocation(ctx, 1, 4, preview.PlanID)
+		assert.ErrorIs(t, err, ErrSeatPlanStale)
+		assert.Nil(t, repo.assigned)
+	})
+}
+
+func TestPlanSeatsHonorsAccommodations(t *testing.T) {
+	ownRoom := "R2"
+	enrollments := []*models.ExamEnrollment{
+		{ID: 1, StudentID: 11, Accommodations: models.ExamAccommodations{SeparateRoom: true}, RoomNumber: &ownRoom},
+		{ID: 2, StudentID: 12, Accommodations: models.ExamAccommodations{ExtraTimeMinutes: 30}},
+		{ID: 3, StudentID: 13},
+		{ID: 4, StudentID: 14},
+	}
+
+	seats := planSeats(&models.Exam{ID: 4}, enrollments, sequentialSeating{})
+
+	require.Len(t, seats, 4)
+	assert.Equal(t, []int{13, 14, 12, 11}, []int{seats[0].StudentID, seats[1].StudentID, seats[2].StudentID, seats[3].StudentID})
+	assert.Equal(t, "S003", seats[2].SeatNumber, "extra-time students sit after the main hall")
+	assert.Equal(t, "X001", seats[3].SeatNumber)
+	assert.Equal(t, &ownRoom, seats[3].RoomNumber)
+}
+
+type accommodationRepo struct {
+	repository.ExamRepository
+	enrollment  *models.ExamEnrollment
+	saved       *models.ExamAccommodations
+	issue       models.HallTicketIssue
+	fingerprint string
+	language    string
+}
+
+func (r *accommodationRepo) GetEnrollment(ctx context.Context, examID, studentID int) (*models.ExamEnrollment, error) {
+	return r.enrollment, nil
+}
+
+func (r *accommodationRepo) GetExamByID(ctx context.Context, collegeID, examID int) (*models.Exam, error) {
+	start := time.Date(2026, 5, 4, 9, 0, 0, 0, time.UTC)
+	return &models.Exam{ID: examID, CollegeID: collegeID, Title: "Finals", StartTime: start, EndTime: start.Add(3 * time.Hour), Duration: 180}, nil
+}
+
+func (r *accommodationRepo) UpdateEnrollment(ctx context.Context, enrollment *models.ExamEnrollment) error {
+	return nil
+}
+
+// IssueHallTicket versions tickets by fingerprint like the repository does
+func (r *accommodationRepo) IssueHallTicket(ctx context.Context, enrollmentID int, fingerprint string) (*models.HallTicketIssue, error) {
+	if r.issue.Version == 0 || fingerprint != r.fingerprint {
+		at := time.Date(2026, 5, 1, 10, r.issue.Version, 0, 0, time.UTC)
+		r.issue = models.HallTicketIssue{Version: r.issue.Version + 1, GeneratedAt: &at}
+		r.fingerprint = fingerprint
+	}
+	issue := r.issue
+	return &issue, nil
+}
+
+func (r *accommodationRepo) GetStudentLanguage(ctx context.Context, collegeID, studentID int) (string, error) {
+	return r.language, nil
+}
+
+func (r *accommodationRepo) GetHallTicketIssue(ctx context.Context, collegeID, examID, studentID int) (*models.HallTicketIssue, error) {
+	issue := r.issue
+	return &issue, nil
+}
+
+func (r *accommodationRepo) UpdateAccommodations(ctx context.Context, collegeID, examID, studentID int, accommodations models.ExamAccommodations) error {
+	r.saved = &accommodations
+	return nil
+}
+
+type hallTicketUserRepo struct {
+	repository.UserRepository
+}
+
+func (hallTicketUserRepo) GetUserByID(ctx context.Context, userID int) (*models.User, error) {
+	return &models.User{ID: userID, Name: "Asha Rao"}, nil
+}
+
+func TestGenerateHallTicketShowsAccommodations(t *testing.T) {
+	repo := &accommodationRepo{enrollment: &models.ExamEnrollment{
+		ID: 9, ExamID: 4, StudentID: 7, CollegeID: 1,
+		Accommodations: models.ExamAccommodations{ExtraTimeMinutes: 45, Scribe: true, Notes: "Ground floor room"},
+	}}
+	svc := &examService{repo: repo, studentRepo: &inboxStudentRepo{}, userRepo: hallTicketUserRepo{}}
+
+	ticket, err := svc.GenerateHallTicket(context.Background(), 4, 7, "en")
+
+	require.NoError(t, err)
+	assert.Equal(t, 45, ticket.ExtraTimeMinutes)
+	assert.Equal(t, time.Date(2026, 5, 4, 12, 45, 0, 0, time.UTC), ticket.EndTime)
+	assert.Equal(t, []string{"Extra time: 45 minutes", "Scribe provided", "Ground floor room"}, ticket.Accommodations)
+}
+
+func TestGenerateHallTicketIncludesExtensions(t *testing.T) {
+	repo := &accommodationRepo{enrollment: &models.ExamEnrollment{
+		ID: 9, ExamID: 4, StudentID: 7, CollegeID: 1, ExtensionMinutes: 10,
+		Accommodations: models.ExamAccommodations{ExtraTimeMinutes: 45},
+	}}
+	svc := &examService{repo: repo, studentRepo: &inboxStudentRepo{}, userRepo: hallTicketUserRepo{}}
+
+	ticket, err := svc.GenerateHallTicket(context.Background(), 4, 7, "en")
+
+	require.NoError(t, err)
+	assert.Equal(t, 55, ticket.ExtraTimeMinutes)
+	assert.Equal(t, time.Date(2026, 5, 4, 12, 55, 0, 0, time.UTC), ticket.EndTime)
+}
+
+func TestGenerateHallTicketLocale(t *testing.T) {
+	repo := &accommodationRepo{
+		enrollment: &models.ExamEnrollment{
+			ID: 9, ExamID: 4, StudentID: 7, CollegeID: 1,
+			Accommodations: models.ExamAccommodations{ExtraTimeMinutes: 45, Notes: "Ground floor room"},
+		},
+		language: "hi",
+	}
+	svc := &examService{repo: repo, studentRepo: &inboxStudentRepo{}, userRepo: hallTicketUserRepo{}}
+	ctx := context.Background()
+
+	english, err := svc.GenerateHallTicket(ctx, 4, 7, "en")
+	require.NoError(t, err)
+	assert.Equal(t, "en", english.Locale)
+	assert.Equal(t, "Seat number", english.Labels["seat_number"])
+
+	own, err := svc.GenerateHallTicket(ctx, 4, 7, "")
+	require.NoError(t, err)
+	assert.Equal(t, "hi", own.Locale, "no locale uses the student's language")
+	assert.Equal(t, "सीट संख्या", own.Labels["seat_number"])
+	assert.Equal(t, []string{"अतिरिक्त समय: 45 मिनट", "Ground floor room"}, own.Accommodations)
+	assert.Equal(t, english.Version, own.Version, "the language does not issue a new version")
+
+	unsupported, err := svc.GenerateHallTicket(ctx, 4, 7, "fr")
+	require.NoError(t, err)
+	assert.Equal(t, "en", unsupported.Locale)
+	assert.Equal(t, english.Labels, unsupported.Labels)
+}
+
+func TestGenerateHallTicketVersions(t *testing.T) {
+	seat := "S001"
+	repo := &accommodationRepo{enrollment: &models.ExamEnrollment{
+		ID: 9, ExamID: 4, StudentID: 7, CollegeID: 1, SeatNumber: &seat,
+	}}
+	svc := &examService{repo: repo, studentRepo: &inboxStudentRepo{}, userRepo: hallTicketUserRepo{}}
+	ctx := context.Background()
+
+	first, err := svc.GenerateHallTicket(ctx, 4, 7, "en")
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.Version)
+	assert.False(t, first.GeneratedAt.IsZero())
+
+	again, err := svc.GenerateHallTicket(ctx, 4, 7, "en")
+	require.NoError(t, err)
+	assert.Equal(t, 1, again.Version, "an unchanged ticket keeps its version")
+	assert.Equal(t, first.GeneratedAt, again.GeneratedAt)
+
+	moved := "S002"
+	repo.enrollment.SeatNumber = &moved
+	second, err := svc.GenerateHallTicket(ctx, 4, 7, "en")
+	require.NoError(t, err)
+	assert.Equal(t, 2, second.Version, "a seat change issues a new version")
+	assert.True(t, second.GeneratedAt.After(first.GeneratedAt))
+
+	stale, err := svc.VerifyHallTicket(ctx, 1, 4, 7, 1)
+	require.NoError(t, err)
+	assert.False(t, stale.Valid)
+	assert.Equal(t, 2, stale.CurrentVersion)
+
+	current, err := svc.VerifyHallTicket(ctx, 1, 4, 7, 2)
+	require.NoError(t, err)
+	assert.True(t, current.Valid)
+}
+
+func TestSetAccommodations(t *testing.T) {
+	repo := &accommodationRepo{}
+	svc := &examService{repo: repo}
+	ctx := context.Background()
+
+	err := svc.SetAccommodations(ctx, 1, 4, 7, models.ExamAccommodations{ExtraTimeMinutes: 300})
+	assert.ErrorIs(t, err, ErrInvalidAccommodations)
+	assert.Nil(t, repo.saved)
+
+	require.NoError(t, svc.SetAccommodations(ctx, 1, 4, 7, models.ExamAccommodations{SeparateRoom: true, Notes: "  reader needed "}))
+	assert.Equal(t, "reader needed", repo.saved.Notes)
+}
+
+func TestPlanSeatsBySeatingScheme(t *testing.T) {
+	roomA, roomB := "A", "B"
+	enrollments := []*models.ExamEnrollment{
+		{ID: 1, StudentID: 11, RoomNumber: &roomB},
+		{ID: 2, StudentID: 12, RoomNumber: &roomA},
+		{ID: 3, StudentID: 13},
+		{ID: 4, StudentID: 14, RoomNumber: &roomA},
+		{ID: 5, StudentID: 15, RoomNumber: &roomB, Accommodations: models.ExamAccommodations{SeparateRoom: true}},
+	}
+
+	tests := []struct {
+		name     string
+		strategy seatingStrategy
+		students []int
+		seats    []string
+	}{
+		{
+			name:     "sequential",
+			strategy: sequentialSeating{},
+			students: []int{11, 12, 13, 14, 15},
+			seats:    []string{"S001", "S002", "S003", "S004", "X001"},
+		},
+		{
+			name:     "room prefixed",
+			strategy: roomPrefixedSeating{},
+			students: []int{13, 12, 14, 11, 15},
+			seats:    []string{"S001", "A-001", "A-002", "B-001", "B-002"},
+		},
+		{
+			name:     "roll sorted",
+			strategy: rollSortedSeating{rollNos: map[int]string{11: "CS-07", 12: "CS-03", 14: "CS-01", 15: "CS-02"}},
+			students: []int{14, 12, 11, 13, 15},
+			seats:    []string{"S001", "S002", "S003", "S004", "X001"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seats := planSeats(&models.Exam{ID: 4}, enrollments, tt.strategy)
+
+			require.Len(t, seats, len(enrollments))
+			taken := map[string]bool{}
+			for i, seat := range seats {
+				assert.Regexp(t, `^([SX]|[AB]-)\d{3}$`, seat.SeatNumber)
+				assert.False(t, taken[seat.SeatNumber], "seat %s assigned twice", seat.SeatNumber)
+				taken[seat.SeatNumber] = true
+				assert.Equal(t, tt.students[i], seat.StudentID)
+				assert.Equal(t, tt.seats[i], seat.SeatNumber)
+			}
+		})
+	}
+}
+
+type rollSeatingRepo struct {
+	previewRepo
+	rollNos map[int]string
+}
+
+func (r *rollSeatingRepo) GetExamByID(ctx context.Context, collegeID, examID int) (*models.Exam, error) {
+	return &models.Exam{ID: examID, CollegeID: collegeID, QuestionPaperSets: 1, SeatingScheme: models.SeatingRollSorted}, nil
+}
+
+func (r *rollSeatingRepo) ListEnrollmentRollNos(ctx context.Context, collegeID, examID int) (map[int]string, error) {
+	return r.rollNos, nil
+}
+
+func TestAllocateSeatsUsesExamSeatingScheme(t *testing.T) {
+	repo := &rollSeatingRepo{
+		previewRepo: previewRepo{enrollments: []*models.ExamEnrollment{{ID: 1, StudentID: 8}, {ID: 2, StudentID: 9}}},
+		rollNos:     map[int]string{8: "EE-20", 9: "EE-04"},
+	}
+	svc := &examService{repo: repo}
+
+	require.NoError(t, svc.AllocateSeats(context.Background(), 1, 4))
+
+	require.Len(t, repo.assigned, 2)
+	assert.Equal(t, 9, repo.assigned[0].StudentID)
+	assert.Equal(t, "S001", repo.assigned[0].SeatNumber)
+	assert.Equal(t, 8, repo.assigned[1].StudentID)
+}
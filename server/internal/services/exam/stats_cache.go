@@ -0,0 +1,49 @@
+package exam
+
+import (
+	"context"
+	"errors"
+
+	"eduhub/server/internal/cache"
+)
+
+// examStatsTTL bounds how long cached stats can outlive a write that did not
+// go through this service
+const examStatsTTL = cache.TTLShort
+
+// cachedExamStats returns the exam's cached stats, or nil on a miss or when
+// caching is disabled. A failing cache is logged and treated as a miss.
+func (s *examService) cachedExamStats(ctx context.Context, examID int) *ExamStats {
+	if s.statsCache == nil {
+		return nil
+	}
+	var stats ExamStats
+	if err := s.statsCache.Get(ctx, cache.BuildExamStatsKey(examID), &stats); err != nil {
+		if !errors.Is(err, cache.ErrKeyNotFound) {
+			s.logger.Warn().Err(err).Int("exam_id", examID).Msg("failed to read cached exam stats")
+		}
+		return nil
+	}
+	return &stats
+}
+
+func (s *examService) cacheExamStats(ctx context.Context, examID int, stats *ExamStats) {
+	if s.statsCache == nil {
+		return
+	}
+	if err := s.statsCache.Set(ctx, cache.BuildExamStatsKey(examID), stats, examStatsTTL); err != nil {
+		s.logger.Warn().Err(err).Int("exam_id", examID).Msg("failed to cache exam stats")
+	}
+}
+
+// invalidateExamStats drops the exam's cached stats. Every write to an exam's
+// marks, enrollments or results calls it once the write succeeds; if it
+// fails the entry still expires within examStatsTTL.
+func (s *examService) invalidateExamStats(ctx context.Context, examID int) {
+	if s.statsCache == nil {
+		return
+	}
+	if err := s.statsCache.Delete(ctx, cache.BuildExamStatsKey(examID)); err != nil {
+		s.logger.Warn().Err(err).Int("exam_id", examID).Msg("failed to invalidate cached exam stats")
+	}
+}
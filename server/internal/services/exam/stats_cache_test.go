@@ -0,0 +1,100 @@
+package exam
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/cache"
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCache stores JSON like RedisCache does, so cached values round-trip
+// the same way
+type memoryCache struct {
+	cache.Cache
+	entries map[string][]byte
+}
+
+func (c *memoryCache) Get(ctx context.Context, key string, dest any) error {
+	data, ok := c.entries[key]
+	if !ok {
+		return cache.ErrKeyNotFound
+	}
+	return json.Unmarshal(data, dest)
+}
+
+func (c *memoryCache) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	c.entries[key] = data
+	return nil
+}
+
+func (c *memoryCache) Delete(ctx context.Context, key string) error {
+	delete(c.entries, key)
+	return nil
+}
+
+type cachedStatsRepo struct {
+	repository.ExamRepository
+	results []*models.ExamResult
+	loads   int
+}
+
+func (r *cachedStatsRepo) GetExamByID(ctx context.Context, collegeID, examID int) (*models.Exam, error) {
+	if collegeID != 1 {
+		return nil, repository.ErrExamNotFound
+	}
+	return &models.Exam{ID: examID, CollegeID: collegeID, TotalMarks: 100, PassingMarks: 40}, nil
+}
+
+func (r *cachedStatsRepo) ListEnrollments(ctx context.Context, examID int) ([]*models.ExamEnrollment, error) {
+	r.loads++
+	return []*models.ExamEnrollment{{ID: 1, Status: "appeared"}, {ID: 2, Status: "appeared"}}, nil
+}
+
+func (r *cachedStatsRepo) ListResults(ctx context.Context, examID int) ([]*models.ExamResult, error) {
+	return r.results, nil
+}
+
+func (r *cachedStatsRepo) DeleteEnrollment(ctx context.Context, examID, studentID int) error {
+	return nil
+}
+
+func TestGetExamStatsCache(t *testing.T) {
+	marks := 80.0
+	repo := &cachedStatsRepo{results: []*models.ExamResult{{StudentID: 5, MarksObtained: &marks}}}
+	statsCache := &memoryCache{entries: map[string][]byte{}}
+	svc := &examService{repo: repo, statsCache: statsCache}
+	ctx := context.Background()
+
+	first, err := svc.GetExamStats(ctx, 1, 4)
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.ResultsPublished)
+	assert.Contains(t, statsCache.entries, cache.BuildExamStatsKey(4))
+
+	cached, err := svc.GetExamStats(ctx, 1, 4)
+	require.NoError(t, err)
+	assert.Equal(t, first, cached)
+	assert.Equal(t, 1, repo.loads, "a cache hit must not reload enrollments")
+
+	_, err = svc.GetExamStats(ctx, 2, 4)
+	assert.ErrorIs(t, err, ErrExamNotFound, "another college never sees the cached stats")
+
+	require.NoError(t, svc.DeleteEnrollment(ctx, 4, 5))
+	assert.NotContains(t, statsCache.entries, cache.BuildExamStatsKey(4))
+
+	repo.results = nil
+	fresh, err := svc.GetExamStats(ctx, 1, 4)
+	require.NoError(t, err)
+	assert.Zero(t, fresh.ResultsPublished)
+	assert.Equal(t, 2, repo.loads)
+}
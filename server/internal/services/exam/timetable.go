@@ -0,0 +1,411 @@
+package exam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+)
+
+// ErrTimetableInfeasible is returned when the courses cannot all be placed in
+// the given slots and rooms without a student or room clash
+var ErrTimetableInfeasible = errors.New("no conflict-free timetable fits the given dates, slots and rooms")
+
+// ErrRoomBooked is returned by ConfirmTimetable when a room was booked by
+// another exam between planning and saving the timetable
+var ErrRoomBooked = repository.ErrRoomBooked
+
+const (
+	timetableDateLayout = "2006-01-02"
+	timetableTimeLayout = "15:04"
+	maxTimetableDays    = 60
+	// maxTimetableSteps bounds the backtracking search so a hopeless request
+	// fails quickly instead of exploring every permutation
+	maxTimetableSteps = 200000
+)
+
+// TimetableSlot is a daily exam window, e.g. {"start": "09:00", "end": "12:00"}.
+// Times are interpreted in UTC.
+type TimetableSlot struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// TimetableRequest describes the exam week to schedule
+type TimetableRequest struct {
+	CourseIDs    []int           `json:"course_ids"`
+	StartDate    string          `json:"start_date"` // YYYY-MM-DD
+	EndDate      string          `json:"end_date"`   // YYYY-MM-DD, inclusive
+	RoomIDs      []int           `json:"room_ids"`
+	Slots        []TimetableSlot `json:"slots"`
+	SkipWeekends bool            `json:"skip_weekends"`
+	ExamType     string          `json:"exam_type"`     // default: final
+	TotalMarks   float64         `json:"total_marks"`   // default: 100
+	PassingMarks float64         `json:"passing_marks"` // default: 40
+}
+
+// ProposedExam is one course placed in a slot and room
+type ProposedExam struct {
+	CourseID     int       `json:"course_id"`
+	CourseName   string    `json:"course_name"`
+	RoomID       int       `json:"room_id"`
+	RoomNumber   string    `json:"room_number"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+	StudentCount int       `json:"student_count"`
+}
+
+// TimetableProposal is the result of a dry run
+type TimetableProposal struct {
+	Exams []ProposedExam `json:"exams"`
+}
+
+type timetableWindow struct {
+	start, end time.Time
+}
+
+// GenerateTimetable proposes a conflict-free timetable without saving it. No
+// student sits two exams in the same slot, no room is used twice in a slot or
+// while already booked by another exam, and every room fits its course.
+func (s *examService) GenerateTimetable(ctx context.Context, collegeID int, req *TimetableRequest) (*TimetableProposal, error) {
+	if collegeID == 0 {
+		return nil, errors.New("college ID is required")
+	}
+	windows, err := req.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	courses, err := s.repo.ListTimetableCourses(ctx, collegeID, req.CourseIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load courses: %w", err)
+	}
+	if len(courses) != len(req.CourseIDs) {
+		return nil, errors.New("one or more courses were not found")
+	}
+
+	rooms := make([]*models.ExamRoom, 0, len(req.RoomIDs))
+	for _, roomID := range req.RoomIDs {
+		room, err := s.repo.GetRoomByID(ctx, collegeID, roomID)
+		if err != nil {
+			return nil, fmt.Errorf("room %d not found", roomID)
+		}
+		if !room.IsActive {
+			return nil, fmt.Errorf("room %s is not active", room.RoomNumber)
+		}
+		rooms = append(rooms, room)
+	}
+	// Try the smallest room that fits first to keep large halls free
+	sort.SliceStable(rooms, func(i, j int) bool { return rooms[i].Capacity < rooms[j].Capacity })
+
+	// Rooms already booked by existing exams are unavailable in that window
+	roomFree := make([][]bool, len(windows))
+	for wi, w := range windows {
+		roomFree[wi] = make([]bool, len(rooms))
+		for ri, room := range rooms {
+			available, err := s.repo.CheckRoomAvailability(ctx, room.ID, w.start.Format(time.RFC3339), w.end.Format(time.RFC3339))
+			if err != nil {
+				return nil, fmt.Errorf("failed to check room availability: %w", err)
+			}
+			roomFree[wi][ri] = available
+		}
+	}
+
+	// Students already sitting another exam in a window cannot take one there
+	busy, err := s.busyStudents(ctx, collegeID, courses, windows)
+	if err != nil {
+		return nil, err
+	}
+
+	search := newTimetableSearch(courses, windows, rooms, roomFree, busy)
+	if !search.place(0) {
+		return nil, ErrTimetableInfeasible
+	}
+	return search.proposal(), nil
+}
+
+// busyStudents returns, per window, the students of the courses who already
+// sit an existing exam overlapping it
+func (s *examService) busyStudents(ctx context.Context, collegeID int, courses []*models.TimetableCourse, windows []timetableWindow) ([]map[int]bool, error) {
+	var studentIDs []int
+	for _, course := range courses {
+		studentIDs = append(studentIDs, course.StudentIDs...)
+	}
+	busy := make([]map[int]bool, len(windows))
+	for wi := range busy {
+		busy[wi] = make(map[int]bool)
+	}
+	if len(studentIDs) == 0 {
+		return busy, nil
+	}
+
+	existing, err := s.repo.ListStudentExamWindows(ctx, collegeID, studentIDs, windows[0].start, windows[len(windows)-1].end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load students' exams: %w", err)
+	}
+	for _, e := range existing {
+		for wi, w := range windows {
+			if e.StartTime.Before(w.end) && w.start.Before(e.EndTime) {
+				busy[wi][e.StudentID] = true
+			}
+		}
+	}
+	return busy, nil
+}
+
+// ConfirmTimetable regenerates the timetable from the same request and creates
+// all of its exams in one transaction. Regenerating rather than trusting a
+// client-supplied proposal means bookings made since the dry run are honoured;
+// CreateExams re-checks the rooms inside the transaction and fails with
+// ErrRoomBooked if one was taken in the meantime.
+func (s *examService) ConfirmTimetable(ctx context.Context, collegeID, createdBy int, req *TimetableRequest) ([]*models.Exam, error) {
+	proposal, err := s.GenerateTimetable(ctx, collegeID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	exams := make([]*models.Exam, 0, len(proposal.Exams))
+	for _, p := range proposal.Exams {
+		roomID := p.RoomID
+		exam := &models.Exam{
+			CollegeID:         collegeID,
+			CourseID:          p.CourseID,
+			Title:             fmt.Sprintf("%s %s exam", p.CourseName, req.ExamType),
+			ExamType:          req.ExamType,
+			StartTime:         p.StartTime,
+			EndTime:           p.EndTime,
+			Duration:          int(p.EndTime.Sub(p.StartTime).Minutes()),
+			TotalMarks:        req.TotalMarks,
+			PassingMarks:      req.PassingMarks,
+			RoomID:            &roomID,
+			Status:            "scheduled",
+			QuestionPaperSets: 1,
+			CreatedBy:         createdBy,
+		}
+		if err := validateNewExam(exam); err != nil {
+			return nil, err
+		}
+		exams = append(exams, exam)
+	}
+
+	if err := s.repo.CreateExams(ctx, exams); err != nil {
+		if errors.Is(err, repository.ErrRoomBooked) {
+			return nil, ErrRoomBooked
+		}
+		return nil, err
+	}
+	return exams, nil
+}
+
+// normalize validates the request, fills in defaults and expands the date
+// range and daily slots into concrete windows in chronological order
+func (req *TimetableRequest) normalize() ([]timetableWindow, error) {
+	if req == nil || len(req.CourseIDs) == 0 {
+		return nil, errors.New("at least one course is required")
+	}
+	seen := make(map[int]bool, len(req.CourseIDs))
+	for _, id := range req.CourseIDs {
+		if id <= 0 || seen[id] {
+			return nil, errors.New("course IDs must be positive and unique")
+		}
+		seen[id] = true
+	}
+	if len(req.RoomIDs) == 0 {
+		return nil, errors.New("at least one room is required")
+	}
+	seenRooms := make(map[int]bool, len(req.RoomIDs))
+	for _, id := range req.RoomIDs {
+		if id <= 0 || seenRooms[id] {
+			return nil, errors.New("room IDs must be positive and unique")
+		}
+		seenRooms[id] = true
+	}
+	if len(req.Slots) == 0 {
+		return nil, errors.New("at least one daily slot is required")
+	}
+
+	if req.ExamType == "" {
+		req.ExamType = "final"
+	}
+	switch req.ExamType {
+	case "midterm", "final", "quiz", "practical":
+	default:
+		return nil, fmt.Errorf("invalid exam type %q", req.ExamType)
+	}
+	if req.TotalMarks == 0 {
+		req.TotalMarks = 100
+	}
+	if req.PassingMarks == 0 {
+		req.PassingMarks = 40
+	}
+
+	startDate, err := time.Parse(timetableDateLayout, req.StartDate)
+	if err != nil {
+		return nil, errors.New("start_date must be YYYY-MM-DD")
+	}
+	endDate, err := time.Parse(timetableDateLayout, req.EndDate)
+	if err != nil {
+		return nil, errors.New("end_date must be YYYY-MM-DD")
+	}
+	if endDate.Before(startDate) {
+		return nil, errors.New("end_date must not be before start_date")
+	}
+	if endDate.Sub(startDate) > maxTimetableDays*24*time.Hour {
+		return nil, fmt.Errorf("date range cannot exceed %d days", maxTimetableDays)
+	}
+
+	type offset struct{ start, end time.Duration }
+	offsets := make([]offset, 0, len(req.Slots))
+	for _, slot := range req.Slots {
+		start, err := time.Parse(timetableTimeLayout, slot.Start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid slot start %q, expected HH:MM", slot.Start)
+		}
+		end, err := time.Parse(timetableTimeLayout, slot.End)
+		if err != nil {
+			return nil, fmt.Errorf("invalid slot end %q, expected HH:MM", slot.End)
+		}
+		if !end.After(start) {
+			return nil, fmt.Errorf("slot %s-%s must end after it starts", slot.Start, slot.End)
+		}
+		midnight := time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC)
+		offsets = append(offsets, offset{start.Sub(midnight), end.Sub(midnight)})
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i].start < offsets[j].start })
+	for i := 1; i < len(offsets); i++ {
+		if offsets[i].start < offsets[i-1].end {
+			return nil, errors.New("daily slots must not overlap")
+		}
+	}
+
+	var windows []timetableWindow
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		if req.SkipWeekends && (day.Weekday() == time.Saturday || day.Weekday() == time.Sunday) {
+			continue
+		}
+		for _, o := range offsets {
+			windows = append(windows, timetableWindow{start: day.Add(o.start), end: day.Add(o.end)})
+		}
+	}
+	if len(windows) == 0 {
+		return nil, errors.New("the date range has no schedulable days")
+	}
+	return windows, nil
+}
+
+// timetableSearch places courses into (window, room) pairs by depth-first
+// search, undoing the latest placement when a later course cannot fit
+type timetableSearch struct {
+	courses  []*models.TimetableCourse
+	windows  []timetableWindow
+	rooms    []*models.ExamRoom
+	roomFree [][]bool
+
+	// sitting[w] counts, per student, exams in window w: existing ones plus
+	// those placed by the search
+	sitting   []map[int]int
+	placement []struct{ window, room int }
+	steps     int
+}
+
+func newTimetableSearch(courses []*models.TimetableCourse, windows []timetableWindow, rooms []*models.ExamRoom, roomFree [][]bool, busy []map[int]bool) *timetableSearch {
+	// Hardest courses first: the largest rosters have the fewest options
+	ordered := append([]*models.TimetableCourse(nil), courses...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return len(ordered[i].StudentIDs) > len(ordered[j].StudentIDs)
+	})
+
+	sitting := make([]map[int]int, len(windows))
+	for i := range sitting {
+		sitting[i] = make(map[int]int)
+		for studentID := range busy[i] {
+			sitting[i][studentID] = 1
+		}
+	}
+	return &timetableSearch{
+		courses:   ordered,
+		windows:   windows,
+		rooms:     rooms,
+		roomFree:  roomFree,
+		sitting:   sitting,
+		placement: make([]struct{ window, room int }, len(ordered)),
+	}
+}
+
+func (t *timetableSearch) place(i int) bool {
+	if i == len(t.courses) {
+		return true
+	}
+	t.steps++
+	if t.steps > maxTimetableSteps {
+		return false
+	}
+
+	course := t.courses[i]
+	for wi := range t.windows {
+		if t.clashes(wi, course) {
+			continue
+		}
+		for ri, room := range t.rooms {
+			if !t.roomFree[wi][ri] || room.Capacity < len(course.StudentIDs) {
+				continue
+			}
+			t.assign(i, wi, ri, true)
+			if t.place(i + 1) {
+				return true
+			}
+			t.assign(i, wi, ri, false)
+		}
+	}
+	return false
+}
+
+func (t *timetableSearch) clashes(window int, course *models.TimetableCourse) bool {
+	for _, studentID := range course.StudentIDs {
+		if t.sitting[window][studentID] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// assign places course i in (window, room), or undoes that placement
+func (t *timetableSearch) assign(i, window, room int, on bool) {
+	delta := 1
+	if !on {
+		delta = -1
+	}
+	t.roomFree[window][room] = !on
+	for _, studentID := range t.courses[i].StudentIDs {
+		t.sitting[window][studentID] += delta
+	}
+	t.placement[i] = struct{ window, room int }{window, room}
+}
+
+func (t *timetableSearch) proposal() *TimetableProposal {
+	exams := make([]ProposedExam, 0, len(t.courses))
+	for i, course := range t.courses {
+		p := t.placement[i]
+		room := t.rooms[p.room]
+		exams = append(exams, ProposedExam{
+			CourseID:     course.CourseID,
+			CourseName:   course.Name,
+			RoomID:       room.ID,
+			RoomNumber:   room.RoomNumber,
+			StartTime:    t.windows[p.window].start,
+			EndTime:      t.windows[p.window].end,
+			StudentCount: len(course.StudentIDs),
+		})
+	}
+	sort.SliceStable(exams, func(i, j int) bool {
+		if !exams[i].StartTime.Equal(exams[j].StartTime) {
+			return exams[i].StartTime.Before(exams[j].StartTime)
+		}
+		return exams[i].CourseID < exams[j].CourseID
+	})
+	return &TimetableProposal{Exams: exams}
+}
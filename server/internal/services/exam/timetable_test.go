@@ -0,0 +1,194 @@
+package exam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type timetableRepo struct {
+	repository.ExamRepository
+	courses []*models.TimetableCourse
+	rooms   map[int]*models.ExamRoom
+	booked  map[string]bool // "roomID@start" already taken by another exam
+	sitting []*models.StudentExamWindow
+	created []*models.Exam
+	err     error
+}
+
+func (r *timetableRepo) ListTimetableCourses(ctx context.Context, collegeID int, courseIDs []int) ([]*models.TimetableCourse, error) {
+	return r.courses, nil
+}
+
+func (r *timetableRepo) GetRoomByID(ctx context.Context, collegeID, roomID int) (*models.ExamRoom, error) {
+	room, ok := r.rooms[roomID]
+	if !ok {
+		return nil, errors.New("room not found")
+	}
+	return room, nil
+}
+
+func (r *timetableRepo) CheckRoomAvailability(ctx context.Context, roomID int, startTime, endTime string) (bool, error) {
+	return !r.booked[roomKey(roomID, startTime)], nil
+}
+
+func (r *timetableRepo) ListStudentExamWindows(ctx context.Context, collegeID int, studentIDs []int, from, to time.Time) ([]*models.StudentExamWindow, error) {
+	return r.sitting, nil
+}
+
+func (r *timetableRepo) CreateExams(ctx context.Context, exams []*models.Exam) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.created = exams
+	return nil
+}
+
+func roomKey(roomID int, start string) string {
+	return string(rune('0'+roomID)) + "@" + start
+}
+
+func newTimetableRepo() *timetableRepo {
+	return &timetableRepo{
+		courses: []*models.TimetableCourse{
+			{CourseID: 1, Name: "Physics", StudentIDs: []int{10, 11, 12}},
+			{CourseID: 2, Name: "Chemistry", StudentIDs: []int{12, 13}}, // shares student 12 with Physics
+			{CourseID: 3, Name: "History", StudentIDs: []int{20}},
+		},
+		rooms: map[int]*models.ExamRoom{
+			1: {ID: 1, RoomNumber: "A1", Capacity: 5, IsActive: true},
+			2: {ID: 2, RoomNumber: "B1", Capacity: 2, IsActive: true},
+		},
+		booked: map[string]bool{},
+	}
+}
+
+func timetableRequest() *TimetableRequest {
+	return &TimetableRequest{
+		CourseIDs: []int{1, 2, 3},
+		StartDate: "2026-11-02",
+		EndDate:   "2026-11-02",
+		RoomIDs:   []int{1, 2},
+		Slots:     []TimetableSlot{{Start: "09:00", End: "12:00"}, {Start: "14:00", End: "17:00"}},
+	}
+}
+
+func TestGenerateTimetable(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no student or room clashes", func(t *testing.T) {
+		svc := &examService{repo: newTimetableRepo()}
+
+		proposal, err := svc.GenerateTimetable(ctx, 1, timetableRequest())
+		require.NoError(t, err)
+		require.Len(t, proposal.Exams, 3)
+
+		byCourse := map[int]ProposedExam{}
+		usedRooms := map[string]bool{}
+		for _, e := range proposal.Exams {
+			byCourse[e.CourseID] = e
+			key := roomKey(e.RoomID, e.StartTime.String())
+			assert.False(t, usedRooms[key], "room %s double booked", e.RoomNumber)
+			usedRooms[key] = true
+		}
+		assert.False(t, byCourse[1].StartTime.Equal(byCourse[2].StartTime), "shared student sits two exams at once")
+		assert.Equal(t, 1, byCourse[1].RoomID, "Physics needs the larger room")
+	})
+
+	t.Run("existing bookings are respected", func(t *testing.T) {
+		repo := newTimetableRepo()
+		repo.booked[roomKey(1, "2026-11-02T09:00:00Z")] = true
+		svc := &examService{repo: repo}
+
+		proposal, err := svc.GenerateTimetable(ctx, 1, timetableRequest())
+		require.NoError(t, err)
+		for _, e := range proposal.Exams {
+			if e.RoomID == 1 {
+				assert.Equal(t, 14, e.StartTime.Hour())
+			}
+		}
+	})
+
+	t.Run("infeasible with a single slot", func(t *testing.T) {
+		req := timetableRequest()
+		req.Slots = req.Slots[:1]
+		svc := &examService{repo: newTimetableRepo()}
+
+		_, err := svc.GenerateTimetable(ctx, 1, req)
+		assert.ErrorIs(t, err, ErrTimetableInfeasible)
+	})
+
+	t.Run("students' existing exams are respected", func(t *testing.T) {
+		repo := newTimetableRepo()
+		// History's only student already sits an exam in the morning
+		repo.sitting = []*models.StudentExamWindow{{
+			StudentID: 20,
+			StartTime: time.Date(2026, 11, 2, 10, 0, 0, 0, time.UTC),
+			EndTime:   time.Date(2026, 11, 2, 11, 0, 0, 0, time.UTC),
+		}}
+		svc := &examService{repo: repo}
+
+		proposal, err := svc.GenerateTimetable(ctx, 1, timetableRequest())
+		require.NoError(t, err)
+		for _, e := range proposal.Exams {
+			if e.CourseID == 3 {
+				assert.Equal(t, 14, e.StartTime.Hour())
+			}
+		}
+	})
+
+	t.Run("repeated rooms are rejected", func(t *testing.T) {
+		req := timetableRequest()
+		req.RoomIDs = []int{1, 2, 1}
+		svc := &examService{repo: newTimetableRepo()}
+
+		_, err := svc.GenerateTimetable(ctx, 1, req)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrTimetableInfeasible)
+	})
+
+	t.Run("overlapping slots are rejected", func(t *testing.T) {
+		req := timetableRequest()
+		req.Slots = []TimetableSlot{{Start: "09:00", End: "12:00"}, {Start: "11:00", End: "13:00"}}
+		svc := &examService{repo: newTimetableRepo()}
+
+		_, err := svc.GenerateTimetable(ctx, 1, req)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrTimetableInfeasible)
+	})
+}
+
+func TestConfirmTimetableCreatesExams(t *testing.T) {
+	repo := newTimetableRepo()
+	svc := &examService{repo: repo}
+
+	exams, err := svc.ConfirmTimetable(context.Background(), 1, 7, timetableRequest())
+	require.NoError(t, err)
+	require.Len(t, repo.created, 3)
+	assert.Equal(t, exams, repo.created)
+
+	for _, e := range exams {
+		assert.Equal(t, "final", e.ExamType)
+		assert.Equal(t, 180, e.Duration)
+		assert.Equal(t, 100.0, e.TotalMarks)
+		assert.Equal(t, 7, e.CreatedBy)
+		require.NotNil(t, e.RoomID)
+	}
+}
+
+func TestConfirmTimetableRoomBookedMeanwhile(t *testing.T) {
+	repo := newTimetableRepo()
+	repo.err = fmt.Errorf("%w: room 1", repository.ErrRoomBooked)
+	svc := &examService{repo: repo}
+
+	_, err := svc.ConfirmTimetable(context.Background(), 1, 7, timetableRequest())
+	assert.ErrorIs(t, err, ErrRoomBooked)
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
@@ -24,10 +25,11 @@ type FeeService interface {
 	ListFeeStructures(ctx context.Context, filter models.FeeFilter) ([]*models.FeeStructure, error)
 
 	// Fee Assignment management
-	AssignFeeToStudent(ctx context.Context, req *models.AssignFeeRequest) error
-	BulkAssignFeeToStudents(ctx context.Context, req *models.BulkAssignFeeRequest) error
+	AssignFeeToStudent(ctx context.Context, req *models.AssignFeeRequest, collegeID int) error
+	BulkAssignFeeToStudents(ctx context.Context, req *models.BulkAssignFeeRequest, collegeID int) error
 	GetStudentFeeAssignments(ctx context.Context, studentID int) ([]*models.FeeAssignment, error)
 	GetStudentFeesSummary(ctx context.Context, studentID int) (*models.StudentFeesSummary, error)
+	GetStudentLedger(ctx context.Context, studentID int, collegeID int) (*models.StudentFeeLedger, error)
 
 	// Fee Payment management
 	MakeFeePayment(ctx context.Context, req *models.MakeFeePaymentRequest, studentID int, processedBy *int) (*models.FeePayment, error)
@@ -43,6 +45,13 @@ type FeeService interface {
 	ProcessWebhookEvent(ctx context.Context, eventType string, payload map[string]any) error
 }
 
+var (
+	// ErrStudentNotInCollege is returned when a student is outside the caller's college
+	ErrStudentNotInCollege = errors.New("student not found in this college")
+	// ErrOverpayment is returned when a payment exceeds the outstanding balance of a fee assignment
+	ErrOverpayment = errors.New("payment exceeds outstanding balance")
+)
+
 type feeService struct {
 	feeRepo       repository.FeeRepository
 	rzp           *razorpay.Client
@@ -140,13 +149,17 @@ func (s *feeService) ListFeeStructures(ctx context.Context, filter models.FeeFil
 	return s.feeRepo.ListFeeStructures(ctx, filter)
 }
 
-func (s *feeService) AssignFeeToStudent(ctx context.Context, req *models.AssignFeeRequest) error {
+func (s *feeService) AssignFeeToStudent(ctx context.Context, req *models.AssignFeeRequest, collegeID int) error {
 	// Get fee structure to determine default amount
-	fee, err := s.feeRepo.GetFeeStructure(ctx, req.FeeStructureID, 0) // College ID will be checked by constraint
+	fee, err := s.feeRepo.GetFeeStructure(ctx, req.FeeStructureID, collegeID)
 	if err != nil {
 		return fmt.Errorf("fee structure not found: %w", err)
 	}
 
+	if err := s.requireStudentInCollege(ctx, req.StudentID, collegeID); err != nil {
+		return err
+	}
+
 	amount := fee.Amount
 	if req.Amount != nil {
 		amount = *req.Amount
@@ -174,13 +187,20 @@ func (s *feeService) AssignFeeToStudent(ctx context.Context, req *models.AssignF
 	return s.feeRepo.AssignFeeToStudent(ctx, assignment)
 }
 
-func (s *feeService) BulkAssignFeeToStudents(ctx context.Context, req *models.BulkAssignFeeRequest) error {
+func (s *feeService) BulkAssignFeeToStudents(ctx context.Context, req *models.BulkAssignFeeRequest, collegeID int) error {
 	// Get fee structure
-	fee, err := s.feeRepo.GetFeeStructure(ctx, req.FeeStructureID, 0)
+	fee, err := s.feeRepo.GetFeeStructure(ctx, req.FeeStructureID, collegeID)
 	if err != nil {
 		return fmt.Errorf("fee structure not found: %w", err)
 	}
 
+	// Reject the whole batch before assigning anything if a student is outside the college
+	for _, studentID := range req.StudentIDs {
+		if err := s.requireStudentInCollege(ctx, studentID, collegeID); err != nil {
+			return fmt.Errorf("student %d: %w", studentID, err)
+		}
+	}
+
 	dueDate := fee.DueDate
 	if req.DueDate != nil {
 		dueDate = req.DueDate
@@ -224,6 +244,62 @@ func (s *feeService) GetStudentFeesSummary(ctx context.Context, studentID int) (
 	return s.feeRepo.GetStudentFeesSummary(ctx, studentID)
 }
 
+// GetStudentLedger returns a student's fee assignments, payments and
+// outstanding balance, provided the student belongs to the college
+func (s *feeService) GetStudentLedger(ctx context.Context, studentID int, collegeID int) (*models.StudentFeeLedger, error) {
+	if err := s.requireStudentInCollege(ctx, studentID, collegeID); err != nil {
+		return nil, err
+	}
+
+	summary, err := s.feeRepo.GetStudentFeesSummary(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.feeRepo.GetStudentFeeAssignments(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := s.feeRepo.GetStudentPayments(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.StudentFeeLedger{
+		StudentID:   studentID,
+		Summary:     summary,
+		Assignments: assignments,
+		Payments:    payments,
+	}, nil
+}
+
+func (s *feeService) requireStudentInCollege(ctx context.Context, studentID int, collegeID int) error {
+	ok, err := s.feeRepo.StudentInCollege(ctx, studentID, collegeID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrStudentNotInCollege
+	}
+	return nil
+}
+
+// checkPaymentAmount rejects payments larger than what is still owed on the
+// assignment after waivers and completed payments
+func (s *feeService) checkPaymentAmount(ctx context.Context, assignment *models.FeeAssignment, amount float64) error {
+	paidAmount, err := s.feeRepo.GetTotalPaidAmount(ctx, assignment.ID)
+	if err != nil {
+		return err
+	}
+
+	outstanding := assignment.Amount - assignment.WaiverAmount - paidAmount
+	if amount > outstanding {
+		return fmt.Errorf("%w: %.2f outstanding", ErrOverpayment, max(outstanding, 0))
+	}
+	return nil
+}
+
 func (s *feeService) MakeFeePayment(ctx context.Context, req *models.MakeFeePaymentRequest, studentID int, processedBy *int) (*models.FeePayment, error) {
 	// Get assignment to verify it exists
 	assignment, err := s.feeRepo.GetFeeAssignment(ctx, req.FeeAssignmentID)
@@ -236,6 +312,10 @@ func (s *feeService) MakeFeePayment(ctx context.Context, req *models.MakeFeePaym
 		return nil, fmt.Errorf("fee assignment does not belong to this student")
 	}
 
+	if err := s.checkPaymentAmount(ctx, assignment, req.Amount); err != nil {
+		return nil, err
+	}
+
 	// Generate receipt number
 	receiptNumber := fmt.Sprintf("RCP-%d-%d-%d", studentID, req.FeeAssignmentID, time.Now().Unix())
 
@@ -291,6 +371,10 @@ func (s *feeService) InitiateOnlinePayment(ctx context.Context, req *models.Init
 		return nil, fmt.Errorf("fee assignment does not belong to this student")
 	}
 
+	if err := s.checkPaymentAmount(ctx, assignment, req.Amount); err != nil {
+		return nil, err
+	}
+
 	// For Razorpay, we create an order
 	amountInPaise := int(req.Amount * 100)
 	orderData := map[string]any{
@@ -363,22 +447,26 @@ func (s *feeService) VerifyPayment(ctx context.Context, req *models.ConfirmOnlin
 		return err
 	}
 
-	assignment, err := s.feeRepo.GetFeeAssignment(ctx, payment.FeeAssignmentID)
+	return s.refreshAssignmentStatus(ctx, payment.FeeAssignmentID)
+}
+
+// refreshAssignmentStatus marks a fee assignment paid or partial from the
+// payments completed against it so far
+func (s *feeService) refreshAssignmentStatus(ctx context.Context, feeAssignmentID int) error {
+	assignment, err := s.feeRepo.GetFeeAssignment(ctx, feeAssignmentID)
 	if err != nil {
 		return err
 	}
 
-	paidAmount, _ := s.feeRepo.GetTotalPaidAmount(ctx, payment.FeeAssignmentID)
+	paidAmount, _ := s.feeRepo.GetTotalPaidAmount(ctx, feeAssignmentID)
 	remainingAmount := assignment.Amount - paidAmount - assignment.WaiverAmount
 
+	status := "partial"
 	if remainingAmount <= 0 {
-		if err := s.feeRepo.UpdateFeeAssignmentStatus(ctx, payment.FeeAssignmentID, "paid"); err != nil {
-			return fmt.Errorf("failed to update fee assignment status: %w", err)
-		}
-	} else {
-		if err := s.feeRepo.UpdateFeeAssignmentStatus(ctx, payment.FeeAssignmentID, "partial"); err != nil {
-			return fmt.Errorf("failed to update fee assignment status: %w", err)
-		}
+		status = "paid"
+	}
+	if err := s.feeRepo.UpdateFeeAssignmentStatus(ctx, feeAssignmentID, status); err != nil {
+		return fmt.Errorf("failed to update fee assignment status: %w", err)
 	}
 
 	return nil
@@ -419,16 +507,13 @@ func (s *feeService) ProcessWebhookEvent(ctx context.Context, eventType string,
 	}
 }
 
-// handlePaymentCaptured processes successful payment webhooks
+// handlePaymentCaptured processes successful payment webhooks. Razorpay
+// retries deliveries and the checkout callback may already have completed the
+// payment, so an already completed payment is left alone.
 func (s *feeService) handlePaymentCaptured(ctx context.Context, payload map[string]any) error {
-	paymentData, ok := payload["payment"].(map[string]any)
-	if !ok {
-		return fmt.Errorf("invalid payment payload structure")
-	}
-
-	entity, ok := paymentData["entity"].(map[string]any)
-	if !ok {
-		return fmt.Errorf("invalid payment entity structure")
+	entity, err := webhookEntity(payload, "payment")
+	if err != nil {
+		return err
 	}
 
 	orderID, _ := entity["order_id"].(string)
@@ -438,20 +523,28 @@ func (s *feeService) handlePaymentCaptured(ctx context.Context, payload map[stri
 		return fmt.Errorf("missing order_id or payment_id in webhook payload")
 	}
 
-	// Update payment status in database using order_id (which is our transaction_id)
-	return s.feeRepo.UpdatePaymentStatusByTransactionID(ctx, orderID, "completed", &paymentID)
+	// Verified payments hold the Razorpay payment ID instead of the order ID
+	payment, err := s.feeRepo.GetFeePaymentByTransactionID(ctx, orderID)
+	if errors.Is(err, repository.ErrPaymentNotFound) {
+		payment, err = s.feeRepo.GetFeePaymentByTransactionID(ctx, paymentID)
+	}
+	if err != nil {
+		return fmt.Errorf("payment for order %s: %w", orderID, err)
+	}
+	if payment.PaymentStatus == "completed" {
+		return nil
+	}
+
+	return s.completeWebhookPayment(ctx, payment, paymentID)
 }
 
-// handlePaymentFailed processes failed payment webhooks
+// handlePaymentFailed processes failed payment webhooks. Only a pending
+// payment is marked failed, so a late failure event for an attempt that was
+// retried successfully cannot undo the completed payment.
 func (s *feeService) handlePaymentFailed(ctx context.Context, payload map[string]any) error {
-	paymentData, ok := payload["payment"].(map[string]any)
-	if !ok {
-		return fmt.Errorf("invalid payment payload structure")
-	}
-
-	entity, ok := paymentData["entity"].(map[string]any)
-	if !ok {
-		return fmt.Errorf("invalid payment entity structure")
+	entity, err := webhookEntity(payload, "payment")
+	if err != nil {
+		return err
 	}
 
 	orderID, _ := entity["order_id"].(string)
@@ -459,20 +552,23 @@ func (s *feeService) handlePaymentFailed(ctx context.Context, payload map[string
 		return fmt.Errorf("missing order_id in webhook payload")
 	}
 
+	payment, err := s.feeRepo.GetFeePaymentByTransactionID(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("payment for order %s: %w", orderID, err)
+	}
+	if payment.PaymentStatus != "pending" {
+		return nil
+	}
+
 	// Update payment status to failed
-	return s.feeRepo.UpdatePaymentStatusByTransactionID(ctx, orderID, "failed", nil)
+	return s.feeRepo.UpdatePaymentStatus(ctx, payment.ID, "failed", payment.TransactionID)
 }
 
 // handleOrderPaid processes order.paid webhooks (alternative to payment.captured)
 func (s *feeService) handleOrderPaid(ctx context.Context, payload map[string]any) error {
-	orderData, ok := payload["order"].(map[string]any)
-	if !ok {
-		return fmt.Errorf("invalid order payload structure")
-	}
-
-	entity, ok := orderData["entity"].(map[string]any)
-	if !ok {
-		return fmt.Errorf("invalid order entity structure")
+	entity, err := webhookEntity(payload, "order")
+	if err != nil {
+		return err
 	}
 
 	orderID, _ := entity["id"].(string)
@@ -480,6 +576,40 @@ func (s *feeService) handleOrderPaid(ctx context.Context, payload map[string]any
 		return fmt.Errorf("missing order_id in webhook payload")
 	}
 
-	// Update payment status to completed
-	return s.feeRepo.UpdatePaymentStatusByTransactionID(ctx, orderID, "completed", nil)
+	payment, err := s.feeRepo.GetFeePaymentByTransactionID(ctx, orderID)
+	if errors.Is(err, repository.ErrPaymentNotFound) {
+		// Already verified and re-keyed by payment ID
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("payment for order %s: %w", orderID, err)
+	}
+	if payment.PaymentStatus == "completed" {
+		return nil
+	}
+
+	return s.completeWebhookPayment(ctx, payment, orderID)
+}
+
+// completeWebhookPayment marks a payment completed under the given gateway
+// transaction ID and updates its fee assignment
+func (s *feeService) completeWebhookPayment(ctx context.Context, payment *models.FeePayment, transactionID string) error {
+	if err := s.feeRepo.UpdatePaymentStatus(ctx, payment.ID, "completed", &transactionID); err != nil {
+		return fmt.Errorf("failed to update payment status: %w", err)
+	}
+	return s.refreshAssignmentStatus(ctx, payment.FeeAssignmentID)
+}
+
+// webhookEntity extracts payload[kind].entity from a Razorpay webhook payload
+func webhookEntity(payload map[string]any, kind string) (map[string]any, error) {
+	data, ok := payload[kind].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s payload structure", kind)
+	}
+
+	entity, ok := data["entity"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s entity structure", kind)
+	}
+	return entity, nil
 }
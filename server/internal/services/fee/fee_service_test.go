@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -87,9 +88,12 @@ func (m *mockFeeRepository) UpdatePaymentStatus(ctx context.Context, paymentID i
 	return args.Error(0)
 }
 
-func (m *mockFeeRepository) UpdatePaymentStatusByTransactionID(ctx context.Context, orderID string, status string, paymentID *string) error {
-	args := m.Called(ctx, orderID, status, paymentID)
-	return args.Error(0)
+func (m *mockFeeRepository) GetFeePaymentByTransactionID(ctx context.Context, transactionID string) (*models.FeePayment, error) {
+	args := m.Called(ctx, transactionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.FeePayment), args.Error(1)
 }
 
 func (m *mockFeeRepository) GetStudentPayments(ctx context.Context, studentID int) ([]*models.FeePayment, error) {
@@ -118,6 +122,11 @@ func (m *mockFeeRepository) GetTotalPaidAmount(ctx context.Context, assignmentID
 	return args.Get(0).(float64), args.Error(1)
 }
 
+func (m *mockFeeRepository) StudentInCollege(ctx context.Context, studentID int, collegeID int) (bool, error) {
+	args := m.Called(ctx, studentID, collegeID)
+	return args.Bool(0), args.Error(1)
+}
+
 func generateValidSignature(orderID, paymentID, secret string) string {
 	signatureString := orderID + "|" + paymentID
 	mac := hmac.New(sha256.New, []byte(secret))
@@ -309,3 +318,140 @@ func TestVerifyPayment_PartialPayment(t *testing.T) {
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
 }
+
+func capturedPayload(orderID, paymentID string) map[string]any {
+	return map[string]any{
+		"payment": map[string]any{
+			"entity": map[string]any{"id": paymentID, "order_id": orderID},
+		},
+	}
+}
+
+func TestProcessWebhookEvent_PaymentCaptured(t *testing.T) {
+	mockRepo := new(mockFeeRepository)
+	service := NewFeeService(mockRepo, "test_key", "test_secret", "whsec")
+	paymentID := "pay_123"
+
+	mockRepo.On("GetFeePaymentByTransactionID", mock.Anything, "order_123").
+		Return(&models.FeePayment{ID: 7, FeeAssignmentID: 3, PaymentStatus: "pending"}, nil)
+	mockRepo.On("UpdatePaymentStatus", mock.Anything, 7, "completed", &paymentID).Return(nil)
+	mockRepo.On("GetFeeAssignment", mock.Anything, 3).Return(&models.FeeAssignment{Amount: 1000.0}, nil)
+	mockRepo.On("GetTotalPaidAmount", mock.Anything, 3).Return(400.0, nil)
+	mockRepo.On("UpdateFeeAssignmentStatus", mock.Anything, 3, "partial").Return(nil)
+
+	err := service.ProcessWebhookEvent(context.Background(), "payment.captured", capturedPayload("order_123", paymentID))
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProcessWebhookEvent_CapturedAfterVerification(t *testing.T) {
+	mockRepo := new(mockFeeRepository)
+	service := NewFeeService(mockRepo, "test_key", "test_secret", "whsec")
+
+	// The checkout callback already re-keyed the payment by payment ID
+	mockRepo.On("GetFeePaymentByTransactionID", mock.Anything, "order_123").Return(nil, repository.ErrPaymentNotFound)
+	mockRepo.On("GetFeePaymentByTransactionID", mock.Anything, "pay_123").
+		Return(&models.FeePayment{ID: 7, FeeAssignmentID: 3, PaymentStatus: "completed"}, nil)
+
+	err := service.ProcessWebhookEvent(context.Background(), "payment.captured", capturedPayload("order_123", "pay_123"))
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "UpdatePaymentStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProcessWebhookEvent_FailedDoesNotUndoCompleted(t *testing.T) {
+	mockRepo := new(mockFeeRepository)
+	service := NewFeeService(mockRepo, "test_key", "test_secret", "whsec")
+
+	mockRepo.On("GetFeePaymentByTransactionID", mock.Anything, "order_123").
+		Return(&models.FeePayment{ID: 7, PaymentStatus: "completed"}, nil)
+
+	err := service.ProcessWebhookEvent(context.Background(), "payment.failed", capturedPayload("order_123", "pay_456"))
+
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "UpdatePaymentStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMakeFeePayment_RejectsOverpayment(t *testing.T) {
+	mockRepo := new(mockFeeRepository)
+	service := NewFeeService(mockRepo, "test_key", "test_secret", "whsec")
+
+	mockRepo.On("GetFeeAssignment", mock.Anything, 3).
+		Return(&models.FeeAssignment{ID: 3, StudentID: 9, Amount: 1000, WaiverAmount: 100}, nil)
+	mockRepo.On("GetTotalPaidAmount", mock.Anything, 3).Return(600.0, nil)
+
+	req := &models.MakeFeePaymentRequest{FeeAssignmentID: 3, Amount: 301, PaymentMethod: "cash"}
+	_, err := service.MakeFeePayment(context.Background(), req, 9, nil)
+
+	assert.ErrorIs(t, err, ErrOverpayment)
+	assert.Contains(t, err.Error(), "300.00 outstanding")
+	mockRepo.AssertNotCalled(t, "CreateFeePayment", mock.Anything, mock.Anything)
+}
+
+func TestMakeFeePayment_SettlesRemainingBalance(t *testing.T) {
+	mockRepo := new(mockFeeRepository)
+	service := NewFeeService(mockRepo, "test_key", "test_secret", "whsec")
+
+	mockRepo.On("GetFeeAssignment", mock.Anything, 3).
+		Return(&models.FeeAssignment{ID: 3, StudentID: 9, Amount: 1000, WaiverAmount: 100}, nil)
+	mockRepo.On("GetTotalPaidAmount", mock.Anything, 3).Return(600.0, nil).Once()
+	mockRepo.On("CreateFeePayment", mock.Anything, mock.AnythingOfType("*models.FeePayment")).Return(nil)
+	mockRepo.On("GetTotalPaidAmount", mock.Anything, 3).Return(900.0, nil).Once()
+	mockRepo.On("UpdateFeeAssignmentStatus", mock.Anything, 3, "paid").Return(nil)
+
+	req := &models.MakeFeePaymentRequest{FeeAssignmentID: 3, Amount: 300, PaymentMethod: "cash"}
+	payment, err := service.MakeFeePayment(context.Background(), req, 9, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 300.0, payment.Amount)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAssignFeeToStudent_RejectsStudentFromAnotherCollege(t *testing.T) {
+	mockRepo := new(mockFeeRepository)
+	service := NewFeeService(mockRepo, "test_key", "test_secret", "whsec")
+
+	mockRepo.On("GetFeeStructure", mock.Anything, 4, 1).Return(&models.FeeStructure{ID: 4, CollegeID: 1, Amount: 500}, nil)
+	mockRepo.On("StudentInCollege", mock.Anything, 9, 1).Return(false, nil)
+
+	err := service.AssignFeeToStudent(context.Background(), &models.AssignFeeRequest{StudentID: 9, FeeStructureID: 4}, 1)
+
+	assert.ErrorIs(t, err, ErrStudentNotInCollege)
+	mockRepo.AssertNotCalled(t, "AssignFeeToStudent", mock.Anything, mock.Anything)
+}
+
+func TestGetStudentLedger(t *testing.T) {
+	mockRepo := new(mockFeeRepository)
+	service := NewFeeService(mockRepo, "test_key", "test_secret", "whsec")
+
+	summary := &models.StudentFeesSummary{TotalFees: 1000, PaidAmount: 400, PendingAmount: 600}
+	assignments := []*models.FeeAssignment{{ID: 3, StudentID: 9, Amount: 1000}}
+	payments := []*models.FeePayment{{ID: 7, FeeAssignmentID: 3, StudentID: 9, Amount: 400}}
+
+	mockRepo.On("StudentInCollege", mock.Anything, 9, 1).Return(true, nil)
+	mockRepo.On("GetStudentFeesSummary", mock.Anything, 9).Return(summary, nil)
+	mockRepo.On("GetStudentFeeAssignments", mock.Anything, 9).Return(assignments, nil)
+	mockRepo.On("GetStudentPayments", mock.Anything, 9).Return(payments, nil)
+
+	ledger, err := service.GetStudentLedger(context.Background(), 9, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 9, ledger.StudentID)
+	assert.Equal(t, 600.0, ledger.Summary.PendingAmount)
+	assert.Len(t, ledger.Assignments, 1)
+	assert.Len(t, ledger.Payments, 1)
+}
+
+func TestGetStudentLedger_OtherCollege(t *testing.T) {
+	mockRepo := new(mockFeeRepository)
+	service := NewFeeService(mockRepo, "test_key", "test_secret", "whsec")
+
+	mockRepo.On("StudentInCollege", mock.Anything, 9, 2).Return(false, nil)
+
+	_, err := service.GetStudentLedger(context.Background(), 9, 2)
+
+	assert.ErrorIs(t, err, ErrStudentNotInCollege)
+	mockRepo.AssertNotCalled(t, "GetStudentPayments", mock.Anything, mock.Anything)
+}
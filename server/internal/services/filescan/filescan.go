@@ -0,0 +1,65 @@
+// Package filescan checks uploaded files before they are made available to other users.
+package filescan
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Scanner names accepted by New and the STORAGE_FILE_SCANNER setting
+const (
+	ScannerNone      = "none"
+	ScannerMIMESniff = "mimesniff"
+)
+
+// Object is a stored file handed to a scanner
+type Object struct {
+	Key         string
+	Name        string
+	ContentType string
+	Size        int64
+	Reader      io.Reader
+}
+
+// Result is a scanner's verdict. Reason explains a rejection to the uploader.
+type Result struct {
+	Clean  bool
+	Reason string
+}
+
+// FileScanner inspects an uploaded file. An error means the scan itself
+// failed; a rejected file is reported through Result, not an error.
+type FileScanner interface {
+	Scan(ctx context.Context, obj Object) (Result, error)
+}
+
+// New returns the scanner registered under name. An empty name selects the no-op scanner.
+func New(name string) (FileScanner, error) {
+	switch name {
+	case "", ScannerNone:
+		return NoopScanner{}, nil
+	case ScannerMIMESniff:
+		return MIMESniffScanner{}, nil
+	default:
+		return nil, fmt.Errorf("unknown file scanner %q", name)
+	}
+}
+
+// NoopScanner accepts every file
+type NoopScanner struct{}
+
+func (NoopScanner) Scan(ctx context.Context, obj Object) (Result, error) {
+	return Result{Clean: true}, nil
+}
+
+// UnavailableScanner stands in for a configured scanner that failed to start.
+// Every scan fails with Err, so uploads are refused rather than let through
+// unscanned.
+type UnavailableScanner struct {
+	Err error
+}
+
+func (s UnavailableScanner) Scan(ctx context.Context, obj Object) (Result, error) {
+	return Result{}, fmt.Errorf("file scanner is unavailable: %w", s.Err)
+}
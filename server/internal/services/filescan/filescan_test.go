@@ -0,0 +1,68 @@
+package filescan
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	s, err := New("")
+	require.NoError(t, err)
+	assert.IsType(t, NoopScanner{}, s)
+
+	s, err = New(ScannerMIMESniff)
+	require.NoError(t, err)
+	assert.IsType(t, MIMESniffScanner{}, s)
+
+	_, err = New("clamav")
+	assert.Error(t, err)
+}
+
+func TestUnavailableScannerFailsEveryScan(t *testing.T) {
+	_, err := UnavailableScanner{Err: errors.New("clamd not reachable")}.Scan(context.Background(), Object{})
+	assert.ErrorContains(t, err, "clamd not reachable")
+}
+
+func TestMIMESniffScanner(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		clean       bool
+	}{
+		{"pdf", "application/pdf", "%PDF-1.7\n...", true},
+		{"docx is a zip archive", "application/vnd.openxmlformats-officedocument.wordprocessingml.document", "PK\x03\x04rest", true},
+		{"plain text with charset", "text/plain; charset=utf-8", "my essay", true},
+		{"unverifiable legacy doc", "application/msword", "\xd0\xcf\x11\xe0\xa1\xb1\x1a\xe1", true},
+		{"windows executable", "application/pdf", "MZ\x90\x00", false},
+		{"html disguised as text", "text/plain", "<html><script>alert(1)</script></html>", false},
+		{"mismatched type", "application/pdf", "PK\x03\x04rest", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := MIMESniffScanner{}.Scan(context.Background(), Object{
+				ContentType: tc.contentType,
+				Reader:      strings.NewReader(tc.body),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.clean, result.Clean)
+			if !tc.clean {
+				assert.NotEmpty(t, result.Reason)
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	assert.True(t, Matches("application/pdf", "application/pdf"))
+	assert.True(t, Matches("application/vnd.openxmlformats-officedocument.wordprocessingml.document", "application/zip"))
+	assert.True(t, Matches("application/msword", "application/octet-stream"))
+	assert.False(t, Matches("application/pdf", "text/html"))
+	assert.False(t, Matches("application/msword", "text/plain"))
+}
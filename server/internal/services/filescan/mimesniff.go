@@ -0,0 +1,70 @@
+package filescan
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// sniffLen is the number of bytes http.DetectContentType considers
+const sniffLen = 512
+
+// sniffedTypes maps declared content types to what their bytes must sniff as.
+// Office Open XML documents are ZIP archives; legacy Office formats can't be
+// told apart from other binary data and are not listed.
+var sniffedTypes = map[string]string{
+	"application/pdf": "application/pdf",
+	"application/zip": "application/zip",
+	"image/png":       "image/png",
+	"image/jpeg":      "image/jpeg",
+	"image/gif":       "image/gif",
+	"text/plain":      "text/plain",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   "application/zip",
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": "application/zip",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         "application/zip",
+}
+
+// Matches reports whether content that sniffs as sniffed may carry the
+// declared type. Types without a known signature, such as legacy Office
+// formats, only match content that sniffs as unrecognised binary data.
+func Matches(declared, sniffed string) bool {
+	if declared == sniffed {
+		return true
+	}
+	if want, ok := sniffedTypes[declared]; ok {
+		return want == sniffed
+	}
+	return sniffed == "application/octet-stream"
+}
+
+// MIMESniffScanner rejects executables, HTML and files whose content doesn't
+// match their declared type. It does not detect malware.
+type MIMESniffScanner struct{}
+
+func (MIMESniffScanner) Scan(ctx context.Context, obj Object) (Result, error) {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(obj.Reader, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return Result{}, fmt.Errorf("failed to read file header: %w", err)
+	}
+	head = head[:n]
+
+	if bytes.HasPrefix(head, []byte("MZ")) || bytes.HasPrefix(head, []byte("\x7fELF")) {
+		return Result{Reason: "executable files are not allowed"}, nil
+	}
+
+	sniffed := http.DetectContentType(head)
+	if strings.HasPrefix(sniffed, "text/html") || strings.HasPrefix(sniffed, "text/xml") {
+		return Result{Reason: "markup files are not allowed"}, nil
+	}
+
+	declared := strings.ToLower(strings.TrimSpace(strings.Split(obj.ContentType, ";")[0]))
+	if want, ok := sniffedTypes[declared]; ok && !strings.HasPrefix(sniffed, want) {
+		return Result{Reason: fmt.Sprintf("file content does not match declared type %s", declared)}, nil
+	}
+
+	return Result{Clean: true}, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/repository"
+	"eduhub/server/internal/validation"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -37,7 +38,7 @@ func NewGradeServices(gradeRepo repository.GradeRepository, studentRepo reposito
 		studentRepo:    studentRepo,
 		enrollmentRepo: enrollmentRepo,
 		courseRepo:     courseRepo,
-		validate:       *validator.New(),
+		validate:       *validation.New(),
 	}
 }
 
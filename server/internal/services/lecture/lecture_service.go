@@ -6,6 +6,7 @@ import (
 
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/repository"
+	"eduhub/server/internal/validation"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -30,7 +31,7 @@ type lectureService struct {
 func NewLectureService(lectureRepo repository.LectureRepository) LectureService {
 	return &lectureService{
 		lectureRepo: lectureRepo,
-		validate:    *validator.New(),
+		validate:    *validation.New(),
 	}
 }
 
@@ -3,15 +3,17 @@ package notification
 import (
 	"context"
 	"fmt"
-	"log"
 
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/repository"
+
+	"github.com/rs/zerolog"
 )
 
 type NotificationService interface {
 	SendNotification(ctx context.Context, notification *models.Notification) error
-	GetUserNotifications(ctx context.Context, collegeID, userID int, unreadOnly bool, limit int) ([]*models.Notification, error)
+	NotifyUsers(ctx context.Context, collegeID int, userIDs []int, notification *models.Notification) error
+	GetUserNotifications(ctx context.Context, collegeID, userID int, unreadOnly bool, limit, offset int) ([]*models.Notification, error)
 	MarkAsRead(ctx context.Context, collegeID, notificationID, userID int) error
 	MarkAllAsRead(ctx context.Context, collegeID, userID int) error
 	DeleteNotification(ctx context.Context, collegeID, notificationID, userID int) error
@@ -19,17 +21,33 @@ type NotificationService interface {
 	BroadcastNotification(ctx context.Context, collegeID int, notification *models.Notification) error
 	BroadcastToUser(ctx context.Context, collegeID, userID int, notification *models.Notification) error
 	BroadcastToUsers(ctx context.Context, collegeID int, userIDs []int, notification *models.Notification) error
+	Notifier
+}
+
+// Notifier drops in-app notifications into users' inboxes for other services.
+// Notifying never blocks or fails the caller; problems are logged.
+type Notifier interface {
+	Notify(ctx context.Context, collegeID int, userIDs []int, notification *models.Notification)
 }
 
+const (
+	defaultNotificationPageSize = 50
+	maxNotificationPageSize     = 100
+)
+
 type notificationService struct {
 	notificationRepo repository.NotificationRepository
 	websocketService WebSocketService
+	logger           zerolog.Logger
 }
 
-func NewNotificationService(notificationRepo repository.NotificationRepository, websocketService WebSocketService) NotificationService {
+// NewNotificationService creates the notification service. Broadcast and
+// inbox failures that are not returned to the caller are logged to logger.
+func NewNotificationService(notificationRepo repository.NotificationRepository, websocketService WebSocketService, logger zerolog.Logger) NotificationService {
 	return &notificationService{
-		notificationRepo:  notificationRepo,
+		notificationRepo: notificationRepo,
 		websocketService: websocketService,
+		logger:           logger,
 	}
 }
 
@@ -40,6 +58,9 @@ func (s *notificationService) SendNotification(ctx context.Context, notification
 	if notification.UserID == 0 {
 		return fmt.Errorf("user ID is required")
 	}
+	if notification.Type == "" {
+		notification.Type = "info"
+	}
 
 	// Create notification in database
 	if err := s.notificationRepo.CreateNotification(ctx, notification); err != nil {
@@ -49,15 +70,59 @@ func (s *notificationService) SendNotification(ctx context.Context, notification
 	// Broadcast to WebSocket clients
 	go func() {
 		if err := s.websocketService.BroadcastToUser(ctx, notification.CollegeID, notification.UserID, notification); err != nil {
-			log.Printf("Failed to broadcast notification: %v", err)
+			s.logger.Error().Err(err).Int("college_id", notification.CollegeID).Int("user_id", notification.UserID).Msg("failed to broadcast notification")
 		}
 	}()
 
 	return nil
 }
 
-func (s *notificationService) GetUserNotifications(ctx context.Context, collegeID, userID int, unreadOnly bool, limit int) ([]*models.Notification, error) {
-	return s.notificationRepo.GetNotificationsByUser(ctx, collegeID, userID, unreadOnly, limit)
+// NotifyUsers stores one copy of notification in each user's inbox and pushes
+// it to those who are connected
+func (s *notificationService) NotifyUsers(ctx context.Context, collegeID int, userIDs []int, notification *models.Notification) error {
+	if notification.Title == "" {
+		return fmt.Errorf("notification title is required")
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+	if notification.Type == "" {
+		notification.Type = "info"
+	}
+	notification.CollegeID = collegeID
+
+	if _, err := s.notificationRepo.CreateNotificationsForUsers(ctx, userIDs, notification); err != nil {
+		return fmt.Errorf("failed to store notifications: %w", err)
+	}
+
+	if s.websocketService != nil {
+		if err := s.websocketService.BroadcastToUsers(ctx, collegeID, userIDs, notification); err != nil {
+			s.logger.Error().Err(err).Int("college_id", collegeID).Int("users", len(userIDs)).Msg("failed to broadcast notification")
+		}
+	}
+	return nil
+}
+
+func (s *notificationService) Notify(ctx context.Context, collegeID int, userIDs []int, notification *models.Notification) {
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := s.NotifyUsers(ctx, collegeID, userIDs, notification); err != nil {
+			s.logger.Error().Err(err).Int("college_id", collegeID).Int("users", len(userIDs)).Msg("failed to notify users")
+		}
+	}()
+}
+
+func (s *notificationService) GetUserNotifications(ctx context.Context, collegeID, userID int, unreadOnly bool, limit, offset int) ([]*models.Notification, error) {
+	if limit <= 0 {
+		limit = defaultNotificationPageSize
+	}
+	if limit > maxNotificationPageSize {
+		limit = maxNotificationPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.notificationRepo.GetNotificationsByUser(ctx, collegeID, userID, unreadOnly, limit, offset)
 }
 
 func (s *notificationService) MarkAsRead(ctx context.Context, collegeID, notificationID, userID int) error {
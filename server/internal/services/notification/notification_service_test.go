@@ -0,0 +1,55 @@
+package notification
+
+import (
+	"context"
+	"testing"
+
+	"eduhub/server/internal/models"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifyUsers(t *testing.T) {
+	mockRepo := new(mockNotificationRepository)
+	svc := NewNotificationService(mockRepo, nil, zerolog.Nop())
+
+	userIDs := []int{4, 5}
+	mockRepo.On("CreateNotificationsForUsers", mock.Anything, userIDs, mock.MatchedBy(func(n *models.Notification) bool {
+		return n.CollegeID == 2 && n.Type == "info" && n.Title == "Results published: Midterm"
+	})).Return(2, nil)
+
+	err := svc.NotifyUsers(context.Background(), 2, userIDs, &models.Notification{Title: "Results published: Midterm"})
+
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestNotifyUsers_NoRecipients(t *testing.T) {
+	mockRepo := new(mockNotificationRepository)
+	svc := NewNotificationService(mockRepo, nil, zerolog.Nop())
+
+	err := svc.NotifyUsers(context.Background(), 2, nil, &models.Notification{Title: "Nobody"})
+
+	require.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "CreateNotificationsForUsers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetUserNotifications_ClampsPaging(t *testing.T) {
+	mockRepo := new(mockNotificationRepository)
+	svc := NewNotificationService(mockRepo, nil, zerolog.Nop())
+
+	mockRepo.On("GetNotificationsByUser", mock.Anything, 1, 7, false, maxNotificationPageSize, 0).
+		Return([]*models.Notification{}, nil).Once()
+	mockRepo.On("GetNotificationsByUser", mock.Anything, 1, 7, true, defaultNotificationPageSize, 50).
+		Return([]*models.Notification{}, nil).Once()
+
+	_, err := svc.GetUserNotifications(context.Background(), 1, 7, false, 500, -5)
+	require.NoError(t, err)
+	_, err = svc.GetUserNotifications(context.Background(), 1, 7, true, 0, 50)
+	require.NoError(t, err)
+
+	assert.True(t, mockRepo.AssertExpectations(t))
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"os"
 	"sync"
 	"time"
 
@@ -63,7 +62,7 @@ type WebSocketMessage struct {
 	CollegeID    int                  `json:"college_id,omitempty"`
 }
 
-func NewWebSocketService(notificationRepo repository.NotificationRepository, allowedOrigins []string) WebSocketService {
+func NewWebSocketService(notificationRepo repository.NotificationRepository, allowedOrigins []string, logger zerolog.Logger) WebSocketService {
 	originsMap := make(map[string]bool)
 	for _, origin := range allowedOrigins {
 		originsMap[origin] = true
@@ -81,11 +80,6 @@ func NewWebSocketService(notificationRepo repository.NotificationRepository, all
 		workerPool[i] = make(chan struct{}, 1)
 	}
 
-	logger := zerolog.New(zerolog.ConsoleWriter{
-		Out:        os.Stdout,
-		TimeFormat: time.RFC3339,
-	}).With().Timestamp().Logger()
-
 	ws := &websocketService{
 		clients:          make(map[int]map[int]*websocket.Conn),
 		connectionTimes:  make(map[int]map[int]time.Time),
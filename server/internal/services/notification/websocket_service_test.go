@@ -9,6 +9,7 @@ import (
 	"eduhub/server/internal/models"
 
 	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -22,8 +23,13 @@ func (m *mockNotificationRepository) CreateNotification(ctx context.Context, not
 	return args.Error(0)
 }
 
-func (m *mockNotificationRepository) GetNotificationsByUser(ctx context.Context, collegeID, userID int, unreadOnly bool, limit int) ([]*models.Notification, error) {
-	args := m.Called(ctx, collegeID, userID, unreadOnly, limit)
+func (m *mockNotificationRepository) CreateNotificationsForUsers(ctx context.Context, userIDs []int, notification *models.Notification) (int, error) {
+	args := m.Called(ctx, userIDs, notification)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *mockNotificationRepository) GetNotificationsByUser(ctx context.Context, collegeID, userID int, unreadOnly bool, limit, offset int) ([]*models.Notification, error) {
+	args := m.Called(ctx, collegeID, userID, unreadOnly, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -53,14 +59,14 @@ func (m *mockNotificationRepository) GetUnreadCount(ctx context.Context, college
 func TestWebSocketService_New(t *testing.T) {
 	mockRepo := new(mockNotificationRepository)
 
-	service := NewWebSocketService(mockRepo, []string{"http://localhost:3000"})
+	service := NewWebSocketService(mockRepo, []string{"http://localhost:3000"}, zerolog.Nop())
 
 	assert.NotNil(t, service)
 }
 
 func TestWebSocketService_GetConnectedUsers_Empty(t *testing.T) {
 	mockRepo := new(mockNotificationRepository)
-	service := NewWebSocketService(mockRepo, []string{"http://localhost:3000"})
+	service := NewWebSocketService(mockRepo, []string{"http://localhost:3000"}, zerolog.Nop())
 
 	users := service.GetConnectedUsers(1)
 
@@ -69,7 +75,7 @@ func TestWebSocketService_GetConnectedUsers_Empty(t *testing.T) {
 
 func TestWebSocketService_BroadcastToUser_NoConnectedUsers(t *testing.T) {
 	mockRepo := new(mockNotificationRepository)
-	service := NewWebSocketService(mockRepo, []string{"http://localhost:3000"})
+	service := NewWebSocketService(mockRepo, []string{"http://localhost:3000"}, zerolog.Nop())
 
 	ctx := context.Background()
 	notification := &models.Notification{Title: "Test"}
@@ -81,7 +87,7 @@ func TestWebSocketService_BroadcastToUser_NoConnectedUsers(t *testing.T) {
 
 func TestWebSocketService_BroadcastToUsers_NoConnectedUsers(t *testing.T) {
 	mockRepo := new(mockNotificationRepository)
-	service := NewWebSocketService(mockRepo, []string{"http://localhost:3000"})
+	service := NewWebSocketService(mockRepo, []string{"http://localhost:3000"}, zerolog.Nop())
 
 	ctx := context.Background()
 	notification := &models.Notification{Title: "Test"}
@@ -93,7 +99,7 @@ func TestWebSocketService_BroadcastToUsers_NoConnectedUsers(t *testing.T) {
 
 func TestWebSocketService_BroadcastTypingIndicator_NoConnectedUsers(t *testing.T) {
 	mockRepo := new(mockNotificationRepository)
-	service := NewWebSocketService(mockRepo, []string{"http://localhost:3000"})
+	service := NewWebSocketService(mockRepo, []string{"http://localhost:3000"}, zerolog.Nop())
 
 	ctx := context.Background()
 
@@ -104,7 +110,7 @@ func TestWebSocketService_BroadcastTypingIndicator_NoConnectedUsers(t *testing.T
 
 func TestWebSocketService_BroadcastPresence_NoConnectedUsers(t *testing.T) {
 	mockRepo := new(mockNotificationRepository)
-	service := NewWebSocketService(mockRepo, []string{"http://localhost:3000"})
+	service := NewWebSocketService(mockRepo, []string{"http://localhost:3000"}, zerolog.Nop())
 
 	ctx := context.Background()
 
@@ -115,7 +121,7 @@ func TestWebSocketService_BroadcastPresence_NoConnectedUsers(t *testing.T) {
 
 func TestWebSocketService_GetConnectionStats(t *testing.T) {
 	mockRepo := new(mockNotificationRepository)
-	service := NewWebSocketService(mockRepo, []string{"http://localhost:3000"})
+	service := NewWebSocketService(mockRepo, []string{"http://localhost:3000"}, zerolog.Nop())
 
 	assert.NotPanics(t, func() {
 		stats := service.GetConnectionStats()
@@ -128,7 +134,7 @@ func TestWebSocketService_GetConnectionStats(t *testing.T) {
 
 func TestWebSocketService_StopIsSafe(t *testing.T) {
 	mockRepo := new(mockNotificationRepository)
-	service := NewWebSocketService(mockRepo, []string{"http://localhost:3000"})
+	service := NewWebSocketService(mockRepo, []string{"http://localhost:3000"}, zerolog.Nop())
 
 	assert.NotPanics(t, func() {
 		service.Stop()
@@ -0,0 +1,164 @@
+package parentalert
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+	"eduhub/server/internal/services/email"
+
+	"github.com/rs/zerolog"
+)
+
+// ParentAlertService sends low-attendance alerts to parents who opted in to notifications
+type ParentAlertService interface {
+	// RunLowAttendanceAlerts emails parents of students in one college whose
+	// recent attendance is below the threshold
+	RunLowAttendanceAlerts(ctx context.Context, collegeID int) (*RunResult, error)
+	// RunAllColleges runs the alert job for every college with opted-in parents
+	RunAllColleges(ctx context.Context) (*RunResult, error)
+}
+
+// Config holds the thresholds used to decide who gets alerted
+type Config struct {
+	Threshold   float64
+	Window      time.Duration
+	MinSessions int
+	Cooldown    time.Duration
+}
+
+// RunResult summarises a job run
+type RunResult struct {
+	Colleges int `json:"colleges"`
+	Eligible int `json:"eligible"`
+	Sent     int `json:"sent"`
+	Failed   int `json:"failed"`
+}
+
+type parentAlertService struct {
+	repo     repository.ParentAlertRepository
+	delivery email.DeliveryService
+	config   Config
+	now      func() time.Time
+	logger   zerolog.Logger
+}
+
+// NewParentAlertService creates a parent alert service that queues alerts on
+// delivery, which retries them and dead-letters any that still fail
+func NewParentAlertService(repo repository.ParentAlertRepository, delivery email.DeliveryService, config Config, logger zerolog.Logger) ParentAlertService {
+	return &parentAlertService{
+		repo:     repo,
+		delivery: delivery,
+		config:   config,
+		now:      time.Now,
+		logger:   logger,
+	}
+}
+
+func (s *parentAlertService) RunAllColleges(ctx context.Context) (*RunResult, error) {
+	collegeIDs, err := s.repo.ListCollegesWithParentNotifications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list colleges: %w", err)
+	}
+
+	total := &RunResult{}
+	for _, collegeID := range collegeIDs {
+		result, err := s.RunLowAttendanceAlerts(ctx, collegeID)
+		if err != nil {
+			// One college failing should not stop alerts for the rest
+			s.logger.Error().Err(err).Int("college_id", collegeID).Msg("low attendance alert run failed")
+			continue
+		}
+		total.Colleges++
+		total.Eligible += result.Eligible
+		total.Sent += result.Sent
+		total.Failed += result.Failed
+	}
+
+	s.logger.Info().
+		Int("colleges", total.Colleges).
+		Int("sent", total.Sent).
+		Int("failed", total.Failed).
+		Msg("low attendance alert run complete")
+	return total, nil
+}
+
+func (s *parentAlertService) RunLowAttendanceAlerts(ctx context.Context, collegeID int) (*RunResult, error) {
+	now := s.now()
+	criteria := models.LowAttendanceCriteria{
+		CollegeID:     collegeID,
+		Threshold:     s.config.Threshold,
+		WindowStart:   now.Add(-s.config.Window),
+		MinSessions:   s.config.MinSessions,
+		CooldownStart: now.Add(-s.config.Cooldown),
+	}
+
+	recipients, err := s.repo.FindLowAttendanceRecipients(ctx, criteria)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find low attendance recipients: %w", err)
+	}
+
+	// Alerts are claimed per cooldown period; a concurrent run computes the
+	// same period, so only one of them wins the claim and sends
+	periodStart := now.Truncate(s.config.Cooldown)
+
+	result := &RunResult{Colleges: 1, Eligible: len(recipients)}
+	for _, recipient := range recipients {
+		alert := &models.ParentAttendanceAlert{
+			RelationshipID: recipient.RelationshipID,
+			ParentUserID:   recipient.ParentUserID,
+			StudentID:      recipient.StudentID,
+			CollegeID:      recipient.CollegeID,
+			AttendanceRate: recipient.AttendanceRate,
+			Threshold:      s.config.Threshold,
+			PeriodStart:    periodStart,
+		}
+		claimed, err := s.repo.ClaimAttendanceAlert(ctx, alert)
+		if err != nil {
+			result.Failed++
+			s.logger.Error().Err(err).
+				Int("relationship_id", recipient.RelationshipID).
+				Msg("failed to claim low attendance alert")
+			continue
+		}
+		if !claimed {
+			result.Eligible--
+			continue
+		}
+
+		// Only an alert that can't be queued is released; one that fails to
+		// send later ends up as a dead letter an admin can resend
+		err = s.delivery.SendTemplateAsync(ctx, recipient.CollegeID, recipient.ParentEmail, email.TemplateAttendanceAlert, s.alertData(recipient))
+		if err != nil {
+			result.Failed++
+			s.logger.Warn().Err(err).
+				Int("relationship_id", recipient.RelationshipID).
+				Msg("failed to queue low attendance alert")
+			if err := s.repo.ReleaseAttendanceAlert(ctx, alert.ID); err != nil {
+				// The claim stays, so the parent is not alerted until the cooldown passes
+				s.logger.Error().Err(err).
+					Int("relationship_id", recipient.RelationshipID).
+					Msg("failed to release low attendance alert")
+			}
+			continue
+		}
+		result.Sent++
+	}
+
+	return result, nil
+}
+
+func (s *parentAlertService) alertData(r *models.LowAttendanceRecipient) email.AttendanceAlertData {
+	return email.AttendanceAlertData{
+		ParentName:     r.ParentName,
+		StudentName:    r.StudentName,
+		RollNo:         r.RollNo,
+		PresentCount:   r.PresentCount,
+		TotalSessions:  r.TotalSessions,
+		WindowDays:     int(s.config.Window.Hours() / 24),
+		AttendanceRate: r.AttendanceRate,
+		Threshold:      s.config.Threshold,
+	}
+}
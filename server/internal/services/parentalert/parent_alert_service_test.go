@@ -0,0 +1,180 @@
+package parentalert
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/services/email"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAlertRepo struct {
+	collegeIDs []int
+	recipients map[int][]*models.LowAttendanceRecipient
+	criteria   []models.LowAttendanceCriteria
+	recorded   []*models.ParentAttendanceAlert
+	released   []int
+	findErr    error
+}
+
+func (r *fakeAlertRepo) ListCollegesWithParentNotifications(ctx context.Context) ([]int, error) {
+	return r.collegeIDs, nil
+}
+
+func (r *fakeAlertRepo) FindLowAttendanceRecipients(ctx context.Context, criteria models.LowAttendanceCriteria) ([]*models.LowAttendanceRecipient, error) {
+	r.criteria = append(r.criteria, criteria)
+	if r.findErr != nil {
+		return nil, r.findErr
+	}
+	return r.recipients[criteria.CollegeID], nil
+}
+
+// ClaimAttendanceAlert enforces the (relationship, period) unique key
+func (r *fakeAlertRepo) ClaimAttendanceAlert(ctx context.Context, alert *models.ParentAttendanceAlert) (bool, error) {
+	for _, claimed := range r.recorded {
+		if claimed.RelationshipID == alert.RelationshipID && claimed.PeriodStart.Equal(alert.PeriodStart) {
+			return false, nil
+		}
+	}
+	alert.ID = len(r.recorded) + 1
+	r.recorded = append(r.recorded, alert)
+	return true, nil
+}
+
+func (r *fakeAlertRepo) ReleaseAttendanceAlert(ctx context.Context, alertID int) error {
+	r.released = append(r.released, alertID)
+	kept := r.recorded[:0]
+	for _, alert := range r.recorded {
+		if alert.ID != alertID {
+			kept = append(kept, alert)
+		}
+	}
+	r.recorded = kept
+	return nil
+}
+
+// fakeDelivery records queued emails in order
+type fakeDelivery struct {
+	email.DeliveryService
+	sent []string
+}
+
+func (d *fakeDelivery) SendTemplateAsync(ctx context.Context, collegeID int, to, templateName string, data any) error {
+	if _, err := email.ParseRecipients(to); err != nil {
+		return err
+	}
+	if _, _, err := email.Render(templateName, data); err != nil {
+		return err
+	}
+	d.sent = append(d.sent, to)
+	return nil
+}
+
+func newTestService(repo *fakeAlertRepo, mail *fakeDelivery, now time.Time) *parentAlertService {
+	svc := NewParentAlertService(repo, mail, Config{
+		Threshold:   75,
+		Window:      30 * 24 * time.Hour,
+		MinSessions: 5,
+		Cooldown:    7 * 24 * time.Hour,
+	}, zerolog.Nop()).(*parentAlertService)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestRunLowAttendanceAlerts(t *testing.T) {
+	now := time.Date(2026, 3, 10, 18, 0, 0, 0, time.UTC)
+
+	t.Run("emails each recipient and records the alert", func(t *testing.T) {
+		repo := &fakeAlertRepo{recipients: map[int][]*models.LowAttendanceRecipient{
+			1: {
+				{RelationshipID: 10, ParentUserID: 100, ParentEmail: "a@example.com", StudentID: 5, CollegeID: 1, AttendanceRate: 60},
+				{RelationshipID: 11, ParentUserID: 101, ParentEmail: "b@example.com", StudentID: 6, CollegeID: 1, AttendanceRate: 50},
+			},
+		}}
+		mail := &fakeDelivery{}
+		svc := newTestService(repo, mail, now)
+
+		result, err := svc.RunLowAttendanceAlerts(context.Background(), 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, &RunResult{Colleges: 1, Eligible: 2, Sent: 2}, result)
+		assert.Equal(t, []string{"a@example.com", "b@example.com"}, mail.sent)
+		require.Len(t, repo.recorded, 2)
+		assert.Equal(t, 10, repo.recorded[0].RelationshipID)
+		assert.Equal(t, 75.0, repo.recorded[0].Threshold)
+
+		require.Len(t, repo.criteria, 1)
+		assert.Equal(t, now.Add(-30*24*time.Hour), repo.criteria[0].WindowStart)
+		assert.Equal(t, now.Add(-7*24*time.Hour), repo.criteria[0].CooldownStart)
+		assert.Equal(t, 5, repo.criteria[0].MinSessions)
+	})
+
+	t.Run("an overlapping run does not send the same alert again", func(t *testing.T) {
+		repo := &fakeAlertRepo{recipients: map[int][]*models.LowAttendanceRecipient{
+			1: {{RelationshipID: 10, ParentEmail: "a@example.com", CollegeID: 1}},
+		}}
+		mail := &fakeDelivery{}
+		first := newTestService(repo, mail, now)
+		second := newTestService(repo, mail, now.Add(time.Minute))
+
+		_, err := first.RunLowAttendanceAlerts(context.Background(), 1)
+		require.NoError(t, err)
+		result, err := second.RunLowAttendanceAlerts(context.Background(), 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, 0, result.Sent)
+		assert.Equal(t, []string{"a@example.com"}, mail.sent)
+	})
+
+	t.Run("an alert that can't be queued is released so it is retried next run", func(t *testing.T) {
+		repo := &fakeAlertRepo{recipients: map[int][]*models.LowAttendanceRecipient{
+			1: {
+				{RelationshipID: 10, ParentEmail: "not-an-address", CollegeID: 1},
+				{RelationshipID: 11, ParentEmail: "b@example.com", CollegeID: 1},
+			},
+		}}
+		mail := &fakeDelivery{}
+		svc := newTestService(repo, mail, now)
+
+		result, err := svc.RunLowAttendanceAlerts(context.Background(), 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, result.Sent)
+		assert.Equal(t, 1, result.Failed)
+		require.Len(t, repo.recorded, 1)
+		assert.Equal(t, 11, repo.recorded[0].RelationshipID)
+		assert.Len(t, repo.released, 1)
+	})
+
+	t.Run("repository error is returned", func(t *testing.T) {
+		repo := &fakeAlertRepo{findErr: errors.New("db down")}
+		svc := newTestService(repo, &fakeDelivery{}, now)
+
+		_, err := svc.RunLowAttendanceAlerts(context.Background(), 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestRunAllColleges(t *testing.T) {
+	repo := &fakeAlertRepo{
+		collegeIDs: []int{1, 2},
+		recipients: map[int][]*models.LowAttendanceRecipient{
+			1: {{RelationshipID: 10, ParentEmail: "a@example.com", CollegeID: 1}},
+			2: {{RelationshipID: 20, ParentEmail: "c@example.com", CollegeID: 2}},
+		},
+	}
+	mail := &fakeDelivery{}
+	svc := newTestService(repo, mail, time.Now())
+
+	result, err := svc.RunAllColleges(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, &RunResult{Colleges: 2, Eligible: 2, Sent: 2}, result)
+	assert.ElementsMatch(t, []string{"a@example.com", "c@example.com"}, mail.sent)
+}
@@ -0,0 +1,93 @@
+package quiz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"eduhub/server/internal/models"
+)
+
+// AbandonedAttemptAction is what happens to an attempt still in progress after
+// its time ran out
+type AbandonedAttemptAction string
+
+const (
+	// AbandonedAttemptSubmit submits the attempt with the answers saved so far
+	// and grades it
+	AbandonedAttemptSubmit AbandonedAttemptAction = "submit"
+	// AbandonedAttemptExpire marks the attempt expired without grading it
+	AbandonedAttemptExpire AbandonedAttemptAction = "expire"
+)
+
+// AbandonedAttemptPolicy controls CloseAbandonedAttempts. Grace is how long
+// after an attempt's time runs out it is left alone, so a submission already
+// on its way is not overtaken.
+type AbandonedAttemptPolicy struct {
+	Grace  time.Duration
+	Action AbandonedAttemptAction
+}
+
+// CloseAbandonedAttempts closes the in-progress attempts whose time ran out
+// more than the grace period ago, either submitting and grading them or
+// marking them expired. Students are told when their attempt was submitted for
+// them. Each attempt is claimed before it is graded, so running this again, or
+// twice at once, never closes an attempt twice; it returns how many attempts
+// were closed.
+func (s *simpleQuizAttemptService) CloseAbandonedAttempts(ctx context.Context, policy AbandonedAttemptPolicy) (int, error) {
+	if policy.Grace < 0 {
+		return 0, fmt.Errorf("grace period must not be negative")
+	}
+	status := models.QuizAttemptStatusCompleted
+	switch policy.Action {
+	case AbandonedAttemptSubmit:
+	case AbandonedAttemptExpire:
+		status = models.QuizAttemptStatusExpired
+	default:
+		return 0, fmt.Errorf("unknown abandoned attempt action: %q", policy.Action)
+	}
+
+	attempts, err := s.attemptRepo.CloseAbandonedQuizAttempts(ctx, time.Now(), policy.Grace, status)
+	if err != nil {
+		return 0, err
+	}
+	if policy.Action == AbandonedAttemptExpire {
+		return len(attempts), nil
+	}
+
+	// A failed grade leaves the attempt submitted without a score, like a
+	// failed grade on a normal submission; carry on with the rest
+	var errs []error
+	for _, attempt := range attempts {
+		graded, err := s.autoGrader.AutoGradeAttempt(ctx, attempt.CollegeID, attempt.ID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to grade attempt %d: %w", attempt.ID, err))
+			graded = nil
+		}
+		s.notifyAutoSubmitted(ctx, attempt, graded)
+	}
+	return len(attempts), errors.Join(errs...)
+}
+
+// notifyAutoSubmitted tells the student their attempt was submitted for them,
+// with the score when it could be graded
+func (s *simpleQuizAttemptService) notifyAutoSubmitted(ctx context.Context, attempt *models.ClosedQuizAttempt, graded *models.QuizAttempt) {
+	if s.inbox == nil {
+		return
+	}
+	message := fmt.Sprintf("Time ran out on your attempt at %s, so it was submitted with the answers you had saved.", attempt.QuizTitle)
+	if graded != nil && graded.Score != nil {
+		message += fmt.Sprintf(" You scored %d.", *graded.Score)
+	}
+
+	entityType := models.NotificationEntityQuiz
+	quizID := attempt.QuizID
+	s.inbox.Notify(ctx, attempt.CollegeID, []int{attempt.UserID}, &models.Notification{
+		Title:             fmt.Sprintf("Quiz submitted: %s", attempt.QuizTitle),
+		Message:           message,
+		Type:              "info",
+		RelatedEntityType: &entityType,
+		RelatedEntityID:   &quizID,
+	})
+}
@@ -0,0 +1,128 @@
+package quiz
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// abandonedAttemptRepo closes each open attempt once, like the conditional
+// UPDATE in the real repository
+type abandonedAttemptRepo struct {
+	repository.QuizAttemptRepository
+	open   []*models.ClosedQuizAttempt
+	grace  time.Duration
+	status models.QuizAttemptStatus
+}
+
+func (r *abandonedAttemptRepo) CloseAbandonedQuizAttempts(ctx context.Context, now time.Time, grace time.Duration, status models.QuizAttemptStatus) ([]*models.ClosedQuizAttempt, error) {
+	r.grace, r.status = grace, status
+	closed := r.open
+	r.open = nil
+	for _, attempt := range closed {
+		attempt.Status = status
+	}
+	return closed, nil
+}
+
+type abandonedGrader struct {
+	AutoGradingService
+	graded []int
+	fail   map[int]bool
+}
+
+func (g *abandonedGrader) AutoGradeAttempt(ctx context.Context, collegeID int, attemptID int) (*models.QuizAttempt, error) {
+	if g.fail[attemptID] {
+		return nil, errors.New("grading failed")
+	}
+	g.graded = append(g.graded, attemptID)
+	score := 7
+	return &models.QuizAttempt{ID: attemptID, Score: &score, Status: models.QuizAttemptStatusGraded}, nil
+}
+
+type abandonedInbox struct {
+	userIDs       [][]int
+	notifications []*models.Notification
+}
+
+func (i *abandonedInbox) Notify(ctx context.Context, collegeID int, userIDs []int, notification *models.Notification) {
+	i.userIDs = append(i.userIDs, userIDs)
+	i.notifications = append(i.notifications, notification)
+}
+
+func newAbandonedFixture() (QuizAttemptServiceSimple, *abandonedAttemptRepo, *abandonedGrader, *abandonedInbox) {
+	repo := &abandonedAttemptRepo{open: []*models.ClosedQuizAttempt{
+		{QuizAttempt: models.QuizAttempt{ID: 1, QuizID: 5, StudentID: 3, CollegeID: 1}, UserID: 30, QuizTitle: "Week 1"},
+		{QuizAttempt: models.QuizAttempt{ID: 2, QuizID: 5, StudentID: 4, CollegeID: 1}, UserID: 40, QuizTitle: "Week 1"},
+	}}
+	grader := &abandonedGrader{fail: map[int]bool{}}
+	inbox := &abandonedInbox{}
+	svc := NewSimpleQuizAttemptService(repo, nil, nil, nil, nil, grader, inbox)
+	return svc, repo, grader, inbox
+}
+
+func TestCloseAbandonedAttempts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("submits, grades and notifies", func(t *testing.T) {
+		svc, repo, grader, inbox := newAbandonedFixture()
+		closed, err := svc.CloseAbandonedAttempts(ctx, AbandonedAttemptPolicy{Grace: 5 * time.Minute, Action: AbandonedAttemptSubmit})
+		require.NoError(t, err)
+		assert.Equal(t, 2, closed)
+		assert.Equal(t, models.QuizAttemptStatusCompleted, repo.status)
+		assert.Equal(t, 5*time.Minute, repo.grace)
+		assert.Equal(t, []int{1, 2}, grader.graded)
+		assert.Equal(t, [][]int{{30}, {40}}, inbox.userIDs)
+		assert.Equal(t, "Quiz submitted: Week 1", inbox.notifications[0].Title)
+		assert.Contains(t, inbox.notifications[0].Message, "You scored 7.")
+		assert.Equal(t, models.NotificationEntityQuiz, *inbox.notifications[0].RelatedEntityType)
+		assert.Equal(t, 5, *inbox.notifications[0].RelatedEntityID)
+	})
+
+	t.Run("running again closes nothing", func(t *testing.T) {
+		svc, _, grader, inbox := newAbandonedFixture()
+		policy := AbandonedAttemptPolicy{Action: AbandonedAttemptSubmit}
+		_, err := svc.CloseAbandonedAttempts(ctx, policy)
+		require.NoError(t, err)
+		closed, err := svc.CloseAbandonedAttempts(ctx, policy)
+		require.NoError(t, err)
+		assert.Zero(t, closed)
+		assert.Len(t, grader.graded, 2)
+		assert.Len(t, inbox.notifications, 2)
+	})
+
+	t.Run("expire neither grades nor notifies", func(t *testing.T) {
+		svc, repo, grader, inbox := newAbandonedFixture()
+		closed, err := svc.CloseAbandonedAttempts(ctx, AbandonedAttemptPolicy{Action: AbandonedAttemptExpire})
+		require.NoError(t, err)
+		assert.Equal(t, 2, closed)
+		assert.Equal(t, models.QuizAttemptStatusExpired, repo.status)
+		assert.Empty(t, grader.graded)
+		assert.Empty(t, inbox.notifications)
+	})
+
+	t.Run("a failed grade does not stop the rest", func(t *testing.T) {
+		svc, _, grader, inbox := newAbandonedFixture()
+		grader.fail[1] = true
+		closed, err := svc.CloseAbandonedAttempts(ctx, AbandonedAttemptPolicy{Action: AbandonedAttemptSubmit})
+		require.Error(t, err)
+		assert.Equal(t, 2, closed)
+		assert.Equal(t, []int{2}, grader.graded)
+		require.Len(t, inbox.notifications, 2)
+		assert.NotContains(t, inbox.notifications[0].Message, "You scored")
+	})
+
+	t.Run("rejects an unknown action", func(t *testing.T) {
+		svc, repo, _, _ := newAbandonedFixture()
+		_, err := svc.CloseAbandonedAttempts(ctx, AbandonedAttemptPolicy{Action: "delete"})
+		require.Error(t, err)
+		assert.Len(t, repo.open, 2)
+	})
+}
@@ -0,0 +1,21 @@
+package quiz
+
+import "eduhub/server/internal/models"
+
+// answersVisibleTo reports whether a caller with the given role may see which
+// answers are correct. Only staff may; students and unknown roles get the
+// sanitized view.
+func answersVisibleTo(role string) bool {
+	return role == "admin" || role == "super_admin" || role == "faculty"
+}
+
+// stripAnswers removes the correct-answer markers from questions and their
+// options so they can be served to a student taking the quiz
+func stripAnswers(questions []*models.Question) {
+	for _, question := range questions {
+		question.CorrectAnswer = nil
+		for _, option := range question.Options {
+			option.IsCorrect = false
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package quiz
+
+import (
+	"context"
+	"testing"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// visibilityQuestionRepo hands out fresh questions on every call so one read
+// cannot affect the next
+type visibilityQuestionRepo struct {
+	repository.QuestionRepository
+}
+
+func (r *visibilityQuestionRepo) questions() []*models.Question {
+	answer := "photosynthesis"
+	return []*models.Question{
+		{ID: 1, QuizID: 5, Type: models.MultipleChoice, Points: 2},
+		{ID: 2, QuizID: 5, Type: models.ShortAnswer, Points: 3, CorrectAnswer: &answer},
+	}
+}
+
+func (r *visibilityQuestionRepo) FindQuestionsByQuiz(ctx context.Context, collegeID int, quizID int, limit, offset uint64) ([]*models.Question, error) {
+	return r.questions(), nil
+}
+
+func (r *visibilityQuestionRepo) GetQuestionByID(ctx context.Context, collegeID int, questionID int) (*models.Question, error) {
+	return r.questions()[questionID-1], nil
+}
+
+type visibilityOptionRepo struct {
+	repository.AnswerOptionRepository
+}
+
+func (r *visibilityOptionRepo) FindAnswerOptionsByQuestion(ctx context.Context, questionID int) ([]*models.AnswerOption, error) {
+	if questionID != 1 {
+		return []*models.AnswerOption{}, nil
+	}
+	return []*models.AnswerOption{
+		{ID: 10, QuestionID: 1, Text: "Paris", IsCorrect: true},
+		{ID: 11, QuestionID: 1, Text: "Rome"},
+	}, nil
+}
+
+type visibilityAnswerRepo struct {
+	repository.StudentAnswerRepository
+}
+
+func (r *visibilityAnswerRepo) FindStudentAnswersByAttempt(ctx context.Context, collegeID int, attemptID int, limit, offset uint64) ([]*models.StudentAnswer, error) {
+	return []*models.StudentAnswer{}, nil
+}
+
+// revealsAnswers reports whether any correct-answer marker survived
+func revealsAnswers(questions []*models.Question) bool {
+	for _, question := range questions {
+		if question.CorrectAnswer != nil {
+			return true
+		}
+		for _, option := range question.Options {
+			if option.IsCorrect {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestQuestionReadsHideAnswersFromStudents(t *testing.T) {
+	ctx := context.Background()
+	svc := NewSimpleQuestionService(&visibilityQuestionRepo{}, &visibilityOptionRepo{})
+
+	for _, role := range []string{"student", ""} {
+		questions, err := svc.ListQuestionsByQuiz(ctx, 1, 5, 50, 0, role)
+		require.NoError(t, err)
+		assert.False(t, revealsAnswers(questions), "role %q", role)
+		assert.Len(t, questions[0].Options, 2, "options are still listed")
+		assert.Equal(t, 2, questions[0].Points)
+
+		for _, id := range []int{1, 2} {
+			question, err := svc.GetQuestion(ctx, 1, id, role)
+			require.NoError(t, err)
+			assert.False(t, revealsAnswers([]*models.Question{question}), "role %q question %d", role, id)
+		}
+	}
+
+	for _, role := range []string{"faculty", "admin"} {
+		questions, err := svc.ListQuestionsByQuiz(ctx, 1, 5, 50, 0, role)
+		require.NoError(t, err)
+		assert.True(t, questions[0].Options[0].IsCorrect, "role %q", role)
+		assert.NotNil(t, questions[1].CorrectAnswer, "role %q", role)
+	}
+}
+
+func TestGetAttemptHidesAnswersUntilSubmitted(t *testing.T) {
+	ctx := context.Background()
+	getAttempt := func(status models.QuizAttemptStatus, role string) *models.QuizAttempt {
+		svc := NewSimpleQuizAttemptService(
+			&autosaveAttemptRepo{attempt: &models.QuizAttempt{ID: 9, QuizID: 5, StudentID: 3, CollegeID: 1, Status: status}},
+			&visibilityAnswerRepo{},
+			&windowQuizRepo{quiz: &models.Quiz{ID: 5, CollegeID: 1}},
+			&visibilityQuestionRepo{},
+			&visibilityOptionRepo{},
+			nil,
+			nil,
+		)
+		attempt, err := svc.GetAttempt(ctx, 1, 9, role)
+		require.NoError(t, err)
+		return attempt
+	}
+
+	assert.False(t, revealsAnswers(getAttempt(models.QuizAttemptStatusInProgress, "student").Quiz.Questions))
+	assert.True(t, revealsAnswers(getAttempt(models.QuizAttemptStatusCompleted, "student").Quiz.Questions))
+	assert.True(t, revealsAnswers(getAttempt(models.QuizAttemptStatusGraded, "student").Quiz.Questions))
+	assert.True(t, revealsAnswers(getAttempt(models.QuizAttemptStatusInProgress, "faculty").Quiz.Questions))
+}
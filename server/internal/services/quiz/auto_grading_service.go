@@ -126,28 +126,53 @@ func (s *autoGradingService) AutoGradeAnswer(ctx context.Context, collegeID int,
 	return nil
 }
 
-// gradeMultipleChoice grades multiple choice and true/false questions
+// gradeMultipleChoice grades multiple choice and true/false questions.
+// The answer is correct only when exactly the correct options are selected.
+// Partial scoring awards points * (correct selected - wrong selected) / correct
+// options, rounded down and never below zero; all or nothing awards the full
+// points for a correct answer and none otherwise.
 func (s *autoGradingService) gradeMultipleChoice(question *models.Question, answer *models.StudentAnswer) (bool, int) {
 	if answer.SelectedOptionID == nil || len(*answer.SelectedOptionID) == 0 {
 		return false, 0
 	}
 
 	// Get correct answer options
-	correctOptions := s.getCorrectOptions(question)
+	correctOptions := make(map[int]bool)
+	for _, correctOpt := range s.getCorrectOptions(question) {
+		if correctOptID, err := strconv.Atoi(correctOpt); err == nil {
+			correctOptions[correctOptID] = true
+		}
+	}
 	if len(correctOptions) == 0 {
 		// If no correct options defined, question can't be graded
 		return false, 0
 	}
 
-	// Check if selected option is correct
-	selectedOption := (*answer.SelectedOptionID)[0]
-	for _, correctOpt := range correctOptions {
-		if correctOptID, err := strconv.Atoi(correctOpt); err == nil && selectedOption == correctOptID {
-			return true, question.Points
+	// Count each selected option once
+	selected := make(map[int]bool, len(*answer.SelectedOptionID))
+	correctSelected, wrongSelected := 0, 0
+	for _, optionID := range *answer.SelectedOptionID {
+		if selected[optionID] {
+			continue
+		}
+		selected[optionID] = true
+		if correctOptions[optionID] {
+			correctSelected++
+		} else {
+			wrongSelected++
 		}
 	}
 
-	return false, 0
+	isCorrect := correctSelected == len(correctOptions) && wrongSelected == 0
+	if isCorrect {
+		return true, question.Points
+	}
+	if question.ScoringMode != models.ScoringPartial {
+		return false, 0
+	}
+
+	net := max(correctSelected-wrongSelected, 0)
+	return false, question.Points * net / len(correctOptions)
 }
 
 // gradeShortAnswer grades short answer questions using exact or partial match
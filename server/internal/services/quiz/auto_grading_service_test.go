@@ -0,0 +1,74 @@
+package quiz
+
+import (
+	"testing"
+
+	"eduhub/server/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGradeMultipleChoice(t *testing.T) {
+	// Options 1, 2 and 3 are correct; 4 and 5 are not
+	question := func(mode models.ScoringMode) *models.Question {
+		return &models.Question{
+			Type:        models.MultipleChoice,
+			Points:      6,
+			ScoringMode: mode,
+			Options: []*models.AnswerOption{
+				{ID: 1, IsCorrect: true},
+				{ID: 2, IsCorrect: true},
+				{ID: 3, IsCorrect: true},
+				{ID: 4},
+				{ID: 5},
+			},
+		}
+	}
+	answer := func(selected ...int) *models.StudentAnswer {
+		return &models.StudentAnswer{SelectedOptionID: &selected}
+	}
+
+	tests := []struct {
+		name        string
+		mode        models.ScoringMode
+		selected    []int
+		wantCorrect bool
+		wantPoints  int
+	}{
+		{"partial fully correct", models.ScoringPartial, []int{3, 1, 2}, true, 6},
+		{"partial partially correct", models.ScoringPartial, []int{1, 2}, false, 4},
+		{"partial over-selected", models.ScoringPartial, []int{1, 2, 3, 4}, false, 4},
+		{"partial more wrong than right clamps at zero", models.ScoringPartial, []int{1, 4, 5}, false, 0},
+		{"partial repeated selection counts once", models.ScoringPartial, []int{1, 1, 1}, false, 2},
+		{"all or nothing fully correct", models.ScoringAllOrNothing, []int{1, 2, 3}, true, 6},
+		{"all or nothing partially correct", models.ScoringAllOrNothing, []int{1, 2}, false, 0},
+		{"all or nothing over-selected", models.ScoringAllOrNothing, []int{1, 2, 3, 4}, false, 0},
+		{"unset mode is all or nothing", "", []int{1, 2}, false, 0},
+	}
+
+	svc := &autoGradingService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			correct, points := svc.gradeMultipleChoice(question(tt.mode), answer(tt.selected...))
+			assert.Equal(t, tt.wantCorrect, correct)
+			assert.Equal(t, tt.wantPoints, points)
+		})
+	}
+}
+
+func TestGradeMultipleChoiceSingleAnswer(t *testing.T) {
+	question := &models.Question{
+		Type:    models.TrueFalse,
+		Points:  2,
+		Options: []*models.AnswerOption{{ID: 7, IsCorrect: true}, {ID: 8}},
+	}
+	svc := &autoGradingService{}
+
+	correct, points := svc.gradeMultipleChoice(question, &models.StudentAnswer{SelectedOptionID: &[]int{7}})
+	assert.True(t, correct)
+	assert.Equal(t, 2, points)
+
+	correct, points = svc.gradeMultipleChoice(question, &models.StudentAnswer{SelectedOptionID: &[]int{8}})
+	assert.False(t, correct)
+	assert.Equal(t, 0, points)
+}
@@ -0,0 +1,95 @@
+package quiz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"eduhub/server/internal/models"
+)
+
+// ErrAttemptNotInProgress is returned when answers are saved to an attempt
+// that has already been submitted or graded
+var ErrAttemptNotInProgress = errors.New("quiz attempt is not in progress")
+
+// maxAutosaveAnswers bounds a single autosave request
+const maxAutosaveAnswers = 500
+
+// AutosaveResult tells the client when its answers were stored so it can show
+// "saved at" and compare against the answers it recovers after a reconnect
+type AutosaveResult struct {
+	AttemptID int       `json:"attempt_id"`
+	Saved     int       `json:"saved"`
+	SavedAt   time.Time `json:"saved_at"`
+}
+
+// AutosaveAnswers stores the current answers of an in-progress attempt without
+// submitting it. Answers may be partial or blank; they are checked and graded
+// only on submit. Grading fields sent by the client are ignored.
+func (s *simpleQuizAttemptService) AutosaveAnswers(ctx context.Context, collegeID, attemptID, studentID int, answers []models.StudentAnswer) (*AutosaveResult, error) {
+	if len(answers) == 0 {
+		return nil, fmt.Errorf("at least one answer is required")
+	}
+	if len(answers) > maxAutosaveAnswers {
+		return nil, fmt.Errorf("at most %d answers can be autosaved at once", maxAutosaveAnswers)
+	}
+
+	attempt, err := s.ownAttempt(ctx, collegeID, attemptID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if attempt.Status != models.QuizAttemptStatusInProgress {
+		return nil, fmt.Errorf("%w: attempt is %s", ErrAttemptNotInProgress, attempt.Status)
+	}
+
+	batch := make([]*models.StudentAnswer, 0, len(answers))
+	seen := make(map[int]bool, len(answers))
+	for i := range answers {
+		answer := &answers[i]
+		if answer.QuestionID == 0 {
+			return nil, fmt.Errorf("question ID is required")
+		}
+		if seen[answer.QuestionID] {
+			return nil, fmt.Errorf("question %d appears more than once", answer.QuestionID)
+		}
+		seen[answer.QuestionID] = true
+
+		question, err := s.questionRepo.GetQuestionByID(ctx, collegeID, answer.QuestionID)
+		if err != nil || question == nil || question.QuizID != attempt.QuizID {
+			return nil, fmt.Errorf("question with ID %d does not belong to attempt's quiz", answer.QuestionID)
+		}
+
+		answer.QuizAttemptID = attemptID
+		answer.IsCorrect = nil
+		answer.PointsAwarded = nil
+		batch = append(batch, answer)
+	}
+
+	savedAt := time.Now().UTC()
+	if err := s.answerRepo.AutosaveStudentAnswers(ctx, batch, savedAt); err != nil {
+		return nil, err
+	}
+	return &AutosaveResult{AttemptID: attemptID, Saved: len(batch), SavedAt: savedAt}, nil
+}
+
+// GetSavedAnswers returns the answers stored so far for the student's attempt,
+// letting a client restore its state after a crash or reconnect
+func (s *simpleQuizAttemptService) GetSavedAnswers(ctx context.Context, collegeID, attemptID, studentID int) ([]*models.StudentAnswer, error) {
+	if _, err := s.ownAttempt(ctx, collegeID, attemptID, studentID); err != nil {
+		return nil, err
+	}
+	return s.answerRepo.FindStudentAnswersByAttempt(ctx, collegeID, attemptID, maxAutosaveAnswers, 0)
+}
+
+// ownAttempt loads an attempt and checks that it belongs to the student
+func (s *simpleQuizAttemptService) ownAttempt(ctx context.Context, collegeID, attemptID, studentID int) (*models.QuizAttempt, error) {
+	attempt, err := s.attemptRepo.GetQuizAttemptByID(ctx, collegeID, attemptID)
+	if err != nil {
+		return nil, fmt.Errorf("attempt not found")
+	}
+	if attempt.StudentID != studentID {
+		return nil, fmt.Errorf("unauthorized")
+	}
+	return attempt, nil
+}
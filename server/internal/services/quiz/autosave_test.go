@@ -0,0 +1,107 @@
+package quiz
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type autosaveAttemptRepo struct {
+	repository.QuizAttemptRepository
+	attempt *models.QuizAttempt
+}
+
+func (r *autosaveAttemptRepo) GetQuizAttemptByID(ctx context.Context, collegeID int, attemptID int) (*models.QuizAttempt, error) {
+	if r.attempt == nil || r.attempt.ID != attemptID {
+		return nil, errors.New("not found")
+	}
+	return r.attempt, nil
+}
+
+type autosaveQuestionRepo struct {
+	repository.QuestionRepository
+}
+
+func (r *autosaveQuestionRepo) GetQuestionByID(ctx context.Context, collegeID int, questionID int) (*models.Question, error) {
+	// Questions 1-10 belong to quiz 5, the rest to another quiz
+	quizID := 5
+	if questionID > 10 {
+		quizID = 6
+	}
+	return &models.Question{ID: questionID, QuizID: quizID}, nil
+}
+
+type autosaveAnswerRepo struct {
+	repository.StudentAnswerRepository
+	saved   []*models.StudentAnswer
+	savedAt time.Time
+}
+
+func (r *autosaveAnswerRepo) AutosaveStudentAnswers(ctx context.Context, answers []*models.StudentAnswer, savedAt time.Time) error {
+	r.saved = answers
+	r.savedAt = savedAt
+	return nil
+}
+
+func newAutosaveService(status models.QuizAttemptStatus) (*simpleQuizAttemptService, *autosaveAnswerRepo) {
+	answers := &autosaveAnswerRepo{}
+	return &simpleQuizAttemptService{
+		attemptRepo:  &autosaveAttemptRepo{attempt: &models.QuizAttempt{ID: 9, QuizID: 5, StudentID: 3, CollegeID: 1, Status: status}},
+		answerRepo:   answers,
+		questionRepo: &autosaveQuestionRepo{},
+	}, answers
+}
+
+func TestAutosaveAnswers(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("saves partial answers and returns the server time", func(t *testing.T) {
+		svc, repo := newAutosaveService(models.QuizAttemptStatusInProgress)
+		correct := true
+		points := 10
+
+		result, err := svc.AutosaveAnswers(ctx, 1, 9, 3, []models.StudentAnswer{
+			{QuestionID: 1, AnswerText: "draft", IsCorrect: &correct, PointsAwarded: &points},
+			{QuestionID: 2},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Saved)
+		assert.Equal(t, repo.savedAt, result.SavedAt)
+		require.Len(t, repo.saved, 2)
+		assert.Equal(t, 9, repo.saved[0].QuizAttemptID)
+		assert.Nil(t, repo.saved[0].IsCorrect, "clients cannot grade themselves")
+		assert.Nil(t, repo.saved[0].PointsAwarded)
+	})
+
+	t.Run("rejects attempts that are no longer in progress", func(t *testing.T) {
+		svc, repo := newAutosaveService(models.QuizAttemptStatusCompleted)
+
+		_, err := svc.AutosaveAnswers(ctx, 1, 9, 3, []models.StudentAnswer{{QuestionID: 1}})
+
+		assert.ErrorIs(t, err, ErrAttemptNotInProgress)
+		assert.Nil(t, repo.saved)
+	})
+
+	t.Run("rejects another student's attempt", func(t *testing.T) {
+		svc, _ := newAutosaveService(models.QuizAttemptStatusInProgress)
+
+		_, err := svc.AutosaveAnswers(ctx, 1, 9, 4, []models.StudentAnswer{{QuestionID: 1}})
+		assert.EqualError(t, err, "unauthorized")
+	})
+
+	t.Run("rejects questions from another quiz", func(t *testing.T) {
+		svc, repo := newAutosaveService(models.QuizAttemptStatusInProgress)
+
+		_, err := svc.AutosaveAnswers(ctx, 1, 9, 3, []models.StudentAnswer{{QuestionID: 1}, {QuestionID: 11}})
+		assert.Error(t, err)
+		assert.Nil(t, repo.saved)
+	})
+}
@@ -0,0 +1,87 @@
+package quiz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+)
+
+var (
+	// ErrQuizNotYetOpen is returned when an attempt is started before the quiz's AvailableFrom
+	ErrQuizNotYetOpen = errors.New("quiz is not yet open")
+	// ErrQuizClosed is returned when an attempt is started after the quiz's AvailableUntil
+	// and the student has no extension covering the current time
+	ErrQuizClosed = errors.New("quiz is closed")
+	// ErrQuizNotInSection is returned when a quiz set for one section of the
+	// course is started by a student outside it
+	ErrQuizNotInSection = errors.New("quiz is only open to another section of the course")
+	// ErrSectionNotFound is returned when a quiz is set for a section that is
+	// not part of its course
+	ErrSectionNotFound = repository.ErrSectionNotFound
+	// ErrQuizNotFound is returned when the quiz does not exist in the college
+	ErrQuizNotFound = repository.ErrQuizNotFound
+	// ErrQuizExtensionNotFound is returned when revoking an extension the
+	// student was never granted
+	ErrQuizExtensionNotFound = repository.ErrQuizExtensionNotFound
+)
+
+// checkQuizWindow reports whether an attempt may start at now. An extension
+// can only push the closing time later for its student; it never opens a quiz
+// early or cuts short a window that was widened after it was granted.
+func checkQuizWindow(quiz *models.Quiz, extension *models.QuizExtension, now time.Time) error {
+	if quiz.AvailableFrom != nil && now.Before(*quiz.AvailableFrom) {
+		return fmt.Errorf("%w: opens at %s", ErrQuizNotYetOpen, quiz.AvailableFrom.Format(time.RFC3339))
+	}
+
+	closesAt := quiz.AvailableUntil
+	if extension != nil && closesAt != nil && extension.AvailableUntil.After(*closesAt) {
+		closesAt = &extension.AvailableUntil
+	}
+	if closesAt != nil && now.After(*closesAt) {
+		return fmt.Errorf("%w: closed at %s", ErrQuizClosed, closesAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// validateQuizWindow rejects a window that closes before it opens
+func validateQuizWindow(quiz *models.Quiz) error {
+	if quiz.AvailableFrom != nil && quiz.AvailableUntil != nil && !quiz.AvailableUntil.After(*quiz.AvailableFrom) {
+		return fmt.Errorf("available_until must be after available_from")
+	}
+	return nil
+}
+
+// GrantExtension lets a student start the quiz until extension.AvailableUntil,
+// replacing any extension the student already had.
+func (s *quizService) GrantExtension(ctx context.Context, extension *models.QuizExtension) error {
+	if extension.QuizID == 0 || extension.StudentID == 0 {
+		return fmt.Errorf("quiz ID and student ID are required")
+	}
+	if extension.AvailableUntil.IsZero() {
+		return fmt.Errorf("available_until is required")
+	}
+
+	quiz, err := s.quizRepo.GetQuizByID(ctx, extension.CollegeID, extension.QuizID)
+	if err != nil {
+		return fmt.Errorf("quiz verification failed: %w", err)
+	}
+	if quiz.AvailableFrom != nil && !extension.AvailableUntil.After(*quiz.AvailableFrom) {
+		return fmt.Errorf("extension must end after the quiz opens")
+	}
+
+	return s.quizRepo.UpsertQuizExtension(ctx, extension)
+}
+
+// ListExtensions lists the extensions granted for a quiz.
+func (s *quizService) ListExtensions(ctx context.Context, collegeID int, quizID int) ([]*models.QuizExtension, error) {
+	return s.quizRepo.ListQuizExtensions(ctx, collegeID, quizID)
+}
+
+// RevokeExtension removes a student's extension for a quiz.
+func (s *quizService) RevokeExtension(ctx context.Context, collegeID int, quizID int, studentID int) error {
+	return s.quizRepo.DeleteQuizExtension(ctx, collegeID, quizID, studentID)
+}
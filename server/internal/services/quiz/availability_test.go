@@ -0,0 +1,132 @@
+package quiz
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type windowQuizRepo struct {
+	repository.QuizRepository
+	quiz       *models.Quiz
+	extensions map[int]*models.QuizExtension
+	// sectionStudents are the students the quiz's section is open to
+	sectionStudents map[int]bool
+}
+
+func (r *windowQuizRepo) GetQuizByID(ctx context.Context, collegeID int, quizID int) (*models.Quiz, error) {
+	return r.quiz, nil
+}
+
+func (r *windowQuizRepo) GetQuizExtension(ctx context.Context, collegeID int, quizID int, studentID int) (*models.QuizExtension, error) {
+	return r.extensions[studentID], nil
+}
+
+func (r *windowQuizRepo) IsQuizOpenToStudent(ctx context.Context, collegeID int, quizID int, studentID int) (bool, error) {
+	return r.quiz.SectionID == nil || r.sectionStudents[studentID], nil
+}
+
+type windowAttemptRepo struct {
+	repository.QuizAttemptRepository
+	created []*models.QuizAttempt
+}
+
+func (r *windowAttemptRepo) CreateQuizAttempt(ctx context.Context, attempt *models.QuizAttempt) error {
+	r.created = append(r.created, attempt)
+	return nil
+}
+
+func startAttemptWithWindow(t *testing.T, from, until time.Time, extensions map[int]*models.QuizExtension) (*models.QuizAttempt, *windowAttemptRepo, error) {
+	t.Helper()
+	attempts := &windowAttemptRepo{}
+	svc := &simpleQuizAttemptService{
+		attemptRepo: attempts,
+		quizRepo: &windowQuizRepo{
+			quiz:       &models.Quiz{ID: 5, CollegeID: 1, AvailableFrom: &from, AvailableUntil: &until},
+			extensions: extensions,
+		},
+	}
+	attempt, err := svc.StartAttempt(context.Background(), 1, 5, 3)
+	return attempt, attempts, err
+}
+
+func TestStartAttemptAvailabilityWindow(t *testing.T) {
+	now := time.Now()
+
+	t.Run("before open", func(t *testing.T) {
+		_, attempts, err := startAttemptWithWindow(t, now.Add(time.Hour), now.Add(2*time.Hour), nil)
+		assert.ErrorIs(t, err, ErrQuizNotYetOpen)
+		assert.Empty(t, attempts.created)
+	})
+
+	t.Run("in window", func(t *testing.T) {
+		attempt, attempts, err := startAttemptWithWindow(t, now.Add(-time.Hour), now.Add(time.Hour), nil)
+		require.NoError(t, err)
+		assert.Equal(t, models.QuizAttemptStatusInProgress, attempt.Status)
+		assert.Len(t, attempts.created, 1)
+	})
+
+	t.Run("after close", func(t *testing.T) {
+		_, attempts, err := startAttemptWithWindow(t, now.Add(-2*time.Hour), now.Add(-time.Hour), nil)
+		assert.ErrorIs(t, err, ErrQuizClosed)
+		assert.NotErrorIs(t, err, ErrQuizNotYetOpen)
+		assert.Empty(t, attempts.created)
+	})
+
+	t.Run("extension reopens a closed quiz for its student", func(t *testing.T) {
+		extensions := map[int]*models.QuizExtension{3: {QuizID: 5, StudentID: 3, AvailableUntil: now.Add(time.Hour)}}
+		_, attempts, err := startAttemptWithWindow(t, now.Add(-2*time.Hour), now.Add(-time.Hour), extensions)
+		require.NoError(t, err)
+		assert.Len(t, attempts.created, 1)
+	})
+
+	t.Run("expired extension is still closed", func(t *testing.T) {
+		extensions := map[int]*models.QuizExtension{3: {QuizID: 5, StudentID: 3, AvailableUntil: now.Add(-30 * time.Minute)}}
+		_, _, err := startAttemptWithWindow(t, now.Add(-2*time.Hour), now.Add(-time.Hour), extensions)
+		assert.ErrorIs(t, err, ErrQuizClosed)
+	})
+
+	t.Run("extension does not open a quiz early", func(t *testing.T) {
+		extensions := map[int]*models.QuizExtension{3: {QuizID: 5, StudentID: 3, AvailableUntil: now.Add(3 * time.Hour)}}
+		_, _, err := startAttemptWithWindow(t, now.Add(time.Hour), now.Add(2*time.Hour), extensions)
+		assert.ErrorIs(t, err, ErrQuizNotYetOpen)
+	})
+}
+
+func TestCheckQuizWindowUnbounded(t *testing.T) {
+	assert.NoError(t, checkQuizWindow(&models.Quiz{}, nil, time.Now()))
+}
+
+func TestStartAttemptSectionQuiz(t *testing.T) {
+	section := 4
+	start := func(sectionStudents map[int]bool) (*windowAttemptRepo, error) {
+		attempts := &windowAttemptRepo{}
+		svc := &simpleQuizAttemptService{
+			attemptRepo: attempts,
+			quizRepo: &windowQuizRepo{
+				quiz:            &models.Quiz{ID: 5, CollegeID: 1, SectionID: &section},
+				sectionStudents: sectionStudents,
+			},
+		}
+		_, err := svc.StartAttempt(context.Background(), 1, 5, 3)
+		return attempts, err
+	}
+
+	t.Run("student in the section", func(t *testing.T) {
+		attempts, err := start(map[int]bool{3: true})
+		require.NoError(t, err)
+		assert.Len(t, attempts.created, 1)
+	})
+
+	t.Run("student outside the section", func(t *testing.T) {
+		attempts, err := start(map[int]bool{8: true})
+		assert.ErrorIs(t, err, ErrQuizNotInSection)
+		assert.Empty(t, attempts.created)
+	})
+}
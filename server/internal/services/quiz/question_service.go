@@ -6,6 +6,7 @@ import (
 
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/repository"
+	"eduhub/server/internal/validation"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -32,8 +33,9 @@ type QuestionService interface {
 	DeleteQuestion(ctx context.Context, collegeID int, questionID int) error
 
 	// FindQuestionsByQuiz retrieves questions for a specific quiz with pagination.
-	// Optionally includes answer options in the response.
-	FindQuestionsByQuiz(ctx context.Context, collegeID int, quizID int, limit, offset uint64, withOptions bool) ([]*models.Question, error)
+	// Optionally includes answer options in the response; their correct-answer
+	// markers are only kept for staff roles.
+	FindQuestionsByQuiz(ctx context.Context, collegeID int, quizID int, limit, offset uint64, withOptions bool, role string) ([]*models.Question, error)
 
 	// CountQuestionsByQuiz returns the total number of questions for a quiz.
 	CountQuestionsByQuiz(ctx context.Context, collegeID int, quizID int) (int, error)
@@ -81,7 +83,7 @@ func NewQuestionService(
 		quizRepo:         quizRepo,
 		courseRepo:       courseRepo,
 		collegeRepo:      collegeRepo,
-		validate:         validator.New(),
+		validate:         validation.New(),
 	}
 }
 
@@ -218,8 +220,9 @@ func (s *questionService) DeleteQuestion(ctx context.Context, collegeID int, que
 }
 
 // FindQuestionsByQuiz retrieves questions for a specific quiz with pagination.
-// Optionally includes answer options in the response.
-func (s *questionService) FindQuestionsByQuiz(ctx context.Context, collegeID int, quizID int, limit, offset uint64, withOptions bool) ([]*models.Question, error) {
+// Optionally includes answer options in the response. Callers that are not
+// staff never receive the correct answers.
+func (s *questionService) FindQuestionsByQuiz(ctx context.Context, collegeID int, quizID int, limit, offset uint64, withOptions bool, role string) ([]*models.Question, error) {
 	// Verify quiz exists and belongs to the college
 	_, err := s.quizRepo.GetQuizByID(ctx, collegeID, quizID)
 	if err != nil {
@@ -242,6 +245,9 @@ func (s *questionService) FindQuestionsByQuiz(ctx context.Context, collegeID int
 		}
 	}
 
+	if !answersVisibleTo(role) {
+		stripAnswers(questions)
+	}
 	return questions, nil
 }
 
@@ -25,6 +25,10 @@ func NewSimpleQuestionService(
 }
 
 func (s *simpleQuestionService) CreateQuestion(ctx context.Context, collegeID int, question *models.Question) error {
+	if !question.ScoringMode.Valid() {
+		return fmt.Errorf("invalid scoring mode: %s", question.ScoringMode)
+	}
+
 	if err := s.questionRepo.CreateQuestion(ctx, question); err != nil {
 		return err
 	}
@@ -39,7 +43,9 @@ func (s *simpleQuestionService) CreateQuestion(ctx context.Context, collegeID in
 	return nil
 }
 
-func (s *simpleQuestionService) GetQuestion(ctx context.Context, collegeID, questionID int) (*models.Question, error) {
+// GetQuestion returns a question with its options. Correct answers are only
+// included for staff roles.
+func (s *simpleQuestionService) GetQuestion(ctx context.Context, collegeID, questionID int, role string) (*models.Question, error) {
 	question, err := s.questionRepo.GetQuestionByID(ctx, collegeID, questionID)
 	if err != nil {
 		return nil, err
@@ -51,10 +57,17 @@ func (s *simpleQuestionService) GetQuestion(ctx context.Context, collegeID, ques
 	}
 	question.Options = options
 
+	if !answersVisibleTo(role) {
+		stripAnswers([]*models.Question{question})
+	}
 	return question, nil
 }
 
 func (s *simpleQuestionService) UpdateQuestion(ctx context.Context, collegeID int, question *models.Question) error {
+	if !question.ScoringMode.Valid() {
+		return fmt.Errorf("invalid scoring mode: %s", question.ScoringMode)
+	}
+
 	if err := s.questionRepo.UpdateQuestion(ctx, collegeID, question); err != nil {
 		return err
 	}
@@ -88,7 +101,9 @@ func (s *simpleQuestionService) DeleteQuestion(ctx context.Context, collegeID, q
 	return s.questionRepo.DeleteQuestion(ctx, collegeID, questionID)
 }
 
-func (s *simpleQuestionService) ListQuestionsByQuiz(ctx context.Context, collegeID, quizID int, limit, offset uint64) ([]*models.Question, error) {
+// ListQuestionsByQuiz returns a page of a quiz's questions with their options.
+// Correct answers are only included for staff roles.
+func (s *simpleQuestionService) ListQuestionsByQuiz(ctx context.Context, collegeID, quizID int, limit, offset uint64, role string) ([]*models.Question, error) {
 	questions, err := s.questionRepo.FindQuestionsByQuiz(ctx, collegeID, quizID, limit, offset)
 	if err != nil {
 		return nil, err
@@ -102,14 +117,17 @@ func (s *simpleQuestionService) ListQuestionsByQuiz(ctx context.Context, college
 		question.Options = options
 	}
 
+	if !answersVisibleTo(role) {
+		stripAnswers(questions)
+	}
 	return questions, nil
 }
 
 // Interface for handler compatibility
 type QuestionServiceSimple interface {
 	CreateQuestion(ctx context.Context, collegeID int, question *models.Question) error
-	GetQuestion(ctx context.Context, collegeID, questionID int) (*models.Question, error)
+	GetQuestion(ctx context.Context, collegeID, questionID int, role string) (*models.Question, error)
 	UpdateQuestion(ctx context.Context, collegeID int, question *models.Question) error
 	DeleteQuestion(ctx context.Context, collegeID, questionID int) error
-	ListQuestionsByQuiz(ctx context.Context, collegeID, quizID int, limit, offset uint64) ([]*models.Question, error)
+	ListQuestionsByQuiz(ctx context.Context, collegeID, quizID int, limit, offset uint64, role string) ([]*models.Question, error)
 }
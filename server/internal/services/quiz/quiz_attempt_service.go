@@ -7,6 +7,7 @@ import (
 
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/repository"
+	"eduhub/server/internal/validation"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -62,7 +63,7 @@ func NewQuizAttemptService(
 		studentAnswerRepo: studentAnswerRepo,
 		quizRepo:         quizRepo,
 		collegeRepo:      collegeRepo,
-		validate:         validator.New(),
+		validate:         validation.New(),
 	}
 }
 
@@ -102,6 +103,15 @@ func (s *quizAttemptService) StartQuizAttempt(ctx context.Context, collegeID int
 		return fmt.Errorf("quiz with ID %d not found in college %d", attempt.QuizID, collegeID)
 	}
 
+	// Enforce the availability window, honouring any extension for this student
+	extension, err := s.quizRepo.GetQuizExtension(ctx, collegeID, attempt.QuizID, attempt.StudentID)
+	if err != nil {
+		return fmt.Errorf("failed to check quiz extension: %w", err)
+	}
+	if err := checkQuizWindow(quiz, extension, time.Now()); err != nil {
+		return err
+	}
+
 	// Set attempt properties
 	attempt.CollegeID = collegeID
 	attempt.CourseID = quiz.CourseID
@@ -7,6 +7,7 @@ import (
 
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/repository"
+	"eduhub/server/internal/services/notification"
 )
 
 // Simple wrapper service for quiz attempts
@@ -17,6 +18,8 @@ type simpleQuizAttemptService struct {
 	questionRepo     repository.QuestionRepository
 	answerOptionRepo repository.AnswerOptionRepository
     autoGrader       AutoGradingService
+	// inbox receives auto-submission notices; nil disables them
+	inbox            notification.Notifier
 }
 
 func NewSimpleQuizAttemptService(
@@ -26,6 +29,7 @@ func NewSimpleQuizAttemptService(
 	questionRepo repository.QuestionRepository,
     answerOptionRepo repository.AnswerOptionRepository,
     autoGrader AutoGradingService,
+	inbox notification.Notifier,
 ) QuizAttemptServiceSimple {
 	return &simpleQuizAttemptService{
 		attemptRepo: attemptRepo,
@@ -34,14 +38,35 @@ func NewSimpleQuizAttemptService(
 		questionRepo:     questionRepo,
         answerOptionRepo: answerOptionRepo,
         autoGrader:       autoGrader,
+		inbox:            inbox,
 	}
 }
 
 func (s *simpleQuizAttemptService) StartAttempt(ctx context.Context, collegeID, quizID, studentID int) (*models.QuizAttempt, error) {
 	// Verify quiz exists
-	_, err := s.quizRepo.GetQuizByID(ctx, collegeID, quizID)
+	quiz, err := s.quizRepo.GetQuizByID(ctx, collegeID, quizID)
 	if err != nil {
-		return nil, fmt.Errorf("quiz not found")
+		return nil, fmt.Errorf("failed to load quiz: %w", err)
+	}
+
+	// Enforce the availability window, honouring any extension for this student
+	extension, err := s.quizRepo.GetQuizExtension(ctx, collegeID, quizID, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check quiz extension: %w", err)
+	}
+	if err := checkQuizWindow(quiz, extension, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// A section quiz is only open to the students of that section
+	if quiz.SectionID != nil {
+		open, err := s.quizRepo.IsQuizOpenToStudent(ctx, collegeID, quizID, studentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check quiz section: %w", err)
+		}
+		if !open {
+			return nil, ErrQuizNotInSection
+		}
 	}
 
 	attempt := &models.QuizAttempt{
@@ -98,7 +123,9 @@ func (s *simpleQuizAttemptService) SubmitAttempt(ctx context.Context, collegeID,
     return gradedAttempt, nil
 }
 
-func (s *simpleQuizAttemptService) GetAttempt(ctx context.Context, collegeID, attemptID int) (*models.QuizAttempt, error) {
+// GetAttempt returns an attempt with its quiz, questions and saved answers.
+// Students see the correct answers only once the attempt is submitted.
+func (s *simpleQuizAttemptService) GetAttempt(ctx context.Context, collegeID, attemptID int, role string) (*models.QuizAttempt, error) {
 	attempt, err := s.attemptRepo.GetQuizAttemptByID(ctx, collegeID, attemptID)
 	if err != nil {
 		return nil, err
@@ -117,6 +144,10 @@ func (s *simpleQuizAttemptService) GetAttempt(ctx context.Context, collegeID, at
 				q.Options = options
 			}
 		}
+		submitted := attempt.Status == models.QuizAttemptStatusCompleted || attempt.Status == models.QuizAttemptStatusGraded
+		if !answersVisibleTo(role) && !submitted {
+			stripAnswers(questions)
+		}
 		attempt.Quiz.Questions = questions
 	}
 
@@ -141,7 +172,10 @@ func (s *simpleQuizAttemptService) GetQuizAttempts(ctx context.Context, collegeI
 type QuizAttemptServiceSimple interface {
 	StartAttempt(ctx context.Context, collegeID, quizID, studentID int) (*models.QuizAttempt, error)
 	SubmitAttempt(ctx context.Context, collegeID, attemptID, studentID int, answers []models.StudentAnswer) (*models.QuizAttempt, error)
-	GetAttempt(ctx context.Context, collegeID, attemptID int) (*models.QuizAttempt, error)
+	AutosaveAnswers(ctx context.Context, collegeID, attemptID, studentID int, answers []models.StudentAnswer) (*AutosaveResult, error)
+	GetSavedAnswers(ctx context.Context, collegeID, attemptID, studentID int) ([]*models.StudentAnswer, error)
+	GetAttempt(ctx context.Context, collegeID, attemptID int, role string) (*models.QuizAttempt, error)
 	GetStudentAttempts(ctx context.Context, collegeID, studentID int) ([]*models.QuizAttempt, error)
 	GetQuizAttempts(ctx context.Context, collegeID, quizID int) ([]*models.QuizAttempt, error)
+	CloseAbandonedAttempts(ctx context.Context, policy AbandonedAttemptPolicy) (int, error)
 }
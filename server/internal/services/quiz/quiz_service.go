@@ -6,6 +6,7 @@ import (
 
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/repository"
+	"eduhub/server/internal/validation"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -22,6 +23,10 @@ type QuizService interface {
 	// Returns an error if the quiz doesn't exist or doesn't belong to the college.
 	GetQuizByID(ctx context.Context, collegeID int, quizID int) (*models.Quiz, error)
 
+	// GetFullQuiz retrieves a quiz together with its questions and their answer options.
+	// The correct-answer markers are stripped unless role is a staff role.
+	GetFullQuiz(ctx context.Context, collegeID int, quizID int, role string) (*models.Quiz, error)
+
 	// UpdateQuiz updates an existing quiz's information.
 	// Validates input data and ensures the quiz exists within the college context.
 	UpdateQuiz(ctx context.Context, quiz *models.Quiz) error
@@ -37,12 +42,23 @@ type QuizService interface {
 	// CountQuizzesByCourse returns the total number of quizzes for a course.
 	// Used for pagination calculations and course statistics.
 	CountQuizzesByCourse(ctx context.Context, collegeID int, courseID int) (int, error)
+
+	// GrantExtension lets a student start a quiz after it closes, until the extension's deadline.
+	GrantExtension(ctx context.Context, extension *models.QuizExtension) error
+
+	// ListExtensions lists the per-student extensions granted for a quiz.
+	ListExtensions(ctx context.Context, collegeID int, quizID int) ([]*models.QuizExtension, error)
+
+	// RevokeExtension removes a student's extension for a quiz.
+	RevokeExtension(ctx context.Context, collegeID int, quizID int, studentID int) error
 }
 
 // quizService implements the QuizService interface.
 type quizService struct {
 	quizRepo         repository.QuizRepository
 	quizAttemptRepo  repository.QuizAttemptRepository
+	questionRepo     repository.QuestionRepository
+	answerOptionRepo repository.AnswerOptionRepository
 	courseRepo       repository.CourseRepository
 	collegeRepo      repository.CollegeRepository
 	enrollmentRepo   repository.EnrollmentRepository
@@ -54,17 +70,21 @@ type quizService struct {
 func NewQuizService(
 	quizRepo repository.QuizRepository,
 	quizAttemptRepo repository.QuizAttemptRepository,
+	questionRepo repository.QuestionRepository,
+	answerOptionRepo repository.AnswerOptionRepository,
 	courseRepo repository.CourseRepository,
 	collegeRepo repository.CollegeRepository,
 	enrollmentRepo repository.EnrollmentRepository,
 ) QuizService {
 	return &quizService{
-		quizRepo:        quizRepo,
-		quizAttemptRepo: quizAttemptRepo,
-		courseRepo:      courseRepo,
-		collegeRepo:     collegeRepo,
-		enrollmentRepo:  enrollmentRepo,
-		validate:        validator.New(),
+		quizRepo:         quizRepo,
+		quizAttemptRepo:  quizAttemptRepo,
+		questionRepo:     questionRepo,
+		answerOptionRepo: answerOptionRepo,
+		courseRepo:       courseRepo,
+		collegeRepo:      collegeRepo,
+		enrollmentRepo:   enrollmentRepo,
+		validate:         validation.New(),
 	}
 }
 
@@ -76,6 +96,9 @@ func (s *quizService) CreateQuiz(ctx context.Context, quiz *models.Quiz) error {
 	if err := s.validate.Struct(quiz); err != nil {
 		return fmt.Errorf("validation failed for quiz: %w", err)
 	}
+	if err := validateQuizWindow(quiz); err != nil {
+		return err
+	}
 
 	// Verify college exists
 	_, err := s.collegeRepo.GetCollegeByID(ctx, quiz.CollegeID)
@@ -102,6 +125,45 @@ func (s *quizService) GetQuizByID(ctx context.Context, collegeID int, quizID int
 	return s.quizRepo.GetQuizByID(ctx, collegeID, quizID)
 }
 
+// GetFullQuiz retrieves a quiz with its questions and answer options nested inside.
+// Options for all questions are loaded in a single query rather than one per question.
+func (s *quizService) GetFullQuiz(ctx context.Context, collegeID int, quizID int, role string) (*models.Quiz, error) {
+	quiz, err := s.quizRepo.GetQuizByID(ctx, collegeID, quizID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The question repository checks the quiz belongs to the college
+	questions, err := s.questionRepo.FindQuestionsByQuiz(ctx, collegeID, quizID, 1000, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load quiz questions: %w", err)
+	}
+	byID := make(map[int]*models.Question, len(questions))
+	questionIDs := make([]int, 0, len(questions))
+	for _, question := range questions {
+		question.Options = []*models.AnswerOption{}
+		byID[question.ID] = question
+		questionIDs = append(questionIDs, question.ID)
+	}
+
+	options, err := s.answerOptionRepo.FindAnswerOptionsByQuestions(ctx, questionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load answer options: %w", err)
+	}
+	for _, option := range options {
+		if question, ok := byID[option.QuestionID]; ok {
+			question.Options = append(question.Options, option)
+		}
+	}
+
+	if !answersVisibleTo(role) {
+		stripAnswers(questions)
+	}
+
+	quiz.Questions = questions
+	return quiz, nil
+}
+
 // UpdateQuiz updates an existing quiz's information.
 // Validates input data and ensures required fields are present.
 func (s *quizService) UpdateQuiz(ctx context.Context, quiz *models.Quiz) error {
@@ -119,6 +181,9 @@ func (s *quizService) UpdateQuiz(ctx context.Context, quiz *models.Quiz) error {
 	if quiz.ID == 0 {
 		return fmt.Errorf("quiz ID is required for update")
 	}
+	if err := validateQuizWindow(quiz); err != nil {
+		return err
+	}
 
 	// Update the quiz in repository
 	if err := s.quizRepo.UpdateQuiz(ctx, quiz); err != nil {
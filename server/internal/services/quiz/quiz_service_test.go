@@ -1,22 +1,85 @@
 package quiz
 
 import (
+	"context"
 	"testing"
 
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewQuizService(t *testing.T) {
-	service := NewQuizService(nil, nil, nil, nil, nil)
+	service := NewQuizService(nil, nil, nil, nil, nil, nil, nil)
 	assert.NotNil(t, service)
 }
 
 func TestQuizServiceInterface(t *testing.T) {
-	var service QuizService = NewQuizService(nil, nil, nil, nil, nil)
+	var service QuizService = NewQuizService(nil, nil, nil, nil, nil, nil, nil)
 	assert.NotNil(t, service)
 }
 
 func TestQuizService_MethodsExist(t *testing.T) {
-	service := NewQuizService(nil, nil, nil, nil, nil)
+	service := NewQuizService(nil, nil, nil, nil, nil, nil, nil)
 	assert.NotNil(t, service)
 }
+
+type fullQuizQuestionRepo struct {
+	repository.QuestionRepository
+	questions []*models.Question
+}
+
+func (r *fullQuizQuestionRepo) FindQuestionsByQuiz(ctx context.Context, collegeID int, quizID int, limit, offset uint64) ([]*models.Question, error) {
+	return r.questions, nil
+}
+
+type fullQuizOptionRepo struct {
+	repository.AnswerOptionRepository
+	options []*models.AnswerOption
+	calls   int
+}
+
+func (r *fullQuizOptionRepo) FindAnswerOptionsByQuestions(ctx context.Context, questionIDs []int) ([]*models.AnswerOption, error) {
+	r.calls++
+	return r.options, nil
+}
+
+func TestGetFullQuiz(t *testing.T) {
+	fixture := func() (QuizService, *fullQuizOptionRepo) {
+		answer := "42"
+		options := &fullQuizOptionRepo{options: []*models.AnswerOption{
+			{ID: 10, QuestionID: 1, Text: "Paris", IsCorrect: true},
+			{ID: 11, QuestionID: 1, Text: "Rome"},
+		}}
+		questions := &fullQuizQuestionRepo{questions: []*models.Question{
+			{ID: 1, QuizID: 5, Type: models.MultipleChoice},
+			{ID: 2, QuizID: 5, Type: models.ShortAnswer, CorrectAnswer: &answer},
+		}}
+		svc := NewQuizService(&windowQuizRepo{quiz: &models.Quiz{ID: 5, CollegeID: 1}}, nil, questions, options, nil, nil, nil)
+		return svc, options
+	}
+
+	t.Run("nests questions and options", func(t *testing.T) {
+		svc, options := fixture()
+		quiz, err := svc.GetFullQuiz(context.Background(), 1, 5, "faculty")
+		require.NoError(t, err)
+		require.Len(t, quiz.Questions, 2)
+		require.Len(t, quiz.Questions[0].Options, 2)
+		assert.True(t, quiz.Questions[0].Options[0].IsCorrect)
+		assert.Empty(t, quiz.Questions[1].Options)
+		assert.Equal(t, "42", *quiz.Questions[1].CorrectAnswer)
+		assert.Equal(t, 1, options.calls, "options are loaded in one query")
+	})
+
+	t.Run("hides answers from students", func(t *testing.T) {
+		svc, _ := fixture()
+		quiz, err := svc.GetFullQuiz(context.Background(), 1, 5, "student")
+		require.NoError(t, err)
+		for _, option := range quiz.Questions[0].Options {
+			assert.False(t, option.IsCorrect)
+		}
+		assert.Nil(t, quiz.Questions[1].CorrectAnswer)
+	})
+}
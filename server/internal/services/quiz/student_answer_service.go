@@ -6,6 +6,7 @@ import (
 
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/repository"
+	"eduhub/server/internal/validation"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -54,7 +55,7 @@ func NewStudentAnswerService(
 		quizAttemptRepo:   quizAttemptRepo,
 		questionRepo:      questionRepo,
 		collegeRepo:       collegeRepo,
-		validate:          validator.New(),
+		validate:          validation.New(),
 	}
 }
 
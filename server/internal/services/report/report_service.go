@@ -5,7 +5,6 @@ import (
 	"context"
 	"fmt"
 	"sort"
-	"strings"
 	"time"
 
 	"eduhub/server/internal/models"
@@ -175,7 +174,7 @@ func (s *reportService) GenerateAttendanceReport(ctx context.Context, collegeID,
 	})
 	for _, record := range records {
 		entry := perStudent[record.StudentID]
-		if strings.EqualFold(record.Status, "present") {
+		if models.IsAttendancePresent(record.Status) {
 			entry.present++
 		}
 		entry.total++
@@ -355,7 +354,7 @@ func summarizeAttendance(records []*models.Attendance) attendanceSummary {
 
 	for _, record := range records {
 		entry := perStudent[record.StudentID]
-		if strings.EqualFold(record.Status, "present") {
+		if models.IsAttendancePresent(record.Status) {
 			entry.present++
 			totalPresent++
 		}
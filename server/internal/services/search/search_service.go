@@ -0,0 +1,82 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+)
+
+const (
+	minQueryLength = 2
+	maxQueryLength = 100
+
+	defaultLimit = 20
+	maxLimit     = 50
+	// maxPerType caps each of students, courses and exams so one type can't
+	// crowd the others out of the results
+	maxPerType = 10
+)
+
+// ErrInvalidQuery is returned when the search term is too short or too long
+var ErrInvalidQuery = errors.New("search query must be between 2 and 100 characters")
+
+// Searcher describes who is searching, which decides what they can see
+type Searcher struct {
+	CollegeID int
+	Role      string
+	UserID    int
+	StudentID int
+}
+
+type SearchService interface {
+	Search(ctx context.Context, searcher Searcher, query string, limit int) ([]*models.SearchResult, error)
+}
+
+type searchService struct {
+	repo repository.SearchRepository
+}
+
+func NewSearchService(repo repository.SearchRepository) SearchService {
+	return &searchService{repo: repo}
+}
+
+// Search finds students, courses and exams matching query in the searcher's
+// college. Admins see everything; faculty see the students and exams of the
+// courses they teach; students see courses and the exams of their own courses
+// but never other students.
+func (s *searchService) Search(ctx context.Context, searcher Searcher, query string, limit int) ([]*models.SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if n := utf8.RuneCountInString(query); n < minQueryLength || n > maxQueryLength {
+		return nil, ErrInvalidQuery
+	}
+
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	limit = min(limit, maxLimit)
+
+	scope := models.SearchScope{CollegeID: searcher.CollegeID}
+	switch searcher.Role {
+	case "admin":
+		scope.IncludeStudents = true
+	case "faculty":
+		scope.IncludeStudents = true
+		scope.InstructorID = searcher.UserID
+	case "student":
+		scope.StudentID = searcher.StudentID
+	default:
+		return []*models.SearchResult{}, nil
+	}
+
+	// Faculty and students are always narrowed to their own courses; a
+	// missing ID must not widen that to the whole college
+	if (searcher.Role == "faculty" && scope.InstructorID == 0) || (searcher.Role == "student" && scope.StudentID == 0) {
+		return []*models.SearchResult{}, nil
+	}
+
+	return s.repo.Search(ctx, scope, query, min(limit, maxPerType), limit)
+}
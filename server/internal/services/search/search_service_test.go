@@ -0,0 +1,83 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"eduhub/server/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type scopeRepo struct {
+	scope   models.SearchScope
+	term    string
+	perType int
+	limit   int
+	called  bool
+}
+
+func (r *scopeRepo) Search(ctx context.Context, scope models.SearchScope, term string, perType, limit int) ([]*models.SearchResult, error) {
+	r.scope, r.term, r.perType, r.limit, r.called = scope, term, perType, limit, true
+	return []*models.SearchResult{}, nil
+}
+
+func TestSearchScopesByRole(t *testing.T) {
+	tests := []struct {
+		name     string
+		searcher Searcher
+		want     models.SearchScope
+	}{
+		{"admin sees the whole college", Searcher{CollegeID: 1, Role: "admin", UserID: 5},
+			models.SearchScope{CollegeID: 1, IncludeStudents: true}},
+		{"faculty see their own courses", Searcher{CollegeID: 1, Role: "faculty", UserID: 5},
+			models.SearchScope{CollegeID: 1, IncludeStudents: true, InstructorID: 5}},
+		{"students never see other students", Searcher{CollegeID: 1, Role: "student", UserID: 5, StudentID: 9},
+			models.SearchScope{CollegeID: 1, StudentID: 9}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &scopeRepo{}
+			_, err := NewSearchService(repo).Search(context.Background(), tt.searcher, "  alg ", 0)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, repo.scope)
+			assert.Equal(t, "alg", repo.term)
+			assert.Equal(t, maxPerType, repo.perType)
+			assert.Equal(t, defaultLimit, repo.limit)
+		})
+	}
+}
+
+func TestSearchRejectsUnscopedCallers(t *testing.T) {
+	for _, searcher := range []Searcher{
+		{CollegeID: 1, Role: "parent", UserID: 5},
+		{CollegeID: 1, Role: "student", UserID: 5},
+		{CollegeID: 1, Role: "faculty"},
+	} {
+		repo := &scopeRepo{}
+		results, err := NewSearchService(repo).Search(context.Background(), searcher, "alg", 10)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+		assert.False(t, repo.called, searcher.Role)
+	}
+}
+
+func TestSearchValidatesQueryAndLimit(t *testing.T) {
+	repo := &scopeRepo{}
+	svc := NewSearchService(repo)
+	admin := Searcher{CollegeID: 1, Role: "admin"}
+
+	_, err := svc.Search(context.Background(), admin, " a ", 10)
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+
+	_, err = svc.Search(context.Background(), admin, "algebra", 500)
+	require.NoError(t, err)
+	assert.Equal(t, maxLimit, repo.limit)
+
+	_, err = svc.Search(context.Background(), admin, "algebra", 4)
+	require.NoError(t, err)
+	assert.Equal(t, 4, repo.perType)
+}
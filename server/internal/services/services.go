@@ -2,6 +2,7 @@ package services
 
 import (
 	"log"
+	"time"
 
 	"eduhub/server/internal/cache"
 	"eduhub/server/internal/config"
@@ -24,15 +25,18 @@ import (
 	"eduhub/server/internal/services/facultytools"
 	"eduhub/server/internal/services/fee"
 	"eduhub/server/internal/services/file"
+	"eduhub/server/internal/services/filescan"
 	"eduhub/server/internal/services/forum"
 	"eduhub/server/internal/services/grades"
 	"eduhub/server/internal/services/lecture"
 	"eduhub/server/internal/services/notification"
+	"eduhub/server/internal/services/parentalert"
 	"eduhub/server/internal/services/placement"
 	"eduhub/server/internal/services/profile"
 	"eduhub/server/internal/services/quiz"
 	"eduhub/server/internal/services/report"
 	"eduhub/server/internal/services/role"
+	"eduhub/server/internal/services/search"
 	"eduhub/server/internal/services/selfservice"
 	"eduhub/server/internal/services/settings"
 	storageservice "eduhub/server/internal/services/storage"
@@ -41,6 +45,7 @@ import (
 	"eduhub/server/internal/services/user"
 	"eduhub/server/internal/services/webhook"
 	storageclient "eduhub/server/internal/storage"
+	"eduhub/server/logger"
 
 	minio "github.com/minio/minio-go/v7"
 )
@@ -51,6 +56,7 @@ type Services struct {
 	StudentService           student.StudentService
 	CollegeService           college.CollegeService
 	CourseService            course.CourseService
+	SectionService           course.SectionService
 	CourseMaterialService    course_material.CourseMaterialService
 	EnrollmentService        enrollment.EnrollmentService
 	GradeService             grades.GradeServices
@@ -75,19 +81,30 @@ type Services struct {
 	WebhookService           webhook.WebhookService
 	AuditService             audit.AuditService
 	EmailService             email.EmailService
+	EmailDeliveryService     email.DeliveryService
 	RoleService              role.RoleService
 	FeeService               fee.FeeService
 	TimetableService         timetable.TimetableService
 	ExamService              exam.ExamService
+	QuestionPaperService     exam.QuestionPaperService
 	PlacementService         placement.PlacementService
 	ForumService             forum.ForumService
 	SelfServiceService       selfservice.SelfServiceService
 	FacultyToolsService      facultytools.FacultyToolsService
 	SettingsService          settings.SettingsService
+	SearchService            search.SearchService
+	ParentAlertService       parentalert.ParentAlertService
 	DB                       *repository.DB
+	// RedisCache is nil when Redis is disabled or unreachable at startup
+	RedisCache *cache.RedisCache
+	// Locker serializes jobs across instances, or within this process only
+	// when Redis is unavailable
+	Locker cache.Locker
 }
 
-func NewServices(cfg *config.Config) *Services {
+// NewServices wires every service from cfg. Services that log get their
+// subsystem's logger from loggers.
+func NewServices(cfg *config.Config, loggers *logger.Subsystems) *Services {
 	kratosService := auth.NewKratosService()
 	ketoService := auth.NewKetoService()
 	hydraService := auth.NewHydraService() // returns nil when HYDRA_PUBLIC_URL is not set
@@ -100,10 +117,13 @@ func NewServices(cfg *config.Config) *Services {
 	gradeRepo := repository.NewGradeRepository(cfg.DB)
 	collegeRepo := repository.NewCollegeRepository(cfg.DB)
 	courseRepo := repository.NewCourseRepository(cfg.DB)
+	courseSectionRepo := repository.NewCourseSectionRepository(cfg.DB)
 	userRepo := repository.NewUserRepository(cfg.DB)
 	lectureRepo := repository.NewLectureRepository(cfg.DB)
 	quizRepo := repository.NewQuizRepository(cfg.DB)
 	quizAttemptRepo := repository.NewQuizAttemptRepository(cfg.DB)
+	questionRepo := repository.NewQuestionRepository(cfg.DB)
+	answerOptionRepo := repository.NewAnswerOptionRepository(cfg.DB)
 	calendarRepo := repository.NewCalendarRepository(cfg.DB)
 	departmentRepo := repository.NewDepartmentRepository(cfg.DB)
 
@@ -123,6 +143,7 @@ func NewServices(cfg *config.Config) *Services {
 	storageEndpoint := "localhost:9000"
 	storageUseSSL := false
 	storageRegion := ""
+	var storageURLExpiry, storageMaxURLExpiry time.Duration
 
 	if cfg.StorageConfig == nil {
 		log.Printf("WARNING: Using default storage configuration. Set STORAGE_BUCKET, STORAGE_ENDPOINT, STORAGE_REGION, STORAGE_ACCESS_KEY, and STORAGE_SECRET_KEY environment variables for production")
@@ -135,6 +156,8 @@ func NewServices(cfg *config.Config) *Services {
 		}
 		storageUseSSL = cfg.StorageConfig.UseSSL
 		storageRegion = cfg.StorageConfig.Region
+		storageURLExpiry = time.Duration(cfg.StorageConfig.PresignedURLExpirySeconds) * time.Second
+		storageMaxURLExpiry = time.Duration(cfg.StorageConfig.MaxPresignedURLExpirySeconds) * time.Second
 
 		if cfg.StorageConfig.AccessKey != "" && cfg.StorageConfig.SecretKey != "" {
 			client, err := storageclient.NewMinioClient(&storageclient.MinioConfig{
@@ -165,27 +188,61 @@ func NewServices(cfg *config.Config) *Services {
 		gradeRepo,
 	)
 	// systemService := system.NewSystemService(cfg.DB)
-	var attendanceService attendance.AttendanceService
+	var redisCache *cache.RedisCache
 	if cfg.RedisConfig != nil && cfg.RedisConfig.Enabled {
-		redisCache, err := cache.NewRedisCache(cfg.RedisConfig.ToRedisCacheConfig())
+		rc, err := cache.NewRedisCache(cfg.RedisConfig.ToRedisCacheConfig())
 		if err != nil {
-			log.Printf("failed to initialize Redis cache for QR attendance: %v (falling back to no cache)", err)
-			attendanceService = attendance.NewAttendanceService(attendanceRepo, studentRepo, enrollmentRepo)
+			log.Printf("failed to initialize Redis cache: %v (falling back to no cache)", err)
 		} else {
-			attendanceService = attendance.NewAttendanceServiceWithCache(attendanceRepo, studentRepo, enrollmentRepo, redisCache)
+			redisCache = rc
 		}
+	}
+
+	var attendanceService attendance.AttendanceService
+	if redisCache != nil {
+		attendanceService = attendance.NewAttendanceServiceWithCache(attendanceRepo, studentRepo, enrollmentRepo, redisCache)
 	} else {
 		attendanceService = attendance.NewAttendanceService(attendanceRepo, studentRepo, enrollmentRepo)
 	}
 	enrollmentService := enrollment.NewEnrollmentService(enrollmentRepo)
 	collegeService := college.NewCollegeService(collegeRepo)
 	courseService := course.NewCourseService(courseRepo, collegeRepo, userRepo)
+	sectionService := course.NewSectionService(courseSectionRepo, courseRepo)
 	gradeService := grades.NewGradeServices(gradeRepo, studentRepo, enrollmentRepo, courseRepo)
 	lectureService := lecture.NewLectureService(lectureRepo)
-	quizService := quiz.NewQuizService(quizRepo, quizAttemptRepo, courseRepo, collegeRepo, enrollmentRepo)
+	quizService := quiz.NewQuizService(quizRepo, quizAttemptRepo, questionRepo, answerOptionRepo, courseRepo, collegeRepo, enrollmentRepo)
 	calendarService := calendar.NewCalendarService(calendarRepo)
 	departmentService := department.NewDepartmentService(departmentRepo)
-	assignmentService := assignment.NewAssignmentService(assignmentRepo, minioClient)
+	var uploadCfg assignment.SubmissionUploadConfig
+	if cfg.StorageConfig != nil {
+		scanner, err := filescan.New(cfg.StorageConfig.FileScanner)
+		if err != nil {
+			// StorageConfig.Validate rejects unknown scanners, so this only happens with a hand-built config
+			log.Printf("failed to initialize file scanner: %v (uploads will be refused)", err)
+			scanner = filescan.UnavailableScanner{Err: err}
+		}
+		uploadCfg = assignment.SubmissionUploadConfig{
+			MaxSizeBytes:     cfg.StorageConfig.MaxSubmissionSizeBytes,
+			AllowedTypes:     cfg.StorageConfig.AllowedSubmissionTypes,
+			URLExpirySeconds: cfg.StorageConfig.PresignedURLExpirySeconds,
+			Scanner:          scanner,
+		}
+	}
+	var latePolicy assignment.LatePolicy
+	if cfg.AssignmentLatePolicyConfig != nil {
+		latePolicy = assignment.LatePolicy{
+			Policy:      cfg.AssignmentLatePolicyConfig.Policy,
+			Percent:     cfg.AssignmentLatePolicyConfig.Percent,
+			MaxPercent:  cfg.AssignmentLatePolicyConfig.MaxPercent,
+			CutoffAfter: cfg.AssignmentLatePolicyConfig.CutoffAfter,
+		}
+	}
+	// In-app notifications are created before the services that post to them
+	notificationRepo := repository.NewNotificationRepository(cfg.DB)
+	notificationLogger := loggers.For(logger.SubsystemNotification)
+	websocketService := notification.NewWebSocketService(notificationRepo, cfg.AppConfig.CORSOrigins, notificationLogger)
+	notificationService := notification.NewNotificationService(notificationRepo, websocketService, notificationLogger)
+	assignmentService := assignment.NewAssignmentService(assignmentRepo, minioClient, uploadCfg, latePolicy, notificationService)
 	userService := user.NewUserService(userRepo)
 	announcementService := announcement.NewAnnouncementService(announcementRepo)
 	profileService := profile.NewProfileService(profileRepo)
@@ -195,10 +252,8 @@ func NewServices(cfg *config.Config) *Services {
 	courseMaterialService := course_material.NewCourseMaterialService(courseRepo, courseMaterialRepo, fileRepo, studentRepo)
 
 	// New services
-	questionRepo := repository.NewQuestionRepository(cfg.DB)
-	// quizAttemptRepo already created earlier
+	// quizAttemptRepo, questionRepo and answerOptionRepo already created earlier
 	studentAnswerRepo := repository.NewStudentAnswerRepository(cfg.DB)
-	notificationRepo := repository.NewNotificationRepository(cfg.DB)
 	webhookRepo := repository.NewWebhookRepository(cfg.DB)
 	auditRepo := repository.NewAuditLogRepository(cfg.DB)
 	roleRepo := repository.NewRoleRepository(cfg.DB)
@@ -210,7 +265,6 @@ func NewServices(cfg *config.Config) *Services {
 	selfServiceRepo := repository.NewSelfServiceRepository(cfg.DB)
 	facultyToolsRepo := repository.NewFacultyToolsRepository(cfg.DB)
 
-	answerOptionRepo := repository.NewAnswerOptionRepository(cfg.DB)
 	questionService := quiz.NewSimpleQuestionService(questionRepo, answerOptionRepo)
 	// Auto-grading service for quiz attempts
 	autoGradingService := quiz.NewAutoGradingService(
@@ -226,6 +280,7 @@ func NewServices(cfg *config.Config) *Services {
 		questionRepo,
 		answerOptionRepo,
 		autoGradingService,
+		notificationService,
 	)
 	var minioNative *minio.Client
 	if minioClient != nil {
@@ -236,15 +291,19 @@ func NewServices(cfg *config.Config) *Services {
 		storageBucket,
 		storageEndpoint,
 		storageUseSSL,
+		storageURLExpiry,
+		storageMaxURLExpiry,
 	)
 	fileService := file.NewFileService(fileRepo, storageService)
-	websocketService := notification.NewWebSocketService(notificationRepo, cfg.AppConfig.CORSOrigins)
-	notificationService := notification.NewNotificationService(notificationRepo, websocketService)
-	analyticsService := analytics.NewAnalyticsService(studentRepo, attendanceRepo, gradeRepo, courseRepo, assignmentRepo, cfg.DB)
+	snapshotRetentionDays := 0
+	if cfg.DashboardSnapshotConfig != nil {
+		snapshotRetentionDays = cfg.DashboardSnapshotConfig.RetentionDays
+	}
+	analyticsService := analytics.NewAnalyticsService(studentRepo, attendanceRepo, gradeRepo, courseRepo, assignmentRepo, cfg.DB, snapshotRetentionDays)
 	advancedAnalyticsService := analytics.NewAdvancedAnalyticsService(cfg.DB, analyticsService)
-	batchService := batch.NewBatchService(studentRepo, enrollmentRepo, gradeRepo)
+	batchService := batch.NewBatchService(studentRepo, enrollmentRepo, gradeRepo, kratosService)
 	reportService := report.NewReportService(studentRepo, gradeRepo, attendanceRepo, enrollmentRepo, courseRepo)
-	webhookService := webhook.NewWebhookService(webhookRepo)
+	webhookService := webhook.NewWebhookService(webhookRepo, loggers.For(logger.SubsystemWebhook))
 	auditService := audit.NewAuditService(auditRepo)
 	var emailService email.EmailService
 	if cfg.EmailConfig != nil {
@@ -259,10 +318,29 @@ func NewServices(cfg *config.Config) *Services {
 		// Email not configured: create service with empty config so SendEmail returns clear error
 		emailService = email.NewEmailService("", "", "", "", "")
 	}
+	emailDeliveryService := email.NewDeliveryService(emailService, repository.NewEmailDeadLetterRepository(cfg.DB), loggers.For(logger.SubsystemEmail))
 	roleService := role.NewRoleService(roleRepo)
 	feeService := fee.NewFeeService(feeRepo, cfg.AppConfig.RazorpayKey, cfg.AppConfig.RazorpaySecret, cfg.AppConfig.RazorpayWebhookSecret)
 	timetableService := timetable.NewTimetableService(timetableRepo, studentRepo)
-	examService := exam.NewExamService(examRepo, studentRepo, courseRepo, userRepo)
+	studentsPerInvigilator := exam.DefaultStudentsPerInvigilator
+	maxExtensionMinutes := exam.DefaultMaxExtensionMinutes
+	revaluationWindowDays := exam.DefaultRevaluationWindowDays
+	notifyParentsOfResults := true
+	if cfg.ExamConfig != nil {
+		studentsPerInvigilator = cfg.ExamConfig.StudentsPerInvigilator
+		maxExtensionMinutes = cfg.ExamConfig.MaxExtensionMinutes
+		revaluationWindowDays = cfg.ExamConfig.RevaluationWindowDays
+		notifyParentsOfResults = cfg.ExamConfig.NotifyParentsOfResults
+	}
+	examLogger := loggers.For(logger.SubsystemExam)
+	resultNotifier := exam.NewResultNotifier(emailDeliveryService, cfg.AppConfig.FrontendURL+"/exams", notifyParentsOfResults, examLogger)
+	// A nil *RedisCache must not become a non-nil cache.Cache
+	var examStatsCache cache.Cache
+	if redisCache != nil {
+		examStatsCache = redisCache
+	}
+	examService := exam.NewExamService(examRepo, studentRepo, courseRepo, userRepo, feeRepo, studentsPerInvigilator, maxExtensionMinutes, revaluationWindowDays, resultNotifier, webhookService, notificationService, examStatsCache, examLogger)
+	questionPaperService := exam.NewQuestionPaperService(examRepo, storageService, examLogger)
 	placementService := placement.NewPlacementService(placementRepo, studentRepo)
 	forumService := forum.NewForumService(forumRepo)
 	selfServiceService := selfservice.NewSelfServiceService(selfServiceRepo)
@@ -271,12 +349,26 @@ func NewServices(cfg *config.Config) *Services {
 	settingsRepo := repository.NewSettingsRepository(cfg.DB)
 	settingsService := settings.NewSettingsService(settingsRepo)
 
+	searchService := search.NewSearchService(repository.NewSearchRepository(cfg.DB))
+
+	alertConfig := parentalert.Config{Threshold: 75, Window: 30 * 24 * time.Hour, MinSessions: 5, Cooldown: 7 * 24 * time.Hour}
+	if cfg.AlertConfig != nil {
+		alertConfig = parentalert.Config{
+			Threshold:   cfg.AlertConfig.Threshold,
+			Window:      time.Duration(cfg.AlertConfig.WindowDays) * 24 * time.Hour,
+			MinSessions: cfg.AlertConfig.MinSessions,
+			Cooldown:    cfg.AlertConfig.Cooldown,
+		}
+	}
+	parentAlertService := parentalert.NewParentAlertService(repository.NewParentAlertRepository(cfg.DB), emailDeliveryService, alertConfig, loggers.For(logger.SubsystemParentAlerts))
+
 	return &Services{
 		Auth:                     authService,
 		Attendance:               attendanceService,
 		StudentService:           studentService,
 		CollegeService:           collegeService,
 		CourseService:            courseService,
+		SectionService:           sectionService,
 		CourseMaterialService:    courseMaterialService,
 		EnrollmentService:        enrollmentService,
 		GradeService:             gradeService,
@@ -301,15 +393,21 @@ func NewServices(cfg *config.Config) *Services {
 		WebhookService:           webhookService,
 		AuditService:             auditService,
 		EmailService:             emailService,
+		EmailDeliveryService:     emailDeliveryService,
 		RoleService:              roleService,
 		FeeService:               feeService,
 		TimetableService:         timetableService,
 		ExamService:              examService,
+		QuestionPaperService:     questionPaperService,
 		PlacementService:         placementService,
 		ForumService:             forumService,
 		SelfServiceService:       selfServiceService,
 		FacultyToolsService:      facultyToolsService,
 		SettingsService:          settingsService,
+		SearchService:            searchService,
+		ParentAlertService:       parentAlertService,
 		DB:                       cfg.DB,
+		RedisCache:               redisCache,
+		Locker:                   cache.NewLocker(redisCache),
 	}
 }
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidObjectKey   = errors.New("invalid object key")
+	ErrObjectAccessDenied = errors.New("access to object denied")
+)
+
+// ObjectAccess describes the caller requesting an object
+type ObjectAccess struct {
+	CollegeID int
+	UserID    int
+	// StudentID is set for students so they can read their own assignment submissions
+	StudentID int
+	// Privileged callers (admins and faculty) may read any object in their college
+	Privileged bool
+}
+
+// AuthorizeObjectKey checks that access may read objectKey. Every key starts
+// with the owning college ID; the rest of the layout depends on who wrote it:
+//
+//	<college>/profiles/<user>/<file>                      profile pictures
+//	<college>/<course>/<assignment>/<student>/<file>      assignment submissions
+//	<college>/<user>/<category>/<file>                    general uploads
+func AuthorizeObjectKey(objectKey string, access ObjectAccess) error {
+	if objectKey == "" || strings.HasPrefix(objectKey, "/") || strings.Contains(objectKey, "..") || strings.Contains(objectKey, "//") {
+		return ErrInvalidObjectKey
+	}
+
+	segments := strings.Split(objectKey, "/")
+	if len(segments) < 3 {
+		return ErrInvalidObjectKey
+	}
+	if access.CollegeID <= 0 || segments[0] != strconv.Itoa(access.CollegeID) {
+		return ErrObjectAccessDenied
+	}
+	if access.Privileged {
+		return nil
+	}
+
+	switch {
+	case segments[1] == "profiles":
+		if isOwner(segments[2], access.UserID) {
+			return nil
+		}
+	case len(segments) == 5 && isNumeric(segments[1]) && isNumeric(segments[2]) && isNumeric(segments[3]):
+		if isOwner(segments[3], access.StudentID) {
+			return nil
+		}
+	default:
+		if isOwner(segments[1], access.UserID) {
+			return nil
+		}
+	}
+	return ErrObjectAccessDenied
+}
+
+func isOwner(segment string, id int) bool {
+	return id > 0 && segment == strconv.Itoa(id)
+}
+
+func isNumeric(segment string) bool {
+	_, err := strconv.Atoi(segment)
+	return err == nil
+}
@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizeObjectKey(t *testing.T) {
+	student := ObjectAccess{CollegeID: 1, UserID: 10, StudentID: 20}
+	faculty := ObjectAccess{CollegeID: 1, UserID: 11, Privileged: true}
+
+	tests := []struct {
+		name   string
+		key    string
+		access ObjectAccess
+		want   error
+	}{
+		{"own upload", "1/10/document/abc_notes.pdf", student, nil},
+		{"own profile picture", "1/profiles/10/abc.png", student, nil},
+		{"own submission", "1/2/3/20/1700000000_essay.pdf", student, nil},
+		{"another user's upload", "1/12/document/abc_notes.pdf", student, ErrObjectAccessDenied},
+		{"another student's submission", "1/2/3/21/1700000000_essay.pdf", student, ErrObjectAccessDenied},
+		{"faculty reads any key in their college", "1/2/3/21/1700000000_essay.pdf", faculty, nil},
+		{"other college is rejected even for faculty", "2/10/document/abc.pdf", faculty, ErrObjectAccessDenied},
+		{"college prefix must match exactly", "10/10/document/abc.pdf", student, ErrObjectAccessDenied},
+		{"path traversal", "1/10/../../2/10/document/abc.pdf", student, ErrInvalidObjectKey},
+		{"leading slash", "/1/10/document/abc.pdf", student, ErrInvalidObjectKey},
+		{"too short", "1/abc.pdf", faculty, ErrInvalidObjectKey},
+		{"empty", "", student, ErrInvalidObjectKey},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, AuthorizeObjectKey(tc.key, tc.access))
+		})
+	}
+}
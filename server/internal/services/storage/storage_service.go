@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"time"
@@ -14,21 +15,56 @@ type StorageService interface {
 	DeleteFile(ctx context.Context, objectKey string) error
 	GetFileURL(ctx context.Context, objectKey string) (string, error)
 	ListFiles(ctx context.Context, prefix string) ([]string, error)
+	// GetPresignedURL returns a time-limited download URL after checking that
+	// the caller described by access may read objectKey. A zero expiry uses
+	// the configured default.
+	GetPresignedURL(ctx context.Context, objectKey string, access ObjectAccess, expiry time.Duration) (*PresignedURL, error)
 }
 
+// ErrInvalidURLExpiry is returned by GetPresignedURL for a negative expiry or
+// one longer than the configured maximum
+var ErrInvalidURLExpiry = errors.New("invalid presigned URL expiry")
+
+// PresignedURL is a time-limited download link for a stored object
+type PresignedURL struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+	ExpiresIn int64     `json:"expires_in"`
+}
+
+const (
+	defaultURLExpiry = time.Hour
+	// defaultMaxURLExpiry is the longest expiry S3 accepts for a presigned URL
+	defaultMaxURLExpiry = 7 * 24 * time.Hour
+)
+
 type storageService struct {
-	minioClient *minio.Client
-	bucketName  string
-	endpoint    string
-	useSSL      bool
+	minioClient  *minio.Client
+	bucketName   string
+	endpoint     string
+	useSSL       bool
+	urlExpiry    time.Duration
+	maxURLExpiry time.Duration
 }
 
-func NewStorageService(minioClient *minio.Client, bucketName, endpoint string, useSSL bool) StorageService {
+// NewStorageService creates a storage service. urlExpiry controls how long
+// presigned URLs stay valid unless a request asks otherwise; zero means one
+// hour. maxURLExpiry is the longest expiry a request may ask for; zero means
+// seven days.
+func NewStorageService(minioClient *minio.Client, bucketName, endpoint string, useSSL bool, urlExpiry, maxURLExpiry time.Duration) StorageService {
+	if urlExpiry <= 0 {
+		urlExpiry = defaultURLExpiry
+	}
+	if maxURLExpiry <= 0 {
+		maxURLExpiry = defaultMaxURLExpiry
+	}
 	return &storageService{
-		minioClient: minioClient,
-		bucketName:  bucketName,
-		endpoint:    endpoint,
-		useSSL:      useSSL,
+		minioClient:  minioClient,
+		bucketName:   bucketName,
+		endpoint:     endpoint,
+		useSSL:       useSSL,
+		urlExpiry:    urlExpiry,
+		maxURLExpiry: maxURLExpiry,
 	}
 }
 
@@ -82,12 +118,51 @@ func (s *storageService) DeleteFile(ctx context.Context, objectKey string) error
 }
 
 func (s *storageService) GetFileURL(ctx context.Context, objectKey string) (string, error) {
+	return s.presign(ctx, objectKey, s.urlExpiry)
+}
+
+func (s *storageService) GetPresignedURL(ctx context.Context, objectKey string, access ObjectAccess, expiry time.Duration) (*PresignedURL, error) {
+	if err := AuthorizeObjectKey(objectKey, access); err != nil {
+		return nil, err
+	}
+	expiry, err := s.resolveURLExpiry(expiry)
+	if err != nil {
+		return nil, err
+	}
+
+	// Authorization is checked first so callers can't probe other tenants' keys
+	url, err := s.presign(ctx, objectKey, expiry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PresignedURL{
+		URL:       url,
+		ExpiresAt: time.Now().Add(expiry),
+		ExpiresIn: int64(expiry / time.Second),
+	}, nil
+}
+
+// resolveURLExpiry returns the default expiry for zero and rejects negative
+// values and ones above the configured maximum
+func (s *storageService) resolveURLExpiry(expiry time.Duration) (time.Duration, error) {
+	switch {
+	case expiry == 0:
+		return s.urlExpiry, nil
+	case expiry < 0:
+		return 0, fmt.Errorf("%w: must be positive", ErrInvalidURLExpiry)
+	case expiry > s.maxURLExpiry:
+		return 0, fmt.Errorf("%w: must not exceed %d seconds", ErrInvalidURLExpiry, int64(s.maxURLExpiry/time.Second))
+	}
+	return expiry, nil
+}
+
+func (s *storageService) presign(ctx context.Context, objectKey string, expiry time.Duration) (string, error) {
 	if s.minioClient == nil {
 		return "", fmt.Errorf("storage service not configured")
 	}
 
-	// Generate presigned URL valid for 1 hour
-	url, err := s.minioClient.PresignedGetObject(ctx, s.bucketName, objectKey, time.Hour, nil)
+	url, err := s.minioClient.PresignedGetObject(ctx, s.bucketName, objectKey, expiry, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate URL: %w", err)
 	}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPresignTestService presigns locally: with the region set, minio does not
+// contact the server to look up the bucket location
+func newPresignTestService(t *testing.T, urlExpiry, maxURLExpiry time.Duration) StorageService {
+	client, err := minio.New("localhost:9000", &minio.Options{
+		Creds:  credentials.NewStaticV4("access", "secret", ""),
+		Region: "us-east-1",
+	})
+	require.NoError(t, err)
+	return NewStorageService(client, "eduhub", "localhost:9000", false, urlExpiry, maxURLExpiry)
+}
+
+func TestGetPresignedURL_Expiry(t *testing.T) {
+	ctx := context.Background()
+	key := "1/10/document/abc_notes.pdf"
+	access := ObjectAccess{CollegeID: 1, UserID: 10}
+	svc := newPresignTestService(t, time.Hour, 24*time.Hour)
+
+	signedExpiry := func(t *testing.T, raw string) string {
+		parsed, err := url.Parse(raw)
+		require.NoError(t, err)
+		return parsed.Query().Get("X-Amz-Expires")
+	}
+
+	t.Run("default", func(t *testing.T) {
+		presigned, err := svc.GetPresignedURL(ctx, key, access, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3600), presigned.ExpiresIn)
+		assert.Equal(t, "3600", signedExpiry(t, presigned.URL))
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		presigned, err := svc.GetPresignedURL(ctx, key, access, 12*time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, int64(43200), presigned.ExpiresIn)
+		assert.Equal(t, "43200", signedExpiry(t, presigned.URL))
+		assert.WithinDuration(t, time.Now().Add(12*time.Hour), presigned.ExpiresAt, time.Minute)
+	})
+
+	t.Run("at the maximum", func(t *testing.T) {
+		presigned, err := svc.GetPresignedURL(ctx, key, access, 24*time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, int64(86400), presigned.ExpiresIn)
+	})
+
+	t.Run("over the maximum", func(t *testing.T) {
+		_, err := svc.GetPresignedURL(ctx, key, access, 25*time.Hour)
+		assert.ErrorIs(t, err, ErrInvalidURLExpiry)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		_, err := svc.GetPresignedURL(ctx, key, access, -time.Minute)
+		assert.ErrorIs(t, err, ErrInvalidURLExpiry)
+	})
+
+	t.Run("access is checked before expiry", func(t *testing.T) {
+		_, err := svc.GetPresignedURL(ctx, "1/12/document/abc_notes.pdf", access, 25*time.Hour)
+		assert.ErrorIs(t, err, ErrObjectAccessDenied)
+	})
+}
+
+func TestNewStorageService_DefaultMaxExpiry(t *testing.T) {
+	svc := newPresignTestService(t, 0, 0)
+
+	presigned, err := svc.GetPresignedURL(context.Background(), "1/10/document/abc.pdf", ObjectAccess{CollegeID: 1, UserID: 10}, 7*24*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7*24*3600), presigned.ExpiresIn)
+
+	_, err = svc.GetPresignedURL(context.Background(), "1/10/document/abc.pdf", ObjectAccess{CollegeID: 1, UserID: 10}, 7*24*time.Hour+time.Second)
+	assert.ErrorIs(t, err, ErrInvalidURLExpiry)
+}
@@ -2,6 +2,7 @@ package student
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -27,8 +28,18 @@ type StudentService interface {
 	CreateStudent(ctx context.Context, student *models.Student) error
 	DeleteStudent(ctx context.Context, collegeID int, studentID int) error
 	FreezeStudent(ctx context.Context, collegeID int, studentID int) error
+	DeactivateStudent(ctx context.Context, collegeID int, studentID int, changedBy int, reason string) (*models.StudentStatusChange, error)
+	ReactivateStudent(ctx context.Context, collegeID int, studentID int, changedBy int, reason string) (*models.StudentStatusChange, error)
+	GetStudentStatusHistory(ctx context.Context, collegeID int, studentID int) ([]*models.StudentStatusChange, error)
 }
 
+const maxStatusReasonLength = 500
+
+var (
+	ErrStatusReasonRequired = errors.New("a reason is required to deactivate a student")
+	ErrStatusReasonTooLong  = fmt.Errorf("reason must be at most %d characters", maxStatusReasonLength)
+)
+
 type studentService struct {
 	studentRepo    repository.StudentRepository
 	attendanceRepo repository.AttendanceRepository
@@ -126,3 +137,48 @@ func (s *studentService) DeleteStudent(ctx context.Context, collegeID int, stude
 func (s *studentService) FreezeStudent(ctx context.Context, collegeID int, studentID int) error {
 	return s.attendanceRepo.FreezeAttendance(ctx, collegeID, studentID)
 }
+
+// DeactivateStudent marks the student inactive and records who did it and why.
+// Inactive students are rejected by LoadStudentProfile, so this locks them out
+// without deleting any of their records.
+func (s *studentService) DeactivateStudent(ctx context.Context, collegeID int, studentID int, changedBy int, reason string) (*models.StudentStatusChange, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrStatusReasonRequired
+	}
+	return s.changeStatus(ctx, &models.StudentStatusChange{
+		StudentID: studentID,
+		CollegeID: collegeID,
+		Action:    models.StudentStatusDeactivate,
+		IsActive:  false,
+		Reason:    reason,
+		ChangedBy: changedBy,
+	})
+}
+
+// ReactivateStudent undoes a deactivation. The reason is optional here since
+// the deactivation entry already explains why the student was locked out.
+func (s *studentService) ReactivateStudent(ctx context.Context, collegeID int, studentID int, changedBy int, reason string) (*models.StudentStatusChange, error) {
+	return s.changeStatus(ctx, &models.StudentStatusChange{
+		StudentID: studentID,
+		CollegeID: collegeID,
+		Action:    models.StudentStatusReactivate,
+		IsActive:  true,
+		Reason:    strings.TrimSpace(reason),
+		ChangedBy: changedBy,
+	})
+}
+
+func (s *studentService) changeStatus(ctx context.Context, change *models.StudentStatusChange) (*models.StudentStatusChange, error) {
+	if len(change.Reason) > maxStatusReasonLength {
+		return nil, ErrStatusReasonTooLong
+	}
+	if err := s.studentRepo.ChangeStudentStatus(ctx, change); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+func (s *studentService) GetStudentStatusHistory(ctx context.Context, collegeID int, studentID int) ([]*models.StudentStatusChange, error) {
+	return s.studentRepo.ListStudentStatusHistory(ctx, collegeID, studentID)
+}
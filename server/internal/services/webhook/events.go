@@ -0,0 +1,37 @@
+package webhook
+
+import (
+	"context"
+	"time"
+)
+
+// Domain events other services publish to webhooks
+const (
+	EventResultPublished       = "result.published"
+	EventExamEnrollmentCreated = "exam.enrollment.created"
+	EventExamCancelled         = "exam.cancelled"
+)
+
+// Event is the JSON body POSTed to a webhook. The X-Webhook-Signature header
+// carries the hex HMAC-SHA256 of the body keyed with the webhook's secret.
+type Event struct {
+	Event     string    `json:"event"`
+	CollegeID int       `json:"college_id"`
+	Timestamp time.Time `json:"timestamp"`
+	Data      any       `json:"data"`
+}
+
+// Emitter publishes domain events. Emitting never blocks or fails the caller;
+// lookup and delivery problems are logged.
+type Emitter interface {
+	Emit(ctx context.Context, collegeID int, event string, data any)
+}
+
+func (s *webhookService) Emit(ctx context.Context, collegeID int, event string, data any) {
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := s.TriggerEvent(ctx, collegeID, event, data); err != nil {
+			s.logger.Error().Err(err).Int("college_id", collegeID).Str("event", event).Msg("failed to emit webhook event")
+		}
+	}()
+}
@@ -8,11 +8,23 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
 	"eduhub/server/internal/models"
 	"eduhub/server/internal/repository"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// defaultMaxAttempts is how many times a delivery is tried before giving up
+	defaultMaxAttempts = 4
+	// defaultRetryBackoff is the wait before the first retry; it doubles after each attempt
+	defaultRetryBackoff = 2 * time.Second
+	// maxStoredResponseBody caps how much of a receiver's response is kept
+	maxStoredResponseBody = 4096
 )
 
 type WebhookService interface {
@@ -23,19 +35,26 @@ type WebhookService interface {
 	DeleteWebhook(ctx context.Context, collegeID, webhookID int) error
 	TriggerEvent(ctx context.Context, collegeID int, event string, payload any) error
 	TestWebhook(ctx context.Context, collegeID, webhookID int) error
+	Emitter
 }
 
 type webhookService struct {
-	webhookRepo repository.WebhookRepository
-	httpClient  *http.Client
+	webhookRepo  repository.WebhookRepository
+	httpClient   *http.Client
+	maxAttempts  int
+	retryBackoff time.Duration
+	logger       zerolog.Logger
 }
 
-func NewWebhookService(webhookRepo repository.WebhookRepository) WebhookService {
+func NewWebhookService(webhookRepo repository.WebhookRepository, logger zerolog.Logger) WebhookService {
 	return &webhookService{
 		webhookRepo: webhookRepo,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		maxAttempts:  defaultMaxAttempts,
+		retryBackoff: defaultRetryBackoff,
+		logger:       logger,
 	}
 }
 
@@ -107,22 +126,37 @@ func (s *webhookService) DeleteWebhook(ctx context.Context, collegeID, webhookID
 	return s.webhookRepo.DeleteWebhook(ctx, collegeID, webhookID)
 }
 
+// TriggerEvent wraps the payload in an Event and delivers it to every active
+// webhook of the college subscribed to the event. Deliveries run in the
+// background with retries, so only the subscription lookup can fail here.
 func (s *webhookService) TriggerEvent(ctx context.Context, collegeID int, event string, payload any) error {
 	// Get all active webhooks for this event
 	webhooks, err := s.webhookRepo.GetWebhooksByEvent(ctx, collegeID, event)
 	if err != nil {
 		return err
 	}
+	if len(webhooks) == 0 {
+		return nil
+	}
 
-	// Send webhook to each endpoint
+	body, err := json.Marshal(Event{
+		Event:     event,
+		CollegeID: collegeID,
+		Timestamp: time.Now().UTC(),
+		Data:      payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", event, err)
+	}
+
+	// Deliveries outlive the request that triggered them
+	deliveryCtx := context.WithoutCancel(ctx)
 	for _, webhook := range webhooks {
 		if !webhook.Active {
 			continue
 		}
 
-		go func(webhook *models.Webhook) {
-			_ = s.sendWebhook(webhook, payload)
-		}(webhook)
+		go s.deliver(deliveryCtx, webhook, event, body)
 	}
 
 	return nil
@@ -152,15 +186,83 @@ func (s *webhookService) sendWebhook(webhook *models.Webhook, payload any) error
 		return err
 	}
 
-	// Create request
-	req, err := http.NewRequest("POST", webhook.URL, bytes.NewBuffer(data))
+	status, _, err := s.post(context.Background(), webhook, "test", data)
 	if err != nil {
 		return err
 	}
+	if status >= 400 {
+		return fmt.Errorf("webhook request failed with status %d", status)
+	}
+
+	return nil
+}
+
+// deliver posts an encoded event to one webhook, retrying network errors,
+// 5xx and 429 responses with exponential backoff. Every attempt is stored in
+// webhook_deliveries and the final one on the webhook itself.
+func (s *webhookService) deliver(ctx context.Context, webhook *models.Webhook, event string, body []byte) {
+	backoff := s.retryBackoff
+	for attempt := 1; ; attempt++ {
+		status, respBody, err := s.post(ctx, webhook, event, body)
+
+		delivery := &models.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventType:     event,
+			Payload:       body,
+			AttemptNumber: attempt,
+		}
+		if status != 0 {
+			delivery.ResponseStatus = &status
+			delivery.ResponseBody = &respBody
+		}
+		switch {
+		case err != nil:
+			msg := err.Error()
+			delivery.ErrorMessage = &msg
+		case status >= 300:
+			msg := fmt.Sprintf("webhook responded with status %d", status)
+			delivery.ErrorMessage = &msg
+		default:
+			now := time.Now()
+			delivery.DeliveredAt = &now
+		}
+		if recErr := s.webhookRepo.CreateDelivery(ctx, delivery); recErr != nil {
+			s.logger.Error().Err(recErr).Int("webhook_id", webhook.ID).Msg("failed to record webhook delivery")
+		}
+
+		retryable := err != nil || status >= 500 || status == http.StatusTooManyRequests
+		if delivery.DeliveredAt != nil || !retryable || attempt >= s.maxAttempts {
+			if recErr := s.webhookRepo.RecordWebhookOutcome(ctx, webhook.ID, delivery); recErr != nil {
+				s.logger.Error().Err(recErr).Int("webhook_id", webhook.ID).Msg("failed to record webhook outcome")
+			}
+			if delivery.DeliveredAt == nil {
+				s.logger.Warn().Int("webhook_id", webhook.ID).Str("event", event).Int("attempts", attempt).
+					Msg("webhook delivery failed")
+			}
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post makes a single signed POST and returns the response status and a
+// truncated response body
+func (s *webhookService) post(ctx context.Context, webhook *models.Webhook, event string, data []byte) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(data))
+	if err != nil {
+		return 0, "", err
+	}
 
 	// Add headers
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "EduHub-Webhook/1.0")
+	req.Header.Set("X-Webhook-Event", event)
 
 	// Add signature if secret is provided
 	if webhook.Secret != "" {
@@ -171,15 +273,12 @@ func (s *webhookService) sendWebhook(webhook *models.Webhook, payload any) error
 	// Send request
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return err
+		return 0, "", err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode >= 400 {
-		return fmt.Errorf("webhook request failed with status %d", resp.StatusCode)
-	}
-
-	return nil
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxStoredResponseBody))
+	return resp.StatusCode, string(respBody), nil
 }
 
 func (s *webhookService) generateSignature(data []byte, secret string) string {
@@ -0,0 +1,122 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"eduhub/server/internal/models"
+	"eduhub/server/internal/repository"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingRepo struct {
+	repository.WebhookRepository
+	mu         sync.Mutex
+	deliveries []*models.WebhookDelivery
+	outcome    *models.WebhookDelivery
+}
+
+func (r *recordingRepo) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries = append(r.deliveries, delivery)
+	return nil
+}
+
+func (r *recordingRepo) RecordWebhookOutcome(ctx context.Context, webhookID int, delivery *models.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.outcome = delivery
+	return nil
+}
+
+func newTestService(repo *recordingRepo) *webhookService {
+	return &webhookService{
+		webhookRepo:  repo,
+		httpClient:   &http.Client{Timeout: time.Second},
+		maxAttempts:  defaultMaxAttempts,
+		retryBackoff: time.Millisecond,
+		logger:       zerolog.Nop(),
+	}
+}
+
+func TestDeliverRetriesUntilSuccess(t *testing.T) {
+	var calls int
+	var signature, event string
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		signature = r.Header.Get("X-Webhook-Signature")
+		event = r.Header.Get("X-Webhook-Event")
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	repo := &recordingRepo{}
+	svc := newTestService(repo)
+	body := []byte(`{"event":"result.published"}`)
+
+	svc.deliver(context.Background(), &models.Webhook{ID: 4, URL: server.URL, Secret: "s3cret"}, EventResultPublished, body)
+
+	assert.Equal(t, 3, calls)
+	require.Len(t, repo.deliveries, 3)
+	assert.Equal(t, 3, repo.deliveries[2].AttemptNumber)
+	assert.NotNil(t, repo.deliveries[0].ErrorMessage)
+	require.NotNil(t, repo.outcome)
+	assert.NotNil(t, repo.outcome.DeliveredAt)
+
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), signature)
+	assert.Equal(t, EventResultPublished, event)
+	assert.Equal(t, body, received)
+}
+
+func TestDeliverDoesNotRetryClientErrors(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer server.Close()
+
+	repo := &recordingRepo{}
+	newTestService(repo).deliver(context.Background(), &models.Webhook{ID: 4, URL: server.URL}, EventResultPublished, []byte(`{}`))
+
+	assert.Equal(t, 1, calls)
+	require.NotNil(t, repo.outcome)
+	assert.Nil(t, repo.outcome.DeliveredAt)
+	assert.Equal(t, http.StatusGone, *repo.outcome.ResponseStatus)
+}
+
+func TestDeliverGivesUpAfterMaxAttempts(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	repo := &recordingRepo{}
+	newTestService(repo).deliver(context.Background(), &models.Webhook{ID: 4, URL: server.URL}, EventResultPublished, []byte(`{}`))
+
+	assert.Equal(t, defaultMaxAttempts, calls)
+	assert.Len(t, repo.deliveries, defaultMaxAttempts)
+	assert.Nil(t, repo.outcome.DeliveredAt)
+}
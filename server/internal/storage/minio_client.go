@@ -110,3 +110,7 @@ func (m *MinioClient) GetFileSize(ctx context.Context, bucketName, objectName st
 	}
 	return int(info.Size), nil
 }
+
+func (m *MinioClient) Remove(ctx context.Context, bucketName, objectName string) error {
+	return m.client.RemoveObject(ctx, bucketName, objectName, minio.RemoveObjectOptions{})
+}
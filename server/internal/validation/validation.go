@@ -0,0 +1,101 @@
+// Package validation wraps go-playground/validator so struct validation
+// failures can be reported to clients field by field.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// New returns a validator that names fields by their JSON tag, so errors use
+// the names clients send
+func New() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		switch name {
+		case "-":
+			return ""
+		case "":
+			return field.Name
+		}
+		return name
+	})
+	return v
+}
+
+// Fields translates the validator errors wrapped in err into a map from field
+// path to message, e.g. "title" -> "required: title is required". Nested
+// fields use dotted paths such as "options[0].text". It returns nil when err
+// holds no validator errors.
+func Fields(err error) map[string]string {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return nil
+	}
+	fields := make(map[string]string, len(validationErrs))
+	for _, fe := range validationErrs {
+		path := fieldPath(fe)
+		if _, seen := fields[path]; !seen {
+			fields[path] = Message(fe)
+		}
+	}
+	return fields
+}
+
+// Message describes one failed rule, prefixed with the rule's tag
+func Message(fe validator.FieldError) string {
+	return fmt.Sprintf("%s: %s %s", fe.Tag(), fe.Field(), describe(fe))
+}
+
+// fieldPath drops the top-level struct name from the error's namespace
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		return ns[i+1:]
+	}
+	return fe.Field()
+}
+
+func describe(fe validator.FieldError) string {
+	param := fe.Param()
+	switch fe.Tag() {
+	case "required", "required_if", "required_unless", "required_with", "required_without":
+		return "is required"
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(param), ", ")
+	case "email":
+		return "must be a valid email address"
+	case "url", "uri":
+		return "must be a valid URL"
+	case "len":
+		return "must be exactly " + param + unit(fe)
+	case "min", "gte":
+		return "must be at least " + param + unit(fe)
+	case "max", "lte":
+		return "must be at most " + param + unit(fe)
+	case "gt":
+		return "must be greater than " + param + unit(fe)
+	case "lt":
+		return "must be less than " + param + unit(fe)
+	}
+	if param != "" {
+		return fmt.Sprintf("must satisfy %s=%s", fe.Tag(), param)
+	}
+	return "is invalid"
+}
+
+// unit is what a size rule counts for the field's kind
+func unit(fe validator.FieldError) string {
+	switch fe.Kind() {
+	case reflect.String:
+		return " characters long"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	}
+	return ""
+}
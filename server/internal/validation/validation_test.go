@@ -0,0 +1,41 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type option struct {
+	Text string `json:"text" validate:"required"`
+}
+
+type quiz struct {
+	Title    string   `json:"title" validate:"required,max=5"`
+	Type     string   `json:"type" validate:"oneof=mcq essay"`
+	Marks    int      `json:"marks" validate:"gte=1"`
+	Email    string   `json:"email,omitempty" validate:"omitempty,email"`
+	Options  []option `json:"options" validate:"min=1,dive"`
+	Internal string   `json:"-" validate:"required"`
+}
+
+func TestFields(t *testing.T) {
+	err := New().Struct(quiz{Title: "Too long", Type: "poll", Email: "nope", Options: []option{{}}})
+	fields := Fields(fmt.Errorf("validation failed for quiz: %w", err))
+
+	assert.Equal(t, map[string]string{
+		"title":           "max: title must be at most 5 characters long",
+		"type":            "oneof: type must be one of: mcq, essay",
+		"marks":           "gte: marks must be at least 1",
+		"email":           "email: email must be a valid email address",
+		"options[0].text": "required: text is required",
+		"Internal":        "required: Internal is required",
+	}, fields)
+}
+
+func TestFields_NotValidationError(t *testing.T) {
+	assert.Nil(t, Fields(errors.New("db down")))
+	assert.Nil(t, Fields(nil))
+}
@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Subsystems whose log level can be overridden with LOG_LEVEL_<NAME>
+const (
+	SubsystemApp          = "app"
+	SubsystemEmail        = "email"
+	SubsystemExam         = "exam"
+	SubsystemNotification = "notification"
+	SubsystemParentAlerts = "parent_alerts"
+	SubsystemWebhook      = "webhook"
+)
+
+var subsystemNames = map[string]bool{
+	SubsystemApp:          true,
+	SubsystemEmail:        true,
+	SubsystemExam:         true,
+	SubsystemNotification: true,
+	SubsystemParentAlerts: true,
+	SubsystemWebhook:      true,
+}
+
+// IsSubsystem reports whether name is a subsystem with its own log level
+func IsSubsystem(name string) bool {
+	return subsystemNames[name]
+}
+
+// Subsystems hands out per-subsystem child loggers. Each logs at its override
+// level when one is configured and at the global level otherwise.
+type Subsystems struct {
+	base      zerolog.Logger
+	global    zerolog.Level
+	overrides map[string]zerolog.Level
+}
+
+// NewSubsystems parses the global level and the per-subsystem overrides
+// (keyed by subsystem name), e.g. ("info", {"exam": "debug"}).
+func NewSubsystems(global string, overrides map[string]string) (*Subsystems, error) {
+	globalLevel, err := zerolog.ParseLevel(global)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level %q: %w", global, err)
+	}
+
+	s := &Subsystems{
+		base: zerolog.New(zerolog.ConsoleWriter{
+			Out:        os.Stdout,
+			TimeFormat: time.RFC3339,
+		}).With().Timestamp().Logger(),
+		global:    globalLevel,
+		overrides: make(map[string]zerolog.Level, len(overrides)),
+	}
+	for name, raw := range overrides {
+		if !IsSubsystem(name) {
+			return nil, fmt.Errorf("unknown log subsystem %q", name)
+		}
+		level, err := zerolog.ParseLevel(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level %q for %s: %w", raw, name, err)
+		}
+		s.overrides[name] = level
+	}
+	return s, nil
+}
+
+// Level returns the level a subsystem logs at
+func (s *Subsystems) Level(name string) zerolog.Level {
+	if level, ok := s.overrides[name]; ok {
+		return level
+	}
+	return s.global
+}
+
+// For returns the logger of a subsystem, tagged with its name
+func (s *Subsystems) For(name string) zerolog.Logger {
+	return s.base.With().Str("component", name).Logger().Level(s.Level(name))
+}
+
+// Apply makes the configured levels take effect process-wide. zerolog drops
+// events below its global level before any logger's own level is consulted,
+// so the global level is lowered to the most verbose subsystem; the shared
+// log.Logger used by code without a subsystem keeps the global level.
+func (s *Subsystems) Apply() {
+	lowest := s.global
+	for _, level := range s.overrides {
+		lowest = min(lowest, level)
+	}
+	zerolog.SetGlobalLevel(lowest)
+	log.Logger = log.Logger.Level(s.global)
+}
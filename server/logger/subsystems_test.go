@@ -0,0 +1,30 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubsystems_Level(t *testing.T) {
+	loggers, err := NewSubsystems("info", map[string]string{SubsystemExam: "debug"})
+	require.NoError(t, err)
+
+	assert.Equal(t, zerolog.DebugLevel, loggers.Level(SubsystemExam))
+	assert.Equal(t, zerolog.InfoLevel, loggers.Level(SubsystemWebhook))
+	assert.Equal(t, zerolog.DebugLevel, loggers.For(SubsystemExam).GetLevel())
+	assert.Equal(t, zerolog.InfoLevel, loggers.For(SubsystemWebhook).GetLevel())
+}
+
+func TestNewSubsystems_Invalid(t *testing.T) {
+	_, err := NewSubsystems("loud", nil)
+	assert.Error(t, err)
+
+	_, err = NewSubsystems("info", map[string]string{SubsystemExam: "loud"})
+	assert.Error(t, err)
+
+	_, err = NewSubsystems("info", map[string]string{"grading": "debug"})
+	assert.Error(t, err)
+}
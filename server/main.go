@@ -4,11 +4,11 @@ package main
 import (
 	"context"
 	"eduhub/server/api/app"
+	"eduhub/server/internal/buildinfo"
 	"eduhub/server/logger"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -32,9 +32,22 @@ import (
 // @in header
 // @name Authorization
 
+// Build details, injected at build time with
+//
+//	go build -ldflags "-X main.version=v1.2.0 -X main.commit=$(git rev-parse --short HEAD) -X main.buildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./server
+//
+// Local builds report "dev".
+var (
+	version   = "dev"
+	commit    = "dev"
+	buildDate = "dev"
+)
+
 func main() {
+	buildinfo.Set(version, commit, buildDate)
 	log := logger.NewZeroLogger(true)
 	loadEnvFiles(log)
+	log.Logger.Info().Str("version", version).Str("commit", commit).Str("build_date", buildDate).Msg("starting eduhub server")
 
 	// Create the app instance (which loads config, logger, db, etc.)
 	setup, err := app.New()
@@ -66,7 +79,7 @@ func main() {
 	}
 
 	// Shutdown the application
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), setup.ShutdownTimeout())
 	defer shutdownCancel()
 
 	if err := setup.Shutdown(shutdownCtx); err != nil {